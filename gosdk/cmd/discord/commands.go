@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mtreilly/godiscord/gosdk/cmd/discord/output"
+	"github.com/mtreilly/godiscord/gosdk/discord/bot"
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func commandsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "commands",
+		Aliases: []string{"command"},
+		Short:   "Manage application commands",
+	}
+	cmd.AddCommand(commandsSyncCmd())
+	return cmd
+}
+
+// Actions reported by commands sync.
+const (
+	actionCreate    = "create"
+	actionUpdate    = "update"
+	actionDelete    = "delete"
+	actionUnchanged = "unchanged"
+)
+
+// commandChange is one row of the sync summary.
+type commandChange struct {
+	Action  string   `json:"action" yaml:"action"`
+	Name    string   `json:"name" yaml:"name"`
+	Type    string   `json:"type" yaml:"type"`
+	ID      string   `json:"id,omitempty" yaml:"id,omitempty"`
+	Changes []string `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+var errNoApplicationID = errors.New("an application ID is required; pass --app-id or set discord.application_id")
+
+func commandsSyncCmd() *cobra.Command {
+	var (
+		appID   string
+		file    string
+		guildID string
+		dryRun  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Make the registered commands match a manifest",
+		Long: `Loads command definitions from a YAML or JSON manifest, compares them with
+the commands registered for the application (globally, or in --guild), and
+overwrites the registered set so they match. Commands are matched by type
+and name, so updated commands keep their IDs.
+
+The summary lists what was created, updated, deleted, or left unchanged. It
+is a table unless --output is given. --dry-run prints the summary without
+changing anything.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := getConfig(cmd)
+			if appID == "" {
+				appID = cfg.Discord.ApplicationID
+			}
+			if appID == "" {
+				return errNoApplicationID
+			}
+			desired, err := bot.LoadCommands(file)
+			if err != nil {
+				return err
+			}
+			rest, err := newRESTClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			changes, err := syncCommands(cmd.Context(), rest.ApplicationCommands(appID), guildID, desired, dryRun)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), summarizeChanges(changes, dryRun))
+			if !cmd.Flags().Changed("output") {
+				return printWith(cmd, output.TableFormatter{}, changes)
+			}
+			return printFormatted(cmd, changes)
+		},
+	}
+	cmd.Flags().StringVar(&appID, "app-id", "", "application ID (defaults to discord.application_id)")
+	cmd.Flags().StringVar(&file, "file", "", "YAML or JSON command manifest")
+	cmd.Flags().StringVar(&guildID, "guild", "", "sync the commands of this guild instead of the global ones")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the changes without applying them")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// syncCommands diffs desired against the registered commands and, unless
+// dryRun is set or nothing differs, overwrites the registered set. guildID
+// selects guild commands; empty means global.
+func syncCommands(ctx context.Context, api *client.ApplicationCommands, guildID string, desired []*types.ApplicationCommand, dryRun bool) ([]commandChange, error) {
+	var (
+		live []*types.ApplicationCommand
+		err  error
+	)
+	if guildID == "" {
+		live, err = api.GetGlobalApplicationCommands(ctx)
+	} else {
+		live, err = api.GetGuildApplicationCommands(ctx, guildID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list commands: %w", err)
+	}
+
+	changes := diffCommands(desired, live)
+	if dryRun || !hasChanges(changes) {
+		return changes, nil
+	}
+
+	var applied []*types.ApplicationCommand
+	if guildID == "" {
+		applied, err = api.BulkOverwriteGlobalApplicationCommands(ctx, desired)
+	} else {
+		applied, err = api.BulkOverwriteGuildApplicationCommands(ctx, guildID, desired)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("overwrite commands: %w", err)
+	}
+	ids := make(map[string]string, len(applied))
+	for _, cmd := range applied {
+		ids[commandKey(cmd)] = cmd.ID
+	}
+	for i := range changes {
+		if changes[i].Action == actionCreate {
+			changes[i].ID = ids[changes[i].Type+"/"+changes[i].Name]
+		}
+	}
+	return changes, nil
+}
+
+// diffCommands matches desired commands to live ones by type and name.
+// Desired commands come first, in manifest order, followed by deletions.
+func diffCommands(desired, live []*types.ApplicationCommand) []commandChange {
+	byKey := make(map[string]*types.ApplicationCommand, len(live))
+	for _, cmd := range live {
+		byKey[commandKey(cmd)] = cmd
+	}
+
+	changes := make([]commandChange, 0, len(desired)+len(live))
+	for _, want := range desired {
+		key := commandKey(want)
+		change := commandChange{Action: actionCreate, Name: want.Name, Type: commandTypeName(want.Type)}
+		if have, ok := byKey[key]; ok {
+			delete(byKey, key)
+			change.ID = have.ID
+			change.Changes = changedCommandFields(want, have)
+			change.Action = actionUnchanged
+			if len(change.Changes) > 0 {
+				change.Action = actionUpdate
+			}
+		}
+		changes = append(changes, change)
+	}
+	for _, have := range live {
+		if _, ok := byKey[commandKey(have)]; ok {
+			changes = append(changes, commandChange{Action: actionDelete, Name: have.Name, Type: commandTypeName(have.Type), ID: have.ID})
+		}
+	}
+	return changes
+}
+
+// changedCommandFields lists the fields of want that differ from have,
+// treating Discord's defaults as equal to unset values.
+func changedCommandFields(want, have *types.ApplicationCommand) []string {
+	var fields []string
+	check := func(name string, a, b any) {
+		if !sameJSON(a, b) {
+			fields = append(fields, name)
+		}
+	}
+	check("description", want.Description, have.Description)
+	check("name_localizations", emptyToNil(want.NameLocalizations), emptyToNil(have.NameLocalizations))
+	check("description_localizations", emptyToNil(want.DescriptionLocalizations), emptyToNil(have.DescriptionLocalizations))
+	check("options", optionsOrNil(want.Options), optionsOrNil(have.Options))
+	check("default_member_permissions", want.DefaultMemberPermissions, have.DefaultMemberPermissions)
+	check("dm_permission", boolOr(want.DMPermission, true), boolOr(have.DMPermission, true))
+	check("nsfw", want.NSFW, have.NSFW)
+	return fields
+}
+
+func hasChanges(changes []commandChange) bool {
+	for _, c := range changes {
+		if c.Action != actionUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeChanges(changes []commandChange, dryRun bool) string {
+	actions := make([]string, len(changes))
+	for i, c := range changes {
+		actions[i] = c.Action
+	}
+	return summarizeActions(actions, dryRun)
+}
+
+// summarizeActions counts each action for the summary printed by commands
+// sync and guild apply.
+func summarizeActions(actions []string, dryRun bool) string {
+	counts := map[string]int{}
+	for _, a := range actions {
+		counts[a]++
+	}
+	summary := fmt.Sprintf("%d to create, %d to update, %d to delete, %d unchanged",
+		counts[actionCreate], counts[actionUpdate], counts[actionDelete], counts[actionUnchanged])
+	if dryRun {
+		return summary + " (dry run)"
+	}
+	if counts[actionCreate]+counts[actionUpdate]+counts[actionDelete] == 0 {
+		return summary + "; nothing to apply"
+	}
+	return summary + "; applied"
+}
+
+// commandKey identifies a command within a scope. Discord allows the same
+// name for a slash command and a context menu command.
+func commandKey(cmd *types.ApplicationCommand) string {
+	return commandTypeName(cmd.Type) + "/" + cmd.Name
+}
+
+func commandTypeName(t types.ApplicationCommandType) string {
+	switch t {
+	case types.ApplicationCommandTypeUser:
+		return "user"
+	case types.ApplicationCommandTypeMessage:
+		return "message"
+	default:
+		// Commands without a type are slash commands.
+		return "chat_input"
+	}
+}
+
+func sameJSON(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+func emptyToNil(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+func optionsOrNil(opts []types.ApplicationCommandOption) []types.ApplicationCommandOption {
+	if len(opts) == 0 {
+		return nil
+	}
+	return opts
+}
+
+func boolOr(b *bool, fallback bool) bool {
+	if b == nil {
+		return fallback
+	}
+	return *b
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/bot"
+	"github.com/mtreilly/godiscord/gosdk/discord/discordtest"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+const commandManifest = `
+- name: ping
+  description: Check latency
+- name: echo
+  description: Repeat a message
+  options:
+    - type: 3
+      name: text
+      description: What to repeat
+      required: true
+- name: greet
+  description: Say hello
+- name: Report
+  type: 2
+`
+
+func loadManifest(t *testing.T) []*types.ApplicationCommand {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "commands.yaml")
+	if err := os.WriteFile(path, []byte(commandManifest), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	cmds, err := bot.LoadCommands(path)
+	if err != nil {
+		t.Fatalf("LoadCommands() error = %v", err)
+	}
+	return cmds
+}
+
+func TestSyncCommands(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	dm := true
+	ping := srv.AddCommand("app", types.ApplicationCommand{Name: "ping", Description: "Check latency", Type: types.ApplicationCommandTypeChatInput, DMPermission: &dm})
+	echo := srv.AddCommand("app", types.ApplicationCommand{Name: "echo", Description: "Repeat", Type: types.ApplicationCommandTypeChatInput})
+	old := srv.AddCommand("app", types.ApplicationCommand{Name: "old", Description: "Retired", Type: types.ApplicationCommandTypeChatInput})
+	api := srv.Client("token").ApplicationCommands("app")
+	desired := loadManifest(t)
+
+	changes, err := syncCommands(context.Background(), api, "", desired, true)
+	if err != nil {
+		t.Fatalf("syncCommands(dry run) error = %v", err)
+	}
+	srv.AssertNoRequest(t, "PUT", "/applications/{app}/commands")
+
+	got := map[string]commandChange{}
+	for _, c := range changes {
+		got[c.Type+"/"+c.Name] = c
+	}
+	if c := got["chat_input/ping"]; c.Action != actionUnchanged || c.ID != ping.ID {
+		t.Fatalf("ping = %+v, want unchanged", c)
+	}
+	if c := got["chat_input/echo"]; c.Action != actionUpdate || c.ID != echo.ID || strings.Join(c.Changes, ",") != "description,options" {
+		t.Fatalf("echo = %+v, want description and options update", c)
+	}
+	if c := got["chat_input/greet"]; c.Action != actionCreate || c.ID != "" {
+		t.Fatalf("greet = %+v, want create", c)
+	}
+	if c := got["user/Report"]; c.Action != actionCreate {
+		t.Fatalf("Report = %+v, want create", c)
+	}
+	if c := got["chat_input/old"]; c.Action != actionDelete || c.ID != old.ID {
+		t.Fatalf("old = %+v, want delete", c)
+	}
+	if summary := summarizeChanges(changes, true); summary != "2 to create, 1 to update, 1 to delete, 1 unchanged (dry run)" {
+		t.Fatalf("summary = %q", summary)
+	}
+
+	changes, err = syncCommands(context.Background(), api, "", desired, false)
+	if err != nil {
+		t.Fatalf("syncCommands() error = %v", err)
+	}
+	srv.AssertRequestCount(t, "PUT", "/applications/{app}/commands", 1)
+	if len(srv.Commands("app", "")) != 4 {
+		t.Fatalf("registered %d commands, want 4", len(srv.Commands("app", "")))
+	}
+	greet := srv.AssertCommand(t, "app", "", "greet")
+	for _, c := range changes {
+		if c.Name == "greet" && c.ID != greet.ID {
+			t.Fatalf("greet ID = %q, want %q", c.ID, greet.ID)
+		}
+	}
+	if srv.AssertCommand(t, "app", "", "echo").ID != echo.ID {
+		t.Fatal("updated command changed ID")
+	}
+
+	changes, err = syncCommands(context.Background(), api, "", desired, false)
+	if err != nil {
+		t.Fatalf("syncCommands(again) error = %v", err)
+	}
+	if hasChanges(changes) {
+		t.Fatalf("second sync reported changes: %+v", changes)
+	}
+	srv.AssertRequestCount(t, "PUT", "/applications/{app}/commands", 1)
+}
+
+func TestSyncGuildCommands(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	srv.AddCommand("app", types.ApplicationCommand{Name: "global", Description: "Stays", Type: types.ApplicationCommandTypeChatInput})
+	api := srv.Client("token").ApplicationCommands("app")
+
+	if _, err := syncCommands(context.Background(), api, "900", loadManifest(t), false); err != nil {
+		t.Fatalf("syncCommands() error = %v", err)
+	}
+	if n := len(srv.Commands("app", "900")); n != 4 {
+		t.Fatalf("guild has %d commands, want 4", n)
+	}
+	srv.AssertCommand(t, "app", "", "global")
+}
+
+func TestCommandsSyncRequiresAppID(t *testing.T) {
+	_, err := runCLI(t, strings.NewReader(""), "commands", "sync", "--file", "commands.yaml")
+	if err != errNoApplicationID {
+		t.Fatalf("error = %v, want %v", err, errNoApplicationID)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/mtreilly/godiscord/gosdk/cmd/discord/output"
 	"github.com/mtreilly/godiscord/gosdk/config"
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
 )
 
 type cliContextKey string
@@ -24,6 +25,7 @@ var (
 	overrideToken   string
 	overrideWebhook string
 	outputFormat    string
+	profileName     string
 )
 
 type cliRuntime struct {
@@ -40,6 +42,11 @@ func newRootCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if cmd.Annotations[annotationNoProfile] == "" {
+				if err := applyProfile(cmd, cfg); err != nil {
+					return err
+				}
+			}
 			if overrideToken != "" {
 				cfg.Discord.BotToken = overrideToken
 			}
@@ -49,7 +56,10 @@ func newRootCommand() *cobra.Command {
 				}
 				cfg.Discord.Webhooks["default"] = overrideWebhook
 			}
-			formatter := output.NewFormatter(outputFormat)
+			formatter, err := output.NewFormatter(outputFormat)
+			if err != nil {
+				return err
+			}
 			cmd.SetContext(context.WithValue(cmd.Context(), configContextKey, cfg))
 			cmd.SetContext(context.WithValue(cmd.Context(), outputContextKey, formatter))
 			if path != "" {
@@ -61,7 +71,8 @@ func newRootCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&configFile, "config", "", "path to Discord config (YAML)")
 	cmd.PersistentFlags().StringVar(&overrideToken, "token", "", "override bot token")
 	cmd.PersistentFlags().StringVar(&overrideWebhook, "webhook", "", "override default webhook URL")
-	cmd.PersistentFlags().StringVar(&outputFormat, "output", "json", "output format (json/table/yaml)")
+	cmd.PersistentFlags().StringVar(&outputFormat, "output", "json", "output format: "+output.Formats)
+	cmd.PersistentFlags().StringVar(&profileName, "profile", "", "CLI profile to use (defaults to the current profile)")
 	return cmd
 }
 
@@ -102,18 +113,32 @@ func getConfig(cmd *cobra.Command) *config.Config {
 	return config.Default()
 }
 
-func getFormatter(cmd *cobra.Command) output.Formatter {
-	if cmd == nil {
-		return output.NewFormatter(outputFormat)
+// newRESTClient returns a REST client authenticated with the configured
+// bot token.
+func newRESTClient(cmd *cobra.Command) (*client.Client, error) {
+	token, err := getConfig(cmd).ResolveToken()
+	if err != nil {
+		return nil, err
 	}
-	if formatter, ok := cmd.Context().Value(outputContextKey).(output.Formatter); ok && formatter != nil {
-		return formatter
+	return client.New(token)
+}
+
+func getFormatter(cmd *cobra.Command) output.Formatter {
+	if cmd != nil {
+		if formatter, ok := cmd.Context().Value(outputContextKey).(output.Formatter); ok && formatter != nil {
+			return formatter
+		}
 	}
-	return output.NewFormatter(outputFormat)
+	return output.JSONFormatter{}
 }
 
 func printFormatted(cmd *cobra.Command, value interface{}) error {
-	formatter := getFormatter(cmd)
+	return printWith(cmd, getFormatter(cmd), value)
+}
+
+// printWith writes value using formatter regardless of --output, for
+// commands whose default rendering differs from the global one.
+func printWith(cmd *cobra.Command, formatter output.Formatter, value interface{}) error {
 	out, err := formatter.Format(value)
 	if err != nil {
 		return err
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mtreilly/godiscord/gosdk/config"
+	"github.com/mtreilly/godiscord/gosdk/version"
+)
+
+// diagnostics is the summary users paste into bug reports. It never
+// includes secrets, only whether they are configured.
+type diagnostics struct {
+	SDK               version.Info `json:"sdk" yaml:"sdk"`
+	UserAgent         string       `json:"user_agent" yaml:"user_agent"`
+	ApplicationID     string       `json:"application_id,omitempty" yaml:"application_id,omitempty"`
+	TokenConfigured   bool         `json:"token_configured" yaml:"token_configured"`
+	Webhooks          int          `json:"webhooks" yaml:"webhooks"`
+	RateLimitStrategy string       `json:"rate_limit_strategy,omitempty" yaml:"rate_limit_strategy,omitempty"`
+}
+
+func collectDiagnostics(cfg *config.Config) diagnostics {
+	_, tokenErr := cfg.ResolveToken()
+	return diagnostics{
+		SDK:               version.Get(),
+		UserAgent:         version.UserAgent(),
+		ApplicationID:     cfg.Discord.ApplicationID,
+		TokenConfigured:   tokenErr == nil,
+		Webhooks:          len(cfg.Discord.Webhooks),
+		RateLimitStrategy: cfg.Client.RateLimit.Strategy,
+	}
+}
+
+func diagnosticsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diagnostics",
+		Short: "Print the SDK build and configuration summary for bug reports",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printFormatted(cmd, collectDiagnostics(getConfig(cmd)))
+		},
+	}
+}
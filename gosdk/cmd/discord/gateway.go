@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mtreilly/godiscord/gosdk/cmd/discord/output"
+	"github.com/mtreilly/godiscord/gosdk/discord/bot"
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+)
+
+func gatewayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gateway",
+		Short: "Inspect the gateway",
+	}
+	cmd.AddCommand(gatewayTailCmd())
+	return cmd
+}
+
+// tailOptions selects the dispatches printed by gateway tail.
+type tailOptions struct {
+	Events   []string
+	Guilds   []string
+	Channels []string
+	// Count stops the tail after this many events; 0 runs until interrupted.
+	Count int
+	// Formatter renders each line; nil writes compact JSON lines.
+	Formatter output.Formatter
+}
+
+// tailLine is one line of gateway tail output.
+type tailLine struct {
+	Type       string          `json:"t"`
+	Seq        int             `json:"s"`
+	ReceivedAt time.Time       `json:"received_at"`
+	Data       json.RawMessage `json:"d"`
+}
+
+func gatewayTailCmd() *cobra.Command {
+	var (
+		opts    tailOptions
+		intents []string
+	)
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Stream gateway events as JSON lines",
+		Long: `Connects to the gateway and prints each dispatch as a JSON line holding the
+event name (t), sequence number (s), the time it was received, and the raw
+event data (d), exactly as Discord sent it.
+
+--intents takes intent names such as guilds,guild_messages,message_content,
+or "default" and "all". Events can be narrowed with --event, --guild and
+--channel, each repeatable or comma separated. Stop with Ctrl-C, or after
+--count events. An explicit --output renders each event in that format
+instead, e.g. --output 'go-template={{.t}} {{.d.content}}'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := getConfig(cmd)
+			if !cmd.Flags().Changed("intents") {
+				intents = cfg.Bot.Intents
+			}
+			mask, err := bot.ParseIntents(intents)
+			if err != nil {
+				return err
+			}
+			token, err := cfg.ResolveToken()
+			if err != nil {
+				return err
+			}
+			conn, err := gateway.NewConnection(token, int(mask))
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			if cmd.Flags().Changed("output") {
+				opts.Formatter = getFormatter(cmd)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "tailing gateway with intents %s\n", mask)
+			return tailGateway(ctx, conn, token, mask, opts, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVar(&intents, "intents", nil, "intents to identify with (defaults to bot.intents, then default)")
+	cmd.Flags().StringSliceVar(&opts.Events, "event", nil, "only print these events, e.g. MESSAGE_CREATE")
+	cmd.Flags().StringSliceVar(&opts.Guilds, "guild", nil, "only print events from these guilds")
+	cmd.Flags().StringSliceVar(&opts.Channels, "channel", nil, "only print events from these channels")
+	cmd.Flags().IntVar(&opts.Count, "count", 0, "exit after this many events")
+	return cmd
+}
+
+// tailGateway runs a gateway client over conn and writes the dispatches
+// that pass opts to w until ctx is done or opts.Count events were written.
+// A session Discord ends for good, e.g. over disallowed intents, is
+// returned as an error.
+func tailGateway(ctx context.Context, conn gateway.Transport, token string, intents gateway.Intent, opts tailOptions, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tail := &tailTransport{
+		Transport: conn,
+		filter:    newTailFilter(opts),
+		write:     lineWriter(w, opts.Formatter),
+		limit:     opts.Count,
+		stop:      cancel,
+	}
+	var (
+		mu      sync.Mutex
+		lostErr error
+	)
+	client, err := gateway.NewClient(token, int(intents),
+		gateway.WithTransport(tail),
+		gateway.WithStateListener(func(s gateway.StateChange) {
+			if s.State == gateway.StateDisconnected && s.Err != nil {
+				mu.Lock()
+				lostErr = s.Err
+				mu.Unlock()
+				cancel()
+			}
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	if err := client.Connect(ctx); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	client.Disconnect()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if lostErr != nil {
+		return lostErr
+	}
+	return tail.err
+}
+
+// tailTransport writes matching dispatches as they are received, before
+// the client decodes them, so the output keeps every field Discord sent.
+type tailTransport struct {
+	gateway.Transport
+
+	filter  tailFilter
+	write   func(tailLine) error
+	limit   int
+	written int
+	stop    func()
+	err     error
+}
+
+// done reports whether the tail has stopped printing, after an error or
+// once the limit is reached. The client may still read a few payloads
+// while it shuts down.
+func (t *tailTransport) done() bool {
+	return t.err != nil || (t.limit > 0 && t.written >= t.limit)
+}
+
+func (t *tailTransport) Receive(ctx context.Context) (*gateway.Payload, error) {
+	payload, err := t.Transport.Receive(ctx)
+	if err != nil || payload.Op != gateway.OpCodeDispatch || t.done() || !t.filter.match(payload) {
+		return payload, err
+	}
+
+	line := tailLine{Type: payload.T, Seq: payload.S, ReceivedAt: time.Now().UTC(), Data: payload.D}
+	if err := t.write(line); err != nil {
+		t.err = err
+		t.stop()
+		return payload, nil
+	}
+	t.written++
+	if t.done() {
+		t.stop()
+	}
+	return payload, nil
+}
+
+// lineWriter writes one tail line per event. JSON stays one compact line
+// per event so the output can be piped to jq; YAML events are separated
+// into documents.
+func lineWriter(w io.Writer, formatter output.Formatter) func(tailLine) error {
+	switch formatter.(type) {
+	case nil, output.JSONFormatter:
+		enc := json.NewEncoder(w)
+		return func(line tailLine) error { return enc.Encode(line) }
+	}
+	return func(line tailLine) error {
+		out, err := formatter.Format(line)
+		if err != nil {
+			return err
+		}
+		if _, ok := formatter.(output.YAMLFormatter); ok {
+			out = append([]byte("---\n"), bytes.TrimSuffix(out, []byte("\n"))...)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", out)
+		return err
+	}
+}
+
+type tailFilter struct {
+	events   map[string]bool
+	guilds   map[string]bool
+	channels map[string]bool
+}
+
+func newTailFilter(opts tailOptions) tailFilter {
+	set := func(values []string, normalize func(string) string) map[string]bool {
+		if len(values) == 0 {
+			return nil
+		}
+		m := make(map[string]bool, len(values))
+		for _, v := range values {
+			m[normalize(strings.TrimSpace(v))] = true
+		}
+		return m
+	}
+	keep := func(s string) string { return s }
+	return tailFilter{
+		events:   set(opts.Events, strings.ToUpper),
+		guilds:   set(opts.Guilds, keep),
+		channels: set(opts.Channels, keep),
+	}
+}
+
+func (f tailFilter) match(payload *gateway.Payload) bool {
+	if f.events != nil && !f.events[payload.T] {
+		return false
+	}
+	if f.guilds == nil && f.channels == nil {
+		return true
+	}
+
+	var ids struct {
+		ID        string `json:"id"`
+		GuildID   string `json:"guild_id"`
+		ChannelID string `json:"channel_id"`
+	}
+	_ = json.Unmarshal(payload.D, &ids)
+	// Guild and channel events carry their own ID in id rather than
+	// guild_id or channel_id.
+	if ids.GuildID == "" && strings.HasPrefix(payload.T, "GUILD_") {
+		ids.GuildID = ids.ID
+	}
+	if ids.ChannelID == "" && (strings.HasPrefix(payload.T, "CHANNEL_") || strings.HasPrefix(payload.T, "THREAD_")) {
+		ids.ChannelID = ids.ID
+	}
+	if f.guilds != nil && !f.guilds[ids.GuildID] {
+		return false
+	}
+	if f.channels != nil && !f.channels[ids.ChannelID] {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/cmd/discord/output"
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+)
+
+func tailPayloads() []*gateway.Payload {
+	dispatch := func(seq int, event, data string) *gateway.Payload {
+		return &gateway.Payload{Op: gateway.OpCodeDispatch, S: seq, T: event, D: json.RawMessage(data)}
+	}
+	return []*gateway.Payload{
+		{Op: gateway.OpCodeHello, D: json.RawMessage(`{"heartbeat_interval":41250}`)},
+		dispatch(1, gateway.EventReady, `{"session_id":"abc"}`),
+		dispatch(2, gateway.EventGuildCreate, `{"id":"10","name":"one"}`),
+		dispatch(3, gateway.EventGuildCreate, `{"id":"20","name":"two"}`),
+		dispatch(4, gateway.EventMessageCreate, `{"id":"1","guild_id":"10","channel_id":"100","content":"a","extra_field":true}`),
+		dispatch(5, gateway.EventMessageCreate, `{"id":"2","guild_id":"20","channel_id":"200","content":"b"}`),
+		dispatch(6, gateway.EventChannelUpdate, `{"id":"100","guild_id":"10","name":"general"}`),
+		dispatch(7, gateway.EventMessageDelete, `{"id":"1","guild_id":"10","channel_id":"100"}`),
+	}
+}
+
+func runTail(t *testing.T, opts tailOptions) []tailLine {
+	t.Helper()
+	replay := gateway.NewReplayTransport(tailPayloads())
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	go func() {
+		// Without --count the tail runs until interrupted.
+		if opts.Count == 0 {
+			<-replay.Done()
+			cancel()
+		}
+	}()
+
+	var buf bytes.Buffer
+	if err := tailGateway(ctx, replay, "token", gateway.DefaultIntents(), opts, &buf); err != nil {
+		t.Fatalf("tailGateway() error = %v", err)
+	}
+	var lines []tailLine
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line tailLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func lineTypes(lines []tailLine) string {
+	var seqs []string
+	for _, l := range lines {
+		seqs = append(seqs, l.Type)
+	}
+	return strings.Join(seqs, ",")
+}
+
+func TestTailGateway(t *testing.T) {
+	lines := runTail(t, tailOptions{})
+	if len(lines) != 7 {
+		t.Fatalf("got %d lines, want every dispatch: %s", len(lines), lineTypes(lines))
+	}
+	if lines[3].Seq != 4 || !strings.Contains(string(lines[3].Data), `"extra_field":true`) || lines[3].ReceivedAt.IsZero() {
+		t.Fatalf("line = %+v, want raw data with sequence and time", lines[3])
+	}
+
+	lines = runTail(t, tailOptions{Events: []string{"message_create"}})
+	if got := lineTypes(lines); got != "MESSAGE_CREATE,MESSAGE_CREATE" {
+		t.Fatalf("event filter = %s", got)
+	}
+
+	lines = runTail(t, tailOptions{Guilds: []string{"10"}})
+	if got := lineTypes(lines); got != "GUILD_CREATE,MESSAGE_CREATE,CHANNEL_UPDATE,MESSAGE_DELETE" {
+		t.Fatalf("guild filter = %s", got)
+	}
+
+	lines = runTail(t, tailOptions{Channels: []string{"100"}, Events: []string{"MESSAGE_CREATE", "CHANNEL_UPDATE"}})
+	if got := lineTypes(lines); got != "MESSAGE_CREATE,CHANNEL_UPDATE" {
+		t.Fatalf("channel filter = %s", got)
+	}
+
+	lines = runTail(t, tailOptions{Count: 2})
+	if got := lineTypes(lines); got != "READY,GUILD_CREATE" {
+		t.Fatalf("count = %s", got)
+	}
+}
+
+func TestTailGatewayFormatter(t *testing.T) {
+	formatter, err := output.NewFormatter("go-template={{.s}} {{.t}} {{.d.content}}")
+	if err != nil {
+		t.Fatalf("NewFormatter() error = %v", err)
+	}
+	var buf bytes.Buffer
+	opts := tailOptions{Events: []string{"MESSAGE_CREATE"}, Count: 2, Formatter: formatter}
+	if err := tailGateway(context.Background(), gateway.NewReplayTransport(tailPayloads()), "token", gateway.DefaultIntents(), opts, &buf); err != nil {
+		t.Fatalf("tailGateway() error = %v", err)
+	}
+	if got := buf.String(); got != "4 MESSAGE_CREATE a\n5 MESSAGE_CREATE b\n" {
+		t.Fatalf("output = %q", got)
+	}
+}
+
+func TestGatewayTailRejectsUnknownIntent(t *testing.T) {
+	_, err := runCLI(t, strings.NewReader(""), "gateway", "tail", "--intents", "guilds,bogus")
+	if err == nil || !strings.Contains(err.Error(), "bogus") {
+		t.Fatalf("error = %v, want unknown intent", err)
+	}
+}
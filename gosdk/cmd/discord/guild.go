@@ -1,9 +1,28 @@
 package main
 
-import "github.com/spf13/cobra"
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/mtreilly/godiscord/gosdk/cmd/discord/output"
+	"github.com/mtreilly/godiscord/gosdk/discord/cdn"
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
 
 func guildCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "guild",
 		Short: "Query guild metadata",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -11,4 +30,839 @@ func guildCmd() *cobra.Command {
 			return printFormatted(cmd, map[string]string{"application_id": cfg.Discord.ApplicationID})
 		},
 	}
+	cmd.AddCommand(guildExportCmd(), guildApplyCmd())
+	return cmd
+}
+
+// everyoneRole names the @everyone role in snapshots. Its ID is the guild's.
+const everyoneRole = "@everyone"
+
+// guildSnapshot is the declarative form of a guild's structure. Roles and
+// channels refer to each other by name so a snapshot can be applied to a
+// guild other than the one it was exported from.
+type guildSnapshot struct {
+	Name     string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Roles    []snapshotRole    `json:"roles" yaml:"roles"`
+	Channels []snapshotChannel `json:"channels" yaml:"channels"`
+	Emojis   []snapshotEmoji   `json:"emojis,omitempty" yaml:"emojis,omitempty"`
+}
+
+type snapshotRole struct {
+	Name         string `json:"name" yaml:"name"`
+	Permissions  string `json:"permissions" yaml:"permissions"`
+	Color        int    `json:"color,omitempty" yaml:"color,omitempty"`
+	Hoist        bool   `json:"hoist,omitempty" yaml:"hoist,omitempty"`
+	Mentionable  bool   `json:"mentionable,omitempty" yaml:"mentionable,omitempty"`
+	UnicodeEmoji string `json:"unicode_emoji,omitempty" yaml:"unicode_emoji,omitempty"`
+}
+
+type snapshotChannel struct {
+	Name string `json:"name" yaml:"name"`
+	// Type is text, voice, category, announcement, stage or forum.
+	Type string `json:"type" yaml:"type"`
+	// Parent is the name of the channel's category.
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// Position is left alone on apply when unset.
+	Position         *int                `json:"position,omitempty" yaml:"position,omitempty"`
+	Topic            string              `json:"topic,omitempty" yaml:"topic,omitempty"`
+	NSFW             bool                `json:"nsfw,omitempty" yaml:"nsfw,omitempty"`
+	Bitrate          int                 `json:"bitrate,omitempty" yaml:"bitrate,omitempty"`
+	UserLimit        int                 `json:"user_limit,omitempty" yaml:"user_limit,omitempty"`
+	RateLimitPerUser int                 `json:"rate_limit_per_user,omitempty" yaml:"rate_limit_per_user,omitempty"`
+	Overwrites       []snapshotOverwrite `json:"permission_overwrites,omitempty" yaml:"permission_overwrites,omitempty"`
+}
+
+// snapshotOverwrite targets a role by name or a member by user ID.
+type snapshotOverwrite struct {
+	Role   string `json:"role,omitempty" yaml:"role,omitempty"`
+	Member string `json:"member,omitempty" yaml:"member,omitempty"`
+	Allow  string `json:"allow,omitempty" yaml:"allow,omitempty"`
+	Deny   string `json:"deny,omitempty" yaml:"deny,omitempty"`
+}
+
+type snapshotEmoji struct {
+	Name string `json:"name" yaml:"name"`
+	// Image is the emoji's CDN URL, downloaded when the emoji is created.
+	Image    string   `json:"image" yaml:"image"`
+	Animated bool     `json:"animated,omitempty" yaml:"animated,omitempty"`
+	Roles    []string `json:"roles,omitempty" yaml:"roles,omitempty"`
+}
+
+func guildExportCmd() *cobra.Command {
+	var (
+		guildID string
+		file    string
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write a guild's roles, channels and emojis as a snapshot",
+		Long: `Exports the roles, channels, permission overwrites and custom emojis of
+--guild as a snapshot that "discord guild apply" can re-create in another
+guild. Roles and categories are referred to by name rather than ID.
+
+The snapshot is printed in the --output format, or written to --file as
+JSON when the name ends in .json and YAML otherwise. Roles managed by
+integrations, such as bot roles, are left out.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rest, err := newRESTClient(cmd)
+			if err != nil {
+				return err
+			}
+			snap, err := exportGuild(cmd.Context(), rest, guildID)
+			if err != nil {
+				return err
+			}
+			if file == "" {
+				return printFormatted(cmd, snap)
+			}
+			var formatter output.Formatter = output.YAMLFormatter{}
+			if strings.EqualFold(filepath.Ext(file), ".json") {
+				formatter = output.JSONFormatter{}
+			}
+			data, err := formatter.Format(snap)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "exported %d roles, %d channels, %d emojis to %s\n",
+				len(snap.Roles), len(snap.Channels), len(snap.Emojis), file)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&guildID, "guild", "", "guild to export")
+	cmd.Flags().StringVar(&file, "file", "", "write the snapshot to this file instead of stdout")
+	_ = cmd.MarkFlagRequired("guild")
+	return cmd
+}
+
+// guildChange is one row of the apply summary.
+type guildChange struct {
+	Action  string   `json:"action" yaml:"action"`
+	Kind    string   `json:"kind" yaml:"kind"`
+	Name    string   `json:"name" yaml:"name"`
+	ID      string   `json:"id,omitempty" yaml:"id,omitempty"`
+	Changes []string `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// applyOptions controls applyGuild.
+type applyOptions struct {
+	DryRun bool
+	// Prune deletes roles and channels that are not in the snapshot.
+	Prune bool
+	// fetch downloads an emoji image as a data URI. Tests replace it.
+	fetch func(ctx context.Context, url string) (string, error)
+}
+
+func guildApplyCmd() *cobra.Command {
+	var (
+		guildID string
+		file    string
+		opts    applyOptions
+	)
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Make a guild's roles, channels and emojis match a snapshot",
+		Long: `Loads a snapshot written by "discord guild export" and changes --guild to
+match it. Roles are matched by name, channels by type, category and name,
+and emojis by name. Missing roles, channels and emojis are created and
+roles and channels that differ are updated; roles are applied first so
+permission overwrites can refer to them. Nothing is deleted unless --prune
+is given, and emojis are never updated or deleted.
+
+The summary lists each change and is a table unless --output is given.
+--dry-run prints the summary without changing anything.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snap, err := loadGuildSnapshot(file)
+			if err != nil {
+				return err
+			}
+			rest, err := newRESTClient(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := applyGuild(cmd.Context(), rest, guildID, snap, opts)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), summarizeGuildChanges(changes, opts.DryRun))
+			if !cmd.Flags().Changed("output") {
+				return printWith(cmd, output.TableFormatter{}, changes)
+			}
+			return printFormatted(cmd, changes)
+		},
+	}
+	cmd.Flags().StringVar(&guildID, "guild", "", "guild to change")
+	cmd.Flags().StringVar(&file, "file", "", "YAML or JSON snapshot")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "show the changes without applying them")
+	cmd.Flags().BoolVar(&opts.Prune, "prune", false, "delete roles and channels missing from the snapshot")
+	_ = cmd.MarkFlagRequired("guild")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func summarizeGuildChanges(changes []guildChange, dryRun bool) string {
+	actions := make([]string, len(changes))
+	for i, c := range changes {
+		actions[i] = c.Action
+	}
+	return summarizeActions(actions, dryRun)
+}
+
+// exportGuild reads a guild's structure into a snapshot.
+func exportGuild(ctx context.Context, rest *client.Client, guildID string) (*guildSnapshot, error) {
+	guilds := rest.Guilds()
+	guild, err := guilds.GetGuild(ctx, guildID, false)
+	if err != nil {
+		return nil, fmt.Errorf("get guild: %w", err)
+	}
+	roles, err := guilds.GetGuildRoles(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+	channels, err := guilds.GetGuildChannels(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("list channels: %w", err)
+	}
+	emojis, err := guilds.ListGuildEmojis(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("list emojis: %w", err)
+	}
+
+	roleNames := make(map[string]string, len(roles))
+	for _, r := range roles {
+		roleNames[r.ID] = r.Name
+	}
+	roleNames[guildID] = everyoneRole
+
+	snap := &guildSnapshot{Name: guild.Name}
+
+	// Highest roles first, as Discord lists them in the hierarchy.
+	sort.SliceStable(roles, func(i, j int) bool { return roles[i].Position > roles[j].Position })
+	for _, r := range roles {
+		if r.Managed {
+			continue
+		}
+		snap.Roles = append(snap.Roles, snapshotRole{
+			Name:         roleNames[r.ID],
+			Permissions:  r.Permissions,
+			Color:        r.Color,
+			Hoist:        r.Hoist,
+			Mentionable:  r.Mentionable,
+			UnicodeEmoji: r.UnicodeEmoji,
+		})
+	}
+
+	channelNames := make(map[string]string, len(channels))
+	for _, c := range channels {
+		channelNames[c.ID] = c.Name
+	}
+	sortChannels(channels)
+	for _, c := range channels {
+		position := c.Position
+		sc := snapshotChannel{
+			Name:             c.Name,
+			Type:             channelTypeName(c.Type),
+			Parent:           channelNames[c.ParentID],
+			Position:         &position,
+			Topic:            c.Topic,
+			NSFW:             c.NSFW,
+			Bitrate:          c.Bitrate,
+			UserLimit:        c.UserLimit,
+			RateLimitPerUser: c.RateLimitPerUser,
+		}
+		for _, ow := range c.PermissionOverwrites {
+			so := snapshotOverwrite{Allow: ow.Allow, Deny: ow.Deny}
+			if ow.Type == types.PermissionOverwriteMember {
+				so.Member = ow.ID
+			} else if so.Role = roleNames[ow.ID]; so.Role == "" {
+				// The role was deleted but Discord kept its overwrite.
+				continue
+			}
+			sc.Overwrites = append(sc.Overwrites, so)
+		}
+		snap.Channels = append(snap.Channels, sc)
+	}
+
+	for _, e := range emojis {
+		se := snapshotEmoji{Name: e.Name, Image: cdn.Emoji(e), Animated: e.Animated}
+		for _, id := range e.Roles {
+			if name := roleNames[id]; name != "" {
+				se.Roles = append(se.Roles, name)
+			}
+		}
+		snap.Emojis = append(snap.Emojis, se)
+	}
+	return snap, nil
+}
+
+// sortChannels orders channels as the client shows them: uncategorized
+// channels, then each category followed by its channels.
+func sortChannels(channels []*types.Channel) {
+	byID := make(map[string]*types.Channel, len(channels))
+	for _, c := range channels {
+		byID[c.ID] = c
+	}
+	group := func(c *types.Channel) *types.Channel {
+		if c.Type == types.ChannelTypeGuildCategory {
+			return c
+		}
+		if parent, ok := byID[c.ParentID]; ok {
+			return parent
+		}
+		return nil
+	}
+	less := func(a, b *types.Channel) bool {
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ID < b.ID
+	}
+	sort.SliceStable(channels, func(i, j int) bool {
+		a, b := channels[i], channels[j]
+		ga, gb := group(a), group(b)
+		switch {
+		case ga != gb && ga == nil:
+			return true
+		case ga != gb && gb == nil:
+			return false
+		case ga != gb:
+			return less(ga, gb)
+		}
+		// Same group: the category itself comes first.
+		if (a.Type == types.ChannelTypeGuildCategory) != (b.Type == types.ChannelTypeGuildCategory) {
+			return a.Type == types.ChannelTypeGuildCategory
+		}
+		return less(a, b)
+	})
+}
+
+// loadGuildSnapshot reads and checks a YAML or JSON snapshot.
+func loadGuildSnapshot(path string) (*guildSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snap guildSnapshot
+	if err := yaml.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if err := snap.validate(); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+func (s *guildSnapshot) validate() error {
+	roles := make(map[string]bool, len(s.Roles))
+	for i, r := range s.Roles {
+		if r.Name == "" {
+			return fmt.Errorf("role %d has no name", i)
+		}
+		if roles[r.Name] {
+			return fmt.Errorf("role %q is defined twice", r.Name)
+		}
+		roles[r.Name] = true
+	}
+	categories := map[string]bool{}
+	for _, c := range s.Channels {
+		if c.Type == "category" {
+			categories[c.Name] = true
+		}
+	}
+	seen := make(map[string]bool, len(s.Channels))
+	for i, c := range s.Channels {
+		if c.Name == "" {
+			return fmt.Errorf("channel %d has no name", i)
+		}
+		if _, err := parseChannelType(c.Type); err != nil {
+			return fmt.Errorf("channel %q: %w", c.Name, err)
+		}
+		if c.Parent != "" && !categories[c.Parent] {
+			return fmt.Errorf("channel %q: no category named %q", c.Name, c.Parent)
+		}
+		if seen[c.key()] {
+			return fmt.Errorf("channel %q is defined twice", c.Name)
+		}
+		seen[c.key()] = true
+		for _, ow := range c.Overwrites {
+			if (ow.Role == "") == (ow.Member == "") {
+				return fmt.Errorf("channel %q: each permission overwrite needs exactly one of role or member", c.Name)
+			}
+		}
+	}
+	for i, e := range s.Emojis {
+		if e.Name == "" || e.Image == "" {
+			return fmt.Errorf("emoji %d needs a name and an image", i)
+		}
+	}
+	return nil
+}
+
+// key identifies a channel by type, category and name; channel names need
+// not be unique across categories.
+func (c snapshotChannel) key() string {
+	return c.Type + ":" + c.Parent + "/" + c.Name
+}
+
+// applyGuild changes guildID to match snap and reports what it changed.
+// Roles go first so channel overwrites can use the IDs of new roles, then
+// categories, then the channels inside them, then emojis.
+func applyGuild(ctx context.Context, rest *client.Client, guildID string, snap *guildSnapshot, opts applyOptions) ([]guildChange, error) {
+	guilds := rest.Guilds()
+	roles, err := guilds.GetGuildRoles(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+	channels, err := guilds.GetGuildChannels(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("list channels: %w", err)
+	}
+	emojis, err := guilds.ListGuildEmojis(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("list emojis: %w", err)
+	}
+
+	a := &guildApplier{
+		rest:    rest,
+		guildID: guildID,
+		opts:    opts,
+		roleIDs: map[string]string{everyoneRole: guildID},
+	}
+	if a.opts.fetch == nil {
+		a.opts.fetch = fetchImage
+	}
+	if err := a.applyRoles(ctx, snap.Roles, roles); err != nil {
+		return a.changes, err
+	}
+	if err := a.applyChannels(ctx, snap.Channels, channels); err != nil {
+		return a.changes, err
+	}
+	if err := a.applyEmojis(ctx, snap.Emojis, emojis); err != nil {
+		return a.changes, err
+	}
+	return a.changes, nil
+}
+
+type guildApplier struct {
+	rest    *client.Client
+	guildID string
+	opts    applyOptions
+	changes []guildChange
+	// roleIDs maps role names to IDs in the target guild. Roles created in
+	// a dry run have no ID.
+	roleIDs map[string]string
+}
+
+func (a *guildApplier) record(c guildChange) {
+	a.changes = append(a.changes, c)
+}
+
+func (a *guildApplier) applyRoles(ctx context.Context, desired []snapshotRole, live []*types.Role) error {
+	byName := make(map[string]*types.Role, len(live))
+	for _, r := range live {
+		name := r.Name
+		if r.ID == a.guildID {
+			name = everyoneRole
+		}
+		if _, dup := byName[name]; !dup {
+			byName[name] = r
+			a.roleIDs[name] = r.ID
+		}
+	}
+
+	for _, want := range desired {
+		have, ok := byName[want.Name]
+		if !ok {
+			if want.Name == everyoneRole {
+				return fmt.Errorf("guild %s has no @everyone role", a.guildID)
+			}
+			change := guildChange{Action: actionCreate, Kind: "role", Name: want.Name}
+			if !a.opts.DryRun {
+				role, err := a.rest.Guilds().CreateGuildRole(ctx, a.guildID, &types.RoleCreateParams{
+					Name:         want.Name,
+					Permissions:  want.Permissions,
+					Color:        want.Color,
+					Hoist:        want.Hoist,
+					Mentionable:  want.Mentionable,
+					UnicodeEmoji: want.UnicodeEmoji,
+				})
+				if err != nil {
+					return fmt.Errorf("create role %q: %w", want.Name, err)
+				}
+				change.ID = role.ID
+			}
+			a.roleIDs[want.Name] = change.ID
+			a.record(change)
+			continue
+		}
+		delete(byName, want.Name)
+
+		patch := rolePatch(want, have)
+		change := guildChange{Action: actionUnchanged, Kind: "role", Name: want.Name, ID: have.ID, Changes: sortedKeys(patch)}
+		if len(patch) > 0 {
+			change.Action = actionUpdate
+			if !a.opts.DryRun {
+				if err := a.rest.Patch(ctx, fmt.Sprintf("/guilds/%s/roles/%s", a.guildID, have.ID), patch, nil); err != nil {
+					return fmt.Errorf("update role %q: %w", want.Name, err)
+				}
+			}
+		}
+		a.record(change)
+	}
+
+	if !a.opts.Prune {
+		return nil
+	}
+	for _, have := range live {
+		if _, extra := byName[have.Name]; !extra || have.ID == a.guildID || have.Managed {
+			continue
+		}
+		delete(byName, have.Name)
+		if !a.opts.DryRun {
+			if err := a.rest.Guilds().DeleteGuildRole(ctx, a.guildID, have.ID); err != nil {
+				return fmt.Errorf("delete role %q: %w", have.Name, err)
+			}
+		}
+		a.record(guildChange{Action: actionDelete, Kind: "role", Name: have.Name, ID: have.ID})
+	}
+	return nil
+}
+
+// rolePatch returns the fields of have that differ from want, keyed by
+// their JSON names. Updates are sent as raw patches because the typed
+// params omit zero values, which would make it impossible to, say, remove
+// a role's color.
+func rolePatch(want snapshotRole, have *types.Role) map[string]any {
+	patch := map[string]any{}
+	if want.Permissions != have.Permissions {
+		patch["permissions"] = want.Permissions
+	}
+	if want.Color != have.Color {
+		patch["color"] = want.Color
+	}
+	if want.Hoist != have.Hoist {
+		patch["hoist"] = want.Hoist
+	}
+	if want.Mentionable != have.Mentionable {
+		patch["mentionable"] = want.Mentionable
+	}
+	if want.UnicodeEmoji != have.UnicodeEmoji {
+		patch["unicode_emoji"] = want.UnicodeEmoji
+	}
+	return patch
+}
+
+func (a *guildApplier) applyChannels(ctx context.Context, desired []snapshotChannel, live []*types.Channel) error {
+	liveNames := make(map[string]string, len(live))
+	for _, c := range live {
+		liveNames[c.ID] = c.Name
+	}
+	liveKey := func(c *types.Channel) string {
+		return channelTypeName(c.Type) + ":" + liveNames[c.ParentID] + "/" + c.Name
+	}
+	byKey := make(map[string]*types.Channel, len(live))
+	for _, c := range live {
+		if _, dup := byKey[liveKey(c)]; !dup {
+			byKey[liveKey(c)] = c
+		}
+	}
+	matched := make(map[string]bool, len(live))
+	categoryIDs := map[string]string{}
+
+	// Categories are applied before the channels that sit in them.
+	ordered := make([]snapshotChannel, 0, len(desired))
+	for _, c := range desired {
+		if c.Type == "category" {
+			ordered = append(ordered, c)
+		}
+	}
+	for _, c := range desired {
+		if c.Type != "category" {
+			ordered = append(ordered, c)
+		}
+	}
+
+	for _, want := range ordered {
+		channelType, _ := parseChannelType(want.Type)
+		overwrites, err := a.resolveOverwrites(want)
+		if err != nil {
+			return err
+		}
+		have := byKey[want.key()]
+		if have == nil || matched[have.ID] {
+			// A channel moved to another category is still the same channel.
+			have = a.findMoved(want, channelType, live, matched)
+		}
+
+		if have == nil {
+			change := guildChange{Action: actionCreate, Kind: want.Type, Name: want.Name}
+			if !a.opts.DryRun {
+				params := &types.ChannelCreateParams{
+					Name:                 want.Name,
+					Type:                 channelType,
+					Topic:                want.Topic,
+					Bitrate:              want.Bitrate,
+					UserLimit:            want.UserLimit,
+					RateLimitPerUser:     want.RateLimitPerUser,
+					PermissionOverwrites: overwrites,
+					ParentID:             categoryIDs[want.Parent],
+					NSFW:                 want.NSFW,
+				}
+				if want.Position != nil {
+					params.Position = *want.Position
+				}
+				channel, err := a.rest.Guilds().CreateGuildChannel(ctx, a.guildID, params)
+				if err != nil {
+					return fmt.Errorf("create channel %q: %w", want.Name, err)
+				}
+				change.ID = channel.ID
+				if channelType == types.ChannelTypeGuildCategory {
+					categoryIDs[want.Name] = channel.ID
+				}
+			}
+			a.record(change)
+			continue
+		}
+		matched[have.ID] = true
+		if channelType == types.ChannelTypeGuildCategory {
+			categoryIDs[want.Name] = have.ID
+		}
+
+		patch := channelPatch(want, have, categoryIDs[want.Parent], overwrites)
+		change := guildChange{Action: actionUnchanged, Kind: want.Type, Name: want.Name, ID: have.ID, Changes: sortedKeys(patch)}
+		if len(patch) > 0 {
+			change.Action = actionUpdate
+			if !a.opts.DryRun {
+				if err := a.rest.Patch(ctx, "/channels/"+have.ID, patch, nil); err != nil {
+					return fmt.Errorf("update channel %q: %w", want.Name, err)
+				}
+			}
+		}
+		a.record(change)
+	}
+
+	if !a.opts.Prune {
+		return nil
+	}
+	// Delete channels before their categories.
+	extra := make([]*types.Channel, 0, len(live))
+	for _, c := range live {
+		if !matched[c.ID] {
+			extra = append(extra, c)
+		}
+	}
+	sort.SliceStable(extra, func(i, j int) bool {
+		return extra[i].Type != types.ChannelTypeGuildCategory && extra[j].Type == types.ChannelTypeGuildCategory
+	})
+	for _, c := range extra {
+		if !a.opts.DryRun {
+			if err := a.rest.Channels().DeleteChannel(ctx, c.ID); err != nil {
+				return fmt.Errorf("delete channel %q: %w", c.Name, err)
+			}
+		}
+		a.record(guildChange{Action: actionDelete, Kind: channelTypeName(c.Type), Name: c.Name, ID: c.ID})
+	}
+	return nil
+}
+
+// findMoved returns the only unmatched live channel with want's type and
+// name, or nil when there is none or the match would be ambiguous.
+func (a *guildApplier) findMoved(want snapshotChannel, channelType types.ChannelType, live []*types.Channel, matched map[string]bool) *types.Channel {
+	var found *types.Channel
+	for _, c := range live {
+		if c.Type != channelType || c.Name != want.Name || matched[c.ID] {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = c
+	}
+	return found
+}
+
+// resolveOverwrites turns role names into IDs in the target guild.
+func (a *guildApplier) resolveOverwrites(c snapshotChannel) ([]types.PermissionOverwrite, error) {
+	overwrites := make([]types.PermissionOverwrite, 0, len(c.Overwrites))
+	for _, ow := range c.Overwrites {
+		po := types.PermissionOverwrite{ID: ow.Member, Type: types.PermissionOverwriteMember, Allow: orZero(ow.Allow), Deny: orZero(ow.Deny)}
+		if ow.Role != "" {
+			id, ok := a.roleIDs[ow.Role]
+			if !ok {
+				return nil, fmt.Errorf("channel %q: permission overwrite for unknown role %q", c.Name, ow.Role)
+			}
+			po.ID, po.Type = id, types.PermissionOverwriteRole
+		}
+		overwrites = append(overwrites, po)
+	}
+	return overwrites, nil
+}
+
+// channelPatch returns the fields of have that differ from want, keyed by
+// their JSON names. Bitrate and position are only compared when set.
+func channelPatch(want snapshotChannel, have *types.Channel, parentID string, overwrites []types.PermissionOverwrite) map[string]any {
+	patch := map[string]any{}
+	if parentID != have.ParentID {
+		patch["parent_id"] = nullable(parentID)
+	}
+	if want.Position != nil && *want.Position != have.Position {
+		patch["position"] = *want.Position
+	}
+	if want.Topic != have.Topic {
+		patch["topic"] = want.Topic
+	}
+	if want.NSFW != have.NSFW {
+		patch["nsfw"] = want.NSFW
+	}
+	if want.Bitrate != 0 && want.Bitrate != have.Bitrate {
+		patch["bitrate"] = want.Bitrate
+	}
+	if want.UserLimit != have.UserLimit {
+		patch["user_limit"] = want.UserLimit
+	}
+	if want.RateLimitPerUser != have.RateLimitPerUser {
+		patch["rate_limit_per_user"] = want.RateLimitPerUser
+	}
+	current := make([]types.PermissionOverwrite, len(have.PermissionOverwrites))
+	for i, ow := range have.PermissionOverwrites {
+		ow.Allow, ow.Deny = orZero(ow.Allow), orZero(ow.Deny)
+		current[i] = ow
+	}
+	if !sameJSON(sortOverwrites(overwrites), sortOverwrites(current)) {
+		patch["permission_overwrites"] = overwrites
+	}
+	return patch
+}
+
+func sortOverwrites(overwrites []types.PermissionOverwrite) []types.PermissionOverwrite {
+	sorted := append([]types.PermissionOverwrite{}, overwrites...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+func (a *guildApplier) applyEmojis(ctx context.Context, desired []snapshotEmoji, live []*types.Emoji) error {
+	existing := make(map[string]*types.Emoji, len(live))
+	for _, e := range live {
+		existing[e.Name] = e
+	}
+	for _, want := range desired {
+		if have, ok := existing[want.Name]; ok {
+			a.record(guildChange{Action: actionUnchanged, Kind: "emoji", Name: want.Name, ID: have.ID})
+			continue
+		}
+		change := guildChange{Action: actionCreate, Kind: "emoji", Name: want.Name}
+		if !a.opts.DryRun {
+			image, err := a.opts.fetch(ctx, want.Image)
+			if err != nil {
+				return fmt.Errorf("download emoji %q: %w", want.Name, err)
+			}
+			params := &types.EmojiCreateParams{Name: want.Name, Image: image}
+			for _, role := range want.Roles {
+				if id := a.roleIDs[role]; id != "" {
+					params.Roles = append(params.Roles, id)
+				}
+			}
+			emoji, err := a.rest.Guilds().CreateGuildEmoji(ctx, a.guildID, params)
+			if err != nil {
+				return fmt.Errorf("create emoji %q: %w", want.Name, err)
+			}
+			change.ID = emoji.ID
+		}
+		a.record(change)
+	}
+	return nil
+}
+
+// maxEmojiSize is the largest image Discord accepts for an emoji.
+const maxEmojiSize = 256 << 10
+
+// fetchImage downloads url and returns it as a data URI.
+func fetchImage(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxEmojiSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxEmojiSize {
+		return "", fmt.Errorf("GET %s: image is larger than %d KiB", url, maxEmojiSize>>10)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = http.DetectContentType(data)
+	}
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+var channelTypeNames = map[types.ChannelType]string{
+	types.ChannelTypeGuildText:       "text",
+	types.ChannelTypeGuildVoice:      "voice",
+	types.ChannelTypeGuildCategory:   "category",
+	types.ChannelTypeGuildNews:       "announcement",
+	types.ChannelTypeGuildStageVoice: "stage",
+	types.ChannelTypeGuildForum:      "forum",
+}
+
+// channelTypeName names t for snapshots; types without a name, such as
+// ones newer than this CLI, are written as their number.
+func channelTypeName(t types.ChannelType) string {
+	if name, ok := channelTypeNames[t]; ok {
+		return name
+	}
+	return strconv.Itoa(int(t))
+}
+
+func parseChannelType(name string) (types.ChannelType, error) {
+	for t, n := range channelTypeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		return types.ChannelType(n), nil
+	}
+	return 0, fmt.Errorf("unknown channel type %q", name)
+}
+
+func orZero(bits string) string {
+	if bits == "" {
+		return "0"
+	}
+	return bits
+}
+
+// nullable sends an empty ID as JSON null, which Discord takes as "none".
+func nullable(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/cmd/discord/output"
+	"github.com/mtreilly/godiscord/gosdk/discord/discordtest"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// fakeGuilds serves the guild structure routes discordtest does not
+// implement, for any number of guilds.
+type fakeGuilds struct {
+	mu     sync.Mutex
+	next   int
+	guilds map[string]*fakeGuild
+}
+
+type fakeGuild struct {
+	name     string
+	roles    []*types.Role
+	channels []*types.Channel
+	emojis   []*types.Emoji
+}
+
+func newFakeGuilds(srv *discordtest.Server) *fakeGuilds {
+	f := &fakeGuilds{next: 1000, guilds: map[string]*fakeGuild{}}
+	srv.Handle(http.MethodGet, "/guilds/{guild}", func(w http.ResponseWriter, r *http.Request) {
+		id, g := f.guild(r)
+		json.NewEncoder(w).Encode(types.Guild{ID: id, Name: g.name})
+	})
+	srv.Handle(http.MethodGet, "/guilds/{guild}/roles", func(w http.ResponseWriter, r *http.Request) {
+		_, g := f.guild(r)
+		json.NewEncoder(w).Encode(g.roles)
+	})
+	srv.Handle(http.MethodPost, "/guilds/{guild}/roles", func(w http.ResponseWriter, r *http.Request) {
+		_, g := f.guild(r)
+		role := &types.Role{ID: f.newID()}
+		json.NewDecoder(r.Body).Decode(role)
+		f.mu.Lock()
+		g.roles = append(g.roles, role)
+		f.mu.Unlock()
+		json.NewEncoder(w).Encode(role)
+	})
+	srv.Handle(http.MethodPatch, "/guilds/{guild}/roles/{role}", func(w http.ResponseWriter, r *http.Request) {
+		_, g := f.guild(r)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, role := range g.roles {
+			if role.ID == lastSegment(r) {
+				json.NewDecoder(r.Body).Decode(role)
+				json.NewEncoder(w).Encode(role)
+			}
+		}
+	})
+	srv.Handle(http.MethodDelete, "/guilds/{guild}/roles/{role}", func(w http.ResponseWriter, r *http.Request) {
+		_, g := f.guild(r)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for i, role := range g.roles {
+			if role.ID == lastSegment(r) {
+				g.roles = append(g.roles[:i], g.roles[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv.Handle(http.MethodGet, "/guilds/{guild}/channels", func(w http.ResponseWriter, r *http.Request) {
+		_, g := f.guild(r)
+		json.NewEncoder(w).Encode(g.channels)
+	})
+	srv.Handle(http.MethodPost, "/guilds/{guild}/channels", func(w http.ResponseWriter, r *http.Request) {
+		id, g := f.guild(r)
+		channel := &types.Channel{ID: f.newID(), GuildID: id}
+		json.NewDecoder(r.Body).Decode(channel)
+		f.mu.Lock()
+		g.channels = append(g.channels, channel)
+		f.mu.Unlock()
+		json.NewEncoder(w).Encode(channel)
+	})
+	srv.Handle(http.MethodPatch, "/channels/{channel}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, g := range f.guilds {
+			for _, channel := range g.channels {
+				if channel.ID == lastSegment(r) {
+					json.NewDecoder(r.Body).Decode(channel)
+					json.NewEncoder(w).Encode(channel)
+				}
+			}
+		}
+	})
+	srv.Handle(http.MethodDelete, "/channels/{channel}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, g := range f.guilds {
+			for i, channel := range g.channels {
+				if channel.ID == lastSegment(r) {
+					g.channels = append(g.channels[:i], g.channels[i+1:]...)
+					break
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv.Handle(http.MethodGet, "/guilds/{guild}/emojis", func(w http.ResponseWriter, r *http.Request) {
+		_, g := f.guild(r)
+		json.NewEncoder(w).Encode(g.emojis)
+	})
+	srv.Handle(http.MethodPost, "/guilds/{guild}/emojis", func(w http.ResponseWriter, r *http.Request) {
+		_, g := f.guild(r)
+		var params types.EmojiCreateParams
+		json.NewDecoder(r.Body).Decode(&params)
+		emoji := &types.Emoji{ID: f.newID(), Name: params.Name, Roles: params.Roles, Available: true}
+		f.mu.Lock()
+		g.emojis = append(g.emojis, emoji)
+		f.mu.Unlock()
+		json.NewEncoder(w).Encode(emoji)
+	})
+	return f
+}
+
+func (f *fakeGuilds) add(id, name string) *fakeGuild {
+	g := &fakeGuild{name: name, roles: []*types.Role{{ID: id, Name: "@everyone", Permissions: "104324673"}}}
+	f.guilds[id] = g
+	return g
+}
+
+// guild returns the guild whose ID follows "guilds" in the request path.
+func (f *fakeGuilds) guild(r *http.Request) (string, *fakeGuild) {
+	parts := strings.Split(r.URL.Path, "/")
+	var id string
+	for i, p := range parts[:len(parts)-1] {
+		if p == "guilds" {
+			id = parts[i+1]
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return id, f.guilds[id]
+}
+
+func (f *fakeGuilds) newID() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.next++
+	return strconv.Itoa(f.next)
+}
+
+func lastSegment(r *http.Request) string {
+	return r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+}
+
+// sourceGuild builds the guild exported in the tests.
+func sourceGuild(f *fakeGuilds) {
+	g := f.add("1", "Source")
+	g.roles = append(g.roles,
+		&types.Role{ID: "10", Name: "Member", Permissions: "1024", Position: 1},
+		&types.Role{ID: "11", Name: "Mod", Permissions: "8192", Position: 3, Color: 0xff0000, Hoist: true},
+		&types.Role{ID: "12", Name: "Helper Bot", Permissions: "8", Position: 2, Managed: true},
+	)
+	g.channels = []*types.Channel{
+		{ID: "20", Type: types.ChannelTypeGuildCategory, Name: "Info", Position: 1},
+		{ID: "21", Type: types.ChannelTypeGuildText, Name: "rules", ParentID: "20", Position: 2, Topic: "Read me",
+			PermissionOverwrites: []types.PermissionOverwrite{
+				{ID: "1", Type: types.PermissionOverwriteRole, Deny: "2048"},
+				{ID: "11", Type: types.PermissionOverwriteRole, Allow: "2048"},
+				{ID: "500", Type: types.PermissionOverwriteMember, Allow: "2048"},
+			}},
+		{ID: "22", Type: types.ChannelTypeGuildVoice, Name: "Lounge", ParentID: "20", Position: 1, Bitrate: 64000, UserLimit: 5},
+		{ID: "23", Type: types.ChannelTypeGuildText, Name: "general", Position: 0, RateLimitPerUser: 5},
+	}
+	g.emojis = []*types.Emoji{{ID: "30", Name: "blob", Roles: []string{"11"}, Available: true}}
+}
+
+func TestExportGuild(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	sourceGuild(newFakeGuilds(srv))
+
+	snap, err := exportGuild(context.Background(), srv.Client("token"), "1")
+	if err != nil {
+		t.Fatalf("exportGuild() error = %v", err)
+	}
+	if snap.Name != "Source" {
+		t.Fatalf("name = %q", snap.Name)
+	}
+
+	var roles []string
+	for _, r := range snap.Roles {
+		roles = append(roles, r.Name)
+	}
+	if strings.Join(roles, ",") != "Mod,Member,@everyone" {
+		t.Fatalf("roles = %v, want hierarchy order without managed roles", roles)
+	}
+
+	var channels []string
+	for _, c := range snap.Channels {
+		channels = append(channels, c.key())
+	}
+	want := "text:/general,category:/Info,voice:Info/Lounge,text:Info/rules"
+	if strings.Join(channels, ",") != want {
+		t.Fatalf("channels = %v, want %s", channels, want)
+	}
+	rules := snap.Channels[3]
+	if len(rules.Overwrites) != 3 || rules.Overwrites[0].Role != "@everyone" || rules.Overwrites[1].Role != "Mod" || rules.Overwrites[2].Member != "500" {
+		t.Fatalf("rules overwrites = %+v", rules.Overwrites)
+	}
+
+	if len(snap.Emojis) != 1 || snap.Emojis[0].Image != "https://cdn.discordapp.com/emojis/30.png" || snap.Emojis[0].Roles[0] != "Mod" {
+		t.Fatalf("emojis = %+v", snap.Emojis)
+	}
+}
+
+func TestApplyGuild(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	f := newFakeGuilds(srv)
+	sourceGuild(f)
+	rest := srv.Client("token")
+	snap, err := exportGuild(context.Background(), rest, "1")
+	if err != nil {
+		t.Fatalf("exportGuild() error = %v", err)
+	}
+
+	target := f.add("2", "Target")
+	target.roles[0].Permissions = "0"
+	target.roles = append(target.roles,
+		&types.Role{ID: "40", Name: "Member", Permissions: "1024", Color: 0x00ff00},
+		&types.Role{ID: "41", Name: "Old", Permissions: "0"},
+	)
+	target.channels = []*types.Channel{
+		{ID: "50", Type: types.ChannelTypeGuildText, Name: "general", Topic: "stale", RateLimitPerUser: 5},
+		{ID: "51", Type: types.ChannelTypeGuildText, Name: "spam"},
+	}
+
+	var fetched []string
+	opts := applyOptions{DryRun: true, Prune: true, fetch: func(ctx context.Context, url string) (string, error) {
+		fetched = append(fetched, url)
+		return "data:image/png;base64,AAAA", nil
+	}}
+	changes, err := applyGuild(context.Background(), rest, "2", snap, opts)
+	if err != nil {
+		t.Fatalf("applyGuild(dry run) error = %v", err)
+	}
+	if summary := summarizeGuildChanges(changes, true); summary != "5 to create, 3 to update, 2 to delete, 0 unchanged (dry run)" {
+		t.Fatalf("summary = %q; changes = %+v", summary, changes)
+	}
+	srv.AssertNoRequest(t, http.MethodPost, "/guilds/{guild}/roles")
+	srv.AssertNoRequest(t, http.MethodPatch, "/channels/{channel}")
+	srv.AssertNoRequest(t, http.MethodDelete, "/channels/{channel}")
+	if len(fetched) != 0 {
+		t.Fatalf("dry run downloaded %v", fetched)
+	}
+
+	opts.DryRun = false
+	if _, err := applyGuild(context.Background(), rest, "2", snap, opts); err != nil {
+		t.Fatalf("applyGuild() error = %v", err)
+	}
+	if len(fetched) != 1 || fetched[0] != snap.Emojis[0].Image {
+		t.Fatalf("fetched = %v", fetched)
+	}
+
+	roleIDs := map[string]string{}
+	for _, r := range target.roles {
+		roleIDs[r.Name] = r.ID
+	}
+	if _, ok := roleIDs["Old"]; ok {
+		t.Fatal("role Old was not pruned")
+	}
+	if target.roles[0].Permissions != snap.Roles[2].Permissions || target.roles[1].Color != 0 {
+		t.Fatalf("roles not updated: %+v %+v", target.roles[0], target.roles[1])
+	}
+	byName := map[string]*types.Channel{}
+	for _, c := range target.channels {
+		byName[c.Name] = c
+	}
+	if _, ok := byName["spam"]; ok {
+		t.Fatal("channel spam was not pruned")
+	}
+	if byName["general"].ID != "50" || byName["general"].Topic != "" {
+		t.Fatalf("general = %+v, want topic cleared in place", byName["general"])
+	}
+	rules := byName["rules"]
+	if rules.ParentID != byName["Info"].ID {
+		t.Fatalf("rules parent = %q, want %q", rules.ParentID, byName["Info"].ID)
+	}
+	ow := sortOverwrites(rules.PermissionOverwrites)
+	if len(ow) != 3 || ow[0].ID != "500" || ow[1].ID != roleIDs["Mod"] || ow[2].ID != "2" {
+		t.Fatalf("rules overwrites = %+v, want IDs in the target guild", ow)
+	}
+	if len(target.emojis) != 1 || target.emojis[0].Roles[0] != roleIDs["Mod"] {
+		t.Fatalf("emojis = %+v", target.emojis)
+	}
+
+	// Applying again finds nothing to do.
+	changes, err = applyGuild(context.Background(), rest, "2", snap, opts)
+	if err != nil {
+		t.Fatalf("applyGuild(again) error = %v", err)
+	}
+	for _, c := range changes {
+		if c.Action != actionUnchanged {
+			t.Fatalf("second apply changed %+v", c)
+		}
+	}
+}
+
+func TestApplyGuildMovesChannels(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	f := newFakeGuilds(srv)
+	g := f.add("2", "Target")
+	g.channels = []*types.Channel{
+		{ID: "60", Type: types.ChannelTypeGuildCategory, Name: "Archive"},
+		{ID: "61", Type: types.ChannelTypeGuildText, Name: "notes", ParentID: "60"},
+	}
+	snap := &guildSnapshot{Channels: []snapshotChannel{
+		{Name: "Archive", Type: "category"},
+		{Name: "notes", Type: "text"},
+	}}
+
+	changes, err := applyGuild(context.Background(), srv.Client("token"), "2", snap, applyOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("applyGuild() error = %v", err)
+	}
+	if len(changes) != 2 || changes[1].Action != actionUpdate || changes[1].ID != "61" || changes[1].Changes[0] != "parent_id" {
+		t.Fatalf("changes = %+v, want notes moved in place", changes)
+	}
+	var body map[string]any
+	srv.AssertRequest(t, http.MethodPatch, "/channels/{channel}").JSON(&body)
+	if v, ok := body["parent_id"]; !ok || v != nil {
+		t.Fatalf("patch = %v, want parent_id null", body)
+	}
+}
+
+func TestLoadGuildSnapshot(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	sourceGuild(newFakeGuilds(srv))
+	snap, err := exportGuild(context.Background(), srv.Client("token"), "1")
+	if err != nil {
+		t.Fatalf("exportGuild() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	for name, formatter := range map[string]output.Formatter{"guild.yaml": output.YAMLFormatter{}, "guild.json": output.JSONFormatter{}} {
+		data, err := formatter.Format(snap)
+		if err != nil {
+			t.Fatalf("Format() error = %v", err)
+		}
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, data, 0o600)
+		loaded, err := loadGuildSnapshot(path)
+		if err != nil {
+			t.Fatalf("loadGuildSnapshot(%s) error = %v", name, err)
+		}
+		if !sameJSON(loaded, snap) {
+			t.Fatalf("%s round trip = %+v, want %+v", name, loaded, snap)
+		}
+	}
+
+	for _, bad := range []string{
+		"roles: [{name: Mod}, {name: Mod}]",
+		"channels: [{name: a, type: text, parent: Missing}]",
+		"channels: [{name: a, type: thread}]",
+		"channels: [{name: a, type: text, permission_overwrites: [{allow: '1'}]}]",
+	} {
+		path := filepath.Join(dir, "bad.yaml")
+		os.WriteFile(path, []byte(bad), 0o600)
+		if _, err := loadGuildSnapshot(path); err == nil {
+			t.Fatalf("loadGuildSnapshot(%q) succeeded", bad)
+		}
+	}
+}
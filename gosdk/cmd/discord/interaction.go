@@ -1,11 +1,31 @@
 package main
 
-import "github.com/spf13/cobra"
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
 
 func interactionCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "interaction",
-		Short: "Respond to interactions",
+	cmd := &cobra.Command{
+		Use:     "interaction",
+		Aliases: []string{"interactions"},
+		Short:   "Respond to interactions",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg := getConfig(cmd)
 			if len(cfg.Discord.Webhooks) > 0 {
@@ -14,4 +34,225 @@ func interactionCmd() *cobra.Command {
 			return printFormatted(cmd, map[string]string{"error": "no webhook configured"})
 		},
 	}
+	cmd.AddCommand(interactionVerifyCmd())
+	return cmd
+}
+
+// verifyOptions configures an interactions endpoint self-test.
+type verifyOptions struct {
+	AppID      string
+	URL        string
+	PublicKey  string
+	PrivateKey string
+	Update     bool
+}
+
+// verifyCheck is the outcome of one verification step.
+type verifyCheck struct {
+	Name   string `json:"name" yaml:"name"`
+	Passed bool   `json:"passed" yaml:"passed"`
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// verifyReport summarizes whether Discord would accept the endpoint.
+type verifyReport struct {
+	URL           string        `json:"url" yaml:"url"`
+	ApplicationID string        `json:"application_id,omitempty" yaml:"application_id,omitempty"`
+	VerifyKey     string        `json:"verify_key,omitempty" yaml:"verify_key,omitempty"`
+	Passed        bool          `json:"passed" yaml:"passed"`
+	Checks        []verifyCheck `json:"checks" yaml:"checks"`
+}
+
+func (r *verifyReport) add(name string, passed bool, detail string) {
+	r.Checks = append(r.Checks, verifyCheck{Name: name, Passed: passed, Detail: detail})
+	if !passed {
+		r.Passed = false
+	}
+}
+
+var errVerifyFailed = errors.New("interactions endpoint verification failed")
+
+func interactionVerifyCmd() *cobra.Command {
+	var (
+		opts    verifyOptions
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check an interactions endpoint the way Discord does before saving it",
+		Long: `Probes the endpoint with the requests Discord sends when the interactions
+endpoint URL is saved: a PING with an invalid signature must be rejected
+with 401, and a correctly signed PING must be answered with a PONG.
+
+Discord's signing key is private, so the signed PING needs --private-key: a
+test key whose public half the endpoint trusts. When a bot token is
+configured, the application's verify key is compared with --public-key (or
+bot.interactions.public_key). --update saves the URL on the application,
+which makes Discord run its own verification.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := getConfig(cmd)
+			if opts.AppID == "" {
+				opts.AppID = cfg.Discord.ApplicationID
+			}
+			if opts.PublicKey == "" {
+				opts.PublicKey = cfg.Bot.Interactions.PublicKey
+			}
+			var rest *client.Client
+			if token, err := cfg.ResolveToken(); err == nil {
+				rest, err = client.New(token)
+				if err != nil {
+					return err
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			report := verifyInteractionEndpoint(ctx, opts, rest, &http.Client{Timeout: timeout})
+			if err := printFormatted(cmd, report); err != nil {
+				return err
+			}
+			if !report.Passed {
+				return errVerifyFailed
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&opts.AppID, "app", "", "application ID (defaults to discord.application_id)")
+	cmd.Flags().StringVar(&opts.URL, "url", "", "https URL of the interactions endpoint")
+	cmd.Flags().StringVar(&opts.PublicKey, "public-key", "", "hex public key the endpoint is configured with")
+	cmd.Flags().StringVar(&opts.PrivateKey, "private-key", "", "hex Ed25519 test key used to sign the PING")
+	cmd.Flags().BoolVar(&opts.Update, "update", false, "save the URL on the application after the local checks pass")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "timeout for the whole verification")
+	_ = cmd.MarkFlagRequired("url")
+	return cmd
+}
+
+// verifyInteractionEndpoint runs the self-test. rest may be nil when no bot
+// token is configured; the application checks and --update are then skipped.
+func verifyInteractionEndpoint(ctx context.Context, opts verifyOptions, rest *client.Client, hc *http.Client) *verifyReport {
+	report := &verifyReport{URL: opts.URL, ApplicationID: opts.AppID, Passed: true}
+
+	parsed, err := url.Parse(opts.URL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		report.add("url", false, "Discord only accepts absolute https URLs")
+		return report
+	}
+	report.add("url", true, "")
+
+	if rest != nil {
+		app, err := rest.Applications().GetCurrentApplication(ctx)
+		switch {
+		case err != nil:
+			report.add("application", false, err.Error())
+		case opts.AppID != "" && app.ID != opts.AppID:
+			report.add("application", false, fmt.Sprintf("bot token belongs to application %s, not %s", app.ID, opts.AppID))
+		default:
+			report.ApplicationID = app.ID
+			report.VerifyKey = app.VerifyKey
+			report.add("application", true, "")
+			if opts.PublicKey != "" {
+				match := strings.EqualFold(strings.TrimSpace(opts.PublicKey), app.VerifyKey)
+				detail := ""
+				if !match {
+					detail = "configured public key does not match the application's verify key; Discord's signatures will be rejected"
+				}
+				report.add("public_key", match, detail)
+			}
+		}
+	}
+
+	ping := []byte(`{"id":"0","application_id":"` + report.ApplicationID + `","type":1,"token":"verify","version":1}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	badSig := hex.EncodeToString(make([]byte, ed25519.SignatureSize))
+	status, _, err := sendPing(ctx, hc, opts.URL, ping, timestamp, badSig)
+	switch {
+	case err != nil:
+		report.add("invalid_signature", false, err.Error())
+	case status != http.StatusUnauthorized:
+		report.add("invalid_signature", false, fmt.Sprintf("expected 401 for a bad signature, got %d", status))
+	default:
+		report.add("invalid_signature", true, "")
+	}
+
+	if opts.PrivateKey != "" {
+		key, err := parsePrivateKey(opts.PrivateKey)
+		if err != nil {
+			report.add("signed_ping", false, err.Error())
+		} else {
+			sig := hex.EncodeToString(ed25519.Sign(key, append([]byte(timestamp), ping...)))
+			status, body, err := sendPing(ctx, hc, opts.URL, ping, timestamp, sig)
+			report.add(signedPingCheck(status, body, err))
+		}
+	}
+
+	if opts.Update {
+		switch {
+		case !report.Passed:
+			report.add("update", false, "skipped because a local check failed")
+		case rest == nil:
+			report.add("update", false, "a bot token is required to update the application")
+		default:
+			endpoint := opts.URL
+			_, err := rest.Applications().EditCurrentApplication(ctx, &types.ApplicationEditParams{InteractionsEndpointURL: &endpoint})
+			if err != nil {
+				report.add("update", false, err.Error())
+			} else {
+				report.add("update", true, "Discord accepted the endpoint")
+			}
+		}
+	}
+	return report
+}
+
+func signedPingCheck(status int, body []byte, err error) (string, bool, string) {
+	if err != nil {
+		return "signed_ping", false, err.Error()
+	}
+	if status == http.StatusUnauthorized {
+		return "signed_ping", false, "endpoint rejected the signature; is it configured with the test key's public half?"
+	}
+	if status != http.StatusOK {
+		return "signed_ping", false, fmt.Sprintf("expected 200, got %d", status)
+	}
+	var resp struct {
+		Type int `json:"type"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Type != int(types.InteractionResponsePong) {
+		return "signed_ping", false, fmt.Sprintf("expected a PONG (type 1), got %s", strings.TrimSpace(string(body)))
+	}
+	return "signed_ping", true, ""
+}
+
+func sendPing(ctx context.Context, hc *http.Client, endpoint string, body []byte, timestamp, signature string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature-Ed25519", signature)
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	return resp.StatusCode, data, err
+}
+
+// parsePrivateKey accepts a hex Ed25519 seed or full private key.
+func parsePrivateKey(value string) (ed25519.PrivateKey, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, fmt.Errorf("invalid private key length: expected %d or %d bytes", ed25519.SeedSize, ed25519.PrivateKeySize)
+	}
 }
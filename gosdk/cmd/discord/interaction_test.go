@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/interactions"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+func TestVerifyInteractionEndpoint(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	pubHex := hex.EncodeToString(pub)
+	server, err := interactions.NewServer(pubHex, interactions.WithLogger(logger.New(logger.ErrorLevel, "json", io.Discard)))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	endpoint := httptest.NewTLSServer(http.HandlerFunc(server.HandleInteraction))
+	defer endpoint.Close()
+
+	var patched bool
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		patched = patched || r.Method == http.MethodPatch
+		io.WriteString(w, `{"id":"42","verify_key":"`+pubHex+`"}`)
+	}))
+	defer api.Close()
+	rest, err := client.New("token", client.WithBaseURL(api.URL), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+
+	opts := verifyOptions{
+		AppID:      "42",
+		URL:        endpoint.URL,
+		PublicKey:  pubHex,
+		PrivateKey: hex.EncodeToString(priv.Seed()),
+		Update:     true,
+	}
+	report := verifyInteractionEndpoint(context.Background(), opts, rest, endpoint.Client())
+	if !report.Passed || !patched || len(report.Checks) != 6 {
+		t.Fatalf("expected passing report with update, got %+v", report)
+	}
+
+	_, other, _ := ed25519.GenerateKey(nil)
+	patched = false
+	opts.PrivateKey = hex.EncodeToString(other)
+	opts.PublicKey = hex.EncodeToString(other.Public().(ed25519.PublicKey))
+	report = verifyInteractionEndpoint(context.Background(), opts, rest, endpoint.Client())
+	if report.Passed || patched {
+		t.Fatalf("expected failure without update, got %+v", report)
+	}
+	failed := map[string]bool{}
+	for _, c := range report.Checks {
+		if !c.Passed {
+			failed[c.Name] = true
+		}
+	}
+	if !failed["public_key"] || !failed["signed_ping"] || !failed["update"] || failed["invalid_signature"] {
+		t.Fatalf("unexpected failing checks %v", failed)
+	}
+
+	report = verifyInteractionEndpoint(context.Background(), verifyOptions{URL: "http://example.com"}, nil, endpoint.Client())
+	if report.Passed || len(report.Checks) != 1 {
+		t.Fatalf("expected https failure, got %+v", report)
+	}
+}
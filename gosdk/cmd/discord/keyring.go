@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService names the CLI's entries in the OS keyring. Entries are
+// keyed by profile name.
+const keyringService = "discord-cli"
+
+var (
+	errKeyringUnavailable = errors.New("no OS keyring available")
+	errSecretNotFound     = errors.New("secret not found in keyring")
+)
+
+// keyring stores secrets outside the config file.
+type keyring interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// openKeyring returns the keyring for this OS. Tests replace it.
+var openKeyring = systemKeyring
+
+// systemKeyring drives the platform's keyring tool: the login keychain via
+// security(1) on macOS, and the Secret Service via secret-tool(1) from
+// libsecret elsewhere. Without either, secrets have to go in the config
+// file.
+func systemKeyring() keyring {
+	switch runtime.GOOS {
+	case "darwin":
+		if path, err := exec.LookPath("security"); err == nil {
+			return macKeychain{path: path}
+		}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if path, err := exec.LookPath("secret-tool"); err == nil {
+			return secretService{path: path}
+		}
+	}
+	return noKeyring{}
+}
+
+// macKeychain stores generic passwords in the login keychain.
+type macKeychain struct{ path string }
+
+func (k macKeychain) Get(account string) (string, error) {
+	out, err := runKeyringTool(k.path, nil, "find-generic-password", "-s", keyringService, "-a", account, "-w")
+	if err != nil {
+		// security exits 44 when the item does not exist.
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && exit.ExitCode() == 44 {
+			return "", errSecretNotFound
+		}
+		return "", err
+	}
+	return strings.TrimRight(out, "\n"), nil
+}
+
+// Set runs add-generic-password through security's interactive mode, which
+// reads the command from stdin, so the secret never shows up in the process
+// list. Interactive mode does not reliably fail when a command does, so the
+// item is read back to confirm it was stored.
+func (k macKeychain) Set(account, secret string) error {
+	command := securityCommand("add-generic-password", "-U", "-s", keyringService, "-a", account, "-l", keyringService+" "+account, "-w", secret)
+	if _, err := runKeyringTool(k.path, strings.NewReader(command), "-i"); err != nil {
+		return err
+	}
+	stored, err := k.Get(account)
+	if err != nil {
+		return err
+	}
+	if stored != secret {
+		return fmt.Errorf("%s add-generic-password: keychain item was not updated", k.path)
+	}
+	return nil
+}
+
+func (k macKeychain) Delete(account string) error {
+	_, err := runKeyringTool(k.path, nil, "delete-generic-password", "-s", keyringService, "-a", account)
+	var exit *exec.ExitError
+	if errors.As(err, &exit) && exit.ExitCode() == 44 {
+		return nil
+	}
+	return err
+}
+
+// securityCommand quotes args as one line for security -i.
+func securityCommand(args ...string) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = `"` + escape.Replace(arg) + `"`
+	}
+	return strings.Join(quoted, " ") + "\n"
+}
+
+// secretService stores secrets through the freedesktop Secret Service,
+// e.g. GNOME Keyring or KWallet. The secret is passed on stdin so it never
+// shows up in the process list.
+type secretService struct{ path string }
+
+func (k secretService) Get(account string) (string, error) {
+	out, err := runKeyringTool(k.path, nil, "lookup", "service", keyringService, "account", account)
+	if err != nil {
+		// lookup exits 1 with no output for missing items.
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && exit.ExitCode() == 1 && out == "" {
+			return "", errSecretNotFound
+		}
+		return "", err
+	}
+	if out == "" {
+		return "", errSecretNotFound
+	}
+	return strings.TrimRight(out, "\n"), nil
+}
+
+func (k secretService) Set(account, secret string) error {
+	_, err := runKeyringTool(k.path, strings.NewReader(secret), "store", "--label", keyringService+" "+account, "service", keyringService, "account", account)
+	return err
+}
+
+func (k secretService) Delete(account string) error {
+	_, err := runKeyringTool(k.path, nil, "clear", "service", keyringService, "account", account)
+	return err
+}
+
+type noKeyring struct{}
+
+func (noKeyring) Get(string) (string, error) { return "", errKeyringUnavailable }
+func (noKeyring) Set(string, string) error   { return errKeyringUnavailable }
+func (noKeyring) Delete(string) error        { return errKeyringUnavailable }
+
+func runKeyringTool(path string, stdin *strings.Reader, args ...string) (string, error) {
+	cmd := exec.Command(path, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%s %s: %w: %s", path, args[0], err, msg)
+		}
+		return stdout.String(), fmt.Errorf("%s %s: %w", path, args[0], err)
+	}
+	return stdout.String(), nil
+}
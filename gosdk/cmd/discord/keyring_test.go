@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeSecretTool is a secret-tool stand-in that keeps one secret per
+// account in dir.
+const fakeSecretTool = `#!/bin/sh
+dir=$(dirname "$0")/store
+mkdir -p "$dir"
+case "$1" in
+store) cat > "$dir/$7" ;;
+lookup) [ -f "$dir/$5" ] || exit 1; cat "$dir/$5" ;;
+clear) rm -f "$dir/$5" ;;
+esac
+`
+
+func TestSecretServiceKeyring(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	tool := filepath.Join(dir, "secret-tool")
+	if err := os.WriteFile(tool, []byte(fakeSecretTool), 0o755); err != nil {
+		t.Fatalf("write fake secret-tool: %v", err)
+	}
+	kr := secretService{path: tool}
+
+	if _, err := kr.Get("work"); !errors.Is(err, errSecretNotFound) {
+		t.Fatalf("Get(missing) error = %v, want errSecretNotFound", err)
+	}
+	if err := kr.Set("work", "s3cret"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, err := kr.Get("work"); err != nil || got != "s3cret" {
+		t.Fatalf("Get() = %q, %v", got, err)
+	}
+	if err := kr.Delete("work"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := kr.Get("work"); !errors.Is(err, errSecretNotFound) {
+		t.Fatalf("Get(deleted) error = %v, want errSecretNotFound", err)
+	}
+}
+
+// fakeSecurity is a security(1) stand-in that logs its arguments and keeps
+// one password per account in dir.
+const fakeSecurity = `#!/bin/sh
+dir=$(dirname "$0")/store
+mkdir -p "$dir"
+echo "$@" >> "$dir/../args"
+if [ "$1" = -i ]; then
+	read -r line
+	eval "set -- $line"
+fi
+case "$1" in
+add-generic-password) printf %s "${10}" > "$dir/$6" ;;
+find-generic-password) [ -f "$dir/$5" ] || exit 44; cat "$dir/$5"; echo ;;
+delete-generic-password) [ -f "$dir/$5" ] || exit 44; rm "$dir/$5" ;;
+esac
+`
+
+func TestMacKeychain(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	tool := filepath.Join(dir, "security")
+	if err := os.WriteFile(tool, []byte(fakeSecurity), 0o755); err != nil {
+		t.Fatalf("write fake security: %v", err)
+	}
+	kr := macKeychain{path: tool}
+
+	if _, err := kr.Get("my work"); !errors.Is(err, errSecretNotFound) {
+		t.Fatalf("Get(missing) error = %v, want errSecretNotFound", err)
+	}
+	if err := kr.Set("my work", `s3c"ret`); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, err := kr.Get("my work"); err != nil || got != `s3c"ret` {
+		t.Fatalf("Get() = %q, %v", got, err)
+	}
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(args), "s3c") {
+		t.Fatalf("secret passed as an argument: %s", args)
+	}
+	if err := kr.Delete("my work"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := kr.Get("my work"); !errors.Is(err, errSecretNotFound) {
+		t.Fatalf("Get(deleted) error = %v, want errSecretNotFound", err)
+	}
+}
+
+func TestNoKeyring(t *testing.T) {
+	if err := (noKeyring{}).Set("a", "b"); !errors.Is(err, errKeyringUnavailable) {
+		t.Fatalf("Set() error = %v", err)
+	}
+}
@@ -9,6 +9,11 @@ func main() {
 	rootCmd.AddCommand(channelCmd())
 	rootCmd.AddCommand(guildCmd())
 	rootCmd.AddCommand(interactionCmd())
+	rootCmd.AddCommand(commandsCmd())
+	rootCmd.AddCommand(gatewayCmd())
+	rootCmd.AddCommand(loginCmd())
+	rootCmd.AddCommand(profileCmd())
+	rootCmd.AddCommand(diagnosticsCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
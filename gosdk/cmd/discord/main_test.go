@@ -2,9 +2,14 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"github.com/mtreilly/godiscord/gosdk/config"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -21,3 +26,58 @@ func TestRootCommand(t *testing.T) {
 		t.Fatalf("expected nil, got %v", err)
 	}
 }
+
+func TestDiagnosticsOmitsSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.Discord.BotToken = "secret-token"
+	cfg.Discord.ApplicationID = "42"
+
+	diag := collectDiagnostics(cfg)
+	if !diag.TokenConfigured || diag.ApplicationID != "42" || diag.SDK.Version == "" {
+		t.Fatalf("unexpected diagnostics %+v", diag)
+	}
+	out, err := json.Marshal(diag)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(out), "secret-token") {
+		t.Fatalf("diagnostics leaked the token: %s", out)
+	}
+}
+
+// runCLI executes the full command tree with args, feeding it stdin.
+func runCLI(t *testing.T, stdin io.Reader, args ...string) (string, error) {
+	t.Helper()
+	root := newRootCommand()
+	root.AddCommand(webhookCmd(), messageCmd(), channelCmd(), guildCmd(), interactionCmd(), commandsCmd(), gatewayCmd(), loginCmd(), profileCmd(), diagnosticsCmd())
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+	root.SetErr(io.Discard)
+	root.SetIn(stdin)
+	root.SetArgs(args)
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestOutputFormats(t *testing.T) {
+	const url = "https://discord.com/api/webhooks/1/token"
+	cases := map[string]string{
+		"json":                             "{\n  \"default_webhook\": \"" + url + "\"\n}\n",
+		"yaml":                             "default_webhook: " + url + "\n\n",
+		"table":                            "default_webhook  " + url + "\n\n",
+		"go-template={{.default_webhook}}": url + "\n",
+	}
+	for format, want := range cases {
+		out, err := runCLI(t, strings.NewReader(""), "--webhook", url, "--output", format, "webhook")
+		if err != nil {
+			t.Fatalf("--output %s error = %v", format, err)
+		}
+		if out != want {
+			t.Fatalf("--output %s = %q, want %q", format, out, want)
+		}
+	}
+
+	if _, err := runCLI(t, strings.NewReader(""), "--output", "xml", "webhook"); err == nil || !strings.Contains(err.Error(), "unknown output format") {
+		t.Fatalf("error = %v, want unknown output format", err)
+	}
+}
@@ -1,14 +1,279 @@
 package main
 
-import "github.com/spf13/cobra"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mtreilly/godiscord/gosdk/cmd/discord/output"
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
 
 func messageCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "message",
-		Short: "Send or edit messages",
+	cmd := &cobra.Command{
+		Use:     "message",
+		Aliases: []string{"messages"},
+		Short:   "Send or edit messages",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg := getConfig(cmd)
 			return printFormatted(cmd, map[string]int{"token_length": len(cfg.Discord.BotToken)})
 		},
 	}
+	cmd.AddCommand(messagePurgeCmd())
+	return cmd
+}
+
+// bulkDeleteMaxAge is the oldest message Discord bulk deletes. Older
+// messages have to be deleted one at a time.
+const bulkDeleteMaxAge = 14 * 24 * time.Hour
+
+// purgeOptions selects the messages messages purge deletes.
+type purgeOptions struct {
+	// Before and After bound the history searched; zero means unbounded.
+	Before    types.Snowflake
+	After     types.Snowflake
+	FromUsers []string
+	// Contains matches content case-insensitively.
+	Contains string
+	// Limit caps how many messages are deleted; 0 means no cap.
+	Limit int
+}
+
+// purgeRow is one matched message in a dry run.
+type purgeRow struct {
+	ID        string    `json:"id" yaml:"id"`
+	Author    string    `json:"author" yaml:"author"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	Content   string    `json:"content" yaml:"content"`
+}
+
+// purgeResult reports what messages purge deleted.
+type purgeResult struct {
+	Matched     int `json:"matched" yaml:"matched"`
+	Deleted     int `json:"deleted" yaml:"deleted"`
+	BulkDeleted int `json:"bulk_deleted" yaml:"bulk_deleted"`
+}
+
+func messagePurgeCmd() *cobra.Command {
+	var (
+		channelID     string
+		before, after string
+		opts          purgeOptions
+		dryRun        bool
+	)
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete messages in a channel matching filters",
+		Long: `Walks a channel's history from newest to oldest and deletes the messages that
+match every filter given. --before and --after take a message ID, an RFC
+3339 time, or an age such as 90m, 48h or 7d. --from-user is repeatable.
+
+Messages under 14 days old are deleted in bulk, 100 at a time; older ones
+are deleted one by one, which is much slower under Discord's rate limits.
+Progress is printed to stderr. --dry-run lists the matching messages
+instead, as a table unless --output is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			var err error
+			if opts.Before, err = parsePurgeBound(before, now); err != nil {
+				return fmt.Errorf("--before: %w", err)
+			}
+			if opts.After, err = parsePurgeBound(after, now); err != nil {
+				return fmt.Errorf("--after: %w", err)
+			}
+			if !opts.Before.IsZero() && !opts.After.IsZero() && !opts.After.Before(opts.Before) {
+				return errors.New("--after must be earlier than --before")
+			}
+			rest, err := newRESTClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			matched, err := findPurgeable(ctx, rest, channelID, opts)
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%d messages match (dry run)\n", len(matched))
+				rows := make([]purgeRow, len(matched))
+				for i, m := range matched {
+					rows[i] = purgeRow{ID: m.ID, Timestamp: m.Timestamp, Content: preview(m.Content)}
+					if m.Author != nil {
+						rows[i].Author = m.Author.Username
+					}
+				}
+				if !cmd.Flags().Changed("output") {
+					return printWith(cmd, output.TableFormatter{}, rows)
+				}
+				return printFormatted(cmd, rows)
+			}
+
+			result, err := purgeMessages(ctx, rest, channelID, matched, now, cmd.ErrOrStderr())
+			if err != nil {
+				return fmt.Errorf("deleted %d of %d messages: %w", result.Deleted, result.Matched, err)
+			}
+			return printFormatted(cmd, result)
+		},
+	}
+	cmd.Flags().StringVar(&channelID, "channel", "", "channel to purge")
+	cmd.Flags().StringVar(&before, "before", "", "only messages older than this message ID, time or age")
+	cmd.Flags().StringVar(&after, "after", "", "only messages newer than this message ID, time or age")
+	cmd.Flags().StringSliceVar(&opts.FromUsers, "from-user", nil, "only messages by these user IDs")
+	cmd.Flags().StringVar(&opts.Contains, "contains", "", "only messages whose content contains this text")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 100, "delete at most this many messages (0 for no limit)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list the matching messages without deleting them")
+	_ = cmd.MarkFlagRequired("channel")
+	return cmd
+}
+
+// parsePurgeBound reads a message ID, an RFC 3339 time, or an age before
+// now. Ages accept Go durations plus whole days, e.g. 7d.
+func parsePurgeBound(value string, now time.Time) (types.Snowflake, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if _, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return types.ParseSnowflake(value)
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return types.SnowflakeFromTime(t), nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return types.SnowflakeFromTime(now.AddDate(0, 0, -n)), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return types.SnowflakeFromTime(now.Add(-d)), nil
+	}
+	return 0, fmt.Errorf("%q is not a message ID, RFC 3339 time or age", value)
+}
+
+// findPurgeable pages backwards through the channel from opts.Before and
+// returns the matching messages, newest first, stopping at opts.After or
+// once opts.Limit messages matched.
+func findPurgeable(ctx context.Context, rest *client.Client, channelID string, opts purgeOptions) ([]*types.Message, error) {
+	params := &client.GetChannelMessagesParams{}
+	if !opts.Before.IsZero() {
+		params.Before = opts.Before.String()
+	}
+	pages, err := rest.Channels().PaginateChannelMessages(channelID, params)
+	if err != nil {
+		return nil, err
+	}
+	defer pages.Close()
+
+	users := make(map[string]bool, len(opts.FromUsers))
+	for _, id := range opts.FromUsers {
+		users[strings.TrimSpace(id)] = true
+	}
+	contains := strings.ToLower(opts.Contains)
+
+	var matched []*types.Message
+	err = pages.ForEach(ctx, func(m *types.Message) error {
+		id, err := types.ParseSnowflake(m.ID)
+		if err != nil {
+			return err
+		}
+		if !opts.After.IsZero() && !id.After(opts.After) {
+			return types.ErrStopIteration
+		}
+		if len(users) > 0 && (m.Author == nil || !users[m.Author.ID]) {
+			return nil
+		}
+		if contains != "" && !strings.Contains(strings.ToLower(m.Content), contains) {
+			return nil
+		}
+		matched = append(matched, m)
+		if opts.Limit > 0 && len(matched) >= opts.Limit {
+			return types.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read channel history: %w", err)
+	}
+	return matched, nil
+}
+
+// purgeMessages deletes msgs, bulk deleting those young enough and the
+// rest one at a time, and writes a progress line to progress after each
+// request. Messages that are already gone count as deleted.
+func purgeMessages(ctx context.Context, rest *client.Client, channelID string, msgs []*types.Message, now time.Time, progress io.Writer) (purgeResult, error) {
+	result := purgeResult{Matched: len(msgs)}
+	report := func() {
+		fmt.Fprintf(progress, "deleted %d/%d messages\n", result.Deleted, result.Matched)
+	}
+
+	// A minute's margin keeps messages near the cutoff from aging out
+	// between the check and the request.
+	cutoff := types.SnowflakeFromTime(now.Add(-bulkDeleteMaxAge + time.Minute))
+	var recent, old []string
+	for _, m := range msgs {
+		if id, err := types.ParseSnowflake(m.ID); err == nil && id.After(cutoff) {
+			recent = append(recent, m.ID)
+		} else {
+			old = append(old, m.ID)
+		}
+	}
+
+	messages := rest.Messages()
+	deleteOne := func(id string) error {
+		if err := messages.DeleteMessage(ctx, channelID, id); err != nil && !types.IsUnknownMessage(err) {
+			return err
+		}
+		result.Deleted++
+		report()
+		return nil
+	}
+
+	for len(recent) > 0 {
+		n := min(len(recent), limits.BulkDeleteMessages)
+		chunk := recent[:n]
+		recent = recent[n:]
+		if len(chunk) == 1 {
+			// Bulk delete needs at least two messages.
+			old = append(old, chunk...)
+			continue
+		}
+		err := messages.BulkDeleteMessages(ctx, channelID, chunk)
+		if errors.Is(err, types.ErrCodeMessageTooOldToBulkDelete) {
+			old = append(old, chunk...)
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Deleted += len(chunk)
+		result.BulkDeleted += len(chunk)
+		report()
+	}
+	for _, id := range old {
+		if err := deleteOne(id); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// preview shortens content to one line for the dry run table.
+func preview(content string) string {
+	content = strings.Join(strings.Fields(content), " ")
+	if r := []rune(content); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return content
 }
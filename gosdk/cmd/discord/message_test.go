@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/discordtest"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// seedHistory fills channel 300 with 150 recent messages alternating
+// between users 1 and 2, every tenth one containing "spam", and three
+// messages by user 1 from three weeks ago.
+func seedHistory(srv *discordtest.Server, now time.Time) {
+	for i := 0; i < 3; i++ {
+		srv.AddMessage(types.Message{ChannelID: "300", Author: &types.User{ID: "1"}, Content: fmt.Sprintf("old %d", i),
+			Timestamp: now.Add(-21*24*time.Hour + time.Duration(i)*time.Minute)})
+	}
+	for i := 0; i < 150; i++ {
+		content := fmt.Sprintf("message %d", i)
+		if i%10 == 0 {
+			content = "Buy SPAM now"
+		}
+		srv.AddMessage(types.Message{ChannelID: "300", Author: &types.User{ID: fmt.Sprint(1 + i%2)}, Content: content,
+			Timestamp: now.Add(-time.Hour + time.Duration(i)*time.Second)})
+	}
+}
+
+func TestPurgeMessages(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	now := time.Now()
+	seedHistory(srv, now)
+	rest := srv.Client("token")
+
+	matched, err := findPurgeable(context.Background(), rest, "300", purgeOptions{FromUsers: []string{"1"}})
+	if err != nil {
+		t.Fatalf("findPurgeable() error = %v", err)
+	}
+	if len(matched) != 78 || matched[0].Content != "message 148" {
+		t.Fatalf("matched %d messages starting %q, want 78 newest first", len(matched), matched[0].Content)
+	}
+
+	var progress bytes.Buffer
+	result, err := purgeMessages(context.Background(), rest, "300", matched, now, &progress)
+	if err != nil {
+		t.Fatalf("purgeMessages() error = %v", err)
+	}
+	if result != (purgeResult{Matched: 78, Deleted: 78, BulkDeleted: 75}) {
+		t.Fatalf("result = %+v", result)
+	}
+	srv.AssertRequestCount(t, "POST", "/channels/{channel}/messages/bulk-delete", 1)
+	srv.AssertRequestCount(t, "DELETE", "/channels/{channel}/messages/{message}", 3)
+	if !strings.HasSuffix(progress.String(), "deleted 78/78 messages\n") {
+		t.Fatalf("progress = %q", progress.String())
+	}
+	for _, m := range srv.Messages("300") {
+		if m.Author.ID == "1" {
+			t.Fatalf("message %q by user 1 survived", m.Content)
+		}
+	}
+}
+
+func TestPurgeMessagesFallsBackToSingleDeletes(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		srv.AddMessage(types.Message{ChannelID: "300", Content: "edge", Timestamp: now.Add(-bulkDeleteMaxAge - 10*time.Minute)})
+	}
+	rest := srv.Client("token")
+	matched, err := findPurgeable(context.Background(), rest, "300", purgeOptions{})
+	if err != nil {
+		t.Fatalf("findPurgeable() error = %v", err)
+	}
+
+	// An hour-old clock makes the messages look young enough to bulk
+	// delete, as a skewed local clock would; the server refuses.
+	result, err := purgeMessages(context.Background(), rest, "300", matched, now.Add(-time.Hour), &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("purgeMessages() error = %v", err)
+	}
+	if result.Deleted != 3 || result.BulkDeleted != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+	srv.AssertRequestCount(t, "POST", "/channels/{channel}/messages/bulk-delete", 1)
+	srv.AssertMessageCount(t, "300", 0)
+}
+
+func TestFindPurgeableFilters(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	now := time.Now()
+	seedHistory(srv, now)
+	rest := srv.Client("token")
+	all := srv.Messages("300")
+
+	matched, err := findPurgeable(context.Background(), rest, "300", purgeOptions{Contains: "spam"})
+	if err != nil || len(matched) != 15 {
+		t.Fatalf("contains: matched %d, err %v; want 15", len(matched), err)
+	}
+
+	before, _ := types.ParseSnowflake(all[103].ID)
+	after, _ := types.ParseSnowflake(all[3].ID)
+	matched, err = findPurgeable(context.Background(), rest, "300", purgeOptions{Before: before, After: after, Limit: 40})
+	if err != nil {
+		t.Fatalf("findPurgeable() error = %v", err)
+	}
+	if len(matched) != 40 || matched[0].ID != all[102].ID || matched[39].ID != all[63].ID {
+		t.Fatalf("bounded: matched %d from %s", len(matched), matched[0].ID)
+	}
+
+	matched, err = findPurgeable(context.Background(), rest, "300", purgeOptions{Before: before, After: after, Limit: 0})
+	if err != nil || len(matched) != 99 {
+		t.Fatalf("bounded without limit: matched %d, err %v; want 99", len(matched), err)
+	}
+}
+
+func TestParsePurgeBound(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"2026-03-01T00:00:00Z": time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		"48h":                  now.Add(-48 * time.Hour),
+		"7d":                   now.AddDate(0, 0, -7),
+	}
+	for value, want := range cases {
+		got, err := parsePurgeBound(value, now)
+		if err != nil || !got.Timestamp().Equal(want) {
+			t.Fatalf("parsePurgeBound(%q) = %v, %v; want %v", value, got.Timestamp(), err, want)
+		}
+	}
+	if got, err := parsePurgeBound("175928847299117063", now); err != nil || got.String() != "175928847299117063" {
+		t.Fatalf("parsePurgeBound(id) = %v, %v", got, err)
+	}
+	if got, err := parsePurgeBound("", now); err != nil || !got.IsZero() {
+		t.Fatalf("parsePurgeBound(\"\") = %v, %v", got, err)
+	}
+	if _, err := parsePurgeBound("last tuesday", now); err == nil {
+		t.Fatal("expected an error for an unparseable bound")
+	}
+}
+
+func TestPurgeRequiresChannel(t *testing.T) {
+	if _, err := runCLI(t, strings.NewReader(""), "--token", "x", "messages", "purge", "--dry-run"); err == nil || !strings.Contains(err.Error(), "channel") {
+		t.Fatalf("error = %v, want missing --channel", err)
+	}
+	if _, err := runCLI(t, strings.NewReader(""), "--token", "x", "messages", "purge", "--channel", "1", "--after", "1h", "--before", "2h"); err == nil || !strings.Contains(err.Error(), "earlier") {
+		t.Fatalf("error = %v, want bound order error", err)
+	}
+}
@@ -2,8 +2,12 @@ package output
 
 import (
 	"bytes"
+	"encoding"
 	"encoding/json"
 	"fmt"
+	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
@@ -15,15 +19,34 @@ type Formatter interface {
 	Format(v interface{}) ([]byte, error)
 }
 
-// NewFormatter returns the requested formatter.
-func NewFormatter(kind string) Formatter {
+// Formats lists the values NewFormatter accepts, for flag help.
+const Formats = "json, yaml, table, go-template=TEMPLATE or go-template-file=PATH"
+
+// NewFormatter returns the formatter for an --output value. Templates are
+// given inline after "go-template=" or read from the file named after
+// "go-template-file=".
+func NewFormatter(kind string) (Formatter, error) {
+	if text, ok := strings.CutPrefix(kind, "go-template="); ok {
+		return NewTemplateFormatter(text)
+	}
+	if path, ok := strings.CutPrefix(kind, "go-template-file="); ok {
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read output template: %w", err)
+		}
+		return NewTemplateFormatter(string(text))
+	}
 	switch strings.ToLower(kind) {
+	case "", "json":
+		return JSONFormatter{}, nil
+	case "yaml", "yml":
+		return YAMLFormatter{}, nil
 	case "table":
-		return TableFormatter{}
-	case "yaml":
-		return YAMLFormatter{}
+		return TableFormatter{}, nil
+	case "go-template", "go-template-file":
+		return nil, fmt.Errorf("output format %s needs a value, e.g. %s='{{.id}}'", kind, kind)
 	default:
-		return JSONFormatter{}
+		return nil, fmt.Errorf("unknown output format %q; use %s", kind, Formats)
 	}
 }
 
@@ -41,24 +64,133 @@ func (YAMLFormatter) Format(v interface{}) ([]byte, error) {
 	return yaml.Marshal(v)
 }
 
-// TableFormatter renders key/value pairs as a simple table.
+// TableFormatter renders values for people. Slices of structs become one
+// row per element under a header of their JSON field names; single structs
+// and maps become sorted key/value rows; other slices one value per line.
 type TableFormatter struct{}
 
 func (TableFormatter) Format(v interface{}) ([]byte, error) {
 	buf := &bytes.Buffer{}
 	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
-	switch data := v.(type) {
-	case map[string]string:
-		for k, val := range data {
-			fmt.Fprintf(w, "%s\t%s\n", k, val)
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch {
+	case writeRows(w, rv):
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			fmt.Fprintf(w, "%s\t%s\n", k.String(), cell(rv.MapIndex(k)))
 		}
-	case map[string]interface{}:
-		for k, val := range data {
-			fmt.Fprintf(w, "%s\t%v\n", k, val)
+	case rv.Kind() == reflect.Struct && !isText(rv):
+		for _, f := range jsonFields(rv.Type()) {
+			fmt.Fprintf(w, "%s\t%s\n", f.name, cell(rv.Field(f.index)))
+		}
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			fmt.Fprintln(w, cell(rv.Index(i)))
 		}
 	default:
-		fmt.Fprintf(w, "%v\n", v)
+		fmt.Fprintln(w, cell(rv))
 	}
 	w.Flush()
 	return buf.Bytes(), nil
 }
+
+// writeRows renders a slice of structs, or of pointers to them, and
+// reports whether v had that shape.
+func writeRows(w *tabwriter.Writer, v reflect.Value) bool {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false
+	}
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return false
+	}
+
+	fields := jsonFields(elem)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = strings.ToUpper(f.name)
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for i := 0; i < v.Len(); i++ {
+		row := reflect.Indirect(v.Index(i))
+		cells := make([]string, len(fields))
+		for j, f := range fields {
+			if row.IsValid() {
+				cells[j] = cell(row.Field(f.index))
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return true
+}
+
+type jsonField struct {
+	index int
+	name  string
+}
+
+// jsonFields lists the exported fields of t under their JSON names.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{index: i, name: name})
+	}
+	return fields
+}
+
+// isText reports whether v renders itself as text, like time.Time.
+func isText(v reflect.Value) bool {
+	_, ok := v.Interface().(encoding.TextMarshaler)
+	return ok
+}
+
+// cell formats a table value, leaving nil pointers blank. Nested structs
+// and maps are written as compact JSON.
+func cell(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if text, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if b, err := text.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = cell(v.Index(i))
+		}
+		return strings.Join(parts, ", ")
+	case reflect.Struct, reflect.Map:
+		if b, err := json.Marshal(v.Interface()); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
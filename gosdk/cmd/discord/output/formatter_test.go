@@ -1,15 +1,108 @@
 package output
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestFormatters(t *testing.T) {
 	data := map[string]string{"foo": "bar", "baz": "qux"}
 	formats := []string{"json", "yaml", "table"}
 	for _, kind := range formats {
-		f := NewFormatter(kind)
+		f, err := NewFormatter(kind)
+		if err != nil {
+			t.Fatalf("NewFormatter(%s) error = %v", kind, err)
+		}
 		out, err := f.Format(data)
 		if err != nil || len(out) == 0 {
 			t.Fatalf("formatter %s failed: %v output=%s", kind, err, out)
 		}
 	}
 }
+
+func TestTableFormatterRows(t *testing.T) {
+	type row struct {
+		Name    string   `json:"name"`
+		ID      *string  `json:"id,omitempty"`
+		Tags    []string `json:"tags"`
+		Private string   `json:"-"`
+	}
+	id := "42"
+	out, err := TableFormatter{}.Format([]*row{{Name: "ping", ID: &id, Tags: []string{"a", "b"}}, {Name: "echo"}})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	want := "NAME  ID  TAGS\nping  42  a, b\necho      \n"
+	if string(out) != want {
+		t.Fatalf("Format() =\n%q\nwant\n%q", out, want)
+	}
+}
+
+func TestNewFormatterErrors(t *testing.T) {
+	for _, kind := range []string{"xml", "go-template", "go-template={{.id", "go-template-file=/does/not/exist"} {
+		if _, err := NewFormatter(kind); err == nil {
+			t.Fatalf("NewFormatter(%q) succeeded", kind)
+		}
+	}
+}
+
+func TestTableFormatterKeyValues(t *testing.T) {
+	type report struct {
+		Name    string            `json:"name"`
+		Count   int               `json:"count"`
+		Started time.Time         `json:"started"`
+		Labels  map[string]string `json:"labels"`
+	}
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	out, err := TableFormatter{}.Format(&report{Name: "bot", Count: 3, Started: started, Labels: map[string]string{"env": "ci"}})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	want := "name     bot\ncount    3\nstarted  2026-01-02T03:04:05Z\nlabels   {\"env\":\"ci\"}\n"
+	if string(out) != want {
+		t.Fatalf("Format(struct) =\n%q\nwant\n%q", out, want)
+	}
+
+	out, _ = TableFormatter{}.Format(map[string]int{"b": 2, "a": 1})
+	if string(out) != "a  1\nb  2\n" {
+		t.Fatalf("Format(map) = %q, want sorted keys", out)
+	}
+	out, _ = TableFormatter{}.Format([]string{"x", "y"})
+	if string(out) != "x\ny\n" {
+		t.Fatalf("Format(slice) = %q", out)
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	type item struct {
+		ID   string   `json:"id"`
+		Size int64    `json:"size"`
+		Tags []string `json:"tags,omitempty"`
+	}
+	items := []item{{ID: "1", Size: 9007199254740993, Tags: []string{"a", "b"}}, {ID: "2"}}
+
+	f, err := NewFormatter(`go-template={{range .}}{{.id}} {{.size}} {{join "," .tags}}{{"\n"}}{{end}}`)
+	if err != nil {
+		t.Fatalf("NewFormatter() error = %v", err)
+	}
+	out, err := f.Format(items)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if string(out) != "1 9007199254740993 a,b\n2 0 " {
+		t.Fatalf("Format() = %q", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "out.tmpl")
+	os.WriteFile(path, []byte(`{{(index . 0).id | upper}} {{json (index . 0).tags}}`), 0o600)
+	f, err = NewFormatter("go-template-file=" + path)
+	if err != nil {
+		t.Fatalf("NewFormatter(file) error = %v", err)
+	}
+	if out, err := f.Format(items); err != nil || !strings.HasPrefix(string(out), `1 ["a","b"]`) {
+		t.Fatalf("Format(file) = %q, %v", out, err)
+	}
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TemplateFormatter renders values with a Go text/template. Values are
+// converted to their JSON form first, so templates use the same field names
+// as the JSON output, e.g. {{.id}} or {{range .}}{{.name}}{{"\n"}}{{end}}.
+type TemplateFormatter struct {
+	tmpl *template.Template
+}
+
+// NewTemplateFormatter parses text. Besides the built-ins, templates can
+// call json, join, upper and lower.
+func NewTemplateFormatter(text string) (*TemplateFormatter, error) {
+	tmpl, err := template.New("output").Option("missingkey=zero").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"join": func(sep string, items any) string {
+			// Missing fields arrive as nil rather than an empty list.
+			list, _ := items.([]any)
+			parts := make([]string, len(list))
+			for i, item := range list {
+				parts[i] = fmt.Sprint(item)
+			}
+			return strings.Join(parts, sep)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse output template: %w", err)
+	}
+	return &TemplateFormatter{tmpl: tmpl}, nil
+}
+
+func (f *TemplateFormatter) Format(v interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var data any
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	// Numbers stay exact instead of becoming float64.
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := f.tmpl.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("execute output template: %w", err)
+	}
+	// Callers end the output with a newline of their own.
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/mtreilly/godiscord/gosdk/config"
+)
+
+// cliConfig is the per-user CLI configuration, separate from the project
+// config files loadConfig discovers. It holds named profiles so switching
+// between bots does not mean juggling tokens in env vars.
+type cliConfig struct {
+	CurrentProfile string              `yaml:"current_profile,omitempty"`
+	Profiles       map[string]*profile `yaml:"profiles,omitempty"`
+}
+
+// profile is one bot's credentials and defaults.
+type profile struct {
+	ApplicationID string            `yaml:"application_id,omitempty"`
+	Webhooks      map[string]string `yaml:"webhooks,omitempty"`
+	// Keyring means the token is in the OS keyring under the profile name.
+	Keyring bool `yaml:"keyring,omitempty"`
+	// Token is set only when the token was saved without a keyring.
+	Token string `yaml:"token,omitempty"`
+}
+
+func (p *profile) tokenStorage() string {
+	switch {
+	case p.Keyring:
+		return "keyring"
+	case p.Token != "":
+		return "file"
+	default:
+		return "none"
+	}
+}
+
+// resolveToken returns the profile's token from wherever it is stored.
+func (p *profile) resolveToken(name string, kr keyring) (string, error) {
+	if p.Keyring {
+		token, err := kr.Get(name)
+		if err != nil {
+			return "", fmt.Errorf("read token for profile %q from keyring: %w", name, err)
+		}
+		return token, nil
+	}
+	return p.Token, nil
+}
+
+// annotationNoProfile marks commands that manage profiles, which must run
+// even when the selected profile is missing or its token unreadable.
+const annotationNoProfile = "discord-cli/no-profile"
+
+// cliConfigPath returns ~/.config/discord-cli/config.yaml, honoring
+// XDG_CONFIG_HOME on every platform.
+func cliConfigPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locate CLI config: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "discord-cli", "config.yaml"), nil
+}
+
+// loadCLIConfig reads path; a missing file is an empty config.
+func loadCLIConfig(path string) (*cliConfig, error) {
+	cfg := &cliConfig{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// save writes the config readable only by the user, replacing the old file
+// atomically.
+func (c *cliConfig) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// applyProfile fills the values cfg leaves unset from the profile named
+// by --profile, or the current profile. Flags still override both.
+func applyProfile(cmd *cobra.Command, cfg *config.Config) error {
+	path, cliCfg, err := openCLIConfig()
+	if err != nil {
+		return err
+	}
+	name := profileName
+	if name == "" {
+		name = cliCfg.CurrentProfile
+	}
+	if name == "" {
+		return nil
+	}
+	p, ok := cliCfg.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found in %s", name, path)
+	}
+
+	if cfg.Discord.ApplicationID == "" {
+		cfg.Discord.ApplicationID = p.ApplicationID
+	}
+	for key, url := range p.Webhooks {
+		if cfg.Discord.Webhooks == nil {
+			cfg.Discord.Webhooks = map[string]string{}
+		}
+		if cfg.Discord.Webhooks[key] == "" {
+			cfg.Discord.Webhooks[key] = url
+		}
+	}
+	if _, err := cfg.ResolveToken(); err != nil && p.tokenStorage() != "none" {
+		token, err := p.resolveToken(name, openKeyring())
+		if err != nil {
+			// Commands that need no token should still work.
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+			return nil
+		}
+		cfg.Discord.BotToken = token
+	}
+	return nil
+}
+
+// loginResult reports where login saved the profile.
+type loginResult struct {
+	Profile      string `json:"profile" yaml:"profile"`
+	TokenStorage string `json:"token_storage" yaml:"token_storage"`
+	Current      bool   `json:"current" yaml:"current"`
+	ConfigPath   string `json:"config_path" yaml:"config_path"`
+}
+
+func loginCmd() *cobra.Command {
+	var (
+		appID    string
+		webhooks map[string]string
+		insecure bool
+	)
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Save a bot token and defaults as a profile",
+		Long: `Saves a profile in ~/.config/discord-cli/config.yaml. The bot token is read
+from stdin (pipe it from a password manager, or type it at the prompt) and
+stored in the OS keyring: the macOS keychain, or the Secret Service via
+secret-tool on Linux. Without a keyring, --insecure-storage keeps the token
+in the config file instead, readable only by you.
+
+The profile is named by --profile and defaults to "default". The first
+profile saved becomes the current one; switch with "discord profile use".`,
+		Annotations: map[string]string{annotationNoProfile: "true"},
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := profileName
+			if name == "" {
+				name = "default"
+			}
+			token := overrideToken
+			if token == "" {
+				var err error
+				if token, err = readToken(cmd); err != nil {
+					return err
+				}
+			}
+
+			path, cliCfg, err := openCLIConfig()
+			if err != nil {
+				return err
+			}
+			p := cliCfg.Profiles[name]
+			if p == nil {
+				p = &profile{}
+			}
+			if appID != "" {
+				p.ApplicationID = appID
+			}
+			for key, url := range webhooks {
+				if p.Webhooks == nil {
+					p.Webhooks = map[string]string{}
+				}
+				p.Webhooks[key] = url
+			}
+
+			if insecure {
+				if p.Keyring {
+					// Best effort: the stale entry is unused either way.
+					_ = openKeyring().Delete(name)
+				}
+				p.Token, p.Keyring = token, false
+			} else {
+				if err := openKeyring().Set(name, token); err != nil {
+					if errors.Is(err, errKeyringUnavailable) {
+						return fmt.Errorf("%w; install secret-tool (libsecret) or pass --insecure-storage to keep the token in %s", err, path)
+					}
+					return fmt.Errorf("save token in keyring: %w", err)
+				}
+				p.Token, p.Keyring = "", true
+			}
+
+			if cliCfg.Profiles == nil {
+				cliCfg.Profiles = map[string]*profile{}
+			}
+			cliCfg.Profiles[name] = p
+			if cliCfg.CurrentProfile == "" {
+				cliCfg.CurrentProfile = name
+			}
+			if err := cliCfg.save(path); err != nil {
+				return err
+			}
+			return printFormatted(cmd, loginResult{
+				Profile:      name,
+				TokenStorage: p.tokenStorage(),
+				Current:      cliCfg.CurrentProfile == name,
+				ConfigPath:   path,
+			})
+		},
+	}
+	cmd.Flags().StringVar(&appID, "app-id", "", "application ID to use with this profile")
+	cmd.Flags().StringToStringVar(&webhooks, "webhooks", nil, "webhook URLs by name, e.g. default=https://discord.com/api/webhooks/...")
+	cmd.Flags().BoolVar(&insecure, "insecure-storage", false, "store the token in the config file instead of the OS keyring")
+	return cmd
+}
+
+// readToken reads the token from the first line of stdin, prompting when
+// stdin is a terminal.
+func readToken(cmd *cobra.Command) (string, error) {
+	in := cmd.InOrStdin()
+	if !stdinPiped(in) {
+		fmt.Fprint(cmd.ErrOrStderr(), "Bot token: ")
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("read token: %w", err)
+	}
+	token := strings.TrimSpace(line)
+	if token == "" {
+		return "", errors.New("no token given on stdin")
+	}
+	return token, nil
+}
+
+// profileRow is one line of profile list.
+type profileRow struct {
+	Name          string `json:"name" yaml:"name"`
+	Current       bool   `json:"current" yaml:"current"`
+	ApplicationID string `json:"application_id,omitempty" yaml:"application_id,omitempty"`
+	Token         string `json:"token" yaml:"token"`
+	Webhooks      int    `json:"webhooks" yaml:"webhooks"`
+}
+
+func profileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "profile",
+		Aliases:     []string{"profiles"},
+		Short:       "Manage CLI profiles",
+		Annotations: map[string]string{annotationNoProfile: "true"},
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:         "list",
+			Short:       "List saved profiles",
+			Annotations: map[string]string{annotationNoProfile: "true"},
+			Args:        cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_, cliCfg, err := openCLIConfig()
+				if err != nil {
+					return err
+				}
+				names := make([]string, 0, len(cliCfg.Profiles))
+				for name := range cliCfg.Profiles {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				rows := make([]profileRow, 0, len(names))
+				for _, name := range names {
+					p := cliCfg.Profiles[name]
+					rows = append(rows, profileRow{
+						Name:          name,
+						Current:       name == cliCfg.CurrentProfile,
+						ApplicationID: p.ApplicationID,
+						Token:         p.tokenStorage(),
+						Webhooks:      len(p.Webhooks),
+					})
+				}
+				return printFormatted(cmd, rows)
+			},
+		},
+		&cobra.Command{
+			Use:         "use <name>",
+			Short:       "Make a profile the current one",
+			Annotations: map[string]string{annotationNoProfile: "true"},
+			Args:        cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				path, cliCfg, err := openCLIConfig()
+				if err != nil {
+					return err
+				}
+				if _, ok := cliCfg.Profiles[args[0]]; !ok {
+					return fmt.Errorf("profile %q not found; create it with discord login --profile %s", args[0], args[0])
+				}
+				cliCfg.CurrentProfile = args[0]
+				if err := cliCfg.save(path); err != nil {
+					return err
+				}
+				return printFormatted(cmd, map[string]string{"current_profile": args[0]})
+			},
+		},
+		&cobra.Command{
+			Use:         "remove <name>",
+			Aliases:     []string{"rm", "delete"},
+			Short:       "Delete a profile and its keyring entry",
+			Annotations: map[string]string{annotationNoProfile: "true"},
+			Args:        cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				path, cliCfg, err := openCLIConfig()
+				if err != nil {
+					return err
+				}
+				p, ok := cliCfg.Profiles[args[0]]
+				if !ok {
+					return fmt.Errorf("profile %q not found", args[0])
+				}
+				if p.Keyring {
+					if err := openKeyring().Delete(args[0]); err != nil {
+						return fmt.Errorf("remove token from keyring: %w", err)
+					}
+				}
+				delete(cliCfg.Profiles, args[0])
+				if cliCfg.CurrentProfile == args[0] {
+					cliCfg.CurrentProfile = ""
+				}
+				if err := cliCfg.save(path); err != nil {
+					return err
+				}
+				return printFormatted(cmd, map[string]string{"removed": args[0]})
+			},
+		},
+	)
+	return cmd
+}
+
+func openCLIConfig() (string, *cliConfig, error) {
+	path, err := cliConfigPath()
+	if err != nil {
+		return "", nil, err
+	}
+	cliCfg, err := loadCLIConfig(path)
+	return path, cliCfg, err
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// memoryKeyring is an in-process keyring for tests.
+type memoryKeyring map[string]string
+
+func (k memoryKeyring) Get(account string) (string, error) {
+	secret, ok := k[account]
+	if !ok {
+		return "", errSecretNotFound
+	}
+	return secret, nil
+}
+
+func (k memoryKeyring) Set(account, secret string) error {
+	k[account] = secret
+	return nil
+}
+
+func (k memoryKeyring) Delete(account string) error {
+	delete(k, account)
+	return nil
+}
+
+func useTestKeyring(t *testing.T, kr keyring) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	prev := openKeyring
+	openKeyring = func() keyring { return kr }
+	t.Cleanup(func() { openKeyring = prev })
+}
+
+func TestLoginStoresTokenInKeyring(t *testing.T) {
+	kr := memoryKeyring{}
+	useTestKeyring(t, kr)
+
+	out, err := runCLI(t, strings.NewReader("bot-token\n"), "login", "--app-id", "42", "--webhooks", "default=https://discord.com/api/webhooks/1/abc")
+	if err != nil {
+		t.Fatalf("login error = %v", err)
+	}
+	var result loginResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("output %q: %v", out, err)
+	}
+	if result.Profile != "default" || result.TokenStorage != "keyring" || !result.Current {
+		t.Fatalf("result = %+v", result)
+	}
+	if kr["default"] != "bot-token" {
+		t.Fatalf("keyring = %v", kr)
+	}
+
+	data, err := os.ReadFile(result.ConfigPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if strings.Contains(string(data), "bot-token") {
+		t.Fatalf("config file holds the token:\n%s", data)
+	}
+	if info, _ := os.Stat(result.ConfigPath); info.Mode().Perm() != 0o600 {
+		t.Fatalf("config mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	out, err = runCLI(t, strings.NewReader(""), "diagnostics")
+	if err != nil {
+		t.Fatalf("diagnostics error = %v", err)
+	}
+	var diag diagnostics
+	json.Unmarshal([]byte(out), &diag)
+	if !diag.TokenConfigured || diag.ApplicationID != "42" {
+		t.Fatalf("profile not applied: %+v", diag)
+	}
+	out, _ = runCLI(t, strings.NewReader(""), "webhook")
+	if !strings.Contains(out, "https://discord.com/api/webhooks/1/abc") {
+		t.Fatalf("profile webhook not applied: %s", out)
+	}
+}
+
+func TestProfileUseAndRemove(t *testing.T) {
+	kr := memoryKeyring{}
+	useTestKeyring(t, kr)
+
+	if _, err := runCLI(t, strings.NewReader("one\n"), "login", "--profile", "one", "--app-id", "1"); err != nil {
+		t.Fatalf("login one: %v", err)
+	}
+	if _, err := runCLI(t, strings.NewReader("two\n"), "login", "--profile", "two", "--app-id", "2", "--insecure-storage"); err != nil {
+		t.Fatalf("login two: %v", err)
+	}
+
+	out, err := runCLI(t, strings.NewReader(""), "profile", "list")
+	if err != nil {
+		t.Fatalf("profile list: %v", err)
+	}
+	var rows []profileRow
+	json.Unmarshal([]byte(out), &rows)
+	if len(rows) != 2 || !rows[0].Current || rows[0].Token != "keyring" || rows[1].Current || rows[1].Token != "file" {
+		t.Fatalf("rows = %+v", rows)
+	}
+
+	if _, err := runCLI(t, strings.NewReader(""), "profile", "use", "two"); err != nil {
+		t.Fatalf("profile use: %v", err)
+	}
+	out, _ = runCLI(t, strings.NewReader(""), "diagnostics")
+	var diag diagnostics
+	json.Unmarshal([]byte(out), &diag)
+	if diag.ApplicationID != "2" || !diag.TokenConfigured {
+		t.Fatalf("current profile not applied: %+v", diag)
+	}
+
+	out, _ = runCLI(t, strings.NewReader(""), "--profile", "one", "diagnostics")
+	json.Unmarshal([]byte(out), &diag)
+	if diag.ApplicationID != "1" {
+		t.Fatalf("--profile not applied: %+v", diag)
+	}
+
+	if _, err := runCLI(t, strings.NewReader(""), "profile", "use", "missing"); err == nil {
+		t.Fatal("expected error switching to a missing profile")
+	}
+	if _, err := runCLI(t, strings.NewReader(""), "--profile", "missing", "diagnostics"); err == nil {
+		t.Fatal("expected error for a missing --profile")
+	}
+
+	if _, err := runCLI(t, strings.NewReader(""), "profile", "remove", "one"); err != nil {
+		t.Fatalf("profile remove: %v", err)
+	}
+	if _, ok := kr["one"]; ok {
+		t.Fatal("keyring entry not removed")
+	}
+}
+
+func TestProfileFillsOnlyUnsetValues(t *testing.T) {
+	useTestKeyring(t, memoryKeyring{})
+	if _, err := runCLI(t, strings.NewReader("profile-token\n"), "login", "--app-id", "1"); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	dir := t.TempDir()
+	project := filepath.Join(dir, "discord.yaml")
+	os.WriteFile(project, []byte("discord:\n  application_id: \"99\"\n"), 0o600)
+	out, err := runCLI(t, strings.NewReader(""), "--config", project, "diagnostics")
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	var diag diagnostics
+	json.Unmarshal([]byte(out), &diag)
+	if diag.ApplicationID != "99" || !diag.TokenConfigured {
+		t.Fatalf("diag = %+v, want project app ID and profile token", diag)
+	}
+}
+
+func TestLoginWithoutKeyring(t *testing.T) {
+	useTestKeyring(t, noKeyring{})
+	_, err := runCLI(t, strings.NewReader("token\n"), "login")
+	if err == nil || !strings.Contains(err.Error(), "--insecure-storage") {
+		t.Fatalf("error = %v, want hint about --insecure-storage", err)
+	}
+	if _, err := runCLI(t, strings.NewReader(""), "login"); err == nil {
+		t.Fatal("expected error for an empty token")
+	}
+}
@@ -1,6 +1,21 @@
 package main
 
-import "github.com/spf13/cobra"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/webhook"
+)
 
 func webhookCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -11,5 +26,193 @@ func webhookCmd() *cobra.Command {
 			return printFormatted(cmd, map[string]string{"default_webhook": cfg.Discord.Webhooks["default"]})
 		},
 	}
+	cmd.AddCommand(webhookSendCmd())
 	return cmd
 }
+
+// webhookSendOptions describes a message built from flags.
+type webhookSendOptions struct {
+	Content    string
+	EmbedJSON  []string
+	EmbedFiles []string
+	Files      []string
+	ThreadID   string
+	ThreadName string
+	Username   string
+	AvatarURL  string
+	Wait       bool
+}
+
+// webhookSendResult is printed for --wait.
+type webhookSendResult struct {
+	ID        string `json:"id" yaml:"id"`
+	ChannelID string `json:"channel_id" yaml:"channel_id"`
+}
+
+func webhookSendCmd() *cobra.Command {
+	var (
+		opts webhookSendOptions
+		name string
+	)
+	cmd := &cobra.Command{
+		Use:   "send",
+		Short: "Send a message through a webhook",
+		Long: `Sends a message through the webhook configured as discord.webhooks.<name>
+(or --webhook). The content comes from --content, or from stdin when
+--content is "-", or when stdin is piped and nothing else was given:
+
+  make test 2>&1 | tail -20 | discord webhook send --username CI
+
+--embed-json and --embed-file take an embed object or an array of them and
+may be repeated, as may --file. With --wait, Discord confirms the send and
+the created message ID is printed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := getConfig(cmd)
+			url := cfg.Discord.Webhooks[name]
+			if url == "" {
+				return fmt.Errorf("no webhook named %q configured; pass --webhook or set discord.webhooks.%s", name, name)
+			}
+			wh, err := webhook.NewClient(url)
+			if err != nil {
+				return err
+			}
+
+			implicit := opts.Content == "" && len(opts.EmbedJSON) == 0 && len(opts.EmbedFiles) == 0 && len(opts.Files) == 0
+			if opts.Content == "-" || (implicit && stdinPiped(cmd.InOrStdin())) {
+				data, err := io.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("read stdin: %w", err)
+				}
+				opts.Content = strings.TrimRight(string(data), "\n")
+			}
+
+			msg, err := sendWebhook(cmd.Context(), wh, opts)
+			if err != nil {
+				return err
+			}
+			if msg == nil {
+				return nil
+			}
+			return printFormatted(cmd, webhookSendResult{ID: msg.ID, ChannelID: msg.ChannelID})
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&name, "name", "default", "configured webhook to send through")
+	flags.StringVar(&opts.Content, "content", "", `message content; "-" reads stdin`)
+	flags.StringArrayVar(&opts.EmbedJSON, "embed-json", nil, "embed as JSON (object or array, repeatable)")
+	flags.StringArrayVar(&opts.EmbedFiles, "embed-file", nil, "file holding embed JSON (object or array, repeatable)")
+	flags.StringArrayVar(&opts.Files, "file", nil, "file to upload (repeatable)")
+	flags.StringVar(&opts.ThreadID, "thread-id", "", "send into an existing thread")
+	flags.StringVar(&opts.ThreadName, "thread-name", "", "create a forum post with this name")
+	flags.StringVar(&opts.Username, "username", "", "override the webhook's username")
+	flags.StringVar(&opts.AvatarURL, "avatar", "", "override the webhook's avatar URL")
+	flags.BoolVar(&opts.Wait, "wait", false, "wait for Discord to create the message and print its ID")
+	cmd.MarkFlagsMutuallyExclusive("thread-id", "thread-name")
+	return cmd
+}
+
+// sendWebhook builds the message described by opts and sends it. The
+// created message is returned only when opts.Wait is set.
+func sendWebhook(ctx context.Context, wh *webhook.Client, opts webhookSendOptions) (*types.Message, error) {
+	msg := &types.WebhookMessage{
+		Content:    opts.Content,
+		Username:   opts.Username,
+		AvatarURL:  opts.AvatarURL,
+		ThreadID:   opts.ThreadID,
+		ThreadName: opts.ThreadName,
+	}
+	for _, raw := range opts.EmbedJSON {
+		embeds, err := parseEmbeds([]byte(raw))
+		if err != nil {
+			return nil, fmt.Errorf("--embed-json: %w", err)
+		}
+		msg.Embeds = append(msg.Embeds, embeds...)
+	}
+	for _, path := range opts.EmbedFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		embeds, err := parseEmbeds(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		msg.Embeds = append(msg.Embeds, embeds...)
+	}
+
+	files, closeFiles, err := openAttachments(opts.Files)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFiles()
+
+	switch {
+	case len(files) > 0 && opts.Wait:
+		return wh.SendWithFilesWait(ctx, msg, files)
+	case len(files) > 0:
+		return nil, wh.SendWithFiles(ctx, msg, files)
+	case opts.Wait:
+		return wh.SendWait(ctx, msg)
+	default:
+		return nil, wh.Send(ctx, msg)
+	}
+}
+
+// parseEmbeds decodes a single embed object or an array of them.
+func parseEmbeds(data []byte) ([]types.Embed, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var embeds []types.Embed
+		if err := json.Unmarshal(data, &embeds); err != nil {
+			return nil, fmt.Errorf("invalid embed JSON: %w", err)
+		}
+		return embeds, nil
+	}
+	var embed types.Embed
+	if err := json.Unmarshal(data, &embed); err != nil {
+		return nil, fmt.Errorf("invalid embed JSON: %w", err)
+	}
+	return []types.Embed{embed}, nil
+}
+
+// openAttachments opens paths for upload. The returned func closes them.
+func openAttachments(paths []string) ([]webhook.FileAttachment, func(), error) {
+	var files []*os.File
+	closeAll := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+	attachments := make([]webhook.FileAttachment, 0, len(paths))
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		files = append(files, f)
+		info, err := f.Stat()
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		attachments = append(attachments, webhook.FileAttachment{
+			Name:        filepath.Base(path),
+			ContentType: mime.TypeByExtension(filepath.Ext(path)),
+			Reader:      f,
+			Size:        info.Size(),
+		})
+	}
+	return attachments, closeAll, nil
+}
+
+// stdinPiped reports whether in is redirected rather than a terminal.
+func stdinPiped(in io.Reader) bool {
+	f, ok := in.(*os.File)
+	if !ok {
+		return in != nil
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
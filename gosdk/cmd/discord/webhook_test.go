@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/discordtest"
+)
+
+func TestWebhookSend(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	srv.AddWebhook("100", "secret", "200")
+	url := srv.WebhookURL("100", "secret")
+
+	dir := t.TempDir()
+	embedFile := filepath.Join(dir, "embeds.json")
+	os.WriteFile(embedFile, []byte(`[{"title":"second"},{"title":"third"}]`), 0o600)
+	report := filepath.Join(dir, "report.txt")
+	os.WriteFile(report, []byte("all green"), 0o600)
+
+	out, err := runCLI(t, strings.NewReader(""), "--webhook", url, "webhook", "send",
+		"--content", "deployed",
+		"--embed-json", `{"title":"first"}`,
+		"--embed-file", embedFile,
+		"--file", report,
+		"--username", "CI",
+		"--wait",
+	)
+	if err != nil {
+		t.Fatalf("webhook send error = %v", err)
+	}
+	var result webhookSendResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("output %q is not JSON: %v", out, err)
+	}
+
+	msg := srv.AssertMessage(t, "200", "deployed")
+	if result.ID != msg.ID || result.ChannelID != "200" {
+		t.Fatalf("result = %+v, want message %s", result, msg.ID)
+	}
+	if len(msg.Embeds) != 3 || msg.Embeds[2].Title != "third" {
+		t.Fatalf("embeds = %+v", msg.Embeds)
+	}
+	if len(msg.Attachments) != 1 || msg.Attachments[0].Filename != "report.txt" {
+		t.Fatalf("attachments = %+v", msg.Attachments)
+	}
+	if msg.Author == nil || msg.Author.Username != "CI" {
+		t.Fatalf("author = %+v, want CI override", msg.Author)
+	}
+}
+
+func TestWebhookSendReadsStdin(t *testing.T) {
+	srv := discordtest.NewServer(t)
+	srv.AddWebhook("100", "secret", "200")
+	url := srv.WebhookURL("100", "secret")
+
+	out, err := runCLI(t, strings.NewReader("build log\n"), "--webhook", url, "webhook", "send", "--thread-id", "300")
+	if err != nil {
+		t.Fatalf("webhook send error = %v", err)
+	}
+	if out != "" {
+		t.Fatalf("output without --wait = %q, want none", out)
+	}
+	srv.AssertMessage(t, "300", "build log")
+	req := srv.AssertRequest(t, "POST", "/webhooks/100/secret")
+	if req.Query.Get("thread_id") != "300" || req.Query.Get("wait") != "" {
+		t.Fatalf("query = %v", req.Query)
+	}
+
+	if _, err := runCLI(t, strings.NewReader("ignored"), "--webhook", url, "webhook", "send", "--embed-json", `{"title":"only"}`); err != nil {
+		t.Fatalf("webhook send error = %v", err)
+	}
+	srv.AssertMessageCount(t, "200", 1)
+	if msgs := srv.Messages("200"); msgs[0].Content != "" {
+		t.Fatalf("stdin read despite an embed: %q", msgs[0].Content)
+	}
+}
+
+func TestWebhookSendErrors(t *testing.T) {
+	if _, err := runCLI(t, strings.NewReader(""), "webhook", "send", "--content", "hi"); err == nil || !strings.Contains(err.Error(), "no webhook") {
+		t.Fatalf("error = %v, want missing webhook", err)
+	}
+
+	srv := discordtest.NewServer(t)
+	url := srv.WebhookURL("100", "secret")
+	if _, err := runCLI(t, strings.NewReader(""), "--webhook", url, "webhook", "send", "--embed-json", "{"); err == nil || !strings.Contains(err.Error(), "embed") {
+		t.Fatalf("error = %v, want embed error", err)
+	}
+	if _, err := runCLI(t, strings.NewReader(""), "--webhook", url, "webhook", "send", "--content", "x", "--thread-id", "1", "--thread-name", "t"); err == nil {
+		t.Fatal("expected --thread-id and --thread-name to conflict")
+	}
+	srv.AssertNoRequest(t, "POST", "/webhooks/{id}/{token}")
+}
+
+func TestParseEmbeds(t *testing.T) {
+	embeds, err := parseEmbeds([]byte(" {\"title\":\"a\"}\n"))
+	if err != nil || len(embeds) != 1 || embeds[0].Title != "a" {
+		t.Fatalf("parseEmbeds(object) = %+v, %v", embeds, err)
+	}
+	embeds, err = parseEmbeds([]byte(`[{"title":"a"},{"title":"b"}]`))
+	if err != nil || len(embeds) != 2 {
+		t.Fatalf("parseEmbeds(array) = %+v, %v", embeds, err)
+	}
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServiceDef describes a REST service in the route DSL.
+type ServiceDef struct {
+	// Service is the Go type name; it also names the Client accessor.
+	Service string `yaml:"service"`
+	// Receiver is the method receiver name (default: lowercase first letter).
+	Receiver string `yaml:"receiver"`
+	// Doc completes "<Service> exposes ...".
+	Doc       string        `yaml:"doc"`
+	Endpoints []EndpointDef `yaml:"endpoints"`
+}
+
+// EndpointDef describes a single route.
+type EndpointDef struct {
+	// Name is the method name.
+	Name string `yaml:"name"`
+	// Doc completes "<Name> ...".
+	Doc string `yaml:"doc"`
+	// Method is the HTTP method (default GET).
+	Method string `yaml:"method"`
+	// Path is the route with {param} placeholders, e.g. /guilds/{guildID}/stickers.
+	Path string `yaml:"path"`
+	// Body is the request payload type, e.g. types.GuildStickerModifyParams.
+	Body string `yaml:"body"`
+	// Validate calls the body's Validate method before sending.
+	Validate bool `yaml:"validate"`
+	// Audit sends the body's AuditLogReason as X-Audit-Log-Reason.
+	Audit bool `yaml:"audit"`
+	// Reason adds a reason argument sent as X-Audit-Log-Reason.
+	Reason bool `yaml:"reason"`
+	// Response is the decoded type: types.X returns *types.X, []*types.X a slice.
+	Response string `yaml:"response"`
+	// TestBody is a Go expression for a valid body used by the generated test.
+	TestBody string `yaml:"test_body"`
+}
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+	identPattern       = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	typePattern        = regexp.MustCompile(`^(\[\]\*?)?([A-Za-z_][A-Za-z0-9_]*\.)?[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+var httpMethods = map[string]string{
+	"GET":    "http.MethodGet",
+	"POST":   "http.MethodPost",
+	"PUT":    "http.MethodPut",
+	"PATCH":  "http.MethodPatch",
+	"DELETE": "http.MethodDelete",
+}
+
+// ParseService decodes and checks a route definition file.
+func ParseService(r io.Reader) (*ServiceDef, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	var def ServiceDef
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("decode routes: %w", err)
+	}
+	if err := def.normalize(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+func (s *ServiceDef) normalize() error {
+	if !identPattern.MatchString(s.Service) {
+		return fmt.Errorf("service: invalid name %q", s.Service)
+	}
+	if s.Receiver == "" {
+		s.Receiver = strings.ToLower(s.Service[:1])
+	}
+	if len(s.Endpoints) == 0 {
+		return fmt.Errorf("service %s: no endpoints", s.Service)
+	}
+	seen := make(map[string]bool)
+	for i := range s.Endpoints {
+		e := &s.Endpoints[i]
+		if !identPattern.MatchString(e.Name) {
+			return fmt.Errorf("endpoint %d: invalid name %q", i, e.Name)
+		}
+		if seen[e.Name] {
+			return fmt.Errorf("endpoint %s: defined twice", e.Name)
+		}
+		seen[e.Name] = true
+		e.Method = strings.ToUpper(e.Method)
+		if e.Method == "" {
+			e.Method = http.MethodGet
+		}
+		if _, ok := httpMethods[e.Method]; !ok {
+			return fmt.Errorf("endpoint %s: unsupported method %q", e.Name, e.Method)
+		}
+		if !strings.HasPrefix(e.Path, "/") {
+			return fmt.Errorf("endpoint %s: path must start with /", e.Name)
+		}
+		for _, typ := range []string{e.Body, e.Response} {
+			if typ != "" && !typePattern.MatchString(typ) {
+				return fmt.Errorf("endpoint %s: invalid type %q", e.Name, typ)
+			}
+		}
+		if e.Body != "" && strings.HasPrefix(e.Body, "[]") {
+			return fmt.Errorf("endpoint %s: body must be a struct type", e.Name)
+		}
+		if e.Body == "" && (e.Validate || e.Audit) {
+			return fmt.Errorf("endpoint %s: validate and audit require a body", e.Name)
+		}
+		if e.Audit && e.Reason {
+			return fmt.Errorf("endpoint %s: audit and reason are exclusive", e.Name)
+		}
+	}
+	return nil
+}
+
+// endpointView is the template model for one endpoint.
+type endpointView struct {
+	EndpointDef
+	Params      []string
+	Args        string
+	MethodConst string
+	PathExpr    string
+	TestPath    string
+	TestArgs    string
+	Slice       bool
+	OutType     string
+	ReturnType  string
+}
+
+func (s *ServiceDef) views() []endpointView {
+	views := make([]endpointView, len(s.Endpoints))
+	for i, e := range s.Endpoints {
+		v := endpointView{EndpointDef: e, MethodConst: httpMethods[e.Method]}
+
+		format := e.Path
+		testPath := e.Path
+		var args []string
+		for n, m := range placeholderPattern.FindAllStringSubmatch(e.Path, -1) {
+			v.Params = append(v.Params, m[1])
+			format = strings.Replace(format, m[0], "%s", 1)
+			testPath = strings.Replace(testPath, m[0], fmt.Sprint(n+1), 1)
+			args = append(args, fmt.Sprintf("%q", fmt.Sprint(n+1)))
+		}
+		if len(v.Params) > 0 {
+			v.PathExpr = fmt.Sprintf("fmt.Sprintf(%q, %s)", format, strings.Join(v.Params, ", "))
+		} else {
+			v.PathExpr = fmt.Sprintf("%q", format)
+		}
+		v.TestPath = testPath
+
+		strArgs := append([]string{}, v.Params...)
+		if e.Reason {
+			strArgs = append(strArgs, "reason")
+		}
+		var sig []string
+		if len(strArgs) > 0 {
+			sig = append(sig, strings.Join(strArgs, ", ")+" string")
+		}
+		if e.Body != "" {
+			sig = append(sig, "params *"+e.Body)
+		}
+		v.Args = strings.Join(sig, ", ")
+
+		if e.Reason {
+			args = append(args, `"cleanup"`)
+		}
+		if e.Body != "" {
+			body := e.TestBody
+			if body == "" {
+				body = "&" + e.Body + "{}"
+			}
+			args = append(args, body)
+		}
+		v.TestArgs = strings.Join(args, ", ")
+
+		if e.Response != "" {
+			v.OutType = e.Response
+			v.ReturnType = "*" + e.Response
+			if v.Slice = strings.HasPrefix(e.Response, "[]"); v.Slice {
+				v.ReturnType = e.Response
+			}
+		}
+		views[i] = v
+	}
+	return views
+}
+
+type fileView struct {
+	Source   string
+	Service  *ServiceDef
+	Views    []endpointView
+	NeedsFmt bool
+	Types    bool
+	// Validates is set when any endpoint has arguments to validate.
+	Validates bool
+}
+
+func (s *ServiceDef) fileView(source string) fileView {
+	fv := fileView{Source: source, Service: s, Views: s.views()}
+	for _, v := range fv.Views {
+		if len(v.Params) > 0 {
+			fv.NeedsFmt = true
+		}
+		if len(v.Params) > 0 || v.Body != "" {
+			fv.Validates = true
+		}
+		if v.Body != "" || strings.Contains(v.Response, "types.") {
+			fv.Types = true
+		}
+	}
+	return fv
+}
+
+// Generate renders the service file and its test file, both gofmt'd.
+func Generate(def *ServiceDef, source string) (service, test []byte, err error) {
+	fv := def.fileView(source)
+	if service, err = render(serviceTemplate, fv); err != nil {
+		return nil, nil, fmt.Errorf("service: %w", err)
+	}
+	if test, err = render(testTemplate, fv); err != nil {
+		return nil, nil, fmt.Errorf("test: %w", err)
+	}
+	return service, test, nil
+}
+
+func render(tmpl *template.Template, data fileView) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, buf.Bytes())
+	}
+	return out, nil
+}
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"lowerFirst": func(s string) string {
+		return strings.ToLower(s[:1]) + s[1:]
+	},
+}
+
+var serviceTemplate = template.Must(template.New("service").Funcs(funcs).Parse(`// Code generated by routegen from {{.Source}}; DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+{{- if .NeedsFmt}}
+	"fmt"
+{{- end}}
+	"net/http"
+{{if .Types}}
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+{{- end}}
+)
+
+{{$svc := .Service -}}
+// {{$svc.Service}} {{$svc.Doc}}
+type {{$svc.Service}} struct {
+	client *Client
+}
+
+// {{$svc.Service}} returns a {{lowerFirst $svc.Service}} service bound to the Client.
+func (c *Client) {{$svc.Service}}() *{{$svc.Service}} {
+	return &{{$svc.Service}}{client: c}
+}
+{{range .Views}}
+// {{.Name}} {{.Doc}}
+func ({{$svc.Receiver}} *{{$svc.Service}}) {{.Name}}(ctx context.Context{{if .Args}}, {{.Args}}{{end}}) {{if .ReturnType}}({{.ReturnType}}, error){{else}}error{{end}} {
+{{- $zero := "" }}{{if .ReturnType}}{{$zero = "nil, "}}{{end}}
+{{- range .Params}}
+	if err := validateID("{{.}}", {{.}}); err != nil {
+		return {{$zero}}err
+	}
+{{- end}}
+{{- if .Body}}
+	if params == nil {
+		return {{$zero}}&types.ValidationError{Field: "params", Message: "params are required"}
+	}
+{{- end}}
+{{- if .Validate}}
+	if err := params.Validate(); err != nil {
+		return {{$zero}}err
+	}
+{{- end}}
+
+{{- $headers := "nil"}}{{if .Audit}}{{$headers = "auditHeaders(params.AuditLogReason)"}}{{else if .Reason}}{{$headers = "auditHeaders(reason)"}}{{end}}
+{{- $body := "nil"}}{{if .Body}}{{$body = "params"}}{{end}}
+{{- if or .Params .Body}}
+{{end}}
+{{- if .ReturnType}}
+	var out {{.OutType}}
+	if err := {{$svc.Receiver}}.client.do(ctx, {{.MethodConst}}, {{.PathExpr}}, {{$body}}, &out, {{$headers}}); err != nil {
+		return nil, err
+	}
+	return {{if .Slice}}out{{else}}&out{{end}}, nil
+{{- else}}
+	return {{$svc.Receiver}}.client.do(ctx, {{.MethodConst}}, {{.PathExpr}}, {{$body}}, nil, {{$headers}})
+{{- end}}
+}
+{{end}}`))
+
+var testTemplate = template.Must(template.New("test").Funcs(funcs).Parse(`// Code generated by routegen from {{.Source}}; DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+{{- if .Validates}}
+	"errors"
+{{- end}}
+	"net/http"
+	"net/http/httptest"
+	"testing"
+{{if .Validates}}
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+{{- end}}
+)
+
+{{$svc := .Service -}}
+func Test{{$svc.Service}}Routes(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		reason bool
+		call   func(*{{$svc.Service}}) error
+	}{
+{{- range .Views}}
+		{"{{.Name}}", "{{.Method}}", "{{.TestPath}}", {{or .Audit .Reason}}, func(s *{{$svc.Service}}) error {
+			{{if .ReturnType}}_, err := {{else}}return {{end}}s.{{.Name}}(context.Background(){{if .TestArgs}}, {{.TestArgs}}{{end}})
+			{{- if .ReturnType}}
+			return err
+			{{- end}}
+		}},
+{{- end}}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tt.method || r.URL.Path != tt.path {
+					t.Errorf("expected %s %s, got %s %s", tt.method, tt.path, r.Method, r.URL.Path)
+				}
+				if tt.reason && r.Header.Get("X-Audit-Log-Reason") == "" {
+					t.Errorf("expected audit log reason header")
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			if err := tt.call(newTestClient(t, server.URL).{{$svc.Service}}()); err != nil {
+				t.Fatalf("{{$svc.Service}}.%s error: %v", tt.name, err)
+			}
+		})
+	}
+}
+
+{{- if .Validates}}
+
+func Test{{$svc.Service}}Validation(t *testing.T) {
+	s := newTestClient(t, "http://127.0.0.1").{{$svc.Service}}()
+	var vErr *types.ValidationError
+	var err error
+{{- range .Views}}{{if or .Params .Body}}
+	{{if .ReturnType}}_, {{end}}err = s.{{.Name}}(context.Background()
+		{{- range .Params}}, ""{{end}}
+		{{- if .Reason}}, ""{{end}}
+		{{- if .Body}}, nil{{end}})
+	if !errors.As(err, &vErr) {
+		t.Fatalf("{{.Name}}: expected validation error, got %v", err)
+	}
+{{- end}}{{end}}
+}
+{{- end}}
+`))
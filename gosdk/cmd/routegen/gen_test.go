@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleRoutes = `
+service: Widgets
+doc: exposes widget helpers.
+endpoints:
+  - name: GetWidget
+    doc: fetches a widget.
+    path: /guilds/{guildID}/widget
+    response: types.Widget
+  - name: ListWidgets
+    doc: lists widgets.
+    path: /widgets
+    response: "[]*types.Widget"
+  - name: UpdateWidget
+    doc: updates a widget.
+    method: patch
+    path: /guilds/{guildID}/widget
+    body: types.WidgetParams
+    validate: true
+    audit: true
+    response: types.Widget
+  - name: DeleteWidget
+    doc: deletes a widget.
+    method: DELETE
+    path: /guilds/{guildID}/widget
+    reason: true
+`
+
+func TestGenerate(t *testing.T) {
+	def, err := ParseService(strings.NewReader(sampleRoutes))
+	if err != nil {
+		t.Fatalf("ParseService() error = %v", err)
+	}
+	service, test, err := Generate(def, "routes/widgets.yaml")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	src := string(service)
+	for _, want := range []string{
+		"// Code generated by routegen from routes/widgets.yaml; DO NOT EDIT.",
+		"func (c *Client) Widgets() *Widgets {",
+		"func (w *Widgets) GetWidget(ctx context.Context, guildID string) (*types.Widget, error) {",
+		"func (w *Widgets) ListWidgets(ctx context.Context) ([]*types.Widget, error) {",
+		"return out, nil",
+		`if err := validateID("guildID", guildID); err != nil {`,
+		"if err := params.Validate(); err != nil {",
+		"http.MethodPatch, fmt.Sprintf(\"/guilds/%s/widget\", guildID), params, &out, auditHeaders(params.AuditLogReason))",
+		"func (w *Widgets) DeleteWidget(ctx context.Context, guildID, reason string) error {",
+		"http.MethodDelete, fmt.Sprintf(\"/guilds/%s/widget\", guildID), nil, nil, auditHeaders(reason))",
+	} {
+		if !strings.Contains(src, want) {
+			t.Fatalf("service missing %q:\n%s", want, src)
+		}
+	}
+
+	tests := string(test)
+	for _, want := range []string{
+		"func TestWidgetsRoutes(t *testing.T) {",
+		`{"UpdateWidget", "PATCH", "/guilds/1/widget", true,`,
+		`s.UpdateWidget(context.Background(), "1", &types.WidgetParams{})`,
+		`return s.DeleteWidget(context.Background(), "1", "cleanup")`,
+		`err = s.DeleteWidget(context.Background(), "", "")`,
+	} {
+		if !strings.Contains(tests, want) {
+			t.Fatalf("test missing %q:\n%s", want, tests)
+		}
+	}
+}
+
+func TestParseServiceErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":  "service: A\nendpoints:\n  - name: X\n    path: /x\n    bogus: 1\n",
+		"no endpoints":   "service: A\n",
+		"bad method":     "service: A\nendpoints:\n  - name: X\n    method: HEAD\n    path: /x\n",
+		"relative path":  "service: A\nendpoints:\n  - name: X\n    path: x\n",
+		"duplicate name": "service: A\nendpoints:\n  - name: X\n    path: /x\n  - name: X\n    path: /y\n",
+		"audit no body":  "service: A\nendpoints:\n  - name: X\n    path: /x\n    audit: true\n",
+		"bad type":       "service: A\nendpoints:\n  - name: X\n    path: /x\n    response: \"map[string]int\"\n",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseService(strings.NewReader(input)); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+// TestGeneratedFilesUpToDate fails when a route definition changed without
+// re-running go generate in discord/client.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	clientDir := filepath.Join("..", "..", "discord", "client")
+	defs, err := filepath.Glob(filepath.Join(clientDir, "routes", "*.yaml"))
+	if err != nil || len(defs) == 0 {
+		t.Fatalf("no route definitions found: %v", err)
+	}
+	for _, def := range defs {
+		base := strings.TrimSuffix(filepath.Base(def), ".yaml")
+		out := filepath.Join(t.TempDir(), base+"_gen.go")
+		if err := generateFile(def, out); err != nil {
+			t.Fatalf("generate %s: %v", def, err)
+		}
+		for _, name := range []string{base + "_gen.go", base + "_gen_test.go"} {
+			want, err := os.ReadFile(filepath.Join(clientDir, name))
+			if err != nil {
+				t.Fatalf("read %s: %v", name, err)
+			}
+			got, err := os.ReadFile(filepath.Join(filepath.Dir(out), name))
+			if err != nil {
+				t.Fatalf("read generated %s: %v", name, err)
+			}
+			// The header names the definition relative to the output file.
+			got = bytes.Replace(got, []byte(sourceName(def, out)), []byte("routes/"+base+".yaml"), 1)
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%s is stale; run go generate ./discord/client", name)
+			}
+		}
+	}
+}
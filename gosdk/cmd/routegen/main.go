@@ -0,0 +1,90 @@
+// Command routegen generates REST service methods and tests for the client
+// package from route definition files.
+//
+// A definition names the service and lists its endpoints:
+//
+//	service: Stickers
+//	doc: exposes sticker REST helpers.
+//	endpoints:
+//	  - name: GetGuildSticker
+//	    doc: retrieves a guild sticker.
+//	    path: /guilds/{guildID}/stickers/{stickerID}
+//	    response: types.Sticker
+//	  - name: DeleteGuildSticker
+//	    method: DELETE
+//	    path: /guilds/{guildID}/stickers/{stickerID}
+//	    reason: true
+//
+// Path placeholders become string arguments checked with validateID. body
+// adds a params argument (validate calls its Validate method, audit sends its
+// AuditLogReason), reason adds an audit log reason argument, and response
+// selects the decoded type. The client package runs it via go generate.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("routegen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	in := fs.String("in", "", "route definition file (YAML)")
+	out := fs.String("out", "", "generated service file (default: <name>_gen.go in the current directory)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *in == "" {
+		fmt.Fprintln(stderr, "routegen: -in is required")
+		return 2
+	}
+	if *out == "" {
+		base := strings.TrimSuffix(filepath.Base(*in), filepath.Ext(*in))
+		*out = base + "_gen.go"
+	}
+
+	if err := generateFile(*in, *out); err != nil {
+		fmt.Fprintf(stderr, "routegen: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// generateFile writes out and its _test.go companion from the definition in.
+func generateFile(in, out string) error {
+	f, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	def, err := ParseService(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", in, err)
+	}
+	service, test, err := Generate(def, filepath.ToSlash(sourceName(in, out)))
+	if err != nil {
+		return fmt.Errorf("%s: %w", in, err)
+	}
+
+	if err := os.WriteFile(out, service, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(strings.TrimSuffix(out, ".go")+"_test.go", test, 0o644)
+}
+
+// sourceName reports in relative to the output directory for the header.
+func sourceName(in, out string) string {
+	if rel, err := filepath.Rel(filepath.Dir(out), in); err == nil {
+		return rel
+	}
+	return filepath.Base(in)
+}
@@ -0,0 +1,481 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Spec is the subset of an OpenAPI document the drift check reads.
+type Spec struct {
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Schema is a JSON schema node from the spec.
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       json.RawMessage    `json:"type"`
+	Format     string             `json:"format"`
+	Title      string             `json:"title"`
+	Properties map[string]*Schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *Schema            `json:"items"`
+	OneOf      []*Schema          `json:"oneOf"`
+	AnyOf      []*Schema          `json:"anyOf"`
+	AllOf      []*Schema          `json:"allOf"`
+	Enum       []json.RawMessage  `json:"enum"`
+	Const      json.RawMessage    `json:"const"`
+}
+
+// ParseSpec decodes an OpenAPI document.
+func ParseSpec(r io.Reader) (*Spec, error) {
+	var spec Spec
+	if err := json.NewDecoder(r).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("decode spec: %w", err)
+	}
+	if len(spec.Components.Schemas) == 0 {
+		return nil, fmt.Errorf("spec has no components.schemas")
+	}
+	return &spec, nil
+}
+
+// resolve follows $ref pointers to #/components/schemas.
+func (s *Spec) resolve(schema *Schema) *Schema {
+	for i := 0; schema != nil && schema.Ref != "" && i < 32; i++ {
+		schema = s.Components.Schemas[refName(schema.Ref)]
+	}
+	return schema
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// types returns the schema's type list; OpenAPI 3.1 allows ["string", "null"].
+func (s *Schema) types() []string {
+	if len(s.Type) == 0 {
+		return nil
+	}
+	var single string
+	if json.Unmarshal(s.Type, &single) == nil {
+		return []string{single}
+	}
+	var list []string
+	json.Unmarshal(s.Type, &list)
+	return list
+}
+
+// properties merges an object's own properties with those of its allOf parts.
+func (s *Spec) properties(schema *Schema) map[string]*Schema {
+	props := make(map[string]*Schema)
+	schema = s.resolve(schema)
+	if schema == nil {
+		return props
+	}
+	for _, part := range schema.AllOf {
+		for name, prop := range s.properties(part) {
+			props[name] = prop
+		}
+	}
+	for name, prop := range schema.Properties {
+		props[name] = prop
+	}
+	return props
+}
+
+// enumValues returns value -> name for an enum schema, covering both "enum"
+// lists and the oneOf/const form Discord's spec uses for named values.
+func (s *Spec) enumValues(schema *Schema) map[string]string {
+	values := make(map[string]string)
+	schema = s.resolve(schema)
+	if schema == nil {
+		return values
+	}
+	for _, raw := range schema.Enum {
+		values[string(raw)] = ""
+	}
+	for _, option := range schema.OneOf {
+		if option = s.resolve(option); option != nil && len(option.Const) > 0 {
+			values[string(option.Const)] = option.Title
+		}
+	}
+	return values
+}
+
+// Package holds the JSON shape of the SDK types under comparison.
+type Package struct {
+	// structs maps a type name to its JSON field names.
+	structs map[string][]string
+	// enums maps a named constant type to value -> constant name.
+	enums map[string]map[string]string
+}
+
+// LoadPackage type-checks the Go files in dir. Imports are stubbed out, so
+// only the package's own declarations are resolved; that is enough for
+// struct tags and constant values.
+func LoadPackage(dir string) (*Package, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	conf := types.Config{
+		Importer: stubImporter{fallback: importer.Default()},
+		Error:    func(error) {},
+	}
+	checked, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+
+	pkg := &Package{structs: make(map[string][]string), enums: make(map[string]map[string]string)}
+	scope := checked.Scope()
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.TypeName:
+			if st, ok := obj.Type().Underlying().(*types.Struct); ok && obj.Exported() {
+				pkg.structs[name] = jsonFields(st)
+			}
+		case *types.Const:
+			named, ok := obj.Type().(*types.Named)
+			if !ok || named.Obj().Pkg() != checked || obj.Val().Kind() == constant.Unknown {
+				continue
+			}
+			typeName := named.Obj().Name()
+			if pkg.enums[typeName] == nil {
+				pkg.enums[typeName] = make(map[string]string)
+			}
+			pkg.enums[typeName][obj.Val().ExactString()] = name
+		}
+	}
+	return pkg, nil
+}
+
+// stubImporter resolves the standard library and treats every other import
+// as an empty package, keeping the check hermetic.
+type stubImporter struct {
+	fallback types.Importer
+}
+
+func (i stubImporter) Import(path string) (*types.Package, error) {
+	if !strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+		if pkg, err := i.fallback.Import(path); err == nil {
+			return pkg, nil
+		}
+	}
+	pkg := types.NewPackage(path, path[strings.LastIndex(path, "/")+1:])
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+// jsonFields lists the JSON names of a struct's fields, flattening embedded
+// structs the way encoding/json does.
+func jsonFields(st *types.Struct) []string {
+	var fields []string
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || (!field.Exported() && !field.Embedded()) {
+			continue
+		}
+		if field.Embedded() && name == "" {
+			typ := field.Type()
+			if ptr, ok := typ.(*types.Pointer); ok {
+				typ = ptr.Elem()
+			}
+			if inner, ok := typ.Underlying().(*types.Struct); ok {
+				fields = append(fields, jsonFields(inner)...)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name()
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// Mapping pairs an SDK type with the schema it mirrors.
+type Mapping struct {
+	GoType string
+	Schema string
+}
+
+// DefaultMappings covers the SDK types most likely to fall behind the API.
+var DefaultMappings = []Mapping{
+	{"Message", "MessageResponse"},
+	{"User", "UserResponse"},
+	{"Guild", "GuildResponse"},
+	{"Role", "GuildRoleResponse"},
+	{"Emoji", "EmojiResponse"},
+	{"Sticker", "GuildStickerResponse"},
+	{"Channel", "GuildChannelResponse"},
+	{"Attachment", "MessageAttachmentResponse"},
+	{"Embed", "MessageEmbedResponse"},
+	{"ApplicationCommand", "ApplicationCommandResponse"},
+	{"ChannelType", "ChannelTypes"},
+	{"ComponentType", "MessageComponentTypes"},
+	{"ButtonStyle", "ButtonStyleTypes"},
+	{"TextInputStyle", "TextInputStyleTypes"},
+	{"InteractionType", "InteractionTypes"},
+	{"InteractionResponseType", "InteractionCallbackTypes"},
+	{"ApplicationCommandType", "ApplicationCommandType"},
+	{"ApplicationCommandOptionType", "ApplicationCommandOptionType"},
+	{"PollLayoutType", "PollLayoutTypes"},
+}
+
+// Field is a schema property the SDK type does not declare.
+type Field struct {
+	Name     string
+	Schema   *Schema
+	Required bool
+}
+
+// EnumValue is a single enum member.
+type EnumValue struct {
+	Name  string
+	Value string
+}
+
+// Drift is the comparison result for one mapping.
+type Drift struct {
+	Mapping
+	// Problem is set when either side of the mapping could not be found.
+	Problem       string
+	MissingFields []Field
+	ExtraFields   []string
+	MissingValues []EnumValue
+	ExtraValues   []EnumValue
+}
+
+// HasDrift reports whether the SDK is missing anything from the schema.
+// Extra struct fields are informational: SDK types often carry gateway-only
+// fields the REST schema omits.
+func (d Drift) HasDrift() bool {
+	return len(d.MissingFields) > 0 || len(d.MissingValues) > 0 || len(d.ExtraValues) > 0
+}
+
+// Compare diffs every mapping between pkg and spec.
+func Compare(pkg *Package, spec *Spec, mappings []Mapping) []Drift {
+	drifts := make([]Drift, 0, len(mappings))
+	for _, m := range mappings {
+		d := Drift{Mapping: m}
+		schema := spec.resolve(spec.Components.Schemas[m.Schema])
+		switch fields, isStruct := pkg.structs[m.GoType]; {
+		case schema == nil:
+			d.Problem = "schema not found in spec"
+		case isStruct:
+			compareFields(spec, schema, fields, &d)
+		case pkg.enums[m.GoType] != nil:
+			compareValues(spec.enumValues(schema), pkg.enums[m.GoType], &d)
+		default:
+			d.Problem = "type not found in package"
+		}
+		drifts = append(drifts, d)
+	}
+	return drifts
+}
+
+func compareFields(spec *Spec, schema *Schema, fields []string, d *Drift) {
+	declared := make(map[string]bool, len(fields))
+	for _, name := range fields {
+		declared[name] = true
+	}
+	required := make(map[string]bool)
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	props := spec.properties(schema)
+	for name, prop := range props {
+		if !declared[name] {
+			d.MissingFields = append(d.MissingFields, Field{Name: name, Schema: prop, Required: required[name]})
+		}
+	}
+	for _, name := range fields {
+		if _, ok := props[name]; !ok {
+			d.ExtraFields = append(d.ExtraFields, name)
+		}
+	}
+	sort.Slice(d.MissingFields, func(i, j int) bool { return d.MissingFields[i].Name < d.MissingFields[j].Name })
+	sort.Strings(d.ExtraFields)
+}
+
+func compareValues(schemaValues, goValues map[string]string, d *Drift) {
+	for value, name := range schemaValues {
+		if _, ok := goValues[value]; !ok {
+			d.MissingValues = append(d.MissingValues, EnumValue{Name: name, Value: value})
+		}
+	}
+	for value, name := range goValues {
+		if _, ok := schemaValues[value]; !ok {
+			d.ExtraValues = append(d.ExtraValues, EnumValue{Name: name, Value: value})
+		}
+	}
+	sortValues(d.MissingValues)
+	sortValues(d.ExtraValues)
+}
+
+func sortValues(values []EnumValue) {
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i].Value) != len(values[j].Value) {
+			return len(values[i].Value) < len(values[j].Value)
+		}
+		return values[i].Value < values[j].Value
+	})
+}
+
+// WriteReport prints drifts in a diff-like format. extra includes SDK fields
+// the schema does not list.
+func WriteReport(w io.Writer, drifts []Drift, extra bool) {
+	for _, d := range drifts {
+		if d.Problem == "" && !d.HasDrift() && (!extra || len(d.ExtraFields) == 0) {
+			continue
+		}
+		fmt.Fprintf(w, "%s (%s)\n", d.GoType, d.Schema)
+		if d.Problem != "" {
+			fmt.Fprintf(w, "  ! %s\n", d.Problem)
+		}
+		for _, f := range d.MissingFields {
+			fmt.Fprintf(w, "  - field %s\n", f.Name)
+		}
+		if extra {
+			for _, name := range d.ExtraFields {
+				fmt.Fprintf(w, "  + field %s (not in schema)\n", name)
+			}
+		}
+		for _, v := range d.MissingValues {
+			fmt.Fprintf(w, "  - value %s %s\n", v.Value, v.Name)
+		}
+		for _, v := range d.ExtraValues {
+			fmt.Fprintf(w, "  + value %s %s (not in schema)\n", v.Value, v.Name)
+		}
+	}
+}
+
+// WriteStubs prints Go struct fields for every missing schema property, ready
+// to paste into the SDK type and refine.
+func WriteStubs(w io.Writer, spec *Spec, drifts []Drift, mappings []Mapping) {
+	known := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		known[m.Schema] = m.GoType
+	}
+	for _, d := range drifts {
+		if len(d.MissingFields) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "// %s: fields from %s\n", d.GoType, d.Schema)
+		for _, f := range d.MissingFields {
+			omit := ",omitempty"
+			if f.Required {
+				omit = ""
+			}
+			fmt.Fprintf(w, "\t%s %s `json:\"%s%s\"`\n", goFieldName(f.Name), goType(spec, f.Schema, known), f.Name, omit)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+var initialisms = map[string]string{
+	"id": "ID", "ids": "IDs", "url": "URL", "nsfw": "NSFW", "tts": "TTS", "api": "API", "json": "JSON",
+}
+
+// goFieldName converts a snake_case JSON name to an exported Go name.
+func goFieldName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		if upper, ok := initialisms[part]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// goType picks a Go type for a schema. Anything it cannot map precisely
+// becomes json.RawMessage.
+func goType(spec *Spec, schema *Schema, known map[string]string) string {
+	if schema == nil {
+		return "json.RawMessage"
+	}
+	if schema.Ref != "" {
+		if goName, ok := known[refName(schema.Ref)]; ok {
+			if resolved := spec.resolve(schema); resolved != nil && len(resolved.Properties) > 0 {
+				return "*" + goName
+			}
+			return goName
+		}
+		return goType(spec, spec.resolve(schema), known)
+	}
+
+	variants := append(append([]*Schema{}, schema.OneOf...), schema.AnyOf...)
+	if len(variants) > 0 && len(schema.Properties) == 0 {
+		var nonNull []*Schema
+		for _, v := range variants {
+			if r := spec.resolve(v); r == nil || !isNull(r) {
+				nonNull = append(nonNull, v)
+			}
+		}
+		if len(nonNull) == 1 {
+			return goType(spec, nonNull[0], known)
+		}
+		if len(schema.Enum) == 0 && len(schema.types()) == 0 {
+			return "json.RawMessage"
+		}
+	}
+
+	for _, typ := range schema.types() {
+		switch typ {
+		case "string":
+			if schema.Format == "date-time" {
+				return "*time.Time"
+			}
+			return "string"
+		case "integer":
+			return "int"
+		case "number":
+			return "float64"
+		case "boolean":
+			return "bool"
+		case "array":
+			return "[]" + strings.TrimPrefix(goType(spec, schema.Items, known), "*")
+		}
+	}
+	return "json.RawMessage"
+}
+
+func isNull(schema *Schema) bool {
+	t := schema.types()
+	return len(t) == 1 && t[0] == "null"
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSource = `package sample
+
+import "time"
+
+type Base struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+type Message struct {
+	Base
+	Content   string    ` + "`json:\"content\"`" + `
+	Timestamp time.Time ` + "`json:\"timestamp\"`" + `
+	GuildID   string    ` + "`json:\"guild_id,omitempty\"`" + `
+	internal  int
+	Skipped   string ` + "`json:\"-\"`" + `
+}
+
+type Kind int
+
+const (
+	KindText Kind = iota
+	KindVoice
+	KindLegacy = Kind(9)
+)
+`
+
+const testSpec = `{
+  "components": {
+    "schemas": {
+      "SnowflakeType": {"type": "string"},
+      "UserResponse": {"type": "object", "properties": {"id": {"$ref": "#/components/schemas/SnowflakeType"}}},
+      "MessageResponse": {
+        "type": "object",
+        "required": ["id", "content", "flags"],
+        "allOf": [{"$ref": "#/components/schemas/MessageBase"}],
+        "properties": {
+          "content": {"type": "string"},
+          "timestamp": {"type": "string", "format": "date-time"},
+          "flags": {"type": "integer"},
+          "edited_timestamp": {"type": ["string", "null"], "format": "date-time"},
+          "author": {"$ref": "#/components/schemas/UserResponse"},
+          "mention_ids": {"type": "array", "items": {"$ref": "#/components/schemas/SnowflakeType"}},
+          "poll": {"oneOf": [{"type": "null"}, {"$ref": "#/components/schemas/PollResponse"}]}
+        }
+      },
+      "MessageBase": {"type": "object", "properties": {"id": {"$ref": "#/components/schemas/SnowflakeType"}}},
+      "PollResponse": {"type": "object", "properties": {"question": {"type": "string"}}},
+      "Kinds": {"type": "integer", "oneOf": [
+        {"title": "TEXT", "const": 0},
+        {"title": "VOICE", "const": 1},
+        {"title": "FORUM", "const": 15}
+      ]}
+    }
+  }
+}`
+
+func loadTestInputs(t *testing.T) (*Package, *Spec) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "sample.go"), []byte(testSource), 0o644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	pkg, err := LoadPackage(dir)
+	if err != nil {
+		t.Fatalf("LoadPackage() error = %v", err)
+	}
+	spec, err := ParseSpec(strings.NewReader(testSpec))
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+	return pkg, spec
+}
+
+func TestCompareFields(t *testing.T) {
+	pkg, spec := loadTestInputs(t)
+	drifts := Compare(pkg, spec, []Mapping{{"Message", "MessageResponse"}})
+	d := drifts[0]
+
+	var missing []string
+	for _, f := range d.MissingFields {
+		missing = append(missing, f.Name)
+	}
+	want := "author,edited_timestamp,flags,mention_ids,poll"
+	if got := strings.Join(missing, ","); got != want {
+		t.Fatalf("missing fields = %s, want %s", got, want)
+	}
+	if got := strings.Join(d.ExtraFields, ","); got != "guild_id" {
+		t.Fatalf("extra fields = %s, want guild_id", got)
+	}
+	if !d.HasDrift() {
+		t.Fatal("expected drift")
+	}
+}
+
+func TestCompareEnums(t *testing.T) {
+	pkg, spec := loadTestInputs(t)
+	d := Compare(pkg, spec, []Mapping{{"Kind", "Kinds"}})[0]
+	if len(d.MissingValues) != 1 || d.MissingValues[0] != (EnumValue{Name: "FORUM", Value: "15"}) {
+		t.Fatalf("missing values = %+v", d.MissingValues)
+	}
+	if len(d.ExtraValues) != 1 || d.ExtraValues[0] != (EnumValue{Name: "KindLegacy", Value: "9"}) {
+		t.Fatalf("extra values = %+v", d.ExtraValues)
+	}
+}
+
+func TestCompareReportsUnknownMappings(t *testing.T) {
+	pkg, spec := loadTestInputs(t)
+	drifts := Compare(pkg, spec, []Mapping{{"Message", "Nope"}, {"Nope", "UserResponse"}})
+	if drifts[0].Problem == "" || drifts[1].Problem == "" {
+		t.Fatalf("expected problems for unknown mappings, got %+v", drifts)
+	}
+	if drifts[0].HasDrift() || drifts[1].HasDrift() {
+		t.Fatal("unknown mappings should not count as drift")
+	}
+}
+
+func TestWriteStubs(t *testing.T) {
+	pkg, spec := loadTestInputs(t)
+	mappings := []Mapping{{"Message", "MessageResponse"}, {"User", "UserResponse"}}
+	var buf bytes.Buffer
+	WriteStubs(&buf, spec, Compare(pkg, spec, mappings), mappings)
+
+	out := buf.String()
+	for _, want := range []string{
+		"\tAuthor *User `json:\"author,omitempty\"`",
+		"\tEditedTimestamp *time.Time `json:\"edited_timestamp,omitempty\"`",
+		"\tFlags int `json:\"flags\"`",
+		"\tMentionIDs []string `json:\"mention_ids,omitempty\"`",
+		"\tPoll json.RawMessage `json:\"poll,omitempty\"`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("stubs missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.json")
+	if err := os.WriteFile(specPath, []byte(testSpec), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	otherPath := filepath.Join(dir, "other.json")
+	if err := os.WriteFile(otherPath, []byte(`{"components":{"schemas":{"Other":{"type":"object"}}}}`), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-spec", specPath, "-pkg", "../../discord/types"}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected drift exit code, got %d: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "Message (MessageResponse)") || !strings.Contains(out, "- field mention_ids") {
+		t.Fatalf("expected Message drift in report:\n%s", out)
+	}
+	if !strings.Contains(out, "Guild (GuildResponse)\n  ! schema not found in spec") {
+		t.Fatalf("expected missing schemas to be reported:\n%s", out)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"-spec", otherPath, "-pkg", "../../discord/types"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected unmapped schemas not to count as drift, got %d", code)
+	}
+	if code := run([]string{"-spec", filepath.Join(dir, "missing.json")}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected error exit code, got %d", code)
+	}
+	if code := run([]string{"-map", "broken"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected flag error exit code, got %d", code)
+	}
+}
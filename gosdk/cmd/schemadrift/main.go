@@ -0,0 +1,133 @@
+// Command schemadrift diffs the SDK's type definitions against Discord's
+// published OpenAPI specification and reports fields and enum values the SDK
+// is missing.
+//
+// Run it from the gosdk module root:
+//
+//	go run ./cmd/schemadrift
+//	go run ./cmd/schemadrift -spec openapi.json -stubs
+//	go run ./cmd/schemadrift -map Webhook=WebhookResponse -extra
+//
+// It exits 1 when drift is found, so it can gate CI, and 2 on errors.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultSpecURL = "https://raw.githubusercontent.com/discord/discord-api-spec/main/specs/openapi.json"
+
+// mappingFlags collects repeated -map GoType=Schema flags.
+type mappingFlags []Mapping
+
+func (m *mappingFlags) String() string {
+	parts := make([]string, len(*m))
+	for i, mapping := range *m {
+		parts[i] = mapping.GoType + "=" + mapping.Schema
+	}
+	return strings.Join(parts, ",")
+}
+
+func (m *mappingFlags) Set(value string) error {
+	goType, schema, ok := strings.Cut(value, "=")
+	if !ok || goType == "" || schema == "" {
+		return fmt.Errorf("expected GoType=SchemaName, got %q", value)
+	}
+	*m = append(*m, Mapping{GoType: goType, Schema: schema})
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("schemadrift", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	specPath := fs.String("spec", defaultSpecURL, "OpenAPI spec file or URL")
+	pkgDir := fs.String("pkg", "discord/types", "directory of the Go package to check")
+	stubs := fs.Bool("stubs", false, "print Go struct fields for missing properties")
+	extra := fs.Bool("extra", false, "also list SDK fields the schema does not declare")
+	var overrides mappingFlags
+	fs.Var(&overrides, "map", "add or override a mapping as GoType=SchemaName (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	spec, err := loadSpec(*specPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "schemadrift: %v\n", err)
+		return 2
+	}
+	pkg, err := LoadPackage(*pkgDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "schemadrift: load %s: %v\n", *pkgDir, err)
+		return 2
+	}
+
+	mappings := mergeMappings(DefaultMappings, overrides)
+	drifts := Compare(pkg, spec, mappings)
+	WriteReport(stdout, drifts, *extra)
+	if *stubs {
+		WriteStubs(stdout, spec, drifts, mappings)
+	}
+
+	for _, d := range drifts {
+		if d.HasDrift() {
+			return 1
+		}
+	}
+	return 0
+}
+
+// mergeMappings applies overrides by Go type name, appending new ones.
+func mergeMappings(base, overrides []Mapping) []Mapping {
+	merged := append([]Mapping{}, base...)
+	for _, o := range overrides {
+		replaced := false
+		for i := range merged {
+			if merged[i].GoType == o.GoType {
+				merged[i].Schema = o.Schema
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, o)
+		}
+	}
+	return merged
+}
+
+func loadSpec(path string) (*Spec, error) {
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ParseSpec(f)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch spec: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch spec: unexpected status %s", resp.Status)
+	}
+	return ParseSpec(resp.Body)
+}
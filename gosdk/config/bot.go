@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Modules a bot can enable. The REST client is always built.
+const (
+	ModuleGateway      = "gateway"
+	ModuleInteractions = "interactions"
+	ModuleState        = "state"
+)
+
+// BotConfig describes a deployable bot: where its token comes from, which
+// gateway intents it needs, which commands it registers, and which modules
+// run. Keeping it in one reviewed file makes deployments reproducible.
+type BotConfig struct {
+	Token        TokenSource        `yaml:"token"`
+	Intents      []string           `yaml:"intents"`
+	Commands     string             `yaml:"commands"`
+	Modules      []string           `yaml:"modules"`
+	Interactions InteractionsConfig `yaml:"interactions"`
+}
+
+// TokenSource says where to read the bot token. The first set field wins,
+// in the order value, file, env; if none is set, discord.bot_token is used.
+type TokenSource struct {
+	Value string `yaml:"value"`
+	File  string `yaml:"file"`
+	Env   string `yaml:"env"`
+}
+
+// InteractionsConfig configures the HTTP interactions endpoint.
+type InteractionsConfig struct {
+	PublicKey string `yaml:"public_key"`
+	Listen    string `yaml:"listen"`
+	Path      string `yaml:"path"`
+}
+
+// ResolveToken returns the bot token from the configured source.
+func (c *Config) ResolveToken() (string, error) {
+	src := c.Bot.Token
+	switch {
+	case src.Value != "":
+		return src.Value, nil
+	case src.File != "":
+		data, err := os.ReadFile(src.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("token file %s is empty", src.File)
+		}
+		return token, nil
+	case src.Env != "":
+		token := os.Getenv(src.Env)
+		if token == "" {
+			return "", fmt.Errorf("token environment variable %s is not set", src.Env)
+		}
+		return token, nil
+	case c.Discord.BotToken != "":
+		return c.Discord.BotToken, nil
+	default:
+		return "", fmt.Errorf("no bot token configured")
+	}
+}
+
+// ModuleEnabled reports whether the named module is listed.
+func (b *BotConfig) ModuleEnabled(name string) bool {
+	for _, m := range b.Modules {
+		if strings.EqualFold(m, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks module names and the settings each module needs.
+func (b *BotConfig) Validate() error {
+	for _, m := range b.Modules {
+		switch strings.ToLower(m) {
+		case ModuleGateway, ModuleInteractions, ModuleState:
+		default:
+			return fmt.Errorf("unknown module %q", m)
+		}
+	}
+	if b.ModuleEnabled(ModuleState) && !b.ModuleEnabled(ModuleGateway) {
+		return fmt.Errorf("module %q requires module %q", ModuleState, ModuleGateway)
+	}
+	if b.ModuleEnabled(ModuleInteractions) && b.Interactions.PublicKey == "" {
+		return fmt.Errorf("module %q requires interactions.public_key", ModuleInteractions)
+	}
+	return nil
+}
+
+func applyBotDefaults(b *BotConfig) {
+	if b.Interactions.Listen == "" {
+		b.Interactions.Listen = ":8080"
+	}
+	if b.Interactions.Path == "" {
+		b.Interactions.Path = "/interactions"
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_BOT_TOKEN", "from-env")
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    string
+		wantErr bool
+	}{
+		{"value", Config{Bot: BotConfig{Token: TokenSource{Value: "v", File: tokenFile}}}, "v", false},
+		{"file", Config{Bot: BotConfig{Token: TokenSource{File: tokenFile, Env: "TEST_BOT_TOKEN"}}}, "from-file", false},
+		{"env", Config{Bot: BotConfig{Token: TokenSource{Env: "TEST_BOT_TOKEN"}}}, "from-env", false},
+		{"legacy", Config{Discord: DiscordConfig{BotToken: "legacy"}}, "legacy", false},
+		{"unset env", Config{Bot: BotConfig{Token: TokenSource{Env: "TEST_MISSING_TOKEN"}}}, "", true},
+		{"none", Config{}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := tt.cfg.ResolveToken()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s: ResolveToken() = %q, %v", tt.name, got, err)
+		}
+	}
+}
+
+func TestLoadBotConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bot.yaml")
+	if err := os.WriteFile(path, []byte(`
+bot:
+  token:
+    env: DISCORD_BOT_TOKEN
+  intents: [default, message_content]
+  commands: commands.yaml
+  modules: [gateway, state]
+`), 0o600); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.Bot.ModuleEnabled(ModuleState) || cfg.Bot.ModuleEnabled(ModuleInteractions) {
+		t.Fatalf("unexpected modules: %v", cfg.Bot.Modules)
+	}
+	if len(cfg.Bot.Intents) != 2 || cfg.Bot.Commands != "commands.yaml" {
+		t.Fatalf("unexpected bot config: %+v", cfg.Bot)
+	}
+	if cfg.Bot.Interactions.Path != "/interactions" {
+		t.Fatalf("expected default interactions path, got %q", cfg.Bot.Interactions.Path)
+	}
+}
+
+func TestBotConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		bot  BotConfig
+	}{
+		{"unknown module", BotConfig{Modules: []string{"voice"}}},
+		{"state without gateway", BotConfig{Modules: []string{"state"}}},
+		{"interactions without key", BotConfig{Modules: []string{"interactions"}}},
+	}
+	for _, tt := range tests {
+		if err := tt.bot.Validate(); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
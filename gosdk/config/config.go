@@ -13,6 +13,7 @@ type Config struct {
 	Discord DiscordConfig `yaml:"discord"`
 	Client  ClientConfig  `yaml:"client"`
 	Logging LoggingConfig `yaml:"logging"`
+	Bot     BotConfig     `yaml:"bot"`
 }
 
 // DiscordConfig contains Discord-specific configuration
@@ -73,6 +74,10 @@ func Load(path string) (*Config, error) {
 	if cfg.Logging.Format == "" {
 		cfg.Logging.Format = "json"
 	}
+	applyBotDefaults(&cfg.Bot)
+	if err := cfg.Bot.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid bot config: %w", err)
+	}
 
 	return &cfg, nil
 }
@@ -101,6 +106,9 @@ func Default() *Config {
 			Format: "json",
 			Output: "stderr",
 		},
+		Bot: BotConfig{
+			Interactions: InteractionsConfig{Listen: ":8080", Path: "/interactions"},
+		},
 	}
 }
 
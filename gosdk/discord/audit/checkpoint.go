@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Checkpoint persists the ID of the last exported entry per guild.
+type Checkpoint interface {
+	// Load returns the last exported entry ID, or "" if the guild has not
+	// been exported yet.
+	Load(ctx context.Context, guildID string) (string, error)
+	// Save records lastID as exported.
+	Save(ctx context.Context, guildID, lastID string) error
+}
+
+// MemoryCheckpoint keeps cursors in memory. It suits tests and one-shot
+// exports; use a FileCheckpoint to resume across restarts.
+type MemoryCheckpoint struct {
+	mu      sync.Mutex
+	cursors map[string]string
+}
+
+// NewMemoryCheckpoint creates an empty MemoryCheckpoint.
+func NewMemoryCheckpoint() *MemoryCheckpoint {
+	return &MemoryCheckpoint{cursors: make(map[string]string)}
+}
+
+// Load returns the cursor for guildID.
+func (m *MemoryCheckpoint) Load(ctx context.Context, guildID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursors[guildID], nil
+}
+
+// Save stores the cursor for guildID.
+func (m *MemoryCheckpoint) Save(ctx context.Context, guildID, lastID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursors[guildID] = lastID
+	return nil
+}
+
+// FileCheckpoint stores cursors as a JSON object in a file. Writes replace
+// the file atomically, so a crash never leaves a truncated checkpoint.
+type FileCheckpoint struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpoint uses the file at path, which need not exist yet.
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load returns the cursor for guildID.
+func (f *FileCheckpoint) Load(ctx context.Context, guildID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cursors, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	return cursors[guildID], nil
+}
+
+// Save stores the cursor for guildID.
+func (f *FileCheckpoint) Save(ctx context.Context, guildID, lastID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cursors, err := f.read()
+	if err != nil {
+		return err
+	}
+	cursors[guildID] = lastID
+
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (f *FileCheckpoint) read() (map[string]string, error) {
+	cursors := make(map[string]string)
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	return cursors, nil
+}
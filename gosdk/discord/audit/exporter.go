@@ -0,0 +1,174 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/utils"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+const defaultExportInterval = 5 * time.Minute
+
+// Source reads a guild's audit log. *client.AuditLogs satisfies it.
+type Source interface {
+	GetGuildAuditLog(ctx context.Context, guildID string, params *types.AuditLogParams) (*types.AuditLog, error)
+}
+
+// Exporter copies new audit log entries from Discord to a Sink.
+type Exporter struct {
+	source     Source
+	sink       Sink
+	guildIDs   []string
+	checkpoint Checkpoint
+	interval   time.Duration
+	pageSize   int
+	since      time.Time
+	logger     *logger.Logger
+}
+
+// Option configures an Exporter.
+type Option func(*Exporter)
+
+// WithCheckpoint persists export progress. Without it progress is kept in
+// memory and a restarted exporter re-exports everything Discord retains.
+func WithCheckpoint(checkpoint Checkpoint) Option {
+	return func(e *Exporter) {
+		if checkpoint != nil {
+			e.checkpoint = checkpoint
+		}
+	}
+}
+
+// WithInterval sets how often Run polls for new entries (default 5 minutes).
+func WithInterval(interval time.Duration) Option {
+	return func(e *Exporter) {
+		if interval > 0 {
+			e.interval = interval
+		}
+	}
+}
+
+// WithPageSize sets how many entries are requested per call (1-100).
+func WithPageSize(size int) Option {
+	return func(e *Exporter) {
+		if size > 0 && size <= limits.AuditLogEntriesPerPage {
+			e.pageSize = size
+		}
+	}
+}
+
+// WithSince skips entries created before t on a guild's first export.
+func WithSince(t time.Time) Option {
+	return func(e *Exporter) {
+		e.since = t
+	}
+}
+
+// WithLogger sets the logger used to report failed polls.
+func WithLogger(l logger.Interface) Option {
+	return func(e *Exporter) {
+		if lg := logger.From(l); lg != nil {
+			e.logger = lg
+		}
+	}
+}
+
+// NewExporter exports the audit logs of guildIDs from source to sink.
+func NewExporter(source Source, sink Sink, guildIDs []string, opts ...Option) (*Exporter, error) {
+	if source == nil {
+		return nil, &types.ValidationError{Field: "source", Message: "audit log source is required"}
+	}
+	if sink == nil {
+		return nil, &types.ValidationError{Field: "sink", Message: "sink is required"}
+	}
+	if len(guildIDs) == 0 {
+		return nil, &types.ValidationError{Field: "guildIDs", Message: "at least one guild is required"}
+	}
+	e := &Exporter{
+		source:     source,
+		sink:       sink,
+		guildIDs:   append([]string(nil), guildIDs...),
+		checkpoint: NewMemoryCheckpoint(),
+		interval:   defaultExportInterval,
+		pageSize:   limits.AuditLogEntriesPerPage,
+		logger:     logger.Default(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(e)
+		}
+	}
+	e.logger = e.logger.WithSubsystem(logger.SubsystemAudit)
+	return e, nil
+}
+
+// Run exports immediately and then every interval until ctx is done. Failed
+// passes are logged and retried on the next tick.
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := e.Sync(ctx); err != nil && ctx.Err() == nil {
+			e.logger.Warn("audit log export failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync exports every entry newer than each guild's checkpoint and reports
+// how many were written.
+func (e *Exporter) Sync(ctx context.Context) (int, error) {
+	total := 0
+	for _, guildID := range e.guildIDs {
+		n, err := e.syncGuild(ctx, guildID)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (e *Exporter) syncGuild(ctx context.Context, guildID string) (int, error) {
+	cursor, err := e.checkpoint.Load(ctx, guildID)
+	if err != nil {
+		return 0, err
+	}
+	if cursor == "" {
+		// after=0 starts from the oldest retained entry.
+		cursor = "0"
+		if !e.since.IsZero() {
+			cursor = utils.TimeToSnowflake(e.since)
+		}
+	}
+
+	exported := 0
+	for {
+		page, err := e.source.GetGuildAuditLog(ctx, guildID, &types.AuditLogParams{After: cursor, Limit: e.pageSize})
+		if err != nil {
+			return exported, err
+		}
+		records := Normalize(guildID, page)
+		if len(records) == 0 {
+			return exported, nil
+		}
+		if err := e.sink.Write(ctx, records); err != nil {
+			return exported, err
+		}
+		cursor = records[len(records)-1].ID
+		if err := e.checkpoint.Save(ctx, guildID, cursor); err != nil {
+			return exported, err
+		}
+		exported += len(records)
+		if len(page.Entries) < e.pageSize {
+			return exported, nil
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/utils"
+)
+
+// fakeSource serves entries the way Discord does for after= queries: the
+// page holds the entries directly after the cursor, newest first.
+type fakeSource struct {
+	entries []types.AuditLogEntry // oldest first
+	calls   int
+	err     error
+}
+
+func (f *fakeSource) GetGuildAuditLog(ctx context.Context, guildID string, params *types.AuditLogParams) (*types.AuditLog, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	var page []types.AuditLogEntry
+	for _, entry := range f.entries {
+		if idLess(params.After, entry.ID) && len(page) < params.Limit {
+			page = append(page, entry)
+		}
+	}
+	for i, j := 0, len(page)-1; i < j; i, j = i+1, j-1 {
+		page[i], page[j] = page[j], page[i]
+	}
+	return &types.AuditLog{Entries: page, Users: []types.User{{ID: "7", Username: "mod"}}}, nil
+}
+
+func entriesAt(start time.Time, n int) []types.AuditLogEntry {
+	entries := make([]types.AuditLogEntry, n)
+	for i := range entries {
+		id := utils.TimeToSnowflake(start.Add(time.Duration(i) * time.Minute))
+		entries[i] = types.AuditLogEntry{ID: id, ActionType: types.AuditLogMemberBanAdd, UserID: "7", TargetID: strconv.Itoa(i)}
+	}
+	return entries
+}
+
+func collect(records *[]Record) Sink {
+	return SinkFunc(func(ctx context.Context, batch []Record) error {
+		*records = append(*records, batch...)
+		return nil
+	})
+}
+
+func TestExporterSyncPagesAndResumes(t *testing.T) {
+	source := &fakeSource{entries: entriesAt(time.Now().Add(-time.Hour), 7)}
+	var records []Record
+	checkpoint := NewFileCheckpoint(filepath.Join(t.TempDir(), "audit.json"))
+
+	exporter, err := NewExporter(source, collect(&records), []string{"1"}, WithPageSize(3), WithCheckpoint(checkpoint))
+	if err != nil {
+		t.Fatalf("NewExporter() error = %v", err)
+	}
+	n, err := exporter.Sync(context.Background())
+	if err != nil || n != 7 {
+		t.Fatalf("Sync() = %d, %v", n, err)
+	}
+	for i, r := range records {
+		if r.TargetID != strconv.Itoa(i) {
+			t.Fatalf("records out of order at %d: %+v", i, r)
+		}
+		if r.GuildID != "1" || r.Username != "mod" || r.CreatedAt.IsZero() {
+			t.Fatalf("record not normalized: %+v", r)
+		}
+	}
+
+	// A new exporter sharing the checkpoint only picks up new entries.
+	source.entries = append(source.entries, entriesAt(time.Now(), 1)...)
+	records = nil
+	exporter, _ = NewExporter(source, collect(&records), []string{"1"}, WithCheckpoint(checkpoint))
+	if n, err := exporter.Sync(context.Background()); err != nil || n != 1 {
+		t.Fatalf("resumed Sync() = %d, %v", n, err)
+	}
+}
+
+func TestExporterSince(t *testing.T) {
+	start := time.Now().Add(-3 * time.Hour)
+	source := &fakeSource{entries: entriesAt(start, 3)}
+	var records []Record
+	exporter, _ := NewExporter(source, collect(&records), []string{"1"}, WithSince(start.Add(90*time.Second)))
+	if n, err := exporter.Sync(context.Background()); err != nil || n != 1 {
+		t.Fatalf("Sync() = %d, %v", n, err)
+	}
+}
+
+func TestExporterSinkFailureKeepsCheckpoint(t *testing.T) {
+	source := &fakeSource{entries: entriesAt(time.Now().Add(-time.Hour), 2)}
+	checkpoint := NewMemoryCheckpoint()
+	failing := SinkFunc(func(ctx context.Context, records []Record) error { return errors.New("disk full") })
+
+	exporter, _ := NewExporter(source, failing, []string{"1"}, WithCheckpoint(checkpoint))
+	if _, err := exporter.Sync(context.Background()); err == nil {
+		t.Fatal("expected sink error")
+	}
+	if cursor, _ := checkpoint.Load(context.Background(), "1"); cursor != "" {
+		t.Fatalf("checkpoint advanced past unwritten entries: %q", cursor)
+	}
+}
+
+func TestExporterRunStopsWithContext(t *testing.T) {
+	source := &fakeSource{err: errors.New("unavailable")}
+	exporter, _ := NewExporter(source, SinkFunc(func(context.Context, []Record) error { return nil }), []string{"1"}, WithInterval(5*time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := exporter.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if source.calls < 2 {
+		t.Fatalf("expected failed polls to be retried, got %d calls", source.calls)
+	}
+}
+
+func TestNewExporterValidation(t *testing.T) {
+	sink := SinkFunc(func(context.Context, []Record) error { return nil })
+	if _, err := NewExporter(nil, sink, []string{"1"}); err == nil {
+		t.Fatal("expected error without source")
+	}
+	if _, err := NewExporter(&fakeSource{}, nil, []string{"1"}); err == nil {
+		t.Fatal("expected error without sink")
+	}
+	if _, err := NewExporter(&fakeSource{}, sink, nil); err == nil {
+		t.Fatal("expected error without guilds")
+	}
+}
@@ -0,0 +1,68 @@
+// Package audit exports guild audit logs to durable storage. Discord keeps
+// audit log entries for 45 days; an Exporter pulls new entries incrementally
+// from a persisted checkpoint and writes them to a Sink (a JSON lines file, a
+// SQL table, or a webhook channel) so the history outlives that window.
+package audit
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/utils"
+)
+
+// Record is a normalized audit log entry, flattened for storage.
+type Record struct {
+	ID         string                      `json:"id"`
+	GuildID    string                      `json:"guild_id"`
+	ActionType types.AuditLogEvent         `json:"action_type"`
+	UserID     string                      `json:"user_id,omitempty"`
+	Username   string                      `json:"username,omitempty"`
+	TargetID   string                      `json:"target_id,omitempty"`
+	Reason     string                      `json:"reason,omitempty"`
+	Changes    []types.AuditLogChange      `json:"changes,omitempty"`
+	Options    *types.AuditLogEntryOptions `json:"options,omitempty"`
+	CreatedAt  time.Time                   `json:"created_at"`
+}
+
+// Normalize converts a page of the audit log into records ordered oldest
+// first, resolving usernames from the users included with the page.
+func Normalize(guildID string, log *types.AuditLog) []Record {
+	if log == nil || len(log.Entries) == 0 {
+		return nil
+	}
+	usernames := make(map[string]string, len(log.Users))
+	for _, user := range log.Users {
+		usernames[user.ID] = user.Username
+	}
+
+	records := make([]Record, 0, len(log.Entries))
+	for _, entry := range log.Entries {
+		createdAt, _ := utils.SnowflakeToTime(entry.ID)
+		records = append(records, Record{
+			ID:         entry.ID,
+			GuildID:    guildID,
+			ActionType: entry.ActionType,
+			UserID:     entry.UserID,
+			Username:   usernames[entry.UserID],
+			TargetID:   entry.TargetID,
+			Reason:     entry.Reason,
+			Changes:    entry.Changes,
+			Options:    entry.Options,
+			CreatedAt:  createdAt.UTC(),
+		})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return idLess(records[i].ID, records[j].ID)
+	})
+	return records
+}
+
+// idLess orders snowflakes numerically without parsing them.
+func idLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
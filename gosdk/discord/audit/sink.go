@@ -0,0 +1,309 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// Sink stores exported records. Write receives records oldest first and must
+// not return until they are durable; the checkpoint advances only after a
+// successful Write. A crash between the two re-delivers the batch, so sinks
+// should tolerate duplicate record IDs.
+type Sink interface {
+	Write(ctx context.Context, records []Record) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, records []Record) error
+
+// Write calls f.
+func (f SinkFunc) Write(ctx context.Context, records []Record) error {
+	return f(ctx, records)
+}
+
+// JSONLinesSink writes one JSON object per record, one per line.
+type JSONLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLinesSink writes records to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{w: w}
+}
+
+// OpenFileSink appends records to the JSON lines file at path, creating it if
+// needed. Close the sink when done.
+func OpenFileSink(path string) (*JSONLinesSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit sink: %w", err)
+	}
+	return NewJSONLinesSink(f), nil
+}
+
+// Write encodes records and, for files, syncs them to disk.
+func (s *JSONLinesSink) Write(ctx context.Context, records []Record) error {
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	for i := range records {
+		if err := enc.Encode(&records[i]); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := io.WriteString(s.w, buf.String()); err != nil {
+		return err
+	}
+	if f, ok := s.w.(*os.File); ok {
+		return f.Sync()
+	}
+	return nil
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (s *JSONLinesSink) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// tableName guards the SQL sink against identifier injection.
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// SQLSink inserts records into a table with the columns
+//
+//	id TEXT PRIMARY KEY, guild_id TEXT, action_type INTEGER, user_id TEXT,
+//	username TEXT, target_id TEXT, reason TEXT, changes TEXT, options TEXT,
+//	created_at TIMESTAMP
+//
+// changes and options hold JSON. Each Write runs in one transaction.
+type SQLSink struct {
+	db     *sql.DB
+	insert string
+}
+
+// SQLOption configures a SQLSink or SQLTrail.
+type SQLOption func(*sqlOptions)
+
+type sqlOptions struct {
+	suffix      string
+	placeholder func(n int) string
+}
+
+// WithDollarPlaceholders uses $1, $2, ... placeholders (PostgreSQL) instead of ?.
+func WithDollarPlaceholders() SQLOption {
+	return func(o *sqlOptions) {
+		o.placeholder = func(n int) string { return "$" + strconv.Itoa(n) }
+	}
+}
+
+// WithInsertSuffix appends a clause to the INSERT statement, typically a
+// conflict clause that skips IDs already stored.
+func WithInsertSuffix(suffix string) SQLOption {
+	return func(o *sqlOptions) {
+		if suffix = strings.TrimSpace(suffix); suffix != "" {
+			o.suffix = " " + suffix
+		}
+	}
+}
+
+// insertStatement validates the table name and builds the INSERT for columns.
+func insertStatement(db *sql.DB, table string, columns []string, opts []SQLOption) (string, error) {
+	if db == nil {
+		return "", &types.ValidationError{Field: "db", Message: "database is required"}
+	}
+	if !tableName.MatchString(table) {
+		return "", &types.ValidationError{Field: "table", Message: "table must be a plain identifier"}
+	}
+	o := sqlOptions{placeholder: func(int) string { return "?" }}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = o.placeholder(i + 1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)%s", table, strings.Join(columns, ", "), strings.Join(placeholders, ", "), o.suffix), nil
+}
+
+// NewSQLSink writes to table through db. To tolerate re-delivered IDs, add
+// a conflict clause for your database with WithInsertSuffix, such as
+// "ON CONFLICT (id) DO NOTHING".
+func NewSQLSink(db *sql.DB, table string, opts ...SQLOption) (*SQLSink, error) {
+	columns := []string{"id", "guild_id", "action_type", "user_id", "username", "target_id", "reason", "changes", "options", "created_at"}
+	insert, err := insertStatement(db, table, columns, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLSink{db: db, insert: insert}, nil
+}
+
+// Write inserts records in a single transaction.
+func (s *SQLSink) Write(ctx context.Context, records []Record) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, s.insert)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range records {
+		changes, err := jsonColumn(r.Changes)
+		if err != nil {
+			return err
+		}
+		options, err := jsonColumn(r.Options)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, r.ID, r.GuildID, int(r.ActionType), r.UserID, r.Username, r.TargetID, r.Reason, changes, options, r.CreatedAt); err != nil {
+			return fmt.Errorf("insert audit entry %s: %w", r.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func jsonColumn(v any) (sql.NullString, error) {
+	switch v := v.(type) {
+	case []types.AuditLogChange:
+		if len(v) == 0 {
+			return sql.NullString{}, nil
+		}
+	case *types.AuditLogEntryOptions:
+		if v == nil {
+			return sql.NullString{}, nil
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// MessageSender posts webhook messages. *webhook.Client satisfies it.
+type MessageSender interface {
+	Send(ctx context.Context, msg *types.WebhookMessage) error
+}
+
+// WebhookSink posts records to a channel as embeds, batching up to ten per
+// message and within Discord's 6000 character limit across a message's
+// embeds. It suits a human-readable mod log more than an archive.
+type WebhookSink struct {
+	sender MessageSender
+}
+
+// NewWebhookSink posts records through sender.
+func NewWebhookSink(sender MessageSender) *WebhookSink {
+	return &WebhookSink{sender: sender}
+}
+
+// Write posts records in order.
+func (s *WebhookSink) Write(ctx context.Context, records []Record) error {
+	var (
+		batch []types.Embed
+		size  int
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := s.sender.Send(ctx, &types.WebhookMessage{Embeds: batch, AllowedMentions: types.NoMentions()})
+		batch, size = nil, 0
+		return err
+	}
+	for _, r := range records {
+		embed := recordEmbed(r)
+		n := embedLength(&embed)
+		if n > limits.EmbedTotalLength {
+			// Capped field values keep entries well under the limit, but an
+			// entry Discord rejects would stall the exporter forever.
+			embed.Fields = []types.EmbedField{{Name: "Details", Value: "Too long to show; see the audit log."}}
+			n = embedLength(&embed)
+		}
+		if len(batch) == limits.MessageEmbeds || size+n > limits.EmbedTotalLength {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		batch = append(batch, embed)
+		size += n
+	}
+	return flush()
+}
+
+func recordEmbed(r Record) types.Embed {
+	createdAt := r.CreatedAt
+	embed := types.Embed{
+		Title:     fmt.Sprintf("Audit log action %d", r.ActionType),
+		Timestamp: &createdAt,
+		Footer:    &types.EmbedFooter{Text: "Entry " + r.ID},
+	}
+	if r.UserID != "" {
+		user := "<@" + r.UserID + ">"
+		if r.Username != "" {
+			user += " (" + r.Username + ")"
+		}
+		embed.Fields = append(embed.Fields, types.EmbedField{Name: "User", Value: user, Inline: true})
+	}
+	if r.TargetID != "" {
+		embed.Fields = append(embed.Fields, types.EmbedField{Name: "Target", Value: r.TargetID, Inline: true})
+	}
+	if r.Reason != "" {
+		embed.Fields = append(embed.Fields, types.EmbedField{Name: "Reason", Value: truncate(r.Reason, limits.EmbedFieldValueLength)})
+	}
+	if len(r.Changes) > 0 {
+		keys := make([]string, 0, len(r.Changes))
+		for _, change := range r.Changes {
+			keys = append(keys, change.Key)
+		}
+		embed.Fields = append(embed.Fields, types.EmbedField{Name: "Changed", Value: truncate(strings.Join(keys, ", "), limits.EmbedFieldValueLength)})
+	}
+	return embed
+}
+
+// embedLength counts the characters Discord totals across a message's embeds.
+func embedLength(e *types.Embed) int {
+	n := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	for _, field := range e.Fields {
+		n += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	if e.Footer != nil {
+		n += utf8.RuneCountInString(e.Footer.Text)
+	}
+	if e.Author != nil {
+		n += utf8.RuneCountInString(e.Author.Name)
+	}
+	return n
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,182 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+var testRecords = []Record{
+	{ID: "1", GuildID: "9", ActionType: types.AuditLogChannelCreate, UserID: "7", Changes: []types.AuditLogChange{{Key: "name", NewValue: json.RawMessage(`"general"`)}}, CreatedAt: time.Unix(0, 0).UTC()},
+	{ID: "2", GuildID: "9", ActionType: types.AuditLogMemberKick, TargetID: "8", Reason: "spam", CreatedAt: time.Unix(0, 0).UTC()},
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for range 2 {
+		sink, err := OpenFileSink(path)
+		if err != nil {
+			t.Fatalf("OpenFileSink() error = %v", err)
+		}
+		if err := sink.Write(context.Background(), testRecords); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		sink.Close()
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, r)
+	}
+	if len(lines) != 4 || lines[2].ID != "1" || lines[1].Reason != "spam" {
+		t.Fatalf("file should append records, got %+v", lines)
+	}
+}
+
+type recordingSender struct {
+	messages []*types.WebhookMessage
+}
+
+func (r *recordingSender) Send(ctx context.Context, msg *types.WebhookMessage) error {
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func TestWebhookSinkBatchesEmbeds(t *testing.T) {
+	records := make([]Record, 12)
+	for i := range records {
+		records[i] = testRecords[i%2]
+	}
+	sender := &recordingSender{}
+	if err := NewWebhookSink(sender).Write(context.Background(), records); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if len(sender.messages) != 2 || len(sender.messages[0].Embeds) != 10 || len(sender.messages[1].Embeds) != 2 {
+		t.Fatalf("unexpected batching: %d messages", len(sender.messages))
+	}
+	for _, msg := range sender.messages {
+		if err := msg.Validate(); err != nil {
+			t.Fatalf("invalid webhook message: %v", err)
+		}
+	}
+}
+
+func TestWebhookSinkLimitsEmbedLength(t *testing.T) {
+	records := make([]Record, 10)
+	for i := range records {
+		records[i] = testRecords[1]
+		records[i].Reason = strings.Repeat("x", 2000)
+	}
+	sender := &recordingSender{}
+	if err := NewWebhookSink(sender).Write(context.Background(), records); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if len(sender.messages) != 2 || len(sender.messages[0].Embeds) != 5 {
+		t.Fatalf("expected two messages of five embeds, got %d messages", len(sender.messages))
+	}
+	for _, msg := range sender.messages {
+		total := 0
+		for i := range msg.Embeds {
+			total += embedLength(&msg.Embeds[i])
+		}
+		if total > limits.EmbedTotalLength {
+			t.Fatalf("message embeds total %d characters", total)
+		}
+	}
+}
+
+func TestSQLSink(t *testing.T) {
+	db := sql.OpenDB(&fakeConnector{})
+	defer db.Close()
+
+	if _, err := NewSQLSink(db, "audit; DROP TABLE users"); err == nil {
+		t.Fatal("expected invalid table name to be rejected")
+	}
+	sink, err := NewSQLSink(db, "audit_log", WithDollarPlaceholders(), WithInsertSuffix("ON CONFLICT (id) DO NOTHING"))
+	if err != nil {
+		t.Fatalf("NewSQLSink() error = %v", err)
+	}
+	if err := sink.Write(context.Background(), testRecords); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if !strings.HasPrefix(fakeDB.query, "INSERT INTO audit_log (id, guild_id") || !strings.Contains(fakeDB.query, "$10) ON CONFLICT (id) DO NOTHING") {
+		t.Fatalf("unexpected statement: %s", fakeDB.query)
+	}
+	if len(fakeDB.rows) != 2 || !fakeDB.committed {
+		t.Fatalf("expected 2 rows in a committed transaction, got %d (committed=%v)", len(fakeDB.rows), fakeDB.committed)
+	}
+	if changes := fakeDB.rows[0][7]; changes != `[{"key":"name","new_value":"general"}]` {
+		t.Fatalf("changes column = %v", changes)
+	}
+	if options := fakeDB.rows[1][8]; options != nil {
+		t.Fatalf("empty options should be NULL, got %v", options)
+	}
+}
+
+// fakeDB is a minimal database/sql driver that records inserts.
+var fakeDB struct {
+	mu        sync.Mutex
+	query     string
+	rows      [][]driver.Value
+	committed bool
+}
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	fakeDB.mu.Lock()
+	fakeDB.query = query
+	fakeDB.mu.Unlock()
+	return fakeStmt{}, nil
+}
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fakeDB.mu.Lock()
+	fakeDB.rows = append(fakeDB.rows, args)
+	fakeDB.mu.Unlock()
+	return driver.RowsAffected(1), nil
+}
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error) { return nil, driver.ErrSkip }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error {
+	fakeDB.mu.Lock()
+	fakeDB.committed = true
+	fakeDB.mu.Unlock()
+	return nil
+}
+func (fakeTx) Rollback() error { return nil }
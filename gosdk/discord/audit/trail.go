@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+)
+
+// RecordRequest appends a request record from client.AuditTrailMiddleware,
+// so a JSON lines file can hold the outgoing request trail:
+//
+//	trail, _ := audit.OpenFileSink("requests.jsonl")
+//	rest.Use(client.AuditTrailMiddleware(trail))
+func (s *JSONLinesSink) RecordRequest(ctx context.Context, record client.RequestRecord) error {
+	data, err := json.Marshal(&record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(data); err != nil {
+		return err
+	}
+	if f, ok := s.w.(*os.File); ok {
+		return f.Sync()
+	}
+	return nil
+}
+
+var _ client.RequestRecorder = (*JSONLinesSink)(nil)
+
+// SQLTrail inserts request records into a table with the columns
+//
+//	time TIMESTAMP, method TEXT, path TEXT, actor TEXT, reason TEXT,
+//	payload TEXT, status INTEGER, error TEXT, duration_ms INTEGER
+//
+// Grant the bot INSERT only to keep the table append-only.
+type SQLTrail struct {
+	db     *sql.DB
+	insert string
+}
+
+// NewSQLTrail writes request records to table through db. WithInsertSuffix
+// is accepted but rarely needed since records have no natural key.
+func NewSQLTrail(db *sql.DB, table string, opts ...SQLOption) (*SQLTrail, error) {
+	columns := []string{"time", "method", "path", "actor", "reason", "payload", "status", "error", "duration_ms"}
+	insert, err := insertStatement(db, table, columns, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLTrail{db: db, insert: insert}, nil
+}
+
+// RecordRequest inserts one record.
+func (t *SQLTrail) RecordRequest(ctx context.Context, r client.RequestRecord) error {
+	_, err := t.db.ExecContext(ctx, t.insert, r.Time, r.Method, r.Path, nullString(r.Actor), nullString(r.Reason),
+		nullString(r.Payload), r.Status, nullString(r.Error), r.Duration.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("insert request record %s %s: %w", r.Method, r.Path, err)
+	}
+	return nil
+}
+
+var _ client.RequestRecorder = (*SQLTrail)(nil)
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
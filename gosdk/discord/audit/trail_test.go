@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+)
+
+var testRequest = client.RequestRecord{
+	Time:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	Method:   "PUT",
+	Path:     "/api/v10/guilds/1/bans/2",
+	Actor:    "moderator:42",
+	Reason:   "raid",
+	Payload:  `{"delete_message_seconds":3600}`,
+	Status:   204,
+	Duration: 120 * time.Millisecond,
+}
+
+func TestJSONLinesSinkRecordRequest(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLinesSink(&buf)
+	if err := sink.RecordRequest(context.Background(), testRequest); err != nil {
+		t.Fatalf("RecordRequest() error = %v", err)
+	}
+	if err := sink.RecordRequest(context.Background(), client.RequestRecord{Method: "DELETE", Path: "/x", Error: "boom"}); err != nil {
+		t.Fatalf("RecordRequest() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", buf.String())
+	}
+	var got client.RequestRecord
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got != testRequest {
+		t.Fatalf("round trip = %+v", got)
+	}
+}
+
+func TestSQLTrail(t *testing.T) {
+	db := sql.OpenDB(&fakeConnector{})
+	defer db.Close()
+	fakeDB.rows = nil
+
+	if _, err := NewSQLTrail(nil, "requests"); err == nil {
+		t.Fatal("expected missing database to be rejected")
+	}
+	trail, err := NewSQLTrail(db, "request_trail", WithDollarPlaceholders())
+	if err != nil {
+		t.Fatalf("NewSQLTrail() error = %v", err)
+	}
+	if err := trail.RecordRequest(context.Background(), testRequest); err != nil {
+		t.Fatalf("RecordRequest() error = %v", err)
+	}
+
+	if !strings.HasPrefix(fakeDB.query, "INSERT INTO request_trail (time, method, path") || !strings.HasSuffix(fakeDB.query, "$9)") {
+		t.Fatalf("unexpected statement: %s", fakeDB.query)
+	}
+	if len(fakeDB.rows) != 1 {
+		t.Fatalf("expected one row, got %d", len(fakeDB.rows))
+	}
+	row := fakeDB.rows[0]
+	if row[3] != "moderator:42" || row[6] != int64(204) || row[7] != nil || row[8] != int64(120) {
+		t.Fatalf("unexpected row: %v", row)
+	}
+}
@@ -0,0 +1,250 @@
+// Package autodelete deletes messages after a delay, such as temporary
+// status messages and verification prompts. Pending deletions live in a
+// Store, so a FileStore keeps them across restarts.
+package autodelete
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+// DefaultRetryDelay is how long a failed deletion waits before it is retried.
+// Deletions Discord answers with 403 or 404 are dropped instead.
+const DefaultRetryDelay = time.Minute
+
+// MessageDeleter deletes messages. *client.MessageService satisfies it.
+type MessageDeleter interface {
+	DeleteMessage(ctx context.Context, channelID, messageID string) error
+}
+
+// Option configures a Deleter.
+type Option func(*Deleter)
+
+// WithRetryDelay sets how long a failed deletion waits before it is retried.
+func WithRetryDelay(d time.Duration) Option {
+	return func(del *Deleter) {
+		if d > 0 {
+			del.retryDelay = d
+		}
+	}
+}
+
+// WithLogger sets the logger for failed deletions.
+func WithLogger(log logger.Interface) Option {
+	return func(del *Deleter) {
+		if lg := logger.From(log); lg != nil {
+			del.logger = lg
+		}
+	}
+}
+
+// Deleter deletes scheduled messages once their TTL passes. Schedule
+// records deletions; Run carries them out, starting with any left in the
+// store by a previous process.
+//
+//	deleter := autodelete.New(rest.Messages(), autodelete.NewFileStore("deletions.json"))
+//	go deleter.Run(ctx)
+//	msg, _ := rest.Messages().CreateMessage(ctx, channelID, &types.MessageCreateParams{Content: "Verifying..."})
+//	deleter.ScheduleMessage(ctx, msg, 30*time.Second)
+type Deleter struct {
+	messages   MessageDeleter
+	store      Store
+	retryDelay time.Duration
+	logger     *logger.Logger
+	now        func() time.Time
+
+	wake chan struct{}
+
+	mu      sync.Mutex
+	retryAt map[string]time.Time
+}
+
+// New creates a Deleter backed by store. A nil store keeps tasks in memory.
+func New(messages MessageDeleter, store Store, opts ...Option) *Deleter {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	d := &Deleter{
+		messages:   messages,
+		store:      store,
+		retryDelay: DefaultRetryDelay,
+		logger:     logger.Default(),
+		now:        time.Now,
+		wake:       make(chan struct{}, 1),
+		retryAt:    make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	d.logger = d.logger.WithSubsystem(logger.SubsystemClient)
+	return d
+}
+
+// Schedule deletes a message once ttl has passed. Scheduling the same
+// message again replaces its deadline.
+func (d *Deleter) Schedule(ctx context.Context, channelID, messageID string, ttl time.Duration) error {
+	if channelID == "" || messageID == "" {
+		return &types.ValidationError{Field: "message", Message: "channel and message IDs are required"}
+	}
+	if ttl < 0 {
+		return &types.ValidationError{Field: "ttl", Message: "ttl must not be negative"}
+	}
+	task := Task{ChannelID: channelID, MessageID: messageID, DeleteAt: d.now().Add(ttl).UTC()}
+	if err := d.store.Add(ctx, task); err != nil {
+		return err
+	}
+	d.notify()
+	return nil
+}
+
+// ScheduleMessage deletes msg once ttl has passed.
+func (d *Deleter) ScheduleMessage(ctx context.Context, msg *types.Message, ttl time.Duration) error {
+	if msg == nil {
+		return &types.ValidationError{Field: "message", Message: "message is required"}
+	}
+	return d.Schedule(ctx, msg.ChannelID, msg.ID, ttl)
+}
+
+// Cancel keeps a scheduled message.
+func (d *Deleter) Cancel(ctx context.Context, channelID, messageID string) error {
+	if err := d.store.Remove(ctx, channelID, messageID); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	delete(d.retryAt, Task{ChannelID: channelID, MessageID: messageID}.key())
+	d.mu.Unlock()
+	d.notify()
+	return nil
+}
+
+// Pending returns the scheduled deletions, soonest first.
+func (d *Deleter) Pending(ctx context.Context) ([]Task, error) {
+	return d.store.List(ctx)
+}
+
+// Run deletes due messages until ctx ends, which it reports as its error.
+// Messages that are already gone count as deleted. Other failures are
+// logged and retried after the retry delay. Deletions run at background
+// priority so they never delay interactive requests.
+func (d *Deleter) Run(ctx context.Context) error {
+	for {
+		next, err := d.deleteDue(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			d.logger.Warn("scheduled deletion store failed", "error", err)
+			next = d.now().Add(d.retryDelay)
+		}
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(next.Sub(d.now()))
+			fire = timer.C
+		}
+		select {
+		case <-ctx.Done():
+		case <-d.wake:
+		case <-fire:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// deleteDue deletes every task whose deadline has passed and returns the
+// next deadline, or zero when nothing is pending.
+func (d *Deleter) deleteDue(ctx context.Context) (time.Time, error) {
+	tasks, err := d.store.List(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	reqCtx := client.WithRequestPriority(ctx, client.PriorityBackground)
+
+	var next time.Time
+	for _, task := range tasks {
+		due := d.dueAt(task)
+		if now := d.now(); due.After(now) {
+			if next.IsZero() || due.Before(next) {
+				next = due
+			}
+			continue
+		}
+		err := d.messages.DeleteMessage(reqCtx, task.ChannelID, task.MessageID)
+		if err != nil && permanentFailure(err) {
+			if !errors.Is(err, types.ErrNotFound) {
+				d.logger.Warn("scheduled message deletion dropped",
+					"channel_id", task.ChannelID,
+					"message_id", task.MessageID,
+					"error", err,
+				)
+			}
+			err = nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return time.Time{}, ctx.Err()
+			}
+			retry := d.now().Add(d.retryDelay)
+			d.mu.Lock()
+			d.retryAt[task.key()] = retry
+			d.mu.Unlock()
+			d.logger.Warn("scheduled message deletion failed",
+				"channel_id", task.ChannelID,
+				"message_id", task.MessageID,
+				"error", err,
+			)
+			if next.IsZero() || retry.Before(next) {
+				next = retry
+			}
+			continue
+		}
+		d.mu.Lock()
+		delete(d.retryAt, task.key())
+		d.mu.Unlock()
+		if err := d.store.Remove(ctx, task.ChannelID, task.MessageID); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return next, nil
+}
+
+// permanentFailure reports whether retrying a deletion cannot succeed: the
+// message is already gone (404) or the bot may not delete it (403).
+func permanentFailure(err error) bool {
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusForbidden
+}
+
+func (d *Deleter) dueAt(task Task) time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if retry, ok := d.retryAt[task.key()]; ok && retry.After(task.DeleteAt) {
+		return retry
+	}
+	return task.DeleteAt
+}
+
+func (d *Deleter) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
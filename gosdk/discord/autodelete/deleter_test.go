@@ -0,0 +1,175 @@
+package autodelete
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+type fakeMessages struct {
+	mu       sync.Mutex
+	deleted  []string
+	failures map[string]int
+	priority client.Priority
+	done     chan string
+}
+
+func newFakeMessages() *fakeMessages {
+	return &fakeMessages{failures: make(map[string]int), done: make(chan string, 10)}
+}
+
+func (f *fakeMessages) DeleteMessage(ctx context.Context, channelID, messageID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.priority, _ = client.RequestPriority(ctx)
+	if f.failures[messageID] > 0 {
+		f.failures[messageID]--
+		return &types.APIError{StatusCode: 500, Message: "try again"}
+	}
+	f.deleted = append(f.deleted, messageID)
+	f.done <- messageID
+	if messageID == "gone" {
+		return &types.APIError{StatusCode: 404, Message: "Unknown Message"}
+	}
+	if messageID == "forbidden" {
+		return &types.APIError{StatusCode: 403, Message: "Missing Permissions"}
+	}
+	return nil
+}
+
+func waitDeleted(t *testing.T, f *fakeMessages, want string) {
+	t.Helper()
+	select {
+	case got := <-f.done:
+		if got != want {
+			t.Fatalf("deleted %s, want %s", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s", want)
+	}
+}
+
+func TestDeleterDeletesAfterTTL(t *testing.T) {
+	messages := newFakeMessages()
+	store := NewMemoryStore()
+	deleter := New(messages, store)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go deleter.Run(ctx)
+
+	if err := deleter.Schedule(ctx, "c", "later", time.Hour); err != nil {
+		t.Fatalf("Schedule error: %v", err)
+	}
+	if err := deleter.ScheduleMessage(ctx, &types.Message{ID: "soon", ChannelID: "c"}, 20*time.Millisecond); err != nil {
+		t.Fatalf("ScheduleMessage error: %v", err)
+	}
+	if err := deleter.Schedule(ctx, "c", "gone", 0); err != nil {
+		t.Fatalf("Schedule error: %v", err)
+	}
+
+	waitDeleted(t, messages, "gone")
+	waitDeleted(t, messages, "soon")
+
+	pending, _ := deleter.Pending(ctx)
+	if len(pending) != 1 || pending[0].MessageID != "later" {
+		t.Fatalf("expected only the later task to remain, got %+v", pending)
+	}
+	messages.mu.Lock()
+	defer messages.mu.Unlock()
+	if messages.priority != client.PriorityBackground {
+		t.Fatalf("deletions should run at background priority, got %v", messages.priority)
+	}
+}
+
+func TestDeleterRetriesAndCancels(t *testing.T) {
+	messages := newFakeMessages()
+	messages.failures["flaky"] = 1
+	deleter := New(messages, nil, WithRetryDelay(20*time.Millisecond),
+		WithLogger(logger.New(logger.ErrorLevel, "json", io.Discard)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go deleter.Run(ctx)
+
+	deleter.Schedule(ctx, "c", "keep", 30*time.Millisecond)
+	if err := deleter.Cancel(ctx, "c", "keep"); err != nil {
+		t.Fatalf("Cancel error: %v", err)
+	}
+	deleter.Schedule(ctx, "c", "flaky", 0)
+	waitDeleted(t, messages, "flaky")
+
+	time.Sleep(50 * time.Millisecond)
+	messages.mu.Lock()
+	defer messages.mu.Unlock()
+	if len(messages.deleted) != 1 {
+		t.Fatalf("cancelled message was deleted: %v", messages.deleted)
+	}
+}
+
+func TestDeleterResumesFromFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deletions.json")
+	first := New(newFakeMessages(), NewFileStore(path))
+	if err := first.Schedule(context.Background(), "c", "m1", 10*time.Millisecond); err != nil {
+		t.Fatalf("Schedule error: %v", err)
+	}
+
+	// A new process picks the task up from the file.
+	messages := newFakeMessages()
+	second := New(messages, NewFileStore(path))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- second.Run(ctx) }()
+	waitDeleted(t, messages, "m1")
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run returned %v", err)
+	}
+
+	pending, err := NewFileStore(path).List(context.Background())
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("expected an empty store, got %+v (%v)", pending, err)
+	}
+}
+
+func TestScheduleValidation(t *testing.T) {
+	deleter := New(newFakeMessages(), nil)
+	if err := deleter.Schedule(context.Background(), "", "m", time.Second); err == nil {
+		t.Fatal("expected error for missing channel")
+	}
+	if err := deleter.Schedule(context.Background(), "c", "m", -time.Second); err == nil {
+		t.Fatal("expected error for negative ttl")
+	}
+	if err := deleter.ScheduleMessage(context.Background(), nil, time.Second); err == nil {
+		t.Fatal("expected error for nil message")
+	}
+}
+
+func TestDeleterDropsForbidden(t *testing.T) {
+	messages := newFakeMessages()
+	store := NewMemoryStore()
+	deleter := New(messages, store, WithRetryDelay(10*time.Millisecond),
+		WithLogger(logger.New(logger.ErrorLevel, "json", io.Discard)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go deleter.Run(ctx)
+
+	deleter.Schedule(ctx, "c", "forbidden", 0)
+	waitDeleted(t, messages, "forbidden")
+
+	time.Sleep(50 * time.Millisecond)
+	if pending, _ := deleter.Pending(ctx); len(pending) != 0 {
+		t.Fatalf("expected forbidden task to be dropped, got %+v", pending)
+	}
+	messages.mu.Lock()
+	defer messages.mu.Unlock()
+	if len(messages.deleted) != 1 {
+		t.Fatalf("forbidden deletion was retried: %v", messages.deleted)
+	}
+}
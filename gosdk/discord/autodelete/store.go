@@ -0,0 +1,186 @@
+package autodelete
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Task is a message waiting to be deleted.
+type Task struct {
+	ChannelID string    `json:"channel_id"`
+	MessageID string    `json:"message_id"`
+	DeleteAt  time.Time `json:"delete_at"`
+}
+
+func (t Task) key() string {
+	return t.ChannelID + "/" + t.MessageID
+}
+
+// Store persists scheduled deletions so they survive restarts.
+type Store interface {
+	// Add stores a task, replacing any task for the same message.
+	Add(ctx context.Context, task Task) error
+	// Remove drops the task for a message. Removing a missing task is not an error.
+	Remove(ctx context.Context, channelID, messageID string) error
+	// List returns every stored task.
+	List(ctx context.Context) ([]Task, error)
+}
+
+// MemoryStore keeps tasks in memory. Pending deletions are lost on restart;
+// use a FileStore to keep them.
+type MemoryStore struct {
+	mu    sync.Mutex
+	tasks map[string]Task
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tasks: make(map[string]Task)}
+}
+
+// Add stores task.
+func (m *MemoryStore) Add(ctx context.Context, task Task) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tasks[task.key()] = task
+	return nil
+}
+
+// Remove drops the task for a message.
+func (m *MemoryStore) Remove(ctx context.Context, channelID, messageID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tasks, Task{ChannelID: channelID, MessageID: messageID}.key())
+	return nil
+}
+
+// List returns the stored tasks, soonest first.
+func (m *MemoryStore) List(ctx context.Context) ([]Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tasks := make([]Task, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		tasks = append(tasks, task)
+	}
+	sortTasks(tasks)
+	return tasks, nil
+}
+
+// FileStore keeps tasks as a JSON array in a file. Writes replace the file
+// atomically, so a crash never leaves a truncated store.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore uses the file at path, which need not exist yet.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Add stores task.
+func (f *FileStore) Add(ctx context.Context, task Task) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tasks, err := f.read()
+	if err != nil {
+		return err
+	}
+	tasks[task.key()] = task
+	return f.write(tasks)
+}
+
+// Remove drops the task for a message.
+func (f *FileStore) Remove(ctx context.Context, channelID, messageID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tasks, err := f.read()
+	if err != nil {
+		return err
+	}
+	key := Task{ChannelID: channelID, MessageID: messageID}.key()
+	if _, ok := tasks[key]; !ok {
+		return nil
+	}
+	delete(tasks, key)
+	return f.write(tasks)
+}
+
+// List returns the stored tasks, soonest first.
+func (f *FileStore) List(ctx context.Context) ([]Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tasks, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		list = append(list, task)
+	}
+	sortTasks(list)
+	return list, nil
+}
+
+func (f *FileStore) read() (map[string]Task, error) {
+	tasks := make(map[string]Task)
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tasks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read deletion store: %w", err)
+	}
+	var list []Task
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("read deletion store: %w", err)
+	}
+	for _, task := range list {
+		tasks[task.key()] = task
+	}
+	return tasks, nil
+}
+
+func (f *FileStore) write(tasks map[string]Task) error {
+	list := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		list = append(list, task)
+	}
+	sortTasks(list)
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write deletion store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write deletion store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write deletion store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("write deletion store: %w", err)
+	}
+	return nil
+}
+
+func sortTasks(tasks []Task) {
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].DeleteAt.Equal(tasks[j].DeleteAt) {
+			return tasks[i].DeleteAt.Before(tasks[j].DeleteAt)
+		}
+		return tasks[i].key() < tasks[j].key()
+	})
+}
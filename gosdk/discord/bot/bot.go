@@ -0,0 +1,194 @@
+// Package bot assembles the SDK's clients from a declarative config file so
+// a deployment is described by one reviewable document:
+//
+//	bot:
+//	  token: {env: DISCORD_BOT_TOKEN}
+//	  intents: [default, message_content]
+//	  commands: commands.yaml
+//	  modules: [gateway, state, interactions]
+//	  interactions:
+//	    public_key: ${DISCORD_PUBLIC_KEY}
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/config"
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/interactions"
+	"github.com/mtreilly/godiscord/gosdk/discord/state"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// Bot holds the components enabled by a config. Modules that are not enabled
+// are left nil.
+type Bot struct {
+	Config   *config.Config
+	Logger   *logger.Logger
+	REST     *client.Client
+	Commands []*types.ApplicationCommand
+
+	Gateway      *gateway.Client
+	State        *state.State
+	Interactions *interactions.Server
+
+	closeLog func() error
+}
+
+// Load reads the config file at path and builds a Bot from it.
+func Load(path string) (*Bot, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg)
+}
+
+// New builds a Bot from cfg. Close the bot to release its log file, if any.
+func New(cfg *config.Config) (*Bot, error) {
+	if cfg == nil {
+		return nil, &types.ValidationError{Field: "config", Message: "config is required"}
+	}
+	if err := cfg.Bot.Validate(); err != nil {
+		return nil, err
+	}
+	token, err := cfg.ResolveToken()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bot{Config: cfg}
+	b.Logger, b.closeLog, err = newLogger(cfg.Logging)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.build(token); err != nil {
+		b.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *Bot) build(token string) error {
+	cfg := b.Config
+	rest, err := client.New(token,
+		client.WithLogger(b.Logger),
+		client.WithTimeout(cfg.Client.Timeout),
+		client.WithMaxRetries(cfg.Client.Retries),
+		client.WithStrategyName(cfg.Client.RateLimit.Strategy),
+	)
+	if err != nil {
+		return err
+	}
+	b.REST = rest
+
+	if cfg.Bot.Commands != "" {
+		if b.Commands, err = LoadCommands(cfg.Bot.Commands); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Bot.ModuleEnabled(config.ModuleGateway) {
+		intents, err := ParseIntents(cfg.Bot.Intents)
+		if err != nil {
+			return err
+		}
+		if b.Gateway, err = gateway.NewClient(token, int(intents), gateway.WithGatewayLogger(b.Logger)); err != nil {
+			return err
+		}
+	}
+	if cfg.Bot.ModuleEnabled(config.ModuleState) {
+		b.State = state.New()
+		b.State.Register(b.Gateway.Dispatcher())
+	}
+	if cfg.Bot.ModuleEnabled(config.ModuleInteractions) {
+		if b.Interactions, err = interactions.NewServer(cfg.Bot.Interactions.PublicKey, interactions.WithLogger(b.Logger)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncCommands overwrites the application's global commands with the
+// manifest. It requires discord.application_id.
+func (b *Bot) SyncCommands(ctx context.Context) ([]*types.ApplicationCommand, error) {
+	if b.Config.Discord.ApplicationID == "" {
+		return nil, &types.ValidationError{Field: "discord.application_id", Message: "application ID is required to sync commands"}
+	}
+	return b.REST.ApplicationCommands(b.Config.Discord.ApplicationID).BulkOverwriteGlobalApplicationCommands(ctx, b.Commands)
+}
+
+// Run connects the gateway and serves the interactions endpoint, as enabled,
+// until ctx is done or the endpoint fails.
+func (b *Bot) Run(ctx context.Context) error {
+	if b.Gateway != nil {
+		if err := b.Gateway.Connect(ctx); err != nil {
+			return fmt.Errorf("gateway connect: %w", err)
+		}
+		defer b.Gateway.Disconnect()
+	}
+
+	serveErr := make(chan error, 1)
+	var srv *http.Server
+	if b.Interactions != nil {
+		handler := b.Interactions.Handler(interactions.WithPathPrefix(b.Config.Bot.Interactions.Path))
+		srv = &http.Server{Addr: b.Config.Bot.Interactions.Listen, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			b.Logger.Info("serving interactions", "addr", srv.Addr, "path", b.Config.Bot.Interactions.Path)
+			serveErr <- srv.ListenAndServe()
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("interactions server: %w", err)
+		}
+	}
+	if srv != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// Close releases resources opened by New.
+func (b *Bot) Close() error {
+	if b.closeLog != nil {
+		return b.closeLog()
+	}
+	return nil
+}
+
+// newLogger builds a logger writing to stderr, stdout, or a file path.
+func newLogger(cfg config.LoggingConfig) (*logger.Logger, func() error, error) {
+	var w io.Writer
+	var closeFn func() error
+	switch cfg.Output {
+	case "", "stderr":
+		w = os.Stderr
+	case "stdout":
+		w = os.Stdout
+	default:
+		f, err := os.OpenFile(cfg.Output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log output: %w", err)
+		}
+		w, closeFn = f, f.Close
+	}
+	return logger.New(logger.ParseLevel(cfg.Level), cfg.Format, w), closeFn, nil
+}
@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/config"
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadBuildsEnabledModules(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	writeFile(t, dir, "commands.yaml", `
+- name: ping
+  description: Check the bot is alive
+- name: echo
+  description: Repeat text
+  options:
+    - type: 3
+      name: text
+      description: What to say
+      required: true
+`)
+	t.Setenv("TEST_PUBLIC_KEY", hex.EncodeToString(pub))
+	path := writeFile(t, dir, "bot.yaml", `
+logging:
+  output: `+filepath.Join(dir, "bot.log")+`
+bot:
+  token:
+    value: test-token
+  intents: [guilds, GUILD_MESSAGES]
+  commands: `+filepath.Join(dir, "commands.yaml")+`
+  modules: [gateway, state, interactions]
+  interactions:
+    public_key: ${TEST_PUBLIC_KEY}
+`)
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	defer b.Close()
+
+	if b.REST == nil || b.Gateway == nil || b.State == nil || b.Interactions == nil {
+		t.Fatalf("expected every module to be built: %+v", b)
+	}
+	if len(b.Commands) != 2 || b.Commands[1].Options[0].Name != "text" || !b.Commands[1].Options[0].Required {
+		t.Fatalf("unexpected commands: %+v", b.Commands)
+	}
+}
+
+func TestNewWithoutModules(t *testing.T) {
+	cfg := config.Default()
+	cfg.Bot.Token.Value = "test-token"
+	b, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if b.REST == nil || b.Gateway != nil || b.State != nil || b.Interactions != nil {
+		t.Fatalf("only the REST client should be built: %+v", b)
+	}
+}
+
+func TestParseIntents(t *testing.T) {
+	mask, err := ParseIntents([]string{"guilds", "MESSAGE_CONTENT"})
+	if err != nil {
+		t.Fatalf("ParseIntents() error = %v", err)
+	}
+	if mask != gateway.IntentGuilds|gateway.IntentMessageContent {
+		t.Fatalf("unexpected mask %b", mask)
+	}
+	if mask, _ := ParseIntents(nil); mask != gateway.DefaultIntents() {
+		t.Fatalf("expected default intents, got %b", mask)
+	}
+	if _, err := ParseIntents([]string{"telepathy"}); err == nil {
+		t.Fatal("expected error for unknown intent")
+	}
+}
+
+func TestLoadCommandsRejectsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "commands.json", `[{"name":"ping","description":"a"},{"name":"ping","description":"b"}]`)
+	if _, err := LoadCommands(path); err == nil {
+		t.Fatal("expected duplicate command error")
+	}
+	path = writeFile(t, dir, "bad.yaml", "- description: missing name\n")
+	if _, err := LoadCommands(path); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// LoadCommands reads a command manifest: a YAML or JSON list of application
+// commands using Discord's field names. Every command is validated.
+func LoadCommands(path string) ([]*types.ApplicationCommand, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read command manifest: %w", err)
+	}
+
+	// The command types only carry json tags, so YAML is decoded generically
+	// and round-tripped through JSON.
+	var raw []any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse command manifest: %w", err)
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse command manifest: %w", err)
+	}
+	var commands []*types.ApplicationCommand
+	if err := json.Unmarshal(encoded, &commands); err != nil {
+		return nil, fmt.Errorf("failed to parse command manifest: %w", err)
+	}
+
+	seen := make(map[string]bool, len(commands))
+	for i, cmd := range commands {
+		if err := cmd.Validate(); err != nil {
+			return nil, fmt.Errorf("command %d (%s): %w", i, cmd.Name, err)
+		}
+		key := fmt.Sprintf("%d/%s", cmd.Type, cmd.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("command %q is defined twice", cmd.Name)
+		}
+		seen[key] = true
+	}
+	return commands, nil
+}
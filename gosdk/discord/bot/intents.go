@@ -0,0 +1,35 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+)
+
+// ParseIntents combines intent names into a mask. Names are those accepted by
+// gateway.IntentByName, matched case-insensitively. "default" and "all"
+// expand to gateway.DefaultIntents and gateway.AllIntents; no names means
+// default.
+func ParseIntents(names []string) (gateway.Intent, error) {
+	if len(names) == 0 {
+		return gateway.DefaultIntents(), nil
+	}
+	var mask gateway.Intent
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		switch key {
+		case "default":
+			mask |= gateway.DefaultIntents()
+		case "all":
+			mask |= gateway.AllIntents()
+		default:
+			intent, ok := gateway.IntentByName(key)
+			if !ok {
+				return 0, fmt.Errorf("unknown intent %q", name)
+			}
+			mask |= intent
+		}
+	}
+	return mask, nil
+}
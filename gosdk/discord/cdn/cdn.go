@@ -0,0 +1,223 @@
+// Package cdn builds Discord CDN URLs for avatars, guild icons and banners,
+// emoji, stickers, role icons and attachments from the hashes and IDs on the
+// types package models.
+package cdn
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+const (
+	// BaseURL is the host for images and attachments.
+	BaseURL = "https://cdn.discordapp.com"
+	// MediaURL serves GIF stickers, which the main CDN does not.
+	MediaURL = "https://media.discordapp.net"
+)
+
+// Format is an image file extension.
+type Format string
+
+const (
+	FormatPNG    Format = "png"
+	FormatJPEG   Format = "jpg"
+	FormatWebP   Format = "webp"
+	FormatGIF    Format = "gif"
+	FormatLottie Format = "json"
+)
+
+const (
+	minSize = 16
+	maxSize = 4096
+)
+
+type options struct {
+	size   int
+	format Format
+	static bool
+}
+
+// Option customises a CDN URL.
+type Option func(*options)
+
+// WithSize requests an image of the given width. Discord only serves powers
+// of two between 16 and 4096, so other sizes are rounded up to the next one
+// and clamped to that range.
+func WithSize(size int) Option {
+	return func(o *options) {
+		if size <= 0 {
+			return
+		}
+		n := minSize
+		for n < size && n < maxSize {
+			n <<= 1
+		}
+		o.size = n
+	}
+}
+
+// WithFormat sets the image format. Animated hashes default to GIF and
+// everything else to PNG.
+func WithFormat(format Format) Option {
+	return func(o *options) {
+		if format != "" {
+			o.format = format
+		}
+	}
+}
+
+// WithStatic serves the first frame of animated images.
+func WithStatic() Option {
+	return func(o *options) {
+		o.static = true
+	}
+}
+
+// IsAnimated reports whether an image hash refers to an animated image.
+func IsAnimated(hash string) bool {
+	return strings.HasPrefix(hash, "a_")
+}
+
+// UserAvatar returns the user's avatar, or their default avatar when they
+// have not set one.
+func UserAvatar(user *types.User, opts ...Option) string {
+	if user == nil {
+		return ""
+	}
+	if user.Avatar == "" {
+		return DefaultAvatar(user)
+	}
+	return image("avatars/"+user.ID, user.Avatar, opts)
+}
+
+// DefaultAvatar returns the generated avatar Discord shows for users
+// without one. Users on the new username system are assigned one of six
+// by ID; legacy users one of five by discriminator.
+func DefaultAvatar(user *types.User) string {
+	if user == nil {
+		return ""
+	}
+	var index uint64
+	if user.Discriminator == "" || user.Discriminator == "0" {
+		id, _ := strconv.ParseUint(user.ID, 10, 64)
+		index = (id >> 22) % 6
+	} else {
+		discriminator, _ := strconv.ParseUint(user.Discriminator, 10, 64)
+		index = discriminator % 5
+	}
+	return fmt.Sprintf("%s/embed/avatars/%d.png", BaseURL, index)
+}
+
+// UserBanner returns the user's profile banner, or "" when unset.
+func UserBanner(user *types.User, opts ...Option) string {
+	if user == nil || user.Banner == "" {
+		return ""
+	}
+	return image("banners/"+user.ID, user.Banner, opts)
+}
+
+// GuildIcon returns the guild's icon, or "" when unset.
+func GuildIcon(guild *types.Guild, opts ...Option) string {
+	if guild == nil || guild.Icon == "" {
+		return ""
+	}
+	return image("icons/"+guild.ID, guild.Icon, opts)
+}
+
+// GuildBanner returns the guild's banner, or "" when unset.
+func GuildBanner(guild *types.Guild, opts ...Option) string {
+	if guild == nil || guild.Banner == "" {
+		return ""
+	}
+	return image("banners/"+guild.ID, guild.Banner, opts)
+}
+
+// GuildSplash returns the guild's invite splash, or "" when unset.
+func GuildSplash(guild *types.Guild, opts ...Option) string {
+	if guild == nil || guild.Splash == "" {
+		return ""
+	}
+	return image("splashes/"+guild.ID, guild.Splash, opts)
+}
+
+// RoleIcon returns the role's icon, or "" when it has none.
+func RoleIcon(role *types.Role, opts ...Option) string {
+	if role == nil || role.Icon == "" {
+		return ""
+	}
+	return image("role-icons/"+role.ID, role.Icon, opts)
+}
+
+// Emoji returns a custom emoji's image, or "" for unicode emoji.
+func Emoji(emoji *types.Emoji, opts ...Option) string {
+	if emoji == nil || emoji.ID == "" {
+		return ""
+	}
+	o := apply(opts)
+	if o.format == "" {
+		o.format = FormatPNG
+		if emoji.Animated && !o.static {
+			o.format = FormatGIF
+		}
+	}
+	return build(BaseURL, "emojis/"+emoji.ID, o)
+}
+
+// Sticker returns a sticker's file. Lottie stickers are JSON animations and
+// GIF stickers are served from MediaURL; sizes only apply to PNG and GIF.
+func Sticker(sticker *types.Sticker, opts ...Option) string {
+	if sticker == nil || sticker.ID == "" {
+		return ""
+	}
+	o := apply(opts)
+	host := BaseURL
+	switch sticker.FormatType {
+	case types.StickerFormatLottie:
+		o.format, o.size = FormatLottie, 0
+	case types.StickerFormatGIF:
+		o.format, host = FormatGIF, MediaURL
+	default:
+		o.format = FormatPNG
+	}
+	return build(host, "stickers/"+sticker.ID, o)
+}
+
+// Attachment returns the unsigned URL of an uploaded file. Discord only
+// serves attachments with signature parameters, so use
+// Messages().RefreshAttachmentURL to turn this into a working link.
+func Attachment(channelID, attachmentID, filename string) string {
+	return fmt.Sprintf("%s/attachments/%s/%s/%s", BaseURL, channelID, attachmentID, url.PathEscape(filename))
+}
+
+func apply(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+func image(path, hash string, opts []Option) string {
+	o := apply(opts)
+	if o.format == "" {
+		o.format = FormatPNG
+		if IsAnimated(hash) && !o.static {
+			o.format = FormatGIF
+		}
+	}
+	return build(BaseURL, path+"/"+hash, o)
+}
+
+func build(host, path string, o options) string {
+	u := fmt.Sprintf("%s/%s.%s", host, path, o.format)
+	if o.size > 0 {
+		u += "?size=" + strconv.Itoa(o.size)
+	}
+	return u
+}
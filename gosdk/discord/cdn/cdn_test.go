@@ -0,0 +1,68 @@
+package cdn
+
+import (
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestImageURLs(t *testing.T) {
+	user := &types.User{ID: "80351110224678912", Avatar: "a_abc", Banner: "def"}
+	guild := &types.Guild{ID: "1", Icon: "icon", Banner: "a_banner", Splash: "splash"}
+	tests := []struct {
+		got, want string
+	}{
+		{UserAvatar(user), "https://cdn.discordapp.com/avatars/80351110224678912/a_abc.gif"},
+		{UserAvatar(user, WithStatic()), "https://cdn.discordapp.com/avatars/80351110224678912/a_abc.png"},
+		{UserAvatar(user, WithFormat(FormatWebP), WithSize(100)), "https://cdn.discordapp.com/avatars/80351110224678912/a_abc.webp?size=128"},
+		{UserBanner(user, WithSize(9000)), "https://cdn.discordapp.com/banners/80351110224678912/def.png?size=4096"},
+		{GuildIcon(guild, WithSize(1)), "https://cdn.discordapp.com/icons/1/icon.png?size=16"},
+		{GuildBanner(guild), "https://cdn.discordapp.com/banners/1/a_banner.gif"},
+		{GuildSplash(guild, WithFormat(FormatJPEG)), "https://cdn.discordapp.com/splashes/1/splash.jpg"},
+		{RoleIcon(&types.Role{ID: "5", Icon: "r"}), "https://cdn.discordapp.com/role-icons/5/r.png"},
+		{Emoji(&types.Emoji{ID: "7", Animated: true}), "https://cdn.discordapp.com/emojis/7.gif"},
+		{Emoji(&types.Emoji{ID: "7"}, WithSize(64)), "https://cdn.discordapp.com/emojis/7.png?size=64"},
+		{Attachment("1", "2", "my file.png"), "https://cdn.discordapp.com/attachments/1/2/my%20file.png"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestMissingHashes(t *testing.T) {
+	if GuildIcon(&types.Guild{ID: "1"}) != "" || GuildIcon(nil) != "" || UserBanner(&types.User{ID: "1"}) != "" {
+		t.Fatalf("expected empty URLs when no hash is set")
+	}
+	if Emoji(&types.Emoji{Name: "👍"}) != "" {
+		t.Fatalf("unicode emoji have no CDN URL")
+	}
+}
+
+func TestDefaultAvatar(t *testing.T) {
+	// (80351110224678912 >> 22) % 6 == 5
+	if got := UserAvatar(&types.User{ID: "80351110224678912", Discriminator: "0"}); got != "https://cdn.discordapp.com/embed/avatars/5.png" {
+		t.Fatalf("new username default = %q", got)
+	}
+	if got := DefaultAvatar(&types.User{ID: "1", Discriminator: "1337"}); got != "https://cdn.discordapp.com/embed/avatars/2.png" {
+		t.Fatalf("legacy default = %q", got)
+	}
+}
+
+func TestSticker(t *testing.T) {
+	tests := []struct {
+		sticker types.Sticker
+		want    string
+	}{
+		{types.Sticker{ID: "1", FormatType: types.StickerFormatPNG}, "https://cdn.discordapp.com/stickers/1.png?size=256"},
+		{types.Sticker{ID: "2", FormatType: types.StickerFormatAPNG}, "https://cdn.discordapp.com/stickers/2.png?size=256"},
+		{types.Sticker{ID: "3", FormatType: types.StickerFormatLottie}, "https://cdn.discordapp.com/stickers/3.json"},
+		{types.Sticker{ID: "4", FormatType: types.StickerFormatGIF}, "https://media.discordapp.net/stickers/4.gif?size=256"},
+	}
+	for _, tt := range tests {
+		if got := Sticker(&tt.sticker, WithSize(160)); got != tt.want {
+			t.Errorf("Sticker(%d) = %q, want %q", tt.sticker.FormatType, got, tt.want)
+		}
+	}
+}
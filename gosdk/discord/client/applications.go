@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// ListTeamMembers returns the developers that own the current application.
+// Applications without a team report their owner as the only member, with
+// the admin role.
+func (a *Applications) ListTeamMembers(ctx context.Context) ([]types.TeamMember, error) {
+	app, err := a.GetCurrentApplication(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if app.Team != nil {
+		return app.Team.Members, nil
+	}
+	if app.Owner == nil {
+		return nil, nil
+	}
+	return []types.TeamMember{{
+		MembershipState: types.TeamMembershipAccepted,
+		User:            *app.Owner,
+		Role:            types.TeamRoleAdmin,
+	}}, nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestListTeamMembers(t *testing.T) {
+	body := `{"id":"1","owner":{"id":"9"},"team":{"id":"5","owner_user_id":"7","members":[{"membership_state":2,"team_id":"5","user":{"id":"7"},"role":"admin"},{"membership_state":1,"team_id":"5","user":{"id":"8"},"role":"developer"}]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/@me" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		io.WriteString(w, body)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	members, err := client.Applications().ListTeamMembers(context.Background())
+	if err != nil {
+		t.Fatalf("ListTeamMembers error: %v", err)
+	}
+	if len(members) != 2 || members[1].User.ID != "8" || members[1].MembershipState != types.TeamMembershipInvited {
+		t.Fatalf("unexpected members %+v", members)
+	}
+
+	body = `{"id":"1","owner":{"id":"9"}}`
+	members, err = client.Applications().ListTeamMembers(context.Background())
+	if err != nil || len(members) != 1 || members[0].User.ID != "9" || members[0].Role != types.TeamRoleAdmin {
+		t.Fatalf("owner fallback: %+v %v", members, err)
+	}
+}
+
+func TestEditCurrentApplicationSendsEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got map[string]any
+		json.NewDecoder(r.Body).Decode(&got)
+		if r.Method != http.MethodPatch || got["interactions_endpoint_url"] != "https://new.example.com/interactions" {
+			t.Fatalf("unexpected request %s %v", r.Method, got)
+		}
+		if _, ok := got["description"]; ok {
+			t.Fatalf("unset fields should be omitted: %v", got)
+		}
+		io.WriteString(w, `{"id":"1","interactions_endpoint_url":"https://new.example.com/interactions"}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	endpoint := "https://new.example.com/interactions"
+	app, err := client.Applications().EditCurrentApplication(context.Background(), &types.ApplicationEditParams{InteractionsEndpointURL: &endpoint})
+	if err != nil || app.InteractionsEndpointURL != endpoint {
+		t.Fatalf("EditCurrentApplication: %+v %v", app, err)
+	}
+}
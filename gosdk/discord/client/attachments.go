@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// attachmentRefreshMargin refreshes URLs slightly before they expire so a
+// returned URL stays usable long enough to fetch.
+const attachmentRefreshMargin = time.Minute
+
+// RefreshAttachmentURL returns a working URL for an attachment link stored
+// from the given message. Unexpired URLs (and URLs without an expiry) are
+// returned as is; otherwise the message is re-fetched, which makes Discord
+// sign fresh URLs for its attachments. The result wraps types.ErrNotFound if
+// the attachment is no longer on the message.
+func (m *MessageService) RefreshAttachmentURL(ctx context.Context, channelID, messageID, storedURL string) (string, error) {
+	if !types.AttachmentURLExpired(storedURL, time.Now(), attachmentRefreshMargin) {
+		return storedURL, nil
+	}
+	attachmentID, ok := types.AttachmentIDFromURL(storedURL)
+	if !ok {
+		return "", &types.ValidationError{Field: "url", Message: "not a Discord attachment URL"}
+	}
+
+	msg, err := m.GetMessage(ctx, channelID, messageID)
+	if err != nil {
+		return "", err
+	}
+	for _, attachment := range msg.Attachments {
+		if attachment.ID == attachmentID {
+			return attachment.URL, nil
+		}
+	}
+	return "", fmt.Errorf("attachment %s on message %s: %w", attachmentID, messageID, types.ErrNotFound)
+}
+
+// RefreshAttachments re-fetches msg when any of its attachment URLs has
+// expired and replaces msg.Attachments with the fresh ones. It reports
+// whether a refresh happened.
+func (m *MessageService) RefreshAttachments(ctx context.Context, msg *types.Message) (bool, error) {
+	if msg == nil {
+		return false, &types.ValidationError{Field: "message", Message: "message is required"}
+	}
+	now := time.Now()
+	stale := false
+	for _, attachment := range msg.Attachments {
+		if types.AttachmentURLExpired(attachment.URL, now, attachmentRefreshMargin) {
+			stale = true
+			break
+		}
+	}
+	if !stale {
+		return false, nil
+	}
+
+	fresh, err := m.GetMessage(ctx, msg.ChannelID, msg.ID)
+	if err != nil {
+		return false, err
+	}
+	msg.Attachments = fresh.Attachments
+	return true, nil
+}
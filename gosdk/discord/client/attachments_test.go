@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func cdnURL(attachmentID string, expiry time.Time) string {
+	return "https://cdn.discordapp.com/attachments/1/" + attachmentID + "/file.png?ex=" +
+		strconv.FormatInt(expiry.Unix(), 16) + "&is=0&hm=abc"
+}
+
+func TestRefreshAttachmentURL(t *testing.T) {
+	fresh := cdnURL("10", time.Now().Add(24*time.Hour))
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/channels/1/messages/2" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(types.Message{ID: "2", ChannelID: "1", Attachments: []types.Attachment{{ID: "10", URL: fresh}}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	ctx := context.Background()
+
+	valid := cdnURL("10", time.Now().Add(time.Hour))
+	if got, err := client.Messages().RefreshAttachmentURL(ctx, "1", "2", valid); err != nil || got != valid {
+		t.Fatalf("RefreshAttachmentURL(valid) = %q, %v", got, err)
+	}
+	if fetches.Load() != 0 {
+		t.Fatal("unexpired URL should not trigger a fetch")
+	}
+
+	got, err := client.Messages().RefreshAttachmentURL(ctx, "1", "2", cdnURL("10", time.Now().Add(-time.Hour)))
+	if err != nil {
+		t.Fatalf("RefreshAttachmentURL(expired) error = %v", err)
+	}
+	if got != fresh {
+		t.Fatalf("RefreshAttachmentURL(expired) = %q, want %q", got, fresh)
+	}
+
+	_, err = client.Messages().RefreshAttachmentURL(ctx, "1", "2", cdnURL("99", time.Now().Add(-time.Hour)))
+	if !errors.Is(err, types.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing attachment, got %v", err)
+	}
+}
+
+func TestRefreshAttachments(t *testing.T) {
+	fresh := cdnURL("10", time.Now().Add(24*time.Hour))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(types.Message{ID: "2", ChannelID: "1", Attachments: []types.Attachment{{ID: "10", URL: fresh}}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	msg := &types.Message{ID: "2", ChannelID: "1", Attachments: []types.Attachment{{ID: "10", URL: cdnURL("10", time.Now().Add(30*time.Second))}}}
+	refreshed, err := client.Messages().RefreshAttachments(context.Background(), msg)
+	if err != nil || !refreshed {
+		t.Fatalf("RefreshAttachments() = %v, %v", refreshed, err)
+	}
+	if msg.Attachments[0].URL != fresh {
+		t.Fatalf("attachment URL not replaced: %s", msg.Attachments[0].URL)
+	}
+
+	refreshed, err = client.Messages().RefreshAttachments(context.Background(), msg)
+	if err != nil || refreshed {
+		t.Fatalf("fresh attachments should not be refetched: %v, %v", refreshed, err)
+	}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// AuditLogs reads guild audit logs.
+type AuditLogs struct {
+	client *Client
+}
+
+// AuditLogs exposes audit log helpers bound to the client.
+func (c *Client) AuditLogs() *AuditLogs {
+	return &AuditLogs{client: c}
+}
+
+// GetGuildAuditLog fetches a page of the guild's audit log. Reading the audit
+// log requires the VIEW_AUDIT_LOG permission.
+func (a *AuditLogs) GetGuildAuditLog(ctx context.Context, guildID string, params *types.AuditLogParams) (*types.AuditLog, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if params != nil {
+		if params.UserID != "" {
+			query.Set("user_id", params.UserID)
+		}
+		if params.ActionType != 0 {
+			query.Set("action_type", strconv.Itoa(int(params.ActionType)))
+		}
+		if params.Before != "" {
+			query.Set("before", params.Before)
+		}
+		if params.After != "" {
+			query.Set("after", params.After)
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	path := fmt.Sprintf("/guilds/%s/audit-logs", guildID)
+	if q := query.Encode(); q != "" {
+		path += "?" + q
+	}
+	var log types.AuditLog
+	if err := a.client.Get(ctx, path, &log); err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
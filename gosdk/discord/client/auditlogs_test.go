@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestAuditLogsGetGuildAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/guilds/1/audit-logs" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("after") != "100" || r.URL.Query().Get("limit") != "50" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"audit_log_entries":[{"id":"101","action_type":22,"user_id":"7","target_id":"8","reason":"spam","changes":[{"key":"nick","old_value":"a","new_value":"b"}]}],"users":[{"id":"7","username":"mod"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	log, err := client.AuditLogs().GetGuildAuditLog(context.Background(), "1", &types.AuditLogParams{After: "100", Limit: 50})
+	if err != nil {
+		t.Fatalf("GetGuildAuditLog error: %v", err)
+	}
+	if len(log.Entries) != 1 || log.Entries[0].ActionType != types.AuditLogMemberBanAdd || log.Entries[0].Reason != "spam" {
+		t.Fatalf("unexpected entries: %+v", log.Entries)
+	}
+	if change := log.Entries[0].Changes[0]; change.Key != "nick" || string(change.NewValue) != `"b"` {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+	if len(log.Users) != 1 {
+		t.Fatalf("expected users, got %+v", log.Users)
+	}
+}
+
+func TestAuditLogsValidation(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1")
+	if _, err := client.AuditLogs().GetGuildAuditLog(context.Background(), "", nil); err == nil {
+		t.Fatal("expected error for missing guild ID")
+	}
+	if _, err := client.AuditLogs().GetGuildAuditLog(context.Background(), "1", &types.AuditLogParams{Limit: 101}); err == nil {
+		t.Fatal("expected error for oversized limit")
+	}
+}
+
+func TestAuditLogsFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("user_id") != "7" || q.Get("action_type") != "22" || q.Get("before") != "500" || q.Has("after") {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"audit_log_entries":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	params := &types.AuditLogParams{UserID: "7", ActionType: types.AuditLogMemberBanAdd, Before: "500"}
+	if _, err := client.AuditLogs().GetGuildAuditLog(context.Background(), "1", params); err != nil {
+		t.Fatalf("GetGuildAuditLog error: %v", err)
+	}
+	if _, err := client.AuditLogs().GetGuildAuditLog(context.Background(), "1", &types.AuditLogParams{Before: "5", After: "1"}); err == nil {
+		t.Fatal("expected error for before and after together")
+	}
+}
+
+func TestPaginateGuildAuditLogBackward(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("action_type") != "20" {
+			t.Fatalf("filter not kept across pages: %s", r.URL.RawQuery)
+		}
+		before, _ := strconv.Atoi(r.URL.Query().Get("before"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var log types.AuditLog
+		for id := before - 1; id >= 1 && len(log.Entries) < limit; id-- {
+			log.Entries = append(log.Entries, types.AuditLogEntry{ID: strconv.Itoa(id), ActionType: types.AuditLogMemberKick})
+		}
+		json.NewEncoder(w).Encode(log)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	p, err := client.AuditLogs().PaginateGuildAuditLog("1", &types.AuditLogParams{Before: "26", ActionType: types.AuditLogMemberKick, Limit: 10})
+	if err != nil {
+		t.Fatalf("PaginateGuildAuditLog error: %v", err)
+	}
+	entries, err := p.All(context.Background())
+	if err != nil {
+		t.Fatalf("All error: %v", err)
+	}
+	if len(entries) != 25 || entries[0].ID != "25" || entries[24].ID != "1" {
+		t.Fatalf("got %d entries from %s to %s", len(entries), entries[0].ID, entries[len(entries)-1].ID)
+	}
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+// DefaultAuditPayloadLimit is the number of payload bytes kept in a RequestRecord.
+const DefaultAuditPayloadLimit = 1024
+
+// RequestRecord describes one mutating API call.
+type RequestRecord struct {
+	Time     time.Time     `json:"time"`
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`
+	Actor    string        `json:"actor,omitempty"`
+	Reason   string        `json:"reason,omitempty"`
+	Payload  string        `json:"payload,omitempty"`
+	Status   int           `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// RequestRecorder stores request records. The audit package provides JSON
+// lines and SQL implementations.
+type RequestRecorder interface {
+	RecordRequest(ctx context.Context, record RequestRecord) error
+}
+
+// RequestRecorderFunc adapts a function to a RequestRecorder.
+type RequestRecorderFunc func(ctx context.Context, record RequestRecord) error
+
+// RecordRequest calls f.
+func (f RequestRecorderFunc) RecordRequest(ctx context.Context, record RequestRecord) error {
+	return f(ctx, record)
+}
+
+type actorKey struct{}
+
+// WithActor tags ctx with the person or process a request is made on behalf
+// of, such as the user who ran a moderation command. AuditTrailMiddleware
+// stores it with every record.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// RequestActor returns the actor set with WithActor.
+func RequestActor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// AuditTrailOption configures AuditTrailMiddleware.
+type AuditTrailOption func(*auditTrail)
+
+// WithAuditPayloadLimit caps the payload bytes kept per record. Zero or
+// negative drops payloads entirely.
+func WithAuditPayloadLimit(n int) AuditTrailOption {
+	return func(a *auditTrail) {
+		a.payloadLimit = n
+	}
+}
+
+// WithAuditLogger reports records the recorder failed to store.
+func WithAuditLogger(log logger.Interface) AuditTrailOption {
+	return func(a *auditTrail) {
+		if lg := logger.From(log); lg != nil {
+			a.log = lg
+		}
+	}
+}
+
+type auditTrail struct {
+	recorder     RequestRecorder
+	payloadLimit int
+	log          *logger.Logger
+}
+
+// AuditTrailMiddleware records every mutating request (anything but GET,
+// HEAD, and OPTIONS) after it completes: the route, the actor from
+// WithActor, the audit log reason, a summary of the JSON payload, and the
+// outcome. Failing to record a request is logged but does not fail it.
+// Register it last with Use so retried requests are recorded once per
+// attempt.
+func AuditTrailMiddleware(recorder RequestRecorder, opts ...AuditTrailOption) Middleware {
+	a := &auditTrail{recorder: recorder, payloadLimit: DefaultAuditPayloadLimit, log: logger.Default()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(a)
+		}
+	}
+	return func(next RequestHandler) RequestHandler {
+		if a.recorder == nil {
+			return next
+		}
+		return func(req *Request) (*http.Response, error) {
+			switch req.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(req)
+			}
+
+			record := RequestRecord{
+				Time:    time.Now().UTC(),
+				Method:  req.Method,
+				Path:    req.URL.Path,
+				Actor:   RequestActor(req.Context()),
+				Payload: a.summarize(req.Request),
+			}
+			if reason := req.Header.Get("X-Audit-Log-Reason"); reason != "" {
+				if unescaped, err := url.QueryUnescape(reason); err == nil {
+					reason = unescaped
+				}
+				record.Reason = reason
+			}
+
+			resp, err := next(req)
+			record.Duration = time.Since(record.Time)
+			record.Status = statusCode(resp)
+			if err != nil {
+				record.Error = err.Error()
+			}
+			if recErr := a.recorder.RecordRequest(context.WithoutCancel(req.Context()), record); recErr != nil {
+				a.log.WithSubsystem(logger.SubsystemClient).Warn("discord.client.audit_trail.failed",
+					"method", record.Method,
+					"path", record.Path,
+					"error", recErr,
+				)
+			}
+			return resp, err
+		}
+	}
+}
+
+// summarize returns the request payload as compact JSON, truncated to the
+// payload limit. Uploads are summarized by size.
+func (a *auditTrail) summarize(req *http.Request) string {
+	if a.payloadLimit <= 0 || req.GetBody == nil || req.ContentLength == 0 {
+		return ""
+	}
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		return fmt.Sprintf("<multipart upload, %d bytes>", req.ContentLength)
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, int64(a.payloadLimit)*4))
+	if err != nil {
+		return ""
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, data) == nil {
+		data = compact.Bytes()
+	}
+	if len(data) <= a.payloadLimit {
+		return string(data)
+	}
+	cut := a.payloadLimit
+	for cut > 0 && !utf8.RuneStart(data[cut]) {
+		cut--
+	}
+	return string(data[:cut]) + "…"
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+func TestAuditTrailMiddlewareRecordsMutations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{"id":"5","name":"mods"}`))
+	}))
+	defer server.Close()
+
+	var records []RequestRecord
+	client := newTestClient(t, server.URL)
+	client.Use(AuditTrailMiddleware(RequestRecorderFunc(func(ctx context.Context, r RequestRecord) error {
+		records = append(records, r)
+		return nil
+	}), WithAuditPayloadLimit(16)))
+
+	ctx := WithActor(context.Background(), "user:42")
+	if _, err := client.Guilds().GetGuildRoles(ctx, "1"); err != nil {
+		t.Fatalf("GetGuildRoles error: %v", err)
+	}
+	if _, err := client.Guilds().CreateGuildRole(ctx, "1", &types.RoleCreateParams{Name: "moderators", AuditLogReason: "staff rota"}); err != nil {
+		t.Fatalf("CreateGuildRole error: %v", err)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("expected only the mutation to be recorded, got %+v", records)
+	}
+	r := records[0]
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.Path, "/guilds/1/roles") || r.Actor != "user:42" || r.Reason != "staff rota" {
+		t.Fatalf("unexpected record: %+v", r)
+	}
+	if r.Payload != `{"name":"moderat…` || r.Status != http.StatusOK || r.Error != "" {
+		t.Fatalf("unexpected payload or result: %+v", r)
+	}
+}
+
+func TestAuditTrailMiddlewareRecordsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":50013,"message":"Missing Permissions"}`))
+	}))
+	defer server.Close()
+
+	var record RequestRecord
+	client := newTestClient(t, server.URL)
+	client.Use(AuditTrailMiddleware(RequestRecorderFunc(func(ctx context.Context, r RequestRecord) error {
+		record = r
+		return errors.New("disk full")
+	}), WithAuditLogger(logger.New(logger.WarnLevel, "json", io.Discard))))
+
+	err := client.Guilds().DeleteGuildRole(context.Background(), "1", "2")
+	if err == nil {
+		t.Fatal("expected API error")
+	}
+	if record.Method != http.MethodDelete || record.Status != http.StatusForbidden || record.Payload != "" {
+		t.Fatalf("unexpected record: %+v", record)
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// ListBans lists a guild's bans in user ID order. Use params.Before or
+// params.After to page; PaginateGuildBans walks the whole list.
+func (g *Guilds) ListBans(ctx context.Context, guildID string, params *types.ListBansParams) ([]*types.Ban, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if params != nil {
+		if params.Limit > 0 {
+			query.Set("limit", fmt.Sprintf("%d", params.Limit))
+		}
+		if params.Before != "" {
+			query.Set("before", params.Before)
+		}
+		if params.After != "" {
+			query.Set("after", params.After)
+		}
+	}
+	path := fmt.Sprintf("/guilds/%s/bans", guildID)
+	if q := query.Encode(); q != "" {
+		path += "?" + q
+	}
+	var bans []*types.Ban
+	if err := g.client.Get(ctx, path, &bans); err != nil {
+		return nil, err
+	}
+	return bans, nil
+}
+
+// GetBan returns the ban for a user. It fails with types.ErrNotFound when
+// the user is not banned.
+func (g *Guilds) GetBan(ctx context.Context, guildID, userID string) (*types.Ban, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := validateID("userID", userID); err != nil {
+		return nil, err
+	}
+	var ban types.Ban
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/bans/%s", guildID, userID), &ban); err != nil {
+		return nil, err
+	}
+	return &ban, nil
+}
+
+// BulkBan bans up to 200 users in one call and reports which of them were
+// banned. Discord rejects the whole request when none of them could be.
+func (g *Guilds) BulkBan(ctx context.Context, guildID string, params *types.BulkBanParams) (*types.BulkBanResult, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	var result types.BulkBanResult
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf("/guilds/%s/bulk-ban", guildID), params, &result, auditHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestGuildsListAndGetBans(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/guilds/1/bans":
+			if got := r.URL.Query(); got.Get("limit") != "10" || got.Get("before") != "50" {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode([]*types.Ban{{Reason: "spam", User: &types.User{ID: "7"}}})
+		case "/guilds/1/bans/7":
+			json.NewEncoder(w).Encode(types.Ban{User: &types.User{ID: "7"}})
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	bans, err := client.Guilds().ListBans(context.Background(), "1", &types.ListBansParams{Limit: 10, Before: "50"})
+	if err != nil || len(bans) != 1 || bans[0].Reason != "spam" {
+		t.Fatalf("ListBans() = %+v, %v", bans, err)
+	}
+	ban, err := client.Guilds().GetBan(context.Background(), "1", "7")
+	if err != nil || ban.User.ID != "7" {
+		t.Fatalf("GetBan() = %+v, %v", ban, err)
+	}
+	if _, err := client.Guilds().ListBans(context.Background(), "1", &types.ListBansParams{Before: "1", After: "2"}); err == nil {
+		t.Fatalf("expected error for before and after")
+	}
+}
+
+func TestGuildsPaginateBans(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		cursors = append(cursors, after)
+		n := limits.BansPerPage
+		if after != "" {
+			n = 1
+		}
+		page := make([]*types.Ban, n)
+		for i := range page {
+			page[i] = &types.Ban{User: &types.User{ID: fmt.Sprintf("%s%d", after, i)}}
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	bans, err := client.Guilds().PaginateGuildBans("1").All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(bans) != limits.BansPerPage+1 || len(cursors) != 2 || cursors[1] != fmt.Sprint(limits.BansPerPage-1) {
+		t.Fatalf("got %d bans with cursors %v", len(bans), cursors)
+	}
+}
+
+func TestGuildsBulkBan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/guilds/1/bulk-ban" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Audit-Log-Reason") != "raid" {
+			t.Fatalf("missing audit log reason")
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["delete_message_seconds"] != float64(3600) || len(body["user_ids"].([]interface{})) != 2 {
+			t.Fatalf("unexpected body %v", body)
+		}
+		json.NewEncoder(w).Encode(types.BulkBanResult{BannedUsers: []string{"2"}, FailedUsers: []string{"3"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.Guilds().BulkBan(context.Background(), "1", &types.BulkBanParams{
+		UserIDs: []string{"2", "3"}, DeleteMessageSeconds: 3600, AuditLogReason: "raid",
+	})
+	if err != nil || len(result.BannedUsers) != 1 || result.FailedUsers[0] != "3" {
+		t.Fatalf("BulkBan() = %+v, %v", result, err)
+	}
+}
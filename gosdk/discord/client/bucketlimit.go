@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+)
+
+// bucketLimiter caps in-flight requests per rate limit bucket. The tracker
+// only learns a bucket's Remaining count from responses, so without a cap
+// every concurrent request sees the same stale count and they all go out
+// together.
+//
+// Slots are counted per route, and each route remembers the bucket Discord
+// reported for it. A request counts against every in-flight request on its
+// route or its bucket, so requests sent before the bucket was known still
+// hold their slots once it is.
+type bucketLimiter struct {
+	max     int
+	tracker ratelimit.Tracker
+
+	mu     sync.Mutex
+	routes map[string]*routeSlots
+	// wake is closed and replaced whenever a slot is released.
+	wake chan struct{}
+}
+
+type routeSlots struct {
+	inFlight int
+	bucket   string
+}
+
+func newBucketLimiter(n int, tracker ratelimit.Tracker) *bucketLimiter {
+	return &bucketLimiter{max: n, tracker: tracker, routes: make(map[string]*routeSlots), wake: make(chan struct{})}
+}
+
+// acquire blocks until a request on route may be sent. The returned release
+// function must be called once the response headers have been recorded.
+func (l *bucketLimiter) acquire(ctx context.Context, route string) (func(), error) {
+	for {
+		bucket, limit := l.limit(route)
+		l.mu.Lock()
+		slots := l.routes[route]
+		if slots == nil {
+			slots = &routeSlots{}
+			l.routes[route] = slots
+		}
+		if bucket != "" {
+			slots.bucket = bucket
+		}
+		if l.inFlight(route, slots.bucket) < limit {
+			slots.inFlight++
+			l.mu.Unlock()
+			var once sync.Once
+			return func() { once.Do(func() { l.release(route) }) }, nil
+		}
+		if slots.inFlight == 0 {
+			delete(l.routes, route)
+		}
+		wake := l.wake
+		l.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// inFlight counts requests in flight on route or on bucket, first looking up
+// the bucket of routes that went out before Discord reported one. Callers
+// must hold l.mu.
+func (l *bucketLimiter) inFlight(route, bucket string) int {
+	if bucket == "" {
+		return l.routes[route].inFlight
+	}
+	n := 0
+	for r, slots := range l.routes {
+		if slots.bucket == "" && l.tracker != nil {
+			if b := l.tracker.GetBucket(r); b != nil {
+				slots.bucket = b.Key
+			}
+		}
+		if r == route || slots.bucket == bucket {
+			n += slots.inFlight
+		}
+	}
+	return n
+}
+
+func (l *bucketLimiter) release(route string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	slots := l.routes[route]
+	if slots == nil {
+		return
+	}
+	slots.inFlight--
+	if slots.inFlight <= 0 {
+		delete(l.routes, route)
+	}
+	close(l.wake)
+	l.wake = make(chan struct{})
+}
+
+// limit resolves the bucket Discord reported for route, if any, and how many
+// requests may be in flight on it: no more than the bucket's Remaining count
+// while its window is open.
+func (l *bucketLimiter) limit(route string) (string, int) {
+	limit := l.max
+	if l.tracker == nil {
+		return "", limit
+	}
+	bucket := l.tracker.GetBucket(route)
+	if bucket == nil {
+		return "", limit
+	}
+	key := bucket.Key
+	if bucket.Limit > 0 && time.Now().Before(bucket.Reset) {
+		// At zero the tracker makes the request wait for the reset; let
+		// one through to do that instead of parking here.
+		remaining := max(bucket.Remaining, 1)
+		limit = min(limit, remaining)
+	}
+	return key, limit
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+)
+
+// concurrencyServer records peak concurrent requests and reports a bucket
+// with the given remaining count on every response.
+func concurrencyServer(remaining string) (*httptest.Server, func() int) {
+	var mu sync.Mutex
+	active, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		if remaining != "" {
+			w.Header().Set("X-RateLimit-Bucket", "shared")
+			w.Header().Set("X-RateLimit-Limit", "50")
+			w.Header().Set("X-RateLimit-Remaining", remaining)
+			w.Header().Set("X-RateLimit-Reset-After", "60")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return server, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return peak
+	}
+}
+
+func runParallelDeletes(t *testing.T, client *Client, n int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := client.Delete(context.Background(), "/channels/1/messages/"+strconv.Itoa(100+i)); err != nil {
+				t.Errorf("Delete() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestBucketConcurrencyCap(t *testing.T) {
+	server, peak := concurrencyServer("")
+	defer server.Close()
+
+	client, err := New("test-token",
+		WithBaseURL(server.URL),
+		WithRateLimiter(&noopTracker{}),
+		WithStrategy(ratelimit.NewReactiveStrategy()),
+		WithBucketConcurrency(3),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	runParallelDeletes(t, client, 12)
+	if got := peak(); got > 3 || got < 2 {
+		t.Fatalf("expected peak concurrency of 3, got %d", got)
+	}
+}
+
+func TestBucketConcurrencyFollowsRemaining(t *testing.T) {
+	server, peak := concurrencyServer("2")
+	defer server.Close()
+
+	client, err := New("test-token",
+		WithBaseURL(server.URL),
+		WithStrategy(ratelimit.NewReactiveStrategy()),
+		WithBucketConcurrency(10),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	// Prime the bucket so the limiter knows Remaining.
+	if err := client.Delete(context.Background(), "/channels/1/messages/1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	runParallelDeletes(t, client, 10)
+	if got := peak(); got > 2 {
+		t.Fatalf("expected at most 2 in flight with Remaining=2, got %d", got)
+	}
+}
+
+func TestBucketLimiterCancel(t *testing.T) {
+	l := newBucketLimiter(1, nil)
+	release, err := l.acquire(context.Background(), "r")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, "r"); err == nil {
+		t.Fatal("expected acquire to time out while the slot is held")
+	}
+	release()
+	release()
+	if _, err := l.acquire(context.Background(), "r"); err != nil {
+		t.Fatalf("acquire() after release error = %v", err)
+	}
+}
+
+func TestBucketLimiterCountsRequestsSentBeforeBucketKnown(t *testing.T) {
+	tracker := &mockTracker{}
+	l := newBucketLimiter(1, tracker)
+	release, err := l.acquire(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	// The response to "a" reveals that "a" and "b" share a bucket.
+	shared := &ratelimit.Bucket{Key: "shared", Limit: 5, Remaining: 5, Reset: time.Now().Add(time.Minute)}
+	tracker.buckets = map[string]*ratelimit.Bucket{"a": shared, "b": shared}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, "b"); err == nil {
+		t.Fatal("expected b to wait for the slot a holds in the shared bucket")
+	}
+	release()
+	if _, err := l.acquire(context.Background(), "b"); err != nil {
+		t.Fatalf("acquire() after release error = %v", err)
+	}
+}
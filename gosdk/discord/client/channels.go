@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 )
 
@@ -66,7 +67,17 @@ func (c *Channels) DeleteChannel(ctx context.Context, channelID string) error {
 	return c.client.Delete(ctx, fmt.Sprintf("/channels/%s", channelID))
 }
 
-// GetChannelMessagesParams controls pagination for channel history.
+// TriggerTyping shows the bot as typing in a channel for about ten seconds,
+// or until it sends a message there.
+func (c *Channels) TriggerTyping(ctx context.Context, channelID string) error {
+	if err := validateID("channelID", channelID); err != nil {
+		return err
+	}
+	return c.client.Post(ctx, fmt.Sprintf("/channels/%s/typing", channelID), nil, nil)
+}
+
+// GetChannelMessagesParams controls pagination for channel history. Around,
+// Before and After are message IDs and are mutually exclusive; Limit is 1-100.
 type GetChannelMessagesParams struct {
 	Limit  int
 	Before string
@@ -125,11 +136,14 @@ func (p *GetChannelMessagesParams) validate() error {
 	if p.Limit < 0 {
 		return &types.ValidationError{Field: "limit", Message: "limit must be positive"}
 	}
-	if p.Limit > 0 && (p.Limit < 1 || p.Limit > 100) {
-		return &types.ValidationError{Field: "limit", Message: "limit must be between 1 and 100"}
+	if p.Limit > 0 && (p.Limit < 1 || p.Limit > limits.MessagesPerPage) {
+		return &types.ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 1 and %d", limits.MessagesPerPage)}
 	}
 	if p.Around != "" && (p.Before != "" || p.After != "") {
 		return &types.ValidationError{Field: "around", Message: "cannot use around with before/after"}
 	}
+	if p.Before != "" && p.After != "" {
+		return &types.ValidationError{Field: "before", Message: "cannot use before with after"}
+	}
 	return nil
 }
@@ -127,6 +127,28 @@ func TestChannelsGetMessagesValidation(t *testing.T) {
 	if _, err := client.Channels().GetChannelMessages(context.Background(), "123", params); err == nil {
 		t.Fatal("expected validation error")
 	}
+	params = &GetChannelMessagesParams{Before: "1", After: "2"}
+	if _, err := client.Channels().GetChannelMessages(context.Background(), "123", params); err == nil {
+		t.Fatal("expected error for before with after")
+	}
+}
+
+func TestChannelsTriggerTyping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/channels/123/typing" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.Channels().TriggerTyping(context.Background(), "123"); err != nil {
+		t.Fatalf("TriggerTyping error: %v", err)
+	}
+	if err := client.Channels().TriggerTyping(context.Background(), ""); err == nil {
+		t.Fatal("expected error for missing channel ID")
+	}
 }
 
 func newTestClient(t *testing.T, baseURL string) *Client {
@@ -10,17 +10,24 @@ import (
 	"net/http"
 	"net/http/httptrace"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/idempotency"
 	"github.com/mtreilly/godiscord/gosdk/logger"
 	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+	"github.com/mtreilly/godiscord/gosdk/retry"
+	"github.com/mtreilly/godiscord/gosdk/version"
 )
 
 const (
-	defaultBaseURL   = "https://discord.com/api"
-	defaultUserAgent = "DiscordGoSDK/0.1 (+https://github.com/mtreilly/godiscord)"
+	defaultBaseURL = "https://discord.com/api"
+
+	// rateLimitLogInterval bounds "rate limit hit" warnings to one per route
+	// per interval; the rest are counted in the next warning.
+	rateLimitLogInterval = 30 * time.Second
 )
 
 // PoolConfig adjusts HTTP transport pooling behavior.
@@ -62,18 +69,37 @@ func defaultPoolConfig() PoolConfig {
 // It mirrors the webhook client's patterns: typed errors, structured logging,
 // shared rate-limit tracking, and context-aware requests.
 type Client struct {
-	token       string
-	baseURL     string
-	httpClient  *http.Client
-	logger      *logger.Logger
-	rateLimiter ratelimit.Tracker
-	strategy    ratelimit.Strategy
-	maxRetries  int
-	timeout     time.Duration
-	poolConfig  PoolConfig
-	poolStats   *poolStats
+	token      string
+	baseURL    string
+	httpClient *http.Client
+	logger     *logger.Logger
+	rateLogger *logger.Logger
+	// requestLogger writes the per-request debug lines (see WithRequestLogSampling).
+	requestLogger *logger.Logger
+	requestLogN   int
+	rateLimiter   ratelimit.Tracker
+	strategy      ratelimit.Strategy
+	maxRetries    int
+	retry         retry.Policy
+	timeout       time.Duration
+	poolConfig    PoolConfig
+	poolStats     *poolStats
+	observer      ratelimit.Observer
+	scheduler     *Scheduler
+
+	bucketConcurrency int
+	buckets           *bucketLimiter
 
 	middlewares []Middleware
+
+	// defaultMentions applies to created and edited messages that set no policy.
+	defaultMentions *types.AllowedMentions
+
+	// idempotency records keyed sends made with CreateMessageIdempotent.
+	idempotency idempotency.Store
+
+	// premiumTiers caches guild boost tiers (guildID -> premiumTierEntry) for upload limits.
+	premiumTiers sync.Map
 }
 
 // Option customises the bot HTTP client.
@@ -89,10 +115,38 @@ func WithHTTPClient(hc *http.Client) Option {
 }
 
 // WithLogger injects a custom logger.
-func WithLogger(l *logger.Logger) Option {
+func WithLogger(l logger.Interface) Option {
+	return func(c *Client) {
+		if lg := logger.From(l); lg != nil {
+			c.logger = lg
+		}
+	}
+}
+
+// WithRequestLogSampling logs the request and response debug lines for one
+// in every n requests, to keep debug output readable under load. Warnings and
+// errors are not sampled.
+func WithRequestLogSampling(n int) Option {
 	return func(c *Client) {
-		if l != nil {
-			c.logger = l
+		c.requestLogN = n
+	}
+}
+
+// WithDefaultAllowedMentions applies mentions to every message created or
+// edited through Messages() that doesn't set its own AllowedMentions, e.g.
+// types.NoMentions() to make sure user-provided text never pings @everyone.
+func WithDefaultAllowedMentions(mentions *types.AllowedMentions) Option {
+	return func(c *Client) {
+		c.defaultMentions = mentions
+	}
+}
+
+// WithIdempotencyStore sets where CreateMessageIdempotent records its keys.
+// The default in-memory store only catches retries made by this process.
+func WithIdempotencyStore(store idempotency.Store) Option {
+	return func(c *Client) {
+		if store != nil {
+			c.idempotency = store
 		}
 	}
 }
@@ -124,6 +178,44 @@ func WithStrategy(strategy ratelimit.Strategy) Option {
 	}
 }
 
+// WithRateLimitObserver reports rate limit waits, 429s, and bucket updates.
+func WithRateLimitObserver(observer ratelimit.Observer) Option {
+	return func(c *Client) {
+		if observer != nil {
+			c.observer = observer
+		}
+	}
+}
+
+// WithScheduler routes requests through a priority-aware scheduler that
+// serializes them per rate limit route. Tag requests with WithRequestPriority.
+func WithScheduler(s *Scheduler) Option {
+	return func(c *Client) {
+		if s != nil {
+			c.scheduler = s
+		}
+	}
+}
+
+// WithMiddleware registers middleware at construction, as Use does.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// WithBucketConcurrency caps concurrent in-flight requests per rate limit
+// bucket at n, and at the bucket's last reported Remaining count while its
+// window is open. Without it, parallel requests that all see the same
+// Remaining count can overrun the bucket and draw 429s. 0 disables the cap.
+func WithBucketConcurrency(n int) Option {
+	return func(c *Client) {
+		if n >= 0 {
+			c.bucketConcurrency = n
+		}
+	}
+}
+
 // WithStrategyName selects a rate limiting strategy by name.
 func WithStrategyName(name string) Option {
 	return func(c *Client) {
@@ -140,6 +232,16 @@ func WithMaxRetries(retries int) Option {
 	}
 }
 
+// WithRetryPolicy replaces the default exponential backoff for failed
+// requests. It takes precedence over WithMaxRetries.
+func WithRetryPolicy(policy retry.Policy) Option {
+	return func(c *Client) {
+		if policy != nil {
+			c.retry = policy
+		}
+	}
+}
+
 // WithTimeout overrides the HTTP client timeout.
 func WithTimeout(d time.Duration) Option {
 	return func(c *Client) {
@@ -176,13 +278,24 @@ func New(token string, opts ...Option) (*Client, error) {
 		timeout:     30 * time.Second,
 		poolConfig:  defaultPoolConfig(),
 		poolStats:   &poolStats{},
+		observer:    ratelimit.NopObserver{},
+		idempotency: idempotency.NewMemoryStore(0),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.logger = c.logger.WithSubsystem(logger.SubsystemClient)
+	c.rateLogger = c.logger.WithSubsystem(logger.SubsystemRateLimit)
+	c.requestLogger = c.logger.Sample(c.requestLogN)
 
 	c.configureHTTPClient()
+	if c.scheduler != nil {
+		c.scheduler.bind(c.rateLimiter)
+	}
+	if c.bucketConcurrency > 0 {
+		c.buckets = newBucketLimiter(c.bucketConcurrency, c.rateLimiter)
+	}
 
 	return c, nil
 }
@@ -270,28 +383,42 @@ func (c *Client) do(ctx context.Context, method, path string, body, out interfac
 	url := c.buildURL(path)
 
 	var payload []byte
+	var contentType string
 	var err error
-	if body != nil {
+	if raw, ok := body.(*multipartBody); ok {
+		payload, contentType = raw.data, raw.contentType
+	} else if body != nil {
 		payload, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
+		contentType = "application/json"
 	}
 
-	backoff := time.Second
-	var lastErr error
+	priority := requestPriority(ctx, route)
+	retrier := retry.New(c.retryPolicy())
+	reqLog := c.requestLogger.Tick().WithContext(ctx)
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoff):
-				backoff *= 2
+	for {
+		release, err := c.schedule(ctx, route, priority)
+		if err != nil {
+			return fmt.Errorf("request scheduling failed: %w", err)
+		}
+		if c.buckets != nil {
+			releaseScheduler := release
+			releaseBucket, err := c.buckets.acquire(ctx, route)
+			if err != nil {
+				releaseScheduler()
+				return fmt.Errorf("request scheduling failed: %w", err)
+			}
+			release = func() {
+				releaseBucket()
+				releaseScheduler()
 			}
 		}
 
 		if err := c.waitForRateLimit(ctx, route); err != nil {
+			release()
 			return fmt.Errorf("rate limit wait failed: %w", err)
 		}
 
@@ -302,6 +429,7 @@ func (c *Client) do(ctx context.Context, method, path string, body, out interfac
 
 		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 		if err != nil {
+			release()
 			return fmt.Errorf("failed to create request: %w", err)
 		}
 
@@ -315,32 +443,37 @@ func (c *Client) do(ctx context.Context, method, path string, body, out interfac
 		}
 
 		if payload != nil {
-			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Content-Type", contentType)
 		}
 		req.Header.Set("Authorization", "Bot "+c.token)
-		req.Header.Set("User-Agent", defaultUserAgent)
+		req.Header.Set("User-Agent", version.UserAgent())
+		// Caller headers replace defaults, e.g. a Bearer Authorization for
+		// OAuth2 user routes.
 		for key, values := range headers {
+			req.Header.Del(key)
 			for _, v := range values {
 				req.Header.Add(key, v)
 			}
 		}
 
 		start := time.Now()
-		c.logger.Debug("discord.client.request",
+		reqLog.Debug("discord.client.request",
 			"method", method,
 			"path", path,
-			"attempt", attempt+1,
+			"attempt", retrier.Attempts()+1,
 		)
 
 		resp, err := c.execute(ctx, &Request{Request: req})
 		if err != nil {
-			lastErr = &types.NetworkError{Op: "request", Err: err}
+			release()
+			if err := retrier.Wait(ctx, retry.Attempt{Err: &types.NetworkError{Op: "request", Err: err}}); err != nil {
+				return err
+			}
 			continue
 		}
 
-		if c.rateLimiter != nil {
-			c.rateLimiter.Update(route, resp.Header)
-		}
+		c.updateRateLimit(route, resp.Header)
+		release()
 
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 			c.recordStrategyOutcome(route, false)
@@ -354,7 +487,7 @@ func (c *Client) do(ctx context.Context, method, path string, body, out interfac
 				resp.Body.Close()
 			}
 
-			c.logger.Debug("discord.client.response",
+			reqLog.Debug("discord.client.response",
 				"method", method,
 				"path", path,
 				"status", resp.StatusCode,
@@ -368,32 +501,34 @@ func (c *Client) do(ctx context.Context, method, path string, body, out interfac
 		resp.Body.Close()
 
 		if resp.StatusCode == http.StatusTooManyRequests {
-			c.logger.Warn("rate limit hit",
+			c.rateLogger.Every("rate limit hit:"+route, rateLimitLogInterval).Warn("rate limit hit",
 				"route", route,
 				"retry_after", apiErr.RetryAfter,
-				"attempt", attempt+1,
+				"attempt", retrier.Attempts()+1,
 			)
 			c.recordStrategyOutcome(route, true)
-
-			if apiErr.RetryAfter > 0 {
-				backoff = time.Duration(apiErr.RetryAfter) * time.Second
-			}
-			lastErr = apiErr
-			continue
+			c.observeRateLimited(route, apiErr, resp.Header)
 		}
 
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			return apiErr
+		if err := retrier.Wait(ctx, retry.Attempt{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retry.ParseRetryAfter(resp.Header, time.Duration(apiErr.RetryAfter)*time.Second),
+			Err:        apiErr,
+		}); err != nil {
+			return err
 		}
-
-		lastErr = apiErr
 	}
+}
 
-	if lastErr != nil {
-		return fmt.Errorf("request failed after %d attempts: %w", c.maxRetries+1, lastErr)
+// retryPolicy returns the configured policy, or the default backoff
+// sized by WithMaxRetries.
+func (c *Client) retryPolicy() retry.Policy {
+	if c.retry != nil {
+		return c.retry
 	}
-
-	return fmt.Errorf("request failed after %d attempts", c.maxRetries+1)
+	policy := retry.Default()
+	policy.MaxAttempts = c.maxRetries + 1
+	return policy
 }
 
 func (c *Client) buildURL(path string) string {
@@ -410,6 +545,14 @@ func (c *Client) buildRoute(method, path string) string {
 	return ratelimit.RouteFromEndpoint(method, c.buildURL(path))
 }
 
+// schedule queues the request with the scheduler, if one is attached.
+func (c *Client) schedule(ctx context.Context, route string, priority Priority) (func(), error) {
+	if c.scheduler == nil {
+		return func() {}, nil
+	}
+	return c.scheduler.Acquire(ctx, route, priority)
+}
+
 func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 	if c.rateLimiter == nil {
 		return nil
@@ -418,11 +561,14 @@ func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 	var strategyName string
 	if c.strategy != nil {
 		strategyName = c.strategy.Name()
+		if err := c.waitForRouteBudget(ctx, route); err != nil {
+			return err
+		}
 		bucket := c.rateLimiter.GetBucket(route)
 		if bucket != nil && c.strategy.ShouldWait(bucket) {
 			waitDuration := c.strategy.CalculateWait(bucket)
 			if waitDuration > 0 {
-				c.logger.Debug("rate limit: proactive wait",
+				c.rateLogger.Debug("rate limit: proactive wait",
 					"route", route,
 					"wait_duration", waitDuration,
 					"strategy", strategyName,
@@ -432,6 +578,7 @@ func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 				case <-ctx.Done():
 					return ctx.Err()
 				case <-time.After(waitDuration):
+					ratelimit.ObserveWait(ctx, c.observer, route, waitDuration, ratelimit.WaitProactive)
 				}
 			}
 		}
@@ -439,17 +586,47 @@ func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 		strategyName = "none"
 	}
 
+	start := time.Now()
 	if err := c.rateLimiter.Wait(ctx, route); err != nil {
 		return err
 	}
+	if waited := time.Since(start); waited >= time.Millisecond {
+		ratelimit.ObserveWait(ctx, c.observer, route, waited, ratelimit.WaitReactive)
+	}
 
-	c.logger.Debug("rate limit: wait complete",
+	c.rateLogger.Debug("rate limit: wait complete",
 		"route", route,
 		"strategy", strategyName,
 	)
 	return nil
 }
 
+// waitForRouteBudget applies client-side per-route budgets such as TokenBucketStrategy.
+func (c *Client) waitForRouteBudget(ctx context.Context, route string) error {
+	wait, err := ratelimit.WaitForRouteBudget(ctx, c.strategy, route, c.observer)
+	if wait > 0 {
+		c.rateLogger.Debug("rate limit: route budget wait",
+			"route", route,
+			"wait_duration", wait,
+		)
+	}
+	return err
+}
+
+// updateRateLimit feeds response headers to the tracker and notifies the observer.
+func (c *Client) updateRateLimit(route string, headers http.Header) {
+	if c.rateLimiter == nil {
+		return
+	}
+	c.rateLimiter.Update(route, headers)
+	c.observer.OnBucketUpdate(route, c.rateLimiter.GetBucket(route))
+}
+
+// observeRateLimited reports a 429 response to the observer.
+func (c *Client) observeRateLimited(route string, apiErr *types.APIError, headers http.Header) {
+	c.observer.On429(route, time.Duration(apiErr.RetryAfter)*time.Second, ratelimit.IsGlobalRateLimit(headers))
+}
+
 func (c *Client) recordStrategyOutcome(route string, hitLimit bool) {
 	if adaptive, ok := c.strategy.(*ratelimit.AdaptiveStrategy); ok {
 		bucket := c.rateLimiter.GetBucket(route)
@@ -500,7 +677,7 @@ func (c *Client) parseErrorResponse(resp *http.Response) *types.APIError {
 
 	var payload struct {
 		Message    string                 `json:"message"`
-		Code       int                    `json:"code"`
+		Code       types.ErrorCode        `json:"code"`
 		Errors     map[string]interface{} `json:"errors"`
 		RetryAfter float64                `json:"retry_after"`
 	}
@@ -527,6 +704,8 @@ func createStrategy(name string) ratelimit.Strategy {
 		return ratelimit.NewDefaultProactiveStrategy()
 	case "adaptive":
 		return ratelimit.NewDefaultAdaptiveStrategy()
+	case "token_bucket":
+		return ratelimit.NewDefaultTokenBucketStrategy()
 	default:
 		return ratelimit.NewDefaultAdaptiveStrategy()
 	}
@@ -1,16 +1,20 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
 	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+	"github.com/mtreilly/godiscord/gosdk/retry"
 )
 
 func TestNewClientRequiresToken(t *testing.T) {
@@ -92,6 +96,39 @@ func TestClientRetriesOnServerError(t *testing.T) {
 	}
 }
 
+func TestClientWithRetryPolicy(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	var seen []retry.Attempt
+	policy := retry.PolicyFunc(func(a retry.Attempt) (time.Duration, bool) {
+		seen = append(seen, a)
+		return time.Millisecond, a.Number < 2
+	})
+	client, err := New("token",
+		WithBaseURL(server.URL),
+		WithRateLimiter(&noopTracker{}),
+		WithStrategy(ratelimit.NewReactiveStrategy()),
+		WithRetryPolicy(policy),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	err = client.Post(context.Background(), "/test", nil, nil)
+	var retryErr *retry.Error
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 2 {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if attempts != 2 || seen[0].StatusCode != http.StatusConflict {
+		t.Fatalf("attempts = %d, seen = %+v", attempts, seen)
+	}
+}
+
 func TestClientReturnsAPIError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -156,7 +193,7 @@ func TestClientWaitsOnRateLimiter(t *testing.T) {
 	}))
 	defer server.Close()
 
-	route := "GET:" + server.URL + "/test"
+	route := "GET:/test"
 	tracker := &mockTracker{
 		buckets: map[string]*ratelimit.Bucket{
 			route: {
@@ -185,6 +222,32 @@ func TestClientWaitsOnRateLimiter(t *testing.T) {
 	}
 }
 
+func TestClientTokenBucketStrategySmoothsBursts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := New("token",
+		WithBaseURL(server.URL),
+		WithRateLimiter(&noopTracker{}),
+		WithStrategy(ratelimit.NewTokenBucketStrategy(20, 1)),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := client.Get(context.Background(), "/test", nil); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected requests to be spaced by the token bucket, took %v", elapsed)
+	}
+}
+
 // --- helpers ---
 
 type noopTracker struct{}
@@ -216,3 +279,38 @@ func (m *mockTracker) GetBucket(route string) *ratelimit.Bucket {
 }
 
 func (m *mockTracker) Clear() {}
+
+func TestRequestLogSamplingAndContextFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := New("test-token",
+		WithBaseURL(server.URL),
+		WithLogger(logger.New(logger.DebugLevel, "json", &buf)),
+		WithRequestLogSampling(2),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := logger.ContextWithFields(context.Background(), "request_id", "r1")
+	for i := 0; i < 4; i++ {
+		if err := client.Get(ctx, "/channels/123", nil); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+
+	out := buf.String()
+	if n := strings.Count(out, "discord.client.request"); n != 2 {
+		t.Fatalf("expected 2 sampled request lines, got %d:\n%s", n, out)
+	}
+	if n := strings.Count(out, "discord.client.response"); n != 2 {
+		t.Fatalf("expected responses to follow their requests, got %d:\n%s", n, out)
+	}
+	if n := strings.Count(out, `"request_id":"r1"`); n != 4 {
+		t.Fatalf("expected context fields on every line, got %d:\n%s", n, out)
+	}
+}
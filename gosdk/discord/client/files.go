@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// FileAttachment is a file uploaded alongside a message.
+type FileAttachment = types.FileAttachment
+
+// multipartBody is a pre-encoded multipart/form-data request body. do sends
+// it as is instead of marshalling it to JSON.
+type multipartBody struct {
+	data        []byte
+	contentType string
+}
+
+// CreateMessageWithFiles sends a message with file attachments. params may
+// omit content when files are present.
+func (m *MessageService) CreateMessageWithFiles(ctx context.Context, channelID string, params *types.MessageCreateParams, files []FileAttachment) (*types.Message, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = &types.MessageCreateParams{}
+	}
+	params = m.withMentionDefaults(params)
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := encodeMultipart(params, files)
+	if err != nil {
+		return nil, err
+	}
+	var msg types.Message
+	if err := m.client.Post(ctx, fmt.Sprintf("/channels/%s/messages", channelID), body, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// PostWithFiles sends payload as payload_json alongside files in a
+// multipart/form-data POST, for endpoints that accept uploads.
+func (c *Client) PostWithFiles(ctx context.Context, path string, payload any, files []FileAttachment, out any) error {
+	body, err := encodeMultipart(payload, files)
+	if err != nil {
+		return err
+	}
+	return c.Post(ctx, path, body, out)
+}
+
+// EncodeMultipart encodes payload and files the way Discord expects uploads:
+// payload_json followed by files[n] parts. It returns the body and its
+// Content-Type, for callers that write the request or response themselves.
+func EncodeMultipart(payload any, files []FileAttachment) ([]byte, string, error) {
+	body, err := encodeMultipart(payload, files)
+	if err != nil {
+		return nil, "", err
+	}
+	return body.data, body.contentType, nil
+}
+
+// encodeMultipart writes payload as payload_json followed by files[n] parts.
+func encodeMultipart(payload any, files []FileAttachment) (*multipartBody, error) {
+	if len(files) == 0 {
+		return nil, &types.ValidationError{Field: "files", Message: "at least one file is required"}
+	}
+	if len(files) > limits.MessageAttachments {
+		return nil, &types.ValidationError{
+			Field:   "files",
+			Message: fmt.Sprintf("too many files: %d (maximum %d)", len(files), limits.MessageAttachments),
+		}
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	if err := writer.WriteField("payload_json", string(raw)); err != nil {
+		return nil, err
+	}
+
+	for i, file := range files {
+		field := fmt.Sprintf("files[%d]", i)
+		if file.Name == "" {
+			return nil, &types.ValidationError{Field: field, Message: "file name is required"}
+		}
+		if file.Reader == nil {
+			return nil, &types.ValidationError{Field: field, Message: "file reader is required"}
+		}
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, quoteEscaper.Replace(file.Name)))
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return nil, fmt.Errorf("read %s: %w", field, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return &multipartBody{data: buf.Bytes(), contentType: writer.FormDataContentType()}, nil
+}
+
+// quoteEscaper escapes filenames for Content-Disposition headers.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -0,0 +1,8 @@
+package client
+
+// REST services declared in routes/ are generated by cmd/routegen.
+//go:generate go run ../../cmd/routegen -in routes/stickers.yaml
+//go:generate go run ../../cmd/routegen -in routes/automod.yaml
+//go:generate go run ../../cmd/routegen -in routes/stages.yaml
+//go:generate go run ../../cmd/routegen -in routes/applications.yaml
+//go:generate go run ../../cmd/routegen -in routes/users.yaml
@@ -5,10 +5,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 )
 
+// premiumTierTTL bounds how long a guild's boost tier is reused for upload limits.
+const premiumTierTTL = 10 * time.Minute
+
+type premiumTierEntry struct {
+	tier    int
+	expires time.Time
+}
+
 // Guilds exposes guild-related REST helpers.
 type Guilds struct {
 	client *Client
@@ -34,9 +44,46 @@ func (g *Guilds) GetGuild(ctx context.Context, guildID string, withCounts bool)
 	if err := g.client.Get(ctx, path, &guild); err != nil {
 		return nil, err
 	}
+	g.rememberPremiumTier(&guild)
 	return &guild, nil
 }
 
+// PremiumTier returns the guild's boost tier, reusing a cached value for up to
+// ten minutes. GetGuild and ModifyGuild refresh the cache as a side effect.
+func (g *Guilds) PremiumTier(ctx context.Context, guildID string) (int, error) {
+	if v, ok := g.client.premiumTiers.Load(guildID); ok {
+		entry := v.(premiumTierEntry)
+		if time.Now().Before(entry.expires) {
+			return entry.tier, nil
+		}
+	}
+
+	guild, err := g.GetGuild(ctx, guildID, false)
+	if err != nil {
+		return 0, err
+	}
+	return guild.PremiumTier, nil
+}
+
+// UploadLimit returns the per-file upload limit in bytes for the guild's boost tier.
+func (g *Guilds) UploadLimit(ctx context.Context, guildID string) (int64, error) {
+	tier, err := g.PremiumTier(ctx, guildID)
+	if err != nil {
+		return 0, err
+	}
+	return limits.UploadLimit(tier), nil
+}
+
+func (g *Guilds) rememberPremiumTier(guild *types.Guild) {
+	if guild == nil || guild.ID == "" {
+		return
+	}
+	g.client.premiumTiers.Store(guild.ID, premiumTierEntry{
+		tier:    guild.PremiumTier,
+		expires: time.Now().Add(premiumTierTTL),
+	})
+}
+
 // GetGuildPreview fetches a preview for a discoverable guild.
 func (g *Guilds) GetGuildPreview(ctx context.Context, guildID string) (*types.GuildPreview, error) {
 	if err := validateID("guildID", guildID); err != nil {
@@ -68,6 +115,7 @@ func (g *Guilds) ModifyGuild(ctx context.Context, guildID string, params *types.
 	if err := g.client.do(ctx, http.MethodPatch, fmt.Sprintf("/guilds/%s", guildID), params, &guild, headers); err != nil {
 		return nil, err
 	}
+	g.rememberPremiumTier(&guild)
 	return &guild, nil
 }
 
@@ -120,6 +168,49 @@ func (g *Guilds) GetGuildRoles(ctx context.Context, guildID string) ([]*types.Ro
 	return roles, nil
 }
 
+// ListGuildEmojis retrieves a guild's custom emojis.
+func (g *Guilds) ListGuildEmojis(ctx context.Context, guildID string) ([]*types.Emoji, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	var emojis []*types.Emoji
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/emojis", guildID), &emojis); err != nil {
+		return nil, err
+	}
+	return emojis, nil
+}
+
+// CreateGuildEmoji uploads a custom emoji with optional audit log reason.
+func (g *Guilds) CreateGuildEmoji(ctx context.Context, guildID string, params *types.EmojiCreateParams) (*types.Emoji, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	headers := http.Header{}
+	if params.AuditLogReason != "" {
+		headers.Set("X-Audit-Log-Reason", url.QueryEscape(params.AuditLogReason))
+	}
+	var emoji types.Emoji
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf("/guilds/%s/emojis", guildID), params, &emoji, headers); err != nil {
+		return nil, err
+	}
+	return &emoji, nil
+}
+
+// ListGuildStickers retrieves a guild's custom stickers.
+func (g *Guilds) ListGuildStickers(ctx context.Context, guildID string) ([]*types.Sticker, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	var stickers []*types.Sticker
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/stickers", guildID), &stickers); err != nil {
+		return nil, err
+	}
+	return stickers, nil
+}
+
 // CreateGuildRole creates a role with optional audit log reason.
 func (g *Guilds) CreateGuildRole(ctx context.Context, guildID string, params *types.RoleCreateParams) (*types.Role, error) {
 	if err := validateID("guildID", guildID); err != nil {
@@ -161,6 +252,23 @@ func (g *Guilds) ModifyGuildRole(ctx context.Context, guildID, roleID string, pa
 	return &role, nil
 }
 
+// ModifyRolePositions moves roles in the hierarchy in one request and
+// returns every role in the guild with its new position. Roles not listed
+// keep their relative order.
+func (g *Guilds) ModifyRolePositions(ctx context.Context, guildID string, positions []types.RolePosition) ([]*types.Role, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := types.ValidateRolePositions(positions); err != nil {
+		return nil, err
+	}
+	var roles []*types.Role
+	if err := g.client.do(ctx, http.MethodPatch, fmt.Sprintf("/guilds/%s/roles", guildID), positions, &roles, nil); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
 // DeleteGuildRole removes a role from the guild.
 func (g *Guilds) DeleteGuildRole(ctx context.Context, guildID, roleID string) error {
 	if err := validateID("guildID", guildID); err != nil {
@@ -217,6 +325,30 @@ func (g *Guilds) ListGuildMembers(ctx context.Context, guildID string, params *t
 	return members, nil
 }
 
+// SearchMembers returns up to limit members whose username or nickname
+// starts with query. limit defaults to 1 and cannot exceed 1000.
+func (g *Guilds) SearchMembers(ctx context.Context, guildID, query string, limit int) ([]*types.Member, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if query == "" {
+		return nil, &types.ValidationError{Field: "query", Message: "query is required"}
+	}
+	if limit < 0 || limit > limits.MembersPerPage {
+		return nil, &types.ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 0 and %d", limits.MembersPerPage)}
+	}
+	values := url.Values{}
+	values.Set("query", query)
+	if limit > 0 {
+		values.Set("limit", fmt.Sprintf("%d", limit))
+	}
+	var members []*types.Member
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/members/search?%s", guildID, values.Encode()), &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
 // AddGuildMemberRole assigns a role to a member.
 func (g *Guilds) AddGuildMemberRole(ctx context.Context, guildID, userID, roleID string) error {
 	if err := validateID("guildID", guildID); err != nil {
@@ -244,3 +376,35 @@ func (g *Guilds) RemoveGuildMemberRole(ctx context.Context, guildID, userID, rol
 	}
 	return g.client.Delete(ctx, fmt.Sprintf("/guilds/%s/members/%s/roles/%s", guildID, userID, roleID))
 }
+
+// GetMembershipScreening retrieves the guild's membership screening form.
+func (g *Guilds) GetMembershipScreening(ctx context.Context, guildID string) (*types.MembershipScreening, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	var screening types.MembershipScreening
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/member-verification", guildID), &screening); err != nil {
+		return nil, err
+	}
+	return &screening, nil
+}
+
+// ModifyMembershipScreening updates the guild's membership screening form.
+// It requires MANAGE_GUILD.
+func (g *Guilds) ModifyMembershipScreening(ctx context.Context, guildID string, params *types.MembershipScreeningModifyParams) (*types.MembershipScreening, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	headers := http.Header{}
+	if params.AuditLogReason != "" {
+		headers.Set("X-Audit-Log-Reason", url.QueryEscape(params.AuditLogReason))
+	}
+	var screening types.MembershipScreening
+	if err := g.client.do(ctx, http.MethodPatch, fmt.Sprintf("/guilds/%s/member-verification", guildID), params, &screening, headers); err != nil {
+		return nil, err
+	}
+	return &screening, nil
+}
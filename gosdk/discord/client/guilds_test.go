@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 )
 
@@ -106,6 +107,38 @@ func TestGuildRoleOperations(t *testing.T) {
 	}
 }
 
+func TestGuildsCreateEmoji(t *testing.T) {
+	var body types.EmojiCreateParams
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/guilds/1/emojis" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(types.Emoji{ID: "5", Name: body.Name, Roles: body.Roles})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	params := &types.EmojiCreateParams{Name: "party_blob", Image: "data:image/png;base64,AAAA", Roles: []string{"2"}}
+	emoji, err := client.Guilds().CreateGuildEmoji(context.Background(), "1", params)
+	if err != nil {
+		t.Fatalf("CreateGuildEmoji error: %v", err)
+	}
+	if emoji.ID != "5" || body.Image != params.Image || len(body.Roles) != 1 {
+		t.Fatalf("emoji = %+v, body = %+v", emoji, body)
+	}
+
+	for _, bad := range []*types.EmojiCreateParams{
+		{Name: "x", Image: params.Image},
+		{Name: "has space", Image: params.Image},
+		{Name: "blob", Image: "https://example.com/blob.png"},
+	} {
+		if _, err := client.Guilds().CreateGuildEmoji(context.Background(), "1", bad); err == nil {
+			t.Fatalf("expected validation error for %+v", bad)
+		}
+	}
+}
+
 func TestGuildMemberOperations(t *testing.T) {
 	var requests []string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -139,3 +172,125 @@ func TestGuildMemberOperations(t *testing.T) {
 		t.Fatalf("expected requests")
 	}
 }
+
+func TestGuildsUploadLimitCachesTier(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		json.NewEncoder(w).Encode(types.Guild{ID: "1", PremiumTier: 2})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	for i := 0; i < 2; i++ {
+		limit, err := client.Guilds().UploadLimit(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("UploadLimit error: %v", err)
+		}
+		if limit != limits.FileSizeTier2 {
+			t.Fatalf("expected tier 2 limit, got %d", limit)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected premium tier to be cached, got %d requests", calls)
+	}
+}
+
+func TestGuildsMembershipScreening(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/1/member-verification" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method == http.MethodPatch {
+			if r.Header.Get("X-Audit-Log-Reason") != "new+rules" {
+				t.Fatalf("missing audit log reason: %q", r.Header.Get("X-Audit-Log-Reason"))
+			}
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			fields, ok := body["form_fields"].(string)
+			if !ok || body["enabled"] != true {
+				t.Fatalf("form_fields should be a JSON string, got %#v", body)
+			}
+			w.Write([]byte(`{"version":"2024-05-01T00:00:00Z","form_fields":` + fields + `}`))
+			return
+		}
+		w.Write([]byte(`{"version":"2024-01-01T00:00:00Z","form_fields":[{"field_type":"TERMS","label":"Read the rules","values":["Be nice"],"required":true}],"description":"Welcome"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	screening, err := client.Guilds().GetMembershipScreening(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetMembershipScreening error: %v", err)
+	}
+	if len(screening.FormFields) != 1 || screening.FormFields[0].Values[0] != "Be nice" || screening.Description != "Welcome" {
+		t.Fatalf("unexpected screening: %+v", screening)
+	}
+
+	enabled := true
+	updated, err := client.Guilds().ModifyMembershipScreening(context.Background(), "1", &types.MembershipScreeningModifyParams{
+		Enabled:        &enabled,
+		FormFields:     []types.ScreeningField{types.NewTermsField("Rules", "Be nice", "No spam")},
+		AuditLogReason: "new rules",
+	})
+	if err != nil {
+		t.Fatalf("ModifyMembershipScreening error: %v", err)
+	}
+	if len(updated.FormFields) != 1 || len(updated.FormFields[0].Values) != 2 {
+		t.Fatalf("unexpected update: %+v", updated)
+	}
+
+	if _, err := client.Guilds().ModifyMembershipScreening(context.Background(), "1", &types.MembershipScreeningModifyParams{
+		FormFields: []types.ScreeningField{types.NewTermsField("Rules")},
+	}); err == nil {
+		t.Fatal("expected validation error for a terms field without rules")
+	}
+}
+
+func TestGuildsSearchMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/1/members/search" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query(); got.Get("query") != "ali" || got.Get("limit") != "5" {
+			t.Fatalf("unexpected query %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode([]*types.Member{{Nick: "alice"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	members, err := client.Guilds().SearchMembers(context.Background(), "1", "ali", 5)
+	if err != nil || len(members) != 1 || members[0].Nick != "alice" {
+		t.Fatalf("SearchMembers() = %+v, %v", members, err)
+	}
+	if _, err := client.Guilds().SearchMembers(context.Background(), "1", "", 5); err == nil {
+		t.Fatalf("expected error for empty query")
+	}
+	if _, err := client.Guilds().SearchMembers(context.Background(), "1", "ali", 1001); err == nil {
+		t.Fatalf("expected error for limit above 1000")
+	}
+}
+
+func TestGuildsModifyRolePositions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/guilds/1/roles" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var positions []types.RolePosition
+		if err := json.NewDecoder(r.Body).Decode(&positions); err != nil || len(positions) != 2 || positions[0].Position != 2 {
+			t.Fatalf("unexpected body %+v, %v", positions, err)
+		}
+		json.NewEncoder(w).Encode([]*types.Role{{ID: "3", Position: 1}, {ID: "2", Position: 2}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	roles, err := client.Guilds().ModifyRolePositions(context.Background(), "1", []types.RolePosition{{ID: "2", Position: 2}, {ID: "3", Position: 1}})
+	if err != nil || len(roles) != 2 {
+		t.Fatalf("ModifyRolePositions() = %+v, %v", roles, err)
+	}
+	if _, err := client.Guilds().ModifyRolePositions(context.Background(), "1", nil); err == nil {
+		t.Fatalf("expected error for empty positions")
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// Invites exposes invite lookup and deletion helpers. Channel and guild
+// invite listing live on Channels and Guilds.
+type Invites struct {
+	client *Client
+}
+
+// Invites returns an invite service bound to the client instance.
+func (c *Client) Invites() *Invites {
+	return &Invites{client: c}
+}
+
+// GetInvite resolves an invite code. Set params.WithCounts for approximate
+// member counts and params.WithExpiration for ExpiresAt.
+func (i *Invites) GetInvite(ctx context.Context, code string, params *types.GetInviteParams) (*types.Invite, error) {
+	if code == "" {
+		return nil, &types.ValidationError{Field: "code", Message: "invite code is required"}
+	}
+
+	query := url.Values{}
+	if params != nil {
+		if params.WithCounts {
+			query.Set("with_counts", "true")
+		}
+		if params.WithExpiration {
+			query.Set("with_expiration", "true")
+		}
+		if params.GuildScheduledEventID != "" {
+			query.Set("guild_scheduled_event_id", params.GuildScheduledEventID)
+		}
+	}
+	path := "/invites/" + url.PathEscape(code)
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	var invite types.Invite
+	if err := i.client.Get(ctx, path, &invite); err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// DeleteInvite revokes an invite and returns it.
+func (i *Invites) DeleteInvite(ctx context.Context, code, reason string) (*types.Invite, error) {
+	if code == "" {
+		return nil, &types.ValidationError{Field: "code", Message: "invite code is required"}
+	}
+	var invite types.Invite
+	if err := i.client.do(ctx, http.MethodDelete, "/invites/"+url.PathEscape(code), nil, &invite, auditHeaders(reason)); err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// GetChannelInvites lists a channel's invites with their metadata.
+func (c *Channels) GetChannelInvites(ctx context.Context, channelID string) ([]*types.Invite, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	var invites []*types.Invite
+	if err := c.client.Get(ctx, fmt.Sprintf("/channels/%s/invites", channelID), &invites); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// CreateChannelInvite creates an invite for a channel. A nil params uses
+// Discord's defaults.
+func (c *Channels) CreateChannelInvite(ctx context.Context, channelID string, params *types.CreateChannelInviteParams) (*types.Invite, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = &types.CreateChannelInviteParams{}
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	var invite types.Invite
+	if err := c.client.do(ctx, http.MethodPost, fmt.Sprintf("/channels/%s/invites", channelID), params, &invite, auditHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// GetGuildInvites lists every invite in a guild with their metadata.
+func (g *Guilds) GetGuildInvites(ctx context.Context, guildID string) ([]*types.Invite, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	var invites []*types.Invite
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/invites", guildID), &invites); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
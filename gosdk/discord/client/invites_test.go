@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestGetInviteQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/invites/abc" || r.URL.Query().Get("with_counts") != "true" || r.URL.Query().Get("with_expiration") != "true" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		io.WriteString(w, `{"code":"abc","approximate_member_count":12,"expires_at":"2026-01-02T03:04:05Z"}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	invite, err := client.Invites().GetInvite(context.Background(), "abc", &types.GetInviteParams{WithCounts: true, WithExpiration: true})
+	if err != nil {
+		t.Fatalf("GetInvite error: %v", err)
+	}
+	if invite.ApproximateMemberCount != 12 || invite.ExpiresAt == nil || invite.URL() != "https://discord.gg/abc" {
+		t.Fatalf("unexpected invite %+v", invite)
+	}
+}
+
+func TestCreateAndDeleteInvite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if r.URL.Path != "/channels/1/invites" || body["max_age"] != float64(0) || body["max_uses"] != float64(5) {
+				t.Fatalf("unexpected create %s %v", r.URL.Path, body)
+			}
+			if r.Header.Get("X-Audit-Log-Reason") != "event" {
+				t.Fatalf("missing reason header")
+			}
+			io.WriteString(w, `{"code":"new","max_uses":5}`)
+		case http.MethodDelete:
+			if r.URL.Path != "/invites/new" || r.Header.Get("X-Audit-Log-Reason") != "cleanup" {
+				t.Fatalf("unexpected delete %s", r.URL.Path)
+			}
+			io.WriteString(w, `{"code":"new"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	never, uses := 0, 5
+	invite, err := client.Channels().CreateChannelInvite(context.Background(), "1", &types.CreateChannelInviteParams{MaxAge: &never, MaxUses: &uses, AuditLogReason: "event"})
+	if err != nil || invite.MaxUses != 5 {
+		t.Fatalf("CreateChannelInvite: %+v %v", invite, err)
+	}
+	if _, err := client.Invites().DeleteInvite(context.Background(), "new", "cleanup"); err != nil {
+		t.Fatalf("DeleteInvite error: %v", err)
+	}
+}
+
+func TestListInvites(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/1/invites" && r.URL.Path != "/channels/2/invites" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		io.WriteString(w, `[{"code":"a","uses":3},{"code":"b"}]`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	guildInvites, err := client.Guilds().GetGuildInvites(context.Background(), "1")
+	if err != nil || len(guildInvites) != 2 || guildInvites[0].Uses != 3 {
+		t.Fatalf("GetGuildInvites: %+v %v", guildInvites, err)
+	}
+	channelInvites, err := client.Channels().GetChannelInvites(context.Background(), "2")
+	if err != nil || len(channelInvites) != 2 {
+		t.Fatalf("GetChannelInvites: %+v %v", channelInvites, err)
+	}
+}
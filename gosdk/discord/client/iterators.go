@@ -0,0 +1,288 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// PaginateReactions pages through the users who reacted to a message with
+// emoji, in user ID order.
+func (m *MessageService) PaginateReactions(channelID, messageID, emoji string, opts ...PaginatorOption) *Paginator[*types.User] {
+	fetch := func(ctx context.Context, after string) ([]*types.User, string, error) {
+		page, err := m.GetReactions(ctx, channelID, messageID, emoji, &GetReactionsParams{Limit: limits.ReactionsPerPage, After: after})
+		if err != nil || len(page) < limits.ReactionsPerPage {
+			return page, "", err
+		}
+		return page, page[len(page)-1].ID, nil
+	}
+	return NewPaginator(fetch, "", opts...)
+}
+
+// ForEachReaction calls fn for every user who reacted to a message with
+// emoji, fetching pages of users as needed. Return types.ErrStopIteration
+// from fn to stop early; any other error from fn is returned as is.
+func (m *MessageService) ForEachReaction(ctx context.Context, channelID, messageID, emoji string, fn func(*types.User) error) error {
+	return m.PaginateReactions(channelID, messageID, emoji).ForEach(ctx, fn)
+}
+
+// PaginateChannelMessages pages through a channel's history. By default it
+// walks backwards from the newest message (or from params.Before); with
+// params.After set it walks forwards from that message, oldest first.
+// params.Limit sets the page size (default 100); Around is not supported.
+func (c *Channels) PaginateChannelMessages(channelID string, params *GetChannelMessagesParams, opts ...PaginatorOption) (*Paginator[*types.Message], error) {
+	var base GetChannelMessagesParams
+	if params != nil {
+		base = *params
+	}
+	if base.Around != "" {
+		return nil, &types.ValidationError{Field: "around", Message: "around cannot be used to iterate messages"}
+	}
+	if base.Before != "" && base.After != "" {
+		return nil, &types.ValidationError{Field: "before/after", Message: "iterate with either before or after, not both"}
+	}
+	if base.Limit == 0 {
+		base.Limit = limits.MessagesPerPage
+	}
+	forward := base.After != ""
+
+	fetch := func(ctx context.Context, cursor string) ([]*types.Message, string, error) {
+		query := base
+		if forward {
+			query.After = cursor
+		} else {
+			query.Before = cursor
+		}
+		page, err := c.GetChannelMessages(ctx, channelID, &query)
+		if err != nil {
+			return nil, "", err
+		}
+		// Discord returns pages newest first in both directions.
+		sort.Slice(page, func(i, j int) bool {
+			if forward {
+				return snowflakeLess(page[i].ID, page[j].ID)
+			}
+			return snowflakeLess(page[j].ID, page[i].ID)
+		})
+		if len(page) < base.Limit {
+			return page, "", nil
+		}
+		return page, page[len(page)-1].ID, nil
+	}
+	start := base.Before
+	if forward {
+		start = base.After
+	}
+	return NewPaginator(fetch, start, opts...), nil
+}
+
+// ForEachMessage calls fn for every message in a channel's history, in the
+// order described by PaginateChannelMessages. Return types.ErrStopIteration
+// from fn to stop early.
+func (c *Channels) ForEachMessage(ctx context.Context, channelID string, params *GetChannelMessagesParams, fn func(*types.Message) error) error {
+	p, err := c.PaginateChannelMessages(channelID, params)
+	if err != nil {
+		return err
+	}
+	return p.ForEach(ctx, fn)
+}
+
+// PaginateCurrentUserGuilds pages through the guilds the bot is in, in guild
+// ID order. Set withCounts for approximate member and presence counts.
+func (u *Users) PaginateCurrentUserGuilds(withCounts bool, opts ...PaginatorOption) *Paginator[*types.Guild] {
+	fetch := func(ctx context.Context, after string) ([]*types.Guild, string, error) {
+		page, err := u.ListCurrentUserGuilds(ctx, &types.ListCurrentUserGuildsParams{
+			After:      after,
+			Limit:      limits.UserGuildsPerPage,
+			WithCounts: withCounts,
+		})
+		if err != nil || len(page) < limits.UserGuildsPerPage {
+			return page, "", err
+		}
+		return page, page[len(page)-1].ID, nil
+	}
+	return NewPaginator(fetch, "", opts...)
+}
+
+// PaginateGuildMembers pages through the members of a guild in user ID
+// order. It requires the GUILD_MEMBERS privileged intent.
+func (g *Guilds) PaginateGuildMembers(guildID string, opts ...PaginatorOption) *Paginator[*types.Member] {
+	fetch := func(ctx context.Context, after string) ([]*types.Member, string, error) {
+		page, err := g.ListGuildMembers(ctx, guildID, &types.ListMembersParams{Limit: limits.MembersPerPage, After: after})
+		if err != nil || len(page) < limits.MembersPerPage {
+			return page, "", err
+		}
+		last := page[len(page)-1]
+		if last.User == nil {
+			return nil, "", &types.ValidationError{Field: "member.user", Message: "member page is missing user IDs"}
+		}
+		return page, last.User.ID, nil
+	}
+	return NewPaginator(fetch, "", opts...)
+}
+
+// ForEachMember calls fn for every member of a guild in user ID order. It
+// requires the GUILD_MEMBERS privileged intent. Return types.ErrStopIteration
+// from fn to stop early.
+func (g *Guilds) ForEachMember(ctx context.Context, guildID string, fn func(*types.Member) error) error {
+	return g.PaginateGuildMembers(guildID).ForEach(ctx, fn)
+}
+
+// PaginateGuildBans pages through a guild's bans in user ID order.
+func (g *Guilds) PaginateGuildBans(guildID string, opts ...PaginatorOption) *Paginator[*types.Ban] {
+	fetch := func(ctx context.Context, after string) ([]*types.Ban, string, error) {
+		page, err := g.ListBans(ctx, guildID, &types.ListBansParams{Limit: limits.BansPerPage, After: after})
+		if err != nil || len(page) < limits.BansPerPage {
+			return page, "", err
+		}
+		last := page[len(page)-1]
+		if last.User == nil {
+			return nil, "", &types.ValidationError{Field: "ban.user", Message: "ban page is missing user IDs"}
+		}
+		return page, last.User.ID, nil
+	}
+	return NewPaginator(fetch, "", opts...)
+}
+
+// PaginateGuildAuditLog pages through a guild's audit log. By default it
+// walks forwards, oldest entry first, starting after params.After; with
+// params.Before set it walks backwards from that entry, newest first. The
+// UserID and ActionType filters apply to every page, and params.Limit sets
+// the page size (default 100).
+func (a *AuditLogs) PaginateGuildAuditLog(guildID string, params *types.AuditLogParams, opts ...PaginatorOption) (*Paginator[types.AuditLogEntry], error) {
+	var base types.AuditLogParams
+	if params != nil {
+		base = *params
+	}
+	if err := base.Validate(); err != nil {
+		return nil, err
+	}
+	if base.Limit == 0 {
+		base.Limit = limits.AuditLogEntriesPerPage
+	}
+	backward := base.Before != ""
+	if !backward && base.After == "" {
+		// Without after Discord returns the newest entries first.
+		base.After = "0"
+	}
+
+	fetch := func(ctx context.Context, cursor string) ([]types.AuditLogEntry, string, error) {
+		query := base
+		if backward {
+			query.Before = cursor
+		} else {
+			query.After = cursor
+		}
+		log, err := a.GetGuildAuditLog(ctx, guildID, &query)
+		if err != nil {
+			return nil, "", err
+		}
+		entries := log.Entries
+		sort.Slice(entries, func(i, j int) bool {
+			if backward {
+				return snowflakeLess(entries[j].ID, entries[i].ID)
+			}
+			return snowflakeLess(entries[i].ID, entries[j].ID)
+		})
+		if len(entries) < base.Limit {
+			return entries, "", nil
+		}
+		return entries, entries[len(entries)-1].ID, nil
+	}
+	start := base.After
+	if backward {
+		start = base.Before
+	}
+	return NewPaginator(fetch, start, opts...), nil
+}
+
+// PaginateArchivedThreads pages through a channel's archived threads, most
+// recently archived first. private selects private rather than public
+// threads. Pages follow Discord's has_more flag.
+//
+// Discord's before filter is exclusive, so each page starts just after the
+// last thread's archive timestamp and threads already returned are skipped.
+// Threads archived at the same instant are therefore not lost across pages.
+func (c *Channels) PaginateArchivedThreads(channelID string, private bool, opts ...PaginatorOption) *Paginator[types.Channel] {
+	list := c.ListPublicArchivedThreads
+	if private {
+		list = c.ListPrivateArchivedThreads
+	}
+	// previous holds the IDs on the last page, the only threads the
+	// overlapping window can return again.
+	var previous map[string]bool
+	fetch := func(ctx context.Context, cursor string) ([]types.Channel, string, error) {
+		params := &ArchivedThreadsParams{}
+		if cursor != "" {
+			before, err := time.Parse(time.RFC3339Nano, cursor)
+			if err != nil {
+				return nil, "", err
+			}
+			params.Before = before.Add(time.Millisecond)
+		}
+		page, err := list(ctx, channelID, params)
+		if err != nil {
+			return nil, "", err
+		}
+		threads := make([]types.Channel, 0, len(page.Threads))
+		seen := make(map[string]bool, len(page.Threads))
+		for _, thread := range page.Threads {
+			seen[thread.ID] = true
+			if !previous[thread.ID] {
+				threads = append(threads, thread)
+			}
+		}
+		previous = seen
+		if !page.HasMore || len(page.Threads) == 0 {
+			return threads, "", nil
+		}
+		last := page.Threads[len(page.Threads)-1]
+		if last.ThreadMetadata == nil || last.ThreadMetadata.ArchiveTimestamp == nil {
+			return nil, "", &types.ValidationError{Field: "thread_metadata.archive_timestamp", Message: "thread page is missing archive timestamps"}
+		}
+		next := last.ThreadMetadata.ArchiveTimestamp.UTC()
+		if len(threads) == 0 {
+			// A full page archived within one millisecond: step past it
+			// rather than fetching the same page forever.
+			next = next.Add(-time.Millisecond)
+		}
+		return threads, next.Format(time.RFC3339Nano), nil
+	}
+	return NewPaginator(fetch, "", opts...)
+}
+
+// PaginateJoinedArchivedThreads pages through the archived private threads in
+// a channel that the current user has joined, newest thread first.
+func (c *Channels) PaginateJoinedArchivedThreads(channelID string, opts ...PaginatorOption) *Paginator[types.Channel] {
+	fetch := func(ctx context.Context, before string) ([]types.Channel, string, error) {
+		page, err := c.ListJoinedPrivateArchivedThreads(ctx, channelID, &JoinedArchivedThreadsParams{Before: before})
+		if err != nil {
+			return nil, "", err
+		}
+		if !page.HasMore || len(page.Threads) == 0 {
+			return page.Threads, "", nil
+		}
+		return page.Threads, page.Threads[len(page.Threads)-1].ID, nil
+	}
+	return NewPaginator(fetch, "", opts...)
+}
+
+// stopIteration turns types.ErrStopIteration into a clean stop.
+func stopIteration(err error) error {
+	if errors.Is(err, types.ErrStopIteration) {
+		return nil
+	}
+	return err
+}
+
+// snowflakeLess orders snowflake IDs numerically.
+func snowflakeLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
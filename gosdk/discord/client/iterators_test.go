@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestForEachReactionPages(t *testing.T) {
+	const total = 250
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var users []types.User
+		for id := after + 1; id <= total && len(users) < limit; id++ {
+			users = append(users, types.User{ID: strconv.Itoa(id)})
+		}
+		json.NewEncoder(w).Encode(users)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	var seen []string
+	err := client.Messages().ForEachReaction(context.Background(), "1", "2", "👍", func(u *types.User) error {
+		seen = append(seen, u.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachReaction error: %v", err)
+	}
+	if len(seen) != total || seen[0] != "1" || seen[total-1] != "250" || calls != 3 {
+		t.Fatalf("saw %d users in %d calls", len(seen), calls)
+	}
+
+	var stoppedAt int
+	err = client.Messages().ForEachReaction(context.Background(), "1", "2", "👍", func(u *types.User) error {
+		stoppedAt++
+		if stoppedAt == 5 {
+			return types.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil || stoppedAt != 5 {
+		t.Fatalf("stop iteration: err=%v after %d users", err, stoppedAt)
+	}
+
+	boom := errors.New("boom")
+	err = client.Messages().ForEachReaction(context.Background(), "1", "2", "👍", func(*types.User) error { return boom })
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+}
+
+// messagePage mimics Discord's channel history: newest first in both
+// directions, holding the messages nearest the cursor.
+func messagePage(r *http.Request, total int) []types.Message {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	var page []types.Message
+	if after := r.URL.Query().Get("after"); after != "" {
+		start, _ := strconv.Atoi(after)
+		for id := start + 1; id <= total && len(page) < limit; id++ {
+			page = append([]types.Message{{ID: strconv.Itoa(id)}}, page...)
+		}
+		return page
+	}
+	start := total
+	if before := r.URL.Query().Get("before"); before != "" {
+		start, _ = strconv.Atoi(before)
+		start--
+	}
+	for id := start; id >= 1 && len(page) < limit; id-- {
+		page = append(page, types.Message{ID: strconv.Itoa(id)})
+	}
+	return page
+}
+
+func TestForEachMessageDirections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(messagePage(r, 25))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	var backward []string
+	err := client.Channels().ForEachMessage(context.Background(), "1", &GetChannelMessagesParams{Limit: 10}, func(m *types.Message) error {
+		backward = append(backward, m.ID)
+		return nil
+	})
+	if err != nil || len(backward) != 25 || backward[0] != "25" || backward[24] != "1" {
+		t.Fatalf("backward iteration: %v %v", backward, err)
+	}
+
+	var forward []string
+	err = client.Channels().ForEachMessage(context.Background(), "1", &GetChannelMessagesParams{Limit: 10, After: "5"}, func(m *types.Message) error {
+		forward = append(forward, m.ID)
+		return nil
+	})
+	if err != nil || len(forward) != 20 || forward[0] != "6" || forward[19] != "25" {
+		t.Fatalf("forward iteration: %v %v", forward, err)
+	}
+
+	if err := client.Channels().ForEachMessage(context.Background(), "1", &GetChannelMessagesParams{Around: "5"}, func(*types.Message) error { return nil }); err == nil {
+		t.Fatal("expected error for around")
+	}
+}
+
+func TestForEachMemberPages(t *testing.T) {
+	const total = 1500
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var members []types.Member
+		for id := after + 1; id <= total && len(members) < limit; id++ {
+			members = append(members, types.Member{User: &types.User{ID: strconv.Itoa(id)}})
+		}
+		json.NewEncoder(w).Encode(members)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	count := 0
+	err := client.Guilds().ForEachMember(context.Background(), "1", func(m *types.Member) error {
+		count++
+		return nil
+	})
+	if err != nil || count != total {
+		t.Fatalf("ForEachMember visited %d members, err %v", count, err)
+	}
+}
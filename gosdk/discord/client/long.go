@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/format"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// SendLong sends params to a channel even when its content exceeds Discord's
+// limit. Content is split with format.SplitMessage and sent as consecutive
+// messages; a reply's message reference stays on the first one, embeds,
+// components and polls ride on the last one, and allowed mentions apply to
+// all of them. Content that would need more than opts.MaxMessages parts
+// is uploaded as a file instead.
+//
+// The created messages are returned in order. On failure the messages sent
+// so far are returned with the error.
+func (m *MessageService) SendLong(ctx context.Context, channelID string, params *types.MessageCreateParams, opts *format.LongMessageOptions) ([]*types.Message, error) {
+	if params == nil {
+		return nil, &types.ValidationError{Field: "params", Message: "message create params required"}
+	}
+
+	chunks := format.SplitMessage(params.Content, 0)
+	if len(chunks) <= 1 {
+		created, err := m.CreateMessage(ctx, channelID, params)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Message{created}, nil
+	}
+
+	if name, upload := opts.Upload(len(chunks)); upload {
+		withFile := *params
+		withFile.Content = ""
+		created, err := m.CreateMessageWithFiles(ctx, channelID, &withFile, []FileAttachment{{
+			Name:        name,
+			ContentType: "text/plain; charset=utf-8",
+			Reader:      strings.NewReader(params.Content),
+		}})
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Message{created}, nil
+	}
+
+	sent := make([]*types.Message, 0, len(chunks))
+	for i, chunk := range chunks {
+		part := *params
+		part.Content = chunk
+		if i > 0 {
+			// Only the first part replies, so the author is pinged once.
+			part.MessageReference, part.RepliedUserPing = nil, nil
+		}
+		if i < len(chunks)-1 {
+			part.Embeds, part.Components, part.Poll = nil, nil, nil
+		}
+		created, err := m.CreateMessage(ctx, channelID, &part)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, created)
+	}
+	return sent, nil
+}
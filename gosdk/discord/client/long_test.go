@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/format"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestMessageServiceSendLongSplits(t *testing.T) {
+	var received []types.MessageCreateParams
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload types.MessageCreateParams
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		received = append(received, payload)
+		json.NewEncoder(w).Encode(types.Message{ID: "1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	content := "```\n" + strings.Repeat("0123456789\n", 250) + "```"
+	params := &types.MessageCreateParams{
+		Content: content,
+		Embeds:  []types.Embed{{Title: "done"}},
+	}
+	sent, err := client.Messages().SendLong(context.Background(), "123", params.ReplyTo("42"), nil)
+	if err != nil {
+		t.Fatalf("SendLong error: %v", err)
+	}
+	if len(sent) != 2 || len(received) != 2 {
+		t.Fatalf("expected 2 messages, sent %d received %d", len(sent), len(received))
+	}
+	for i, payload := range received {
+		if len(payload.Content) > 2000 || strings.Count(payload.Content, "```") != 2 {
+			t.Fatalf("part %d is not a balanced code block within the limit", i)
+		}
+	}
+	if len(received[0].Embeds) != 0 || len(received[1].Embeds) != 1 {
+		t.Fatal("embeds should only be sent with the last part")
+	}
+	if received[0].MessageReference == nil || received[1].MessageReference != nil {
+		t.Fatal("only the first part should reply")
+	}
+}
+
+func TestMessageServiceSendLongUploadsFile(t *testing.T) {
+	var payloadJSON, fileName, fileBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/form-data" {
+			t.Fatalf("expected multipart request, got %q", r.Header.Get("Content-Type"))
+		}
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("read part: %v", err)
+			}
+			data, _ := io.ReadAll(part)
+			switch part.FormName() {
+			case "payload_json":
+				payloadJSON = string(data)
+			case "files[0]":
+				fileName, fileBody = part.FileName(), string(data)
+			}
+		}
+		json.NewEncoder(w).Encode(types.Message{ID: "1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	content := strings.Repeat("y", 3000)
+	sent, err := client.Messages().SendLong(context.Background(), "123", &types.MessageCreateParams{Content: content}, &format.LongMessageOptions{MaxMessages: 1})
+	if err != nil {
+		t.Fatalf("SendLong error: %v", err)
+	}
+	if len(sent) != 1 {
+		t.Fatalf("expected one message, got %d", len(sent))
+	}
+	if fileName != format.DefaultLongMessageFileName || fileBody != content {
+		t.Fatalf("uploaded %q with %d bytes", fileName, len(fileBody))
+	}
+	if strings.Contains(payloadJSON, `"content"`) {
+		t.Fatalf("content should move into the file, payload_json = %s", payloadJSON)
+	}
+}
+
+func TestCreateMessageWithFilesValidation(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1")
+	_, err := client.Messages().CreateMessageWithFiles(context.Background(), "123", nil, nil)
+	if err == nil {
+		t.Fatal("expected error without files")
+	}
+	_, err = client.Messages().CreateMessageWithFiles(context.Background(), "123", nil, []FileAttachment{{Reader: strings.NewReader("x")}})
+	if err == nil {
+		t.Fatal("expected error for unnamed file")
+	}
+	forward := &types.MessageCreateParams{
+		Content:          "look",
+		MessageReference: &types.MessageReference{Type: types.MessageReferenceForward, MessageID: "1", ChannelID: "2"},
+	}
+	_, err = client.Messages().CreateMessageWithFiles(context.Background(), "123", forward, []FileAttachment{{Name: "a.txt", Reader: strings.NewReader("x")}})
+	if err == nil {
+		t.Fatal("expected error for a forward with content")
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/utils"
+	"github.com/mtreilly/godiscord/gosdk/idempotency"
 )
 
 // MessageService provides helpers for channel message operations.
@@ -32,6 +36,10 @@ func (m *MessageService) CreateMessage(ctx context.Context, channelID string, pa
 	if params == nil {
 		return nil, &types.ValidationError{Field: "params", Message: "message create params required"}
 	}
+	params = m.withMentionDefaults(params)
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 
 	var msg types.Message
 	if err := m.client.Post(ctx, fmt.Sprintf("/channels/%s/messages", channelID), params, &msg); err != nil {
@@ -40,6 +48,68 @@ func (m *MessageService) CreateMessage(ctx context.Context, channelID string, pa
 	return &msg, nil
 }
 
+// withMentionDefaults applies the client's default mention policy and then
+// params.RepliedUserPing, copying params rather than changing them.
+func (m *MessageService) withMentionDefaults(params *types.MessageCreateParams) *types.MessageCreateParams {
+	if params.AllowedMentions == nil && m.client.defaultMentions != nil {
+		withDefault := *params
+		withDefault.AllowedMentions = m.client.defaultMentions
+		params = &withDefault
+	}
+	if params.RepliedUserPing != nil {
+		withPing := *params
+		withPing.AllowedMentions = params.AllowedMentions.WithRepliedUser(*params.RepliedUserPing)
+		params = &withPing
+	}
+	return params
+}
+
+// CreateMessageIdempotent sends a message under an idempotency key, so
+// calling it again with the same key after an ambiguous failure (a timeout
+// or dropped connection) does not post the message twice. A key whose
+// message was created returns it with WasDuplicate set and sends nothing.
+//
+// The key also becomes the message nonce with enforce_nonce, so Discord
+// collapses this call's own retries. Discord only honours the nonce for a
+// few minutes, so a key whose earlier send ended without a response
+// returns idempotency.ErrPending rather than risking a second post.
+func (m *MessageService) CreateMessageIdempotent(ctx context.Context, channelID, key string, params *types.MessageCreateParams) (*idempotency.Result, error) {
+	if key == "" {
+		return nil, &types.ValidationError{Field: "key", Message: "idempotency key is required"}
+	}
+	if params == nil {
+		return nil, &types.ValidationError{Field: "params", Message: "message create params required"}
+	}
+
+	store := m.client.idempotency
+	record, claimed, err := store.Claim(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("idempotency claim failed: %w", err)
+	}
+	if !claimed {
+		if record.Pending() {
+			return nil, idempotency.ErrPending
+		}
+		return &idempotency.Result{Message: record.Message, WasDuplicate: true}, nil
+	}
+
+	withNonce := *params
+	withNonce.Nonce = idempotency.Nonce(key)
+	withNonce.EnforceNonce = true
+
+	msg, err := m.CreateMessage(ctx, channelID, &withNonce)
+	if err != nil {
+		if idempotency.Rejected(err) {
+			_ = store.Release(ctx, key)
+		}
+		return nil, err
+	}
+	if err := store.Complete(ctx, key, msg); err != nil {
+		m.client.logger.Warn("idempotency record failed", "key", key, "error", err)
+	}
+	return &idempotency.Result{Message: msg}, nil
+}
+
 // GetMessage fetches a single message.
 func (m *MessageService) GetMessage(ctx context.Context, channelID, messageID string) (*types.Message, error) {
 	if err := validateID("channelID", channelID); err != nil {
@@ -56,6 +126,80 @@ func (m *MessageService) GetMessage(ctx context.Context, channelID, messageID st
 	return &msg, nil
 }
 
+// Reply sends content as a reply to messageID. Whether the author is
+// pinged follows the client's default mention policy; use CreateMessage
+// with MessageCreateParams.ReplyTo for finer control.
+func (m *MessageService) Reply(ctx context.Context, channelID, messageID, content string) (*types.Message, error) {
+	if err := validateID("messageID", messageID); err != nil {
+		return nil, err
+	}
+	params := &types.MessageCreateParams{Content: content}
+	return m.CreateMessage(ctx, channelID, params.ReplyTo(messageID))
+}
+
+// ForwardMessage forwards a message from sourceChannelID into channelID.
+// The forward shows a snapshot of the original and cannot add content.
+func (m *MessageService) ForwardMessage(ctx context.Context, channelID, sourceChannelID, messageID string) (*types.Message, error) {
+	if err := validateID("sourceChannelID", sourceChannelID); err != nil {
+		return nil, err
+	}
+	if err := validateID("messageID", messageID); err != nil {
+		return nil, err
+	}
+	return m.CreateMessage(ctx, channelID, &types.MessageCreateParams{
+		MessageReference: &types.MessageReference{
+			Type:      types.MessageReferenceForward,
+			MessageID: messageID,
+			ChannelID: sourceChannelID,
+		},
+	})
+}
+
+// CrosspostMessage publishes a message in an announcement channel to the
+// channels following it.
+func (m *MessageService) CrosspostMessage(ctx context.Context, channelID, messageID string) (*types.Message, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if err := validateID("messageID", messageID); err != nil {
+		return nil, err
+	}
+
+	var msg types.Message
+	if err := m.client.Post(ctx, fmt.Sprintf("/channels/%s/messages/%s/crosspost", channelID, messageID), nil, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// LatestMessages returns up to n of the newest messages in a channel, newest
+// first, fetching as many pages as needed.
+func (m *MessageService) LatestMessages(ctx context.Context, channelID string, n int) ([]*types.Message, error) {
+	if n < 1 {
+		return nil, &types.ValidationError{Field: "n", Message: "n must be at least 1"}
+	}
+	params := &GetChannelMessagesParams{Limit: min(n, limits.MessagesPerPage)}
+	p, err := m.client.Channels().PaginateChannelMessages(channelID, params, WithMaxItems(n))
+	if err != nil {
+		return nil, err
+	}
+	return p.All(ctx)
+}
+
+// MessagesSince returns every message sent at or after since, oldest first.
+// Busy channels can hold a lot of history; pass WithMaxItems to cap it.
+func (m *MessageService) MessagesSince(ctx context.Context, channelID string, since time.Time, opts ...PaginatorOption) ([]*types.Message, error) {
+	if since.IsZero() {
+		return nil, &types.ValidationError{Field: "since", Message: "since is required"}
+	}
+	params := &GetChannelMessagesParams{After: utils.TimeToSnowflake(since)}
+	p, err := m.client.Channels().PaginateChannelMessages(channelID, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return p.All(ctx)
+}
+
 // EditMessage updates message content/embeds.
 func (m *MessageService) EditMessage(ctx context.Context, channelID, messageID string, params *types.MessageEditParams) (*types.Message, error) {
 	if err := validateID("channelID", channelID); err != nil {
@@ -67,6 +211,14 @@ func (m *MessageService) EditMessage(ctx context.Context, channelID, messageID s
 	if params == nil {
 		return nil, &types.ValidationError{Field: "params", Message: "message edit params required"}
 	}
+	if params.AllowedMentions == nil && m.client.defaultMentions != nil {
+		withDefault := *params
+		withDefault.AllowedMentions = m.client.defaultMentions
+		params = &withDefault
+	}
+	if err := params.AllowedMentions.Validate(); err != nil {
+		return nil, err
+	}
 
 	var msg types.Message
 	if err := m.client.Patch(ctx, fmt.Sprintf("/channels/%s/messages/%s", channelID, messageID), params, &msg); err != nil {
@@ -94,8 +246,8 @@ func (m *MessageService) BulkDeleteMessages(ctx context.Context, channelID strin
 	if len(messageIDs) == 0 {
 		return &types.ValidationError{Field: "messages", Message: "at least one message ID required"}
 	}
-	if len(messageIDs) > 100 {
-		return &types.ValidationError{Field: "messages", Message: "maximum 100 messages per bulk delete"}
+	if len(messageIDs) > limits.BulkDeleteMessages {
+		return &types.ValidationError{Field: "messages", Message: fmt.Sprintf("maximum %d messages per bulk delete", limits.BulkDeleteMessages)}
 	}
 
 	payload := struct {
@@ -220,8 +372,8 @@ func (p *GetReactionsParams) validate() error {
 	if p == nil {
 		return nil
 	}
-	if p.Limit < 0 || p.Limit > 100 {
-		return &types.ValidationError{Field: "limit", Message: "limit must be between 0 and 100"}
+	if p.Limit < 0 || p.Limit > limits.ReactionsPerPage {
+		return &types.ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 0 and %d", limits.ReactionsPerPage)}
 	}
 	return nil
 }
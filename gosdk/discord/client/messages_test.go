@@ -3,11 +3,16 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/utils"
+	"github.com/mtreilly/godiscord/gosdk/idempotency"
 )
 
 func TestMessageServiceCreate(t *testing.T) {
@@ -38,6 +43,50 @@ func TestMessageServiceCreate(t *testing.T) {
 	}
 }
 
+func TestMessageServiceDefaultAllowedMentions(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		bodies = append(bodies, string(payload["allowed_mentions"]))
+		json.NewEncoder(w).Encode(types.Message{ID: "42"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	WithDefaultAllowedMentions(types.NoMentions())(client)
+
+	ctx := context.Background()
+	params := &types.MessageCreateParams{Content: "@everyone"}
+	if _, err := client.Messages().CreateMessage(ctx, "123", params); err != nil {
+		t.Fatalf("CreateMessage error: %v", err)
+	}
+	if params.AllowedMentions != nil {
+		t.Fatal("default should not be written back to caller params")
+	}
+	own := &types.AllowedMentions{Users: []string{"1"}}
+	if _, err := client.Messages().CreateMessage(ctx, "123", &types.MessageCreateParams{Content: "<@1>", AllowedMentions: own}); err != nil {
+		t.Fatalf("CreateMessage error: %v", err)
+	}
+	if _, err := client.Messages().EditMessage(ctx, "123", "42", &types.MessageEditParams{Content: "edited"}); err != nil {
+		t.Fatalf("EditMessage error: %v", err)
+	}
+
+	want := []string{`{}`, `{"users":["1"]}`, `{}`}
+	for i := range want {
+		if bodies[i] != want[i] {
+			t.Fatalf("request %d allowed_mentions = %s, want %s", i, bodies[i], want[i])
+		}
+	}
+
+	bad := &types.AllowedMentions{Parse: []types.AllowedMentionType{types.AllowedMentionUsers}, Users: []string{"1"}}
+	if _, err := client.Messages().CreateMessage(ctx, "123", &types.MessageCreateParams{Content: "x", AllowedMentions: bad}); err == nil {
+		t.Fatal("expected validation error for conflicting allowed mentions")
+	}
+}
+
 func TestMessageServiceEdit(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPatch {
@@ -195,3 +244,252 @@ func TestMessageServiceGetReactions(t *testing.T) {
 		t.Fatalf("unexpected users %+v", users)
 	}
 }
+
+func TestMessageServiceLatestMessages(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		top := 1000
+		if before := r.URL.Query().Get("before"); before != "" {
+			top, _ = strconv.Atoi(before)
+			top--
+		}
+		page := make([]*types.Message, 0, 100)
+		for id := top; id > top-100; id-- {
+			page = append(page, &types.Message{ID: strconv.Itoa(id)})
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	msgs, err := client.Messages().LatestMessages(context.Background(), "123", 150)
+	if err != nil {
+		t.Fatalf("LatestMessages error: %v", err)
+	}
+	if len(msgs) != 150 || msgs[0].ID != "1000" || msgs[149].ID != "851" {
+		t.Fatalf("unexpected messages: %d, first %s", len(msgs), msgs[0].ID)
+	}
+	if len(requests) != 2 || requests[0] != "limit=100" || requests[1] != "before=901&limit=100" {
+		t.Fatalf("unexpected requests %v", requests)
+	}
+
+	if _, err := client.Messages().LatestMessages(context.Background(), "123", 0); err == nil {
+		t.Fatal("expected error for n=0")
+	}
+}
+
+func TestMessageServiceMessagesSince(t *testing.T) {
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("after"); got != utils.TimeToSnowflake(since) {
+			t.Fatalf("after = %s", got)
+		}
+		json.NewEncoder(w).Encode([]*types.Message{{ID: "1189000000000000002"}, {ID: "1189000000000000001"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	msgs, err := client.Messages().MessagesSince(context.Background(), "123", since)
+	if err != nil {
+		t.Fatalf("MessagesSince error: %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].ID != "1189000000000000001" {
+		t.Fatalf("expected oldest first, got %+v", msgs)
+	}
+	if _, err := client.Messages().MessagesSince(context.Background(), "123", time.Time{}); err == nil {
+		t.Fatal("expected error for zero time")
+	}
+}
+
+func TestMessageServiceCrosspost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/channels/123/messages/456/crosspost" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(types.Message{ID: "456", Flags: 1})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	msg, err := client.Messages().CrosspostMessage(context.Background(), "123", "456")
+	if err != nil {
+		t.Fatalf("CrosspostMessage error: %v", err)
+	}
+	if msg.ID != "456" {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+	if _, err := client.Messages().CrosspostMessage(context.Background(), "123", ""); err == nil {
+		t.Fatal("expected error for missing message ID")
+	}
+}
+
+func TestMessageServiceForward(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels/123/messages" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		ref, _ := body["message_reference"].(map[string]interface{})
+		if ref["type"] != float64(1) || ref["message_id"] != "456" || ref["channel_id"] != "789" {
+			t.Fatalf("unexpected message_reference %v", body["message_reference"])
+		}
+		if _, ok := body["content"]; ok {
+			t.Fatalf("forward should not send content: %v", body)
+		}
+		json.NewEncoder(w).Encode(types.Message{
+			ID:               "1",
+			MessageReference: &types.MessageReference{Type: types.MessageReferenceForward, MessageID: "456", ChannelID: "789"},
+			MessageSnapshots: []types.MessageSnapshot{{Message: &types.Message{Content: "news"}}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	msg, err := client.Messages().ForwardMessage(context.Background(), "123", "789", "456")
+	if err != nil {
+		t.Fatalf("ForwardMessage error: %v", err)
+	}
+	if len(msg.MessageSnapshots) != 1 || msg.MessageSnapshots[0].Message.Content != "news" {
+		t.Fatalf("unexpected snapshots %+v", msg.MessageSnapshots)
+	}
+
+	_, err = client.Messages().CreateMessage(context.Background(), "123", &types.MessageCreateParams{
+		Content:          "extra",
+		MessageReference: &types.MessageReference{Type: types.MessageReferenceForward, MessageID: "456", ChannelID: "789"},
+	})
+	if err == nil {
+		t.Fatal("expected error for a forward with content")
+	}
+}
+
+func TestMessageServiceReply(t *testing.T) {
+	var payloads []map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		payloads = append(payloads, payload)
+		json.NewEncoder(w).Encode(types.Message{ID: "42"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	ctx := context.Background()
+	if _, err := client.Messages().Reply(ctx, "123", "456", "thanks"); err != nil {
+		t.Fatalf("Reply error: %v", err)
+	}
+	if got := string(payloads[0]["message_reference"]); got != `{"message_id":"456"}` {
+		t.Fatalf("message_reference = %s", got)
+	}
+	if _, ok := payloads[0]["allowed_mentions"]; ok {
+		t.Fatal("plain reply should leave allowed_mentions to Discord")
+	}
+
+	params := (&types.MessageCreateParams{Content: "quiet"}).ReplyTo("456").FailIfNotExists(false).MentionRepliedUser(false)
+	if _, err := client.Messages().CreateMessage(ctx, "123", params); err != nil {
+		t.Fatalf("CreateMessage error: %v", err)
+	}
+	if got := string(payloads[1]["message_reference"]); got != `{"message_id":"456","fail_if_not_exists":false}` {
+		t.Fatalf("message_reference = %s", got)
+	}
+	if got := string(payloads[1]["allowed_mentions"]); got != `{"parse":["users","roles","everyone"]}` {
+		t.Fatalf("allowed_mentions = %s", got)
+	}
+
+	WithDefaultAllowedMentions(types.NoMentions())(client)
+	params = (&types.MessageCreateParams{Content: "ping"}).ReplyTo("456").MentionRepliedUser(true)
+	if _, err := client.Messages().CreateMessage(ctx, "123", params); err != nil {
+		t.Fatalf("CreateMessage error: %v", err)
+	}
+	if got := string(payloads[2]["allowed_mentions"]); got != `{"replied_user":true}` {
+		t.Fatalf("allowed_mentions = %s, want the default policy plus the reply ping", got)
+	}
+	if params.AllowedMentions != nil {
+		t.Fatal("reply ping should not be written back to caller params")
+	}
+
+	if _, err := client.Messages().Reply(ctx, "123", "", "x"); err == nil {
+		t.Fatal("expected error for missing message ID")
+	}
+}
+
+func TestMessageServiceCreateMessageIdempotent(t *testing.T) {
+	var requests int
+	var nonce string
+	var enforce bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var payload types.MessageCreateParams
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		nonce, enforce = payload.Nonce, payload.EnforceNonce
+		json.NewEncoder(w).Encode(types.Message{ID: "42", Timestamp: time.Now()})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	ctx := context.Background()
+	params := &types.MessageCreateParams{Content: "hello"}
+
+	first, err := client.Messages().CreateMessageIdempotent(ctx, "123", "order-1", params)
+	if err != nil {
+		t.Fatalf("CreateMessageIdempotent error: %v", err)
+	}
+	if first.WasDuplicate || first.Message.ID != "42" {
+		t.Fatalf("first result = %+v", first)
+	}
+	if nonce != idempotency.Nonce("order-1") || !enforce {
+		t.Fatalf("nonce = %q, enforce_nonce = %v", nonce, enforce)
+	}
+	if params.Nonce != "" {
+		t.Fatal("params should not be modified")
+	}
+
+	second, err := client.Messages().CreateMessageIdempotent(ctx, "123", "order-1", params)
+	if err != nil {
+		t.Fatalf("CreateMessageIdempotent error: %v", err)
+	}
+	if !second.WasDuplicate || second.Message.ID != "42" || requests != 1 {
+		t.Fatalf("second result = %+v after %d requests", second, requests)
+	}
+}
+
+func TestMessageServiceCreateMessageIdempotentPending(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	store := idempotency.NewMemoryStore(0)
+	store.Claim(context.Background(), "order-1") // an earlier attempt that never got a response
+	client := newTestClient(t, server.URL)
+	WithIdempotencyStore(store)(client)
+
+	_, err := client.Messages().CreateMessageIdempotent(context.Background(), "123", "order-1", &types.MessageCreateParams{Content: "hello"})
+	if !errors.Is(err, idempotency.ErrPending) {
+		t.Fatalf("expected ErrPending, got %v", err)
+	}
+}
+
+func TestMessageServiceCreateMessageIdempotentRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"Missing Permissions","code":50013}`))
+	}))
+	defer server.Close()
+
+	store := idempotency.NewMemoryStore(0)
+	client := newTestClient(t, server.URL)
+	WithIdempotencyStore(store)(client)
+
+	if _, err := client.Messages().CreateMessageIdempotent(context.Background(), "123", "order-1", &types.MessageCreateParams{Content: "hello"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if store.Len() != 0 {
+		t.Fatal("expected rejected send to release its key")
+	}
+}
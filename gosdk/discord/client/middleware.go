@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/logger"
+	"github.com/mtreilly/godiscord/gosdk/retry"
 )
 
 // Request wraps http.Request to allow middleware to override context/metadata.
@@ -36,12 +37,14 @@ type RequestHandler func(req *Request) (*http.Response, error)
 type Middleware func(next RequestHandler) RequestHandler
 
 // LoggingMiddleware emits debug-level logs for request/response pairs.
-func LoggingMiddleware(log *logger.Logger) Middleware {
+func LoggingMiddleware(l logger.Interface) Middleware {
+	log := logger.From(l)
 	if log == nil {
 		log = logger.Default()
 	}
 	return func(next RequestHandler) RequestHandler {
 		return func(req *Request) (*http.Response, error) {
+			log := log.WithContext(req.Context())
 			start := time.Now()
 			log.Debug("discord.client.middleware.request",
 				"method", req.Method,
@@ -84,7 +87,7 @@ func RetryMiddleware(maxRetries int, shouldRetry func(*http.Response, error) boo
 		return func(req *Request) (*http.Response, error) {
 			var lastErr error
 			var resp *http.Response
-			backoff := time.Second
+			backoff := retry.Backoff{Base: time.Second}
 
 			for attempt := 0; attempt <= maxRetries; attempt++ {
 				resp, lastErr = next(req)
@@ -92,14 +95,13 @@ func RetryMiddleware(maxRetries int, shouldRetry func(*http.Response, error) boo
 					return resp, lastErr
 				}
 
-				timer := time.NewTimer(backoff)
+				timer := time.NewTimer(backoff.Delay(attempt + 1))
 				select {
 				case <-req.Context().Done():
 					timer.Stop()
 					return resp, req.Context().Err()
 				case <-timer.C:
 				}
-				backoff *= 2
 			}
 
 			return resp, lastErr
@@ -124,7 +126,8 @@ func MetricsMiddleware(collect func(method, path string, status int, duration ti
 }
 
 // DryRunMiddleware short-circuits non-GET requests when enabled.
-func DryRunMiddleware(enabled bool, log *logger.Logger) Middleware {
+func DryRunMiddleware(enabled bool, l logger.Interface) Middleware {
+	log := logger.From(l)
 	return func(next RequestHandler) RequestHandler {
 		return func(req *Request) (*http.Response, error) {
 			if enabled && req.Method != http.MethodGet {
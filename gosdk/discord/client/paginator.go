@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+)
+
+// PageFunc fetches the page of items at cursor and returns the cursor of the
+// following page. An empty next cursor marks the last page. The first call
+// receives the paginator's starting cursor.
+type PageFunc[T any] func(ctx context.Context, cursor string) (items []T, next string, err error)
+
+// PaginatorOption configures a Paginator.
+type PaginatorOption func(*paginatorConfig)
+
+type paginatorConfig struct {
+	prefetch bool
+	maxItems int
+}
+
+// WithPrefetch fetches the next page in the background while the caller
+// works through the current one. Prefetches run at PriorityBackground unless
+// the context already carries a priority, so they yield to other traffic
+// and leave rate limit headroom in place.
+func WithPrefetch() PaginatorOption {
+	return func(cfg *paginatorConfig) {
+		cfg.prefetch = true
+	}
+}
+
+// WithMaxItems stops pagination after n items.
+func WithMaxItems(n int) PaginatorOption {
+	return func(cfg *paginatorConfig) {
+		if n > 0 {
+			cfg.maxItems = n
+		}
+	}
+}
+
+// Paginator walks a paginated endpoint page by page. It is not safe for
+// concurrent use.
+type Paginator[T any] struct {
+	fetch  PageFunc[T]
+	cfg    paginatorConfig
+	cursor string
+	done   bool
+	seen   int
+
+	pending *prefetchedPage[T]
+}
+
+type prefetchedPage[T any] struct {
+	cursor string
+	cancel context.CancelFunc
+	result chan pageResult[T]
+}
+
+type pageResult[T any] struct {
+	items []T
+	next  string
+	err   error
+}
+
+// NewPaginator pages through fetch starting at cursor.
+func NewPaginator[T any](fetch PageFunc[T], cursor string, opts ...PaginatorOption) *Paginator[T] {
+	p := &Paginator[T]{fetch: fetch, cursor: cursor}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p.cfg)
+		}
+	}
+	return p
+}
+
+// Done reports whether every page has been returned.
+func (p *Paginator[T]) Done() bool {
+	return p.done
+}
+
+// Next returns the next page. Once Done reports true it returns nil, nil.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	res, ok := p.takePrefetched(ctx)
+	if !ok {
+		items, next, err := p.fetch(ctx, p.cursor)
+		res = pageResult[T]{items: items, next: next, err: err}
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	items := res.items
+	if p.cfg.maxItems > 0 && p.seen+len(items) >= p.cfg.maxItems {
+		items = items[:p.cfg.maxItems-p.seen]
+		res.next = ""
+	}
+	p.seen += len(items)
+	p.cursor = res.next
+	if res.next == "" {
+		p.done = true
+	} else if p.cfg.prefetch {
+		p.startPrefetch(ctx)
+	}
+	return items, nil
+}
+
+// All collects every remaining item.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for !p.done {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, page...)
+	}
+	return all, nil
+}
+
+// ForEach calls fn for every remaining item. Return types.ErrStopIteration
+// from fn to stop early; any other error from fn is returned as is.
+func (p *Paginator[T]) ForEach(ctx context.Context, fn func(T) error) error {
+	defer p.Close()
+	for !p.done {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range page {
+			if err := fn(item); err != nil {
+				return stopIteration(err)
+			}
+		}
+	}
+	return nil
+}
+
+// Close cancels an in-flight prefetch. The paginator can still be used.
+func (p *Paginator[T]) Close() {
+	if p.pending != nil {
+		p.pending.cancel()
+		p.pending = nil
+	}
+}
+
+func (p *Paginator[T]) startPrefetch(ctx context.Context) {
+	if _, ok := RequestPriority(ctx); !ok {
+		ctx = WithRequestPriority(ctx, PriorityBackground)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	pending := &prefetchedPage[T]{cursor: p.cursor, cancel: cancel, result: make(chan pageResult[T], 1)}
+	p.pending = pending
+	go func() {
+		items, next, err := p.fetch(ctx, pending.cursor)
+		pending.result <- pageResult[T]{items: items, next: next, err: err}
+	}()
+}
+
+// takePrefetched waits for a prefetched page. A prefetch cancelled along with
+// an earlier caller's context is discarded so the page is fetched again.
+func (p *Paginator[T]) takePrefetched(ctx context.Context) (pageResult[T], bool) {
+	pending := p.pending
+	if pending == nil {
+		return pageResult[T]{}, false
+	}
+	p.pending = nil
+	select {
+	case res := <-pending.result:
+		pending.cancel()
+		if res.err != nil && (errors.Is(res.err, context.Canceled) || errors.Is(res.err, context.DeadlineExceeded)) && ctx.Err() == nil {
+			return pageResult[T]{}, false
+		}
+		return res, true
+	case <-ctx.Done():
+		pending.cancel()
+		return pageResult[T]{err: ctx.Err()}, true
+	}
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// countingPages serves the integers 1..total in pages of size, using the
+// last value of a page as the cursor.
+func countingPages(total, size int) PageFunc[int] {
+	return func(ctx context.Context, cursor string) ([]int, string, error) {
+		start, _ := strconv.Atoi(cursor)
+		var page []int
+		for n := start + 1; n <= total && len(page) < size; n++ {
+			page = append(page, n)
+		}
+		if len(page) < size {
+			return page, "", nil
+		}
+		return page, strconv.Itoa(page[len(page)-1]), nil
+	}
+}
+
+func TestPaginatorNextAndAll(t *testing.T) {
+	p := NewPaginator(countingPages(25, 10), "")
+	page, err := p.Next(context.Background())
+	if err != nil || len(page) != 10 || page[0] != 1 || p.Done() {
+		t.Fatalf("first page = %v, err=%v, done=%v", page, err, p.Done())
+	}
+	rest, err := p.All(context.Background())
+	if err != nil || len(rest) != 15 || rest[14] != 25 || !p.Done() {
+		t.Fatalf("rest = %v, err=%v, done=%v", rest, err, p.Done())
+	}
+	if page, err := p.Next(context.Background()); page != nil || err != nil {
+		t.Fatalf("expected nil page after done, got %v, %v", page, err)
+	}
+}
+
+func TestPaginatorMaxItems(t *testing.T) {
+	all, err := NewPaginator(countingPages(100, 10), "", WithMaxItems(15)).All(context.Background())
+	if err != nil || len(all) != 15 || all[14] != 15 {
+		t.Fatalf("All = %v, err=%v", all, err)
+	}
+}
+
+func TestPaginatorErrorKeepsPosition(t *testing.T) {
+	boom := errors.New("boom")
+	fail := true
+	pages := countingPages(20, 10)
+	p := NewPaginator(func(ctx context.Context, cursor string) ([]int, string, error) {
+		if cursor == "10" && fail {
+			fail = false
+			return nil, "", boom
+		}
+		return pages(ctx, cursor)
+	}, "")
+	if _, err := p.All(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	page, err := p.Next(context.Background())
+	if err != nil || len(page) != 10 || page[0] != 11 {
+		t.Fatalf("retry page = %v, err=%v", page, err)
+	}
+}
+
+func TestPaginatorPrefetchUsesBackgroundPriority(t *testing.T) {
+	var mu sync.Mutex
+	priorities := map[string]Priority{}
+	pages := countingPages(30, 10)
+	p := NewPaginator(func(ctx context.Context, cursor string) ([]int, string, error) {
+		prio, _ := RequestPriority(ctx)
+		mu.Lock()
+		priorities[cursor] = prio
+		mu.Unlock()
+		return pages(ctx, cursor)
+	}, "", WithPrefetch())
+
+	all, err := p.All(context.Background())
+	if err != nil || len(all) != 30 {
+		t.Fatalf("All = %d items, err=%v", len(all), err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if priorities[""] != PriorityNormal {
+		t.Fatalf("first page priority = %v", priorities[""])
+	}
+	if priorities["10"] != PriorityBackground || priorities["20"] != PriorityBackground {
+		t.Fatalf("prefetch priorities = %v", priorities)
+	}
+}
+
+func TestPaginatorRefetchesCancelledPrefetch(t *testing.T) {
+	pages := countingPages(20, 10)
+	var mu sync.Mutex
+	calls := map[string]int{}
+	p := NewPaginator(func(ctx context.Context, cursor string) ([]int, string, error) {
+		mu.Lock()
+		calls[cursor]++
+		first := calls[cursor] == 1
+		mu.Unlock()
+		if cursor == "10" && first {
+			// The prefetch only ends when the first caller's context does.
+			<-ctx.Done()
+			return nil, "", ctx.Err()
+		}
+		return pages(ctx, cursor)
+	}, "", WithPrefetch())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := p.Next(ctx); err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	cancel()
+
+	page, err := p.Next(context.Background())
+	if err != nil || len(page) != 10 || page[0] != 11 {
+		t.Fatalf("second page = %v, err=%v", page, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls["10"] != 2 {
+		t.Fatalf("expected the cancelled prefetch to be refetched, got %d calls", calls["10"])
+	}
+}
+
+func TestPaginateGuildAuditLog(t *testing.T) {
+	const total = 150
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var log types.AuditLog
+		for id := after + 1; id <= total && len(log.Entries) < limit; id++ {
+			// Discord lists entries newest first.
+			log.Entries = append([]types.AuditLogEntry{{ID: strconv.Itoa(id)}}, log.Entries...)
+		}
+		json.NewEncoder(w).Encode(log)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if _, err := client.AuditLogs().PaginateGuildAuditLog("1", &types.AuditLogParams{Limit: 500}); err == nil {
+		t.Fatal("expected validation error for limit")
+	}
+	p, err := client.AuditLogs().PaginateGuildAuditLog("1", nil)
+	if err != nil {
+		t.Fatalf("PaginateGuildAuditLog error: %v", err)
+	}
+	entries, err := p.All(context.Background())
+	if err != nil {
+		t.Fatalf("All error: %v", err)
+	}
+	if len(entries) != total || entries[0].ID != "1" || entries[total-1].ID != "150" {
+		t.Fatalf("got %d entries from %s to %s", len(entries), entries[0].ID, entries[len(entries)-1].ID)
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// GetPollAnswerVotersParams controls pagination for poll answer voters.
+type GetPollAnswerVotersParams struct {
+	After string
+	Limit int
+}
+
+func (p *GetPollAnswerVotersParams) validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Limit < 0 || p.Limit > limits.PollVotersPerPage {
+		return &types.ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 0 and %d", limits.PollVotersPerPage)}
+	}
+	return nil
+}
+
+// GetPollAnswerVoters lists users who voted for an answer, ordered by user ID.
+func (m *MessageService) GetPollAnswerVoters(ctx context.Context, channelID, messageID string, answerID int, params *GetPollAnswerVotersParams) ([]*types.User, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if err := validateID("messageID", messageID); err != nil {
+		return nil, err
+	}
+	if answerID < 1 {
+		return nil, &types.ValidationError{Field: "answerID", Message: "answer ID must be positive"}
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if params != nil {
+		if params.After != "" {
+			query.Set("after", params.After)
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	path := fmt.Sprintf("/channels/%s/polls/%s/answers/%d", channelID, messageID, answerID)
+	if q := query.Encode(); q != "" {
+		path += "?" + q
+	}
+
+	var out struct {
+		Users []*types.User `json:"users"`
+	}
+	if err := m.client.Get(ctx, path, &out); err != nil {
+		return nil, err
+	}
+	return out.Users, nil
+}
+
+// EndPoll closes a poll the bot created before its duration runs out and
+// returns the message with finalized results.
+func (m *MessageService) EndPoll(ctx context.Context, channelID, messageID string) (*types.Message, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if err := validateID("messageID", messageID); err != nil {
+		return nil, err
+	}
+	var msg types.Message
+	if err := m.client.Post(ctx, fmt.Sprintf("/channels/%s/polls/%s/expire", channelID, messageID), nil, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestGetPollAnswerVoters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels/1/polls/2/answers/3" || r.URL.Query().Get("after") != "10" || r.URL.Query().Get("limit") != "50" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		io.WriteString(w, `{"users":[{"id":"11"},{"id":"12"}]}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	users, err := client.Messages().GetPollAnswerVoters(context.Background(), "1", "2", 3, &GetPollAnswerVotersParams{After: "10", Limit: 50})
+	if err != nil || len(users) != 2 || users[1].ID != "12" {
+		t.Fatalf("GetPollAnswerVoters: %+v %v", users, err)
+	}
+	if _, err := client.Messages().GetPollAnswerVoters(context.Background(), "1", "2", 0, nil); err == nil {
+		t.Fatal("expected error for answer ID 0")
+	}
+}
+
+func TestEndPollAndCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/channels/1/polls/2/expire":
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method %s", r.Method)
+			}
+			io.WriteString(w, `{"id":"2","poll":{"question":{"text":"Q"},"answers":[],"results":{"is_finalized":true,"answer_counts":[]}}}`)
+		case "/channels/1/messages":
+			var body types.MessageCreateParams
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Poll == nil || len(body.Poll.Answers) != 2 {
+				t.Fatalf("poll missing from request: %+v", body)
+			}
+			io.WriteString(w, `{"id":"3"}`)
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	msg, err := client.Messages().EndPoll(context.Background(), "1", "2")
+	if err != nil || msg.Poll == nil || !msg.Poll.Results.IsFinalized {
+		t.Fatalf("EndPoll: %+v %v", msg, err)
+	}
+	if _, err := client.Messages().CreateMessage(context.Background(), "1", &types.MessageCreateParams{Poll: types.NewPoll("Lunch?", 24, "Pizza", "Tacos")}); err != nil {
+		t.Fatalf("CreateMessage with poll: %v", err)
+	}
+	if _, err := client.Messages().CreateMessage(context.Background(), "1", &types.MessageCreateParams{Poll: types.NewPoll("", 24, "A")}); err == nil {
+		t.Fatal("expected poll validation error")
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// GetRoleConnectionMetadata lists the application's linked role metadata records.
+func (a *Applications) GetRoleConnectionMetadata(ctx context.Context, applicationID string) ([]types.ApplicationRoleConnectionMetadata, error) {
+	if err := validateID("applicationID", applicationID); err != nil {
+		return nil, err
+	}
+	var records []types.ApplicationRoleConnectionMetadata
+	if err := a.client.Get(ctx, fmt.Sprintf("/applications/%s/role-connections/metadata", applicationID), &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// UpdateRoleConnectionMetadata replaces the application's linked role
+// metadata records.
+func (a *Applications) UpdateRoleConnectionMetadata(ctx context.Context, applicationID string, records []types.ApplicationRoleConnectionMetadata) ([]types.ApplicationRoleConnectionMetadata, error) {
+	if err := validateID("applicationID", applicationID); err != nil {
+		return nil, err
+	}
+	if err := types.ValidateRoleConnectionMetadata(records); err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = []types.ApplicationRoleConnectionMetadata{}
+	}
+	var out []types.ApplicationRoleConnectionMetadata
+	if err := a.client.do(ctx, http.MethodPut, fmt.Sprintf("/applications/%s/role-connections/metadata", applicationID), records, &out, nil); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetUserRoleConnection returns the role connection of the user who granted
+// accessToken, an OAuth2 bearer token with the role_connections.write scope.
+func (a *Applications) GetUserRoleConnection(ctx context.Context, accessToken, applicationID string) (*types.ApplicationRoleConnection, error) {
+	if err := validateID("applicationID", applicationID); err != nil {
+		return nil, err
+	}
+	headers, err := bearerHeaders(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	var conn types.ApplicationRoleConnection
+	if err := a.client.do(ctx, http.MethodGet, fmt.Sprintf("/users/@me/applications/%s/role-connection", applicationID), nil, &conn, headers); err != nil {
+		return nil, err
+	}
+	return &conn, nil
+}
+
+// UpdateUserRoleConnection sets the platform details and metadata values of
+// the user who granted accessToken. Discord re-evaluates their linked roles
+// with the new values.
+func (a *Applications) UpdateUserRoleConnection(ctx context.Context, accessToken, applicationID string, conn *types.ApplicationRoleConnection) (*types.ApplicationRoleConnection, error) {
+	if err := validateID("applicationID", applicationID); err != nil {
+		return nil, err
+	}
+	if err := conn.Validate(); err != nil {
+		return nil, err
+	}
+	headers, err := bearerHeaders(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	var out types.ApplicationRoleConnection
+	if err := a.client.do(ctx, http.MethodPut, fmt.Sprintf("/users/@me/applications/%s/role-connection", applicationID), conn, &out, headers); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// bearerHeaders authenticates a request as an OAuth2 user instead of the bot.
+func bearerHeaders(accessToken string) (http.Header, error) {
+	accessToken = strings.TrimSpace(accessToken)
+	if accessToken == "" {
+		return nil, &types.ValidationError{Field: "accessToken", Message: "OAuth2 access token is required"}
+	}
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+accessToken)
+	return headers, nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestUpdateRoleConnectionMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/applications/1/role-connections/metadata" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bot token" {
+			t.Fatalf("metadata should use the bot token, got %q", r.Header.Get("Authorization"))
+		}
+		io.Copy(w, r.Body)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	records := []types.ApplicationRoleConnectionMetadata{{
+		Type:        types.RoleConnectionIntegerGreaterThanOrEqual,
+		Key:         "matches_won",
+		Name:        "Matches won",
+		Description: "Ranked matches won",
+	}}
+	out, err := client.Applications().UpdateRoleConnectionMetadata(context.Background(), "1", records)
+	if err != nil || len(out) != 1 || out[0].Key != "matches_won" {
+		t.Fatalf("UpdateRoleConnectionMetadata: %+v %v", out, err)
+	}
+
+	records = append(records, records[0])
+	if _, err := client.Applications().UpdateRoleConnectionMetadata(context.Background(), "1", records); err == nil {
+		t.Fatal("expected duplicate key error")
+	}
+}
+
+func TestUpdateUserRoleConnectionUsesBearer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/@me/applications/1/role-connection" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Values("Authorization"); len(got) != 1 || got[0] != "Bearer user-token" {
+			t.Fatalf("expected only the bearer token, got %v", got)
+		}
+		var conn types.ApplicationRoleConnection
+		json.NewDecoder(r.Body).Decode(&conn)
+		if conn.Metadata["matches_won"] != "12" || conn.Metadata["verified"] != "1" || conn.Metadata["joined"] != "2024-05-01T00:00:00Z" {
+			t.Fatalf("unexpected metadata %v", conn.Metadata)
+		}
+		json.NewEncoder(w).Encode(conn)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	conn := (&types.ApplicationRoleConnection{PlatformName: "Arena"}).
+		SetInt("matches_won", 12).
+		SetBool("verified", true).
+		SetTime("joined", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	out, err := client.Applications().UpdateUserRoleConnection(context.Background(), "user-token", "1", conn)
+	if err != nil || out.PlatformName != "Arena" {
+		t.Fatalf("UpdateUserRoleConnection: %+v %v", out, err)
+	}
+
+	if _, err := client.Applications().GetUserRoleConnection(context.Background(), "", "1"); err == nil {
+		t.Fatal("expected error without an access token")
+	}
+}
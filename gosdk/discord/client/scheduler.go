@@ -0,0 +1,342 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+)
+
+// Priority classifies a request for the Scheduler. Higher priorities are
+// dispatched first when requests compete for the same bucket or for global
+// in-flight slots.
+type Priority int
+
+const (
+	// PriorityBackground is for bulk work (member scans, purges, exports)
+	// that should yield rate limit headroom to everything else.
+	PriorityBackground Priority = -1
+	// PriorityNormal is the default for REST calls.
+	PriorityNormal Priority = 0
+	// PriorityInteractive is for interaction callbacks and follow-ups, which
+	// must land within Discord's response windows.
+	PriorityInteractive Priority = 1
+)
+
+// String returns a readable priority name.
+func (p Priority) String() string {
+	switch {
+	case p <= PriorityBackground:
+		return "background"
+	case p >= PriorityInteractive:
+		return "interactive"
+	default:
+		return "normal"
+	}
+}
+
+type priorityContextKey struct{}
+
+// WithRequestPriority tags every request made with ctx with a priority class.
+// Requests without a tag default to PriorityInteractive for interaction and
+// webhook routes and PriorityNormal otherwise.
+func WithRequestPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityContextKey{}, p)
+}
+
+// RequestPriority reports the priority attached to ctx, if any.
+func RequestPriority(ctx context.Context) (Priority, bool) {
+	p, ok := ctx.Value(priorityContextKey{}).(Priority)
+	return p, ok
+}
+
+// requestPriority resolves the priority for a request on route.
+func requestPriority(ctx context.Context, route string) Priority {
+	if p, ok := RequestPriority(ctx); ok {
+		return p
+	}
+	if strings.Contains(route, ":/interactions/") || strings.Contains(route, ":/webhooks/") {
+		return PriorityInteractive
+	}
+	return PriorityNormal
+}
+
+// SchedulerOption configures a Scheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithMaxInFlight caps concurrent requests across all buckets (0 = unlimited).
+func WithMaxInFlight(n int) SchedulerOption {
+	return func(s *Scheduler) {
+		if n >= 0 {
+			s.maxInFlight = n
+		}
+	}
+}
+
+// WithInteractiveReserve keeps n of the in-flight slots free for interactive
+// requests. It only applies together with WithMaxInFlight.
+func WithInteractiveReserve(n int) SchedulerOption {
+	return func(s *Scheduler) {
+		if n >= 0 {
+			s.reserve = n
+		}
+	}
+}
+
+// WithBackgroundHeadroom holds background requests back while their bucket
+// has n or fewer requests remaining, leaving that capacity to higher
+// priorities until the bucket resets.
+func WithBackgroundHeadroom(n int) SchedulerOption {
+	return func(s *Scheduler) {
+		if n >= 0 {
+			s.headroom = n
+		}
+	}
+}
+
+// SchedulerStats is a point-in-time view of the scheduler queues.
+type SchedulerStats struct {
+	InFlight int
+	Queued   map[Priority]int
+}
+
+// Scheduler serializes requests per rate limit route and orders queued
+// requests by priority, so bulk jobs cannot starve interaction follow-ups.
+// Attach one with WithScheduler; a single Scheduler may be shared by several
+// clients using the same token.
+type Scheduler struct {
+	maxInFlight int
+	reserve     int
+	headroom    int
+
+	mu       sync.Mutex
+	tracker  ratelimit.Tracker
+	lanes    map[string]*schedulerLane
+	inFlight int
+	seq      uint64
+}
+
+// schedulerLane holds the requests queued for a single route, highest
+// priority first.
+type schedulerLane struct {
+	active  bool
+	waiters []*schedulerWaiter
+}
+
+type schedulerWaiter struct {
+	route    string
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+	granted  bool
+}
+
+// NewScheduler creates a request scheduler.
+func NewScheduler(opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{lanes: make(map[string]*schedulerLane)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
+}
+
+// bind supplies the tracker used for background headroom checks.
+func (s *Scheduler) bind(tracker ratelimit.Tracker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tracker == nil {
+		s.tracker = tracker
+	}
+}
+
+// Acquire blocks until a request on route may be sent. The returned release
+// function must be called once the response has been processed.
+func (s *Scheduler) Acquire(ctx context.Context, route string, p Priority) (func(), error) {
+	for {
+		if err := s.waitForHeadroom(ctx, route, p); err != nil {
+			return nil, err
+		}
+		release, err := s.acquire(ctx, route, p)
+		if err != nil {
+			return nil, err
+		}
+		// The bucket may have drained while the request was queued.
+		if s.headroomReset(route, p).IsZero() {
+			return release, nil
+		}
+		release()
+	}
+}
+
+func (s *Scheduler) acquire(ctx context.Context, route string, p Priority) (func(), error) {
+	s.mu.Lock()
+	s.seq++
+	w := &schedulerWaiter{route: route, priority: p, seq: s.seq, ready: make(chan struct{})}
+	lane := s.lanes[route]
+	if lane == nil {
+		lane = &schedulerLane{}
+		s.lanes[route] = lane
+	}
+	lane.enqueue(w)
+	s.dispatch()
+	s.mu.Unlock()
+
+	release := func() { s.release(route) }
+	select {
+	case <-w.ready:
+		return release, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if w.granted {
+			s.mu.Unlock()
+			release()
+		} else {
+			lane.remove(w)
+			s.prune(route)
+			s.mu.Unlock()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Scheduler) release(route string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lane := s.lanes[route]; lane != nil && lane.active {
+		lane.active = false
+		s.inFlight--
+	}
+	s.prune(route)
+	s.dispatch()
+}
+
+// dispatch grants idle lanes to their head waiters in priority order while
+// in-flight capacity remains. Callers must hold s.mu.
+func (s *Scheduler) dispatch() {
+	var heads []*schedulerWaiter
+	for _, lane := range s.lanes {
+		if !lane.active && len(lane.waiters) > 0 {
+			heads = append(heads, lane.waiters[0])
+		}
+	}
+	sortWaiters(heads)
+
+	for _, w := range heads {
+		if !s.hasCapacity(w.priority) {
+			// Lower priorities face the same or a stricter limit.
+			return
+		}
+		lane := s.lanes[w.route]
+		lane.waiters = lane.waiters[1:]
+		lane.active = true
+		s.inFlight++
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+func (s *Scheduler) hasCapacity(p Priority) bool {
+	if s.maxInFlight == 0 {
+		return true
+	}
+	limit := s.maxInFlight
+	if p < PriorityInteractive {
+		limit -= s.reserve
+	}
+	return s.inFlight < limit
+}
+
+// prune drops an idle, empty lane. Callers must hold s.mu.
+func (s *Scheduler) prune(route string) {
+	if lane := s.lanes[route]; lane != nil && !lane.active && len(lane.waiters) == 0 {
+		delete(s.lanes, route)
+	}
+}
+
+func (s *Scheduler) waitForHeadroom(ctx context.Context, route string, p Priority) error {
+	reset := s.headroomReset(route, p)
+	if reset.IsZero() {
+		return nil
+	}
+	timer := time.NewTimer(time.Until(reset))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// headroomReset returns when a background request on route may proceed, or
+// the zero time if it may proceed now.
+func (s *Scheduler) headroomReset(route string, p Priority) time.Time {
+	if p > PriorityBackground || s.headroom == 0 {
+		return time.Time{}
+	}
+	s.mu.Lock()
+	tracker := s.tracker
+	s.mu.Unlock()
+	if tracker == nil {
+		return time.Time{}
+	}
+	bucket := tracker.GetBucket(route)
+	if bucket == nil || bucket.Remaining > s.headroom || !time.Now().Before(bucket.Reset) {
+		return time.Time{}
+	}
+	return bucket.Reset
+}
+
+// Stats returns the number of in-flight and queued requests.
+func (s *Scheduler) Stats() SchedulerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := SchedulerStats{InFlight: s.inFlight, Queued: make(map[Priority]int)}
+	for _, lane := range s.lanes {
+		for _, w := range lane.waiters {
+			stats.Queued[w.priority]++
+		}
+	}
+	return stats
+}
+
+// enqueue inserts w after every waiter with an equal or higher priority.
+func (l *schedulerLane) enqueue(w *schedulerWaiter) {
+	pos := len(l.waiters)
+	for i, existing := range l.waiters {
+		if existing.priority < w.priority {
+			pos = i
+			break
+		}
+	}
+	l.waiters = append(l.waiters[:pos:pos], append([]*schedulerWaiter{w}, l.waiters[pos:]...)...)
+}
+
+func (l *schedulerLane) remove(w *schedulerWaiter) {
+	for i, existing := range l.waiters {
+		if existing == w {
+			l.waiters = append(l.waiters[:i:i], l.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+func sortWaiters(waiters []*schedulerWaiter) {
+	// Insertion sort: the number of idle lanes with queued work is small.
+	for i := 1; i < len(waiters); i++ {
+		for j := i; j > 0 && waiterBefore(waiters[j], waiters[j-1]); j-- {
+			waiters[j], waiters[j-1] = waiters[j-1], waiters[j]
+		}
+	}
+}
+
+func waiterBefore(a, b *schedulerWaiter) bool {
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.seq < b.seq
+}
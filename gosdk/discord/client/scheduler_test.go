@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+)
+
+func TestSchedulerOrdersByPriority(t *testing.T) {
+	s := NewScheduler()
+	ctx := context.Background()
+
+	// Hold the lane so every later request queues behind it.
+	release, err := s.Acquire(ctx, "GET:/guilds/1/members", PriorityNormal)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	for _, p := range []Priority{PriorityBackground, PriorityNormal, PriorityInteractive} {
+		wg.Add(1)
+		go func(p Priority) {
+			defer wg.Done()
+			rel, err := s.Acquire(ctx, "GET:/guilds/1/members", p)
+			if err != nil {
+				t.Errorf("Acquire(%s) error = %v", p, err)
+				return
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+			rel()
+		}(p)
+		waitForQueued(t, s, p)
+	}
+
+	release()
+	wg.Wait()
+
+	want := []Priority{PriorityInteractive, PriorityNormal, PriorityBackground}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("dispatch order = %v, want %v", order, want)
+		}
+	}
+	if stats := s.Stats(); stats.InFlight != 0 || len(s.lanes) != 0 {
+		t.Fatalf("expected idle scheduler, got %+v (%d lanes)", stats, len(s.lanes))
+	}
+}
+
+func TestSchedulerInteractiveReserve(t *testing.T) {
+	s := NewScheduler(WithMaxInFlight(2), WithInteractiveReserve(1))
+	ctx := context.Background()
+
+	release, err := s.Acquire(ctx, "GET:/guilds/1/members", PriorityBackground)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer release()
+
+	// A second background request on another route must leave the reserved slot free.
+	blocked, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(blocked, "GET:/guilds/2/members", PriorityBackground); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected background request to wait, got %v", err)
+	}
+
+	rel, err := s.Acquire(ctx, "POST:/interactions/:id/:token/callback", PriorityInteractive)
+	if err != nil {
+		t.Fatalf("interactive Acquire() error = %v", err)
+	}
+	rel()
+	if stats := s.Stats(); stats.Queued[PriorityBackground] != 0 {
+		t.Fatalf("cancelled waiter still queued: %+v", stats)
+	}
+}
+
+func TestSchedulerBackgroundHeadroom(t *testing.T) {
+	tracker := ratelimit.NewMemoryTracker()
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Limit", "5")
+	headers.Set("X-RateLimit-Remaining", "1")
+	headers.Set("X-RateLimit-Reset-After", "0.05")
+	tracker.Update("GET:/guilds/1/members", headers)
+
+	s := NewScheduler(WithBackgroundHeadroom(1))
+	s.bind(tracker)
+
+	// Higher priorities ignore the headroom.
+	rel, err := s.Acquire(context.Background(), "GET:/guilds/1/members", PriorityNormal)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	rel()
+
+	start := time.Now()
+	rel, err = s.Acquire(context.Background(), "GET:/guilds/1/members", PriorityBackground)
+	if err != nil {
+		t.Fatalf("background Acquire() error = %v", err)
+	}
+	rel()
+	if waited := time.Since(start); waited < 30*time.Millisecond {
+		t.Fatalf("background request waited %v, expected it to hold for the reset", waited)
+	}
+}
+
+func TestClientSchedulerSerializesRoute(t *testing.T) {
+	var mu sync.Mutex
+	active, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		if active > peak {
+			peak = active
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	scheduler := NewScheduler()
+	client, err := New("test-token",
+		WithBaseURL(server.URL),
+		WithRateLimiter(&noopTracker{}),
+		WithStrategy(ratelimit.NewReactiveStrategy()),
+		WithScheduler(scheduler),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := WithRequestPriority(context.Background(), PriorityBackground)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := client.Delete(ctx, "/channels/1/messages/"+strconv.Itoa(100+i)); err != nil {
+				t.Errorf("Delete() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if peak != 1 {
+		t.Fatalf("expected requests on one route to be serialized, peak concurrency %d", peak)
+	}
+}
+
+func TestRequestPriorityDefaults(t *testing.T) {
+	ctx := context.Background()
+	if p := requestPriority(ctx, "POST:/interactions/:id/:token/callback"); p != PriorityInteractive {
+		t.Fatalf("interaction callback priority = %s", p)
+	}
+	if p := requestPriority(ctx, "POST:/webhooks/1/abc"); p != PriorityInteractive {
+		t.Fatalf("follow-up priority = %s", p)
+	}
+	if p := requestPriority(ctx, "GET:/guilds/1/members"); p != PriorityNormal {
+		t.Fatalf("default priority = %s", p)
+	}
+	tagged := WithRequestPriority(ctx, PriorityBackground)
+	if p := requestPriority(tagged, "POST:/webhooks/1/abc"); p != PriorityBackground {
+		t.Fatalf("tagged priority = %s", p)
+	}
+}
+
+func waitForQueued(t *testing.T, s *Scheduler, p Priority) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if s.Stats().Queued[p] > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("request with priority %s never queued", p)
+}
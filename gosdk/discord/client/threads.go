@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// StartThreadInForum creates a thread with a starter message in a forum or
+// media channel. The returned channel carries the starter message in
+// Message.
+func (c *Channels) StartThreadInForum(ctx context.Context, channelID string, params *types.ForumThreadParams) (*types.Channel, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		return nil, &types.ValidationError{Field: "params", Message: "forum thread params required"}
+	}
+	if params.Message != nil && params.Message.AllowedMentions == nil && c.client.defaultMentions != nil {
+		withDefault := *params
+		message := *params.Message
+		message.AllowedMentions = c.client.defaultMentions
+		withDefault.Message = &message
+		params = &withDefault
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var thread types.Channel
+	if err := c.client.do(ctx, http.MethodPost, fmt.Sprintf("/channels/%s/threads", channelID), params, &thread, auditHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+// ListThreadMembersParams controls ListThreadMembers. Pagination with After
+// and Limit requires WithMember, as it does in Discord's API.
+type ListThreadMembersParams struct {
+	WithMember bool
+	After      string
+	Limit      int
+}
+
+func (p *ListThreadMembersParams) validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Limit < 0 || p.Limit > limits.ThreadMembersPerPage {
+		return &types.ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 0 and %d", limits.ThreadMembersPerPage)}
+	}
+	if !p.WithMember && (p.After != "" || p.Limit > 0) {
+		return &types.ValidationError{Field: "with_member", Message: "after and limit require with_member"}
+	}
+	return nil
+}
+
+// ListThreadMembers lists the members of a thread. It requires the
+// GUILD_MEMBERS privileged intent.
+func (c *Channels) ListThreadMembers(ctx context.Context, threadID string, params *ListThreadMembersParams) ([]*types.ThreadMember, error) {
+	if err := validateID("threadID", threadID); err != nil {
+		return nil, err
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if params != nil {
+		if params.WithMember {
+			query.Set("with_member", "true")
+		}
+		if params.After != "" {
+			query.Set("after", params.After)
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	path := fmt.Sprintf("/channels/%s/thread-members", threadID)
+	if q := query.Encode(); q != "" {
+		path += "?" + q
+	}
+
+	var members []*types.ThreadMember
+	if err := c.client.Get(ctx, path, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// GetThreadMember returns a user's membership in a thread. With withMember
+// set, the guild member is included in Member.
+func (c *Channels) GetThreadMember(ctx context.Context, threadID, userID string, withMember bool) (*types.ThreadMember, error) {
+	if err := validateID("threadID", threadID); err != nil {
+		return nil, err
+	}
+	if err := validateID("userID", userID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/channels/%s/thread-members/%s", threadID, userID)
+	if withMember {
+		path += "?with_member=true"
+	}
+
+	var member types.ThreadMember
+	if err := c.client.Get(ctx, path, &member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// AddThreadMember adds a user to a thread. The thread must not be archived.
+func (c *Channels) AddThreadMember(ctx context.Context, threadID, userID string) error {
+	if err := validateID("threadID", threadID); err != nil {
+		return err
+	}
+	if err := validateID("userID", userID); err != nil {
+		return err
+	}
+	return c.client.Put(ctx, fmt.Sprintf("/channels/%s/thread-members/%s", threadID, userID), nil, nil)
+}
+
+// RemoveThreadMember removes a user from a thread.
+func (c *Channels) RemoveThreadMember(ctx context.Context, threadID, userID string) error {
+	if err := validateID("threadID", threadID); err != nil {
+		return err
+	}
+	if err := validateID("userID", userID); err != nil {
+		return err
+	}
+	return c.client.Delete(ctx, fmt.Sprintf("/channels/%s/thread-members/%s", threadID, userID))
+}
+
+// JoinThread adds the current user to a thread.
+func (c *Channels) JoinThread(ctx context.Context, threadID string) error {
+	if err := validateID("threadID", threadID); err != nil {
+		return err
+	}
+	return c.client.Put(ctx, fmt.Sprintf("/channels/%s/thread-members/@me", threadID), nil, nil)
+}
+
+// LeaveThread removes the current user from a thread.
+func (c *Channels) LeaveThread(ctx context.Context, threadID string) error {
+	if err := validateID("threadID", threadID); err != nil {
+		return err
+	}
+	return c.client.Delete(ctx, fmt.Sprintf("/channels/%s/thread-members/@me", threadID))
+}
+
+// ListActiveThreads lists a guild's active threads the bot can see, with the
+// bot's membership in each thread it has joined.
+func (g *Guilds) ListActiveThreads(ctx context.Context, guildID string) (*types.ThreadList, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	var list types.ThreadList
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/threads/active", guildID), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ArchivedThreadsParams pages through public or private archived threads,
+// most recently archived first.
+type ArchivedThreadsParams struct {
+	// Before returns threads archived before this time. Zero starts with the
+	// most recently archived thread.
+	Before time.Time
+	Limit  int
+}
+
+// JoinedArchivedThreadsParams pages through the private archived threads the
+// current user has joined, in descending thread ID order.
+type JoinedArchivedThreadsParams struct {
+	// Before returns threads with IDs lower than this thread ID.
+	Before string
+	Limit  int
+}
+
+// ListPublicArchivedThreads lists a channel's archived public threads. It
+// requires READ_MESSAGE_HISTORY.
+func (c *Channels) ListPublicArchivedThreads(ctx context.Context, channelID string, params *ArchivedThreadsParams) (*types.ThreadList, error) {
+	return c.listArchivedThreads(ctx, channelID, "public", params)
+}
+
+// ListPrivateArchivedThreads lists a channel's archived private threads. It
+// requires READ_MESSAGE_HISTORY and MANAGE_THREADS.
+func (c *Channels) ListPrivateArchivedThreads(ctx context.Context, channelID string, params *ArchivedThreadsParams) (*types.ThreadList, error) {
+	return c.listArchivedThreads(ctx, channelID, "private", params)
+}
+
+func (c *Channels) listArchivedThreads(ctx context.Context, channelID, visibility string, params *ArchivedThreadsParams) (*types.ThreadList, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if params != nil {
+		if params.Limit < 0 {
+			return nil, &types.ValidationError{Field: "limit", Message: "limit cannot be negative"}
+		}
+		if !params.Before.IsZero() {
+			query.Set("before", params.Before.UTC().Format(time.RFC3339Nano))
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	return c.getThreadList(ctx, fmt.Sprintf("/channels/%s/threads/archived/%s", channelID, visibility), query)
+}
+
+// ListJoinedPrivateArchivedThreads lists the archived private threads in a
+// channel that the current user has joined. It requires READ_MESSAGE_HISTORY.
+func (c *Channels) ListJoinedPrivateArchivedThreads(ctx context.Context, channelID string, params *JoinedArchivedThreadsParams) (*types.ThreadList, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if params != nil {
+		if params.Limit < 0 {
+			return nil, &types.ValidationError{Field: "limit", Message: "limit cannot be negative"}
+		}
+		if params.Before != "" {
+			if err := validateID("before", params.Before); err != nil {
+				return nil, err
+			}
+			query.Set("before", params.Before)
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	return c.getThreadList(ctx, fmt.Sprintf("/channels/%s/users/@me/threads/archived/private", channelID), query)
+}
+
+func (c *Channels) getThreadList(ctx context.Context, path string, query url.Values) (*types.ThreadList, error) {
+	if q := query.Encode(); q != "" {
+		path += "?" + q
+	}
+	var list types.ThreadList
+	if err := c.client.Get(ctx, path, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
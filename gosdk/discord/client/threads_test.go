@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestStartThreadInForum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/channels/1/threads" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Audit-Log-Reason") != "release" {
+			t.Fatalf("missing audit reason")
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		tags, _ := body["applied_tags"].([]any)
+		msg, _ := body["message"].(map[string]any)
+		if body["auto_archive_duration"] != float64(types.ThreadArchiveDay) || body["rate_limit_per_user"] != float64(30) || len(tags) != 2 || msg["content"] != "v2 is out" {
+			t.Fatalf("unexpected body %v", body)
+		}
+		io.WriteString(w, `{"id":"9","type":11,"parent_id":"1","applied_tags":["a","b"],"message":{"id":"9","content":"v2 is out"}}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	thread, err := client.Channels().StartThreadInForum(context.Background(), "1", &types.ForumThreadParams{
+		Name:                "Release v2",
+		AutoArchiveDuration: types.ThreadArchiveDay,
+		RateLimitPerUser:    30,
+		AppliedTags:         []string{"a", "b"},
+		Message:             &types.MessageCreateParams{Content: "v2 is out"},
+		AuditLogReason:      "release",
+	})
+	if err != nil {
+		t.Fatalf("StartThreadInForum error: %v", err)
+	}
+	if thread.ID != "9" || len(thread.AppliedTags) != 2 || thread.Message == nil || thread.Message.Content != "v2 is out" {
+		t.Fatalf("unexpected thread %+v", thread)
+	}
+}
+
+func TestThreadMemberEndpoints(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/channels/5/thread-members":
+			io.WriteString(w, `[{"id":"5","user_id":"7","join_timestamp":"2024-01-01T00:00:00Z","flags":0,"member":{"user":{"id":"7"}}}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/channels/5/thread-members/7":
+			io.WriteString(w, `{"id":"5","user_id":"7","join_timestamp":"2024-01-01T00:00:00Z","flags":0}`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	ctx := context.Background()
+	channels := client.Channels()
+
+	members, err := channels.ListThreadMembers(ctx, "5", &ListThreadMembersParams{WithMember: true, After: "3", Limit: 50})
+	if err != nil {
+		t.Fatalf("ListThreadMembers error: %v", err)
+	}
+	if len(members) != 1 || members[0].UserID != "7" || members[0].Member == nil {
+		t.Fatalf("unexpected members %+v", members)
+	}
+	member, err := channels.GetThreadMember(ctx, "5", "7", false)
+	if err != nil || member.UserID != "7" {
+		t.Fatalf("GetThreadMember = %+v, %v", member, err)
+	}
+	if err := channels.AddThreadMember(ctx, "5", "8"); err != nil {
+		t.Fatalf("AddThreadMember error: %v", err)
+	}
+	if err := channels.RemoveThreadMember(ctx, "5", "8"); err != nil {
+		t.Fatalf("RemoveThreadMember error: %v", err)
+	}
+	if err := channels.JoinThread(ctx, "5"); err != nil {
+		t.Fatalf("JoinThread error: %v", err)
+	}
+	if err := channels.LeaveThread(ctx, "5"); err != nil {
+		t.Fatalf("LeaveThread error: %v", err)
+	}
+
+	want := []string{
+		"GET /channels/5/thread-members?after=3&limit=50&with_member=true",
+		"GET /channels/5/thread-members/7?",
+		"PUT /channels/5/thread-members/8?",
+		"DELETE /channels/5/thread-members/8?",
+		"PUT /channels/5/thread-members/@me?",
+		"DELETE /channels/5/thread-members/@me?",
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v", calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("call %d = %q, want %q", i, calls[i], want[i])
+		}
+	}
+}
+
+func TestListThreadMembersValidation(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1:0")
+	ctx := context.Background()
+	if _, err := client.Channels().ListThreadMembers(ctx, "5", &ListThreadMembersParams{Limit: 10}); err == nil {
+		t.Fatalf("expected error for limit without with_member")
+	}
+	if _, err := client.Channels().ListThreadMembers(ctx, "5", &ListThreadMembersParams{WithMember: true, Limit: 101}); err == nil {
+		t.Fatalf("expected error for limit over 100")
+	}
+	if err := client.Channels().AddThreadMember(ctx, "5", ""); err == nil {
+		t.Fatalf("expected error for missing user ID")
+	}
+}
+
+func TestListActiveThreads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/1/threads/active" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		io.WriteString(w, `{"threads":[{"id":"5","type":11},{"id":"6","type":11}],"members":[{"id":"5","user_id":"9","join_timestamp":"2024-01-01T00:00:00Z","flags":0}]}`)
+	}))
+	defer server.Close()
+
+	list, err := newTestClient(t, server.URL).Guilds().ListActiveThreads(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("ListActiveThreads error: %v", err)
+	}
+	if len(list.Threads) != 2 || len(list.Members) != 1 || list.HasMore {
+		t.Fatalf("unexpected list %+v", list)
+	}
+}
+
+func TestPaginateArchivedThreads(t *testing.T) {
+	var befores []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels/1/threads/archived/private" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		before := r.URL.Query().Get("before")
+		befores = append(befores, before)
+		if before == "" {
+			io.WriteString(w, `{"threads":[{"id":"9","thread_metadata":{"archived":true,"auto_archive_duration":60,"archive_timestamp":"2024-03-02T00:00:00Z","locked":false}},{"id":"8","thread_metadata":{"archived":true,"auto_archive_duration":60,"archive_timestamp":"2024-03-01T12:00:00Z","locked":false}}],"members":[],"has_more":true}`)
+			return
+		}
+		// The second page overlaps the first at its last archive timestamp
+		// and holds another thread archived at the same instant.
+		io.WriteString(w, `{"threads":[{"id":"8","thread_metadata":{"archived":true,"auto_archive_duration":60,"archive_timestamp":"2024-03-01T12:00:00Z","locked":false}},{"id":"7","thread_metadata":{"archived":true,"auto_archive_duration":60,"archive_timestamp":"2024-03-01T12:00:00Z","locked":false}},{"id":"4","thread_metadata":{"archived":true,"auto_archive_duration":60,"archive_timestamp":"2024-02-01T00:00:00Z","locked":false}}],"members":[],"has_more":false}`)
+	}))
+	defer server.Close()
+
+	var ids []string
+	err := newTestClient(t, server.URL).Channels().PaginateArchivedThreads("1", true).ForEach(context.Background(), func(thread types.Channel) error {
+		ids = append(ids, thread.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEach error: %v", err)
+	}
+	if strings.Join(ids, ",") != "9,8,7,4" {
+		t.Fatalf("ids = %v", ids)
+	}
+	if len(befores) != 2 || befores[1] != "2024-03-01T12:00:00.001Z" {
+		t.Fatalf("before cursors = %v", befores)
+	}
+}
+
+func TestPaginateJoinedArchivedThreads(t *testing.T) {
+	var befores []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels/1/users/@me/threads/archived/private" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		before := r.URL.Query().Get("before")
+		befores = append(befores, before)
+		if before == "" {
+			io.WriteString(w, `{"threads":[{"id":"9"},{"id":"8"}],"members":[],"has_more":true}`)
+			return
+		}
+		io.WriteString(w, `{"threads":[{"id":"3"}],"members":[],"has_more":false}`)
+	}))
+	defer server.Close()
+
+	var ids []string
+	err := newTestClient(t, server.URL).Channels().PaginateJoinedArchivedThreads("1").ForEach(context.Background(), func(thread types.Channel) error {
+		ids = append(ids, thread.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEach error: %v", err)
+	}
+	if len(ids) != 3 || len(befores) != 2 || befores[1] != "8" {
+		t.Fatalf("ids = %v, befores = %v", ids, befores)
+	}
+}
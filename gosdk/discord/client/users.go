@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// ListCurrentUserGuilds lists a page of the guilds the bot is in, in guild ID
+// order. Use PaginateCurrentUserGuilds to walk every page.
+func (u *Users) ListCurrentUserGuilds(ctx context.Context, params *types.ListCurrentUserGuildsParams) ([]*types.Guild, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if params != nil {
+		if params.Before != "" {
+			query.Set("before", params.Before)
+		}
+		if params.After != "" {
+			query.Set("after", params.After)
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.WithCounts {
+			query.Set("with_counts", "true")
+		}
+	}
+
+	path := "/users/@me/guilds"
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	var guilds []*types.Guild
+	if err := u.client.Get(ctx, path, &guilds); err != nil {
+		return nil, err
+	}
+	return guilds, nil
+}
+
+// SendDM opens a DM channel with a user and sends a message to it.
+func (u *Users) SendDM(ctx context.Context, userID string, params *types.MessageCreateParams) (*types.Message, error) {
+	if err := validateID("userID", userID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	channel, err := u.CreateDM(ctx, &types.CreateDMParams{RecipientID: userID})
+	if err != nil {
+		return nil, err
+	}
+	return u.client.Messages().CreateMessage(ctx, channel.ID, params)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestUsersListCurrentUserGuilds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/@me/guilds" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.RawQuery; got != "after=10&limit=50&with_counts=true" {
+			t.Fatalf("unexpected query %s", got)
+		}
+		json.NewEncoder(w).Encode([]*types.Guild{{ID: "11", Name: "one", Owner: true, Permissions: "8"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	guilds, err := client.Users().ListCurrentUserGuilds(context.Background(), &types.ListCurrentUserGuildsParams{After: "10", Limit: 50, WithCounts: true})
+	if err != nil {
+		t.Fatalf("ListCurrentUserGuilds error: %v", err)
+	}
+	if len(guilds) != 1 || !guilds[0].Owner || guilds[0].Permissions != "8" {
+		t.Fatalf("unexpected guilds %+v", guilds)
+	}
+	if _, err := client.Users().ListCurrentUserGuilds(context.Background(), &types.ListCurrentUserGuildsParams{Limit: 500}); err == nil {
+		t.Fatal("expected error for limit above 200")
+	}
+}
+
+func TestUsersPaginateCurrentUserGuilds(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		cursors = append(cursors, after)
+		start, _ := strconv.Atoi(after)
+		n := limits.UserGuildsPerPage
+		if after != "" {
+			n = 3
+		}
+		page := make([]*types.Guild, n)
+		for i := range page {
+			page[i] = &types.Guild{ID: strconv.Itoa(start + i + 1)}
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	guilds, err := client.Users().PaginateCurrentUserGuilds(false).All(context.Background())
+	if err != nil {
+		t.Fatalf("All error: %v", err)
+	}
+	if len(guilds) != limits.UserGuildsPerPage+3 {
+		t.Fatalf("got %d guilds", len(guilds))
+	}
+	if len(cursors) != 2 || cursors[0] != "" || cursors[1] != "200" {
+		t.Fatalf("unexpected cursors %v", cursors)
+	}
+}
+
+func TestUsersSendDM(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/users/@me/channels":
+			var body types.CreateDMParams
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.RecipientID != "42" {
+				t.Fatalf("unexpected recipient %q", body.RecipientID)
+			}
+			json.NewEncoder(w).Encode(types.Channel{ID: "900", Type: 1})
+		case "/channels/900/messages":
+			json.NewEncoder(w).Encode(types.Message{ID: "1", ChannelID: "900", Content: "hi"})
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	msg, err := client.Users().SendDM(context.Background(), "42", &types.MessageCreateParams{Content: "hi"})
+	if err != nil {
+		t.Fatalf("SendDM error: %v", err)
+	}
+	if msg.ChannelID != "900" || len(paths) != 2 {
+		t.Fatalf("unexpected result %+v after %v", msg, paths)
+	}
+	if _, err := client.Users().SendDM(context.Background(), "42", nil); err == nil {
+		t.Fatal("expected error for nil params")
+	}
+}
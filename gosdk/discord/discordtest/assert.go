@@ -0,0 +1,89 @@
+package discordtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// AssertRequest fails t unless a request matching method and pattern was
+// received, and returns the most recent match.
+func (s *Server) AssertRequest(t testing.TB, method, pattern string) Request {
+	t.Helper()
+	matches := s.RequestsTo(method, pattern)
+	if len(matches) == 0 {
+		t.Fatalf("discordtest: no %s %s request; received:\n%s", method, pattern, s.describeRequests())
+		return Request{}
+	}
+	return matches[len(matches)-1]
+}
+
+// AssertRequestCount fails t unless exactly n requests matching method and
+// pattern were received.
+func (s *Server) AssertRequestCount(t testing.TB, method, pattern string, n int) {
+	t.Helper()
+	if got := len(s.RequestsTo(method, pattern)); got != n {
+		t.Errorf("discordtest: got %d %s %s requests, want %d; received:\n%s", got, method, pattern, n, s.describeRequests())
+	}
+}
+
+// AssertNoRequest fails t if a request matching method and pattern was
+// received.
+func (s *Server) AssertNoRequest(t testing.TB, method, pattern string) {
+	t.Helper()
+	s.AssertRequestCount(t, method, pattern, 0)
+}
+
+// AssertMessage fails t unless channelID holds a message with the given
+// content, and returns the most recent one.
+func (s *Server) AssertMessage(t testing.TB, channelID, content string) types.Message {
+	t.Helper()
+	messages := s.Messages(channelID)
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Content == content {
+			return messages[i]
+		}
+	}
+	var contents []string
+	for _, msg := range messages {
+		contents = append(contents, "  "+msg.Content)
+	}
+	t.Fatalf("discordtest: no message %q in channel %s; channel has %d:\n%s", content, channelID, len(messages), strings.Join(contents, "\n"))
+	return types.Message{}
+}
+
+// AssertMessageCount fails t unless channelID holds exactly n messages.
+func (s *Server) AssertMessageCount(t testing.TB, channelID string, n int) {
+	t.Helper()
+	if got := len(s.Messages(channelID)); got != n {
+		t.Errorf("discordtest: channel %s has %d messages, want %d", channelID, got, n)
+	}
+}
+
+// AssertCommand fails t unless appID has a command named name, globally
+// when guildID is empty, and returns it.
+func (s *Server) AssertCommand(t testing.TB, appID, guildID, name string) types.ApplicationCommand {
+	t.Helper()
+	cmds := s.Commands(appID, guildID)
+	var names []string
+	for _, cmd := range cmds {
+		if cmd.Name == name {
+			return cmd
+		}
+		names = append(names, cmd.Name)
+	}
+	t.Fatalf("discordtest: no command %q registered; have %v", name, names)
+	return types.ApplicationCommand{}
+}
+
+func (s *Server) describeRequests() string {
+	var b strings.Builder
+	for _, r := range s.Requests() {
+		b.WriteString("  " + r.Method + " " + r.Path + "\n")
+	}
+	if b.Len() == 0 {
+		return "  (none)\n"
+	}
+	return b.String()
+}
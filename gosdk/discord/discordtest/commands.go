@@ -0,0 +1,181 @@
+package discordtest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// AddCommand stores cmd for appID, as a guild command when cmd.GuildID is
+// set, filling in its ID and version. It returns the stored command.
+func (s *Server) AddCommand(appID string, cmd types.ApplicationCommand) types.ApplicationCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, _ := s.upsertCommand(appID, cmd.GuildID, cmd)
+	return stored
+}
+
+// Commands returns the commands registered for appID, globally when
+// guildID is empty.
+func (s *Server) Commands(appID, guildID string) []types.ApplicationCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]types.ApplicationCommand(nil), s.commands[commandScope(appID, guildID)]...)
+}
+
+func commandScope(appID, guildID string) string {
+	return appID + "/" + guildID
+}
+
+func commandType(cmd types.ApplicationCommand) types.ApplicationCommandType {
+	if cmd.Type == 0 {
+		return types.ApplicationCommandTypeChatInput
+	}
+	return cmd.Type
+}
+
+// upsertCommand creates cmd, or replaces the command of the same name and
+// type like Discord does, reporting whether it was created. s.mu must be
+// held.
+func (s *Server) upsertCommand(appID, guildID string, cmd types.ApplicationCommand) (types.ApplicationCommand, bool) {
+	scope := commandScope(appID, guildID)
+	cmd.ApplicationID = appID
+	cmd.GuildID = guildID
+	cmd.Type = commandType(cmd)
+	cmd.Version = s.newID()
+	for i, existing := range s.commands[scope] {
+		if existing.Name == cmd.Name && existing.Type == cmd.Type {
+			cmd.ID = existing.ID
+			s.commands[scope][i] = cmd
+			return cmd, false
+		}
+	}
+	if cmd.ID == "" {
+		cmd.ID = s.newID()
+	}
+	s.commands[scope] = append(s.commands[scope], cmd)
+	return cmd, true
+}
+
+// findCommand returns the index of command id in scope, or -1. s.mu must
+// be held.
+func (s *Server) findCommand(scope, id string) int {
+	for i, cmd := range s.commands[scope] {
+		if cmd.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) listCommands(w http.ResponseWriter, req Request, params map[string]string) {
+	cmds := s.Commands(params["app"], params["guild"])
+	if cmds == nil {
+		cmds = []types.ApplicationCommand{}
+	}
+	writeJSON(w, http.StatusOK, cmds)
+}
+
+func (s *Server) createCommand(w http.ResponseWriter, req Request, params map[string]string) {
+	var cmd types.ApplicationCommand
+	if err := req.JSON(&cmd); err != nil || cmd.Name == "" {
+		writeError(w, http.StatusBadRequest, 50035, "Invalid Form Body")
+		return
+	}
+	s.mu.Lock()
+	stored, created := s.upsertCommand(params["app"], params["guild"], cmd)
+	s.mu.Unlock()
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, stored)
+}
+
+func (s *Server) overwriteCommands(w http.ResponseWriter, req Request, params map[string]string) {
+	var cmds []types.ApplicationCommand
+	if err := req.JSON(&cmds); err != nil {
+		writeError(w, http.StatusBadRequest, 50035, "Invalid Form Body")
+		return
+	}
+	for _, cmd := range cmds {
+		if cmd.Name == "" {
+			writeError(w, http.StatusBadRequest, 50035, "Invalid Form Body")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	scope := commandScope(params["app"], params["guild"])
+	previous := s.commands[scope]
+	s.commands[scope] = nil
+	out := []types.ApplicationCommand{}
+	for _, cmd := range cmds {
+		// Commands keep their ID across overwrites when name and type match.
+		cmd.ID = ""
+		for _, old := range previous {
+			if old.Name == cmd.Name && old.Type == commandType(cmd) {
+				cmd.ID = old.ID
+			}
+		}
+		stored, _ := s.upsertCommand(params["app"], params["guild"], cmd)
+		out = append(out, stored)
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getCommand(w http.ResponseWriter, req Request, params map[string]string) {
+	s.mu.Lock()
+	scope := commandScope(params["app"], params["guild"])
+	i := s.findCommand(scope, params["command"])
+	var cmd types.ApplicationCommand
+	if i >= 0 {
+		cmd = s.commands[scope][i]
+	}
+	s.mu.Unlock()
+	if i < 0 {
+		writeUnknownCommand(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, cmd)
+}
+
+func (s *Server) editCommand(w http.ResponseWriter, req Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scope := commandScope(params["app"], params["guild"])
+	i := s.findCommand(scope, params["command"])
+	if i < 0 {
+		writeUnknownCommand(w)
+		return
+	}
+	// Fields present in the body replace the stored ones.
+	cmd := s.commands[scope][i]
+	if err := json.Unmarshal(req.Body, &cmd); err != nil {
+		writeError(w, http.StatusBadRequest, 50035, "Invalid Form Body")
+		return
+	}
+	cmd.ID = params["command"]
+	cmd.Version = s.newID()
+	s.commands[scope][i] = cmd
+	writeJSON(w, http.StatusOK, cmd)
+}
+
+func (s *Server) deleteCommand(w http.ResponseWriter, req Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scope := commandScope(params["app"], params["guild"])
+	i := s.findCommand(scope, params["command"])
+	if i < 0 {
+		writeUnknownCommand(w)
+		return
+	}
+	s.commands[scope] = append(s.commands[scope][:i:i], s.commands[scope][i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeUnknownCommand(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, 10063, "Unknown application command")
+}
@@ -0,0 +1,68 @@
+package discordtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestCommandRoutes(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(t)
+	commands := srv.Client("token").ApplicationCommands("500")
+
+	created, err := commands.CreateGlobalApplicationCommand(ctx, &types.ApplicationCommand{Name: "ping", Description: "Ping"})
+	if err != nil {
+		t.Fatalf("CreateGlobalApplicationCommand() error = %v", err)
+	}
+	again, err := commands.CreateGlobalApplicationCommand(ctx, &types.ApplicationCommand{Name: "ping", Description: "Pong"})
+	if err != nil || again.ID != created.ID {
+		t.Fatalf("re-create should replace by name: %+v, %v", again, err)
+	}
+	if got := srv.AssertCommand(t, "500", "", "ping"); got.Description != "Pong" || got.ApplicationID != "500" {
+		t.Fatalf("command = %+v", got)
+	}
+
+	edited, err := commands.EditGlobalApplicationCommand(ctx, created.ID, &types.ApplicationCommand{Name: "ping", Description: "Edited"})
+	if err != nil || edited.Description != "Edited" || edited.ID != created.ID {
+		t.Fatalf("EditGlobalApplicationCommand() = %+v, %v", edited, err)
+	}
+
+	if err := commands.DeleteGlobalApplicationCommand(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteGlobalApplicationCommand() error = %v", err)
+	}
+	err = commands.DeleteGlobalApplicationCommand(ctx, created.ID)
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 10063 {
+		t.Fatalf("second delete error = %v", err)
+	}
+}
+
+func TestBulkOverwriteGuildCommands(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(t)
+	existing := srv.AddCommand("500", types.ApplicationCommand{GuildID: "9", Name: "keep", Description: "Keep"})
+	srv.AddCommand("500", types.ApplicationCommand{GuildID: "9", Name: "drop", Description: "Drop"})
+	commands := srv.Client("token").ApplicationCommands("500")
+
+	out, err := commands.BulkOverwriteGuildApplicationCommands(ctx, "9", []*types.ApplicationCommand{
+		{Name: "keep", Description: "Keep v2"},
+		{Name: "new", Description: "New"},
+	})
+	if err != nil {
+		t.Fatalf("BulkOverwriteGuildApplicationCommands() error = %v", err)
+	}
+	if len(out) != 2 || out[0].ID != existing.ID {
+		t.Fatalf("overwrite = %+v", out)
+	}
+
+	listed, err := commands.GetGuildApplicationCommands(ctx, "9")
+	if err != nil || len(listed) != 2 {
+		t.Fatalf("GetGuildApplicationCommands() = %v, %v", listed, err)
+	}
+	if len(srv.Commands("500", "")) != 0 {
+		t.Fatal("guild overwrite touched global commands")
+	}
+}
@@ -0,0 +1,405 @@
+package discordtest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// bulkDeleteMaxAge is the oldest message bulk delete accepts.
+const bulkDeleteMaxAge = 14 * 24 * time.Hour
+
+// botUser authors messages created through the REST routes.
+var botUser = types.User{ID: "1", Username: "discordtest", Bot: true}
+
+// messagePayload is the union of the create, edit and webhook execute
+// bodies the server reads.
+type messagePayload struct {
+	Content          *string                   `json:"content"`
+	Embeds           *[]types.Embed            `json:"embeds"`
+	Components       *[]types.MessageComponent `json:"components"`
+	Poll             *types.Poll               `json:"poll"`
+	Flags            *int                      `json:"flags"`
+	Nonce            string                    `json:"nonce"`
+	EnforceNonce     bool                      `json:"enforce_nonce"`
+	MessageReference *types.MessageReference   `json:"message_reference"`
+	Username         string                    `json:"username"`
+	ThreadName       string                    `json:"thread_name"`
+}
+
+func (p *messagePayload) empty(files []string) bool {
+	return (p.Content == nil || *p.Content == "") &&
+		(p.Embeds == nil || len(*p.Embeds) == 0) &&
+		(p.Components == nil || len(*p.Components) == 0) &&
+		p.Poll == nil && len(files) == 0
+}
+
+// apply copies the fields present in p onto msg.
+func (p *messagePayload) apply(msg *types.Message) {
+	if p.Content != nil {
+		msg.Content = *p.Content
+	}
+	if p.Embeds != nil {
+		msg.Embeds = *p.Embeds
+	}
+	if p.Components != nil {
+		msg.Components = *p.Components
+	}
+	if p.Flags != nil {
+		msg.Flags = *p.Flags
+	}
+}
+
+// AddWebhook registers webhook id so its messages land in channelID and
+// requests with a different token are rejected. Unregistered webhooks
+// accept any token and post to a channel with the webhook's ID.
+func (s *Server) AddWebhook(id, token, channelID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhooks[id] = webhookEntry{token: token, channelID: channelID}
+}
+
+// AddMessage stores msg as if it had been sent, filling in a missing
+// timestamp and ID. The ID is derived from the timestamp, so seeding an old
+// Timestamp produces a message bulk delete refuses. It returns the stored
+// message.
+func (s *Server) AddMessage(msg types.Message) types.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = s.now()
+	}
+	if msg.ID == "" {
+		msg.ID = s.idAt(msg.Timestamp)
+	}
+	s.store(&msg)
+	return msg
+}
+
+// Messages returns the messages stored in channelID, oldest first.
+func (s *Server) Messages(channelID string) []types.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []types.Message
+	for _, id := range s.channels[channelID] {
+		out = append(out, *s.messages[id])
+	}
+	return out
+}
+
+// Message returns the stored message with the given ID.
+func (s *Server) Message(id string) (types.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, ok := s.messages[id]
+	if !ok {
+		return types.Message{}, false
+	}
+	return *msg, true
+}
+
+// store adds msg to its channel, keeping the channel ordered by ID. s.mu
+// must be held.
+func (s *Server) store(msg *types.Message) {
+	s.messages[msg.ID] = msg
+	ids := append(s.channels[msg.ChannelID], msg.ID)
+	sort.Slice(ids, func(i, j int) bool { return snowflake(ids[i]) < snowflake(ids[j]) })
+	s.channels[msg.ChannelID] = ids
+}
+
+// remove deletes message id. s.mu must be held.
+func (s *Server) remove(id string) {
+	msg, ok := s.messages[id]
+	if !ok {
+		return
+	}
+	delete(s.messages, id)
+	ids := s.channels[msg.ChannelID]
+	for i, other := range ids {
+		if other == id {
+			s.channels[msg.ChannelID] = append(ids[:i:i], ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// create builds and stores a message from p. s.mu must be held.
+func (s *Server) create(channelID string, author types.User, p *messagePayload, files []string) *types.Message {
+	if p.EnforceNonce && p.Nonce != "" {
+		for _, id := range s.channels[channelID] {
+			if msg := s.messages[id]; msg.Author != nil && msg.Author.ID == author.ID && s.nonces[id] == p.Nonce {
+				return msg
+			}
+		}
+	}
+	msg := &types.Message{
+		ID:               s.newID(),
+		ChannelID:        channelID,
+		Timestamp:        s.now().UTC(),
+		Author:           &author,
+		Poll:             p.Poll,
+		MessageReference: p.MessageReference,
+	}
+	p.apply(msg)
+	for _, name := range files {
+		msg.Attachments = append(msg.Attachments, types.Attachment{ID: s.newID(), Filename: name})
+	}
+	if p.Nonce != "" {
+		s.nonces[msg.ID] = p.Nonce
+	}
+	s.store(msg)
+	return msg
+}
+
+func (s *Server) createMessage(w http.ResponseWriter, req Request, params map[string]string) {
+	var p messagePayload
+	if err := req.JSON(&p); err != nil {
+		writeError(w, http.StatusBadRequest, 50109, "The request body contains invalid JSON.")
+		return
+	}
+	if p.empty(req.Files) {
+		writeError(w, http.StatusBadRequest, 50006, "Cannot send an empty message")
+		return
+	}
+	s.mu.Lock()
+	msg := *s.create(params["channel"], botUser, &p, req.Files)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, msg)
+}
+
+func (s *Server) listMessages(w http.ResponseWriter, req Request, params map[string]string) {
+	limit := 50
+	if v := req.Query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			writeError(w, http.StatusBadRequest, 50035, "Invalid Form Body")
+			return
+		}
+		limit = n
+	}
+	before, after := snowflake(req.Query.Get("before")), snowflake(req.Query.Get("after"))
+
+	s.mu.Lock()
+	ids := s.channels[params["channel"]]
+	out := []types.Message{}
+	if after != 0 {
+		// Oldest first from after, returned newest first like Discord.
+		for _, id := range ids {
+			if snowflake(id) > after && len(out) < limit {
+				out = append([]types.Message{*s.messages[id]}, out...)
+			}
+		}
+	} else {
+		for i := len(ids) - 1; i >= 0 && len(out) < limit; i-- {
+			if before == 0 || snowflake(ids[i]) < before {
+				out = append(out, *s.messages[ids[i]])
+			}
+		}
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getMessage(w http.ResponseWriter, req Request, params map[string]string) {
+	s.mu.Lock()
+	msg, ok := s.channelMessage(params["channel"], params["message"])
+	s.mu.Unlock()
+	if !ok {
+		writeUnknownMessage(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, msg)
+}
+
+func (s *Server) editMessage(w http.ResponseWriter, req Request, params map[string]string) {
+	var p messagePayload
+	if err := req.JSON(&p); err != nil {
+		writeError(w, http.StatusBadRequest, 50109, "The request body contains invalid JSON.")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.channelMessage(params["channel"], params["message"]); !ok {
+		writeUnknownMessage(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.edit(params["message"], &p))
+}
+
+func (s *Server) deleteMessage(w http.ResponseWriter, req Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.channelMessage(params["channel"], params["message"]); !ok {
+		writeUnknownMessage(w)
+		return
+	}
+	s.remove(params["message"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) bulkDelete(w http.ResponseWriter, req Request, params map[string]string) {
+	var body struct {
+		Messages []string `json:"messages"`
+	}
+	if err := req.JSON(&body); err != nil || len(body.Messages) < 2 || len(body.Messages) > 100 {
+		writeError(w, http.StatusBadRequest, 50035, "Invalid Form Body")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := s.now().Add(-bulkDeleteMaxAge)
+	for _, id := range body.Messages {
+		if types.Snowflake(snowflake(id)).Timestamp().Before(cutoff) {
+			writeError(w, http.StatusBadRequest, 50034, "You can only bulk delete messages that are under 14 days old.")
+			return
+		}
+	}
+	for _, id := range body.Messages {
+		if msg, ok := s.messages[id]; ok && msg.ChannelID == params["channel"] {
+			s.remove(id)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) executeWebhook(w http.ResponseWriter, req Request, params map[string]string) {
+	var p messagePayload
+	if err := req.JSON(&p); err != nil {
+		writeError(w, http.StatusBadRequest, 50109, "The request body contains invalid JSON.")
+		return
+	}
+	if p.empty(req.Files) {
+		writeError(w, http.StatusBadRequest, 50006, "Cannot send an empty message")
+		return
+	}
+
+	s.mu.Lock()
+	channelID, ok := s.webhookChannel(params["webhook"], params["token"])
+	if !ok {
+		s.mu.Unlock()
+		writeInvalidWebhookToken(w)
+		return
+	}
+	switch {
+	case req.Query.Get("thread_id") != "":
+		channelID = req.Query.Get("thread_id")
+	case p.ThreadName != "":
+		channelID = s.newID()
+	}
+	author := types.User{ID: params["webhook"], Username: p.Username, Bot: true}
+	if author.Username == "" {
+		author.Username = "Webhook"
+	}
+	msg := *s.create(channelID, author, &p, req.Files)
+	s.mu.Unlock()
+
+	if req.Query.Get("wait") != "true" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, msg)
+}
+
+func (s *Server) getWebhookMessage(w http.ResponseWriter, req Request, params map[string]string) {
+	s.mu.Lock()
+	msg, status := s.webhookMessage(params)
+	s.mu.Unlock()
+	switch status {
+	case http.StatusUnauthorized:
+		writeInvalidWebhookToken(w)
+	case http.StatusNotFound:
+		writeUnknownMessage(w)
+	default:
+		writeJSON(w, http.StatusOK, msg)
+	}
+}
+
+func (s *Server) editWebhookMessage(w http.ResponseWriter, req Request, params map[string]string) {
+	var p messagePayload
+	if err := req.JSON(&p); err != nil {
+		writeError(w, http.StatusBadRequest, 50109, "The request body contains invalid JSON.")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch _, status := s.webhookMessage(params); status {
+	case http.StatusUnauthorized:
+		writeInvalidWebhookToken(w)
+	case http.StatusNotFound:
+		writeUnknownMessage(w)
+	default:
+		writeJSON(w, http.StatusOK, s.edit(params["message"], &p))
+	}
+}
+
+func (s *Server) deleteWebhookMessage(w http.ResponseWriter, req Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch _, status := s.webhookMessage(params); status {
+	case http.StatusUnauthorized:
+		writeInvalidWebhookToken(w)
+	case http.StatusNotFound:
+		writeUnknownMessage(w)
+	default:
+		s.remove(params["message"])
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// edit applies p to message id and returns the result. s.mu must be held.
+func (s *Server) edit(id string, p *messagePayload) types.Message {
+	msg := s.messages[id]
+	p.apply(msg)
+	edited := s.now().UTC()
+	msg.EditedTimestamp = &edited
+	return *msg
+}
+
+// channelMessage looks up a message in channelID. s.mu must be held.
+func (s *Server) channelMessage(channelID, id string) (types.Message, bool) {
+	msg, ok := s.messages[id]
+	if !ok || msg.ChannelID != channelID {
+		return types.Message{}, false
+	}
+	return *msg, true
+}
+
+// webhookChannel checks token against a registered webhook and returns the
+// channel it posts to. s.mu must be held.
+func (s *Server) webhookChannel(id, token string) (string, bool) {
+	entry, ok := s.webhooks[id]
+	if !ok {
+		return id, true
+	}
+	return entry.channelID, entry.token == token
+}
+
+// webhookMessage looks up a message sent by the webhook in params, returning
+// the HTTP status to answer with. s.mu must be held.
+func (s *Server) webhookMessage(params map[string]string) (types.Message, int) {
+	if _, ok := s.webhookChannel(params["webhook"], params["token"]); !ok {
+		return types.Message{}, http.StatusUnauthorized
+	}
+	msg, ok := s.messages[params["message"]]
+	if !ok || msg.Author == nil || msg.Author.ID != params["webhook"] {
+		return types.Message{}, http.StatusNotFound
+	}
+	return *msg, http.StatusOK
+}
+
+func writeUnknownMessage(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, 10008, "Unknown Message")
+}
+
+func writeInvalidWebhookToken(w http.ResponseWriter) {
+	writeError(w, http.StatusUnauthorized, 50027, "Invalid Webhook Token")
+}
+
+// snowflake parses id, returning 0 for an empty or malformed ID.
+func snowflake(id string) uint64 {
+	n, _ := strconv.ParseUint(id, 10, 64)
+	return n
+}
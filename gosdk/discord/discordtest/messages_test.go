@@ -0,0 +1,203 @@
+package discordtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/webhook"
+)
+
+func TestMessageCRUD(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(t)
+	messages := srv.Client("token").Messages()
+
+	created, err := messages.CreateMessage(ctx, "10", &types.MessageCreateParams{Content: "hello"})
+	if err != nil {
+		t.Fatalf("CreateMessage() error = %v", err)
+	}
+	if created.ID == "" || created.ChannelID != "10" || created.Author == nil || !created.Author.Bot {
+		t.Fatalf("created = %+v", created)
+	}
+
+	edited, err := messages.EditMessage(ctx, "10", created.ID, &types.MessageEditParams{Content: "hello again"})
+	if err != nil {
+		t.Fatalf("EditMessage() error = %v", err)
+	}
+	if edited.Content != "hello again" || edited.EditedTimestamp == nil {
+		t.Fatalf("edited = %+v", edited)
+	}
+
+	got, err := messages.GetMessage(ctx, "10", created.ID)
+	if err != nil || got.Content != "hello again" {
+		t.Fatalf("GetMessage() = %+v, %v", got, err)
+	}
+
+	if err := messages.DeleteMessage(ctx, "10", created.ID); err != nil {
+		t.Fatalf("DeleteMessage() error = %v", err)
+	}
+	_, err = messages.GetMessage(ctx, "10", created.ID)
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 10008 {
+		t.Fatalf("GetMessage() after delete error = %v", err)
+	}
+	srv.AssertRequest(t, http.MethodPatch, "/channels/10/messages/{message}")
+}
+
+func TestCreateMessageRejectsEmpty(t *testing.T) {
+	srv := NewServer(t)
+	req, _ := http.NewRequest(http.MethodPost, srv.BaseURL()+"/channels/1/messages", strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bot token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestCreateMessageEnforcesNonce(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(t)
+	rest := srv.Client("token")
+
+	first, err := rest.Messages().CreateMessageIdempotent(ctx, "1", "deploy-42", &types.MessageCreateParams{Content: "deployed"})
+	if err != nil {
+		t.Fatalf("CreateMessageIdempotent() error = %v", err)
+	}
+	// A second client has no record of the key, so only the nonce dedupes.
+	second, err := srv.Client("token").Messages().CreateMessageIdempotent(ctx, "1", "deploy-42", &types.MessageCreateParams{Content: "deployed"})
+	if err != nil {
+		t.Fatalf("second CreateMessageIdempotent() error = %v", err)
+	}
+	if second.Message.ID != first.Message.ID {
+		t.Fatalf("nonce not enforced: %s != %s", second.Message.ID, first.Message.ID)
+	}
+	srv.AssertMessageCount(t, "1", 1)
+}
+
+func TestListMessages(t *testing.T) {
+	srv := NewServer(t)
+	var ids []string
+	for _, content := range []string{"a", "b", "c", "d"} {
+		ids = append(ids, srv.AddMessage(types.Message{ChannelID: "1", Content: content}).ID)
+	}
+	channels := srv.Client("token").Channels()
+
+	latest, err := channels.GetChannelMessages(context.Background(), "1", &client.GetChannelMessagesParams{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetChannelMessages() error = %v", err)
+	}
+	if len(latest) != 2 || latest[0].Content != "d" || latest[1].Content != "c" {
+		t.Fatalf("latest = %v", contents(latest))
+	}
+
+	before, _ := channels.GetChannelMessages(context.Background(), "1", &client.GetChannelMessagesParams{Before: ids[2]})
+	if got := contents(before); got != "b,a" {
+		t.Fatalf("before = %s", got)
+	}
+	after, _ := channels.GetChannelMessages(context.Background(), "1", &client.GetChannelMessagesParams{After: ids[0], Limit: 2})
+	if got := contents(after); got != "c,b" {
+		t.Fatalf("after = %s", got)
+	}
+}
+
+func contents(msgs []*types.Message) string {
+	var out []string
+	for _, m := range msgs {
+		out = append(out, m.Content)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestBulkDelete(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(t)
+	messages := srv.Client("token").Messages()
+	a := srv.AddMessage(types.Message{ChannelID: "1", Content: "a"})
+	b := srv.AddMessage(types.Message{ChannelID: "1", Content: "b"})
+	old := srv.AddMessage(types.Message{ChannelID: "1", Content: "old", Timestamp: time.Now().Add(-15 * 24 * time.Hour)})
+
+	err := messages.BulkDeleteMessages(ctx, "1", []string{a.ID, old.ID})
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 50034 {
+		t.Fatalf("BulkDeleteMessages() with an old message error = %v", err)
+	}
+	if err := messages.BulkDeleteMessages(ctx, "1", []string{a.ID, b.ID}); err != nil {
+		t.Fatalf("BulkDeleteMessages() error = %v", err)
+	}
+	if got := srv.Messages("1"); len(got) != 1 || got[0].ID != old.ID {
+		t.Fatalf("remaining = %+v", got)
+	}
+}
+
+func TestWebhookExecute(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(t)
+	srv.AddWebhook("100", "secret", "55")
+	hook := srv.Webhook("100", "secret")
+
+	if err := hook.SendSimple(ctx, "fire and forget"); err != nil {
+		t.Fatalf("SendSimple() error = %v", err)
+	}
+	msg, err := hook.SendWait(ctx, &types.WebhookMessage{Content: "waited", Username: "CI"})
+	if err != nil {
+		t.Fatalf("SendWait() error = %v", err)
+	}
+	if msg.ChannelID != "55" || msg.Author.Username != "CI" {
+		t.Fatalf("message = %+v", msg)
+	}
+	srv.AssertMessage(t, "55", "fire and forget")
+	srv.AssertMessageCount(t, "55", 2)
+	if got := srv.AssertRequest(t, http.MethodPost, "/webhooks/100/{token}"); got.Query.Get("wait") != "true" {
+		t.Fatalf("last request query = %v", got.Query)
+	}
+
+	edited := "edited"
+	if _, err := hook.Edit(ctx, msg.ID, &webhook.MessageEditParams{Content: &edited}); err != nil {
+		t.Fatalf("Edit() error = %v", err)
+	}
+	srv.AssertMessage(t, "55", "edited")
+	if err := hook.Delete(ctx, msg.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	srv.AssertMessageCount(t, "55", 1)
+
+	err = srv.Webhook("100", "wrong").SendSimple(ctx, "nope")
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 50027 {
+		t.Fatalf("wrong token error = %v", err)
+	}
+}
+
+func TestWebhookThreadsAndFiles(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(t)
+	hook := srv.Webhook("100", "secret")
+
+	if _, err := hook.SendWaitWithOpts(ctx, &types.WebhookMessage{Content: "in thread"}, webhook.SendOpts{ThreadID: "77"}); err != nil {
+		t.Fatalf("SendWaitWithOpts() error = %v", err)
+	}
+	srv.AssertMessage(t, "77", "in thread")
+
+	msg, err := hook.SendWithFilesWait(ctx, &types.WebhookMessage{Content: "report"}, []webhook.FileAttachment{
+		{Name: "report.txt", Reader: strings.NewReader("ok")},
+	})
+	if err != nil {
+		t.Fatalf("SendWithFilesWait() error = %v", err)
+	}
+	if len(msg.Attachments) != 1 || msg.Attachments[0].Filename != "report.txt" {
+		t.Fatalf("attachments = %+v", msg.Attachments)
+	}
+	if files := srv.AssertRequest(t, http.MethodPost, "/webhooks/{id}/{token}").Files; len(files) != 1 {
+		t.Fatalf("recorded files = %v", files)
+	}
+}
@@ -0,0 +1,502 @@
+// Package discordtest provides an in-memory fake of the Discord REST API for
+// testing code built on the SDK.
+//
+// A Server answers the routes bots use most (webhook execution, channel
+// message CRUD and bulk delete, and application commands) from in-memory
+// state, records every request, and can simulate rate limits and failures:
+//
+//	srv := discordtest.NewServer(t)
+//	rest := srv.Client("token")
+//	hook := srv.Webhook("123", "secret")
+//
+//	runMyBot(rest, hook)
+//
+//	srv.AssertMessage(t, "123", "deploy finished")
+//	srv.AssertRequestCount(t, "POST", "/channels/{channel}/messages", 1)
+//
+// Paths are matched with or without the /api and /api/vN prefixes, so both
+// the REST client and webhook URLs work unchanged. Route patterns in the
+// helpers use {name} for a single path segment.
+package discordtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/webhook"
+)
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	// Path has the /api[/vN] prefix removed.
+	Path   string
+	Query  url.Values
+	Header http.Header
+	// Body is the JSON payload; for multipart requests, the payload_json part.
+	Body []byte
+	// Files lists the names of files uploaded with a multipart request.
+	Files []string
+}
+
+// JSON decodes the request payload into v.
+func (r Request) JSON(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Server is a fake Discord API backed by httptest.Server. It is safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	tb       testing.TB
+	mu       sync.Mutex
+	now      func() time.Time
+	lastID   types.Snowflake
+	seq      uint64
+	requests []Request
+	handlers []handler
+	faults   []*fault
+	buckets  []*bucket
+
+	messages map[string]*types.Message             // message ID -> message
+	nonces   map[string]string                     // message ID -> nonce
+	channels map[string][]string                   // channel ID -> message IDs, oldest first
+	webhooks map[string]webhookEntry               // webhook ID -> registration
+	commands map[string][]types.ApplicationCommand // scope -> commands
+}
+
+type handler struct {
+	method  string
+	pattern *pattern
+	fn      http.HandlerFunc
+}
+
+type webhookEntry struct {
+	token     string
+	channelID string
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithClock makes the server read the current time from now, which sets
+// message timestamps and IDs and drives rate limit windows.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+// NewServer starts a Server that is closed when the test ends.
+func NewServer(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+	s := &Server{
+		tb:       t,
+		now:      time.Now,
+		messages: make(map[string]*types.Message),
+		nonces:   make(map[string]string),
+		channels: make(map[string][]string),
+		webhooks: make(map[string]webhookEntry),
+		commands: make(map[string][]types.ApplicationCommand),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// BaseURL is the REST base URL to pass to client.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/v10"
+}
+
+// WebhookURL returns the execute URL of webhook id.
+func (s *Server) WebhookURL(id, token string) string {
+	return fmt.Sprintf("%s/api/webhooks/%s/%s", s.URL, id, token)
+}
+
+// Client returns a REST client for the server. Rate limits reported by the
+// server are honored as they would be against Discord.
+func (s *Server) Client(token string, opts ...client.Option) *client.Client {
+	c, err := client.New(token, append([]client.Option{client.WithBaseURL(s.BaseURL())}, opts...)...)
+	if err != nil {
+		s.tb.Fatalf("discordtest: client.New() error = %v", err)
+	}
+	return c
+}
+
+// Webhook returns a webhook client for webhook id.
+func (s *Server) Webhook(id, token string, opts ...webhook.Option) *webhook.Client {
+	c, err := webhook.NewClient(s.WebhookURL(id, token), opts...)
+	if err != nil {
+		s.tb.Fatalf("discordtest: webhook.NewClient() error = %v", err)
+	}
+	return c
+}
+
+// Handle overrides the built-in behavior for requests matching method and
+// pattern, e.g. to serve a route the server does not implement. Later
+// registrations take precedence. Requests are still recorded, and rate
+// limits and faults still apply.
+func (s *Server) Handle(method, pattern string, fn http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, handler{method: method, pattern: compilePattern(pattern), fn: fn})
+}
+
+type fault struct {
+	method    string
+	pattern   *pattern
+	status    int
+	code      int
+	message   string
+	remaining int
+}
+
+// Fail makes the next times requests matching method and pattern fail with
+// status and a Discord error body carrying code and message. A 429 status
+// also sets Retry-After and retry_after to one second.
+func (s *Server) Fail(method, pattern string, times, status, code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{
+		method:    method,
+		pattern:   compilePattern(pattern),
+		status:    status,
+		code:      code,
+		message:   message,
+		remaining: times,
+	})
+}
+
+type bucket struct {
+	method  string
+	pattern *pattern
+	name    string
+	limit   int
+	window  time.Duration
+	used    int
+	resetAt time.Time
+}
+
+// RateLimit puts requests matching method and pattern in a bucket allowing
+// limit requests per window. Matching responses carry the X-RateLimit-*
+// headers, and requests beyond the limit get a 429 until the window resets.
+// An empty method matches every method.
+func (s *Server) RateLimit(method, pattern string, limit int, window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buckets = append(s.buckets, &bucket{
+		method:  method,
+		pattern: compilePattern(pattern),
+		name:    fmt.Sprintf("bucket-%d", len(s.buckets)+1),
+		limit:   limit,
+		window:  window,
+	})
+}
+
+// Requests returns every request received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the received requests matching method and pattern.
+// An empty method matches every method.
+func (s *Server) RequestsTo(method, pattern string) []Request {
+	p := compilePattern(pattern)
+	var out []Request
+	for _, r := range s.Requests() {
+		if (method == "" || r.Method == method) && p.match(r.Path) != nil {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Reset clears recorded requests, stored messages, commands, faults and
+// rate limit state. Handlers and webhooks stay registered.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+	s.faults = nil
+	s.buckets = nil
+	s.messages = make(map[string]*types.Message)
+	s.nonces = make(map[string]string)
+	s.channels = make(map[string][]string)
+	s.commands = make(map[string][]types.ApplicationCommand)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := readRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, 50109, "The request body contains invalid JSON.")
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	if f := s.takeFault(req); f != nil {
+		s.mu.Unlock()
+		if f.status == http.StatusTooManyRequests {
+			writeRateLimited(w, time.Second)
+			return
+		}
+		writeError(w, f.status, f.code, f.message)
+		return
+	}
+	if limited, retryAfter := s.applyBuckets(w.Header(), req); limited {
+		s.mu.Unlock()
+		writeRateLimited(w, retryAfter)
+		return
+	}
+	var override http.HandlerFunc
+	for i := len(s.handlers) - 1; i >= 0; i-- {
+		h := s.handlers[i]
+		if (h.method == "" || h.method == req.Method) && h.pattern.match(req.Path) != nil {
+			override = h.fn
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if override != nil {
+		override(w, r)
+		return
+	}
+	s.route(w, req)
+}
+
+// takeFault returns the first pending fault for req. s.mu must be held.
+func (s *Server) takeFault(req Request) *fault {
+	for _, f := range s.faults {
+		if f.remaining > 0 && (f.method == "" || f.method == req.Method) && f.pattern.match(req.Path) != nil {
+			f.remaining--
+			return f
+		}
+	}
+	return nil
+}
+
+// applyBuckets counts req against its bucket and sets the rate limit
+// headers. s.mu must be held.
+func (s *Server) applyBuckets(h http.Header, req Request) (bool, time.Duration) {
+	now := s.now()
+	for _, b := range s.buckets {
+		if (b.method != "" && b.method != req.Method) || b.pattern.match(req.Path) == nil {
+			continue
+		}
+		if !now.Before(b.resetAt) {
+			b.used = 0
+			b.resetAt = now.Add(b.window)
+		}
+		resetAfter := b.resetAt.Sub(now)
+		h.Set("X-RateLimit-Bucket", b.name)
+		h.Set("X-RateLimit-Limit", strconv.Itoa(b.limit))
+		h.Set("X-RateLimit-Reset", strconv.FormatFloat(float64(b.resetAt.UnixMilli())/1000, 'f', 3, 64))
+		h.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter.Seconds(), 'f', 3, 64))
+		if b.used >= b.limit {
+			h.Set("X-RateLimit-Remaining", "0")
+			return true, resetAfter
+		}
+		b.used++
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(b.limit-b.used))
+		return false, 0
+	}
+	return false, 0
+}
+
+// route dispatches req to the built-in routes.
+func (s *Server) route(w http.ResponseWriter, req Request) {
+	for _, rt := range routes {
+		if rt.method != req.Method {
+			continue
+		}
+		if params := rt.pattern.match(req.Path); params != nil {
+			if rt.auth && !strings.HasPrefix(req.Header.Get("Authorization"), "Bot ") &&
+				!strings.HasPrefix(req.Header.Get("Authorization"), "Bearer ") {
+				writeError(w, http.StatusUnauthorized, 0, "401: Unauthorized")
+				return
+			}
+			rt.fn(s, w, req, params)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, 0, "404: Not Found")
+}
+
+type route struct {
+	method  string
+	pattern *pattern
+	auth    bool
+	fn      func(s *Server, w http.ResponseWriter, req Request, params map[string]string)
+}
+
+var routes = []route{
+	{http.MethodPost, compilePattern("/webhooks/{webhook}/{token}"), false, (*Server).executeWebhook},
+	{http.MethodGet, compilePattern("/webhooks/{webhook}/{token}/messages/{message}"), false, (*Server).getWebhookMessage},
+	{http.MethodPatch, compilePattern("/webhooks/{webhook}/{token}/messages/{message}"), false, (*Server).editWebhookMessage},
+	{http.MethodDelete, compilePattern("/webhooks/{webhook}/{token}/messages/{message}"), false, (*Server).deleteWebhookMessage},
+
+	{http.MethodGet, compilePattern("/channels/{channel}/messages"), true, (*Server).listMessages},
+	{http.MethodPost, compilePattern("/channels/{channel}/messages"), true, (*Server).createMessage},
+	{http.MethodPost, compilePattern("/channels/{channel}/messages/bulk-delete"), true, (*Server).bulkDelete},
+	{http.MethodGet, compilePattern("/channels/{channel}/messages/{message}"), true, (*Server).getMessage},
+	{http.MethodPatch, compilePattern("/channels/{channel}/messages/{message}"), true, (*Server).editMessage},
+	{http.MethodDelete, compilePattern("/channels/{channel}/messages/{message}"), true, (*Server).deleteMessage},
+
+	{http.MethodGet, compilePattern("/applications/{app}/commands"), true, (*Server).listCommands},
+	{http.MethodPost, compilePattern("/applications/{app}/commands"), true, (*Server).createCommand},
+	{http.MethodPut, compilePattern("/applications/{app}/commands"), true, (*Server).overwriteCommands},
+	{http.MethodGet, compilePattern("/applications/{app}/commands/{command}"), true, (*Server).getCommand},
+	{http.MethodPatch, compilePattern("/applications/{app}/commands/{command}"), true, (*Server).editCommand},
+	{http.MethodDelete, compilePattern("/applications/{app}/commands/{command}"), true, (*Server).deleteCommand},
+	{http.MethodGet, compilePattern("/applications/{app}/guilds/{guild}/commands"), true, (*Server).listCommands},
+	{http.MethodPost, compilePattern("/applications/{app}/guilds/{guild}/commands"), true, (*Server).createCommand},
+	{http.MethodPut, compilePattern("/applications/{app}/guilds/{guild}/commands"), true, (*Server).overwriteCommands},
+	{http.MethodGet, compilePattern("/applications/{app}/guilds/{guild}/commands/{command}"), true, (*Server).getCommand},
+	{http.MethodPatch, compilePattern("/applications/{app}/guilds/{guild}/commands/{command}"), true, (*Server).editCommand},
+	{http.MethodDelete, compilePattern("/applications/{app}/guilds/{guild}/commands/{command}"), true, (*Server).deleteCommand},
+}
+
+// newID returns a snowflake for the current time that is greater than
+// every ID handed out before. s.mu must be held.
+func (s *Server) newID() string {
+	id := types.SnowflakeFromTime(s.now())
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return id.String()
+}
+
+// idAt returns a snowflake for t, used for seeded messages. s.mu must be held.
+func (s *Server) idAt(t time.Time) string {
+	s.seq++
+	return (types.SnowflakeFromTime(t) | types.Snowflake(s.seq&0xfff)).String()
+}
+
+// pattern matches request paths against a route with {name} segments.
+type pattern struct {
+	segments []string
+}
+
+func compilePattern(p string) *pattern {
+	return &pattern{segments: strings.Split(strings.Trim(normalizePath(p), "/"), "/")}
+}
+
+// match returns the path parameters, or nil if path does not match.
+func (p *pattern) match(path string) map[string]string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != len(p.segments) {
+		return nil
+	}
+	params := make(map[string]string)
+	for i, seg := range p.segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			params[seg[1:len(seg)-1]] = segments[i]
+			continue
+		}
+		if seg != segments[i] {
+			return nil
+		}
+	}
+	return params
+}
+
+var apiPrefix = regexp.MustCompile(`^/api(/v\d+)?(/|$)`)
+
+// normalizePath strips the /api[/vN] prefix.
+func normalizePath(path string) string {
+	if loc := apiPrefix.FindStringIndex(path); loc != nil {
+		path = "/" + path[loc[1]:]
+	}
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// readRequest records r. The body is restored afterwards so Handle
+// overrides can read it.
+func readRequest(r *http.Request) (Request, error) {
+	req := Request{
+		Method: r.Method,
+		Path:   normalizePath(r.URL.Path),
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+	}
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		return req, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		req.Body = raw
+		return req, nil
+	}
+	reader := multipart.NewReader(bytes.NewReader(raw), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return req, nil
+		}
+		if err != nil {
+			return req, err
+		}
+		if part.FormName() == "payload_json" {
+			if req.Body, err = io.ReadAll(part); err != nil {
+				return req, err
+			}
+		} else if part.FileName() != "" {
+			req.Files = append(req.Files, part.FileName())
+		}
+		part.Close()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, map[string]interface{}{"code": code, "message": message})
+}
+
+func writeRateLimited(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := retryAfter.Seconds()
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(seconds))))
+	w.Header().Set("X-RateLimit-Scope", "user")
+	writeJSON(w, http.StatusTooManyRequests, map[string]interface{}{
+		"message":     "You are being rate limited.",
+		"retry_after": seconds,
+		"global":      false,
+	})
+}
@@ -0,0 +1,148 @@
+package discordtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestPatternMatch(t *testing.T) {
+	p := compilePattern("/api/v10/channels/{channel}/messages/{message}")
+	params := p.match(normalizePath("/api/v9/channels/1/messages/2"))
+	if params["channel"] != "1" || params["message"] != "2" {
+		t.Fatalf("params = %v", params)
+	}
+	if p.match("/channels/1/messages") != nil || p.match("/channels/1/pins/2") != nil {
+		t.Fatal("pattern matched a different route")
+	}
+	if got := normalizePath("/api/webhooks/1/t"); got != "/webhooks/1/t" {
+		t.Fatalf("normalizePath() = %q", got)
+	}
+}
+
+func TestRESTRoutesRequireAuthorization(t *testing.T) {
+	srv := NewServer(t)
+	resp, err := http.Get(srv.BaseURL() + "/channels/1/messages")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestUnknownRoute(t *testing.T) {
+	srv := NewServer(t)
+	err := srv.Client("token").Get(context.Background(), "/guilds/1/emojis", nil)
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("error = %v, want 404", err)
+	}
+	srv.AssertRequest(t, http.MethodGet, "/guilds/{guild}/emojis")
+}
+
+func TestHandleOverridesRoute(t *testing.T) {
+	srv := NewServer(t)
+	srv.Handle(http.MethodGet, "/guilds/{guild}", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(types.Guild{ID: "9", Name: "fake"})
+	})
+
+	var guild types.Guild
+	if err := srv.Client("token").Get(context.Background(), "/guilds/9", &guild); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if guild.Name != "fake" {
+		t.Fatalf("guild = %+v", guild)
+	}
+}
+
+func TestFailInjectsErrors(t *testing.T) {
+	srv := NewServer(t)
+	srv.Fail(http.MethodPost, "/channels/{channel}/messages", 1, http.StatusForbidden, 50013, "Missing Permissions")
+	rest := srv.Client("token")
+
+	_, err := rest.Messages().CreateMessage(context.Background(), "1", &types.MessageCreateParams{Content: "hi"})
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 50013 {
+		t.Fatalf("error = %v, want code 50013", err)
+	}
+	if _, err := rest.Messages().CreateMessage(context.Background(), "1", &types.MessageCreateParams{Content: "hi"}); err != nil {
+		t.Fatalf("second CreateMessage() error = %v", err)
+	}
+	srv.AssertMessageCount(t, "1", 1)
+}
+
+func TestRateLimitHeadersAnd429(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	srv := NewServer(t, WithClock(func() time.Time { return now }))
+	srv.RateLimit(http.MethodPost, "/channels/{channel}/messages", 1, 2*time.Second)
+
+	post := func() *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, srv.BaseURL()+"/channels/1/messages", strings.NewReader(`{"content":"hi"}`))
+		req.Header.Set("Authorization", "Bot token")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST error = %v", err)
+		}
+		return resp
+	}
+
+	first := post()
+	first.Body.Close()
+	if first.Header.Get("X-RateLimit-Remaining") != "0" || first.Header.Get("X-RateLimit-Reset-After") != "2.000" {
+		t.Fatalf("headers = %v", first.Header)
+	}
+
+	second := post()
+	defer second.Body.Close()
+	var body struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	json.NewDecoder(second.Body).Decode(&body)
+	if second.StatusCode != http.StatusTooManyRequests || body.RetryAfter != 2 || second.Header.Get("Retry-After") != "2" {
+		t.Fatalf("status = %d, retry_after = %v, headers = %v", second.StatusCode, body.RetryAfter, second.Header)
+	}
+
+	now = now.Add(2 * time.Second)
+	third := post()
+	third.Body.Close()
+	if third.StatusCode != http.StatusOK {
+		t.Fatalf("status after reset = %d", third.StatusCode)
+	}
+	srv.AssertMessageCount(t, "1", 2)
+}
+
+func TestClientHonorsRateLimit(t *testing.T) {
+	srv := NewServer(t)
+	srv.RateLimit("", "/channels/{channel}/messages", 1, 300*time.Millisecond)
+	rest := srv.Client("token")
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := rest.Messages().CreateMessage(context.Background(), "1", &types.MessageCreateParams{Content: "hi"}); err != nil {
+			t.Fatalf("CreateMessage() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("second request was not delayed: %v", elapsed)
+	}
+	srv.AssertMessageCount(t, "1", 2)
+}
+
+func TestReset(t *testing.T) {
+	srv := NewServer(t)
+	srv.AddMessage(types.Message{ChannelID: "1", Content: "old"})
+	srv.Client("token").Get(context.Background(), "/channels/1/messages", nil)
+	srv.Reset()
+
+	if len(srv.Requests()) != 0 || len(srv.Messages("1")) != 0 {
+		t.Fatalf("Reset() left requests = %v, messages = %v", srv.Requests(), srv.Messages("1"))
+	}
+}
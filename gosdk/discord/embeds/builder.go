@@ -4,15 +4,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 )
 
 const (
-	maxTitleRunes       = 256
-	maxDescriptionRunes = 4096
-	maxFieldNameRunes   = 256
-	maxFieldValueRunes  = 1024
-	maxFields           = 25
+	maxTitleRunes       = limits.EmbedTitleLength
+	maxDescriptionRunes = limits.EmbedDescriptionLength
+	maxFieldNameRunes   = limits.EmbedFieldNameLength
+	maxFieldValueRunes  = limits.EmbedFieldValueLength
+	maxFields           = limits.EmbedFields
 )
 
 // Builder provides a fluent API for constructing embeds.
@@ -0,0 +1,203 @@
+// Package files downloads message and interaction attachments from Discord's
+// CDN, with size limits and content-type checks suitable for handling
+// user-supplied uploads such as attachment command options.
+package files
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// DefaultMaxSize is the download limit when WithMaxSize is not set: the
+// largest upload a boosted guild accepts.
+const DefaultMaxSize = limits.FileSizeTier3
+
+// Downloader fetches attachment content. It is safe for concurrent use.
+type Downloader struct {
+	httpClient   *http.Client
+	maxSize      int64
+	contentTypes []string
+}
+
+// Option configures a Downloader.
+type Option func(*Downloader)
+
+// WithHTTPClient sets the HTTP client used for downloads.
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Downloader) {
+		if client != nil {
+			d.httpClient = client
+		}
+	}
+}
+
+// WithMaxSize limits downloads to bytes. Larger attachments are rejected
+// before any request is made when their size is known, and the download is
+// cut off once the limit is passed otherwise.
+func WithMaxSize(bytes int64) Option {
+	return func(d *Downloader) {
+		if bytes > 0 {
+			d.maxSize = bytes
+		}
+	}
+}
+
+// WithContentTypes restricts downloads to the given media types. An entry
+// ending in "/" matches a whole family, e.g. "image/" accepts any image.
+func WithContentTypes(contentTypes ...string) Option {
+	return func(d *Downloader) {
+		for _, ct := range contentTypes {
+			if ct = strings.ToLower(strings.TrimSpace(ct)); ct != "" {
+				d.contentTypes = append(d.contentTypes, ct)
+			}
+		}
+	}
+}
+
+// New creates a Downloader.
+func New(opts ...Option) *Downloader {
+	d := &Downloader{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		maxSize:    DefaultMaxSize,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	return d
+}
+
+// Download fetches att with a Downloader built from opts.
+func Download(ctx context.Context, att *types.Attachment, opts ...Option) ([]byte, error) {
+	return New(opts...).Download(ctx, att)
+}
+
+// Download reads the whole attachment into memory.
+func (d *Downloader) Download(ctx context.Context, att *types.Attachment) ([]byte, error) {
+	body, err := d.Open(ctx, att)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Open starts downloading att and returns its content as a stream. Reads fail
+// with a *types.ValidationError once more than the size limit has been read.
+// The caller must close the returned reader.
+func (d *Downloader) Open(ctx context.Context, att *types.Attachment) (io.ReadCloser, error) {
+	if att == nil {
+		return nil, &types.ValidationError{Field: "attachment", Message: "attachment is required"}
+	}
+	url := att.URL
+	if url == "" {
+		url = att.ProxyURL
+	}
+	if url == "" {
+		return nil, &types.ValidationError{Field: "attachment.url", Message: "attachment has no URL"}
+	}
+	if int64(att.Size) > d.maxSize {
+		return nil, d.tooLarge(att.Filename)
+	}
+	if att.ContentType != "" && !d.allowed(att.ContentType) {
+		return nil, d.disallowed(att.ContentType)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, &types.NetworkError{Op: "download", Err: err}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+			// Signed CDN URLs answer 404/403 once expired.
+			return nil, fmt.Errorf("download %s: status %d (the URL may have expired): %w", att.Filename, resp.StatusCode, types.ErrNotFound)
+		}
+		return nil, fmt.Errorf("download %s: unexpected status %d", att.Filename, resp.StatusCode)
+	}
+	if resp.ContentLength > d.maxSize {
+		resp.Body.Close()
+		return nil, d.tooLarge(att.Filename)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !d.allowed(ct) {
+		resp.Body.Close()
+		return nil, d.disallowed(ct)
+	}
+
+	return &limitedBody{body: resp.Body, remaining: d.maxSize, err: d.tooLarge(att.Filename)}, nil
+}
+
+func (d *Downloader) allowed(contentType string) bool {
+	if len(d.contentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, want := range d.contentTypes {
+		if mediaType == want || (strings.HasSuffix(want, "/") && strings.HasPrefix(mediaType, want)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Downloader) tooLarge(name string) error {
+	return &types.ValidationError{
+		Field:   "attachment.size",
+		Message: fmt.Sprintf("attachment %s exceeds maximum %d bytes", name, d.maxSize),
+	}
+}
+
+func (d *Downloader) disallowed(contentType string) error {
+	return &types.ValidationError{
+		Field:   "attachment.content_type",
+		Message: fmt.Sprintf("content type %q is not allowed", contentType),
+	}
+}
+
+// limitedBody fails reads once more than remaining bytes have been read.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, l.err
+	}
+	// Read one byte past the limit so an exact-size body still reaches EOF.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.body.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, l.err
+	}
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	return l.body.Close()
+}
@@ -0,0 +1,89 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func newFileServer(t *testing.T, contentType, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownload(t *testing.T) {
+	server := newFileServer(t, "text/plain; charset=utf-8", "hello")
+	data, err := Download(context.Background(), &types.Attachment{Filename: "a.txt", URL: server.URL + "/a.txt"})
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("Download() = %q", data)
+	}
+}
+
+func TestDownloadSizeLimit(t *testing.T) {
+	server := newFileServer(t, "text/plain", strings.Repeat("x", 64))
+	var validationErr *types.ValidationError
+
+	_, err := Download(context.Background(), &types.Attachment{URL: server.URL, Size: 64}, WithMaxSize(10))
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected size rejection before download, got %v", err)
+	}
+
+	// Without a declared size the limit is enforced while reading.
+	d := New(WithMaxSize(10), WithHTTPClient(&http.Client{Transport: &http.Transport{DisableCompression: true}}))
+	_, err = d.Download(context.Background(), &types.Attachment{URL: server.URL})
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected size error, got %v", err)
+	}
+
+	data, err := Download(context.Background(), &types.Attachment{URL: server.URL}, WithMaxSize(64))
+	if err != nil || len(data) != 64 {
+		t.Fatalf("exact-size download = %d bytes, %v", len(data), err)
+	}
+}
+
+func TestDownloadContentTypes(t *testing.T) {
+	server := newFileServer(t, "image/png", "png")
+	images := WithContentTypes("image/")
+
+	if _, err := Download(context.Background(), &types.Attachment{URL: server.URL}, images); err != nil {
+		t.Fatalf("image download error = %v", err)
+	}
+	if _, err := Download(context.Background(), &types.Attachment{URL: server.URL, ContentType: "application/zip"}, images); err == nil {
+		t.Fatal("expected declared content type to be rejected")
+	}
+	if _, err := Download(context.Background(), &types.Attachment{URL: server.URL}, WithContentTypes("text/plain")); err == nil {
+		t.Fatal("expected served content type to be rejected")
+	}
+}
+
+func TestDownloadErrors(t *testing.T) {
+	server := newFileServer(t, "text/plain", "")
+	if _, err := Download(context.Background(), nil); err == nil {
+		t.Fatal("expected error for nil attachment")
+	}
+	if _, err := Download(context.Background(), &types.Attachment{}); err == nil {
+		t.Fatal("expected error for attachment without URL")
+	}
+	_, err := Download(context.Background(), &types.Attachment{URL: server.URL + "/missing"})
+	if !errors.Is(err, types.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
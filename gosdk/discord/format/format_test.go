@@ -0,0 +1,110 @@
+package format
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{User("1"), "<@1>"},
+		{Role("2"), "<@&2>"},
+		{Channel("3"), "<#3>"},
+		{SlashCommand("config set", "4"), "</config set:4>"},
+		{Emoji("wave", "5", false), "<:wave:5>"},
+		{Emoji("wave", "5", true), "<a:wave:5>"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	ts := time.Unix(1618953630, 0)
+	if got := Timestamp(ts, ""); got != "<t:1618953630>" {
+		t.Fatalf("Timestamp() = %q", got)
+	}
+	if got := Timestamp(ts, TimestampLongDate); got != "<t:1618953630:D>" {
+		t.Fatalf("Timestamp(D) = %q", got)
+	}
+	if got := RelativeTime(ts); got != "<t:1618953630:R>" {
+		t.Fatalf("RelativeTime() = %q", got)
+	}
+}
+
+func TestCode(t *testing.T) {
+	if got := InlineCode("x := 1"); got != "`x := 1`" {
+		t.Fatalf("InlineCode() = %q", got)
+	}
+	if got := InlineCode("a`b"); got != "`` a`b ``" {
+		t.Fatalf("InlineCode() with backtick = %q", got)
+	}
+	if got := CodeBlock("go", "fmt.Println()"); got != "```go\nfmt.Println()\n```" {
+		t.Fatalf("CodeBlock() = %q", got)
+	}
+	if got := CodeBlock("", "```\nnested"); got != "```\n`\u200b``\nnested\n```" {
+		t.Fatalf("CodeBlock() with fence = %q", got)
+	}
+}
+
+func TestStyles(t *testing.T) {
+	if got := Bold(Italic("hi")); got != "***hi***" {
+		t.Fatalf("Bold(Italic()) = %q", got)
+	}
+	if got := Quote("a\nb"); got != "> a\n> b" {
+		t.Fatalf("Quote() = %q", got)
+	}
+	if got := Hyperlink("docs", "https://example.com"); got != "[docs](<https://example.com>)" {
+		t.Fatalf("Hyperlink() = %q", got)
+	}
+	if got := Spoiler(Strikethrough(Underline("x"))); got != "||~~__x__~~||" {
+		t.Fatalf("nested styles = %q", got)
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{"**bold** _it_ ~~s~~ `c` ||sp||", `\*\*bold\*\* \_it\_ \~\~s\~\~ \` + "`c\\`" + ` \|\|sp\|\|`},
+		{"# heading\n- item\n> quote", "\\# heading\n\\- item\n\\> quote"},
+		{"a - b # c > d", "a - b # c > d"},
+		{"[x](y)", `\[x\](y)`},
+		{`back\slash`, `back\\slash`},
+	}
+	for _, tt := range tests {
+		if got := EscapeMarkdown(tt.in); got != tt.want {
+			t.Errorf("EscapeMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeMentions(t *testing.T) {
+	got := EscapeMentions("@everyone <@1> <#2>")
+	want := "@\u200beveryone <@\u200b1> <#\u200b2>"
+	if got != want {
+		t.Fatalf("EscapeMentions() = %q, want %q", got, want)
+	}
+}
+
+func TestAllowedMentionsFor(t *testing.T) {
+	am := AllowedMentionsFor("@everyone <@1> <@!1> <@&2> <#3> <@4>")
+	data, err := json.Marshal(am)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if got := string(data); got != `{"roles":["2"],"users":["1","4"]}` {
+		t.Fatalf("AllowedMentionsFor() = %s", got)
+	}
+
+	none, _ := json.Marshal(AllowedMentionsFor("@here hello"))
+	if string(none) != `{}` {
+		t.Fatalf("expected empty policy, got %s", none)
+	}
+}
@@ -0,0 +1,118 @@
+// Package format builds the Discord markdown fragments messages are made of:
+// mentions, timestamps, code blocks, and escaped user text.
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// zeroWidthSpace breaks up sequences Discord would otherwise interpret.
+const zeroWidthSpace = "\u200b"
+
+// TimestampStyle selects how a <t:...> timestamp renders in the client.
+type TimestampStyle string
+
+const (
+	TimestampShortTime     TimestampStyle = "t" // 16:20
+	TimestampLongTime      TimestampStyle = "T" // 16:20:30
+	TimestampShortDate     TimestampStyle = "d" // 20/04/2021
+	TimestampLongDate      TimestampStyle = "D" // 20 April 2021
+	TimestampShortDateTime TimestampStyle = "f" // 20 April 2021 16:20 (default)
+	TimestampLongDateTime  TimestampStyle = "F" // Tuesday, 20 April 2021 16:20
+	TimestampRelative      TimestampStyle = "R" // 2 months ago
+)
+
+// Timestamp renders t in each reader's local time zone. An empty style uses
+// Discord's default (short date/time).
+func Timestamp(t time.Time, style TimestampStyle) string {
+	unix := strconv.FormatInt(t.Unix(), 10)
+	if style == "" {
+		return "<t:" + unix + ">"
+	}
+	return "<t:" + unix + ":" + string(style) + ">"
+}
+
+// RelativeTime renders t as "in 5 minutes" / "2 hours ago".
+func RelativeTime(t time.Time) string {
+	return Timestamp(t, TimestampRelative)
+}
+
+// Bold wraps text in **.
+func Bold(text string) string { return "**" + text + "**" }
+
+// Italic wraps text in *.
+func Italic(text string) string { return "*" + text + "*" }
+
+// Underline wraps text in __.
+func Underline(text string) string { return "__" + text + "__" }
+
+// Strikethrough wraps text in ~~.
+func Strikethrough(text string) string { return "~~" + text + "~~" }
+
+// Spoiler hides text behind ||.
+func Spoiler(text string) string { return "||" + text + "||" }
+
+// Quote prefixes every line of text with "> ".
+func Quote(text string) string {
+	return "> " + strings.ReplaceAll(text, "\n", "\n> ")
+}
+
+// Hyperlink builds a masked link. Discord only renders masked links in
+// embeds, webhook messages, and interaction responses.
+func Hyperlink(text, url string) string {
+	return fmt.Sprintf("[%s](<%s>)", text, url)
+}
+
+// InlineCode wraps text in backticks, using a double-backtick fence when text
+// itself contains one.
+func InlineCode(text string) string {
+	if !strings.Contains(text, "`") {
+		return "`" + text + "`"
+	}
+	// Pad with spaces so a leading or trailing backtick doesn't merge into
+	// the fence.
+	return "`` " + strings.ReplaceAll(text, "``", "`"+zeroWidthSpace+"`") + " ``"
+}
+
+// CodeBlock wraps code in a fenced block with optional syntax highlighting.
+// Fences inside code are broken up so they can't end the block early.
+func CodeBlock(language, code string) string {
+	code = strings.ReplaceAll(code, "```", "`"+zeroWidthSpace+"``")
+	if !strings.HasSuffix(code, "\n") {
+		code += "\n"
+	}
+	return "```" + language + "\n" + code + "```"
+}
+
+// inlineMarkdown lists characters that format text anywhere in a line.
+const inlineMarkdown = "\\*_~`|[]"
+
+// EscapeMarkdown backslash-escapes markdown in user-provided text so it
+// renders literally. Headings, lists, and quotes are only escaped at the
+// start of a line, where Discord interprets them.
+func EscapeMarkdown(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	lineStart := true
+	for _, r := range text {
+		if lineStart && strings.ContainsRune("#->", r) {
+			b.WriteByte('\\')
+		} else if strings.ContainsRune(inlineMarkdown, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+		lineStart = r == '\n' || (lineStart && (r == ' ' || r == '\t'))
+	}
+	return b.String()
+}
+
+// EscapeMentions defuses @everyone, @here, and user, role, and channel
+// mentions in text so they render as plain text even without an
+// allowed_mentions policy.
+func EscapeMentions(text string) string {
+	text = strings.ReplaceAll(text, "@", "@"+zeroWidthSpace)
+	return strings.ReplaceAll(text, "<#", "<#"+zeroWidthSpace)
+}
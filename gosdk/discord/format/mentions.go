@@ -0,0 +1,61 @@
+package format
+
+import (
+	"regexp"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// User mentions a user.
+func User(userID string) string { return "<@" + userID + ">" }
+
+// Role mentions a role.
+func Role(roleID string) string { return "<@&" + roleID + ">" }
+
+// Channel links a channel.
+func Channel(channelID string) string { return "<#" + channelID + ">" }
+
+// SlashCommand renders a clickable slash command. name may include
+// subcommands separated by spaces ("config set").
+func SlashCommand(name, commandID string) string {
+	return "</" + name + ":" + commandID + ">"
+}
+
+// Emoji renders a custom emoji.
+func Emoji(name, emojiID string, animated bool) string {
+	if animated {
+		return "<a:" + name + ":" + emojiID + ">"
+	}
+	return "<:" + name + ":" + emojiID + ">"
+}
+
+var (
+	userMentionPattern = regexp.MustCompile(`<@!?(\d+)>`)
+	roleMentionPattern = regexp.MustCompile(`<@&(\d+)>`)
+)
+
+// AllowedMentionsFor returns an allowed_mentions policy that pings exactly the
+// users and roles mentioned with <@id> and <@&id> in content, and never
+// @everyone or @here. Only pass content you wrote: any mention inside
+// user-provided text would be allowed to ping too. For mixed content, build
+// the policy from the IDs you added yourself with types.NewAllowedMentions.
+// The result is never nil; a message with no mentions gets an empty policy,
+// which suppresses all pings.
+func AllowedMentionsFor(content string) *types.AllowedMentions {
+	return &types.AllowedMentions{
+		Users: matchIDs(userMentionPattern, content),
+		Roles: matchIDs(roleMentionPattern, content),
+	}
+}
+
+func matchIDs(pattern *regexp.Regexp, content string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range pattern.FindAllStringSubmatch(content, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			ids = append(ids, m[1])
+		}
+	}
+	return ids
+}
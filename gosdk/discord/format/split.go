@@ -0,0 +1,172 @@
+package format
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+const (
+	fence = "```"
+	// closeFence is appended to a chunk that ends inside a code block.
+	closeFence = "\n" + fence
+)
+
+// DefaultLongMessageMax is the number of split messages the clients'
+// SendLong methods send before switching to a file upload.
+const DefaultLongMessageMax = 5
+
+// DefaultLongMessageFileName names the file SendLong uploads.
+const DefaultLongMessageFileName = "message.txt"
+
+// LongMessageOptions controls how the clients' SendLong methods deliver
+// oversized content.
+type LongMessageOptions struct {
+	// MaxMessages is the number of messages past which the content is
+	// uploaded as a text file instead. Zero uses DefaultLongMessageMax and a
+	// negative value always splits.
+	MaxMessages int
+	// FileName names the uploaded file. Empty uses DefaultLongMessageFileName.
+	FileName string
+}
+
+// Upload reports whether content split into parts messages should be
+// uploaded as a file instead, and the file's name. o may be nil.
+func (o *LongMessageOptions) Upload(parts int) (string, bool) {
+	var opts LongMessageOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.MaxMessages == 0 {
+		opts.MaxMessages = DefaultLongMessageMax
+	}
+	if opts.MaxMessages < 0 || parts <= opts.MaxMessages {
+		return "", false
+	}
+	if opts.FileName == "" {
+		opts.FileName = DefaultLongMessageFileName
+	}
+	return opts.FileName, true
+}
+
+// SplitMessage splits content into chunks of at most maxLen bytes (values
+// below 1 use limits.MessageContentLength). Splits fall on line boundaries
+// where possible, then on spaces, and never inside a UTF-8 sequence. A code
+// block cut by a split is closed at the end of one chunk and reopened, with
+// its language, at the start of the next. Whitespace-only chunks are dropped.
+func SplitMessage(content string, maxLen int) []string {
+	if maxLen < 1 {
+		maxLen = limits.MessageContentLength
+	}
+	if len(content) <= maxLen {
+		if strings.TrimSpace(content) == "" {
+			return nil
+		}
+		return []string{content}
+	}
+
+	s := &splitter{max: maxLen}
+	for _, line := range strings.SplitAfter(content, "\n") {
+		s.add(line)
+	}
+	return s.finish()
+}
+
+type splitter struct {
+	max     int
+	chunks  []string
+	current strings.Builder
+	// prefixLen is the length of a reopened code fence at the start of
+	// current; a chunk holding only that prefix has nothing to flush.
+	prefixLen int
+	inFence   bool
+	lang      string
+}
+
+func (s *splitter) add(line string) {
+	for line != "" {
+		inFence, lang := fenceState(line, s.inFence, s.lang)
+		reserve := 0
+		if inFence || s.inFence {
+			reserve = len(closeFence)
+		}
+		if s.current.Len()+len(line)+reserve > s.max && s.pending() {
+			s.flush()
+			continue
+		}
+		if room := s.max - s.current.Len() - reserve; len(line) > room {
+			head := cut(line, room)
+			s.current.WriteString(head)
+			line = line[len(head):]
+			s.flush()
+			continue
+		}
+		s.current.WriteString(line)
+		s.inFence, s.lang = inFence, lang
+		return
+	}
+}
+
+func (s *splitter) pending() bool {
+	return s.current.Len() > s.prefixLen
+}
+
+func (s *splitter) flush() {
+	chunk := strings.TrimRight(s.current.String(), "\n")
+	if s.inFence {
+		chunk += closeFence
+	}
+	if strings.TrimSpace(chunk) != "" {
+		s.chunks = append(s.chunks, chunk)
+	}
+	s.current.Reset()
+	s.prefixLen = 0
+	if s.inFence {
+		s.current.WriteString(fence + s.lang + "\n")
+		s.prefixLen = s.current.Len()
+	}
+}
+
+func (s *splitter) finish() []string {
+	if s.pending() {
+		s.flush()
+	}
+	return s.chunks
+}
+
+// fenceState reports whether a code block is open after line, and its
+// language, given the state before it.
+func fenceState(line string, inFence bool, lang string) (bool, string) {
+	if strings.Count(line, fence)%2 == 0 {
+		return inFence, lang
+	}
+	if inFence {
+		return false, ""
+	}
+	trimmed := strings.TrimSpace(line)
+	if rest, ok := strings.CutPrefix(trimmed, fence); ok && !strings.ContainsAny(rest, " \t`") {
+		return true, rest
+	}
+	return true, ""
+}
+
+// cut returns the longest prefix of s no longer than n bytes, preferring to
+// end after a space. It always returns at least one rune.
+func cut(s string, n int) string {
+	if n >= len(s) {
+		return s
+	}
+	end := max(n, 0)
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	if end == 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		return s[:size]
+	}
+	if i := strings.LastIndexAny(s[:end], " \t"); i > end/2 {
+		end = i + 1
+	}
+	return s[:end]
+}
@@ -0,0 +1,98 @@
+package format
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitMessageShortContent(t *testing.T) {
+	if got := SplitMessage("hello", 0); len(got) != 1 || got[0] != "hello" {
+		t.Fatalf("SplitMessage(short) = %q", got)
+	}
+	if got := SplitMessage("  \n ", 0); got != nil {
+		t.Fatalf("SplitMessage(blank) = %q, want nil", got)
+	}
+}
+
+func TestSplitMessageLines(t *testing.T) {
+	content := strings.Repeat("line of text\n", 300)
+	chunks := SplitMessage(content, 0)
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %d", len(chunks))
+	}
+	var joined []string
+	for _, chunk := range chunks {
+		if len(chunk) > 2000 {
+			t.Fatalf("chunk length %d exceeds limit", len(chunk))
+		}
+		if strings.HasPrefix(chunk, "\n") || strings.HasSuffix(chunk, "\n") {
+			t.Fatalf("chunk should be trimmed of newlines: %q", chunk[:20])
+		}
+		joined = append(joined, chunk)
+	}
+	if got := strings.Join(joined, "\n") + "\n"; got != content {
+		t.Fatal("rejoined chunks do not match original content")
+	}
+}
+
+func TestSplitMessageCodeBlocks(t *testing.T) {
+	content := "intro\n```go\n" + strings.Repeat("fmt.Println(\"hi\")\n", 20) + "```\noutro"
+	chunks := SplitMessage(content, 120)
+	if len(chunks) < 3 {
+		t.Fatalf("expected several chunks, got %d", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if len(chunk) > 120 {
+			t.Fatalf("chunk %d length %d exceeds limit", i, len(chunk))
+		}
+		if strings.Count(chunk, "```")%2 != 0 {
+			t.Fatalf("chunk %d has unbalanced fences: %q", i, chunk)
+		}
+	}
+	if !strings.HasPrefix(chunks[1], "```go\n") {
+		t.Fatalf("continued code block should reopen with its language: %q", chunks[1])
+	}
+	if last := chunks[len(chunks)-1]; !strings.HasSuffix(last, "outro") {
+		t.Fatalf("last chunk = %q", last)
+	}
+}
+
+func TestSplitMessageLongLines(t *testing.T) {
+	words := strings.Repeat("word ", 100)
+	for _, chunk := range SplitMessage(words, 64) {
+		if len(chunk) > 64 {
+			t.Fatalf("chunk length %d exceeds limit", len(chunk))
+		}
+		if strings.HasSuffix(strings.TrimSpace(chunk), "wo") {
+			t.Fatalf("chunk split mid-word: %q", chunk)
+		}
+	}
+
+	runes := strings.Repeat("é", 100)
+	for _, chunk := range SplitMessage(runes, 15) {
+		if !utf8.ValidString(chunk) || len(chunk) > 15 {
+			t.Fatalf("invalid chunk %q", chunk)
+		}
+	}
+}
+
+func TestLongMessageOptionsUpload(t *testing.T) {
+	tests := []struct {
+		opts  *LongMessageOptions
+		parts int
+		name  string
+		want  bool
+	}{
+		{nil, DefaultLongMessageMax, "", false},
+		{nil, DefaultLongMessageMax + 1, DefaultLongMessageFileName, true},
+		{&LongMessageOptions{MaxMessages: 2, FileName: "log.txt"}, 3, "log.txt", true},
+		{&LongMessageOptions{MaxMessages: -1}, 100, "", false},
+	}
+	for _, tt := range tests {
+		name, upload := tt.opts.Upload(tt.parts)
+		if name != tt.name || upload != tt.want {
+			t.Errorf("%+v.Upload(%d) = %q, %v; want %q, %v", tt.opts, tt.parts, name, upload, tt.name, tt.want)
+		}
+	}
+}
@@ -12,20 +12,6 @@ import (
 	"github.com/mtreilly/godiscord/gosdk/logger"
 )
 
-// Activity represents a Discord presence activity.
-type Activity struct {
-	Name string `json:"name"`
-	Type int    `json:"type"`
-}
-
-// PresenceUpdate describes the payload sent to the gateway.
-type PresenceUpdate struct {
-	Since      *int       `json:"since,omitempty"`
-	Activities []Activity `json:"activities,omitempty"`
-	Status     string     `json:"status,omitempty"`
-	AFK        bool       `json:"afk"`
-}
-
 // ClientOption configures the gateway client.
 type ClientOption func(*Client)
 
@@ -39,10 +25,10 @@ func WithDispatcher(d *Dispatcher) ClientOption {
 }
 
 // WithGatewayLogger overrides the logger.
-func WithGatewayLogger(l *logger.Logger) ClientOption {
+func WithGatewayLogger(l logger.Interface) ClientOption {
 	return func(c *Client) {
-		if l != nil {
-			c.logger = l
+		if lg := logger.From(l); lg != nil {
+			c.logger = lg
 		}
 	}
 }
@@ -56,6 +42,15 @@ func WithConnection(conn *Connection) ClientOption {
 	}
 }
 
+// WithTransport replaces the websocket connection with a custom transport.
+func WithTransport(t Transport) ClientOption {
+	return func(c *Client) {
+		if t != nil {
+			c.conn = t
+		}
+	}
+}
+
 // WithConnectionOptions augments the underlying connection options.
 func WithConnectionOptions(opts ...ConnectionOption) ClientOption {
 	return func(c *Client) {
@@ -67,16 +62,25 @@ func WithConnectionOptions(opts ...ConnectionOption) ClientOption {
 type Client struct {
 	token          string
 	intents        int
-	conn           *Connection
+	conn           Transport
+	session        Session
+	heartbeater    *Heartbeater
 	dispatcher     *Dispatcher
 	logger         *logger.Logger
-	status         string
-	activity       *Activity
+	presence       *PresenceUpdate
 	connectionOpts []ConnectionOption
+	reconnect      ReconnectPolicy
+	stateListeners []func(StateChange)
+	state          ConnectionState
+	err            error
 
 	eventCancel context.CancelFunc
 	wg          sync.WaitGroup
 	mu          sync.RWMutex
+
+	// sharded is set for ShardManager shards. The manager owns their shared
+	// dispatcher, closing it and checking intents once for every shard.
+	sharded bool
 }
 
 // NewClient builds a gateway client configured with the given token and intents.
@@ -93,11 +97,13 @@ func NewClient(token string, intents int, opts ...ClientOption) (*Client, error)
 		intents:    intents,
 		dispatcher: NewDispatcher(),
 		logger:     logger.Default(),
+		reconnect:  DefaultReconnectPolicy(),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.logger = c.logger.WithSubsystem(logger.SubsystemGateway)
 
 	if c.conn == nil {
 		conn, err := NewConnection(token, intents, c.connectionOpts...)
@@ -106,6 +112,7 @@ func NewClient(token string, intents int, opts ...ClientOption) (*Client, error)
 		}
 		c.conn = conn
 	}
+	c.heartbeater = NewHeartbeater(c.conn.Send, c.session.Sequence, c.logger)
 
 	return c, nil
 }
@@ -119,11 +126,19 @@ func (c *Client) Connect(ctx context.Context) error {
 		return types.ErrAlreadyConnected
 	}
 
+	if !c.sharded {
+		logIntentWarnings(c.logger, c.CheckIntents())
+		c.dispatcher.start()
+	}
+
 	runCtx, cancel := context.WithCancel(ctx)
 	c.eventCancel = cancel
+	c.setState(StateChange{State: StateConnecting})
 
 	if err := c.conn.Connect(runCtx); err != nil {
 		cancel()
+		c.eventCancel = nil
+		c.setState(StateChange{State: StateDisconnected, Err: err})
 		return err
 	}
 
@@ -134,78 +149,88 @@ func (c *Client) Connect(ctx context.Context) error {
 		c.logger.Warn("identify failed", "error", err)
 		cancel()
 		c.wg.Wait()
+		c.eventCancel = nil
+		c.setState(StateChange{State: StateDisconnected, Err: err})
 		return err
 	}
 
-	if c.status != "" || c.activity != nil {
-		if err := c.UpdatePresence(runCtx, c.status, c.activity); err != nil {
-			c.logger.Warn("restore presence failed", "error", err)
-		}
-	}
+	c.restorePresence(runCtx)
 
 	return nil
 }
 
-// Disconnect closes the gateway connection and waits for the read loop.
+// Disconnect closes the gateway connection, waits for the read loop and
+// closes the dispatcher, so events already queued for workers are handled
+// before it returns. Connect starts the dispatcher's workers again.
 func (c *Client) Disconnect() error {
 	if c.eventCancel != nil {
 		c.eventCancel()
 		c.eventCancel = nil
 	}
 	c.wg.Wait()
+	if !c.sharded {
+		c.dispatcher.Close()
+	}
 	if c.conn == nil {
 		return nil
 	}
-	return c.conn.Close()
+	c.heartbeater.Stop()
+	err := c.conn.Close()
+	if c.State() != StateDisconnected {
+		c.setState(StateChange{State: StateDisconnected})
+	}
+	return err
 }
 
 // On registers a generic event handler.
-func (c *Client) On(eventType string, handler EventHandler) {
-	c.dispatcher.On(eventType, handler)
+func (c *Client) On(eventType string, handler EventHandler, opts ...HandlerOption) HandlerID {
+	return c.dispatcher.On(eventType, handler, opts...)
+}
+
+// Once registers a handler that is removed after its first invocation.
+func (c *Client) Once(eventType string, handler EventHandler, opts ...HandlerOption) HandlerID {
+	return c.dispatcher.Once(eventType, handler, opts...)
+}
+
+// Off removes a handler registered with On or Once.
+func (c *Client) Off(id HandlerID) bool {
+	return c.dispatcher.Off(id)
 }
 
 // OnMessageCreate registers a MESSAGE_CREATE handler.
-func (c *Client) OnMessageCreate(handler func(context.Context, *MessageCreateEvent) error) {
-	c.dispatcher.OnMessageCreate(handler)
+func (c *Client) OnMessageCreate(handler func(context.Context, *MessageCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return c.dispatcher.OnMessageCreate(handler, opts...)
 }
 
 // OnMessageUpdate registers a MESSAGE_UPDATE handler.
-func (c *Client) OnMessageUpdate(handler func(context.Context, *MessageUpdateEvent) error) {
-	c.dispatcher.OnMessageUpdate(handler)
+func (c *Client) OnMessageUpdate(handler func(context.Context, *MessageUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return c.dispatcher.OnMessageUpdate(handler, opts...)
 }
 
 // OnInteraction registers an INTERACTION_CREATE handler.
-func (c *Client) OnInteraction(handler func(context.Context, *InteractionCreateEvent) error) {
-	c.dispatcher.OnInteraction(handler)
+func (c *Client) OnInteraction(handler func(context.Context, *InteractionCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return c.dispatcher.OnInteraction(handler, opts...)
 }
 
-// UpdatePresence sends a presence update to the gateway and remembers the desired state.
-func (c *Client) UpdatePresence(ctx context.Context, status string, activity *Activity) error {
-	c.mu.Lock()
-	c.status = status
-	c.activity = activity
-	c.mu.Unlock()
-
-	if c.conn == nil {
-		return types.ErrNotConnected
-	}
+// OnRaw registers a fallback handler for dispatches without a typed decoder.
+func (c *Client) OnRaw(handler func(context.Context, *RawEvent) error, opts ...HandlerOption) HandlerID {
+	return c.dispatcher.OnRaw(handler, opts...)
+}
 
-	update := PresenceUpdate{
-		Status: status,
-		AFK:    false,
-	}
-	if activity != nil {
-		update.Activities = []Activity{*activity}
-	}
+// Intents returns the intents sent when identifying.
+func (c *Client) Intents() Intent {
+	return Intent(c.intents)
+}
 
-	payload := &Payload{Op: OpCodePresenceUpdate}
-	raw, err := json.Marshal(update)
-	if err != nil {
-		return fmt.Errorf("marshal presence update: %w", err)
-	}
-	payload.D = raw
+// CheckIntents compares the registered handlers with the configured intents.
+// Connect logs the same warnings.
+func (c *Client) CheckIntents() []IntentWarning {
+	return CheckIntents(c.Intents(), c.dispatcher.EventTypes())
+}
 
-	return c.conn.Send(ctx, payload)
+// Dispatcher exposes the underlying dispatcher for typed handler registration.
+func (c *Client) Dispatcher() *Dispatcher {
+	return c.dispatcher
 }
 
 // RequestGuildMembers sends a GUILD_MEMBERS request to the gateway.
@@ -269,11 +294,16 @@ func (c *Client) run(ctx context.Context) {
 	for {
 		payload, err := c.conn.Receive(ctx)
 		if err != nil {
-			if ctx.Err() == nil {
-				c.logger.Warn("gateway receive failed", "error", err)
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn("gateway receive failed", "error", err)
+			if !c.recover(ctx, err) {
+				return
 			}
-			return
+			continue
 		}
+		c.session.Observe(payload)
 
 		switch payload.Op {
 		case OpCodeDispatch:
@@ -281,9 +311,12 @@ func (c *Client) run(ctx context.Context) {
 		case OpCodeHello:
 			c.handleHello(ctx, payload)
 		case OpCodeReconnect:
-			go c.handleReconnect(ctx)
+			c.logger.Info("gateway requested reconnect")
+			if !c.recover(ctx, errReconnectRequested) {
+				return
+			}
 		case OpCodeInvalidSession:
-			c.conn.SetSession("")
+			c.session.Reset()
 			if err := c.identify(ctx); err != nil {
 				c.logger.Warn("identify after invalid session failed", "error", err)
 			}
@@ -301,8 +334,13 @@ func (c *Client) handleDispatch(ctx context.Context, payload *Payload) {
 		return
 	}
 
-	if ready, ok := event.(*ReadyEvent); ok && ready.SessionID != "" {
-		c.conn.SetSession(ready.SessionID)
+	if ready, ok := event.(*ReadyEvent); ok {
+		if ready.SessionID != "" {
+			c.session.SetID(ready.SessionID)
+		}
+		c.setState(StateChange{State: StateConnected})
+	} else if payload.T == EventResumed {
+		c.setState(StateChange{State: StateConnected, Resumed: true})
 	}
 
 	if err := c.dispatcher.Dispatch(ctx, event); err != nil {
@@ -319,82 +357,101 @@ func (c *Client) handleHello(ctx context.Context, payload *Payload) {
 		return
 	}
 	if hello.HeartbeatInterval > 0 {
-		c.conn.heartbeatInterval = time.Duration(hello.HeartbeatInterval) * time.Millisecond
-		c.conn.stopHeartbeat()
-		c.conn.startHeartbeat(ctx)
+		interval := time.Duration(hello.HeartbeatInterval) * time.Millisecond
+		if ht, ok := c.conn.(HeartbeatTransport); ok {
+			ht.StartHeartbeat(ctx, interval, c.session.Sequence)
+		} else {
+			c.heartbeater.Start(ctx, interval)
+		}
 	}
 }
 
-func (c *Client) handleReconnect(ctx context.Context) {
-	c.logger.Info("gateway requested reconnect")
-	if err := c.conn.reconnect(ctx); err != nil {
-		c.logger.Warn("reconnect failed", "error", err)
-		return
+// recover runs the reconnect loop and reports whether the read loop should
+// continue. When reconnecting gives up the client moves to StateDisconnected.
+func (c *Client) recover(ctx context.Context, cause error) bool {
+	err := c.reconnectLoop(ctx, cause)
+	if err == nil {
+		return true
 	}
-	if err := c.identify(ctx); err != nil {
-		c.logger.Warn("identify after reconnect failed", "error", err)
-		return
-	}
-	if err := c.UpdatePresence(ctx, c.status, c.activity); err != nil {
-		c.logger.Warn("restore presence failed", "error", err)
+	if ctx.Err() == nil {
+		c.logger.Error("gateway reconnect abandoned", "error", err)
+		c.setState(StateChange{State: StateDisconnected, Err: err})
 	}
+	return false
+}
+
+// eventDecoders maps dispatch names to constructors for their typed events.
+var eventDecoders = map[string]func() Event{
+	EventReady:                 func() Event { return &ReadyEvent{} },
+	EventMessageCreate:         func() Event { return &MessageCreateEvent{Message: &types.Message{}} },
+	EventMessageUpdate:         func() Event { return &MessageUpdateEvent{Message: &types.Message{}} },
+	EventMessageDelete:         func() Event { return &MessageDeleteEvent{} },
+	EventGuildCreate:           func() Event { return &GuildCreateEvent{Guild: &types.Guild{}} },
+	EventGuildUpdate:           func() Event { return &GuildUpdateEvent{Guild: &types.Guild{}} },
+	EventGuildDelete:           func() Event { return &GuildDeleteEvent{} },
+	EventInteractionCreate:     func() Event { return &InteractionCreateEvent{Interaction: &types.Interaction{}} },
+	EventGuildMemberAdd:        func() Event { return &GuildMemberAddEvent{Member: &types.Member{}} },
+	EventGuildMemberUpdate:     func() Event { return &GuildMemberUpdateEvent{Member: &types.Member{}} },
+	EventGuildMemberRemove:     func() Event { return &GuildMemberRemoveEvent{} },
+	EventGuildMembersChunk:     func() Event { return &GuildMembersChunkEvent{} },
+	EventGuildRoleCreate:       func() Event { return &GuildRoleCreateEvent{} },
+	EventGuildRoleUpdate:       func() Event { return &GuildRoleUpdateEvent{} },
+	EventGuildRoleDelete:       func() Event { return &GuildRoleDeleteEvent{} },
+	EventGuildBanAdd:           func() Event { return &GuildBanAddEvent{} },
+	EventGuildBanRemove:        func() Event { return &GuildBanRemoveEvent{} },
+	EventGuildEmojisUpdate:     func() Event { return &GuildEmojisUpdateEvent{} },
+	EventGuildStickersUpdate:   func() Event { return &GuildStickersUpdateEvent{} },
+	EventMessageReactionAdd:    func() Event { return &MessageReactionAddEvent{} },
+	EventMessageReactionRemove: func() Event { return &MessageReactionRemoveEvent{} },
+	EventChannelCreate:         func() Event { return &ChannelCreateEvent{Channel: &types.Channel{}} },
+	EventChannelUpdate:         func() Event { return &ChannelUpdateEvent{Channel: &types.Channel{}} },
+	EventChannelDelete:         func() Event { return &ChannelDeleteEvent{Channel: &types.Channel{}} },
+	EventThreadCreate:          func() Event { return &ThreadCreateEvent{Channel: &types.Channel{}} },
+	EventThreadUpdate:          func() Event { return &ThreadUpdateEvent{Channel: &types.Channel{}} },
+	EventThreadDelete:          func() Event { return &ThreadDeleteEvent{} },
+	EventThreadListSync:        func() Event { return &ThreadListSyncEvent{} },
+	EventThreadMemberUpdate:    func() Event { return &ThreadMemberUpdateEvent{ThreadMember: &types.ThreadMember{}} },
+	EventThreadMembersUpdate:   func() Event { return &ThreadMembersUpdateEvent{} },
+	EventVoiceStateUpdate:      func() Event { return &VoiceStateUpdateEvent{VoiceState: &types.VoiceState{}} },
+	EventPresenceUpdate:        func() Event { return &PresenceUpdateEvent{} },
+	EventTypingStart:           func() Event { return &TypingStartEvent{} },
+	EventAutoModerationRuleCreate: func() Event {
+		return &AutoModerationRuleCreateEvent{AutoModerationRule: &types.AutoModerationRule{}}
+	},
+	EventAutoModerationRuleUpdate: func() Event {
+		return &AutoModerationRuleUpdateEvent{AutoModerationRule: &types.AutoModerationRule{}}
+	},
+	EventAutoModerationRuleDelete: func() Event {
+		return &AutoModerationRuleDeleteEvent{AutoModerationRule: &types.AutoModerationRule{}}
+	},
+	EventAutoModerationActionExecution: func() Event {
+		return &AutoModerationActionExecutionEvent{AutoModerationActionExecution: &types.AutoModerationActionExecution{}}
+	},
+	EventStageInstanceCreate:   func() Event { return &StageInstanceCreateEvent{StageInstance: &types.StageInstance{}} },
+	EventStageInstanceUpdate:   func() Event { return &StageInstanceUpdateEvent{StageInstance: &types.StageInstance{}} },
+	EventStageInstanceDelete:   func() Event { return &StageInstanceDeleteEvent{StageInstance: &types.StageInstance{}} },
+	EventMessagePollVoteAdd:    func() Event { return &MessagePollVoteAddEvent{} },
+	EventMessagePollVoteRemove: func() Event { return &MessagePollVoteRemoveEvent{} },
 }
 
+// decodeEvent converts a dispatch payload into its typed event. Dispatches
+// without a registered decoder are surfaced as *RawEvent.
 func decodeEvent(payload *Payload) (Event, error) {
 	if payload == nil || payload.Op != OpCodeDispatch || payload.T == "" {
 		return nil, nil
 	}
 
-	switch payload.T {
-	case EventReady:
-		var evt ReadyEvent
-		if err := json.Unmarshal(payload.D, &evt); err != nil {
-			return nil, err
-		}
-		return &evt, nil
-	case EventMessageCreate:
-		var msg types.Message
-		if err := json.Unmarshal(payload.D, &msg); err != nil {
-			return nil, err
-		}
-		return &MessageCreateEvent{Message: &msg}, nil
-	case EventMessageUpdate:
-		var msg types.Message
-		if err := json.Unmarshal(payload.D, &msg); err != nil {
-			return nil, err
-		}
-		return &MessageUpdateEvent{Message: &msg}, nil
-	case EventMessageDelete:
-		var evt MessageDeleteEvent
-		if err := json.Unmarshal(payload.D, &evt); err != nil {
-			return nil, err
-		}
-		return &evt, nil
-	case EventGuildCreate:
-		var guild types.Guild
-		if err := json.Unmarshal(payload.D, &guild); err != nil {
-			return nil, err
-		}
-		return &GuildCreateEvent{Guild: &guild}, nil
-	case EventGuildUpdate:
-		var guild types.Guild
-		if err := json.Unmarshal(payload.D, &guild); err != nil {
-			return nil, err
-		}
-		return &GuildUpdateEvent{Guild: &guild}, nil
-	case EventGuildDelete:
-		var evt GuildDeleteEvent
-		if err := json.Unmarshal(payload.D, &evt); err != nil {
-			return nil, err
-		}
-		return &evt, nil
-	case EventInteractionCreate:
-		var interaction types.Interaction
-		if err := json.Unmarshal(payload.D, &interaction); err != nil {
-			return nil, err
-		}
-		return &InteractionCreateEvent{Interaction: &interaction}, nil
-	default:
-		return nil, nil
+	factory, ok := eventDecoders[payload.T]
+	if !ok {
+		return &RawEvent{EventType: payload.T, Data: payload.D}, nil
+	}
+
+	event := factory()
+	if len(payload.D) == 0 {
+		return event, nil
+	}
+	if err := json.Unmarshal(payload.D, event); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", payload.T, err)
 	}
+	return event, nil
 }
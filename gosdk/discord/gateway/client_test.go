@@ -26,13 +26,117 @@ func TestDecodeEventReady(t *testing.T) {
 }
 
 func TestDecodeEventUnknown(t *testing.T) {
-	payload := &Payload{Op: OpCodeDispatch, T: "UNKNOWN"}
+	payload := &Payload{Op: OpCodeDispatch, T: "UNKNOWN", D: json.RawMessage(`{"a":1}`)}
 	event, err := decodeEvent(payload)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if event != nil {
-		t.Fatalf("expected nil event, got %T", event)
+	raw, ok := event.(*RawEvent)
+	if !ok {
+		t.Fatalf("expected RawEvent, got %T", event)
+	}
+	if raw.Type() != "UNKNOWN" || string(raw.Data) != `{"a":1}` {
+		t.Fatalf("unexpected raw event: %+v", raw)
+	}
+}
+
+func TestDecodeEventTyped(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		check func(Event) bool
+	}{
+		{EventGuildMemberAdd, `{"guild_id":"g1","user":{"id":"u1"},"roles":["r1"]}`, func(e Event) bool {
+			evt, ok := e.(*GuildMemberAddEvent)
+			return ok && evt.GuildID == "g1" && evt.User.ID == "u1" && len(evt.Roles) == 1
+		}},
+		{EventGuildMemberRemove, `{"guild_id":"g1","user":{"id":"u1"}}`, func(e Event) bool {
+			evt, ok := e.(*GuildMemberRemoveEvent)
+			return ok && evt.User.ID == "u1"
+		}},
+		{EventGuildMembersChunk, `{"guild_id":"g1","members":[{"user":{"id":"u1"}}],"chunk_index":0,"chunk_count":2,"nonce":"n"}`, func(e Event) bool {
+			evt, ok := e.(*GuildMembersChunkEvent)
+			return ok && len(evt.Members) == 1 && evt.ChunkCount == 2 && evt.Nonce == "n"
+		}},
+		{EventMessageReactionAdd, `{"user_id":"u1","message_id":"m1","emoji":{"id":null,"name":"👍"}}`, func(e Event) bool {
+			evt, ok := e.(*MessageReactionAddEvent)
+			return ok && evt.MessageID == "m1" && evt.Emoji.Name == "👍"
+		}},
+		{EventMessagePollVoteAdd, `{"user_id":"u1","channel_id":"c1","message_id":"m1","guild_id":"g1","answer_id":2}`, func(e Event) bool {
+			evt, ok := e.(*MessagePollVoteAddEvent)
+			return ok && evt.MessageID == "m1" && evt.AnswerID == 2
+		}},
+		{EventMessagePollVoteRemove, `{"user_id":"u1","channel_id":"c1","message_id":"m1","answer_id":3}`, func(e Event) bool {
+			evt, ok := e.(*MessagePollVoteRemoveEvent)
+			return ok && evt.UserID == "u1" && evt.AnswerID == 3
+		}},
+		{EventChannelCreate, `{"id":"c1","type":0,"name":"general"}`, func(e Event) bool {
+			evt, ok := e.(*ChannelCreateEvent)
+			return ok && evt.Name == "general"
+		}},
+		{EventThreadCreate, `{"id":"t1","type":11,"newly_created":true}`, func(e Event) bool {
+			evt, ok := e.(*ThreadCreateEvent)
+			return ok && evt.ID == "t1" && evt.NewlyCreated
+		}},
+		{EventThreadMembersUpdate, `{"id":"t1","member_count":2,"removed_member_ids":["u1"]}`, func(e Event) bool {
+			evt, ok := e.(*ThreadMembersUpdateEvent)
+			return ok && evt.MemberCount == 2 && len(evt.RemovedMemberIDs) == 1
+		}},
+		{EventVoiceStateUpdate, `{"guild_id":"g1","channel_id":"v1","user_id":"u1","self_mute":true}`, func(e Event) bool {
+			evt, ok := e.(*VoiceStateUpdateEvent)
+			return ok && evt.ChannelID == "v1" && evt.SelfMute
+		}},
+		{EventPresenceUpdate, `{"user":{"id":"u1"},"status":"idle","activities":[{"name":"x","type":0}]}`, func(e Event) bool {
+			evt, ok := e.(*PresenceUpdateEvent)
+			return ok && evt.Status == "idle" && len(evt.Activities) == 1
+		}},
+		{EventTypingStart, `{"channel_id":"c1","user_id":"u1","timestamp":1700000000}`, func(e Event) bool {
+			evt, ok := e.(*TypingStartEvent)
+			return ok && evt.Time().Unix() == 1700000000
+		}},
+		{EventGuildRoleDelete, `{"guild_id":"g1","role_id":"r1"}`, func(e Event) bool {
+			evt, ok := e.(*GuildRoleDeleteEvent)
+			return ok && evt.RoleID == "r1"
+		}},
+		{EventGuildBanAdd, `{"guild_id":"g1","user":{"id":"u1"}}`, func(e Event) bool {
+			evt, ok := e.(*GuildBanAddEvent)
+			return ok && evt.User.ID == "u1"
+		}},
+		{EventGuildEmojisUpdate, `{"guild_id":"g1","emojis":[{"id":"e1","name":"party"}]}`, func(e Event) bool {
+			evt, ok := e.(*GuildEmojisUpdateEvent)
+			return ok && len(evt.Emojis) == 1 && evt.Emojis[0].Name == "party"
+		}},
+		{EventAutoModerationRuleCreate, `{"id":"r1","guild_id":"g1","name":"no invites","event_type":1,"trigger_type":1,"actions":[{"type":1}],"enabled":true}`, func(e Event) bool {
+			evt, ok := e.(*AutoModerationRuleCreateEvent)
+			return ok && evt.Name == "no invites" && evt.TriggerType == types.AutoModerationTriggerKeyword && len(evt.Actions) == 1
+		}},
+		{EventStageInstanceCreate, `{"id":"s1","guild_id":"g1","channel_id":"c1","topic":"Town hall","privacy_level":2}`, func(e Event) bool {
+			evt, ok := e.(*StageInstanceCreateEvent)
+			return ok && evt.Topic == "Town hall" && evt.PrivacyLevel == types.StagePrivacyGuildOnly
+		}},
+		{EventStageInstanceDelete, `{"id":"s1","guild_id":"g1","channel_id":"c1","topic":"Town hall"}`, func(e Event) bool {
+			evt, ok := e.(*StageInstanceDeleteEvent)
+			return ok && evt.ChannelID == "c1"
+		}},
+		{EventAutoModerationActionExecution, `{"guild_id":"g1","action":{"type":3,"metadata":{"duration_seconds":60}},"rule_id":"r1","rule_trigger_type":1,"user_id":"u1","matched_keyword":"spam"}`, func(e Event) bool {
+			evt, ok := e.(*AutoModerationActionExecutionEvent)
+			return ok && evt.RuleID == "r1" && evt.Action.Type == types.AutoModerationActionTimeout && evt.Action.Metadata.DurationSeconds == 60 && evt.MatchedKeyword == "spam"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := decodeEvent(&Payload{Op: OpCodeDispatch, T: tt.name, D: json.RawMessage(tt.data)})
+			if err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if event.Type() != tt.name {
+				t.Fatalf("expected type %s, got %s", tt.name, event.Type())
+			}
+			if !tt.check(event) {
+				t.Fatalf("unexpected event %+v", event)
+			}
+		})
 	}
 }
 
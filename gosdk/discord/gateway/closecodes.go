@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// CloseError is a gateway close frame. Compare against the Err* close code
+// variables with errors.Is, or use AsCloseError to inspect the code.
+type CloseError struct {
+	Code   int
+	Reason string
+	// Err is the underlying transport error, if any.
+	Err error
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("gateway closed with code %d: %s", e.Code, e.Reason)
+}
+
+func (e *CloseError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches another *CloseError with the same code. Authentication and
+// rate-limit closes also match types.ErrUnauthorized and types.ErrRateLimited.
+func (e *CloseError) Is(target error) bool {
+	if t, ok := target.(*CloseError); ok {
+		return t.Code == e.Code
+	}
+	switch target {
+	case types.ErrUnauthorized:
+		return e.Code == 4004
+	case types.ErrRateLimited:
+		return e.Code == 4008
+	}
+	return false
+}
+
+// IsFatal reports whether Discord will reject a reconnect with the same
+// token, shard and intents, so the client must stop instead of retrying.
+func (e *CloseError) IsFatal() bool {
+	switch e.Code {
+	case 4004, 4010, 4011, 4012, 4013, 4014:
+		return true
+	}
+	return false
+}
+
+// ResetsSession reports whether the session is gone and the next connection
+// must identify rather than resume.
+func (e *CloseError) ResetsSession() bool {
+	return e.Code == 4007 || e.Code == 4009
+}
+
+// Gateway close codes. See
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-close-event-codes.
+var (
+	ErrUnknownError         = &CloseError{Code: 4000, Reason: "unknown error"}
+	ErrUnknownOpcode        = &CloseError{Code: 4001, Reason: "unknown opcode"}
+	ErrDecodeError          = &CloseError{Code: 4002, Reason: "decode error"}
+	ErrNotAuthenticated     = &CloseError{Code: 4003, Reason: "not authenticated"}
+	ErrAuthenticationFailed = &CloseError{Code: 4004, Reason: "authentication failed"}
+	ErrAlreadyAuthenticated = &CloseError{Code: 4005, Reason: "already authenticated"}
+	ErrInvalidSeq           = &CloseError{Code: 4007, Reason: "invalid seq"}
+	ErrRateLimited          = &CloseError{Code: 4008, Reason: "rate limited"}
+	ErrSessionTimedOut      = &CloseError{Code: 4009, Reason: "session timed out"}
+	ErrInvalidShard         = &CloseError{Code: 4010, Reason: "invalid shard"}
+	ErrShardingRequired     = &CloseError{Code: 4011, Reason: "sharding required"}
+	ErrInvalidAPIVersion    = &CloseError{Code: 4012, Reason: "invalid API version"}
+	ErrInvalidIntents       = &CloseError{Code: 4013, Reason: "invalid intents"}
+	ErrDisallowedIntents    = &CloseError{Code: 4014, Reason: "disallowed intents"}
+)
+
+var closeErrors = map[int]*CloseError{}
+
+func init() {
+	for _, e := range []*CloseError{
+		ErrUnknownError, ErrUnknownOpcode, ErrDecodeError, ErrNotAuthenticated,
+		ErrAuthenticationFailed, ErrAlreadyAuthenticated, ErrInvalidSeq, ErrRateLimited,
+		ErrSessionTimedOut, ErrInvalidShard, ErrShardingRequired, ErrInvalidAPIVersion,
+		ErrInvalidIntents, ErrDisallowedIntents,
+	} {
+		closeErrors[e.Code] = e
+	}
+}
+
+// AsCloseError extracts the close frame from err, converting websocket
+// close errors so callers need not import the websocket library.
+func AsCloseError(err error) (*CloseError, bool) {
+	var ce *CloseError
+	if errors.As(err, &ce) {
+		return ce, true
+	}
+	var wsErr *websocket.CloseError
+	if !errors.As(err, &wsErr) {
+		return nil, false
+	}
+	reason := wsErr.Text
+	if known, ok := closeErrors[wsErr.Code]; ok {
+		reason = known.Reason
+	}
+	return &CloseError{Code: wsErr.Code, Reason: reason, Err: err}, true
+}
+
+// closeErrorOr converts websocket close frames to *CloseError and returns
+// other errors unchanged.
+func closeErrorOr(err error) error {
+	if ce, ok := AsCloseError(err); ok {
+		return ce
+	}
+	return err
+}
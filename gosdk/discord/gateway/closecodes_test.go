@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestAsCloseErrorFromWebsocket(t *testing.T) {
+	wsErr := &websocket.CloseError{Code: 4013, Text: "Invalid intent(s)."}
+	err := fmt.Errorf("receive: %w", wsErr)
+
+	ce, ok := AsCloseError(err)
+	if !ok {
+		t.Fatal("expected a close error")
+	}
+	if ce.Code != 4013 || ce.Reason != "invalid intents" || !ce.IsFatal() {
+		t.Fatalf("unexpected close error %+v", ce)
+	}
+	if !errors.Is(ce, ErrInvalidIntents) || errors.Is(ce, ErrDisallowedIntents) {
+		t.Fatalf("errors.Is mismatch for %v", ce)
+	}
+	var raw *websocket.CloseError
+	if !errors.As(ce, &raw) {
+		t.Fatal("expected the websocket error to remain reachable")
+	}
+
+	if _, ok := AsCloseError(errors.New("eof")); ok {
+		t.Fatal("plain errors are not close errors")
+	}
+	if ce, _ := AsCloseError(&websocket.CloseError{Code: 1006, Text: "abnormal"}); ce.Reason != "abnormal" || ce.IsFatal() {
+		t.Fatalf("unknown codes keep the websocket text: %+v", ce)
+	}
+}
+
+func TestCloseErrorSemantics(t *testing.T) {
+	fatal := map[*CloseError]bool{
+		ErrUnknownError: false, ErrUnknownOpcode: false, ErrDecodeError: false,
+		ErrNotAuthenticated: false, ErrAuthenticationFailed: true, ErrAlreadyAuthenticated: false,
+		ErrInvalidSeq: false, ErrRateLimited: false, ErrSessionTimedOut: false,
+		ErrInvalidShard: true, ErrShardingRequired: true, ErrInvalidAPIVersion: true,
+		ErrInvalidIntents: true, ErrDisallowedIntents: true,
+	}
+	for ce, want := range fatal {
+		if ce.IsFatal() != want {
+			t.Fatalf("%v IsFatal() = %v, want %v", ce, ce.IsFatal(), want)
+		}
+	}
+	if !ErrInvalidSeq.ResetsSession() || !ErrSessionTimedOut.ResetsSession() || ErrRateLimited.ResetsSession() {
+		t.Fatal("unexpected ResetsSession results")
+	}
+	if !errors.Is(ErrRateLimited, types.ErrRateLimited) || !errors.Is(ErrAuthenticationFailed, types.ErrUnauthorized) {
+		t.Fatal("expected close errors to match the shared sentinels")
+	}
+}
@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"net/url"
+)
+
+// Compression selects the transport compression negotiated with the gateway.
+type Compression string
+
+const (
+	// CompressionNone receives plain JSON text frames.
+	CompressionNone Compression = ""
+	// CompressionZlibStream receives a single zlib stream spanning the whole connection.
+	CompressionZlibStream Compression = "zlib-stream"
+)
+
+// zlibSuffix terminates every complete zlib-stream message (Z_SYNC_FLUSH).
+var zlibSuffix = []byte{0x00, 0x00, 0xff, 0xff}
+
+// zlibInflater decodes payloads from a connection-wide zlib stream. Frames are
+// buffered until a sync-flush suffix arrives, then handed to a persistent
+// decompressor so the shared dictionary carries across messages.
+type zlibInflater struct {
+	pending bytes.Buffer
+	input   bytes.Buffer
+	reader  io.ReadCloser
+	decoder *json.Decoder
+}
+
+func newZlibInflater() *zlibInflater {
+	return &zlibInflater{}
+}
+
+// Write buffers a binary frame and reports whether a full message is ready.
+func (z *zlibInflater) Write(frame []byte) bool {
+	z.pending.Write(frame)
+	return bytes.HasSuffix(z.pending.Bytes(), zlibSuffix)
+}
+
+// Decode inflates the buffered message into payload.
+func (z *zlibInflater) Decode(payload *Payload) error {
+	z.input.Write(z.pending.Bytes())
+	z.pending.Reset()
+
+	if z.reader == nil {
+		reader, err := zlib.NewReader(&z.input)
+		if err != nil {
+			return err
+		}
+		z.reader = reader
+		z.decoder = json.NewDecoder(reader)
+	}
+	return z.decoder.Decode(payload)
+}
+
+// Close releases the decompressor.
+func (z *zlibInflater) Close() error {
+	if z.reader == nil {
+		return nil
+	}
+	return z.reader.Close()
+}
+
+// gatewayURLWithCompression adds the compress query parameter when enabled.
+func gatewayURLWithCompression(raw string, compression Compression) (string, error) {
+	if compression == CompressionNone {
+		return raw, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("compress", string(compression))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
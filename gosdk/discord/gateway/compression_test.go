@@ -0,0 +1,151 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// zlibFrames compresses each payload into one sync-flushed chunk of a shared stream.
+func zlibFrames(t *testing.T, payloads ...Payload) [][]byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	frames := make([][]byte, 0, len(payloads))
+	for _, p := range payloads {
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatalf("compress: %v", err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("flush: %v", err)
+		}
+		frames = append(frames, append([]byte(nil), buf.Bytes()...))
+		buf.Reset()
+	}
+	return frames
+}
+
+func TestZlibInflaterSharedStream(t *testing.T) {
+	frames := zlibFrames(t,
+		Payload{Op: OpCodeHello, D: json.RawMessage(`{"heartbeat_interval":41250}`)},
+		Payload{Op: OpCodeDispatch, S: 1, T: EventReady, D: json.RawMessage(`{"v":10}`)},
+		Payload{Op: OpCodeDispatch, S: 2, T: EventMessageCreate, D: json.RawMessage(`{"id":"m1"}`)},
+	)
+
+	inflater := newZlibInflater()
+	defer inflater.Close()
+
+	for i, frame := range frames {
+		// Split each message across two websocket frames.
+		half := len(frame) / 2
+		if inflater.Write(frame[:half]) {
+			t.Fatalf("frame %d: partial frame reported complete", i)
+		}
+		if !inflater.Write(frame[half:]) {
+			t.Fatalf("frame %d: full frame not reported complete", i)
+		}
+
+		var payload Payload
+		if err := inflater.Decode(&payload); err != nil {
+			t.Fatalf("frame %d: decode error: %v", i, err)
+		}
+		if payload.S != i {
+			t.Fatalf("frame %d: unexpected sequence %d", i, payload.S)
+		}
+	}
+}
+
+func TestGatewayURLWithCompression(t *testing.T) {
+	got, err := gatewayURLWithCompression(defaultGatewayURL, CompressionZlibStream)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "wss://gateway.discord.gg/?compress=zlib-stream&encoding=json&v=10" {
+		t.Fatalf("unexpected url: %s", got)
+	}
+
+	plain, err := gatewayURLWithCompression(defaultGatewayURL, CompressionNone)
+	if err != nil || plain != defaultGatewayURL {
+		t.Fatalf("expected url unchanged, got %s (%v)", plain, err)
+	}
+}
+
+func TestNewConnectionRejectsUnknownCompression(t *testing.T) {
+	if _, err := NewConnection("token", 0, WithCompression("brotli")); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestConnectionReceiveZlibStream(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	frames := zlibFrames(t,
+		Payload{Op: OpCodeHello, D: json.RawMessage(`{"heartbeat_interval":41250}`)},
+		Payload{Op: OpCodeDispatch, S: 7, T: EventReady, D: json.RawMessage(`{"v":10}`)},
+	)
+	queryCh := make(chan string, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queryCh <- r.URL.Query().Get("compress")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		for _, frame := range frames {
+			if err := conn.WriteMessage(websocket.BinaryMessage, frame); err != nil {
+				t.Errorf("write frame: %v", err)
+				return
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	conn, err := NewConnection("token", 0,
+		WithGatewayURL(wsURL(server)+"/?v=10&encoding=json"),
+		WithCompression(CompressionZlibStream),
+	)
+	if err != nil {
+		t.Fatalf("new connection error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := conn.Connect(ctx); err != nil {
+		t.Fatalf("connect error: %v", err)
+	}
+	defer conn.Close()
+
+	if got := <-queryCh; got != string(CompressionZlibStream) {
+		t.Fatalf("expected compress query, got %q", got)
+	}
+
+	hello, err := conn.Receive(ctx)
+	if err != nil {
+		t.Fatalf("receive hello: %v", err)
+	}
+	if hello.Op != OpCodeHello {
+		t.Fatalf("expected hello, got %d", hello.Op)
+	}
+
+	ready, err := conn.Receive(ctx)
+	if err != nil {
+		t.Fatalf("receive ready: %v", err)
+	}
+	if ready.T != EventReady || ready.S != 7 {
+		t.Fatalf("unexpected payload %+v", ready)
+	}
+}
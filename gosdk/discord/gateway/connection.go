@@ -12,6 +12,7 @@ import (
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 	"github.com/mtreilly/godiscord/gosdk/logger"
+	"github.com/mtreilly/godiscord/gosdk/version"
 )
 
 const (
@@ -21,6 +22,9 @@ const (
 
 type ConnectionOption func(*Connection)
 
+// Connection is the default Transport: a gorilla/websocket connection that
+// heartbeats on its own. The Client owns the Session; it hands the
+// Connection the HELLO interval and its sequence through StartHeartbeat.
 type Connection struct {
 	token             string
 	intents           int
@@ -30,12 +34,11 @@ type Connection struct {
 	writeMu           sync.Mutex
 	conn              *websocket.Conn
 	mu                sync.Mutex
-	sequence          int
-	sessionID         string
-	heartbeatTicker   *time.Ticker
-	heartbeatCtx      context.Context
-	heartbeatCancel   context.CancelFunc
+	sequence          func() int
+	heartbeater       *Heartbeater
 	heartbeatInterval time.Duration
+	compression       Compression
+	inflater          *zlibInflater
 }
 
 func WithGatewayURL(url string) ConnectionOption {
@@ -46,10 +49,10 @@ func WithGatewayURL(url string) ConnectionOption {
 	}
 }
 
-func WithLogger(l *logger.Logger) ConnectionOption {
+func WithLogger(l logger.Interface) ConnectionOption {
 	return func(c *Connection) {
-		if l != nil {
-			c.logger = l
+		if lg := logger.From(l); lg != nil {
+			c.logger = lg
 		}
 	}
 }
@@ -70,6 +73,13 @@ func WithHeartbeatInterval(interval time.Duration) ConnectionOption {
 	}
 }
 
+// WithCompression enables gateway transport compression.
+func WithCompression(compression Compression) ConnectionOption {
+	return func(c *Connection) {
+		c.compression = compression
+	}
+}
+
 func NewConnection(token string, intents int, opts ...ConnectionOption) (*Connection, error) {
 	if token == "" {
 		return nil, &types.ValidationError{
@@ -90,10 +100,20 @@ func NewConnection(token string, intents int, opts ...ConnectionOption) (*Connec
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.logger = c.logger.WithSubsystem(logger.SubsystemGateway)
 
 	if c.gatewayURL == "" {
 		c.gatewayURL = defaultGatewayURL
 	}
+	switch c.compression {
+	case CompressionNone, CompressionZlibStream:
+	default:
+		return nil, &types.ValidationError{
+			Field:   "compression",
+			Message: fmt.Sprintf("unsupported compression %q", c.compression),
+		}
+	}
+	c.heartbeater = NewHeartbeater(c.Send, c.lastSequence, c.logger)
 	return c, nil
 }
 
@@ -106,30 +126,46 @@ func (c *Connection) Connect(ctx context.Context) error {
 	c.mu.Unlock()
 
 	headers := http.Header{}
-	headers.Set("User-Agent", "godiscord-gateway/1.0")
+	headers.Set("User-Agent", version.UserAgent())
 
-	conn, _, err := c.dialer.DialContext(ctx, c.gatewayURL, headers)
+	gatewayURL, err := gatewayURLWithCompression(c.gatewayURL, c.compression)
+	if err != nil {
+		return fmt.Errorf("parse gateway url: %w", err)
+	}
+
+	conn, _, err := c.dialer.DialContext(ctx, gatewayURL, headers)
 	if err != nil {
 		return fmt.Errorf("dial websocket: %w", err)
 	}
 
 	c.mu.Lock()
 	c.conn = conn
+	if c.compression == CompressionZlibStream {
+		c.inflater = newZlibInflater()
+	}
 	c.mu.Unlock()
 
-	c.logger.Info("gateway connected", "url", c.gatewayURL)
-	c.startHeartbeat(ctx)
+	c.logger.Info("gateway connected", "url", gatewayURL)
+	c.mu.Lock()
+	interval := c.heartbeatInterval
+	c.mu.Unlock()
+	c.heartbeater.Start(ctx, interval)
 	return nil
 }
 
 func (c *Connection) Close() error {
-	c.stopHeartbeat()
+	c.heartbeater.Stop()
 
 	c.mu.Lock()
 	conn := c.conn
 	c.conn = nil
+	inflater := c.inflater
+	c.inflater = nil
 	c.mu.Unlock()
 
+	if inflater != nil {
+		_ = inflater.Close()
+	}
 	if conn == nil {
 		return nil
 	}
@@ -164,6 +200,7 @@ func (c *Connection) Send(ctx context.Context, payload *Payload) error {
 func (c *Connection) Receive(ctx context.Context) (*Payload, error) {
 	c.mu.Lock()
 	conn := c.conn
+	inflater := c.inflater
 	c.mu.Unlock()
 
 	if conn == nil {
@@ -171,121 +208,59 @@ func (c *Connection) Receive(ctx context.Context) (*Payload, error) {
 	}
 
 	var payload Payload
-	if err := conn.ReadJSON(&payload); err != nil {
-		return nil, err
-	}
-
-	if payload.S > 0 {
-		c.mu.Lock()
-		c.sequence = payload.S
-		c.mu.Unlock()
+	if inflater == nil {
+		if err := conn.ReadJSON(&payload); err != nil {
+			return nil, closeErrorOr(err)
+		}
+	} else if err := c.receiveCompressed(conn, inflater, &payload); err != nil {
+		return nil, closeErrorOr(err)
 	}
 
 	return &payload, nil
 }
 
-func (c *Connection) startHeartbeat(ctx context.Context) {
-	c.mu.Lock()
-	if c.heartbeatCtx != nil {
-		c.mu.Unlock()
-		return
-	}
-	ctx, cancel := context.WithCancel(ctx)
-	c.heartbeatCtx = ctx
-	c.heartbeatCancel = cancel
-	c.heartbeatTicker = time.NewTicker(c.heartbeatInterval)
-	ticker := c.heartbeatTicker
-	c.mu.Unlock()
-
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				if err := c.sendHeartbeat(ctx); err != nil {
-					c.logger.Warn("heartbeat failed", "error", err)
-				}
+func (c *Connection) receiveCompressed(conn *websocket.Conn, inflater *zlibInflater, payload *Payload) error {
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if messageType == websocket.TextMessage {
+			return json.Unmarshal(data, payload)
+		}
+		if inflater.Write(data) {
+			if err := inflater.Decode(payload); err != nil {
+				return fmt.Errorf("inflate payload: %w", err)
 			}
+			return nil
 		}
-	}()
-}
-
-func (c *Connection) stopHeartbeat() {
-	c.mu.Lock()
-	if c.heartbeatTicker != nil {
-		c.heartbeatTicker.Stop()
-		c.heartbeatTicker = nil
-	}
-	if c.heartbeatCancel != nil {
-		c.heartbeatCancel()
-	}
-	c.heartbeatCtx = nil
-	c.heartbeatCancel = nil
-	c.mu.Unlock()
-}
-
-func (c *Connection) sendHeartbeat(ctx context.Context) error {
-	c.mu.Lock()
-	seq := c.sequence
-	c.mu.Unlock()
-
-	var data json.RawMessage
-	if seq > 0 {
-		data = json.RawMessage(fmt.Sprintf("%d", seq))
-	} else {
-		data = json.RawMessage("null")
 	}
-	payload := &Payload{Op: OpCodeHeartbeat, D: data}
-	return c.Send(ctx, payload)
 }
 
-func (c *Connection) reconnect(ctx context.Context) error {
-	if err := c.Close(); err != nil {
-		c.logger.Warn("failed to close before reconnect", "error", err)
-	}
-	if err := c.Connect(ctx); err != nil {
-		return err
-	}
-
-	if c.sessionID != "" {
-		return c.resume(ctx)
+// StartHeartbeat restarts the heartbeat loop at the interval Discord sent in
+// HELLO, reading the last sequence number from sequence. It implements
+// HeartbeatTransport.
+func (c *Connection) StartHeartbeat(ctx context.Context, interval time.Duration, sequence func() int) {
+	if interval <= 0 {
+		return
 	}
-	return nil
-}
-
-func (c *Connection) resume(ctx context.Context) error {
 	c.mu.Lock()
-	session := c.sessionID
-	seq := c.sequence
-	c.mu.Unlock()
-
-	if session == "" {
-		return &types.ValidationError{
-			Field:   "session_id",
-			Message: "session ID required to resume",
-		}
+	c.heartbeatInterval = interval
+	if sequence != nil {
+		c.sequence = sequence
 	}
-
-	payload := &Payload{Op: OpCodeResume}
-	state := map[string]interface{}{
-		"token":      c.token,
-		"session_id": session,
-		"seq":        seq,
-	}
-	raw, _ := json.Marshal(state)
-	payload.D = raw
-	return c.Send(ctx, payload)
-}
-
-func (c *Connection) SetSession(sessionID string) {
-	c.mu.Lock()
-	c.sessionID = sessionID
 	c.mu.Unlock()
+	c.heartbeater.Start(ctx, interval)
 }
 
-func (c *Connection) SetSequence(seq int) {
+// lastSequence returns the sequence number to heartbeat with, or 0 before
+// StartHeartbeat supplies a source.
+func (c *Connection) lastSequence() int {
 	c.mu.Lock()
-	c.sequence = seq
+	sequence := c.sequence
 	c.mu.Unlock()
+	if sequence == nil {
+		return 0
+	}
+	return sequence()
 }
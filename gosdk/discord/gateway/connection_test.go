@@ -107,10 +107,14 @@ func TestConnectionResumePayload(t *testing.T) {
 	}
 	defer conn.Close()
 
-	conn.SetSession("session-123")
-	conn.SetSequence(42)
-
-	if err := conn.resume(ctx); err != nil {
+	var session Session
+	session.SetID("session-123")
+	session.SetSequence(42)
+	resume, err := session.ResumePayload("token")
+	if err != nil {
+		t.Fatalf("resume payload error: %v", err)
+	}
+	if err := conn.Send(ctx, resume); err != nil {
 		t.Fatalf("resume error: %v", err)
 	}
 
@@ -133,3 +137,50 @@ func TestConnectionResumePayload(t *testing.T) {
 		t.Fatalf("did not observe resume payload")
 	}
 }
+
+func TestConnectionStartHeartbeatUsesSequence(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	beatCh := make(chan *Payload, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		var payload Payload
+		if err := conn.ReadJSON(&payload); err != nil {
+			return
+		}
+		beatCh <- &payload
+	}))
+	defer server.Close()
+
+	conn, err := NewConnection("token", 0, WithGatewayURL(wsURL(server)))
+	if err != nil {
+		t.Fatalf("new connection error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := conn.Connect(ctx); err != nil {
+		t.Fatalf("connect error: %v", err)
+	}
+	defer conn.Close()
+
+	var session Session
+	session.SetSequence(7)
+	conn.StartHeartbeat(ctx, 10*time.Millisecond, session.Sequence)
+
+	select {
+	case payload := <-beatCh:
+		if payload.Op != OpCodeHeartbeat || string(payload.D) != "7" {
+			t.Fatalf("unexpected heartbeat %d %s", payload.Op, payload.D)
+		}
+	case <-ctx.Done():
+		t.Fatal("did not observe heartbeat")
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/logger"
 )
@@ -12,88 +15,419 @@ import (
 // EventHandler processes a gateway event.
 type EventHandler func(ctx context.Context, event Event) error
 
+// HandlerID identifies a registered handler so it can be removed with Off.
+type HandlerID uint64
+
+// HandlerOption configures a single handler registration.
+type HandlerOption func(*handlerEntry)
+
+// WithPriority orders handlers for the same event; higher priorities run
+// first and equal priorities run in registration order (default 0).
+func WithPriority(priority int) HandlerOption {
+	return func(h *handlerEntry) {
+		h.priority = priority
+	}
+}
+
+// WithFilter skips the handler unless match returns true. Once handlers are
+// only consumed by events that pass the filter.
+func WithFilter(match func(Event) bool) HandlerOption {
+	return func(h *handlerEntry) {
+		if match != nil {
+			h.filter = match
+		}
+	}
+}
+
+type handlerEntry struct {
+	id       HandlerID
+	priority int
+	handler  EventHandler
+	filter   func(Event) bool
+	once     bool
+	fired    atomic.Bool
+}
+
 // Dispatcher routes gateway events to registered handlers.
 type Dispatcher struct {
 	mu       sync.RWMutex
-	handlers map[string][]EventHandler
+	handlers map[string][]*handlerEntry
+	nextID   HandlerID
 	logger   *logger.Logger
+
+	workers       int
+	queueSize     int
+	guildOrdering bool
+	pool          *dispatchPool
+
+	middlewares []DispatchMiddleware
+	deliver     EventHandler
 }
 
+// DispatchMiddleware wraps the delivery of each event to its handlers, for
+// example to trace or time it. next runs every matching handler and returns
+// their joined errors.
+type DispatchMiddleware func(next EventHandler) EventHandler
+
 // DispatcherOption configures the dispatcher.
 type DispatcherOption func(*Dispatcher)
 
 // WithDispatcherLogger overrides the logger used by the dispatcher.
-func WithDispatcherLogger(l *logger.Logger) DispatcherOption {
+func WithDispatcherLogger(l logger.Interface) DispatcherOption {
 	return func(d *Dispatcher) {
-		if l != nil {
-			d.logger = l
+		if lg := logger.From(l); lg != nil {
+			d.logger = lg
 		}
 	}
 }
 
+// WithDispatchMiddleware wraps event delivery in mw, outermost first.
+func WithDispatchMiddleware(mw ...DispatchMiddleware) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.middlewares = append(d.middlewares, mw...)
+	}
+}
+
 // NewDispatcher constructs a dispatcher with optional configuration.
 func NewDispatcher(opts ...DispatcherOption) *Dispatcher {
 	d := &Dispatcher{
-		handlers: make(map[string][]EventHandler),
+		handlers: make(map[string][]*handlerEntry),
 		logger:   logger.Default(),
 	}
 	for _, opt := range opts {
 		opt(d)
 	}
+	d.logger = d.logger.WithSubsystem(logger.SubsystemGateway)
+	d.deliver = d.run
+	for i := len(d.middlewares) - 1; i >= 0; i-- {
+		if d.middlewares[i] != nil {
+			d.deliver = d.middlewares[i](d.deliver)
+		}
+	}
+	d.startPool()
 	return d
 }
 
-// On registers a handler for the given event type.
-func (d *Dispatcher) On(eventType string, handler EventHandler) {
+// On registers a handler for the given event type. The returned ID can be
+// passed to Off; it is zero when the registration is ignored.
+func (d *Dispatcher) On(eventType string, handler EventHandler, opts ...HandlerOption) HandlerID {
+	return d.register(eventType, handler, false, opts)
+}
+
+// Once registers a handler that is removed after its first invocation.
+// Combined with WithFilter it supports flows such as waiting for the next
+// message from a given user in a given channel.
+func (d *Dispatcher) Once(eventType string, handler EventHandler, opts ...HandlerOption) HandlerID {
+	return d.register(eventType, handler, true, opts)
+}
+
+// Off removes a handler registered with On or Once. It reports whether a
+// handler was removed.
+func (d *Dispatcher) Off(id HandlerID) bool {
+	if id == 0 {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for eventType, entries := range d.handlers {
+		for i, entry := range entries {
+			if entry.id != id {
+				continue
+			}
+			remaining := append(entries[:i:i], entries[i+1:]...)
+			if len(remaining) == 0 {
+				delete(d.handlers, eventType)
+			} else {
+				d.handlers[eventType] = remaining
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypes returns the sorted event types that have at least one handler.
+func (d *Dispatcher) EventTypes() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	names := make([]string, 0, len(d.handlers))
+	for eventType := range d.handlers {
+		names = append(names, eventType)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d *Dispatcher) register(eventType string, handler EventHandler, once bool, opts []HandlerOption) HandlerID {
 	if eventType == "" || handler == nil {
-		return
+		return 0
 	}
+	entry := &handlerEntry{handler: handler, once: once}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(entry)
+		}
+	}
+
 	d.mu.Lock()
-	d.handlers[eventType] = append(d.handlers[eventType], handler)
-	d.mu.Unlock()
+	defer d.mu.Unlock()
+	d.nextID++
+	entry.id = d.nextID
+
+	// Insert after every handler with an equal or higher priority.
+	entries := d.handlers[eventType]
+	pos := len(entries)
+	for i, existing := range entries {
+		if existing.priority < entry.priority {
+			pos = i
+			break
+		}
+	}
+	entries = append(entries[:pos:pos], append([]*handlerEntry{entry}, entries[pos:]...)...)
+	d.handlers[eventType] = entries
+	return entry.id
 }
 
-// OnMessageCreate registers a handler for MESSAGE_CREATE events.
-func (d *Dispatcher) OnMessageCreate(handler func(context.Context, *MessageCreateEvent) error) {
-	d.On(EventMessageCreate, func(ctx context.Context, event Event) error {
-		evt, ok := event.(*MessageCreateEvent)
+// onTyped registers a handler that receives events asserted to T.
+func onTyped[T Event](d *Dispatcher, eventType string, handler func(context.Context, T) error, opts []HandlerOption) HandlerID {
+	if handler == nil {
+		return 0
+	}
+	return d.On(eventType, func(ctx context.Context, event Event) error {
+		evt, ok := event.(T)
 		if !ok {
 			return fmt.Errorf("unexpected event type %T", event)
 		}
 		return handler(ctx, evt)
-	})
+	}, opts...)
+}
+
+// OnMessageCreate registers a handler for MESSAGE_CREATE events.
+func (d *Dispatcher) OnMessageCreate(handler func(context.Context, *MessageCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventMessageCreate, handler, opts)
 }
 
 // OnMessageUpdate registers a handler for MESSAGE_UPDATE events.
-func (d *Dispatcher) OnMessageUpdate(handler func(context.Context, *MessageUpdateEvent) error) {
-	d.On(EventMessageUpdate, func(ctx context.Context, event Event) error {
-		evt, ok := event.(*MessageUpdateEvent)
-		if !ok {
-			return fmt.Errorf("unexpected event type %T", event)
-		}
-		return handler(ctx, evt)
-	})
+func (d *Dispatcher) OnMessageUpdate(handler func(context.Context, *MessageUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventMessageUpdate, handler, opts)
+}
+
+// OnMessageDelete registers a handler for MESSAGE_DELETE events.
+func (d *Dispatcher) OnMessageDelete(handler func(context.Context, *MessageDeleteEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventMessageDelete, handler, opts)
 }
 
 // OnInteraction registers a handler for INTERACTION_CREATE events.
-func (d *Dispatcher) OnInteraction(handler func(context.Context, *InteractionCreateEvent) error) {
-	d.On(EventInteractionCreate, func(ctx context.Context, event Event) error {
-		evt, ok := event.(*InteractionCreateEvent)
-		if !ok {
-			return fmt.Errorf("unexpected event type %T", event)
-		}
-		return handler(ctx, evt)
-	})
+func (d *Dispatcher) OnInteraction(handler func(context.Context, *InteractionCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventInteractionCreate, handler, opts)
+}
+
+// OnGuildMemberAdd registers a handler for GUILD_MEMBER_ADD events.
+func (d *Dispatcher) OnGuildMemberAdd(handler func(context.Context, *GuildMemberAddEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildMemberAdd, handler, opts)
+}
+
+// OnGuildMemberUpdate registers a handler for GUILD_MEMBER_UPDATE events.
+func (d *Dispatcher) OnGuildMemberUpdate(handler func(context.Context, *GuildMemberUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildMemberUpdate, handler, opts)
+}
+
+// OnGuildMemberRemove registers a handler for GUILD_MEMBER_REMOVE events.
+func (d *Dispatcher) OnGuildMemberRemove(handler func(context.Context, *GuildMemberRemoveEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildMemberRemove, handler, opts)
+}
+
+// OnGuildMembersChunk registers a handler for GUILD_MEMBERS_CHUNK events.
+func (d *Dispatcher) OnGuildMembersChunk(handler func(context.Context, *GuildMembersChunkEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildMembersChunk, handler, opts)
+}
+
+// OnGuildRoleCreate registers a handler for GUILD_ROLE_CREATE events.
+func (d *Dispatcher) OnGuildRoleCreate(handler func(context.Context, *GuildRoleCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildRoleCreate, handler, opts)
+}
+
+// OnGuildRoleUpdate registers a handler for GUILD_ROLE_UPDATE events.
+func (d *Dispatcher) OnGuildRoleUpdate(handler func(context.Context, *GuildRoleUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildRoleUpdate, handler, opts)
 }
 
-// Dispatch invokes handlers for the supplied event.
+// OnGuildRoleDelete registers a handler for GUILD_ROLE_DELETE events.
+func (d *Dispatcher) OnGuildRoleDelete(handler func(context.Context, *GuildRoleDeleteEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildRoleDelete, handler, opts)
+}
+
+// OnGuildBanAdd registers a handler for GUILD_BAN_ADD events.
+func (d *Dispatcher) OnGuildBanAdd(handler func(context.Context, *GuildBanAddEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildBanAdd, handler, opts)
+}
+
+// OnGuildBanRemove registers a handler for GUILD_BAN_REMOVE events.
+func (d *Dispatcher) OnGuildBanRemove(handler func(context.Context, *GuildBanRemoveEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildBanRemove, handler, opts)
+}
+
+// OnGuildEmojisUpdate registers a handler for GUILD_EMOJIS_UPDATE events.
+func (d *Dispatcher) OnGuildEmojisUpdate(handler func(context.Context, *GuildEmojisUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildEmojisUpdate, handler, opts)
+}
+
+// OnGuildStickersUpdate registers a handler for GUILD_STICKERS_UPDATE events.
+func (d *Dispatcher) OnGuildStickersUpdate(handler func(context.Context, *GuildStickersUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventGuildStickersUpdate, handler, opts)
+}
+
+// OnMessageReactionAdd registers a handler for MESSAGE_REACTION_ADD events.
+func (d *Dispatcher) OnMessageReactionAdd(handler func(context.Context, *MessageReactionAddEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventMessageReactionAdd, handler, opts)
+}
+
+// OnMessageReactionRemove registers a handler for MESSAGE_REACTION_REMOVE events.
+func (d *Dispatcher) OnMessageReactionRemove(handler func(context.Context, *MessageReactionRemoveEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventMessageReactionRemove, handler, opts)
+}
+
+// OnMessagePollVoteAdd registers a handler for MESSAGE_POLL_VOTE_ADD events.
+func (d *Dispatcher) OnMessagePollVoteAdd(handler func(context.Context, *MessagePollVoteAddEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventMessagePollVoteAdd, handler, opts)
+}
+
+// OnMessagePollVoteRemove registers a handler for MESSAGE_POLL_VOTE_REMOVE events.
+func (d *Dispatcher) OnMessagePollVoteRemove(handler func(context.Context, *MessagePollVoteRemoveEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventMessagePollVoteRemove, handler, opts)
+}
+
+// OnChannelCreate registers a handler for CHANNEL_CREATE events.
+func (d *Dispatcher) OnChannelCreate(handler func(context.Context, *ChannelCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventChannelCreate, handler, opts)
+}
+
+// OnChannelUpdate registers a handler for CHANNEL_UPDATE events.
+func (d *Dispatcher) OnChannelUpdate(handler func(context.Context, *ChannelUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventChannelUpdate, handler, opts)
+}
+
+// OnChannelDelete registers a handler for CHANNEL_DELETE events.
+func (d *Dispatcher) OnChannelDelete(handler func(context.Context, *ChannelDeleteEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventChannelDelete, handler, opts)
+}
+
+// OnThreadCreate registers a handler for THREAD_CREATE events.
+func (d *Dispatcher) OnThreadCreate(handler func(context.Context, *ThreadCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventThreadCreate, handler, opts)
+}
+
+// OnThreadUpdate registers a handler for THREAD_UPDATE events.
+func (d *Dispatcher) OnThreadUpdate(handler func(context.Context, *ThreadUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventThreadUpdate, handler, opts)
+}
+
+// OnThreadDelete registers a handler for THREAD_DELETE events.
+func (d *Dispatcher) OnThreadDelete(handler func(context.Context, *ThreadDeleteEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventThreadDelete, handler, opts)
+}
+
+// OnThreadListSync registers a handler for THREAD_LIST_SYNC events.
+func (d *Dispatcher) OnThreadListSync(handler func(context.Context, *ThreadListSyncEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventThreadListSync, handler, opts)
+}
+
+// OnThreadMemberUpdate registers a handler for THREAD_MEMBER_UPDATE events.
+func (d *Dispatcher) OnThreadMemberUpdate(handler func(context.Context, *ThreadMemberUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventThreadMemberUpdate, handler, opts)
+}
+
+// OnThreadMembersUpdate registers a handler for THREAD_MEMBERS_UPDATE events.
+func (d *Dispatcher) OnThreadMembersUpdate(handler func(context.Context, *ThreadMembersUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventThreadMembersUpdate, handler, opts)
+}
+
+// OnVoiceStateUpdate registers a handler for VOICE_STATE_UPDATE events.
+func (d *Dispatcher) OnVoiceStateUpdate(handler func(context.Context, *VoiceStateUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventVoiceStateUpdate, handler, opts)
+}
+
+// OnPresenceUpdate registers a handler for PRESENCE_UPDATE events.
+func (d *Dispatcher) OnPresenceUpdate(handler func(context.Context, *PresenceUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventPresenceUpdate, handler, opts)
+}
+
+// OnTypingStart registers a handler for TYPING_START events.
+func (d *Dispatcher) OnTypingStart(handler func(context.Context, *TypingStartEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventTypingStart, handler, opts)
+}
+
+// OnAutoModerationRuleCreate registers a handler for AUTO_MODERATION_RULE_CREATE events.
+func (d *Dispatcher) OnAutoModerationRuleCreate(handler func(context.Context, *AutoModerationRuleCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventAutoModerationRuleCreate, handler, opts)
+}
+
+// OnAutoModerationRuleUpdate registers a handler for AUTO_MODERATION_RULE_UPDATE events.
+func (d *Dispatcher) OnAutoModerationRuleUpdate(handler func(context.Context, *AutoModerationRuleUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventAutoModerationRuleUpdate, handler, opts)
+}
+
+// OnAutoModerationRuleDelete registers a handler for AUTO_MODERATION_RULE_DELETE events.
+func (d *Dispatcher) OnAutoModerationRuleDelete(handler func(context.Context, *AutoModerationRuleDeleteEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventAutoModerationRuleDelete, handler, opts)
+}
+
+// OnAutoModerationActionExecution registers a handler for AUTO_MODERATION_ACTION_EXECUTION events.
+func (d *Dispatcher) OnAutoModerationActionExecution(handler func(context.Context, *AutoModerationActionExecutionEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventAutoModerationActionExecution, handler, opts)
+}
+
+// OnStageInstanceCreate registers a handler for STAGE_INSTANCE_CREATE events.
+func (d *Dispatcher) OnStageInstanceCreate(handler func(context.Context, *StageInstanceCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventStageInstanceCreate, handler, opts)
+}
+
+// OnStageInstanceUpdate registers a handler for STAGE_INSTANCE_UPDATE events.
+func (d *Dispatcher) OnStageInstanceUpdate(handler func(context.Context, *StageInstanceUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventStageInstanceUpdate, handler, opts)
+}
+
+// OnStageInstanceDelete registers a handler for STAGE_INSTANCE_DELETE events.
+func (d *Dispatcher) OnStageInstanceDelete(handler func(context.Context, *StageInstanceDeleteEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventStageInstanceDelete, handler, opts)
+}
+
+// OnRaw registers a fallback handler for dispatches without a typed decoder.
+func (d *Dispatcher) OnRaw(handler func(context.Context, *RawEvent) error, opts ...HandlerOption) HandlerID {
+	return onTyped(d, EventRaw, handler, opts)
+}
+
+// Dispatch invokes handlers for the supplied event. With WithWorkers it queues
+// the event instead and returns once a worker will pick it up.
 func (d *Dispatcher) Dispatch(ctx context.Context, event Event) error {
 	if event == nil {
 		return nil
 	}
+	if _, ok := EventReceivedAt(ctx); !ok {
+		ctx = context.WithValue(ctx, receivedAtKey{}, time.Now())
+	}
+	if d.pool != nil {
+		return d.pool.enqueue(ctx, event)
+	}
+	return d.deliver(ctx, event)
+}
+
+type receivedAtKey struct{}
+
+// EventReceivedAt returns when the event being handled was passed to
+// Dispatch. The gap to now is how long it waited for a worker.
+func EventReceivedAt(ctx context.Context) (time.Time, bool) {
+	at, ok := ctx.Value(receivedAtKey{}).(time.Time)
+	return at, ok
+}
 
+// run invokes the handlers registered for event.
+func (d *Dispatcher) run(ctx context.Context, event Event) error {
 	d.mu.RLock()
-	handlers := append([]EventHandler(nil), d.handlers[event.Type()]...)
+	handlers := append([]*handlerEntry(nil), d.handlers[event.Type()]...)
+	if _, ok := event.(*RawEvent); ok {
+		handlers = append(handlers, d.handlers[EventRaw]...)
+	}
 	d.mu.RUnlock()
 
 	if len(handlers) == 0 {
@@ -101,8 +435,17 @@ func (d *Dispatcher) Dispatch(ctx context.Context, event Event) error {
 	}
 
 	var errs []error
-	for _, handler := range handlers {
-		if err := handler(ctx, event); err != nil {
+	for _, entry := range handlers {
+		if entry.filter != nil && !entry.filter(event) {
+			continue
+		}
+		if entry.once {
+			if !entry.fired.CompareAndSwap(false, true) {
+				continue
+			}
+			d.Off(entry.id)
+		}
+		if err := entry.handler(ctx, event); err != nil {
 			d.logger.Error("event handler error", "event", event.Type(), "error", err)
 			errs = append(errs, err)
 		}
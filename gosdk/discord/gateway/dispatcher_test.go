@@ -60,3 +60,131 @@ func TestDispatcherCollectsErrors(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestDispatcherRawFallback(t *testing.T) {
+	dispatcher := NewDispatcher()
+	rawCalls := 0
+
+	dispatcher.OnRaw(func(ctx context.Context, event *RawEvent) error {
+		if event.Type() != "SOMETHING_NEW" {
+			return errors.New("unexpected raw event")
+		}
+		rawCalls++
+		return nil
+	})
+
+	if err := dispatcher.Dispatch(context.Background(), &RawEvent{EventType: "SOMETHING_NEW"}); err != nil {
+		t.Fatalf("dispatch error: %v", err)
+	}
+	if err := dispatcher.Dispatch(context.Background(), &MessageCreateEvent{Message: &types.Message{}}); err != nil {
+		t.Fatalf("dispatch error: %v", err)
+	}
+	if rawCalls != 1 {
+		t.Fatalf("expected raw handler to run once, got %d", rawCalls)
+	}
+}
+
+func TestDispatcherPriorityOrder(t *testing.T) {
+	dispatcher := NewDispatcher()
+	var order []string
+	record := func(name string) EventHandler {
+		return func(ctx context.Context, event Event) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	dispatcher.On(EventReady, record("default-1"))
+	dispatcher.On(EventReady, record("low"), WithPriority(-5))
+	dispatcher.On(EventReady, record("high"), WithPriority(10))
+	dispatcher.On(EventReady, record("default-2"))
+
+	if err := dispatcher.Dispatch(context.Background(), &ReadyEvent{}); err != nil {
+		t.Fatalf("dispatch error: %v", err)
+	}
+	want := []string{"high", "default-1", "default-2", "low"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestDispatcherOnceWithFilter(t *testing.T) {
+	dispatcher := NewDispatcher()
+	var got []string
+
+	fromUser := WithFilter(func(event Event) bool {
+		evt := event.(*MessageCreateEvent)
+		return evt.Author != nil && evt.Author.ID == "u1" && evt.ChannelID == "c1"
+	})
+	dispatcher.Once(EventMessageCreate, func(ctx context.Context, event Event) error {
+		got = append(got, event.(*MessageCreateEvent).Content)
+		return nil
+	}, fromUser)
+
+	messages := []*MessageCreateEvent{
+		{Message: &types.Message{ChannelID: "c1", Author: &types.User{ID: "u2"}, Content: "other user"}},
+		{Message: &types.Message{ChannelID: "c1", Author: &types.User{ID: "u1"}, Content: "first"}},
+		{Message: &types.Message{ChannelID: "c1", Author: &types.User{ID: "u1"}, Content: "second"}},
+	}
+	for _, msg := range messages {
+		if err := dispatcher.Dispatch(context.Background(), msg); err != nil {
+			t.Fatalf("dispatch error: %v", err)
+		}
+	}
+	if len(got) != 1 || got[0] != "first" {
+		t.Fatalf("expected only the first matching message, got %v", got)
+	}
+}
+
+func TestDispatcherOff(t *testing.T) {
+	dispatcher := NewDispatcher()
+	calls := 0
+	id := dispatcher.On(EventReady, func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+
+	dispatcher.Dispatch(context.Background(), &ReadyEvent{})
+	if !dispatcher.Off(id) {
+		t.Fatalf("expected handler to be removed")
+	}
+	if dispatcher.Off(id) {
+		t.Fatalf("expected second Off to report false")
+	}
+	dispatcher.Dispatch(context.Background(), &ReadyEvent{})
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestDispatcherMiddleware(t *testing.T) {
+	var order []string
+	mw := func(name string) DispatchMiddleware {
+		return func(next EventHandler) EventHandler {
+			return func(ctx context.Context, event Event) error {
+				if _, ok := EventReceivedAt(ctx); !ok {
+					t.Errorf("%s: missing received time", name)
+				}
+				order = append(order, name)
+				return next(ctx, event)
+			}
+		}
+	}
+	dispatcher := NewDispatcher(WithDispatchMiddleware(mw("outer"), mw("inner")))
+	dispatcher.On(EventReady, func(ctx context.Context, event Event) error {
+		order = append(order, "handler")
+		return errors.New("boom")
+	})
+
+	if err := dispatcher.Dispatch(context.Background(), &ReadyEvent{}); err == nil {
+		t.Fatal("expected handler error through middleware")
+	}
+	if len(order) != 3 || order[0] != "outer" || order[1] != "inner" || order[2] != "handler" {
+		t.Fatalf("order = %v", order)
+	}
+}
@@ -1,6 +1,9 @@
 package gateway
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 )
 
@@ -11,6 +14,7 @@ type Event interface {
 
 const (
 	EventReady             = "READY"
+	EventResumed           = "RESUMED"
 	EventMessageCreate     = "MESSAGE_CREATE"
 	EventMessageUpdate     = "MESSAGE_UPDATE"
 	EventMessageDelete     = "MESSAGE_DELETE"
@@ -18,6 +22,48 @@ const (
 	EventGuildUpdate       = "GUILD_UPDATE"
 	EventGuildDelete       = "GUILD_DELETE"
 	EventInteractionCreate = "INTERACTION_CREATE"
+
+	EventGuildMemberAdd        = "GUILD_MEMBER_ADD"
+	EventGuildMemberUpdate     = "GUILD_MEMBER_UPDATE"
+	EventGuildMemberRemove     = "GUILD_MEMBER_REMOVE"
+	EventGuildMembersChunk     = "GUILD_MEMBERS_CHUNK"
+	EventGuildRoleCreate       = "GUILD_ROLE_CREATE"
+	EventGuildRoleUpdate       = "GUILD_ROLE_UPDATE"
+	EventGuildRoleDelete       = "GUILD_ROLE_DELETE"
+	EventGuildBanAdd           = "GUILD_BAN_ADD"
+	EventGuildBanRemove        = "GUILD_BAN_REMOVE"
+	EventGuildEmojisUpdate     = "GUILD_EMOJIS_UPDATE"
+	EventGuildStickersUpdate   = "GUILD_STICKERS_UPDATE"
+	EventMessageReactionAdd    = "MESSAGE_REACTION_ADD"
+	EventMessageReactionRemove = "MESSAGE_REACTION_REMOVE"
+	EventChannelCreate         = "CHANNEL_CREATE"
+	EventChannelUpdate         = "CHANNEL_UPDATE"
+	EventChannelDelete         = "CHANNEL_DELETE"
+	EventThreadCreate          = "THREAD_CREATE"
+	EventThreadUpdate          = "THREAD_UPDATE"
+	EventThreadDelete          = "THREAD_DELETE"
+	EventThreadListSync        = "THREAD_LIST_SYNC"
+	EventThreadMemberUpdate    = "THREAD_MEMBER_UPDATE"
+	EventThreadMembersUpdate   = "THREAD_MEMBERS_UPDATE"
+	EventVoiceStateUpdate      = "VOICE_STATE_UPDATE"
+	EventPresenceUpdate        = "PRESENCE_UPDATE"
+	EventTypingStart           = "TYPING_START"
+
+	EventAutoModerationRuleCreate      = "AUTO_MODERATION_RULE_CREATE"
+	EventAutoModerationRuleUpdate      = "AUTO_MODERATION_RULE_UPDATE"
+	EventAutoModerationRuleDelete      = "AUTO_MODERATION_RULE_DELETE"
+	EventAutoModerationActionExecution = "AUTO_MODERATION_ACTION_EXECUTION"
+
+	EventStageInstanceCreate = "STAGE_INSTANCE_CREATE"
+	EventStageInstanceUpdate = "STAGE_INSTANCE_UPDATE"
+	EventStageInstanceDelete = "STAGE_INSTANCE_DELETE"
+
+	EventMessagePollVoteAdd    = "MESSAGE_POLL_VOTE_ADD"
+	EventMessagePollVoteRemove = "MESSAGE_POLL_VOTE_REMOVE"
+
+	// EventRaw is the pseudo event type used to register fallback handlers
+	// that receive every dispatch without a typed decoder.
+	EventRaw = "RAW"
 )
 
 // ReadyEvent signals the gateway is ready for the client.
@@ -82,3 +128,331 @@ type GuildDeleteEvent struct {
 }
 
 func (e *GuildDeleteEvent) Type() string { return EventGuildDelete }
+
+// GuildMemberAddEvent fires when a user joins a guild.
+type GuildMemberAddEvent struct {
+	*types.Member
+	GuildID string `json:"guild_id"`
+}
+
+func (e *GuildMemberAddEvent) Type() string { return EventGuildMemberAdd }
+
+// GuildMemberUpdateEvent fires when a guild member is updated.
+type GuildMemberUpdateEvent struct {
+	*types.Member
+	GuildID string `json:"guild_id"`
+}
+
+func (e *GuildMemberUpdateEvent) Type() string { return EventGuildMemberUpdate }
+
+// GuildMemberRemoveEvent fires when a user leaves or is removed from a guild.
+type GuildMemberRemoveEvent struct {
+	GuildID string      `json:"guild_id"`
+	User    *types.User `json:"user"`
+}
+
+func (e *GuildMemberRemoveEvent) Type() string { return EventGuildMemberRemove }
+
+// GuildMembersChunkEvent carries one chunk of the response to a Request
+// Guild Members (opcode 8) request.
+type GuildMembersChunkEvent struct {
+	GuildID    string            `json:"guild_id"`
+	Members    []types.Member    `json:"members"`
+	ChunkIndex int               `json:"chunk_index"`
+	ChunkCount int               `json:"chunk_count"`
+	NotFound   []string          `json:"not_found,omitempty"`
+	Presences  []json.RawMessage `json:"presences,omitempty"`
+	Nonce      string            `json:"nonce,omitempty"`
+}
+
+func (e *GuildMembersChunkEvent) Type() string { return EventGuildMembersChunk }
+
+// GuildRoleCreateEvent fires when a role is created.
+type GuildRoleCreateEvent struct {
+	GuildID string      `json:"guild_id"`
+	Role    *types.Role `json:"role"`
+}
+
+func (e *GuildRoleCreateEvent) Type() string { return EventGuildRoleCreate }
+
+// GuildRoleUpdateEvent fires when a role is updated.
+type GuildRoleUpdateEvent struct {
+	GuildID string      `json:"guild_id"`
+	Role    *types.Role `json:"role"`
+}
+
+func (e *GuildRoleUpdateEvent) Type() string { return EventGuildRoleUpdate }
+
+// GuildRoleDeleteEvent fires when a role is deleted.
+type GuildRoleDeleteEvent struct {
+	GuildID string `json:"guild_id"`
+	RoleID  string `json:"role_id"`
+}
+
+func (e *GuildRoleDeleteEvent) Type() string { return EventGuildRoleDelete }
+
+// GuildBanAddEvent fires when a user is banned from a guild.
+type GuildBanAddEvent struct {
+	GuildID string      `json:"guild_id"`
+	User    *types.User `json:"user"`
+}
+
+func (e *GuildBanAddEvent) Type() string { return EventGuildBanAdd }
+
+// GuildBanRemoveEvent fires when a user is unbanned from a guild.
+type GuildBanRemoveEvent struct {
+	GuildID string      `json:"guild_id"`
+	User    *types.User `json:"user"`
+}
+
+func (e *GuildBanRemoveEvent) Type() string { return EventGuildBanRemove }
+
+// GuildEmojisUpdateEvent carries a guild's full emoji list after any change.
+type GuildEmojisUpdateEvent struct {
+	GuildID string        `json:"guild_id"`
+	Emojis  []types.Emoji `json:"emojis"`
+}
+
+func (e *GuildEmojisUpdateEvent) Type() string { return EventGuildEmojisUpdate }
+
+// GuildStickersUpdateEvent carries a guild's full sticker list after any change.
+type GuildStickersUpdateEvent struct {
+	GuildID  string          `json:"guild_id"`
+	Stickers []types.Sticker `json:"stickers"`
+}
+
+func (e *GuildStickersUpdateEvent) Type() string { return EventGuildStickersUpdate }
+
+// MessageReactionAddEvent fires when a user adds a reaction to a message.
+type MessageReactionAddEvent struct {
+	UserID          string        `json:"user_id"`
+	ChannelID       string        `json:"channel_id"`
+	MessageID       string        `json:"message_id"`
+	GuildID         string        `json:"guild_id,omitempty"`
+	Member          *types.Member `json:"member,omitempty"`
+	Emoji           types.Emoji   `json:"emoji"`
+	MessageAuthorID string        `json:"message_author_id,omitempty"`
+	Burst           bool          `json:"burst,omitempty"`
+	ReactionType    int           `json:"type,omitempty"`
+}
+
+func (e *MessageReactionAddEvent) Type() string { return EventMessageReactionAdd }
+
+// MessageReactionRemoveEvent fires when a user removes a reaction from a message.
+type MessageReactionRemoveEvent struct {
+	UserID       string      `json:"user_id"`
+	ChannelID    string      `json:"channel_id"`
+	MessageID    string      `json:"message_id"`
+	GuildID      string      `json:"guild_id,omitempty"`
+	Emoji        types.Emoji `json:"emoji"`
+	Burst        bool        `json:"burst,omitempty"`
+	ReactionType int         `json:"type,omitempty"`
+}
+
+func (e *MessageReactionRemoveEvent) Type() string { return EventMessageReactionRemove }
+
+// MessagePollVoteAddEvent fires when a user votes for a poll answer. It
+// requires IntentGuildMessagePolls or IntentDirectMessagePolls.
+type MessagePollVoteAddEvent struct {
+	UserID    string `json:"user_id"`
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id"`
+	GuildID   string `json:"guild_id,omitempty"`
+	AnswerID  int    `json:"answer_id"`
+}
+
+func (e *MessagePollVoteAddEvent) Type() string { return EventMessagePollVoteAdd }
+
+// MessagePollVoteRemoveEvent fires when a user removes a poll vote.
+type MessagePollVoteRemoveEvent struct {
+	UserID    string `json:"user_id"`
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id"`
+	GuildID   string `json:"guild_id,omitempty"`
+	AnswerID  int    `json:"answer_id"`
+}
+
+func (e *MessagePollVoteRemoveEvent) Type() string { return EventMessagePollVoteRemove }
+
+// ChannelCreateEvent fires when a guild channel is created.
+type ChannelCreateEvent struct {
+	*types.Channel
+}
+
+func (e *ChannelCreateEvent) Type() string { return EventChannelCreate }
+
+// ChannelUpdateEvent fires when a channel is updated.
+type ChannelUpdateEvent struct {
+	*types.Channel
+}
+
+func (e *ChannelUpdateEvent) Type() string { return EventChannelUpdate }
+
+// ChannelDeleteEvent fires when a channel is deleted.
+type ChannelDeleteEvent struct {
+	*types.Channel
+}
+
+func (e *ChannelDeleteEvent) Type() string { return EventChannelDelete }
+
+// ThreadCreateEvent fires when a thread is created or the bot is added to one.
+type ThreadCreateEvent struct {
+	*types.Channel
+	NewlyCreated bool `json:"newly_created,omitempty"`
+}
+
+func (e *ThreadCreateEvent) Type() string { return EventThreadCreate }
+
+// ThreadUpdateEvent fires when a thread is updated.
+type ThreadUpdateEvent struct {
+	*types.Channel
+}
+
+func (e *ThreadUpdateEvent) Type() string { return EventThreadUpdate }
+
+// ThreadDeleteEvent fires when a thread is deleted.
+type ThreadDeleteEvent struct {
+	ID          string            `json:"id"`
+	GuildID     string            `json:"guild_id"`
+	ParentID    string            `json:"parent_id,omitempty"`
+	ChannelType types.ChannelType `json:"type"`
+}
+
+func (e *ThreadDeleteEvent) Type() string { return EventThreadDelete }
+
+// ThreadListSyncEvent fires when the client gains access to a channel's threads.
+type ThreadListSyncEvent struct {
+	GuildID    string                `json:"guild_id"`
+	ChannelIDs []string              `json:"channel_ids,omitempty"`
+	Threads    []*types.Channel      `json:"threads"`
+	Members    []*types.ThreadMember `json:"members"`
+}
+
+func (e *ThreadListSyncEvent) Type() string { return EventThreadListSync }
+
+// ThreadMemberUpdateEvent fires when the current user's thread membership changes.
+type ThreadMemberUpdateEvent struct {
+	*types.ThreadMember
+	GuildID string `json:"guild_id"`
+}
+
+func (e *ThreadMemberUpdateEvent) Type() string { return EventThreadMemberUpdate }
+
+// ThreadMembersUpdateEvent fires when users are added to or removed from a thread.
+type ThreadMembersUpdateEvent struct {
+	ID               string                `json:"id"`
+	GuildID          string                `json:"guild_id"`
+	MemberCount      int                   `json:"member_count"`
+	AddedMembers     []*types.ThreadMember `json:"added_members,omitempty"`
+	RemovedMemberIDs []string              `json:"removed_member_ids,omitempty"`
+}
+
+func (e *ThreadMembersUpdateEvent) Type() string { return EventThreadMembersUpdate }
+
+// VoiceStateUpdateEvent fires when a user joins, leaves, or moves between voice channels.
+type VoiceStateUpdateEvent struct {
+	*types.VoiceState
+}
+
+func (e *VoiceStateUpdateEvent) Type() string { return EventVoiceStateUpdate }
+
+// ClientStatus reports a user's status per platform.
+type ClientStatus struct {
+	Desktop string `json:"desktop,omitempty"`
+	Mobile  string `json:"mobile,omitempty"`
+	Web     string `json:"web,omitempty"`
+}
+
+// PresenceUpdateEvent fires when a user's presence changes.
+type PresenceUpdateEvent struct {
+	User         *types.User  `json:"user"`
+	GuildID      string       `json:"guild_id"`
+	Status       string       `json:"status"`
+	Activities   []Activity   `json:"activities"`
+	ClientStatus ClientStatus `json:"client_status"`
+}
+
+func (e *PresenceUpdateEvent) Type() string { return EventPresenceUpdate }
+
+// TypingStartEvent fires when a user starts typing in a channel.
+type TypingStartEvent struct {
+	ChannelID string        `json:"channel_id"`
+	GuildID   string        `json:"guild_id,omitempty"`
+	UserID    string        `json:"user_id"`
+	Timestamp int64         `json:"timestamp"`
+	Member    *types.Member `json:"member,omitempty"`
+}
+
+func (e *TypingStartEvent) Type() string { return EventTypingStart }
+
+// Time returns the typing start timestamp as a time.Time.
+func (e *TypingStartEvent) Time() time.Time {
+	return time.Unix(e.Timestamp, 0)
+}
+
+// AutoModerationRuleCreateEvent fires when a rule is created. It requires
+// the AutoMod configuration intent.
+type AutoModerationRuleCreateEvent struct {
+	*types.AutoModerationRule
+}
+
+func (e *AutoModerationRuleCreateEvent) Type() string { return EventAutoModerationRuleCreate }
+
+// AutoModerationRuleUpdateEvent fires when a rule is updated.
+type AutoModerationRuleUpdateEvent struct {
+	*types.AutoModerationRule
+}
+
+func (e *AutoModerationRuleUpdateEvent) Type() string { return EventAutoModerationRuleUpdate }
+
+// AutoModerationRuleDeleteEvent fires when a rule is deleted.
+type AutoModerationRuleDeleteEvent struct {
+	*types.AutoModerationRule
+}
+
+func (e *AutoModerationRuleDeleteEvent) Type() string { return EventAutoModerationRuleDelete }
+
+// AutoModerationActionExecutionEvent fires when a rule triggers and an
+// action runs. It requires the AutoMod execution intent; Content and the
+// matched fields are empty without the message content intent.
+type AutoModerationActionExecutionEvent struct {
+	*types.AutoModerationActionExecution
+}
+
+func (e *AutoModerationActionExecutionEvent) Type() string {
+	return EventAutoModerationActionExecution
+}
+
+// StageInstanceCreateEvent fires when a stage goes live.
+type StageInstanceCreateEvent struct {
+	*types.StageInstance
+}
+
+func (e *StageInstanceCreateEvent) Type() string { return EventStageInstanceCreate }
+
+// StageInstanceUpdateEvent fires when a live stage's topic or privacy changes.
+type StageInstanceUpdateEvent struct {
+	*types.StageInstance
+}
+
+func (e *StageInstanceUpdateEvent) Type() string { return EventStageInstanceUpdate }
+
+// StageInstanceDeleteEvent fires when a stage ends.
+type StageInstanceDeleteEvent struct {
+	*types.StageInstance
+}
+
+func (e *StageInstanceDeleteEvent) Type() string { return EventStageInstanceDelete }
+
+// RawEvent carries a dispatch the SDK has no typed decoder for.
+type RawEvent struct {
+	EventType string
+	Data      json.RawMessage
+}
+
+func (e *RawEvent) Type() string { return e.EventType }
+
+// Decode unmarshals the raw payload into v.
+func (e *RawEvent) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
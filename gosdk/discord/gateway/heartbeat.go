@@ -0,0 +1,181 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+// Session tracks the gateway session ID and last sequence number needed to
+// heartbeat and resume. The zero value is ready to use and safe for
+// concurrent access.
+type Session struct {
+	mu       sync.Mutex
+	id       string
+	sequence int
+}
+
+// ID returns the session ID from the last READY, or "" before one arrives.
+func (s *Session) ID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id
+}
+
+// SetID records the session ID.
+func (s *Session) SetID(id string) {
+	s.mu.Lock()
+	s.id = id
+	s.mu.Unlock()
+}
+
+// Sequence returns the last sequence number observed.
+func (s *Session) Sequence() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sequence
+}
+
+// SetSequence records the last sequence number.
+func (s *Session) SetSequence(seq int) {
+	s.mu.Lock()
+	s.sequence = seq
+	s.mu.Unlock()
+}
+
+// Observe updates the sequence from a received payload.
+func (s *Session) Observe(payload *Payload) {
+	if payload == nil || payload.S <= 0 {
+		return
+	}
+	s.SetSequence(payload.S)
+}
+
+// Reset forgets the session so the next connection identifies from scratch.
+func (s *Session) Reset() {
+	s.mu.Lock()
+	s.id = ""
+	s.sequence = 0
+	s.mu.Unlock()
+}
+
+// ResumePayload builds the RESUME payload for the current session.
+func (s *Session) ResumePayload(token string) (*Payload, error) {
+	s.mu.Lock()
+	id, seq := s.id, s.sequence
+	s.mu.Unlock()
+
+	if id == "" {
+		return nil, &types.ValidationError{
+			Field:   "session_id",
+			Message: "session ID required to resume",
+		}
+	}
+	raw, err := json.Marshal(map[string]interface{}{
+		"token":      token,
+		"session_id": id,
+		"seq":        seq,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Payload{Op: OpCodeResume, D: raw}, nil
+}
+
+// SendFunc writes a payload to the gateway.
+type SendFunc func(ctx context.Context, payload *Payload) error
+
+// Heartbeater sends HEARTBEAT payloads on an interval through send, using
+// sequence for the last sequence number. Transports that don't manage their
+// own heartbeat can be driven by one.
+type Heartbeater struct {
+	send     SendFunc
+	sequence func() int
+	logger   *logger.Logger
+
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	interval time.Duration
+}
+
+// NewHeartbeater builds a Heartbeater. A nil sequence sends null sequences
+// and a nil logger uses logger.Default().
+func NewHeartbeater(send SendFunc, sequence func() int, l *logger.Logger) *Heartbeater {
+	if sequence == nil {
+		sequence = func() int { return 0 }
+	}
+	if l == nil {
+		l = logger.Default()
+	}
+	return &Heartbeater{send: send, sequence: sequence, logger: l}
+}
+
+// Start begins heartbeating every interval until ctx ends or Stop is called.
+// Starting a running Heartbeater restarts it with the new interval.
+func (h *Heartbeater) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	h.mu.Lock()
+	if h.cancel != nil {
+		h.cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	h.cancel = cancel
+	h.interval = interval
+	h.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := h.Beat(ctx); err != nil {
+					h.logger.Warn("heartbeat failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop halts the heartbeat loop. It is safe to call when not running.
+func (h *Heartbeater) Stop() {
+	h.mu.Lock()
+	cancel := h.cancel
+	h.cancel = nil
+	h.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// Running reports whether the heartbeat loop is active.
+func (h *Heartbeater) Running() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.cancel != nil
+}
+
+// Interval returns the interval passed to the last Start.
+func (h *Heartbeater) Interval() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.interval
+}
+
+// Beat sends a single heartbeat immediately.
+func (h *Heartbeater) Beat(ctx context.Context) error {
+	data := json.RawMessage("null")
+	if seq := h.sequence(); seq > 0 {
+		data = json.RawMessage(strconv.Itoa(seq))
+	}
+	return h.send(ctx, &Payload{Op: OpCodeHeartbeat, D: data})
+}
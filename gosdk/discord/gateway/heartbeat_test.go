@@ -0,0 +1,64 @@
+package gateway
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSessionResumePayload(t *testing.T) {
+	var s Session
+	if _, err := s.ResumePayload("token"); err == nil {
+		t.Fatal("expected error without session ID")
+	}
+	s.SetID("abc")
+	s.Observe(&Payload{S: 5})
+	s.Observe(&Payload{})
+	if s.Sequence() != 5 {
+		t.Fatalf("sequence = %d, want 5", s.Sequence())
+	}
+	payload, err := s.ResumePayload("token")
+	if err != nil {
+		t.Fatalf("ResumePayload() error = %v", err)
+	}
+	if payload.Op != OpCodeResume || string(payload.D) != `{"seq":5,"session_id":"abc","token":"token"}` {
+		t.Fatalf("unexpected resume payload %d %s", payload.Op, payload.D)
+	}
+	s.Reset()
+	if s.ID() != "" || s.Sequence() != 0 {
+		t.Fatalf("Reset() left %q/%d", s.ID(), s.Sequence())
+	}
+}
+
+func TestHeartbeaterStartStop(t *testing.T) {
+	var beats atomic.Int32
+	send := func(ctx context.Context, payload *Payload) error {
+		if payload.Op != OpCodeHeartbeat || string(payload.D) != "3" {
+			t.Errorf("unexpected heartbeat %d %s", payload.Op, payload.D)
+		}
+		beats.Add(1)
+		return nil
+	}
+	h := NewHeartbeater(send, func() int { return 3 }, nil)
+	h.Start(context.Background(), 5*time.Millisecond)
+	if !h.Running() || h.Interval() != 5*time.Millisecond {
+		t.Fatalf("Running() = %v, Interval() = %v", h.Running(), h.Interval())
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for beats.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	h.Stop()
+	if beats.Load() < 2 {
+		t.Fatalf("expected heartbeats, got %d", beats.Load())
+	}
+	if h.Running() {
+		t.Fatal("expected Stop to halt the loop")
+	}
+
+	if err := NewHeartbeater(send, func() int { return 3 }, nil).Beat(context.Background()); err != nil {
+		t.Fatalf("Beat() error = %v", err)
+	}
+}
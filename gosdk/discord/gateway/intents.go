@@ -1,5 +1,12 @@
 package gateway
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
 // Intent enumerates Discord gateway intents.
 type Intent int
 
@@ -21,8 +28,14 @@ const (
 	IntentDirectMessageTyping
 	IntentMessageContent
 	IntentGuildScheduledEvents
-	IntentAutoModerationConfiguration
-	IntentAutoModerationExecution
+)
+
+// Discord skips bits 17-19 and 22-23, so these intents are not part of the iota run.
+const (
+	IntentAutoModerationConfiguration Intent = 1 << 20
+	IntentAutoModerationExecution     Intent = 1 << 21
+	IntentGuildMessagePolls           Intent = 1 << 24
+	IntentDirectMessagePolls          Intent = 1 << 25
 )
 
 // AllIntents returns a mask with every intent enabled.
@@ -31,7 +44,8 @@ func AllIntents() Intent {
 		IntentGuildIntegrations | IntentGuildWebhooks | IntentGuildInvites | IntentGuildVoiceStates |
 		IntentGuildPresences | IntentGuildMessages | IntentGuildMessageReactions | IntentGuildMessageTyping |
 		IntentDirectMessages | IntentDirectMessageReactions | IntentDirectMessageTyping | IntentMessageContent |
-		IntentGuildScheduledEvents | IntentAutoModerationConfiguration | IntentAutoModerationExecution
+		IntentGuildScheduledEvents | IntentAutoModerationConfiguration | IntentAutoModerationExecution |
+		IntentGuildMessagePolls | IntentDirectMessagePolls
 }
 
 // DefaultIntents returns a safe intent mask for non-privileged bots.
@@ -47,3 +61,216 @@ func (i Intent) Has(intent Intent) bool {
 	}
 	return i&intent == intent
 }
+
+// PrivilegedIntents returns the intents that must be enabled in the
+// developer portal before Discord accepts them.
+func PrivilegedIntents() Intent {
+	return IntentGuildMembers | IntentGuildPresences | IntentMessageContent
+}
+
+// Intents starts an empty mask for chaining, e.g.
+// Intents().Guilds().GuildMessages().MessageContent().
+func Intents() Intent {
+	return 0
+}
+
+// With returns the mask with the given intents added.
+func (i Intent) With(intents ...Intent) Intent {
+	for _, intent := range intents {
+		i |= intent
+	}
+	return i
+}
+
+// Without returns the mask with the given intents removed.
+func (i Intent) Without(intents ...Intent) Intent {
+	for _, intent := range intents {
+		i &^= intent
+	}
+	return i
+}
+
+// Builder methods: each returns the mask with one more intent.
+
+func (i Intent) Guilds() Intent                 { return i | IntentGuilds }
+func (i Intent) GuildMembers() Intent           { return i | IntentGuildMembers }
+func (i Intent) GuildBans() Intent              { return i | IntentGuildBans }
+func (i Intent) GuildEmojis() Intent            { return i | IntentGuildEmojis }
+func (i Intent) GuildIntegrations() Intent      { return i | IntentGuildIntegrations }
+func (i Intent) GuildWebhooks() Intent          { return i | IntentGuildWebhooks }
+func (i Intent) GuildInvites() Intent           { return i | IntentGuildInvites }
+func (i Intent) GuildVoiceStates() Intent       { return i | IntentGuildVoiceStates }
+func (i Intent) GuildPresences() Intent         { return i | IntentGuildPresences }
+func (i Intent) GuildMessages() Intent          { return i | IntentGuildMessages }
+func (i Intent) GuildMessageReactions() Intent  { return i | IntentGuildMessageReactions }
+func (i Intent) GuildMessageTyping() Intent     { return i | IntentGuildMessageTyping }
+func (i Intent) DirectMessages() Intent         { return i | IntentDirectMessages }
+func (i Intent) DirectMessageReactions() Intent { return i | IntentDirectMessageReactions }
+func (i Intent) DirectMessageTyping() Intent    { return i | IntentDirectMessageTyping }
+func (i Intent) MessageContent() Intent         { return i | IntentMessageContent }
+func (i Intent) GuildScheduledEvents() Intent   { return i | IntentGuildScheduledEvents }
+func (i Intent) AutoModerationConfiguration() Intent {
+	return i | IntentAutoModerationConfiguration
+}
+func (i Intent) AutoModerationExecution() Intent { return i | IntentAutoModerationExecution }
+func (i Intent) GuildMessagePolls() Intent       { return i | IntentGuildMessagePolls }
+func (i Intent) DirectMessagePolls() Intent      { return i | IntentDirectMessagePolls }
+
+// intentNames lists each intent under Discord's name, followed by any older
+// names it is still known by.
+var intentNames = []struct {
+	intent  Intent
+	name    string
+	aliases []string
+}{
+	{IntentGuilds, "GUILDS", nil},
+	{IntentGuildMembers, "GUILD_MEMBERS", nil},
+	{IntentGuildBans, "GUILD_MODERATION", []string{"GUILD_BANS"}},
+	{IntentGuildEmojis, "GUILD_EMOJIS_AND_STICKERS", []string{"GUILD_EMOJIS"}},
+	{IntentGuildIntegrations, "GUILD_INTEGRATIONS", nil},
+	{IntentGuildWebhooks, "GUILD_WEBHOOKS", nil},
+	{IntentGuildInvites, "GUILD_INVITES", nil},
+	{IntentGuildVoiceStates, "GUILD_VOICE_STATES", nil},
+	{IntentGuildPresences, "GUILD_PRESENCES", nil},
+	{IntentGuildMessages, "GUILD_MESSAGES", nil},
+	{IntentGuildMessageReactions, "GUILD_MESSAGE_REACTIONS", nil},
+	{IntentGuildMessageTyping, "GUILD_MESSAGE_TYPING", nil},
+	{IntentDirectMessages, "DIRECT_MESSAGES", nil},
+	{IntentDirectMessageReactions, "DIRECT_MESSAGE_REACTIONS", nil},
+	{IntentDirectMessageTyping, "DIRECT_MESSAGE_TYPING", nil},
+	{IntentMessageContent, "MESSAGE_CONTENT", nil},
+	{IntentGuildScheduledEvents, "GUILD_SCHEDULED_EVENTS", nil},
+	{IntentAutoModerationConfiguration, "AUTO_MODERATION_CONFIGURATION", nil},
+	{IntentAutoModerationExecution, "AUTO_MODERATION_EXECUTION", nil},
+	{IntentGuildMessagePolls, "GUILD_MESSAGE_POLLS", nil},
+	{IntentDirectMessagePolls, "DIRECT_MESSAGE_POLLS", nil},
+}
+
+// IntentByName looks up a single intent by its Discord name, such as
+// GUILD_MESSAGES, or an older alias like GUILD_BANS. Matching ignores case.
+func IntentByName(name string) (Intent, bool) {
+	for _, n := range intentNames {
+		if strings.EqualFold(name, n.name) {
+			return n.intent, true
+		}
+		for _, alias := range n.aliases {
+			if strings.EqualFold(name, alias) {
+				return n.intent, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// String lists the intents in the mask using Discord's names, joined by "|".
+func (i Intent) String() string {
+	if i == 0 {
+		return "NONE"
+	}
+	var parts []string
+	rest := i
+	for _, n := range intentNames {
+		if i&n.intent != 0 {
+			parts = append(parts, n.name)
+			rest &^= n.intent
+		}
+	}
+	if rest != 0 {
+		parts = append(parts, fmt.Sprintf("0x%x", int(rest)))
+	}
+	return strings.Join(parts, "|")
+}
+
+// eventIntents maps dispatch events to the intents that deliver them; any
+// one of the listed intents is enough. Events missing from the map, such as
+// READY and INTERACTION_CREATE, are always sent.
+var eventIntents = map[string]Intent{
+	EventGuildCreate:                   IntentGuilds,
+	EventGuildUpdate:                   IntentGuilds,
+	EventGuildDelete:                   IntentGuilds,
+	EventGuildRoleCreate:               IntentGuilds,
+	EventGuildRoleUpdate:               IntentGuilds,
+	EventGuildRoleDelete:               IntentGuilds,
+	EventChannelCreate:                 IntentGuilds,
+	EventChannelUpdate:                 IntentGuilds,
+	EventChannelDelete:                 IntentGuilds,
+	EventThreadCreate:                  IntentGuilds,
+	EventThreadUpdate:                  IntentGuilds,
+	EventThreadDelete:                  IntentGuilds,
+	EventThreadListSync:                IntentGuilds,
+	EventThreadMemberUpdate:            IntentGuilds,
+	EventThreadMembersUpdate:           IntentGuilds | IntentGuildMembers,
+	EventStageInstanceCreate:           IntentGuilds,
+	EventStageInstanceUpdate:           IntentGuilds,
+	EventStageInstanceDelete:           IntentGuilds,
+	EventGuildMemberAdd:                IntentGuildMembers,
+	EventGuildMemberUpdate:             IntentGuildMembers,
+	EventGuildMemberRemove:             IntentGuildMembers,
+	EventGuildBanAdd:                   IntentGuildBans,
+	EventGuildBanRemove:                IntentGuildBans,
+	EventGuildEmojisUpdate:             IntentGuildEmojis,
+	EventGuildStickersUpdate:           IntentGuildEmojis,
+	EventVoiceStateUpdate:              IntentGuildVoiceStates,
+	EventPresenceUpdate:                IntentGuildPresences,
+	EventMessageCreate:                 IntentGuildMessages | IntentDirectMessages,
+	EventMessageUpdate:                 IntentGuildMessages | IntentDirectMessages,
+	EventMessageDelete:                 IntentGuildMessages | IntentDirectMessages,
+	EventMessageReactionAdd:            IntentGuildMessageReactions | IntentDirectMessageReactions,
+	EventMessageReactionRemove:         IntentGuildMessageReactions | IntentDirectMessageReactions,
+	EventTypingStart:                   IntentGuildMessageTyping | IntentDirectMessageTyping,
+	EventAutoModerationRuleCreate:      IntentAutoModerationConfiguration,
+	EventAutoModerationRuleUpdate:      IntentAutoModerationConfiguration,
+	EventAutoModerationRuleDelete:      IntentAutoModerationConfiguration,
+	EventAutoModerationActionExecution: IntentAutoModerationExecution,
+	EventMessagePollVoteAdd:            IntentGuildMessagePolls | IntentDirectMessagePolls,
+	EventMessagePollVoteRemove:         IntentGuildMessagePolls | IntentDirectMessagePolls,
+}
+
+// IntentWarning describes a handler whose events the configured intents
+// will not deliver, or will deliver without message content.
+type IntentWarning struct {
+	Event string
+	// Requires lists the intents that would cover the event; any one is enough.
+	Requires Intent
+	Message  string
+}
+
+func (w IntentWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Event, w.Message)
+}
+
+// CheckIntents reports events that mask does not cover. Message create and
+// update handlers also get a warning when MESSAGE_CONTENT is missing, since
+// Discord then blanks content, embeds, attachments and components.
+func CheckIntents(mask Intent, events []string) []IntentWarning {
+	var warnings []IntentWarning
+	for _, event := range events {
+		required, ok := eventIntents[event]
+		if !ok {
+			continue
+		}
+		if mask&required == 0 {
+			warnings = append(warnings, IntentWarning{
+				Event:    event,
+				Requires: required,
+				Message:  fmt.Sprintf("handler registered but intents do not include %s", required),
+			})
+			continue
+		}
+		if (event == EventMessageCreate || event == EventMessageUpdate) && !mask.Has(IntentMessageContent) {
+			warnings = append(warnings, IntentWarning{
+				Event:    event,
+				Requires: IntentMessageContent,
+				Message:  "message content will be empty without MESSAGE_CONTENT",
+			})
+		}
+	}
+	return warnings
+}
+
+// logIntentWarnings logs each warning from CheckIntents.
+func logIntentWarnings(l *logger.Logger, warnings []IntentWarning) {
+	for _, w := range warnings {
+		l.Warn("gateway intents do not cover handler", "event", w.Event, "requires", w.Requires.String(), "detail", w.Message)
+	}
+}
@@ -1,6 +1,9 @@
 package gateway
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestAllIntentsIncludesEverything(t *testing.T) {
 	mask := AllIntents()
@@ -27,3 +30,105 @@ func TestHasHandlesZero(t *testing.T) {
 		t.Fatalf("mask should always report true for zero intent")
 	}
 }
+
+func TestIntentBitsMatchDiscord(t *testing.T) {
+	bits := map[Intent]Intent{
+		IntentMessageContent:              1 << 15,
+		IntentGuildScheduledEvents:        1 << 16,
+		IntentAutoModerationConfiguration: 1 << 20,
+		IntentAutoModerationExecution:     1 << 21,
+		IntentGuildMessagePolls:           1 << 24,
+		IntentDirectMessagePolls:          1 << 25,
+	}
+	for intent, want := range bits {
+		if intent != want {
+			t.Fatalf("intent %d should be %d", intent, want)
+		}
+	}
+}
+
+func TestIntentsBuilder(t *testing.T) {
+	mask := Intents().Guilds().GuildMessages().MessageContent()
+	if mask != IntentGuilds|IntentGuildMessages|IntentMessageContent {
+		t.Fatalf("builder mask = %v", mask)
+	}
+	if got := mask.Without(IntentMessageContent); got.Has(IntentMessageContent) || !got.Has(IntentGuilds) {
+		t.Fatalf("Without() = %v", got)
+	}
+	if got := Intents().With(IntentGuildBans, IntentGuildInvites); got != IntentGuildBans|IntentGuildInvites {
+		t.Fatalf("With() = %v", got)
+	}
+	if s := mask.String(); s != "GUILDS|GUILD_MESSAGES|MESSAGE_CONTENT" {
+		t.Fatalf("String() = %q", s)
+	}
+	if s := Intent(0).String(); s != "NONE" {
+		t.Fatalf("zero String() = %q", s)
+	}
+	if s := (IntentGuilds | 1<<30).String(); s != "GUILDS|0x40000000" {
+		t.Fatalf("unknown bit String() = %q", s)
+	}
+}
+
+func TestCheckIntents(t *testing.T) {
+	events := []string{EventGuildMemberAdd, EventInteractionCreate, EventMessageCreate, EventTypingStart}
+	warnings := CheckIntents(Intents().Guilds().DirectMessages(), events)
+
+	byEvent := map[string]IntentWarning{}
+	for _, w := range warnings {
+		byEvent[w.Event] = w
+	}
+	if len(warnings) != 3 {
+		t.Fatalf("warnings = %v", warnings)
+	}
+	if w := byEvent[EventGuildMemberAdd]; w.Requires != IntentGuildMembers {
+		t.Fatalf("member warning = %+v", w)
+	}
+	if w := byEvent[EventMessageCreate]; w.Requires != IntentMessageContent {
+		t.Fatalf("expected message content warning, got %+v", w)
+	}
+	if w := byEvent[EventTypingStart]; w.Requires != IntentGuildMessageTyping|IntentDirectMessageTyping {
+		t.Fatalf("typing warning = %+v", w)
+	}
+
+	if warnings := CheckIntents(AllIntents(), events); len(warnings) != 0 {
+		t.Fatalf("expected no warnings with all intents, got %v", warnings)
+	}
+}
+
+func TestClientCheckIntents(t *testing.T) {
+	client, err := NewClient("token", int(Intents().Guilds()), WithTransport(NewPipeTransport(0)))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client.Dispatcher().OnGuildBanAdd(func(context.Context, *GuildBanAddEvent) error { return nil })
+	warnings := client.CheckIntents()
+	if len(warnings) != 1 || warnings[0].Event != EventGuildBanAdd {
+		t.Fatalf("warnings = %v", warnings)
+	}
+	if types := client.Dispatcher().EventTypes(); len(types) != 1 || types[0] != EventGuildBanAdd {
+		t.Fatalf("EventTypes() = %v", types)
+	}
+}
+
+func TestIntentByName(t *testing.T) {
+	tests := map[string]Intent{
+		"GUILD_MESSAGES":   IntentGuildMessages,
+		"message_content":  IntentMessageContent,
+		"guild_bans":       IntentGuildBans,
+		"GUILD_MODERATION": IntentGuildBans,
+		"guild_emojis":     IntentGuildEmojis,
+	}
+	for name, want := range tests {
+		if got, ok := IntentByName(name); !ok || got != want {
+			t.Errorf("IntentByName(%q) = %v, %v, want %v", name, got, ok, want)
+		}
+	}
+	if _, ok := IntentByName("guild_everything"); ok {
+		t.Error("expected unknown intent to be rejected")
+	}
+	for _, n := range intentNames {
+		if got, ok := IntentByName(n.intent.String()); !ok || got != n.intent {
+			t.Errorf("IntentByName(%q) does not round-trip", n.intent.String())
+		}
+	}
+}
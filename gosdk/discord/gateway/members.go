@@ -0,0 +1,141 @@
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// DefaultMemberChunkTimeout bounds FetchGuildMembers when ctx has no deadline.
+const DefaultMemberChunkTimeout = 30 * time.Second
+
+// RequestGuildMembersParams describes a Request Guild Members (opcode 8)
+// request. Set either Query (an empty query with Limit 0 requests every
+// member, which needs the GUILD_MEMBERS intent) or UserIDs.
+type RequestGuildMembersParams struct {
+	GuildID string
+	// Query matches the start of usernames. Ignored when UserIDs is set.
+	Query string
+	Limit int
+	// UserIDs fetches specific members, up to 100.
+	UserIDs []string
+	// Presences includes presences in the chunks; needs GUILD_PRESENCES.
+	Presences bool
+	// Nonce identifies the chunks answering this request. FetchGuildMembers
+	// generates one when empty.
+	Nonce string
+}
+
+// Validate checks the request before it is sent.
+func (p *RequestGuildMembersParams) Validate() error {
+	if p == nil || p.GuildID == "" {
+		return &types.ValidationError{Field: "guild_id", Message: "guild_id is required"}
+	}
+	if p.Limit < 0 {
+		return &types.ValidationError{Field: "limit", Message: "limit cannot be negative"}
+	}
+	if len(p.UserIDs) > 100 {
+		return &types.ValidationError{Field: "user_ids", Message: "cannot request more than 100 user IDs"}
+	}
+	if len(p.Nonce) > 32 {
+		return &types.ValidationError{Field: "nonce", Message: "nonce cannot exceed 32 bytes"}
+	}
+	return nil
+}
+
+func (p *RequestGuildMembersParams) payload() ([]byte, error) {
+	data := struct {
+		GuildID   string   `json:"guild_id"`
+		Query     *string  `json:"query,omitempty"`
+		Limit     int      `json:"limit"`
+		Presences bool     `json:"presences,omitempty"`
+		UserIDs   []string `json:"user_ids,omitempty"`
+		Nonce     string   `json:"nonce,omitempty"`
+	}{GuildID: p.GuildID, Limit: p.Limit, Presences: p.Presences, UserIDs: p.UserIDs, Nonce: p.Nonce}
+	if len(p.UserIDs) == 0 {
+		data.Query = &p.Query
+	}
+	return json.Marshal(data)
+}
+
+// FetchGuildMembers sends a Request Guild Members request and collects every
+// GUILD_MEMBERS_CHUNK answering it. It returns once the last chunk arrives,
+// or with ctx's error (after DefaultMemberChunkTimeout if ctx has no
+// deadline) if the chunks stop coming.
+func (c *Client) FetchGuildMembers(ctx context.Context, params RequestGuildMembersParams) ([]types.Member, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if c.conn == nil {
+		return nil, types.ErrNotConnected
+	}
+	if params.Nonce == "" {
+		nonce, err := newNonce()
+		if err != nil {
+			return nil, err
+		}
+		params.Nonce = nonce
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultMemberChunkTimeout)
+		defer cancel()
+	}
+
+	var (
+		mu       sync.Mutex
+		members  []types.Member
+		received int
+		done     = make(chan struct{})
+	)
+	id := c.dispatcher.OnGuildMembersChunk(func(_ context.Context, chunk *GuildMembersChunkEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if received >= chunk.ChunkCount && received > 0 {
+			return nil
+		}
+		members = append(members, chunk.Members...)
+		received++
+		if received >= chunk.ChunkCount {
+			close(done)
+		}
+		return nil
+	}, WithFilter(func(e Event) bool {
+		chunk, ok := e.(*GuildMembersChunkEvent)
+		return ok && chunk.Nonce == params.Nonce && chunk.GuildID == params.GuildID
+	}))
+	defer c.dispatcher.Off(id)
+
+	raw, err := params.payload()
+	if err != nil {
+		return nil, fmt.Errorf("marshal guild member request: %w", err)
+	}
+	if err := c.conn.Send(ctx, &Payload{Op: OpCodeRequestGuildMembers, D: raw}); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-done:
+		mu.Lock()
+		defer mu.Unlock()
+		return members, nil
+	case <-ctx.Done():
+		mu.Lock()
+		defer mu.Unlock()
+		return nil, fmt.Errorf("guild member chunks incomplete (%d received): %w", received, ctx.Err())
+	}
+}
+
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFetchGuildMembersCollectsChunks(t *testing.T) {
+	pipe := NewPipeTransport(0)
+	client, err := NewClient("token", 0, WithTransport(pipe))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+	nextSent(t, ctx, pipe, OpCodeIdentify)
+
+	type result struct {
+		count int
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		members, err := client.FetchGuildMembers(ctx, RequestGuildMembersParams{GuildID: "g1"})
+		done <- result{len(members), err}
+	}()
+
+	request := nextSent(t, ctx, pipe, OpCodeRequestGuildMembers)
+	var body map[string]interface{}
+	if err := json.Unmarshal(request.D, &body); err != nil {
+		t.Fatalf("unmarshal request: %v", err)
+	}
+	nonce, _ := body["nonce"].(string)
+	if nonce == "" || body["query"] != "" || body["limit"] != float64(0) {
+		t.Fatalf("unexpected request %s", request.D)
+	}
+
+	chunk := func(index int, nonce string, ids ...string) *Payload {
+		members := make([]map[string]interface{}, len(ids))
+		for i, id := range ids {
+			members[i] = map[string]interface{}{"user": map[string]string{"id": id}}
+		}
+		raw, _ := json.Marshal(map[string]interface{}{
+			"guild_id": "g1", "members": members, "chunk_index": index, "chunk_count": 2, "nonce": nonce,
+		})
+		return &Payload{Op: OpCodeDispatch, T: EventGuildMembersChunk, D: raw}
+	}
+	pipe.Push(ctx, chunk(0, nonce, "u1", "u2"))
+	pipe.Push(ctx, chunk(0, "other", "x1"))
+	pipe.Push(ctx, chunk(1, nonce, "u3"))
+
+	select {
+	case r := <-done:
+		if r.err != nil || r.count != 3 {
+			t.Fatalf("FetchGuildMembers() = %d members, %v", r.count, r.err)
+		}
+	case <-ctx.Done():
+		t.Fatal("FetchGuildMembers did not return")
+	}
+}
+
+func TestFetchGuildMembersTimeout(t *testing.T) {
+	pipe := NewPipeTransport(0)
+	client, err := NewClient("token", 0, WithTransport(pipe))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+	nextSent(t, ctx, pipe, OpCodeIdentify)
+
+	short, cancelShort := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancelShort()
+	_, err = client.FetchGuildMembers(short, RequestGuildMembersParams{GuildID: "g1", UserIDs: []string{"u1"}, Nonce: "n1"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+}
+
+func TestRequestGuildMembersParamsValidate(t *testing.T) {
+	ids := make([]string, 101)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+	bad := []RequestGuildMembersParams{
+		{},
+		{GuildID: "g", Limit: -1},
+		{GuildID: "g", UserIDs: ids},
+		{GuildID: "g", Nonce: "0123456789012345678901234567890123"},
+	}
+	for _, p := range bad {
+		if err := p.Validate(); err == nil {
+			t.Fatalf("expected error for %+v", p)
+		}
+	}
+	raw, _ := (&RequestGuildMembersParams{GuildID: "g", Query: "ignored", UserIDs: []string{"1"}}).payload()
+	if string(raw) != `{"guild_id":"g","limit":0,"user_ids":["1"]}` {
+		t.Fatalf("payload = %s", raw)
+	}
+}
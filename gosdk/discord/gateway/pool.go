@@ -0,0 +1,357 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// WithWorkers runs handlers on n goroutines fed by a queue of the given
+// size instead of inline on the gateway read loop. Dispatch then returns
+// once the event is queued; handler errors are logged rather than returned.
+// When the queue is full Dispatch blocks until there is room, so events are
+// never dropped and a slow handler slows the read loop instead.
+// Client.Disconnect drains the queue and stops the workers, and Connect
+// starts them again.
+func WithWorkers(n, queue int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.workers = n
+			d.queueSize = queue
+		}
+	}
+}
+
+// WithGuildOrdering makes a worker pool deliver events for the same guild
+// in order by pinning each guild to one worker. Events without a guild
+// share a worker. Each worker then has its own queue of the configured size.
+func WithGuildOrdering() DispatcherOption {
+	return func(d *Dispatcher) {
+		d.guildOrdering = true
+	}
+}
+
+// DispatchStats reports worker pool activity. The counters are cumulative.
+type DispatchStats struct {
+	Workers       int
+	QueueCapacity int
+	// QueueDepth is the number of events waiting for a worker.
+	QueueDepth int
+	Enqueued   uint64
+	Completed  uint64
+	// Failed counts events for which at least one handler returned an error.
+	Failed uint64
+	// Blocked counts Dispatch calls that waited for queue space, and
+	// BlockedTime the total time spent waiting.
+	Blocked     uint64
+	BlockedTime time.Duration
+}
+
+type dispatchJob struct {
+	ctx   context.Context
+	event Event
+}
+
+type dispatchPool struct {
+	workers       int
+	queueSize     int
+	guildOrdering bool
+	deliver       EventHandler
+
+	// lifecycle serializes start and close, so workers are never launched
+	// while a close is still waiting for the previous ones.
+	lifecycle sync.Mutex
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	queues    []chan dispatchJob
+	closed    bool
+
+	enqueued    atomic.Uint64
+	completed   atomic.Uint64
+	failed      atomic.Uint64
+	blocked     atomic.Uint64
+	blockedTime atomic.Int64
+}
+
+// startPool sets up the workers configured by WithWorkers.
+func (d *Dispatcher) startPool() {
+	if d.workers <= 0 {
+		return
+	}
+	if d.queueSize < 1 {
+		d.queueSize = 1
+	}
+	d.pool = &dispatchPool{
+		workers:       d.workers,
+		queueSize:     d.queueSize,
+		guildOrdering: d.guildOrdering,
+		deliver:       d.deliver,
+		closed:        true,
+	}
+	d.pool.start()
+}
+
+// start launches fresh queues and workers if the pool is closed. The
+// counters carry over, so Stats stays cumulative across restarts.
+func (p *dispatchPool) start() {
+	p.lifecycle.Lock()
+	defer p.lifecycle.Unlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		return
+	}
+
+	if p.guildOrdering {
+		p.queues = make([]chan dispatchJob, p.workers)
+		for i := range p.queues {
+			p.queues[i] = make(chan dispatchJob, p.queueSize)
+		}
+	} else {
+		p.queues = []chan dispatchJob{make(chan dispatchJob, p.queueSize)}
+	}
+	for i := 0; i < p.workers; i++ {
+		queue := p.queues[i%len(p.queues)]
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for job := range queue {
+				if err := p.deliver(job.ctx, job.event); err != nil {
+					p.failed.Add(1)
+				}
+				p.completed.Add(1)
+			}
+		}()
+	}
+	p.closed = false
+}
+
+// enqueue hands event to a worker, waiting for queue space if necessary.
+func (p *dispatchPool) enqueue(ctx context.Context, event Event) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return types.ErrQueueClosed
+	}
+
+	queue := p.queues[0]
+	if len(p.queues) > 1 {
+		h := fnv.New32a()
+		h.Write([]byte(eventGuildID(event)))
+		queue = p.queues[h.Sum32()%uint32(len(p.queues))]
+	}
+
+	job := dispatchJob{ctx: ctx, event: event}
+	select {
+	case queue <- job:
+		p.enqueued.Add(1)
+		return nil
+	default:
+	}
+
+	p.blocked.Add(1)
+	start := time.Now()
+	defer func() { p.blockedTime.Add(int64(time.Since(start))) }()
+	select {
+	case queue <- job:
+		p.enqueued.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close stops accepting events and waits for the workers to finish the
+// queued ones.
+func (p *dispatchPool) close() {
+	p.lifecycle.Lock()
+	defer p.lifecycle.Unlock()
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, queue := range p.queues {
+			close(queue)
+		}
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// Stats reports worker pool metrics. It is the zero value when the
+// dispatcher runs handlers inline.
+func (d *Dispatcher) Stats() DispatchStats {
+	p := d.pool
+	if p == nil {
+		return DispatchStats{}
+	}
+	stats := DispatchStats{
+		Workers:     d.workers,
+		Enqueued:    p.enqueued.Load(),
+		Completed:   p.completed.Load(),
+		Failed:      p.failed.Load(),
+		Blocked:     p.blocked.Load(),
+		BlockedTime: time.Duration(p.blockedTime.Load()),
+	}
+	p.mu.RLock()
+	for _, queue := range p.queues {
+		stats.QueueCapacity += cap(queue)
+		stats.QueueDepth += len(queue)
+	}
+	p.mu.RUnlock()
+	return stats
+}
+
+// Close waits for queued events to be handled and stops the worker pool.
+// Later Dispatch calls return types.ErrQueueClosed until the pool is started
+// again, which Client.Connect and ShardManager.Connect do. Close is a no-op
+// for dispatchers without workers.
+func (d *Dispatcher) Close() {
+	if d.pool != nil {
+		d.pool.close()
+	}
+}
+
+// start restarts a worker pool stopped by Close. It is a no-op for running
+// pools and for dispatchers without workers.
+func (d *Dispatcher) start() {
+	if d.pool != nil {
+		d.pool.start()
+	}
+}
+
+// eventGuildID returns the guild an event belongs to, or "" for events
+// outside a guild.
+func eventGuildID(event Event) string {
+	switch e := event.(type) {
+	case *GuildCreateEvent:
+		if e.Guild != nil {
+			return e.Guild.ID
+		}
+	case *GuildUpdateEvent:
+		if e.Guild != nil {
+			return e.Guild.ID
+		}
+	case *MessageCreateEvent:
+		if e.Message != nil {
+			return e.GuildID
+		}
+	case *MessageUpdateEvent:
+		if e.Message != nil {
+			return e.GuildID
+		}
+	case *InteractionCreateEvent:
+		if e.Interaction != nil {
+			return e.GuildID
+		}
+	case *ChannelCreateEvent:
+		if e.Channel != nil {
+			return e.GuildID
+		}
+	case *ChannelUpdateEvent:
+		if e.Channel != nil {
+			return e.GuildID
+		}
+	case *ChannelDeleteEvent:
+		if e.Channel != nil {
+			return e.GuildID
+		}
+	case *ThreadCreateEvent:
+		if e.Channel != nil {
+			return e.GuildID
+		}
+	case *ThreadUpdateEvent:
+		if e.Channel != nil {
+			return e.GuildID
+		}
+	case *VoiceStateUpdateEvent:
+		if e.VoiceState != nil {
+			return e.GuildID
+		}
+	case *AutoModerationRuleCreateEvent:
+		if e.AutoModerationRule != nil {
+			return e.GuildID
+		}
+	case *AutoModerationRuleUpdateEvent:
+		if e.AutoModerationRule != nil {
+			return e.GuildID
+		}
+	case *AutoModerationRuleDeleteEvent:
+		if e.AutoModerationRule != nil {
+			return e.GuildID
+		}
+	case *AutoModerationActionExecutionEvent:
+		if e.AutoModerationActionExecution != nil {
+			return e.GuildID
+		}
+	case *StageInstanceCreateEvent:
+		if e.StageInstance != nil {
+			return e.GuildID
+		}
+	case *StageInstanceUpdateEvent:
+		if e.StageInstance != nil {
+			return e.GuildID
+		}
+	case *StageInstanceDeleteEvent:
+		if e.StageInstance != nil {
+			return e.GuildID
+		}
+	case *MessageDeleteEvent:
+		return e.GuildID
+	case *GuildDeleteEvent:
+		return e.GuildID
+	case *GuildMemberAddEvent:
+		return e.GuildID
+	case *GuildMemberUpdateEvent:
+		return e.GuildID
+	case *GuildMemberRemoveEvent:
+		return e.GuildID
+	case *GuildMembersChunkEvent:
+		return e.GuildID
+	case *GuildRoleCreateEvent:
+		return e.GuildID
+	case *GuildRoleUpdateEvent:
+		return e.GuildID
+	case *GuildRoleDeleteEvent:
+		return e.GuildID
+	case *GuildBanAddEvent:
+		return e.GuildID
+	case *GuildBanRemoveEvent:
+		return e.GuildID
+	case *GuildEmojisUpdateEvent:
+		return e.GuildID
+	case *GuildStickersUpdateEvent:
+		return e.GuildID
+	case *MessageReactionAddEvent:
+		return e.GuildID
+	case *MessageReactionRemoveEvent:
+		return e.GuildID
+	case *MessagePollVoteAddEvent:
+		return e.GuildID
+	case *MessagePollVoteRemoveEvent:
+		return e.GuildID
+	case *ThreadDeleteEvent:
+		return e.GuildID
+	case *ThreadListSyncEvent:
+		return e.GuildID
+	case *ThreadMemberUpdateEvent:
+		return e.GuildID
+	case *ThreadMembersUpdateEvent:
+		return e.GuildID
+	case *PresenceUpdateEvent:
+		return e.GuildID
+	case *TypingStartEvent:
+		return e.GuildID
+	case *RawEvent:
+		var data struct {
+			GuildID string `json:"guild_id"`
+		}
+		_ = json.Unmarshal(e.Data, &data)
+		return data.GuildID
+	}
+	return ""
+}
@@ -0,0 +1,218 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestDispatcherWorkersRunOffCaller(t *testing.T) {
+	d := NewDispatcher(WithWorkers(2, 4))
+	release := make(chan struct{})
+	done := make(chan struct{}, 2)
+	d.On(EventMessageCreate, func(ctx context.Context, e Event) error {
+		<-release
+		done <- struct{}{}
+		return errors.New("boom")
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := d.Dispatch(ctx, &MessageCreateEvent{Message: &types.Message{}}); err != nil {
+			t.Fatalf("Dispatch() error = %v", err)
+		}
+	}
+	close(release)
+	<-done
+	<-done
+	d.Close()
+
+	stats := d.Stats()
+	if stats.Workers != 2 || stats.Enqueued != 2 || stats.Completed != 2 || stats.Failed != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if err := d.Dispatch(ctx, &MessageCreateEvent{}); !errors.Is(err, types.ErrQueueClosed) {
+		t.Fatalf("Dispatch after Close error = %v", err)
+	}
+}
+
+func TestDispatcherBackpressure(t *testing.T) {
+	d := NewDispatcher(WithWorkers(1, 1))
+	defer d.Close()
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	d.On(EventTypingStart, func(ctx context.Context, e Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+
+	ctx := context.Background()
+	d.Dispatch(ctx, &TypingStartEvent{}) // occupies the worker
+	<-started
+	d.Dispatch(ctx, &TypingStartEvent{}) // fills the queue
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- d.Dispatch(ctx, &TypingStartEvent{}) }()
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case err := <-blocked:
+		t.Fatalf("Dispatch returned %v while queue was full", err)
+	default:
+	}
+	if depth := d.Stats().QueueDepth; depth != 1 {
+		t.Fatalf("QueueDepth = %d, want 1", depth)
+	}
+
+	close(release)
+	if err := <-blocked; err != nil {
+		t.Fatalf("blocked Dispatch error = %v", err)
+	}
+	if stats := d.Stats(); stats.Blocked != 1 || stats.BlockedTime <= 0 {
+		t.Fatalf("unexpected backpressure stats %+v", stats)
+	}
+
+}
+
+func TestDispatcherEnqueueHonoursContext(t *testing.T) {
+	d := NewDispatcher(WithWorkers(1, 1))
+	defer d.Close()
+	hold := make(chan struct{})
+	defer close(hold)
+	started := make(chan struct{}, 1)
+	d.On(EventTypingStart, func(context.Context, Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-hold
+		return nil
+	})
+
+	ctx := context.Background()
+	d.Dispatch(ctx, &TypingStartEvent{})
+	<-started
+	d.Dispatch(ctx, &TypingStartEvent{})
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := d.Dispatch(cancelled, &TypingStartEvent{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Dispatch with cancelled context error = %v", err)
+	}
+}
+
+func TestDispatcherGuildOrdering(t *testing.T) {
+	d := NewDispatcher(WithWorkers(4, 8), WithGuildOrdering())
+	var mu sync.Mutex
+	got := map[string][]string{}
+	var wg sync.WaitGroup
+	d.OnMessageDelete(func(ctx context.Context, e *MessageDeleteEvent) error {
+		defer wg.Done()
+		mu.Lock()
+		got[e.GuildID] = append(got[e.GuildID], e.ID)
+		mu.Unlock()
+		return nil
+	})
+
+	ctx := context.Background()
+	guilds := []string{"1", "2", "3", ""}
+	var want []string
+	for i := 0; i < 20; i++ {
+		id := string(rune('a' + i))
+		want = append(want, id)
+		for _, g := range guilds {
+			wg.Add(1)
+			d.Dispatch(ctx, &MessageDeleteEvent{ID: id, GuildID: g})
+		}
+	}
+	wg.Wait()
+	d.Close()
+
+	for _, g := range guilds {
+		if len(got[g]) != len(want) {
+			t.Fatalf("guild %q got %v", g, got[g])
+		}
+		for i := range want {
+			if got[g][i] != want[i] {
+				t.Fatalf("guild %q out of order: %v", g, got[g])
+			}
+		}
+	}
+}
+
+func TestEventGuildID(t *testing.T) {
+	tests := []struct {
+		event Event
+		want  string
+	}{
+		{&GuildCreateEvent{Guild: &types.Guild{ID: "g1"}}, "g1"},
+		{&GuildDeleteEvent{GuildID: "g2"}, "g2"},
+		{&MessageCreateEvent{Message: &types.Message{GuildID: "g3"}}, "g3"},
+		{&InteractionCreateEvent{}, ""},
+		{&ChannelCreateEvent{Channel: &types.Channel{GuildID: "g5"}}, "g5"},
+		{&TypingStartEvent{GuildID: "g6"}, "g6"},
+		{&ThreadCreateEvent{}, ""},
+		{&RawEvent{EventType: "X", Data: []byte(`{"guild_id":"g4"}`)}, "g4"},
+		{&ReadyEvent{}, ""},
+	}
+	for _, tt := range tests {
+		if got := eventGuildID(tt.event); got != tt.want {
+			t.Fatalf("eventGuildID(%T) = %q, want %q", tt.event, got, tt.want)
+		}
+	}
+}
+
+func TestDispatcherStatsInline(t *testing.T) {
+	d := NewDispatcher()
+	if stats := d.Stats(); stats != (DispatchStats{}) {
+		t.Fatalf("inline dispatcher stats = %+v", stats)
+	}
+	d.Close()
+}
+
+func TestClientReconnectRestartsWorkers(t *testing.T) {
+	d := NewDispatcher(WithWorkers(2, 8))
+	pipe := NewPipeTransport(0)
+	client, err := NewClient("token", 0, WithTransport(pipe), WithDispatcher(d))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	fired := make(chan string, 2)
+	d.OnMessageCreate(func(ctx context.Context, e *MessageCreateEvent) error {
+		fired <- e.ID
+		return nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	client.Disconnect()
+	if err := d.Dispatch(ctx, &ReadyEvent{}); !errors.Is(err, types.ErrQueueClosed) {
+		t.Fatalf("Dispatch() after Disconnect error = %v, want ErrQueueClosed", err)
+	}
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("second Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+	pipe.Push(ctx, &Payload{Op: OpCodeDispatch, T: EventMessageCreate, S: 1, D: json.RawMessage(`{"id":"m1","channel_id":"c"}`)})
+
+	select {
+	case id := <-fired:
+		if id != "m1" {
+			t.Fatalf("handler got %q, want m1", id)
+		}
+	case <-ctx.Done():
+		t.Fatal("handler did not fire after reconnecting")
+	}
+}
@@ -0,0 +1,162 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// ActivityType is the kind of activity shown in a presence.
+type ActivityType int
+
+const (
+	ActivityPlaying   ActivityType = 0
+	ActivityStreaming ActivityType = 1
+	ActivityListening ActivityType = 2
+	ActivityWatching  ActivityType = 3
+	ActivityCustom    ActivityType = 4
+	ActivityCompeting ActivityType = 5
+)
+
+// Presence statuses accepted by UpdatePresence and SetPresence.
+const (
+	StatusOnline    = "online"
+	StatusIdle      = "idle"
+	StatusDND       = "dnd"
+	StatusInvisible = "invisible"
+	StatusOffline   = "offline"
+)
+
+// Activity represents a Discord presence activity.
+type Activity struct {
+	Name string       `json:"name"`
+	Type ActivityType `json:"type"`
+	// URL is the stream link for ActivityStreaming (Twitch or YouTube).
+	URL string `json:"url,omitempty"`
+	// State is the custom status text for ActivityCustom, or the party
+	// status for other types.
+	State   string `json:"state,omitempty"`
+	Details string `json:"details,omitempty"`
+}
+
+// Playing returns a "Playing name" activity.
+func Playing(name string) *Activity { return &Activity{Name: name, Type: ActivityPlaying} }
+
+// Streaming returns a "Streaming name" activity linking to url.
+func Streaming(name, url string) *Activity {
+	return &Activity{Name: name, Type: ActivityStreaming, URL: url}
+}
+
+// Listening returns a "Listening to name" activity.
+func Listening(name string) *Activity { return &Activity{Name: name, Type: ActivityListening} }
+
+// Watching returns a "Watching name" activity.
+func Watching(name string) *Activity { return &Activity{Name: name, Type: ActivityWatching} }
+
+// Competing returns a "Competing in name" activity.
+func Competing(name string) *Activity { return &Activity{Name: name, Type: ActivityCompeting} }
+
+// CustomStatus returns a custom status showing text.
+func CustomStatus(text string) *Activity {
+	return &Activity{Name: "Custom Status", Type: ActivityCustom, State: text}
+}
+
+// Validate checks the activity before it is sent.
+func (a *Activity) Validate() error {
+	if a == nil {
+		return nil
+	}
+	switch a.Type {
+	case ActivityCustom:
+		if a.State == "" {
+			return &types.ValidationError{Field: "activity.state", Message: "custom status text is required"}
+		}
+	case ActivityPlaying, ActivityStreaming, ActivityListening, ActivityWatching, ActivityCompeting:
+		if a.Name == "" {
+			return &types.ValidationError{Field: "activity.name", Message: "activity name is required"}
+		}
+	default:
+		return &types.ValidationError{Field: "activity.type", Message: fmt.Sprintf("unknown activity type %d", a.Type)}
+	}
+	return nil
+}
+
+// PresenceUpdate describes the payload sent to the gateway.
+type PresenceUpdate struct {
+	// Since is when the client went idle, in Unix milliseconds.
+	Since      *int       `json:"since,omitempty"`
+	Activities []Activity `json:"activities,omitempty"`
+	Status     string     `json:"status,omitempty"`
+	AFK        bool       `json:"afk"`
+}
+
+// IdleSince sets Since from t.
+func (p *PresenceUpdate) IdleSince(t time.Time) *PresenceUpdate {
+	ms := int(t.UnixMilli())
+	p.Since = &ms
+	return p
+}
+
+// Validate checks the status and activities.
+func (p *PresenceUpdate) Validate() error {
+	switch p.Status {
+	case "", StatusOnline, StatusIdle, StatusDND, StatusInvisible, StatusOffline:
+	default:
+		return &types.ValidationError{Field: "status", Message: fmt.Sprintf("unknown status %q", p.Status)}
+	}
+	for i := range p.Activities {
+		if err := p.Activities[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdatePresence sends a presence update to the gateway and remembers the desired state.
+func (c *Client) UpdatePresence(ctx context.Context, status string, activity *Activity) error {
+	update := PresenceUpdate{Status: status}
+	if activity != nil {
+		update.Activities = []Activity{*activity}
+	}
+	return c.SetPresence(ctx, update)
+}
+
+// SetPresence sends a full presence update and remembers it, so it is
+// restored after the client identifies again.
+func (c *Client) SetPresence(ctx context.Context, update PresenceUpdate) error {
+	if err := update.Validate(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.presence = &update
+	c.mu.Unlock()
+
+	if c.conn == nil {
+		return types.ErrNotConnected
+	}
+	return c.sendPresence(ctx, update)
+}
+
+func (c *Client) sendPresence(ctx context.Context, update PresenceUpdate) error {
+	raw, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("marshal presence update: %w", err)
+	}
+	return c.conn.Send(ctx, &Payload{Op: OpCodePresenceUpdate, D: raw})
+}
+
+// restorePresence resends the last presence set on the client, if any.
+func (c *Client) restorePresence(ctx context.Context) {
+	c.mu.RLock()
+	presence := c.presence
+	c.mu.RUnlock()
+	if presence == nil {
+		return
+	}
+	if err := c.sendPresence(ctx, *presence); err != nil {
+		c.logger.Warn("restore presence failed", "error", err)
+	}
+}
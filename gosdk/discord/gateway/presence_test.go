@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestActivityValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		activity *Activity
+		valid    bool
+	}{
+		{"listening", Listening("lofi"), true},
+		{"streaming", Streaming("speedrun", "https://twitch.tv/x"), true},
+		{"custom", CustomStatus("on break"), true},
+		{"custom without text", &Activity{Type: ActivityCustom}, false},
+		{"unnamed", &Activity{Type: ActivityWatching}, false},
+		{"unknown type", &Activity{Name: "x", Type: 9}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.activity.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() error = %v, want valid=%v", tt.name, err, tt.valid)
+		}
+	}
+
+	if err := (&PresenceUpdate{Status: "busy"}).Validate(); err == nil {
+		t.Fatal("expected error for unknown status")
+	}
+}
+
+func TestSetPresenceSendsAndRestores(t *testing.T) {
+	pipe := NewPipeTransport(0)
+	client, err := NewClient("token", 0, WithTransport(pipe))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+	nextSent(t, ctx, pipe, OpCodeIdentify)
+
+	idle := time.UnixMilli(1700000000000)
+	update := (&PresenceUpdate{Status: StatusIdle, AFK: true, Activities: []Activity{*CustomStatus("brb")}}).IdleSince(idle)
+	if err := client.SetPresence(ctx, *update); err != nil {
+		t.Fatalf("SetPresence() error = %v", err)
+	}
+	sent := nextSent(t, ctx, pipe, OpCodePresenceUpdate)
+	want := `{"since":1700000000000,"activities":[{"name":"Custom Status","type":4,"state":"brb"}],"status":"idle","afk":true}`
+	if string(sent.D) != want {
+		t.Fatalf("presence payload = %s, want %s", sent.D, want)
+	}
+
+	// After a fresh identify the stored presence is sent again.
+	pipe.Push(ctx, &Payload{Op: OpCodeReconnect})
+	nextSent(t, ctx, pipe, OpCodeIdentify)
+	restored := nextSent(t, ctx, pipe, OpCodePresenceUpdate)
+	var got PresenceUpdate
+	if err := json.Unmarshal(restored.D, &got); err != nil || got.Status != StatusIdle || got.Activities[0].State != "brb" {
+		t.Fatalf("restored presence = %s (%v)", restored.D, err)
+	}
+
+	if err := client.SetPresence(ctx, PresenceUpdate{Activities: []Activity{{Type: ActivityCustom}}}); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
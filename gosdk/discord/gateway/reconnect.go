@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// errReconnectRequested is the cause recorded when Discord sends opcode 7.
+var errReconnectRequested = errors.New("gateway requested reconnect")
+
+// ReconnectPolicy controls how the client reconnects after the gateway
+// connection drops or Discord asks it to reconnect. The first attempt is
+// made immediately; later attempts wait BackoffBase, doubling up to
+// BackoffMax.
+type ReconnectPolicy struct {
+	// MaxAttempts stops reconnecting after this many consecutive failures.
+	// Zero retries until the context ends.
+	MaxAttempts int
+	// BackoffBase is the wait before the second attempt. Default 1s.
+	BackoffBase time.Duration
+	// BackoffMax caps the wait between attempts. Default 2m.
+	BackoffMax time.Duration
+	// Jitter randomizes each wait by up to half its length, so many shards
+	// or processes do not reconnect in lockstep.
+	Jitter bool
+}
+
+// DefaultReconnectPolicy retries forever with jittered backoff from 1s to 2m.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{BackoffBase: time.Second, BackoffMax: 2 * time.Minute, Jitter: true}
+}
+
+// WithReconnectPolicy replaces DefaultReconnectPolicy.
+func WithReconnectPolicy(p ReconnectPolicy) ClientOption {
+	return func(c *Client) {
+		c.reconnect = p
+	}
+}
+
+// delay returns the wait before the given attempt, counting from 1.
+func (p ReconnectPolicy) delay(attempt int) time.Duration {
+	if attempt <= 1 {
+		return 0
+	}
+	base, max := p.BackoffBase, p.BackoffMax
+	if base <= 0 {
+		base = time.Second
+	}
+	if max <= 0 {
+		max = 2 * time.Minute
+	}
+	d := max
+	if shift := attempt - 2; shift < 30 && base<<shift < max {
+		d = base << shift
+	}
+	if p.Jitter {
+		d -= time.Duration(rand.Int63n(int64(d)/2 + 1))
+	}
+	return d
+}
+
+// reconnectLoop reopens the connection after cause, resuming the session
+// when possible. It returns an error when the policy is exhausted, the
+// close is fatal, or ctx ends.
+func (c *Client) reconnectLoop(ctx context.Context, cause error) error {
+	c.heartbeater.Stop()
+	if err := c.conn.Close(); err != nil {
+		c.logger.Debug("close before reconnect failed", "error", err)
+	}
+	if ce, ok := AsCloseError(cause); ok && ce.ResetsSession() {
+		c.session.Reset()
+	}
+
+	for attempt := 1; ; attempt++ {
+		if ce, ok := AsCloseError(cause); ok && ce.IsFatal() {
+			return ce
+		}
+		if max := c.reconnect.MaxAttempts; max > 0 && attempt > max {
+			return fmt.Errorf("gateway reconnect failed after %d attempts: %w", max, cause)
+		}
+
+		delay := c.reconnect.delay(attempt)
+		c.setState(StateChange{State: StateReconnecting, Attempt: attempt, Delay: delay, Err: cause})
+		c.logger.Info("reconnecting to gateway", "attempt", attempt, "delay", delay, "cause", cause)
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if err := c.conn.Connect(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logger.Warn("reconnect failed", "attempt", attempt, "error", err)
+			cause = err
+			continue
+		}
+		if err := c.handshake(ctx); err != nil {
+			c.logger.Warn("handshake after reconnect failed", "attempt", attempt, "error", err)
+			_ = c.conn.Close()
+			cause = err
+			continue
+		}
+		return nil
+	}
+}
+
+// handshake resumes the session if there is one, and identifies otherwise.
+func (c *Client) handshake(ctx context.Context) error {
+	if resume, err := c.session.ResumePayload(c.token); err == nil {
+		return c.conn.Send(ctx, resume)
+	}
+	if err := c.identify(ctx); err != nil {
+		return err
+	}
+	c.restorePresence(ctx)
+	return nil
+}
@@ -0,0 +1,191 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestReconnectPolicyDelay(t *testing.T) {
+	p := ReconnectPolicy{BackoffBase: time.Second, BackoffMax: 5 * time.Second}
+	want := []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := p.delay(i + 1); got != w {
+			t.Fatalf("delay(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+	if got := p.delay(100); got != 5*time.Second {
+		t.Fatalf("delay(100) = %v, want cap", got)
+	}
+
+	p.Jitter = true
+	for i := 0; i < 50; i++ {
+		if got := p.delay(3); got < time.Second || got > 2*time.Second {
+			t.Fatalf("jittered delay %v outside [1s, 2s]", got)
+		}
+	}
+}
+
+type stateRecorder struct {
+	mu      sync.Mutex
+	changes []StateChange
+	ch      chan StateChange
+}
+
+func newStateRecorder() *stateRecorder {
+	return &stateRecorder{ch: make(chan StateChange, 32)}
+}
+
+func (r *stateRecorder) record(change StateChange) {
+	r.mu.Lock()
+	r.changes = append(r.changes, change)
+	r.mu.Unlock()
+	r.ch <- change
+}
+
+func (r *stateRecorder) wait(t *testing.T, ctx context.Context, state ConnectionState) StateChange {
+	t.Helper()
+	for {
+		select {
+		case change := <-r.ch:
+			if change.State == state {
+				return change
+			}
+		case <-ctx.Done():
+			t.Fatalf("did not observe state %s", state)
+		}
+	}
+}
+
+func TestClientReconnectsAfterReceiveFailure(t *testing.T) {
+	pipe := NewPipeTransport(0)
+	states := newStateRecorder()
+	client, err := NewClient("token", 0, WithTransport(pipe), WithStateListener(states.record))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+
+	nextSent(t, ctx, pipe, OpCodeIdentify)
+	pipe.Push(ctx, &Payload{Op: OpCodeDispatch, T: EventReady, S: 3, D: json.RawMessage(`{"session_id":"abc"}`)})
+	states.wait(t, ctx, StateConnected)
+
+	pipe.Close()
+	change := states.wait(t, ctx, StateReconnecting)
+	if change.Attempt != 1 || change.Delay != 0 || change.Err == nil {
+		t.Fatalf("unexpected reconnect state %+v", change)
+	}
+	nextSent(t, ctx, pipe, OpCodeResume)
+
+	pipe.Push(ctx, &Payload{Op: OpCodeDispatch, T: EventResumed, S: 4})
+	if change := states.wait(t, ctx, StateConnected); !change.Resumed {
+		t.Fatalf("expected resumed connection, got %+v", change)
+	}
+	if client.State() != StateConnected {
+		t.Fatalf("State() = %s, want connected", client.State())
+	}
+}
+
+// failingTransport fails every Receive with recvErr and every Connect after
+// the first with connectErr.
+type failingTransport struct {
+	recvErr    error
+	connectErr error
+	connects   atomic.Int32
+}
+
+func (f *failingTransport) Connect(ctx context.Context) error {
+	if f.connects.Add(1) > 1 && f.connectErr != nil {
+		return f.connectErr
+	}
+	return nil
+}
+
+func (f *failingTransport) Close() error                               { return nil }
+func (f *failingTransport) Send(ctx context.Context, p *Payload) error { return nil }
+func (f *failingTransport) Receive(ctx context.Context) (*Payload, error) {
+	return nil, f.recvErr
+}
+
+func TestClientStopsOnFatalCloseCode(t *testing.T) {
+	transport := &failingTransport{recvErr: &websocket.CloseError{Code: 4004, Text: "Authentication failed."}}
+	states := newStateRecorder()
+	client, err := NewClient("token", 0, WithTransport(transport), WithStateListener(states.record))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+
+	change := states.wait(t, ctx, StateDisconnected)
+	var ce *websocket.CloseError
+	if !errors.As(change.Err, &ce) || ce.Code != 4004 {
+		t.Fatalf("expected close 4004, got %v", change.Err)
+	}
+	if !errors.Is(change.Err, ErrAuthenticationFailed) {
+		t.Fatalf("expected ErrAuthenticationFailed, got %v", change.Err)
+	}
+	if n := transport.connects.Load(); n != 1 {
+		t.Fatalf("connects = %d, want no retry", n)
+	}
+	if client.Err() == nil {
+		t.Fatal("expected Err() to report the fatal close")
+	}
+}
+
+func TestClientGivesUpAfterMaxAttempts(t *testing.T) {
+	transport := &failingTransport{
+		recvErr:    errors.New("connection reset"),
+		connectErr: errors.New("dial failed"),
+	}
+	states := newStateRecorder()
+	client, err := NewClient("token", 0,
+		WithTransport(transport),
+		WithStateListener(states.record),
+		WithReconnectPolicy(ReconnectPolicy{MaxAttempts: 3, BackoffBase: time.Millisecond, BackoffMax: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+
+	states.wait(t, ctx, StateDisconnected)
+	if n := transport.connects.Load(); n != 4 {
+		t.Fatalf("connects = %d, want 1 initial + 3 retries", n)
+	}
+
+	states.mu.Lock()
+	defer states.mu.Unlock()
+	var delays []time.Duration
+	for _, change := range states.changes {
+		if change.State == StateReconnecting {
+			delays = append(delays, change.Delay)
+		}
+	}
+	if len(delays) != 3 || delays[0] != 0 || delays[1] != time.Millisecond {
+		t.Fatalf("unexpected reconnect delays %v", delays)
+	}
+}
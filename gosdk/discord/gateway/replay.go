@@ -0,0 +1,235 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// A recording is a sequence of gateway payloads as received from Discord,
+// stored as JSON lines: one {"op":..,"d":..,"s":..,"t":..} object per line.
+// Capture one from a live session with RecordingTransport, then feed it to
+// a Client through ReplayTransport, or straight into a Dispatcher with
+// Replay, to test event handlers offline.
+
+// RecordingTransport wraps a Transport and writes every payload it receives
+// to a recording. Only inbound payloads are written, so the token sent in
+// IDENTIFY never reaches the recording.
+//
+// The wrapper does not run the inner transport's heartbeat loop; the Client
+// heartbeats itself while recording.
+type RecordingTransport struct {
+	Transport
+
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecordingTransport records the payloads inner receives to w.
+func NewRecordingTransport(inner Transport, w io.Writer) *RecordingTransport {
+	return &RecordingTransport{Transport: inner, enc: json.NewEncoder(w)}
+}
+
+// Receive returns the next payload from the inner transport after
+// recording it. A failed write does not interrupt the session; see Err.
+func (r *RecordingTransport) Receive(ctx context.Context) (*Payload, error) {
+	payload, err := r.Transport.Receive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		if werr := r.enc.Encode(payload); werr != nil {
+			r.err = fmt.Errorf("record payload: %w", werr)
+		}
+	}
+	return payload, nil
+}
+
+// Err returns the first error writing the recording, after which nothing
+// more is recorded.
+func (r *RecordingTransport) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// ReadRecording decodes the payloads recorded in r. Blank lines are
+// skipped, so hand-edited recordings may be spaced out.
+func ReadRecording(r io.Reader) ([]*Payload, error) {
+	var payloads []*Payload
+	scanner := bufio.NewScanner(r)
+	// GUILD_CREATE for a large guild can run to megabytes.
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		raw := scanner.Bytes()
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+		var payload Payload
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		payloads = append(payloads, &payload)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read recording: %w", err)
+	}
+	return payloads, nil
+}
+
+// LoadRecording reads the recording stored at path.
+func LoadRecording(path string) ([]*Payload, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadRecording(f)
+}
+
+// ReplayTransport is a Transport that plays back a recording. Receive
+// returns the recorded payloads in order, then blocks until the transport
+// is closed. Payloads the client sends are kept for inspection rather than
+// delivered anywhere.
+//
+// Because the Client handles each payload before asking for the next,
+// Done is closed once every recorded payload has been handled, unless the
+// dispatcher runs handlers on a worker pool.
+type ReplayTransport struct {
+	payloads []*Payload
+
+	mu       sync.Mutex
+	next     int
+	closed   chan struct{}
+	sent     []*Payload
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewReplayTransport creates an unconnected ReplayTransport for payloads.
+func NewReplayTransport(payloads []*Payload) *ReplayTransport {
+	return &ReplayTransport{payloads: payloads, done: make(chan struct{})}
+}
+
+// Connect marks the transport open. Playback continues where it left off,
+// so a recording that includes a RECONNECT resumes on the next payload.
+func (r *ReplayTransport) Connect(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed != nil {
+		return types.ErrAlreadyConnected
+	}
+	r.closed = make(chan struct{})
+	return nil
+}
+
+// Close marks the transport closed and unblocks a pending Receive.
+func (r *ReplayTransport) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed != nil {
+		close(r.closed)
+		r.closed = nil
+	}
+	return nil
+}
+
+// Send keeps payload for Sent.
+func (r *ReplayTransport) Send(ctx context.Context, payload *Payload) error {
+	if payload == nil {
+		return &types.ValidationError{
+			Field:   "payload",
+			Message: "payload is required",
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed == nil {
+		return types.ErrNotConnected
+	}
+	r.sent = append(r.sent, payload)
+	return nil
+}
+
+// Receive returns the next recorded payload. Once the recording is
+// exhausted it closes Done and blocks until the transport is closed or ctx
+// ends.
+func (r *ReplayTransport) Receive(ctx context.Context) (*Payload, error) {
+	r.mu.Lock()
+	closed := r.closed
+	if closed == nil {
+		r.mu.Unlock()
+		return nil, types.ErrNotConnected
+	}
+	if r.next < len(r.payloads) {
+		payload := r.payloads[r.next]
+		r.next++
+		r.mu.Unlock()
+		return payload, nil
+	}
+	r.mu.Unlock()
+
+	r.doneOnce.Do(func() { close(r.done) })
+	select {
+	case <-closed:
+		return nil, types.ErrNotConnected
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// StartHeartbeat does nothing. Implementing HeartbeatTransport stops the
+// Client heartbeating during playback, so Sent holds only what the recording
+// provoked.
+func (r *ReplayTransport) StartHeartbeat(ctx context.Context, interval time.Duration, sequence func() int) {
+}
+
+// Done is closed when the client asks for a payload after the last one.
+func (r *ReplayTransport) Done() <-chan struct{} {
+	return r.done
+}
+
+// Sent returns the payloads the client has sent, in order.
+func (r *ReplayTransport) Sent() []*Payload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Payload(nil), r.sent...)
+}
+
+// Replay decodes the dispatch payloads in a recording and passes each event
+// to d, in order, without a Client or transport. Other opcodes are skipped.
+// It stops at the first event that fails to decode or whose handlers
+// return an error.
+//
+// With a worker pool, Dispatch only queues events; close the dispatcher to
+// wait for them before asserting on handler results.
+func Replay(ctx context.Context, d *Dispatcher, payloads []*Payload) error {
+	for i, payload := range payloads {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		event, err := decodeEvent(payload)
+		if err != nil {
+			return fmt.Errorf("replay payload %d: %w", i, err)
+		}
+		if event == nil {
+			continue
+		}
+		if err := d.Dispatch(ctx, event); err != nil {
+			return fmt.Errorf("replay payload %d (%s): %w", i, payload.T, err)
+		}
+	}
+	return nil
+}
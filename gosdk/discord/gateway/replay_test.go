@@ -0,0 +1,205 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordingTransportWritesReceivedPayloads(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	pipe := NewPipeTransport(0)
+	var buf bytes.Buffer
+	rec := NewRecordingTransport(pipe, &buf)
+	if err := rec.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer rec.Close()
+
+	if err := rec.Send(ctx, &Payload{Op: OpCodeIdentify, D: json.RawMessage(`{"token":"secret"}`)}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	pipe.Push(ctx, &Payload{Op: OpCodeHello, D: json.RawMessage(`{"heartbeat_interval":10}`)})
+	pipe.Push(ctx, &Payload{Op: OpCodeDispatch, T: EventMessageCreate, S: 1, D: json.RawMessage(`{"id":"1","content":"hi"}`)})
+	for i := 0; i < 2; i++ {
+		if _, err := rec.Receive(ctx); err != nil {
+			t.Fatalf("Receive() error = %v", err)
+		}
+	}
+
+	if strings.Contains(buf.String(), "secret") {
+		t.Fatalf("recording contains sent payload: %s", buf.String())
+	}
+	payloads, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecording() error = %v", err)
+	}
+	if len(payloads) != 2 {
+		t.Fatalf("recorded %d payloads, want 2", len(payloads))
+	}
+	if payloads[1].Op != OpCodeDispatch || payloads[1].T != EventMessageCreate || payloads[1].S != 1 {
+		t.Fatalf("unexpected payload %+v", payloads[1])
+	}
+	if rec.Err() != nil {
+		t.Fatalf("Err() = %v", rec.Err())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestRecordingTransportKeepsSessionOnWriteError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	pipe := NewPipeTransport(0)
+	rec := NewRecordingTransport(pipe, failingWriter{})
+	rec.Connect(ctx)
+	defer rec.Close()
+
+	pipe.Push(ctx, &Payload{Op: OpCodeHeartbeatAck})
+	if _, err := rec.Receive(ctx); err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if err := rec.Err(); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("Err() = %v, want write error", err)
+	}
+}
+
+func TestReadRecording(t *testing.T) {
+	payloads, err := ReadRecording(strings.NewReader("{\"op\":11}\n\n  \n{\"op\":0,\"t\":\"RESUMED\",\"s\":3}\n"))
+	if err != nil {
+		t.Fatalf("ReadRecording() error = %v", err)
+	}
+	if len(payloads) != 2 || payloads[1].T != EventResumed {
+		t.Fatalf("unexpected payloads %+v", payloads)
+	}
+
+	_, err = ReadRecording(strings.NewReader("{\"op\":11}\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("ReadRecording() error = %v, want line 2", err)
+	}
+}
+
+func TestReplayTransportDrivesClient(t *testing.T) {
+	payloads, err := LoadRecording("testdata/session.jsonl")
+	if err != nil {
+		t.Fatalf("LoadRecording() error = %v", err)
+	}
+
+	dispatcher := NewDispatcher()
+	var contents []string
+	var deleted string
+	dispatcher.OnMessageCreate(func(ctx context.Context, event *MessageCreateEvent) error {
+		contents = append(contents, event.Author.Username+": "+event.Content)
+		return nil
+	})
+	dispatcher.OnMessageDelete(func(ctx context.Context, event *MessageDeleteEvent) error {
+		deleted = event.ID
+		return nil
+	})
+
+	replay := NewReplayTransport(payloads)
+	client, err := NewClient("token", 0, WithTransport(replay), WithDispatcher(dispatcher))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+
+	select {
+	case <-replay.Done():
+	case <-ctx.Done():
+		t.Fatal("replay did not finish")
+	}
+
+	want := []string{"ada: !ping", "grace: hello"}
+	if strings.Join(contents, "|") != strings.Join(want, "|") {
+		t.Fatalf("messages = %q, want %q", contents, want)
+	}
+	if deleted != "400000000000000001" {
+		t.Fatalf("deleted = %q", deleted)
+	}
+	if client.session.ID() != "replay-session" {
+		t.Fatalf("session ID = %q, want replay-session", client.session.ID())
+	}
+
+	sent := replay.Sent()
+	if len(sent) != 1 || sent[0].Op != OpCodeIdentify {
+		t.Fatalf("sent = %+v, want only IDENTIFY", sent)
+	}
+}
+
+func TestReplayTransportRequiresConnect(t *testing.T) {
+	replay := NewReplayTransport(nil)
+	if _, err := replay.Receive(context.Background()); err == nil {
+		t.Fatal("Receive() before Connect should fail")
+	}
+	if err := replay.Send(context.Background(), &Payload{Op: OpCodeHeartbeat}); err == nil {
+		t.Fatal("Send() before Connect should fail")
+	}
+}
+
+func TestReplayIntoDispatcher(t *testing.T) {
+	payloads, err := LoadRecording("testdata/session.jsonl")
+	if err != nil {
+		t.Fatalf("LoadRecording() error = %v", err)
+	}
+
+	dispatcher := NewDispatcher()
+	var order []string
+	dispatcher.On(EventReady, func(ctx context.Context, event Event) error {
+		order = append(order, event.Type())
+		return nil
+	})
+	dispatcher.On(EventGuildCreate, func(ctx context.Context, event Event) error {
+		order = append(order, event.Type()+":"+event.(*GuildCreateEvent).Name)
+		return nil
+	})
+	dispatcher.OnMessageCreate(func(ctx context.Context, event *MessageCreateEvent) error {
+		order = append(order, event.Type())
+		return nil
+	})
+
+	if err := Replay(context.Background(), dispatcher, payloads); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	want := "READY,GUILD_CREATE:Replay Guild,MESSAGE_CREATE,MESSAGE_CREATE"
+	if got := strings.Join(order, ","); got != want {
+		t.Fatalf("order = %s, want %s", got, want)
+	}
+}
+
+func TestReplayStopsOnHandlerError(t *testing.T) {
+	payloads, err := LoadRecording("testdata/session.jsonl")
+	if err != nil {
+		t.Fatalf("LoadRecording() error = %v", err)
+	}
+
+	boom := errors.New("boom")
+	dispatcher := NewDispatcher()
+	calls := 0
+	dispatcher.OnMessageCreate(func(ctx context.Context, event *MessageCreateEvent) error {
+		calls++
+		return boom
+	})
+
+	err = Replay(context.Background(), dispatcher, payloads)
+	if !errors.Is(err, boom) || !strings.Contains(err.Error(), "payload 4 (MESSAGE_CREATE)") {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,143 @@
+package gateway
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// ScreeningTracker follows members through Discord's membership screening.
+// Members join with Pending set and receive a GUILD_MEMBER_UPDATE clearing it
+// once they accept the rules; the tracker remembers who is pending so it can
+// report that transition. It needs the GUILD_MEMBERS intent.
+//
+// Members who were already pending before the tracker started are only
+// recognised once an event shows them pending, such as the member list in
+// GUILD_CREATE for small guilds.
+type ScreeningTracker struct {
+	mu       sync.Mutex
+	pending  map[string]map[string]struct{}
+	handlers []func(context.Context, *GuildMemberUpdateEvent) error
+}
+
+// NewScreeningTracker creates an empty tracker.
+func NewScreeningTracker() *ScreeningTracker {
+	return &ScreeningTracker{pending: make(map[string]map[string]struct{})}
+}
+
+// OnPassed registers a handler that runs when a pending member completes
+// screening. Register handlers before calling Register.
+func (t *ScreeningTracker) OnPassed(handler func(context.Context, *GuildMemberUpdateEvent) error) {
+	if handler == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handlers = append(t.handlers, handler)
+}
+
+// IsPending reports whether the member has not completed screening yet.
+func (t *ScreeningTracker) IsPending(guildID, userID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.pending[guildID][userID]
+	return ok
+}
+
+// Pending lists the user IDs still screening in a guild.
+func (t *ScreeningTracker) Pending(guildID string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := make([]string, 0, len(t.pending[guildID]))
+	for id := range t.pending[guildID] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Register subscribes the tracker to the dispatcher.
+func (t *ScreeningTracker) Register(d *Dispatcher) {
+	if d == nil {
+		return
+	}
+	d.On(EventGuildCreate, func(ctx context.Context, event Event) error {
+		evt, ok := event.(*GuildCreateEvent)
+		if !ok || evt.Guild == nil {
+			return nil
+		}
+		for _, member := range evt.Members {
+			if member.Pending && member.User != nil {
+				t.setPending(evt.ID, member.User.ID, true)
+			}
+		}
+		return nil
+	})
+	d.OnGuildMemberAdd(func(ctx context.Context, evt *GuildMemberAddEvent) error {
+		if evt.Member != nil && evt.User != nil && evt.Pending {
+			t.setPending(evt.GuildID, evt.User.ID, true)
+		}
+		return nil
+	})
+	d.OnGuildMemberUpdate(func(ctx context.Context, evt *GuildMemberUpdateEvent) error {
+		if evt.Member == nil || evt.User == nil {
+			return nil
+		}
+		if evt.Pending {
+			t.setPending(evt.GuildID, evt.User.ID, true)
+			return nil
+		}
+		if !t.setPending(evt.GuildID, evt.User.ID, false) {
+			return nil
+		}
+		t.mu.Lock()
+		handlers := append([]func(context.Context, *GuildMemberUpdateEvent) error(nil), t.handlers...)
+		t.mu.Unlock()
+		for _, handler := range handlers {
+			if err := handler(ctx, evt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	d.OnGuildMemberRemove(func(ctx context.Context, evt *GuildMemberRemoveEvent) error {
+		if evt.User != nil {
+			t.setPending(evt.GuildID, evt.User.ID, false)
+		}
+		return nil
+	})
+	d.On(EventGuildDelete, func(ctx context.Context, event Event) error {
+		// Outages also send GUILD_DELETE; only forget guilds the bot left.
+		if evt, ok := event.(*GuildDeleteEvent); ok && !evt.Unavailable {
+			t.mu.Lock()
+			delete(t.pending, evt.GuildID)
+			t.mu.Unlock()
+		}
+		return nil
+	})
+}
+
+// setPending updates a member's screening state and reports whether it
+// changed.
+func (t *ScreeningTracker) setPending(guildID, userID string, pending bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	members := t.pending[guildID]
+	_, was := members[userID]
+	if pending == was {
+		return false
+	}
+	if pending {
+		if members == nil {
+			members = make(map[string]struct{})
+			t.pending[guildID] = members
+		}
+		members[userID] = struct{}{}
+		return true
+	}
+	delete(members, userID)
+	if len(members) == 0 {
+		delete(t.pending, guildID)
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func screeningMember(userID string, pending bool) *types.Member {
+	return &types.Member{User: &types.User{ID: userID}, Pending: pending}
+}
+
+func TestScreeningTrackerReportsPassedMembers(t *testing.T) {
+	d := NewDispatcher()
+	tracker := NewScreeningTracker()
+	var passed []string
+	tracker.OnPassed(func(ctx context.Context, evt *GuildMemberUpdateEvent) error {
+		passed = append(passed, evt.GuildID+"/"+evt.User.ID)
+		return nil
+	})
+	tracker.Register(d)
+	ctx := context.Background()
+
+	d.Dispatch(ctx, &GuildCreateEvent{Guild: &types.Guild{ID: "g1", Members: []types.Member{*screeningMember("old", true), *screeningMember("done", false)}}})
+	d.Dispatch(ctx, &GuildMemberAddEvent{GuildID: "g1", Member: screeningMember("new", true)})
+	d.Dispatch(ctx, &GuildMemberAddEvent{GuildID: "g1", Member: screeningMember("open", false)})
+	if got := tracker.Pending("g1"); len(got) != 2 || got[0] != "new" || got[1] != "old" {
+		t.Fatalf("Pending = %v", got)
+	}
+
+	// Role changes while still pending and updates for members who never
+	// screened do not count.
+	d.Dispatch(ctx, &GuildMemberUpdateEvent{GuildID: "g1", Member: screeningMember("new", true)})
+	d.Dispatch(ctx, &GuildMemberUpdateEvent{GuildID: "g1", Member: screeningMember("open", false)})
+	d.Dispatch(ctx, &GuildMemberUpdateEvent{GuildID: "g1", Member: screeningMember("new", false)})
+	d.Dispatch(ctx, &GuildMemberUpdateEvent{GuildID: "g1", Member: screeningMember("new", false)})
+	if len(passed) != 1 || passed[0] != "g1/new" {
+		t.Fatalf("passed = %v", passed)
+	}
+	if tracker.IsPending("g1", "new") || !tracker.IsPending("g1", "old") {
+		t.Fatal("unexpected pending state")
+	}
+
+	d.Dispatch(ctx, &GuildMemberRemoveEvent{GuildID: "g1", User: &types.User{ID: "old"}})
+	if tracker.IsPending("g1", "old") {
+		t.Fatal("removed members should be forgotten")
+	}
+}
+
+func TestScreeningTrackerGuildDelete(t *testing.T) {
+	d := NewDispatcher()
+	tracker := NewScreeningTracker()
+	tracker.Register(d)
+	ctx := context.Background()
+
+	d.Dispatch(ctx, &GuildMemberAddEvent{GuildID: "g1", Member: screeningMember("u1", true)})
+	d.Dispatch(ctx, &GuildDeleteEvent{GuildID: "g1", Unavailable: true})
+	if !tracker.IsPending("g1", "u1") {
+		t.Fatal("an outage should not clear pending members")
+	}
+	d.Dispatch(ctx, &GuildDeleteEvent{GuildID: "g1"})
+	if len(tracker.Pending("g1")) != 0 {
+		t.Fatal("leaving a guild should clear its pending members")
+	}
+}
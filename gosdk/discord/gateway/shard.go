@@ -10,6 +10,7 @@ import (
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 	"github.com/mtreilly/godiscord/gosdk/logger"
+	"github.com/mtreilly/godiscord/gosdk/version"
 )
 
 const defaultGatewayBotURL = "https://discord.com/api/v10/gateway/bot"
@@ -25,10 +26,10 @@ type Shard struct {
 type ShardManagerOption func(*ShardManager)
 
 // WithShardLogger overrides the logger.
-func WithShardLogger(l *logger.Logger) ShardManagerOption {
+func WithShardLogger(l logger.Interface) ShardManagerOption {
 	return func(sm *ShardManager) {
-		if l != nil {
-			sm.logger = l
+		if lg := logger.From(l); lg != nil {
+			sm.logger = lg
 		}
 	}
 }
@@ -49,6 +50,14 @@ func WithShardConnectionOptions(opts ...ConnectionOption) ShardManagerOption {
 	}
 }
 
+// WithShardClientOptions applies extra client options, such as a reconnect
+// policy or state listener, to every shard.
+func WithShardClientOptions(opts ...ClientOption) ShardManagerOption {
+	return func(sm *ShardManager) {
+		sm.clientOpts = append(sm.clientOpts, opts...)
+	}
+}
+
 // WithShardGatewayBotURL overrides the /gateway/bot endpoint.
 func WithShardGatewayBotURL(url string) ShardManagerOption {
 	return func(sm *ShardManager) {
@@ -75,6 +84,7 @@ type ShardManager struct {
 	logger           *logger.Logger
 	dispatcher       *Dispatcher
 	connectionOpts   []ConnectionOption
+	clientOpts       []ClientOption
 	gatewayBotURL    string
 	gatewayBotClient *http.Client
 
@@ -96,6 +106,7 @@ func NewShardManager(token string, shardCount, intents int, opts ...ShardManager
 	for _, opt := range opts {
 		opt(sm)
 	}
+	sm.logger = sm.logger.WithSubsystem(logger.SubsystemGateway)
 	return sm
 }
 
@@ -108,19 +119,24 @@ func (sm *ShardManager) Connect(ctx context.Context) error {
 	}
 	sm.mu.Unlock()
 
+	logIntentWarnings(sm.logger, CheckIntents(Intent(sm.intents), sm.dispatcher.EventTypes()))
+	sm.dispatcher.start()
+
 	for id := 0; id < sm.shardCount; id++ {
 		connOpts := append([]ConnectionOption{}, sm.connectionOpts...)
 		shardURL := fmt.Sprintf("%s&shard=%d,%d", defaultGatewayURL, id, sm.shardCount)
 		connOpts = append(connOpts, WithGatewayURL(shardURL))
 
-		client, err := NewClient(sm.token, sm.intents,
+		clientOpts := append([]ClientOption{
 			WithDispatcher(sm.dispatcher),
 			WithGatewayLogger(sm.logger),
 			WithConnectionOptions(connOpts...),
-		)
+		}, sm.clientOpts...)
+		client, err := NewClient(sm.token, sm.intents, clientOpts...)
 		if err != nil {
 			return fmt.Errorf("init shard %d: %w", id, err)
 		}
+		client.sharded = true
 		if err := client.Connect(ctx); err != nil {
 			return fmt.Errorf("connect shard %d: %w", id, err)
 		}
@@ -132,7 +148,7 @@ func (sm *ShardManager) Connect(ctx context.Context) error {
 	return nil
 }
 
-// Disconnect closes all shard clients.
+// Disconnect closes all shard clients, then the shared dispatcher.
 func (sm *ShardManager) Disconnect() error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -143,6 +159,7 @@ func (sm *ShardManager) Disconnect() error {
 		}
 	}
 	sm.shards = nil
+	sm.dispatcher.Close()
 	if len(errs) == 0 {
 		return nil
 	}
@@ -150,23 +167,33 @@ func (sm *ShardManager) Disconnect() error {
 }
 
 // On registers an event handler across all shards.
-func (sm *ShardManager) On(eventType string, handler EventHandler) {
-	sm.dispatcher.On(eventType, handler)
+func (sm *ShardManager) On(eventType string, handler EventHandler, opts ...HandlerOption) HandlerID {
+	return sm.dispatcher.On(eventType, handler, opts...)
+}
+
+// Once registers a handler across all shards that is removed after its first invocation.
+func (sm *ShardManager) Once(eventType string, handler EventHandler, opts ...HandlerOption) HandlerID {
+	return sm.dispatcher.Once(eventType, handler, opts...)
+}
+
+// Off removes a handler registered with On or Once.
+func (sm *ShardManager) Off(id HandlerID) bool {
+	return sm.dispatcher.Off(id)
 }
 
 // OnMessageCreate registers a MESSAGE_CREATE handler.
-func (sm *ShardManager) OnMessageCreate(handler func(context.Context, *MessageCreateEvent) error) {
-	sm.dispatcher.OnMessageCreate(handler)
+func (sm *ShardManager) OnMessageCreate(handler func(context.Context, *MessageCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return sm.dispatcher.OnMessageCreate(handler, opts...)
 }
 
 // OnMessageUpdate registers a MESSAGE_UPDATE handler.
-func (sm *ShardManager) OnMessageUpdate(handler func(context.Context, *MessageUpdateEvent) error) {
-	sm.dispatcher.OnMessageUpdate(handler)
+func (sm *ShardManager) OnMessageUpdate(handler func(context.Context, *MessageUpdateEvent) error, opts ...HandlerOption) HandlerID {
+	return sm.dispatcher.OnMessageUpdate(handler, opts...)
 }
 
 // OnInteraction registers an INTERACTION_CREATE handler.
-func (sm *ShardManager) OnInteraction(handler func(context.Context, *InteractionCreateEvent) error) {
-	sm.dispatcher.OnInteraction(handler)
+func (sm *ShardManager) OnInteraction(handler func(context.Context, *InteractionCreateEvent) error, opts ...HandlerOption) HandlerID {
+	return sm.dispatcher.OnInteraction(handler, opts...)
 }
 
 // Broadcast sends the payload to every shard.
@@ -186,6 +213,22 @@ func (sm *ShardManager) Broadcast(ctx context.Context, payload *Payload) error {
 	return errors.Join(errs...)
 }
 
+// Ready reports whether every shard holds a live session. It is false before
+// Connect and while any shard is reconnecting.
+func (sm *ShardManager) Ready() bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if len(sm.shards) == 0 {
+		return false
+	}
+	for _, shard := range sm.shards {
+		if !shard.client.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
 // AutoScale consults /gateway/bot and adjusts the shard count based on the provided strategy.
 func (sm *ShardManager) AutoScale(ctx context.Context, guildCount int, strategy ShardingStrategy) error {
 	if sm.token == "" {
@@ -282,7 +325,7 @@ func fetchGatewayBotInfo(ctx context.Context, client *http.Client, endpoint, tok
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bot "+token)
-	req.Header.Set("User-Agent", "godiscord-gateway/1.0")
+	req.Header.Set("User-Agent", version.UserAgent())
 
 	resp, err := client.Do(req)
 	if err != nil {
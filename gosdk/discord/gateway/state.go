@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConnectionState describes where the client is in its connection lifecycle.
+type ConnectionState int
+
+const (
+	// StateDisconnected means no connection is open or being attempted.
+	StateDisconnected ConnectionState = iota
+	// StateConnecting means the first connection is being opened.
+	StateConnecting
+	// StateConnected means READY or RESUMED has been received.
+	StateConnected
+	// StateReconnecting means the connection dropped and a retry is pending.
+	StateReconnecting
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case StateDisconnected:
+		return "disconnected"
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	default:
+		return fmt.Sprintf("ConnectionState(%d)", int(s))
+	}
+}
+
+// StateChange is delivered to state listeners on every transition.
+type StateChange struct {
+	State ConnectionState
+	// Attempt counts reconnect attempts since the connection dropped.
+	Attempt int
+	// Delay is the backoff before this reconnect attempt.
+	Delay time.Duration
+	// Resumed reports whether StateConnected came from a RESUMED dispatch.
+	Resumed bool
+	// Err is the failure that caused a reconnect or a final disconnect.
+	Err error
+}
+
+// WithStateListener registers fn to observe connection state changes. It is
+// called synchronously from the read loop and must not block.
+func WithStateListener(fn func(StateChange)) ClientOption {
+	return func(c *Client) {
+		if fn != nil {
+			c.stateListeners = append(c.stateListeners, fn)
+		}
+	}
+}
+
+// OnStateChange registers fn for every state transition. Like
+// WithStateListener, fn runs on the read loop and must not block.
+func (c *Client) OnStateChange(fn func(StateChange)) {
+	if fn == nil {
+		return
+	}
+	c.mu.Lock()
+	c.stateListeners = append(c.stateListeners, fn)
+	c.mu.Unlock()
+}
+
+// OnConnect registers fn for each new session, i.e. every READY.
+func (c *Client) OnConnect(fn func()) {
+	if fn == nil {
+		return
+	}
+	c.OnStateChange(func(s StateChange) {
+		if s.State == StateConnected && !s.Resumed {
+			fn()
+		}
+	})
+}
+
+// OnResume registers fn for each successful resume, i.e. every RESUMED.
+func (c *Client) OnResume(fn func()) {
+	if fn == nil {
+		return
+	}
+	c.OnStateChange(func(s StateChange) {
+		if s.State == StateConnected && s.Resumed {
+			fn()
+		}
+	})
+}
+
+// OnReconnecting registers fn before each reconnect attempt.
+func (c *Client) OnReconnecting(fn func(attempt int, delay time.Duration, cause error)) {
+	if fn == nil {
+		return
+	}
+	c.OnStateChange(func(s StateChange) {
+		if s.State == StateReconnecting {
+			fn(s.Attempt, s.Delay, s.Err)
+		}
+	})
+}
+
+// OnDisconnect registers fn for when the client stops: err is nil after
+// Disconnect and non-nil when reconnecting gave up.
+func (c *Client) OnDisconnect(fn func(err error)) {
+	if fn == nil {
+		return
+	}
+	c.OnStateChange(func(s StateChange) {
+		if s.State == StateDisconnected {
+			fn(s.Err)
+		}
+	})
+}
+
+// StateChanges returns a channel of state transitions with room for buffer
+// pending values; when it is full, new transitions are dropped rather than
+// stalling the read loop. The channel is never closed.
+func (c *Client) StateChanges(buffer int) <-chan StateChange {
+	if buffer < 1 {
+		buffer = 1
+	}
+	ch := make(chan StateChange, buffer)
+	c.OnStateChange(func(s StateChange) {
+		select {
+		case ch <- s:
+		default:
+		}
+	})
+	return ch
+}
+
+// Ready reports whether the client holds a live session, suitable for a
+// readiness probe.
+func (c *Client) Ready() bool {
+	return c.State() == StateConnected
+}
+
+// State returns the current connection state.
+func (c *Client) State() ConnectionState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// Err returns the error that ended the connection after reconnecting gave
+// up, or nil while the client is healthy.
+func (c *Client) Err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.err
+}
+
+func (c *Client) setState(change StateChange) {
+	c.mu.Lock()
+	c.state = change.State
+	if change.State == StateDisconnected {
+		c.err = change.Err
+	}
+	listeners := c.stateListeners
+	c.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(change)
+	}
+}
@@ -0,0 +1,124 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClientStateCallbacks(t *testing.T) {
+	pipe := NewPipeTransport(0)
+	client, err := NewClient("token", 0, WithTransport(pipe))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	events := make(chan string, 16)
+	client.OnConnect(func() { events <- "connect" })
+	client.OnResume(func() { events <- "resume" })
+	client.OnReconnecting(func(attempt int, delay time.Duration, cause error) {
+		if cause == nil {
+			t.Errorf("reconnecting without a cause")
+		}
+		events <- "reconnecting"
+	})
+	client.OnDisconnect(func(err error) {
+		if err != nil {
+			t.Errorf("OnDisconnect after Disconnect got %v", err)
+		}
+		events <- "disconnect"
+	})
+	changes := client.StateChanges(16)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case got := <-events:
+			if got != want {
+				t.Fatalf("callback %q, want %q", got, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("callback %q never fired", want)
+		}
+	}
+
+	if client.Ready() {
+		t.Fatal("Ready() before Connect")
+	}
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	nextSent(t, ctx, pipe, OpCodeIdentify)
+	pipe.Push(ctx, &Payload{Op: OpCodeDispatch, T: EventReady, S: 1, D: json.RawMessage(`{"session_id":"abc"}`)})
+	expect("connect")
+	if !client.Ready() {
+		t.Fatal("Ready() = false after READY")
+	}
+
+	pipe.Push(ctx, &Payload{Op: OpCodeReconnect})
+	expect("reconnecting")
+	nextSent(t, ctx, pipe, OpCodeResume)
+	pipe.Push(ctx, &Payload{Op: OpCodeDispatch, T: EventResumed, S: 2})
+	expect("resume")
+
+	if err := client.Disconnect(); err != nil {
+		t.Fatalf("Disconnect() error = %v", err)
+	}
+	expect("disconnect")
+	if client.Ready() {
+		t.Fatal("Ready() after Disconnect")
+	}
+
+	var seen []ConnectionState
+	for len(changes) > 0 {
+		seen = append(seen, (<-changes).State)
+	}
+	want := []ConnectionState{StateConnecting, StateConnected, StateReconnecting, StateConnected, StateDisconnected}
+	if len(seen) != len(want) {
+		t.Fatalf("states = %v, want %v", seen, want)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("states = %v, want %v", seen, want)
+		}
+	}
+}
+
+func TestClientOnDisconnectReportsFailure(t *testing.T) {
+	transport := &failingTransport{recvErr: errors.New("reset"), connectErr: errors.New("dial failed")}
+	client, err := NewClient("token", 0,
+		WithTransport(transport),
+		WithReconnectPolicy(ReconnectPolicy{MaxAttempts: 1}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	done := make(chan error, 1)
+	client.OnDisconnect(func(err error) { done <- err })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected OnDisconnect to report the failure")
+		}
+	case <-ctx.Done():
+		t.Fatal("OnDisconnect never fired")
+	}
+}
+
+func TestConnectionStateString(t *testing.T) {
+	if StateReconnecting.String() != "reconnecting" || ConnectionState(9).String() != "ConnectionState(9)" {
+		t.Fatalf("unexpected String() output")
+	}
+}
@@ -0,0 +1,136 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// Transport carries gateway payloads between a Client and Discord. The
+// default is the websocket-backed *Connection; substitute another
+// implementation to test against an in-memory pipe, use a different
+// websocket library, or route through a proxy.
+//
+// Send may be called concurrently with Receive. Close followed by Connect
+// must open a fresh connection, as the Client does when Discord asks it to
+// reconnect.
+type Transport interface {
+	Connect(ctx context.Context) error
+	Close() error
+	Send(ctx context.Context, payload *Payload) error
+	Receive(ctx context.Context) (*Payload, error)
+}
+
+// HeartbeatTransport is implemented by transports that run their own
+// heartbeat loop. The Client passes them the interval from HELLO and its
+// session's sequence instead of heartbeating itself.
+type HeartbeatTransport interface {
+	Transport
+	StartHeartbeat(ctx context.Context, interval time.Duration, sequence func() int)
+}
+
+// PipeTransport is an in-memory Transport for tests. Payloads given to Push
+// are returned from Receive, and payloads the client sends are delivered on
+// Sent.
+type PipeTransport struct {
+	inbound chan *Payload
+	sent    chan *Payload
+
+	mu     sync.Mutex
+	closed chan struct{}
+}
+
+// NewPipeTransport creates an unconnected PipeTransport. buffer sizes both
+// directions; values below 1 use 16.
+func NewPipeTransport(buffer int) *PipeTransport {
+	if buffer < 1 {
+		buffer = 16
+	}
+	return &PipeTransport{
+		inbound: make(chan *Payload, buffer),
+		sent:    make(chan *Payload, buffer),
+	}
+}
+
+// Connect marks the pipe open.
+func (p *PipeTransport) Connect(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed != nil {
+		return types.ErrAlreadyConnected
+	}
+	p.closed = make(chan struct{})
+	return nil
+}
+
+// Close marks the pipe closed and unblocks pending calls.
+func (p *PipeTransport) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed != nil {
+		close(p.closed)
+		p.closed = nil
+	}
+	return nil
+}
+
+// Send delivers payload to Sent.
+func (p *PipeTransport) Send(ctx context.Context, payload *Payload) error {
+	if payload == nil {
+		return &types.ValidationError{
+			Field:   "payload",
+			Message: "payload is required",
+		}
+	}
+	closed := p.done()
+	if closed == nil {
+		return types.ErrNotConnected
+	}
+	select {
+	case p.sent <- payload:
+		return nil
+	case <-closed:
+		return types.ErrNotConnected
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive returns the next pushed payload.
+func (p *PipeTransport) Receive(ctx context.Context) (*Payload, error) {
+	closed := p.done()
+	if closed == nil {
+		return nil, types.ErrNotConnected
+	}
+	select {
+	case payload := <-p.inbound:
+		return payload, nil
+	case <-closed:
+		return nil, types.ErrNotConnected
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Push queues a payload for the client to receive, as if Discord sent it.
+func (p *PipeTransport) Push(ctx context.Context, payload *Payload) error {
+	select {
+	case p.inbound <- payload:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Sent returns the channel of payloads the client has sent.
+func (p *PipeTransport) Sent() <-chan *Payload {
+	return p.sent
+}
+
+func (p *PipeTransport) done() chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
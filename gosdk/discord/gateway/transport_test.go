@@ -0,0 +1,86 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func nextSent(t *testing.T, ctx context.Context, pipe *PipeTransport, op OpCode) *Payload {
+	t.Helper()
+	for {
+		select {
+		case payload := <-pipe.Sent():
+			if payload.Op == op {
+				return payload
+			}
+		case <-ctx.Done():
+			t.Fatalf("did not observe opcode %d", op)
+		}
+	}
+}
+
+func TestClientOverPipeTransport(t *testing.T) {
+	pipe := NewPipeTransport(0)
+	client, err := NewClient("token", 0, WithTransport(pipe))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+
+	nextSent(t, ctx, pipe, OpCodeIdentify)
+
+	pipe.Push(ctx, &Payload{Op: OpCodeHello, D: json.RawMessage(`{"heartbeat_interval":10}`)})
+	pipe.Push(ctx, &Payload{Op: OpCodeDispatch, T: EventReady, S: 7, D: json.RawMessage(`{"session_id":"abc"}`)})
+
+	heartbeat := nextSent(t, ctx, pipe, OpCodeHeartbeat)
+	for string(heartbeat.D) != "7" {
+		heartbeat = nextSent(t, ctx, pipe, OpCodeHeartbeat)
+	}
+	if client.session.ID() != "abc" {
+		t.Fatalf("session ID = %q, want abc", client.session.ID())
+	}
+
+	pipe.Push(ctx, &Payload{Op: OpCodeReconnect})
+	resume := nextSent(t, ctx, pipe, OpCodeResume)
+	var state map[string]interface{}
+	if err := json.Unmarshal(resume.D, &state); err != nil {
+		t.Fatalf("unmarshal resume: %v", err)
+	}
+	if state["session_id"] != "abc" || state["seq"] != float64(7) {
+		t.Fatalf("unexpected resume payload %v", state)
+	}
+}
+
+func TestPipeTransportClosed(t *testing.T) {
+	pipe := NewPipeTransport(1)
+	ctx := context.Background()
+	if err := pipe.Send(ctx, &Payload{}); err == nil {
+		t.Fatal("expected error sending on unconnected pipe")
+	}
+	if err := pipe.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if err := pipe.Connect(ctx); err == nil {
+		t.Fatal("expected error connecting twice")
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := pipe.Receive(ctx)
+		done <- err
+	}()
+	pipe.Close()
+	if err := <-done; err == nil {
+		t.Fatal("expected Receive to fail after Close")
+	}
+	if err := pipe.Connect(ctx); err != nil {
+		t.Fatalf("reconnect error = %v", err)
+	}
+}
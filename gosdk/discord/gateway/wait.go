@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// WaitFor blocks until the dispatcher delivers an event of type T for which
+// predicate returns true, or ctx is done. A nil predicate matches the first
+// event of that type. Use context.WithTimeout to bound the wait:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+//	defer cancel()
+//	reply, err := gateway.WaitFor(ctx, client.Dispatcher(), func(m *gateway.MessageCreateEvent) bool {
+//		return m.Author != nil && m.Author.ID == userID && m.ChannelID == channelID
+//	})
+//
+// The temporary handler is removed when WaitFor returns.
+func WaitFor[T Event](ctx context.Context, d *Dispatcher, predicate func(T) bool) (T, error) {
+	var zero T
+	if d == nil {
+		return zero, fmt.Errorf("gateway: WaitFor requires a dispatcher")
+	}
+	eventType := eventTypeOf[T]()
+	if eventType == "" {
+		return zero, fmt.Errorf("gateway: cannot determine event type for %T", zero)
+	}
+
+	matched := make(chan T, 1)
+	id := d.Once(eventType, func(ctx context.Context, event Event) error {
+		matched <- event.(T)
+		return nil
+	}, WithFilter(func(event Event) bool {
+		evt, ok := event.(T)
+		return ok && (predicate == nil || predicate(evt))
+	}))
+	defer d.Off(id)
+
+	select {
+	case evt := <-matched:
+		return evt, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// eventTypeOf returns the dispatch name for an event type such as *MessageCreateEvent.
+func eventTypeOf[T Event]() string {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Pointer {
+		return ""
+	}
+	evt, ok := reflect.New(typ.Elem()).Interface().(Event)
+	if !ok {
+		return ""
+	}
+	return evt.Type()
+}
@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestWaitForMatchingEvent(t *testing.T) {
+	dispatcher := NewDispatcher()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		dispatcher.Dispatch(context.Background(), &MessageReactionAddEvent{UserID: "u2", MessageID: "m1"})
+		dispatcher.Dispatch(context.Background(), &MessageReactionAddEvent{UserID: "u1", MessageID: "m1"})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	evt, err := WaitFor(ctx, dispatcher, func(e *MessageReactionAddEvent) bool {
+		return e.UserID == "u1" && e.MessageID == "m1"
+	})
+	if err != nil {
+		t.Fatalf("WaitFor error: %v", err)
+	}
+	if evt.UserID != "u1" {
+		t.Fatalf("expected reaction from u1, got %s", evt.UserID)
+	}
+
+	dispatcher.mu.RLock()
+	remaining := len(dispatcher.handlers[EventMessageReactionAdd])
+	dispatcher.mu.RUnlock()
+	if remaining != 0 {
+		t.Fatalf("expected temporary handler to be removed, %d left", remaining)
+	}
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	dispatcher := NewDispatcher()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitFor(ctx, dispatcher, func(e *MessageCreateEvent) bool { return true })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	dispatcher.mu.RLock()
+	defer dispatcher.mu.RUnlock()
+	if len(dispatcher.handlers[EventMessageCreate]) != 0 {
+		t.Fatalf("expected handler removed after timeout")
+	}
+}
+
+func TestWaitForNilPredicate(t *testing.T) {
+	dispatcher := NewDispatcher()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		dispatcher.Dispatch(context.Background(), &InteractionCreateEvent{Interaction: &types.Interaction{ID: "i1"}})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	evt, err := WaitFor[*InteractionCreateEvent](ctx, dispatcher, nil)
+	if err != nil || evt.ID != "i1" {
+		t.Fatalf("expected interaction i1, got %+v err=%v", evt, err)
+	}
+}
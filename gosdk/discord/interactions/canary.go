@@ -0,0 +1,177 @@
+package interactions
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// CanaryOption configures a Canary.
+type CanaryOption func(*Canary)
+
+// WithCanaryPercent routes the given percentage (0-100) of invocations to
+// the candidate handler.
+func WithCanaryPercent(percent float64) CanaryOption {
+	return func(c *Canary) {
+		c.SetPercent(percent)
+	}
+}
+
+// WithCanaryGuilds always routes invocations from the given guilds to the
+// candidate handler, regardless of the percentage.
+func WithCanaryGuilds(guildIDs ...string) CanaryOption {
+	return func(c *Canary) {
+		for _, id := range guildIDs {
+			if id != "" {
+				c.guilds[id] = struct{}{}
+			}
+		}
+	}
+}
+
+// Canary splits invocations of one command between a stable handler and a
+// candidate rewrite so the candidate can be rolled out gradually. Register
+// its Handle method like any other handler:
+//
+//	canary := interactions.NewCanary("stats", statsV1, statsV2, interactions.WithCanaryPercent(5))
+//	server.RegisterCommand("stats", canary.Handle)
+//
+// Routing is sticky: the percentage is applied to a hash of the guild ID (or
+// the user ID in DMs), so a guild keeps seeing the same implementation while
+// the percentage is unchanged and raising it only ever adds guilds.
+type Canary struct {
+	name      string
+	stable    Handler
+	candidate Handler
+	guilds    map[string]struct{}
+
+	basisPoints atomic.Uint32
+
+	mu    sync.Mutex
+	stats CanaryStats
+}
+
+// CanaryStats compares the two implementations behind a Canary.
+type CanaryStats struct {
+	Stable    VariantStats
+	Candidate VariantStats
+}
+
+// VariantStats counts the invocations one implementation handled.
+type VariantStats struct {
+	Invocations uint64
+	Errors      uint64
+}
+
+// ErrorRate is the fraction of invocations that returned an error.
+func (v VariantStats) ErrorRate() float64 {
+	if v.Invocations == 0 {
+		return 0
+	}
+	return float64(v.Errors) / float64(v.Invocations)
+}
+
+// NewCanary builds a canary for the named command. With no options every
+// invocation goes to stable. A nil candidate also keeps everything on stable.
+func NewCanary(name string, stable, candidate Handler, opts ...CanaryOption) *Canary {
+	c := &Canary{
+		name:      name,
+		stable:    stable,
+		candidate: candidate,
+		guilds:    make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// SetPercent changes the share of invocations routed to the candidate. It is
+// safe to call while the canary is serving, e.g. from an admin command.
+func (c *Canary) SetPercent(percent float64) {
+	percent = math.Max(0, math.Min(100, percent))
+	c.basisPoints.Store(uint32(math.Round(percent * 100)))
+}
+
+// Percent reports the share of invocations routed to the candidate.
+func (c *Canary) Percent() float64 {
+	return float64(c.basisPoints.Load()) / 100
+}
+
+// Stats returns a snapshot of the per-implementation counters.
+func (c *Canary) Stats() CanaryStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Handle routes the interaction to the stable or candidate handler and
+// records the outcome.
+func (c *Canary) Handle(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+	candidate := c.useCandidate(i)
+	handler := c.stable
+	if candidate {
+		handler = c.candidate
+	}
+	if handler == nil {
+		return nil, nil
+	}
+	resp, err := handler(ctx, i)
+
+	c.mu.Lock()
+	variant := &c.stats.Stable
+	if candidate {
+		variant = &c.stats.Candidate
+	}
+	variant.Invocations++
+	if err != nil {
+		variant.Errors++
+	}
+	c.mu.Unlock()
+	return resp, err
+}
+
+func (c *Canary) useCandidate(i *types.Interaction) bool {
+	if c.candidate == nil || i == nil {
+		return false
+	}
+	if _, ok := c.guilds[i.GuildID]; ok && i.GuildID != "" {
+		return true
+	}
+	bp := c.basisPoints.Load()
+	if bp == 0 {
+		return false
+	}
+	if bp >= 10000 {
+		return true
+	}
+	key := canaryKey(i)
+	if key == "" {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(c.name))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return h.Sum32()%10000 < bp
+}
+
+// canaryKey identifies the audience an invocation belongs to.
+func canaryKey(i *types.Interaction) string {
+	switch {
+	case i.GuildID != "":
+		return i.GuildID
+	case i.User != nil:
+		return i.User.ID
+	case i.Member != nil && i.Member.User != nil:
+		return i.Member.User.ID
+	default:
+		return i.ID
+	}
+}
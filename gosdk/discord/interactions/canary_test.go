@@ -0,0 +1,109 @@
+package interactions
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func canaryHandlers() (stable, candidate Handler) {
+	stable = func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return NewMessageResponse("stable").Build()
+	}
+	candidate = func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		if i.GuildID == "broken" {
+			return nil, errors.New("boom")
+		}
+		return NewMessageResponse("candidate").Build()
+	}
+	return stable, candidate
+}
+
+func guildInvocation(guildID string) *types.Interaction {
+	return &types.Interaction{
+		Type:    types.InteractionTypeApplicationCommand,
+		GuildID: guildID,
+		Data:    &types.InteractionData{Name: "stats"},
+	}
+}
+
+func TestCanaryPercentIsStickyAndMonotonic(t *testing.T) {
+	stable, candidate := canaryHandlers()
+	canary := NewCanary("stats", stable, candidate, WithCanaryPercent(20))
+
+	picked := map[string]bool{}
+	for n := 0; n < 2000; n++ {
+		i := guildInvocation(strconv.Itoa(n))
+		picked[i.GuildID] = canary.useCandidate(i)
+		if canary.useCandidate(i) != picked[i.GuildID] {
+			t.Fatalf("guild %s switched implementations", i.GuildID)
+		}
+	}
+	stats := 0
+	for _, ok := range picked {
+		if ok {
+			stats++
+		}
+	}
+	if stats < 300 || stats > 500 {
+		t.Fatalf("expected about 20%% of guilds on the candidate, got %d of 2000", stats)
+	}
+
+	canary.SetPercent(50)
+	for id, wasCandidate := range picked {
+		if wasCandidate && !canary.useCandidate(guildInvocation(id)) {
+			t.Fatalf("raising the percentage moved guild %s back to stable", id)
+		}
+	}
+	if canary.Percent() != 50 {
+		t.Fatalf("Percent = %v", canary.Percent())
+	}
+	canary.SetPercent(0)
+	if canary.useCandidate(guildInvocation("1")) {
+		t.Fatal("0% should route everything to stable")
+	}
+}
+
+func TestCanaryGuildsAndStats(t *testing.T) {
+	stable, candidate := canaryHandlers()
+	router := NewRouter()
+	canary := router.CommandCanary("Stats", stable, candidate, WithCanaryGuilds("beta", "broken"))
+
+	run := func(guildID string) string {
+		i := guildInvocation(guildID)
+		resp, err := router.Resolve(i)(context.Background(), i)
+		if err != nil {
+			return "error"
+		}
+		return resp.Data.Content
+	}
+	if got := run("beta"); got != "candidate" {
+		t.Fatalf("beta guild got %q", got)
+	}
+	if got := run("other"); got != "stable" {
+		t.Fatalf("other guild got %q", got)
+	}
+	if got := run("broken"); got != "error" {
+		t.Fatalf("broken guild got %q", got)
+	}
+
+	stats := canary.Stats()
+	if stats.Stable.Invocations != 1 || stats.Stable.ErrorRate() != 0 {
+		t.Fatalf("stable stats = %+v", stats.Stable)
+	}
+	if stats.Candidate.Invocations != 2 || stats.Candidate.Errors != 1 || stats.Candidate.ErrorRate() != 0.5 {
+		t.Fatalf("candidate stats = %+v", stats.Candidate)
+	}
+}
+
+func TestCanaryWithoutCandidate(t *testing.T) {
+	stable, _ := canaryHandlers()
+	canary := NewCanary("stats", stable, nil, WithCanaryPercent(100))
+	resp, err := canary.Handle(context.Background(), guildInvocation("1"))
+	if err != nil || resp.Data.Content != "stable" {
+		t.Fatalf("expected stable response, got %+v, %v", resp, err)
+	}
+}
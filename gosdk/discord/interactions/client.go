@@ -26,7 +26,8 @@ func NewInteractionClient(c *client.Client) (*InteractionClient, error) {
 	return &InteractionClient{base: c}, nil
 }
 
-// CreateInteractionResponse sends the initial callback payload for an interaction.
+// CreateInteractionResponse sends the initial callback payload for an
+// interaction. Responses carrying Files are uploaded as multipart/form-data.
 func (ic *InteractionClient) CreateInteractionResponse(ctx context.Context, interactionID, token string, resp *types.InteractionResponse) error {
 	if err := ensureID("interactionID", interactionID); err != nil {
 		return err
@@ -42,6 +43,9 @@ func (ic *InteractionClient) CreateInteractionResponse(ctx context.Context, inte
 	}
 
 	path := fmt.Sprintf("/interactions/%s/%s/callback", interactionID, token)
+	if len(resp.Files) > 0 {
+		return ic.base.PostWithFiles(ctx, path, resp, resp.Files, nil)
+	}
 	return ic.base.Post(ctx, path, resp, nil)
 }
 
@@ -102,6 +106,24 @@ func (ic *InteractionClient) CreateFollowupMessage(ctx context.Context, applicat
 	return &msg, nil
 }
 
+// CreateFollowupMessageWithFiles sends a follow-up message with file
+// attachments. params may omit content when files are present.
+func (ic *InteractionClient) CreateFollowupMessageWithFiles(ctx context.Context, applicationID, token string, params *types.MessageCreateParams, files []types.FileAttachment) (*types.Message, error) {
+	if err := ensureAppAndToken(applicationID, token); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = &types.MessageCreateParams{}
+	}
+
+	path := ic.webhookPath(applicationID, token) + buildWaitQuery()
+	var msg types.Message
+	if err := ic.base.PostWithFiles(ctx, path, params, files, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
 // EditFollowupMessage updates an existing follow-up message.
 func (ic *InteractionClient) EditFollowupMessage(ctx context.Context, applicationID, token, messageID string, params *types.MessageEditParams) (*types.Message, error) {
 	if err := ensureAppAndToken(applicationID, token); err != nil {
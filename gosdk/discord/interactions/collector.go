@@ -0,0 +1,385 @@
+package interactions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+// CollectorEndReason explains why a ComponentCollector stopped.
+type CollectorEndReason string
+
+const (
+	// CollectorEndUser means Stop was called.
+	CollectorEndUser CollectorEndReason = "user"
+	// CollectorEndLimit means the maximum number of interactions was collected.
+	CollectorEndLimit CollectorEndReason = "limit"
+	// CollectorEndTime means the overall timeout elapsed.
+	CollectorEndTime CollectorEndReason = "time"
+	// CollectorEndIdle means no interaction arrived within the idle timeout.
+	CollectorEndIdle CollectorEndReason = "idle"
+	// CollectorEndContext means the collector's context was cancelled.
+	CollectorEndContext CollectorEndReason = "context"
+)
+
+// CollectorOption configures a ComponentCollector.
+type CollectorOption func(*collectorConfig)
+
+type collectorConfig struct {
+	users     map[string]struct{}
+	customIDs map[string]struct{}
+	filter    func(*types.Interaction) bool
+	max       int
+	timeout   time.Duration
+	idle      time.Duration
+	ack       *types.InteractionResponse
+	client    *InteractionClient
+	logger    *logger.Logger
+}
+
+// WithCollectorUsers only collects interactions from the given user IDs.
+func WithCollectorUsers(userIDs ...string) CollectorOption {
+	return func(c *collectorConfig) {
+		for _, id := range userIDs {
+			if id != "" {
+				c.users[id] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithCollectorCustomIDs only collects components with the given custom IDs.
+func WithCollectorCustomIDs(customIDs ...string) CollectorOption {
+	return func(c *collectorConfig) {
+		for _, id := range customIDs {
+			if id != "" {
+				c.customIDs[id] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithCollectorFilter adds an arbitrary predicate applied after the other filters.
+func WithCollectorFilter(filter func(*types.Interaction) bool) CollectorOption {
+	return func(c *collectorConfig) {
+		if filter != nil {
+			c.filter = filter
+		}
+	}
+}
+
+// WithCollectorMax stops the collector after n interactions (0 = unlimited).
+func WithCollectorMax(n int) CollectorOption {
+	return func(c *collectorConfig) {
+		if n >= 0 {
+			c.max = n
+		}
+	}
+}
+
+// WithCollectorTimeout stops the collector after d regardless of activity.
+func WithCollectorTimeout(d time.Duration) CollectorOption {
+	return func(c *collectorConfig) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// WithCollectorIdle stops the collector when no interaction arrives for d.
+func WithCollectorIdle(d time.Duration) CollectorOption {
+	return func(c *collectorConfig) {
+		if d > 0 {
+			c.idle = d
+		}
+	}
+}
+
+// WithCollectorAck overrides the acknowledgement sent for each collected
+// interaction (default DEFERRED_UPDATE_MESSAGE). Pass nil to leave
+// acknowledgement to the consumer.
+func WithCollectorAck(resp *types.InteractionResponse) CollectorOption {
+	return func(c *collectorConfig) {
+		c.ack = resp
+	}
+}
+
+// WithCollectorInteractionClient sets the client used to acknowledge
+// interactions received from the gateway.
+func WithCollectorInteractionClient(ic *InteractionClient) CollectorOption {
+	return func(c *collectorConfig) {
+		if ic != nil {
+			c.client = ic
+		}
+	}
+}
+
+// WithCollectorLogger overrides the logger used to report acknowledgement failures.
+func WithCollectorLogger(l logger.Interface) CollectorOption {
+	return func(c *collectorConfig) {
+		if lg := logger.From(l); lg != nil {
+			c.logger = lg
+		}
+	}
+}
+
+// ComponentCollector gathers component interactions on a single message and
+// streams them to the caller, similar to discord.js collectors.
+//
+// Interactions reach the collector either from the gateway (Register) or from
+// an HTTP server (Middleware or Handle). Collected interactions are buffered
+// rather than handed over synchronously, so acknowledging them never waits
+// on the consumer and the callback deadline is always met.
+type ComponentCollector struct {
+	messageID string
+	cfg       collectorConfig
+
+	out  chan *types.Interaction
+	done chan struct{}
+
+	mu         sync.Mutex
+	count      int
+	reason     CollectorEndReason
+	idleTimer  *time.Timer
+	unregister func()
+	stopOnce   sync.Once
+}
+
+// NewComponentCollector starts collecting component interactions on messageID.
+// The collector ends when ctx is done, a limit or timeout is reached, or Stop
+// is called; Interactions is closed at that point.
+func NewComponentCollector(ctx context.Context, messageID string, opts ...CollectorOption) *ComponentCollector {
+	cfg := collectorConfig{
+		users:     make(map[string]struct{}),
+		customIDs: make(map[string]struct{}),
+		ack:       &types.InteractionResponse{Type: types.InteractionResponseDeferredUpdateMessage},
+		logger:    logger.Default(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	cfg.logger = cfg.logger.WithSubsystem(logger.SubsystemInteractions)
+
+	buffer := cfg.max
+	if buffer <= 0 || buffer > 32 {
+		buffer = 32
+	}
+	c := &ComponentCollector{
+		messageID: messageID,
+		cfg:       cfg,
+		out:       make(chan *types.Interaction, buffer),
+		done:      make(chan struct{}),
+	}
+
+	if cfg.idle > 0 {
+		c.mu.Lock()
+		c.idleTimer = time.AfterFunc(cfg.idle, func() { c.end(CollectorEndIdle) })
+		c.mu.Unlock()
+	}
+	go c.watch(ctx)
+	return c
+}
+
+// Interactions streams collected interactions. It is closed when the collector ends.
+func (c *ComponentCollector) Interactions() <-chan *types.Interaction {
+	return c.out
+}
+
+// Done is closed when the collector ends.
+func (c *ComponentCollector) Done() <-chan struct{} {
+	return c.done
+}
+
+// Reason reports why the collector ended ("" while it is running).
+func (c *ComponentCollector) Reason() CollectorEndReason {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reason
+}
+
+// Count returns the number of interactions collected so far.
+func (c *ComponentCollector) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.count
+}
+
+// Stop ends the collector.
+func (c *ComponentCollector) Stop() {
+	c.end(CollectorEndUser)
+}
+
+// Collect drains the collector and returns every interaction once it ends.
+func (c *ComponentCollector) Collect() []*types.Interaction {
+	var collected []*types.Interaction
+	for i := range c.out {
+		collected = append(collected, i)
+	}
+	return collected
+}
+
+// Register feeds the collector from gateway INTERACTION_CREATE events and
+// acknowledges matches through the configured InteractionClient. The handler
+// is removed when the collector ends.
+func (c *ComponentCollector) Register(d *gateway.Dispatcher) {
+	if d == nil {
+		return
+	}
+	id := d.OnInteraction(func(ctx context.Context, evt *gateway.InteractionCreateEvent) error {
+		if evt.Interaction == nil {
+			return nil
+		}
+		resp, ok := c.Handle(ctx, evt.Interaction)
+		if !ok || resp == nil {
+			return nil
+		}
+		if c.cfg.client == nil {
+			c.cfg.logger.Warn("collector cannot acknowledge gateway interaction: no interaction client configured", "interaction_id", evt.ID)
+			return nil
+		}
+		return c.cfg.client.CreateInteractionResponse(ctx, evt.ID, evt.Token, resp)
+	}, gateway.WithFilter(func(event gateway.Event) bool {
+		evt, ok := event.(*gateway.InteractionCreateEvent)
+		return ok && c.matches(evt.Interaction)
+	}))
+
+	c.mu.Lock()
+	ended := c.reason != ""
+	if !ended {
+		c.unregister = func() { d.Off(id) }
+	}
+	c.mu.Unlock()
+	if ended {
+		d.Off(id)
+	}
+}
+
+// Middleware lets an HTTP interactions server feed the collector: matching
+// interactions are acknowledged in the HTTP response, everything else is
+// passed to the next handler.
+func (c *ComponentCollector) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			if resp, ok := c.Handle(ctx, i); ok {
+				return resp, nil
+			}
+			return next(ctx, i)
+		}
+	}
+}
+
+// Handle offers an interaction to the collector. When it is collected, Handle
+// returns the acknowledgement to send and true. Delivery never blocks, so
+// the acknowledgement is always on time: when the consumer has fallen a
+// full buffer behind Interactions, the interaction is not collected and
+// Handle returns false.
+func (c *ComponentCollector) Handle(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, bool) {
+	if !c.matches(i) {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	if c.reason != "" || (c.cfg.max > 0 && c.count >= c.cfg.max) {
+		c.mu.Unlock()
+		return nil, false
+	}
+	// end closes out only after setting reason under mu, so the send
+	// cannot race the close.
+	select {
+	case c.out <- i:
+	default:
+		c.mu.Unlock()
+		c.cfg.logger.Warn("collector buffer full; interaction not collected", "interaction_id", i.ID, "message_id", c.messageID)
+		return nil, false
+	}
+	c.count++
+	reachedMax := c.cfg.max > 0 && c.count >= c.cfg.max
+	if c.idleTimer != nil {
+		c.idleTimer.Reset(c.cfg.idle)
+	}
+	c.mu.Unlock()
+
+	if reachedMax {
+		c.end(CollectorEndLimit)
+	}
+	return c.cfg.ack, true
+}
+
+func (c *ComponentCollector) matches(i *types.Interaction) bool {
+	if i == nil || i.Type != types.InteractionTypeMessageComponent || i.Data == nil {
+		return false
+	}
+	if i.Message == nil || i.Message.ID != c.messageID {
+		return false
+	}
+	if len(c.cfg.customIDs) > 0 {
+		if _, ok := c.cfg.customIDs[i.Data.CustomID]; !ok {
+			return false
+		}
+	}
+	if len(c.cfg.users) > 0 {
+		if _, ok := c.cfg.users[interactionUserID(i)]; !ok {
+			return false
+		}
+	}
+	if c.cfg.filter != nil && !c.cfg.filter(i) {
+		return false
+	}
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (c *ComponentCollector) watch(ctx context.Context) {
+	var timeout <-chan time.Time
+	if c.cfg.timeout > 0 {
+		timer := time.NewTimer(c.cfg.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-ctx.Done():
+		c.end(CollectorEndContext)
+	case <-timeout:
+		c.end(CollectorEndTime)
+	case <-c.done:
+	}
+}
+
+func (c *ComponentCollector) end(reason CollectorEndReason) {
+	c.stopOnce.Do(func() {
+		c.mu.Lock()
+		c.reason = reason
+		unregister := c.unregister
+		if c.idleTimer != nil {
+			c.idleTimer.Stop()
+		}
+		c.mu.Unlock()
+
+		close(c.done)
+		if unregister != nil {
+			unregister()
+		}
+		close(c.out)
+	})
+}
+
+// interactionUserID returns the invoking user for guild and DM interactions.
+func interactionUserID(i *types.Interaction) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
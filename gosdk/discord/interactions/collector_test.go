@@ -0,0 +1,166 @@
+package interactions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func componentInteraction(id, messageID, userID, customID string) *types.Interaction {
+	return &types.Interaction{
+		ID:      id,
+		Type:    types.InteractionTypeMessageComponent,
+		Token:   "token-" + id,
+		Message: &types.Message{ID: messageID},
+		Member:  &types.Member{User: &types.User{ID: userID}},
+		Data:    &types.InteractionData{CustomID: customID},
+	}
+}
+
+func TestComponentCollectorFiltersAndLimit(t *testing.T) {
+	collector := NewComponentCollector(context.Background(), "m1",
+		WithCollectorUsers("u1"),
+		WithCollectorCustomIDs("yes", "no"),
+		WithCollectorMax(2),
+	)
+
+	go func() {
+		ctx := context.Background()
+		collector.Handle(ctx, componentInteraction("1", "m2", "u1", "yes")) // other message
+		collector.Handle(ctx, componentInteraction("2", "m1", "u2", "yes")) // other user
+		collector.Handle(ctx, componentInteraction("3", "m1", "u1", "maybe"))
+		if resp, ok := collector.Handle(ctx, componentInteraction("4", "m1", "u1", "yes")); !ok || resp.Type != types.InteractionResponseDeferredUpdateMessage {
+			t.Errorf("expected deferred update ack, got %+v ok=%v", resp, ok)
+		}
+		collector.Handle(ctx, componentInteraction("5", "m1", "u1", "no"))
+		if _, ok := collector.Handle(ctx, componentInteraction("6", "m1", "u1", "no")); ok {
+			t.Errorf("expected interactions past the limit to be ignored")
+		}
+	}()
+
+	collected := collector.Collect()
+	if len(collected) != 2 || collected[0].ID != "4" || collected[1].ID != "5" {
+		t.Fatalf("unexpected collected interactions: %+v", collected)
+	}
+	if collector.Reason() != CollectorEndLimit {
+		t.Fatalf("expected limit reason, got %q", collector.Reason())
+	}
+}
+
+func TestComponentCollectorHandleDoesNotBlock(t *testing.T) {
+	collector := NewComponentCollector(context.Background(), "m1")
+	defer collector.Stop()
+
+	ctx := context.Background()
+	for n := 0; ; n++ {
+		if _, ok := collector.Handle(ctx, componentInteraction("1", "m1", "u1", "x")); !ok {
+			if n == 0 {
+				t.Fatal("expected the first interaction to be collected")
+			}
+			break
+		}
+	}
+	if collector.Count() != len(collector.Interactions()) {
+		t.Fatalf("count %d does not match %d buffered interactions", collector.Count(), len(collector.Interactions()))
+	}
+}
+
+func TestComponentCollectorTimeouts(t *testing.T) {
+	collector := NewComponentCollector(context.Background(), "m1", WithCollectorTimeout(20*time.Millisecond))
+	if got := collector.Collect(); len(got) != 0 {
+		t.Fatalf("expected nothing collected, got %d", len(got))
+	}
+	if collector.Reason() != CollectorEndTime {
+		t.Fatalf("expected time reason, got %q", collector.Reason())
+	}
+
+	idle := NewComponentCollector(context.Background(), "m1", WithCollectorIdle(20*time.Millisecond))
+	<-idle.Done()
+	if idle.Reason() != CollectorEndIdle {
+		t.Fatalf("expected idle reason, got %q", idle.Reason())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := NewComponentCollector(ctx, "m1")
+	cancel()
+	<-cancelled.Done()
+	if cancelled.Reason() != CollectorEndContext {
+		t.Fatalf("expected context reason, got %q", cancelled.Reason())
+	}
+}
+
+func TestComponentCollectorGatewayAcknowledges(t *testing.T) {
+	var acks int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/interactions/7/token-7/callback" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		atomic.AddInt32(&acks, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ic, err := NewInteractionClient(newInteractionTestClient(t, server.URL))
+	if err != nil {
+		t.Fatalf("NewInteractionClient error: %v", err)
+	}
+
+	dispatcher := gateway.NewDispatcher()
+	collector := NewComponentCollector(context.Background(), "m1",
+		WithCollectorMax(1),
+		WithCollectorInteractionClient(ic),
+	)
+	collector.Register(dispatcher)
+
+	go dispatcher.Dispatch(context.Background(), &gateway.InteractionCreateEvent{Interaction: componentInteraction("7", "m1", "u1", "go")})
+
+	select {
+	case i := <-collector.Interactions():
+		if i.ID != "7" {
+			t.Fatalf("unexpected interaction %s", i.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for interaction")
+	}
+	<-collector.Done()
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&acks) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&acks) != 1 {
+		t.Fatalf("expected one acknowledgement, got %d", acks)
+	}
+
+	// The dispatcher handler is removed once the collector ends.
+	if err := dispatcher.Dispatch(context.Background(), &gateway.InteractionCreateEvent{Interaction: componentInteraction("8", "m1", "u1", "go")}); err != nil {
+		t.Fatalf("dispatch error: %v", err)
+	}
+	if atomic.LoadInt32(&acks) != 1 {
+		t.Fatalf("expected no acknowledgement after the collector ended")
+	}
+}
+
+func TestComponentCollectorMiddleware(t *testing.T) {
+	collector := NewComponentCollector(context.Background(), "m1", WithCollectorMax(1))
+	next := func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return &types.InteractionResponse{Type: types.InteractionResponseChannelMessageWithSource}, nil
+	}
+	handler := collector.Middleware()(next)
+
+	resp, err := handler(context.Background(), componentInteraction("1", "other", "u1", "x"))
+	if err != nil || resp.Type != types.InteractionResponseChannelMessageWithSource {
+		t.Fatalf("expected passthrough for unrelated message, got %+v", resp)
+	}
+
+	go handler(context.Background(), componentInteraction("2", "m1", "u1", "x"))
+	if got := collector.Collect(); len(got) != 1 {
+		t.Fatalf("expected one collected interaction, got %d", len(got))
+	}
+}
@@ -0,0 +1,238 @@
+package interactions
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+const (
+	confirmEmoji = "✅"
+	cancelEmoji  = "❌"
+)
+
+// ConfirmOption configures Confirm.
+type ConfirmOption func(*confirmConfig)
+
+type confirmConfig struct {
+	timeout      time.Duration
+	confirmLabel string
+	cancelLabel  string
+	reactions    bool
+	client       *InteractionClient
+}
+
+// WithConfirmTimeout bounds how long Confirm waits for an answer (default 60s).
+func WithConfirmTimeout(d time.Duration) ConfirmOption {
+	return func(c *confirmConfig) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// WithConfirmLabels overrides the button labels (default "Confirm"/"Cancel").
+func WithConfirmLabels(confirm, cancel string) ConfirmOption {
+	return func(c *confirmConfig) {
+		if confirm != "" {
+			c.confirmLabel = confirm
+		}
+		if cancel != "" {
+			c.cancelLabel = cancel
+		}
+	}
+}
+
+// WithConfirmReactions prompts with ✅/❌ reactions instead of buttons, for
+// bots that only receive gateway events. It requires the message reactions intent.
+func WithConfirmReactions() ConfirmOption {
+	return func(c *confirmConfig) {
+		c.reactions = true
+	}
+}
+
+// WithConfirmInteractionClient overrides the client used to acknowledge button
+// presses (default: an InteractionClient wrapping the bot client).
+func WithConfirmInteractionClient(ic *InteractionClient) ConfirmOption {
+	return func(c *confirmConfig) {
+		if ic != nil {
+			c.client = ic
+		}
+	}
+}
+
+// Confirm posts prompt to channelID with ✅/❌ controls, waits for userID to
+// choose, disables the controls, and reports whether the user confirmed.
+//
+// Answers arrive through d, so the bot must be connected to the gateway.
+// Confirm returns types.ErrPromptTimeout when nobody answers in time and
+// ctx.Err() when ctx is cancelled; the controls are disabled in both cases.
+func Confirm(ctx context.Context, bot *client.Client, d *gateway.Dispatcher, channelID, userID, prompt string, opts ...ConfirmOption) (bool, error) {
+	if bot == nil {
+		return false, &types.ValidationError{Field: "client", Message: "client is required"}
+	}
+	if d == nil {
+		return false, &types.ValidationError{Field: "dispatcher", Message: "dispatcher is required"}
+	}
+	if err := ensureID("channelID", channelID); err != nil {
+		return false, err
+	}
+	if err := ensureID("userID", userID); err != nil {
+		return false, err
+	}
+	if prompt == "" {
+		return false, &types.ValidationError{Field: "prompt", Message: "prompt is required"}
+	}
+
+	cfg := confirmConfig{
+		timeout:      time.Minute,
+		confirmLabel: "Confirm",
+		cancelLabel:  "Cancel",
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	if cfg.reactions {
+		return confirmWithReactions(ctx, bot, d, channelID, userID, prompt, cfg)
+	}
+	return confirmWithButtons(ctx, bot, d, channelID, userID, prompt, cfg)
+}
+
+func confirmWithButtons(ctx context.Context, bot *client.Client, d *gateway.Dispatcher, channelID, userID, prompt string, cfg confirmConfig) (bool, error) {
+	nonce := strconv.FormatInt(time.Now().UnixNano(), 36)
+	confirmID, cancelID := "confirm:"+nonce+":yes", "confirm:"+nonce+":no"
+
+	msg, err := bot.Messages().CreateMessage(ctx, channelID, &types.MessageCreateParams{
+		Content:    prompt,
+		Components: confirmButtons(cfg, confirmID, cancelID, false),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	ic := cfg.client
+	if ic == nil {
+		ic = &InteractionClient{base: bot}
+	}
+	// The button press is acknowledged with an update that disables both buttons.
+	collector := NewComponentCollector(ctx, msg.ID,
+		WithCollectorUsers(userID),
+		WithCollectorCustomIDs(confirmID, cancelID),
+		WithCollectorMax(1),
+		WithCollectorTimeout(cfg.timeout),
+		WithCollectorInteractionClient(ic),
+		WithCollectorAck(&types.InteractionResponse{
+			Type: types.InteractionResponseUpdateMessage,
+			Data: &types.InteractionApplicationCommandCallbackData{
+				Components: confirmButtons(cfg, confirmID, cancelID, true),
+			},
+		}),
+	)
+	collector.Register(d)
+
+	if i, ok := <-collector.Interactions(); ok {
+		return i.Data.CustomID == confirmID, nil
+	}
+
+	// Nobody answered: disable the buttons ourselves.
+	disableCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	_, editErr := bot.Messages().EditMessage(disableCtx, channelID, msg.ID, &types.MessageEditParams{
+		Components: confirmButtons(cfg, confirmID, cancelID, true),
+	})
+	return false, confirmEndError(ctx, collector.Reason(), editErr)
+}
+
+func confirmWithReactions(ctx context.Context, bot *client.Client, d *gateway.Dispatcher, channelID, userID, prompt string, cfg confirmConfig) (bool, error) {
+	messages := bot.Messages()
+	msg, err := messages.CreateMessage(ctx, channelID, &types.MessageCreateParams{Content: prompt})
+	if err != nil {
+		return false, err
+	}
+
+	waitCtx, cancelWait := context.WithTimeout(ctx, cfg.timeout)
+	defer cancelWait()
+
+	// Start listening before adding the reactions so a fast answer is not missed.
+	type answer struct {
+		evt *gateway.MessageReactionAddEvent
+		err error
+	}
+	answered := make(chan answer, 1)
+	go func() {
+		evt, err := gateway.WaitFor(waitCtx, d, func(evt *gateway.MessageReactionAddEvent) bool {
+			return evt.MessageID == msg.ID && evt.UserID == userID &&
+				(evt.Emoji.Name == confirmEmoji || evt.Emoji.Name == cancelEmoji)
+		})
+		answered <- answer{evt: evt, err: err}
+	}()
+
+	for _, emoji := range []string{confirmEmoji, cancelEmoji} {
+		if err := messages.CreateReaction(waitCtx, channelID, msg.ID, emoji); err != nil {
+			cancelWait()
+			<-answered
+			return false, err
+		}
+	}
+
+	result := <-answered
+
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	cleanupErr := messages.DeleteAllReactions(cleanupCtx, channelID, msg.ID, "")
+
+	if result.err != nil {
+		reason := CollectorEndTime
+		if ctx.Err() != nil {
+			reason = CollectorEndContext
+		}
+		return false, confirmEndError(ctx, reason, cleanupErr)
+	}
+	return result.evt.Emoji.Name == confirmEmoji, nil
+}
+
+// confirmButtons builds the ✅/❌ action row.
+func confirmButtons(cfg confirmConfig, confirmID, cancelID string, disabled bool) []types.MessageComponent {
+	return []types.MessageComponent{{
+		Type: types.ComponentTypeActionRow,
+		Components: []types.MessageComponent{
+			{
+				Type:     types.ComponentTypeButton,
+				Style:    int(types.ButtonStyleSuccess),
+				Label:    cfg.confirmLabel,
+				Emoji:    &types.Emoji{Name: confirmEmoji},
+				CustomID: confirmID,
+				Disabled: disabled,
+			},
+			{
+				Type:     types.ComponentTypeButton,
+				Style:    int(types.ButtonStyleDanger),
+				Label:    cfg.cancelLabel,
+				Emoji:    &types.Emoji{Name: cancelEmoji},
+				CustomID: cancelID,
+				Disabled: disabled,
+			},
+		},
+	}}
+}
+
+// confirmEndError maps an unanswered prompt to its error, keeping any failure
+// to disable the controls.
+func confirmEndError(ctx context.Context, reason CollectorEndReason, cleanupErr error) error {
+	err := types.ErrPromptTimeout
+	if reason == CollectorEndContext && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	if cleanupErr != nil {
+		return errors.Join(err, cleanupErr)
+	}
+	return err
+}
@@ -0,0 +1,207 @@
+package interactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// confirmServer fakes the message endpoints used by Confirm.
+type confirmServer struct {
+	mu        sync.Mutex
+	customIDs []string
+	acks      []types.InteractionResponse
+	edits     []types.MessageEditParams
+	reactions int
+	cleared   bool
+	posted    chan struct{}
+	reacted   chan struct{}
+}
+
+func newConfirmServer(t *testing.T) (*confirmServer, *httptest.Server) {
+	cs := &confirmServer{posted: make(chan struct{}, 1), reacted: make(chan struct{}, 1)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/channels/c1/messages":
+			var params types.MessageCreateParams
+			json.NewDecoder(r.Body).Decode(&params)
+			for _, row := range params.Components {
+				for _, button := range row.Components {
+					cs.customIDs = append(cs.customIDs, button.CustomID)
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"m1","channel_id":"c1"}`))
+			cs.posted <- struct{}{}
+		case r.Method == http.MethodPost && r.URL.Path == "/interactions/7/token-7/callback":
+			var resp types.InteractionResponse
+			json.NewDecoder(r.Body).Decode(&resp)
+			cs.acks = append(cs.acks, resp)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPatch && r.URL.Path == "/channels/c1/messages/m1":
+			var params types.MessageEditParams
+			json.NewDecoder(r.Body).Decode(&params)
+			cs.edits = append(cs.edits, params)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"m1"}`))
+		case r.Method == http.MethodPut:
+			cs.reactions++
+			if cs.reactions == 2 {
+				cs.reacted <- struct{}{}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/channels/c1/messages/m1/reactions":
+			cs.cleared = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return cs, server
+}
+
+func TestConfirmButtons(t *testing.T) {
+	cs, server := newConfirmServer(t)
+	defer server.Close()
+
+	dispatcher := gateway.NewDispatcher()
+	done := make(chan struct{})
+	go func() {
+		<-cs.posted
+		cs.mu.Lock()
+		confirmID := cs.customIDs[0]
+		cs.mu.Unlock()
+		// Keep pressing until Confirm has registered its collector; another
+		// user's press is always ignored.
+		ctx := context.Background()
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			dispatcher.Dispatch(ctx, &gateway.InteractionCreateEvent{Interaction: componentInteraction("6", "m1", "u2", confirmID)})
+			dispatcher.Dispatch(ctx, &gateway.InteractionCreateEvent{Interaction: componentInteraction("7", "m1", "u1", confirmID)})
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	ok, err := Confirm(context.Background(), newInteractionTestClient(t, server.URL), dispatcher, "c1", "u1", "Delete everything?")
+	close(done)
+	if err != nil {
+		t.Fatalf("Confirm error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected confirmation")
+	}
+
+	// The acknowledgement is sent once Confirm has read the press.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		cs.mu.Lock()
+		acked := len(cs.acks) > 0
+		cs.mu.Unlock()
+		if acked {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if len(cs.customIDs) != 2 {
+		t.Fatalf("expected two buttons, got %v", cs.customIDs)
+	}
+	if len(cs.acks) != 1 || cs.acks[0].Type != types.InteractionResponseUpdateMessage {
+		t.Fatalf("expected an update acknowledgement, got %+v", cs.acks)
+	}
+	for _, button := range cs.acks[0].Data.Components[0].Components {
+		if !button.Disabled {
+			t.Fatalf("expected disabled buttons in acknowledgement, got %+v", button)
+		}
+	}
+}
+
+func TestConfirmTimeoutDisablesButtons(t *testing.T) {
+	cs, server := newConfirmServer(t)
+	defer server.Close()
+
+	ok, err := Confirm(context.Background(), newInteractionTestClient(t, server.URL), gateway.NewDispatcher(), "c1", "u1", "Continue?",
+		WithConfirmTimeout(20*time.Millisecond),
+		WithConfirmLabels("Yes", "No"),
+	)
+	if ok || !errors.Is(err, types.ErrPromptTimeout) {
+		t.Fatalf("expected timeout, got ok=%v err=%v", ok, err)
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if len(cs.edits) != 1 {
+		t.Fatalf("expected one edit, got %d", len(cs.edits))
+	}
+	buttons := cs.edits[0].Components[0].Components
+	if buttons[0].Label != "Yes" || !buttons[0].Disabled || !buttons[1].Disabled {
+		t.Fatalf("unexpected buttons after timeout: %+v", buttons)
+	}
+}
+
+func TestConfirmReactions(t *testing.T) {
+	cs, server := newConfirmServer(t)
+	defer server.Close()
+
+	dispatcher := gateway.NewDispatcher()
+	done := make(chan struct{})
+	go func() {
+		<-cs.reacted
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			dispatcher.Dispatch(context.Background(), &gateway.MessageReactionAddEvent{
+				UserID: "u1", ChannelID: "c1", MessageID: "m1", Emoji: types.Emoji{Name: cancelEmoji},
+			})
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	ok, err := Confirm(context.Background(), newInteractionTestClient(t, server.URL), dispatcher, "c1", "u1", "Continue?", WithConfirmReactions())
+	close(done)
+	if err != nil {
+		t.Fatalf("Confirm error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected cancellation")
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.reactions != 2 || !cs.cleared {
+		t.Fatalf("expected two reactions and a cleanup, got %d cleared=%v", cs.reactions, cs.cleared)
+	}
+}
+
+func TestConfirmValidation(t *testing.T) {
+	if _, err := Confirm(context.Background(), nil, gateway.NewDispatcher(), "c1", "u1", "ok?"); err == nil {
+		t.Fatal("expected error without a client")
+	}
+	var vErr *types.ValidationError
+	_, err := Confirm(context.Background(), newInteractionTestClient(t, "http://127.0.0.1"), gateway.NewDispatcher(), "c1", "u1", "")
+	if !errors.As(err, &vErr) || vErr.Field != "prompt" {
+		t.Fatalf("expected prompt validation error, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package interactions
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+const (
+	maxCustomIDLength     = limits.CustomIDLength
+	customIDSeparator     = ":"
+	defaultExpiredMessage = "This button has expired. Please run the command again."
+)
+
+// CustomIDCodec packs a versioned payload into a component custom_id.
+//
+// Encoded IDs have the form "<namespace>:<version>:<base64url(json)>". When the
+// payload schema changes, bump the version so components sent before the
+// change are rejected instead of being decoded into the wrong shape.
+type CustomIDCodec struct {
+	namespace      string
+	version        int
+	expiredMessage string
+}
+
+// CustomIDOption configures a CustomIDCodec.
+type CustomIDOption func(*CustomIDCodec)
+
+// WithExpiredMessage overrides the ephemeral reply sent for stale components.
+func WithExpiredMessage(message string) CustomIDOption {
+	return func(c *CustomIDCodec) {
+		if message != "" {
+			c.expiredMessage = message
+		}
+	}
+}
+
+// NewCustomIDCodec creates a codec for the given namespace and schema version.
+func NewCustomIDCodec(namespace string, version int, opts ...CustomIDOption) (*CustomIDCodec, error) {
+	if namespace == "" || strings.Contains(namespace, customIDSeparator) {
+		return nil, &types.ValidationError{Field: "namespace", Message: "namespace is required and must not contain ':'"}
+	}
+	if version < 0 {
+		return nil, &types.ValidationError{Field: "version", Message: "version must be non-negative"}
+	}
+	c := &CustomIDCodec{
+		namespace:      namespace,
+		version:        version,
+		expiredMessage: defaultExpiredMessage,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c, nil
+}
+
+// Encode serialises payload into a custom_id within Discord's 100 character limit.
+func (c *CustomIDCodec) Encode(payload interface{}) (string, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshal custom_id payload: %w", err)
+	}
+	id := c.prefix() + base64.RawURLEncoding.EncodeToString(raw)
+	if len(id) > maxCustomIDLength {
+		return "", &types.ValidationError{
+			Field:   "custom_id",
+			Message: fmt.Sprintf("encoded custom_id is %d characters, max %d", len(id), maxCustomIDLength),
+		}
+	}
+	return id, nil
+}
+
+// Decode parses a custom_id produced by Encode into out. IDs from an older
+// version return an error wrapping types.ErrCustomIDExpired.
+func (c *CustomIDCodec) Decode(customID string, out interface{}) error {
+	parts := strings.SplitN(customID, customIDSeparator, 3)
+	if len(parts) != 3 || parts[0] != c.namespace {
+		return &types.ValidationError{Field: "custom_id", Message: fmt.Sprintf("custom_id does not belong to namespace %q", c.namespace)}
+	}
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return &types.ValidationError{Field: "custom_id", Message: "custom_id version is not a number"}
+	}
+	if version != c.version {
+		return fmt.Errorf("%w: got version %d, want %d", types.ErrCustomIDExpired, version, c.version)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return &types.ValidationError{Field: "custom_id", Message: "custom_id payload is not valid base64"}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("unmarshal custom_id payload: %w", err)
+	}
+	return nil
+}
+
+// Pattern returns a regex matching every version of this namespace, suitable
+// for Router.ComponentPattern so stale IDs still reach the Wrap handler.
+func (c *CustomIDCodec) Pattern() string {
+	return "^" + regexp.QuoteMeta(c.namespace+customIDSeparator)
+}
+
+// Wrap rejects stale custom_ids with an ephemeral "expired" reply before the
+// handler runs.
+func (c *CustomIDCodec) Wrap(next Handler) Handler {
+	return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		if i != nil && i.Data != nil && c.isStale(i.Data.CustomID) {
+			return c.ExpiredResponse()
+		}
+		return next(ctx, i)
+	}
+}
+
+// ExpiredResponse builds the ephemeral reply used for stale components.
+func (c *CustomIDCodec) ExpiredResponse() (*types.InteractionResponse, error) {
+	return NewMessageResponse(c.expiredMessage).SetEphemeral(true).Build()
+}
+
+func (c *CustomIDCodec) prefix() string {
+	return c.namespace + customIDSeparator + strconv.Itoa(c.version) + customIDSeparator
+}
+
+func (c *CustomIDCodec) isStale(customID string) bool {
+	parts := strings.SplitN(customID, customIDSeparator, 3)
+	if len(parts) != 3 || parts[0] != c.namespace {
+		return false
+	}
+	return parts[1] != strconv.Itoa(c.version)
+}
@@ -0,0 +1,101 @@
+package interactions
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+type pagePayload struct {
+	Page  int    `json:"p"`
+	Query string `json:"q"`
+}
+
+func TestCustomIDCodecRoundTrip(t *testing.T) {
+	codec, err := NewCustomIDCodec("page", 2)
+	if err != nil {
+		t.Fatalf("NewCustomIDCodec error: %v", err)
+	}
+
+	id, err := codec.Encode(pagePayload{Page: 3, Query: "go"})
+	if err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if !strings.HasPrefix(id, "page:2:") {
+		t.Fatalf("unexpected custom_id %q", id)
+	}
+
+	var decoded pagePayload
+	if err := codec.Decode(id, &decoded); err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if decoded.Page != 3 || decoded.Query != "go" {
+		t.Fatalf("unexpected payload %+v", decoded)
+	}
+}
+
+func TestCustomIDCodecRejectsStaleAndForeignIDs(t *testing.T) {
+	v1, _ := NewCustomIDCodec("page", 1)
+	v2, _ := NewCustomIDCodec("page", 2)
+
+	id, err := v1.Encode(pagePayload{Page: 1})
+	if err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if err := v2.Decode(id, &pagePayload{}); !errors.Is(err, types.ErrCustomIDExpired) {
+		t.Fatalf("expected ErrCustomIDExpired, got %v", err)
+	}
+
+	var vErr *types.ValidationError
+	if err := v2.Decode("other:2:e30", nil); !errors.As(err, &vErr) {
+		t.Fatalf("expected validation error for foreign namespace, got %v", err)
+	}
+}
+
+func TestCustomIDCodecLengthLimit(t *testing.T) {
+	codec, _ := NewCustomIDCodec("big", 1)
+	if _, err := codec.Encode(strings.Repeat("x", 100)); err == nil {
+		t.Fatal("expected length validation error")
+	}
+}
+
+func TestCustomIDCodecWrapRepliesExpired(t *testing.T) {
+	old, _ := NewCustomIDCodec("vote", 1)
+	current, _ := NewCustomIDCodec("vote", 2, WithExpiredMessage("expired!"))
+
+	staleID, _ := old.Encode(1)
+	freshID, _ := current.Encode(1)
+
+	called := 0
+	handler := current.Wrap(func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		called++
+		return nil, nil
+	})
+
+	resp, err := handler(context.Background(), &types.Interaction{Data: &types.InteractionData{CustomID: staleID}})
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if resp == nil || resp.Data.Content != "expired!" || resp.Data.Flags&interactionResponseFlagEphemeral == 0 {
+		t.Fatalf("expected ephemeral expired response, got %+v", resp)
+	}
+	if called != 0 {
+		t.Fatalf("handler should not run for stale IDs")
+	}
+
+	if _, err := handler(context.Background(), &types.Interaction{Data: &types.InteractionData{CustomID: freshID}}); err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if called != 1 {
+		t.Fatalf("expected handler to run for current IDs")
+	}
+
+	router := NewRouter()
+	router.ComponentPattern(current.Pattern(), handler)
+	if router.Resolve(&types.Interaction{Type: types.InteractionTypeMessageComponent, Data: &types.InteractionData{CustomID: staleID}}) == nil {
+		t.Fatalf("expected pattern to match stale IDs")
+	}
+}
@@ -0,0 +1,102 @@
+package interactions
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// ParsePublicKey decodes an application's hex-encoded Ed25519 public key, as
+// shown in the developer portal.
+func ParsePublicKey(publicKey string) (ed25519.PublicKey, error) {
+	pubBytes, err := hex.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(pubBytes) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key length: expected %d bytes", ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(pubBytes), nil
+}
+
+// Verify reports whether signature, the hex value of the
+// X-Signature-Ed25519 header, signs timestamp (X-Signature-Timestamp)
+// followed by the raw request body. Use it on runtimes without net/http,
+// then pass the body to Server.Dispatch.
+func Verify(publicKey ed25519.PublicKey, timestamp, signature string, body []byte) bool {
+	if len(publicKey) != ed25519.PublicKeySize || timestamp == "" || signature == "" {
+		return false
+	}
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	message := make([]byte, 0, len(timestamp)+len(body))
+	message = append(message, timestamp...)
+	message = append(message, body...)
+	return ed25519.Verify(publicKey, message, sig)
+}
+
+// Dispatch decodes a raw interaction body and routes it like
+// HandleInteraction, without any HTTP plumbing. It does not check the
+// signature; call Verify first.
+//
+// PINGs get a PONG. Payloads that cannot be decoded or fail validation
+// return an error wrapping types.ErrMalformedInteraction, and interactions
+// with no handler return types.ErrUnhandledInteraction. Handler errors are
+// wrapped in a *HandlerError, so a handler that returns one of those
+// sentinels is not mistaken for a bad request. A nil response means the
+// handler replied out of band.
+func (s *Server) Dispatch(ctx context.Context, body []byte) (*types.InteractionResponse, error) {
+	interaction, err := s.decodeInteraction(body)
+	if err != nil {
+		s.rejectMalformed("decode", err)
+		return nil, fmt.Errorf("%w: %w", types.ErrMalformedInteraction, err)
+	}
+	if s.validatePayloads {
+		if err := interaction.Validate(); err != nil {
+			s.rejectMalformed("validate", err)
+			return nil, fmt.Errorf("%w: %w", types.ErrMalformedInteraction, err)
+		}
+	}
+
+	if interaction.Type == types.InteractionTypePing {
+		return &types.InteractionResponse{Type: types.InteractionResponsePong}, nil
+	}
+
+	handler := s.resolveHandler(interaction)
+	if handler == nil {
+		return nil, types.ErrUnhandledInteraction
+	}
+
+	if s.interactionClient != nil {
+		ctx = ContextWithResponder(ctx, NewResponder(s.interactionClient, interaction))
+	}
+	start := time.Now()
+	resp, err := handler(ctx, interaction)
+	s.observeLatency(interaction, time.Since(start))
+	if err != nil {
+		return resp, &HandlerError{Err: err}
+	}
+	return resp, nil
+}
+
+// HandlerError wraps an error returned by an interaction handler, telling it
+// apart from the errors Dispatch raises itself. errors.Is and errors.As see
+// through it to the handler's error.
+type HandlerError struct {
+	Err error
+}
+
+func (e *HandlerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,116 @@
+package interactions
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestVerify(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	body := []byte(`{"type":1}`)
+	signature := hex.EncodeToString(ed25519.Sign(priv, append([]byte("123"), body...)))
+
+	if !Verify(pub, "123", signature, body) {
+		t.Fatalf("expected valid signature")
+	}
+	tests := map[string]struct {
+		timestamp, signature string
+		body                 []byte
+	}{
+		"tampered body":       {"123", signature, []byte(`{"type":2}`)},
+		"other timestamp":     {"124", signature, body},
+		"missing timestamp":   {"", signature, body},
+		"missing signature":   {"123", "", body},
+		"signature not hex":   {"123", "zz", body},
+		"truncated signature": {"123", signature[:10], body},
+	}
+	for name, tt := range tests {
+		if Verify(pub, tt.timestamp, tt.signature, tt.body) {
+			t.Fatalf("%s: expected verification to fail", name)
+		}
+	}
+	if Verify(nil, "123", signature, body) {
+		t.Fatalf("expected nil key to fail")
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	got, err := ParsePublicKey(" " + hex.EncodeToString(pub) + "\n")
+	if err != nil || !got.Equal(pub) {
+		t.Fatalf("ParsePublicKey = %x, %v", got, err)
+	}
+	if _, err := ParsePublicKey("abcd"); err == nil {
+		t.Fatalf("expected length error")
+	}
+	if _, err := ParsePublicKey("not hex"); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	server, _ := newTestServer(t)
+	handlerErr := errors.New("boom")
+	server.RegisterCommand("hello", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return NewMessageResponse("world").Build()
+	})
+	server.RegisterCommand("fail", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return nil, handlerErr
+	})
+	ctx := context.Background()
+
+	resp, err := server.Dispatch(ctx, []byte(`{"type":1}`))
+	if err != nil || resp.Type != types.InteractionResponsePong {
+		t.Fatalf("ping: %+v, %v", resp, err)
+	}
+	resp, err = server.Dispatch(ctx, []byte(`{"type":2,"data":{"name":"hello"}}`))
+	if err != nil || resp.Data == nil || resp.Data.Content != "world" {
+		t.Fatalf("command: %+v, %v", resp, err)
+	}
+	_, err = server.Dispatch(ctx, []byte(`{"type":2,"data":{"name":"fail"}}`))
+	var wrapped *HandlerError
+	if !errors.Is(err, handlerErr) || !errors.As(err, &wrapped) {
+		t.Fatalf("expected wrapped handler error, got %v", err)
+	}
+	if _, err := server.Dispatch(ctx, []byte(`{"type":2,"data":{"name":"missing"}}`)); !errors.Is(err, types.ErrUnhandledInteraction) {
+		t.Fatalf("expected ErrUnhandledInteraction, got %v", err)
+	}
+	if _, err := server.Dispatch(ctx, []byte(`{"type":`)); !errors.Is(err, types.ErrMalformedInteraction) {
+		t.Fatalf("expected ErrMalformedInteraction, got %v", err)
+	}
+	if stats := server.Stats(); stats.Malformed != 1 || stats.Requests != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestDispatchPayloadValidation(t *testing.T) {
+	server, _ := newTestServer(t)
+	WithPayloadValidation(true)(server)
+	_, err := server.Dispatch(context.Background(), []byte(`{"type":2,"data":{"name":"hello"}}`))
+	var verr *types.ValidationError
+	if !errors.Is(err, types.ErrMalformedInteraction) || !errors.As(err, &verr) {
+		t.Fatalf("expected wrapped validation error, got %v", err)
+	}
+}
+
+func TestHandleInteractionHandlerSentinelIsServerError(t *testing.T) {
+	server, priv := newTestServer(t)
+	server.RegisterCommand("lookup", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return nil, fmt.Errorf("lookup: %w", types.ErrUnhandledInteraction)
+	})
+	body := []byte(`{"type":2,"data":{"name":"lookup"}}`)
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body))
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rr.Code)
+	}
+}
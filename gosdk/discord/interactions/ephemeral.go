@@ -0,0 +1,76 @@
+package interactions
+
+import (
+	"context"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+type ephemeralKey struct{}
+
+// ephemeralState records whether the current invocation opted out of an
+// ephemeral default.
+type ephemeralState struct {
+	public bool
+}
+
+// WithEphemeral makes the handler's message responses ephemeral by default,
+// so only the invoking user sees them. This covers responses built with
+// NewMessageResponse and NewDeferredResponse as well as the deferred
+// response sent by WithAutoDefer. Call Public inside the handler to answer
+// publicly for a single invocation.
+func WithEphemeral() HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.ephemeral = true
+	}
+}
+
+// EphemeralByDefault wraps handler so its message responses are ephemeral
+// unless it calls Public. Server registrations use WithEphemeral; this is for
+// handlers registered directly on a Router.
+func EphemeralByDefault(handler Handler) Handler {
+	if handler == nil {
+		return nil
+	}
+	return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		state := &ephemeralState{}
+		resp, err := handler(context.WithValue(ctx, ephemeralKey{}, state), i)
+		if err == nil && !state.public {
+			markEphemeral(resp)
+		}
+		return resp, err
+	}
+}
+
+// Public opts the current invocation of an ephemeral-by-default handler out
+// of the default so its response is visible to everyone. It has no effect in
+// other handlers, or after WithAutoDefer has already sent an ephemeral
+// deferred response.
+func Public(ctx context.Context) {
+	if state, ok := ctx.Value(ephemeralKey{}).(*ephemeralState); ok {
+		state.public = true
+	}
+}
+
+// IsEphemeralDefault reports whether responses in ctx will be made ephemeral.
+func IsEphemeralDefault(ctx context.Context) bool {
+	state, ok := ctx.Value(ephemeralKey{}).(*ephemeralState)
+	return ok && !state.public
+}
+
+// markEphemeral sets the ephemeral flag on message responses; updates to an
+// existing message keep that message's visibility and are left alone.
+func markEphemeral(resp *types.InteractionResponse) {
+	if resp == nil {
+		return
+	}
+	switch resp.Type {
+	case types.InteractionResponseChannelMessageWithSource, types.InteractionResponseDeferredChannelMessageWithSource:
+	default:
+		return
+	}
+	if resp.Data == nil {
+		resp.Data = &types.InteractionApplicationCommandCallbackData{}
+	}
+	resp.Data.Flags |= interactionResponseFlagEphemeral
+}
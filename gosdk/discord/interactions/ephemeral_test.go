@@ -0,0 +1,91 @@
+package interactions
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func invokeCommand(t *testing.T, server *Server, priv ed25519.PrivateKey, name string) *types.InteractionResponse {
+	t.Helper()
+	body, _ := json.Marshal(&types.Interaction{
+		Type: types.InteractionTypeApplicationCommand,
+		Data: &types.InteractionData{Name: name},
+	})
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body))
+	var resp types.InteractionResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return &resp
+}
+
+func TestServerEphemeralByDefault(t *testing.T) {
+	server, priv := newTestServer(t)
+	server.RegisterCommand("secret", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		if !IsEphemeralDefault(ctx) {
+			t.Error("expected ephemeral default in handler context")
+		}
+		return NewMessageResponse("token: 123").Build()
+	}, WithEphemeral())
+	server.RegisterCommand("announce", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		Public(ctx)
+		return NewMessageResponse("hello all").Build()
+	}, WithEphemeral())
+	server.RegisterCommand("plain", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		if IsEphemeralDefault(ctx) {
+			t.Error("unexpected ephemeral default")
+		}
+		return NewMessageResponse("hi").Build()
+	})
+
+	if resp := invokeCommand(t, server, priv, "secret"); resp.Data.Flags&interactionResponseFlagEphemeral == 0 {
+		t.Fatalf("expected ephemeral flag, got %d", resp.Data.Flags)
+	}
+	if resp := invokeCommand(t, server, priv, "announce"); resp.Data.Flags&interactionResponseFlagEphemeral != 0 {
+		t.Fatal("Public should opt out of the ephemeral default")
+	}
+	if resp := invokeCommand(t, server, priv, "plain"); resp.Data.Flags&interactionResponseFlagEphemeral != 0 {
+		t.Fatal("handlers without WithEphemeral should stay public")
+	}
+}
+
+func TestServerEphemeralAutoDefer(t *testing.T) {
+	server, priv := newTestServer(t)
+	release := make(chan struct{})
+	defer close(release)
+	server.RegisterCommand("slow", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		<-release
+		return nil, nil
+	}, WithEphemeral(), WithAutoDefer(10*time.Millisecond))
+
+	resp := invokeCommand(t, server, priv, "slow")
+	if resp.Type != types.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatalf("expected deferred response, got %d", resp.Type)
+	}
+	if resp.Data == nil || resp.Data.Flags&interactionResponseFlagEphemeral == 0 {
+		t.Fatal("expected ephemeral deferred response")
+	}
+}
+
+func TestEphemeralByDefaultLeavesUpdatesAlone(t *testing.T) {
+	handler := EphemeralByDefault(func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return &types.InteractionResponse{Type: types.InteractionResponseUpdateMessage}, nil
+	})
+	resp, err := handler(context.Background(), &types.Interaction{})
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if resp.Data != nil {
+		t.Fatalf("update responses should not be modified, got %+v", resp.Data)
+	}
+	if EphemeralByDefault(nil) != nil {
+		t.Fatal("expected nil for nil handler")
+	}
+}
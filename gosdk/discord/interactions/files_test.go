@@ -0,0 +1,119 @@
+package interactions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// readMultipart returns payload_json and the files[n] parts of a multipart body.
+func readMultipart(t *testing.T, contentType string, body io.Reader) (string, map[string]string) {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("unexpected content type %q", contentType)
+	}
+	reader := multipart.NewReader(body, params["boundary"])
+	var payload string
+	files := make(map[string]string)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		data, _ := io.ReadAll(part)
+		if part.FormName() == "payload_json" {
+			payload = string(data)
+		} else {
+			files[part.FileName()] = string(data)
+		}
+	}
+	return payload, files
+}
+
+func TestResponseBuilderAddFile(t *testing.T) {
+	resp, err := NewMessageResponse("report").
+		AddFile("chart.png", strings.NewReader("png")).
+		AddFileWithType("rows", "text/csv", strings.NewReader("a,b")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build error: %v", err)
+	}
+	if len(resp.Files) != 2 || resp.Files[0].ContentType != "image/png" || resp.Files[1].ContentType != "text/csv" {
+		t.Fatalf("unexpected files %+v", resp.Files)
+	}
+
+	if _, err := NewDeferredResponse().AddFile("a.txt", strings.NewReader("x")).Build(); err == nil {
+		t.Fatalf("expected error attaching a file to a deferred response")
+	}
+}
+
+func TestInteractionClientCreateInteractionResponseWithFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/interactions/abc/token/callback" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		payload, files := readMultipart(t, r.Header.Get("Content-Type"), r.Body)
+		if !strings.Contains(payload, `"content":"export"`) || files["rows.csv"] != "a,b" {
+			t.Fatalf("unexpected multipart payload %q files %v", payload, files)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ic, _ := NewInteractionClient(newInteractionTestClient(t, server.URL))
+	resp, _ := NewMessageResponse("export").AddFile("rows.csv", strings.NewReader("a,b")).Build()
+	if err := ic.CreateInteractionResponse(context.Background(), "abc", "token", resp); err != nil {
+		t.Fatalf("CreateInteractionResponse error: %v", err)
+	}
+}
+
+func TestInteractionClientCreateFollowupMessageWithFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/webhooks/app/token" || r.URL.Query().Get("wait") != "true" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		_, files := readMultipart(t, r.Header.Get("Content-Type"), r.Body)
+		if files["log.txt"] != "done" {
+			t.Fatalf("unexpected files %v", files)
+		}
+		json.NewEncoder(w).Encode(types.Message{ID: "m1"})
+	}))
+	defer server.Close()
+
+	ic, _ := NewInteractionClient(newInteractionTestClient(t, server.URL))
+	msg, err := ic.CreateFollowupMessageWithFiles(context.Background(), "app", "token", nil, []types.FileAttachment{{Name: "log.txt", Reader: strings.NewReader("done")}})
+	if err != nil || msg.ID != "m1" {
+		t.Fatalf("CreateFollowupMessageWithFiles = %+v, %v", msg, err)
+	}
+}
+
+func TestServerWritesMultipartResponse(t *testing.T) {
+	server, priv := newTestServer(t)
+	server.RegisterCommand("chart", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return NewMessageResponse("here you go").AddFile("chart.png", strings.NewReader("PNGDATA")).Build()
+	})
+
+	body, _ := json.Marshal(commandInteraction("chart"))
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	payload, files := readMultipart(t, rr.Header().Get("Content-Type"), rr.Body)
+	if !strings.Contains(payload, `"type":4`) || files["chart.png"] != "PNGDATA" {
+		t.Fatalf("unexpected multipart response %q %v", payload, files)
+	}
+}
@@ -0,0 +1,134 @@
+package interactions
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultHealthPath is where Handler answers health checks, relative to
+	// the path prefix.
+	DefaultHealthPath = "/healthz"
+	// DefaultShutdownTimeout bounds how long ListenAndServe waits for
+	// in-flight interactions once its context ends.
+	DefaultShutdownTimeout = 10 * time.Second
+
+	readHeaderTimeout = 10 * time.Second
+)
+
+// HTTPOption configures Handler, Serve, and ListenAndServe.
+type HTTPOption func(*httpConfig)
+
+type httpConfig struct {
+	prefix          string
+	healthPath      string
+	shutdownTimeout time.Duration
+}
+
+// WithPathPrefix mounts the endpoint under prefix, e.g. "/discord". Discord
+// then posts to the prefix itself and health checks go to prefix+"/healthz".
+func WithPathPrefix(prefix string) HTTPOption {
+	return func(c *httpConfig) {
+		c.prefix = strings.TrimSuffix(prefix, "/")
+	}
+}
+
+// WithHealthPath moves the health check endpoint, relative to the path
+// prefix. An empty path disables it.
+func WithHealthPath(path string) HTTPOption {
+	return func(c *httpConfig) {
+		if path != "" && !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		c.healthPath = path
+	}
+}
+
+// WithShutdownTimeout overrides DefaultShutdownTimeout. Non-positive values
+// are ignored.
+func WithShutdownTimeout(d time.Duration) HTTPOption {
+	return func(c *httpConfig) {
+		if d > 0 {
+			c.shutdownTimeout = d
+		}
+	}
+}
+
+func newHTTPConfig(opts []HTTPOption) httpConfig {
+	cfg := httpConfig{healthPath: DefaultHealthPath, shutdownTimeout: DefaultShutdownTimeout}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// Handler returns an http.Handler serving interactions at the path prefix
+// ("/" by default) and a health check that answers GET and HEAD with 200.
+// Other paths get 404.
+func (s *Server) Handler(opts ...HTTPOption) http.Handler {
+	cfg := newHTTPConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, cfg.prefix)
+		switch {
+		case !ok:
+			http.NotFound(w, r)
+		case rest == "" || rest == "/":
+			s.HandleInteraction(w, r)
+		case cfg.healthPath != "" && rest == cfg.healthPath:
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, "ok\n")
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// ListenAndServe serves Handler on addr until ctx is done, then shuts down
+// gracefully, letting in-flight interactions finish within the shutdown
+// timeout. It returns nil after a clean shutdown.
+func (s *Server) ListenAndServe(ctx context.Context, addr string, opts ...HTTPOption) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln, opts...)
+}
+
+// Serve is ListenAndServe on an existing listener, which it closes.
+func (s *Server) Serve(ctx context.Context, ln net.Listener, opts ...HTTPOption) error {
+	cfg := newHTTPConfig(opts)
+	srv := &http.Server{Handler: s.Handler(opts...), ReadHeaderTimeout: readHeaderTimeout}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		s.logger.Info("serving interactions", "addr", ln.Addr().String(), "path", cfg.prefix+"/")
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
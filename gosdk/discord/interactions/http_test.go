@@ -0,0 +1,141 @@
+package interactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestServerHandlerRoutes(t *testing.T) {
+	server, priv := newTestServer(t)
+	handler := server.Handler(WithPathPrefix("/discord/"))
+
+	body, _ := json.Marshal(&types.Interaction{Type: types.InteractionTypePing})
+	for _, path := range []string{"/discord", "/discord/"} {
+		req := newSignedRequest(t, priv, body)
+		req.URL.Path = path
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("POST %s: expected 200, got %d", path, rr.Code)
+		}
+	}
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/discord/healthz", http.StatusOK},
+		{http.MethodHead, "/discord/healthz", http.StatusOK},
+		{http.MethodPost, "/discord/healthz", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/healthz", http.StatusNotFound},
+		{http.MethodPost, "/discord/other", http.StatusNotFound},
+		{http.MethodGet, "/discord", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, nil))
+		if rr.Code != tt.want {
+			t.Fatalf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, rr.Code)
+		}
+	}
+}
+
+func TestServerHandlerHealthPath(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	rr := httptest.NewRecorder()
+	server.Handler(WithHealthPath("ready")).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 from custom health path, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	server.Handler(WithHealthPath("")).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, DefaultHealthPath, nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected disabled health check to 404, got %d", rr.Code)
+	}
+}
+
+func TestServerServeGracefulShutdown(t *testing.T) {
+	server, priv := newTestServer(t)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server.RegisterCommand("slow", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		close(started)
+		<-release
+		return &types.InteractionResponse{Type: types.InteractionResponseDeferredChannelMessageWithSource}, nil
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx, ln, WithShutdownTimeout(5*time.Second)) }()
+
+	base := "http://" + ln.Addr().String()
+	resp, err := http.Get(base + DefaultHealthPath)
+	if err != nil {
+		t.Fatalf("health check: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("health check status %d", resp.StatusCode)
+	}
+
+	body, _ := json.Marshal(&types.Interaction{
+		ID:    "1",
+		Type:  types.InteractionTypeApplicationCommand,
+		Token: "token",
+		Data:  &types.InteractionData{Name: "slow"},
+	})
+	req := newSignedRequest(t, priv, body)
+	req.RequestURI = ""
+	req.URL.Scheme, req.URL.Host = "http", ln.Addr().String()
+	inflight := make(chan int, 1)
+	go func() {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			inflight <- 0
+			return
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		inflight <- resp.StatusCode
+	}()
+
+	<-started
+	cancel()
+	select {
+	case err := <-done:
+		t.Fatalf("Serve returned before in-flight request finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	if code := <-inflight; code != http.StatusOK {
+		t.Fatalf("in-flight request status %d", code)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Serve error: %v", err)
+	}
+}
+
+func TestServerListenAndServeBadAddr(t *testing.T) {
+	server, _ := newTestServer(t)
+	err := server.ListenAndServe(context.Background(), "127.0.0.1:-1")
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("expected listen error, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package interactions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// malformedLogInterval spaces out warnings about rejected payloads, which
+// arrive in bursts when an endpoint is probed.
+const malformedLogInterval = time.Minute
+
+// WithDisallowUnknownFields rejects payloads containing fields the SDK types
+// do not model, at any depth. Discord adds fields over time, so enable this
+// only where rejecting unexpected input matters more than forward
+// compatibility.
+func WithDisallowUnknownFields(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.disallowUnknownFields = enabled
+	}
+}
+
+// WithPayloadValidation runs Interaction.Validate on every decoded payload
+// and rejects those missing required fields before any handler runs.
+func WithPayloadValidation(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.validatePayloads = enabled
+	}
+}
+
+// ServerStats counts interaction requests, including those rejected before
+// reaching a handler.
+type ServerStats struct {
+	// Requests is the number of POST requests received.
+	Requests uint64
+	// InvalidSignatures counts requests that failed signature verification.
+	InvalidSignatures uint64
+	// Malformed counts requests whose body could not be read, decoded, or
+	// validated.
+	Malformed uint64
+}
+
+type serverStats struct {
+	requests          atomic.Uint64
+	invalidSignatures atomic.Uint64
+	malformed         atomic.Uint64
+}
+
+// Stats returns a snapshot of the server's request counters.
+func (s *Server) Stats() ServerStats {
+	return ServerStats{
+		Requests:          s.stats.requests.Load(),
+		InvalidSignatures: s.stats.invalidSignatures.Load(),
+		Malformed:         s.stats.malformed.Load(),
+	}
+}
+
+// decodeInteraction decodes body, rejecting unknown fields when configured.
+func (s *Server) decodeInteraction(body []byte) (*types.Interaction, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if s.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	var interaction types.Interaction
+	if err := dec.Decode(&interaction); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after interaction payload")
+	}
+	return &interaction, nil
+}
+
+// rejectMalformed counts and logs a payload that could not be used.
+func (s *Server) rejectMalformed(reason string, err error) {
+	s.stats.malformed.Add(1)
+	s.logger.Every("malformed interaction", malformedLogInterval).Warn("rejected malformed interaction", "reason", reason, "error", err)
+}
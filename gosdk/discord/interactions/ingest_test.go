@@ -0,0 +1,73 @@
+package interactions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestServerDefaultDecodingIsLenient(t *testing.T) {
+	server, priv := newTestServer(t)
+	server.RegisterCommand("hello", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return NewMessageResponse("hi").Build()
+	})
+
+	body := []byte(`{"type":2,"data":{"name":"hello"},"app_permissions":"8"}`)
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected unknown fields to be ignored by default, got %d", rr.Code)
+	}
+	if stats := server.Stats(); stats.Requests != 1 || stats.Malformed != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestServerStrictDecoding(t *testing.T) {
+	server, priv := newTestServer(t)
+	WithDisallowUnknownFields(true)(server)
+	WithPayloadValidation(true)(server)
+	server.RegisterCommand("hello", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return NewMessageResponse("hi").Build()
+	})
+
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"valid", `{"id":"1","token":"t","type":2,"data":{"name":"hello"}}`, http.StatusOK},
+		{"unknown field", `{"id":"1","token":"t","type":2,"data":{"name":"hello"},"extra":true}`, http.StatusBadRequest},
+		{"missing token", `{"id":"1","type":2,"data":{"name":"hello"}}`, http.StatusBadRequest},
+		{"missing command name", `{"id":"1","token":"t","type":2}`, http.StatusBadRequest},
+		{"not json", `garbage`, http.StatusBadRequest},
+		{"trailing data", `{"id":"1","token":"t","type":1} {}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		server.HandleInteraction(rr, newSignedRequest(t, priv, []byte(tt.body)))
+		if rr.Code != tt.code {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.code, rr.Code)
+		}
+	}
+
+	if stats := server.Stats(); stats.Requests != 6 || stats.Malformed != 5 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestServerStatsCountsInvalidSignatures(t *testing.T) {
+	server, _ := newTestServer(t)
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rr.Code)
+	}
+	if stats := server.Stats(); stats.InvalidSignatures != 1 || stats.Malformed != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
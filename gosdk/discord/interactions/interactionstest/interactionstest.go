@@ -0,0 +1,279 @@
+// Package interactionstest provides helpers for testing interaction
+// handlers: a test key pair that signs requests the way Discord does,
+// builders for command, component, autocomplete and modal payloads with
+// resolved data, and assertions on the returned InteractionResponse.
+//
+//	h := interactionstest.New(t)
+//	h.Server.RegisterCommand("greet", greetHandler)
+//
+//	resp := h.Send(interactionstest.Command("greet",
+//		interactionstest.InGuild("1", "2"),
+//		interactionstest.UserOption("who", types.User{ID: "3", Username: "ada"}),
+//	))
+//	resp.AssertStatus(t, http.StatusOK)
+//	resp.AssertContent(t, "Hello, ada!")
+package interactionstest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/interactions"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// Header names Discord signs interaction requests with.
+const (
+	SignatureHeader = "X-Signature-Ed25519"
+	TimestampHeader = "X-Signature-Timestamp"
+)
+
+// flagEphemeral is the message flag for responses only the invoking user sees.
+const flagEphemeral = 1 << 6
+
+// KeyPair signs interaction requests. Configure the code under test with
+// PublicKeyHex, as you would with the key from the developer portal.
+type KeyPair struct {
+	PublicKey  ed25519.PublicKey
+	PrivateKey ed25519.PrivateKey
+}
+
+// NewKeyPair generates a key pair, failing t on error.
+func NewKeyPair(t testing.TB) *KeyPair {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("interactionstest: GenerateKey() error = %v", err)
+	}
+	return &KeyPair{PublicKey: pub, PrivateKey: priv}
+}
+
+// PublicKeyHex returns the public key in the hex form interactions.NewServer
+// expects.
+func (k *KeyPair) PublicKeyHex() string {
+	return hex.EncodeToString(k.PublicKey)
+}
+
+// Sign returns the hex signature Discord would send for body at timestamp.
+func (k *KeyPair) Sign(timestamp string, body []byte) string {
+	message := append([]byte(timestamp), body...)
+	return hex.EncodeToString(ed25519.Sign(k.PrivateKey, message))
+}
+
+// SignedRequest returns a POST request for body with valid signature
+// headers for the current time.
+func (k *KeyPair) SignedRequest(body []byte) *http.Request {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, k.Sign(timestamp, body))
+	return req
+}
+
+// Request marshals i and returns it as a signed POST request.
+func (k *KeyPair) Request(t testing.TB, i *types.Interaction) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(i)
+	if err != nil {
+		t.Fatalf("interactionstest: marshal interaction: %v", err)
+	}
+	return k.SignedRequest(body)
+}
+
+// Harness pairs an interactions.Server with the key pair that signs the
+// requests sent to it.
+type Harness struct {
+	*KeyPair
+	Server *interactions.Server
+
+	t       testing.TB
+	handler http.Handler
+}
+
+// New returns a Harness whose Server verifies requests signed by a fresh
+// key pair. opts are passed to interactions.NewServer.
+func New(t testing.TB, opts ...interactions.ServerOption) *Harness {
+	t.Helper()
+	keys := NewKeyPair(t)
+	server, err := interactions.NewServer(keys.PublicKeyHex(), opts...)
+	if err != nil {
+		t.Fatalf("interactionstest: NewServer() error = %v", err)
+	}
+	return &Harness{KeyPair: keys, Server: server, t: t, handler: http.HandlerFunc(server.HandleInteraction)}
+}
+
+// Send signs i, serves it with the Server, and returns the response.
+func (h *Harness) Send(i *types.Interaction) *Response {
+	h.t.Helper()
+	return Serve(h.t, h.handler, h.Request(h.t, i))
+}
+
+// Response is a recorded reply to an interaction request.
+type Response struct {
+	// Code is the HTTP status.
+	Code   int
+	Header http.Header
+	// Body is the raw body; for multipart responses, the payload_json part.
+	Body []byte
+	// Interaction is the decoded response, or nil when the body was not an
+	// interaction response (errors and 204s).
+	Interaction *types.InteractionResponse
+	// Files lists the names of files attached to a multipart response.
+	Files []string
+}
+
+// Serve runs req through handler, which may be an interactions.Server's
+// HandleInteraction, its Handler(), or any wrapping middleware, and decodes
+// the response.
+func Serve(t testing.TB, handler http.Handler, req *http.Request) *Response {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	resp := &Response{Code: rr.Code, Header: rr.Header(), Body: rr.Body.Bytes()}
+	mediaType, params, _ := mime.ParseMediaType(rr.Header().Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		resp.Body = nil
+		reader := multipart.NewReader(bytes.NewReader(rr.Body.Bytes()), params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("interactionstest: read multipart response: %v", err)
+			}
+			if part.FormName() == "payload_json" {
+				resp.Body, _ = io.ReadAll(part)
+			} else if part.FileName() != "" {
+				resp.Files = append(resp.Files, part.FileName())
+			}
+		}
+	}
+
+	// Error bodies are plain text; anything else that decodes is the
+	// interaction response, whatever content type the handler set.
+	var decoded types.InteractionResponse
+	if rr.Code == http.StatusOK && json.Unmarshal(resp.Body, &decoded) == nil {
+		resp.Interaction = &decoded
+	}
+	return resp
+}
+
+// Data returns the response data, or an empty value when there is none.
+func (r *Response) Data() types.InteractionApplicationCommandCallbackData {
+	if r.Interaction == nil || r.Interaction.Data == nil {
+		return types.InteractionApplicationCommandCallbackData{}
+	}
+	return *r.Interaction.Data
+}
+
+// AssertStatus fails t unless the HTTP status is code.
+func (r *Response) AssertStatus(t testing.TB, code int) {
+	t.Helper()
+	if r.Code != code {
+		t.Fatalf("interactionstest: status = %d, want %d; body: %s", r.Code, code, r.Body)
+	}
+}
+
+// AssertType fails t unless the response has the given callback type.
+func (r *Response) AssertType(t testing.TB, typ types.InteractionResponseType) {
+	t.Helper()
+	if r.Interaction == nil {
+		t.Fatalf("interactionstest: no interaction response (status %d): %s", r.Code, r.Body)
+	}
+	if r.Interaction.Type != typ {
+		t.Fatalf("interactionstest: response type = %d, want %d", r.Interaction.Type, typ)
+	}
+}
+
+// AssertContent fails t unless the response message content is content.
+func (r *Response) AssertContent(t testing.TB, content string) {
+	t.Helper()
+	if r.Interaction == nil {
+		t.Fatalf("interactionstest: no interaction response (status %d): %s", r.Code, r.Body)
+	}
+	if got := r.Data().Content; got != content {
+		t.Fatalf("interactionstest: content = %q, want %q", got, content)
+	}
+}
+
+// AssertEphemeral fails t unless the response is flagged ephemeral.
+func (r *Response) AssertEphemeral(t testing.TB) {
+	t.Helper()
+	if r.Data().Flags&flagEphemeral == 0 {
+		t.Fatalf("interactionstest: response is not ephemeral: %s", r.Body)
+	}
+}
+
+// AssertPublic fails t if the response is flagged ephemeral.
+func (r *Response) AssertPublic(t testing.TB) {
+	t.Helper()
+	if r.Data().Flags&flagEphemeral != 0 {
+		t.Fatalf("interactionstest: response is ephemeral: %s", r.Body)
+	}
+}
+
+// AssertModal fails t unless the response opens the modal customID.
+func (r *Response) AssertModal(t testing.TB, customID string) {
+	t.Helper()
+	r.AssertType(t, types.InteractionResponseModal)
+	if got := r.Data().CustomID; got != customID {
+		t.Fatalf("interactionstest: modal custom ID = %q, want %q", got, customID)
+	}
+}
+
+// AssertChoices fails t unless the response is an autocomplete result with
+// choices of exactly these names, in order.
+func (r *Response) AssertChoices(t testing.TB, names ...string) {
+	t.Helper()
+	r.AssertType(t, types.InteractionResponseAutocompleteResult)
+	choices := r.Data().Choices
+	got := make([]string, len(choices))
+	for i, c := range choices {
+		got[i] = c.Name
+	}
+	if len(got) != len(names) {
+		t.Fatalf("interactionstest: choices = %q, want %q", got, names)
+	}
+	for i := range got {
+		if got[i] != names[i] {
+			t.Fatalf("interactionstest: choices = %q, want %q", got, names)
+		}
+	}
+}
+
+// AssertComponent fails t unless the response message contains a component
+// with customID, at any depth, and returns it.
+func (r *Response) AssertComponent(t testing.TB, customID string) types.MessageComponent {
+	t.Helper()
+	if c, ok := findComponent(r.Data().Components, customID); ok {
+		return c
+	}
+	t.Fatalf("interactionstest: no component %q in response: %s", customID, r.Body)
+	return types.MessageComponent{}
+}
+
+func findComponent(components []types.MessageComponent, customID string) (types.MessageComponent, bool) {
+	for _, c := range components {
+		if c.CustomID == customID {
+			return c, true
+		}
+		if found, ok := findComponent(c.Components, customID); ok {
+			return found, true
+		}
+	}
+	return types.MessageComponent{}, false
+}
@@ -0,0 +1,112 @@
+package interactionstest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/interactions"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestSignedRequestVerifies(t *testing.T) {
+	keys := NewKeyPair(t)
+	req := keys.Request(t, Ping())
+	body, _ := io.ReadAll(req.Body)
+
+	if !interactions.Verify(keys.PublicKey, req.Header.Get(TimestampHeader), req.Header.Get(SignatureHeader), body) {
+		t.Fatal("signature did not verify")
+	}
+	if interactions.Verify(NewKeyPair(t).PublicKey, req.Header.Get(TimestampHeader), req.Header.Get(SignatureHeader), body) {
+		t.Fatal("signature verified with another key")
+	}
+}
+
+func TestHarnessPing(t *testing.T) {
+	h := New(t)
+	resp := h.Send(Ping())
+	resp.AssertStatus(t, http.StatusOK)
+	resp.AssertType(t, types.InteractionResponsePong)
+}
+
+func TestHarnessRejectsForeignSignature(t *testing.T) {
+	h := New(t)
+	resp := Serve(t, h.Server.Handler(), NewKeyPair(t).Request(t, Ping()))
+	resp.AssertStatus(t, http.StatusUnauthorized)
+	if resp.Interaction != nil {
+		t.Fatalf("error response decoded as %+v", resp.Interaction)
+	}
+}
+
+func TestHarnessCommandResponse(t *testing.T) {
+	h := New(t)
+	h.Server.RegisterCommand("greet", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		who := i.Data.Resolved.Users[i.Data.Options[0].Value.(string)]
+		return interactions.NewMessageResponse("Hello, "+who.Username+"!").
+			SetEphemeral(true).
+			AddFile("greeting.txt", strings.NewReader("hi")).
+			Build()
+	})
+
+	resp := h.Send(Command("greet", InGuild("1", "2"), UserOption("who", types.User{ID: "3", Username: "ada"})))
+	resp.AssertStatus(t, http.StatusOK)
+	resp.AssertType(t, types.InteractionResponseChannelMessageWithSource)
+	resp.AssertContent(t, "Hello, ada!")
+	resp.AssertEphemeral(t)
+	if len(resp.Files) != 1 || resp.Files[0] != "greeting.txt" {
+		t.Fatalf("files = %v", resp.Files)
+	}
+}
+
+func TestHarnessModalAndComponents(t *testing.T) {
+	h := New(t)
+	h.Server.RegisterComponent("open", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return interactions.NewModalResponse("feedback", "Feedback").
+			SetModalComponents(&types.ActionRow{Components: []types.Component{
+				&types.TextInput{CustomID: "text", Label: "Feedback", Style: types.TextInputStyleParagraph},
+			}}).
+			Build()
+	})
+	h.Server.RegisterModal("feedback", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		text := interactions.ModalData(i).Value("text")
+		return interactions.NewMessageResponse("got: " + text).
+			SetRawComponents(types.MessageComponent{
+				Type:       types.ComponentTypeActionRow,
+				Components: []types.MessageComponent{{Type: types.ComponentTypeButton, CustomID: "undo", Label: "Undo", Style: 2}},
+			}).
+			Build()
+	})
+
+	h.Send(Button("open")).AssertModal(t, "feedback")
+
+	resp := h.Send(ModalSubmit("feedback", TextInput("text", "great")))
+	resp.AssertContent(t, "got: great")
+	resp.AssertPublic(t)
+	if c := resp.AssertComponent(t, "undo"); c.Label != "Undo" {
+		t.Fatalf("component = %+v", c)
+	}
+}
+
+func TestServeAutocomplete(t *testing.T) {
+	keys := NewKeyPair(t)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var i types.Interaction
+		json.NewDecoder(r.Body).Decode(&i)
+		json.NewEncoder(w).Encode(&types.InteractionResponse{
+			Type: types.InteractionResponseAutocompleteResult,
+			Data: &types.InteractionApplicationCommandCallbackData{Choices: []types.AutocompleteChoice{
+				{Name: i.Data.Options[0].Value.(string) + "1", Value: "1"},
+				{Name: i.Data.Options[0].Value.(string) + "2", Value: "2"},
+			}},
+		})
+	})
+
+	resp := Serve(t, handler, keys.Request(t, Autocomplete("search", Focused("query", "go"))))
+	if resp.Interaction == nil {
+		t.Fatalf("response without JSON content type was not decoded: %s", resp.Body)
+	}
+	resp.AssertChoices(t, "go1", "go2")
+}
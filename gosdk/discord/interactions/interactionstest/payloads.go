@@ -0,0 +1,360 @@
+package interactionstest
+
+import (
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// Defaults used by the payload builders.
+const (
+	ApplicationID = "100000000000000001"
+	ChannelID     = "100000000000000002"
+	Token         = "interactionstest-token"
+)
+
+// DefaultUser invokes interactions unless AsUser or WithMember is given.
+var DefaultUser = types.User{ID: "100000000000000003", Username: "tester"}
+
+var ids = types.NewSnowflakeGenerator(1, 1)
+
+// Option customizes a fabricated interaction.
+type Option func(*types.Interaction)
+
+func newInteraction(typ types.InteractionType, data *types.InteractionData, opts []Option) *types.Interaction {
+	user := DefaultUser
+	i := &types.Interaction{
+		ID:            ids.Next().String(),
+		ApplicationID: ApplicationID,
+		Type:          typ,
+		Data:          data,
+		ChannelID:     ChannelID,
+		User:          &user,
+		Token:         Token,
+		Version:       1,
+		Locale:        "en-US",
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Ping returns the PING Discord sends to check the endpoint.
+func Ping() *types.Interaction {
+	return newInteraction(types.InteractionTypePing, nil, nil)
+}
+
+// Command returns a chat input command interaction for name. Add options
+// with StringOption, UserOption, Subcommand and the like.
+func Command(name string, opts ...Option) *types.Interaction {
+	return newInteraction(types.InteractionTypeApplicationCommand, &types.InteractionData{
+		ID:   ids.Next().String(),
+		Name: name,
+		Type: types.ApplicationCommandTypeChatInput,
+	}, opts)
+}
+
+// UserCommand returns a user context menu command targeting target, which
+// is included in the resolved data.
+func UserCommand(name string, target types.User, opts ...Option) *types.Interaction {
+	i := newInteraction(types.InteractionTypeApplicationCommand, &types.InteractionData{
+		ID:       ids.Next().String(),
+		Name:     name,
+		Type:     types.ApplicationCommandTypeUser,
+		TargetID: target.ID,
+	}, nil)
+	resolved(i).Users[target.ID] = target
+	return apply(i, opts)
+}
+
+// MessageCommand returns a message context menu command targeting target,
+// which is included in the resolved data.
+func MessageCommand(name string, target types.Message, opts ...Option) *types.Interaction {
+	i := newInteraction(types.InteractionTypeApplicationCommand, &types.InteractionData{
+		ID:       ids.Next().String(),
+		Name:     name,
+		Type:     types.ApplicationCommandTypeMessage,
+		TargetID: target.ID,
+	}, nil)
+	resolved(i).Messages[target.ID] = target
+	return apply(i, opts)
+}
+
+// Autocomplete returns an autocomplete interaction for command name. Mark
+// the option being typed with Focused.
+func Autocomplete(name string, opts ...Option) *types.Interaction {
+	return newInteraction(types.InteractionTypeApplicationCommandAutocomplete, &types.InteractionData{
+		ID:   ids.Next().String(),
+		Name: name,
+		Type: types.ApplicationCommandTypeChatInput,
+	}, opts)
+}
+
+// Button returns a button click on customID.
+func Button(customID string, opts ...Option) *types.Interaction {
+	return newInteraction(types.InteractionTypeMessageComponent, &types.InteractionData{
+		CustomID:      customID,
+		ComponentType: types.ComponentTypeButton,
+	}, opts)
+}
+
+// Select returns a string select submission of values on customID. Use
+// SelectUsers and the like for entity selects.
+func Select(customID string, values []string, opts ...Option) *types.Interaction {
+	return newInteraction(types.InteractionTypeMessageComponent, &types.InteractionData{
+		CustomID:      customID,
+		ComponentType: types.ComponentTypeStringSelect,
+		Values:        values,
+	}, opts)
+}
+
+// SelectUsers returns a user select submission on customID, with the users
+// in the resolved data.
+func SelectUsers(customID string, users []types.User, opts ...Option) *types.Interaction {
+	i := newInteraction(types.InteractionTypeMessageComponent, &types.InteractionData{
+		CustomID:      customID,
+		ComponentType: types.ComponentTypeUserSelect,
+	}, nil)
+	for _, u := range users {
+		i.Data.Values = append(i.Data.Values, u.ID)
+		resolved(i).Users[u.ID] = u
+	}
+	return apply(i, opts)
+}
+
+// SelectRoles returns a role select submission on customID, with the roles
+// in the resolved data.
+func SelectRoles(customID string, roles []types.Role, opts ...Option) *types.Interaction {
+	i := newInteraction(types.InteractionTypeMessageComponent, &types.InteractionData{
+		CustomID:      customID,
+		ComponentType: types.ComponentTypeRoleSelect,
+	}, nil)
+	for _, r := range roles {
+		i.Data.Values = append(i.Data.Values, r.ID)
+		resolved(i).Roles[r.ID] = r
+	}
+	return apply(i, opts)
+}
+
+// ModalSubmit returns a submission of the modal customID. Add fields with
+// TextInput and SelectInput.
+func ModalSubmit(customID string, opts ...Option) *types.Interaction {
+	return newInteraction(types.InteractionTypeModalSubmit, &types.InteractionData{
+		CustomID: customID,
+	}, opts)
+}
+
+func apply(i *types.Interaction, opts []Option) *types.Interaction {
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// resolved returns i's resolved data, creating its maps.
+func resolved(i *types.Interaction) *types.ResolvedData {
+	r := i.Data.Resolved
+	if r == nil {
+		r = &types.ResolvedData{}
+		i.Data.Resolved = r
+	}
+	if r.Users == nil {
+		r.Users = make(map[string]types.User)
+		r.Members = make(map[string]types.Member)
+		r.Roles = make(map[string]types.Role)
+		r.Channels = make(map[string]types.Channel)
+		r.Messages = make(map[string]types.Message)
+		r.Attachments = make(map[string]types.Attachment)
+	}
+	return r
+}
+
+// InGuild sends the interaction from channelID in guildID. The invoking
+// user becomes a guild member, as Discord sends member rather than user
+// for guild interactions.
+func InGuild(guildID, channelID string) Option {
+	return func(i *types.Interaction) {
+		i.GuildID = guildID
+		i.ChannelID = channelID
+		i.GuildLocale = "en-US"
+		if i.User != nil {
+			i.Member = &types.Member{User: i.User, Roles: []string{}}
+			i.User = nil
+		}
+	}
+}
+
+// AsUser makes u the invoking user, as a member in guild interactions.
+func AsUser(u types.User) Option {
+	return func(i *types.Interaction) {
+		if i.GuildID != "" || i.Member != nil {
+			if i.Member == nil {
+				i.Member = &types.Member{Roles: []string{}}
+			}
+			i.Member.User = &u
+			i.User = nil
+			return
+		}
+		i.User = &u
+	}
+}
+
+// WithMember makes m the invoking guild member. Combine it with InGuild.
+func WithMember(m types.Member) Option {
+	return func(i *types.Interaction) {
+		if m.User == nil {
+			user := DefaultUser
+			m.User = &user
+		}
+		i.Member = &m
+		i.User = nil
+	}
+}
+
+// WithLocale sets the invoking user's locale.
+func WithLocale(locale string) Option {
+	return func(i *types.Interaction) {
+		i.Locale = locale
+	}
+}
+
+// WithMessage sets the message a component interaction was triggered on.
+func WithMessage(m types.Message) Option {
+	return func(i *types.Interaction) {
+		i.Message = &m
+	}
+}
+
+func addOption(i *types.Interaction, opt types.ApplicationCommandOption) {
+	i.Data.Options = append(i.Data.Options, opt)
+}
+
+// StringOption adds a string option.
+func StringOption(name, value string) Option {
+	return func(i *types.Interaction) {
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionString, Name: name, Value: value})
+	}
+}
+
+// IntegerOption adds an integer option.
+func IntegerOption(name string, value int64) Option {
+	return func(i *types.Interaction) {
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionInteger, Name: name, Value: value})
+	}
+}
+
+// NumberOption adds a number option.
+func NumberOption(name string, value float64) Option {
+	return func(i *types.Interaction) {
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionNumber, Name: name, Value: value})
+	}
+}
+
+// BooleanOption adds a boolean option.
+func BooleanOption(name string, value bool) Option {
+	return func(i *types.Interaction) {
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionBoolean, Name: name, Value: value})
+	}
+}
+
+// UserOption adds a user option, with u in the resolved data.
+func UserOption(name string, u types.User) Option {
+	return func(i *types.Interaction) {
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionUser, Name: name, Value: u.ID})
+		resolved(i).Users[u.ID] = u
+	}
+}
+
+// MemberOption adds a user option for a guild member, with the user and
+// member in the resolved data. m.User must be set.
+func MemberOption(name string, m types.Member) Option {
+	return func(i *types.Interaction) {
+		u := *m.User
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionUser, Name: name, Value: u.ID})
+		r := resolved(i)
+		r.Users[u.ID] = u
+		// Resolved members omit the user, which is under Users.
+		m.User = nil
+		r.Members[u.ID] = m
+	}
+}
+
+// RoleOption adds a role option, with role in the resolved data.
+func RoleOption(name string, role types.Role) Option {
+	return func(i *types.Interaction) {
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionRole, Name: name, Value: role.ID})
+		resolved(i).Roles[role.ID] = role
+	}
+}
+
+// ChannelOption adds a channel option, with ch in the resolved data.
+func ChannelOption(name string, ch types.Channel) Option {
+	return func(i *types.Interaction) {
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionChannel, Name: name, Value: ch.ID})
+		resolved(i).Channels[ch.ID] = ch
+	}
+}
+
+// AttachmentOption adds an attachment option, with a in the resolved data.
+func AttachmentOption(name string, a types.Attachment) Option {
+	return func(i *types.Interaction) {
+		if a.ID == "" {
+			a.ID = ids.Next().String()
+		}
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionAttachment, Name: name, Value: a.ID})
+		resolved(i).Attachments[a.ID] = a
+	}
+}
+
+// Focused adds the string option being typed in an autocomplete
+// interaction.
+func Focused(name, value string) Option {
+	return func(i *types.Interaction) {
+		addOption(i, types.ApplicationCommandOption{Type: types.CommandOptionString, Name: name, Value: value, Focused: true})
+	}
+}
+
+// Subcommand nests the options built by opts under subcommand name. Nest
+// Subcommand inside SubcommandGroup for grouped commands.
+func Subcommand(name string, opts ...Option) Option {
+	return nested(types.CommandOptionSubCommand, name, opts)
+}
+
+// SubcommandGroup nests the subcommands built by opts under group name.
+func SubcommandGroup(name string, opts ...Option) Option {
+	return nested(types.CommandOptionSubCommandGroup, name, opts)
+}
+
+func nested(typ types.ApplicationCommandOptionType, name string, opts []Option) Option {
+	return func(i *types.Interaction) {
+		// Build the nested options on their own, then move them under the
+		// new option; resolved data stays at the top level.
+		saved := i.Data.Options
+		i.Data.Options = nil
+		for _, opt := range opts {
+			opt(i)
+		}
+		children := i.Data.Options
+		i.Data.Options = append(saved, types.ApplicationCommandOption{Type: typ, Name: name, Options: children})
+	}
+}
+
+// TextInput adds a submitted text input to a modal submission.
+func TextInput(customID, value string) Option {
+	return func(i *types.Interaction) {
+		addRow(i, types.MessageComponent{Type: types.ComponentTypeTextInput, CustomID: customID, Value: value})
+	}
+}
+
+// SelectInput adds a submitted string select to a modal submission.
+func SelectInput(customID string, values ...string) Option {
+	return func(i *types.Interaction) {
+		addRow(i, types.MessageComponent{Type: types.ComponentTypeStringSelect, CustomID: customID, Values: values})
+	}
+}
+
+func addRow(i *types.Interaction, c types.MessageComponent) {
+	i.Data.Components = append(i.Data.Components, types.MessageComponent{
+		Type:       types.ComponentTypeActionRow,
+		Components: []types.MessageComponent{c},
+	})
+}
@@ -0,0 +1,97 @@
+package interactionstest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/interactions"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestBuildersProduceValidPayloads(t *testing.T) {
+	payloads := map[string]*types.Interaction{
+		"ping":         Ping(),
+		"command":      Command("ping"),
+		"user":         UserCommand("inspect", types.User{ID: "5"}),
+		"message":      MessageCommand("quote", types.Message{ID: "6", Content: "hi"}),
+		"autocomplete": Autocomplete("search", Focused("q", "a")),
+		"button":       Button("ok"),
+		"select":       Select("color", []string{"red"}),
+		"users":        SelectUsers("who", []types.User{{ID: "7"}}),
+		"roles":        SelectRoles("roles", []types.Role{{ID: "8"}}),
+		"modal":        ModalSubmit("form", TextInput("name", "ada")),
+	}
+	for name, i := range payloads {
+		if err := i.Validate(); err != nil {
+			t.Errorf("%s: Validate() error = %v", name, err)
+		}
+	}
+	if Command("a").ID == Command("a").ID {
+		t.Error("interactions should get distinct IDs")
+	}
+}
+
+func TestCommandOptionsRoundTrip(t *testing.T) {
+	member := types.Member{User: &types.User{ID: "11", Username: "grace"}, Nick: "G"}
+	i := Command("admin",
+		InGuild("1", "2"),
+		AsUser(types.User{ID: "9", Username: "mod"}),
+		SubcommandGroup("roles",
+			Subcommand("grant",
+				MemberOption("member", member),
+				RoleOption("role", types.Role{ID: "12", Name: "Helper"}),
+				IntegerOption("days", 7),
+				BooleanOption("notify", true),
+			),
+		),
+		ChannelOption("log", types.Channel{ID: "13"}),
+	)
+
+	var decoded types.Interaction
+	data, _ := json.Marshal(i)
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if decoded.User != nil || decoded.Member == nil || decoded.Member.User.ID != "9" {
+		t.Fatalf("guild interaction should carry a member: user=%+v member=%+v", decoded.User, decoded.Member)
+	}
+	opts := decoded.Data.Options
+	if len(opts) != 2 || opts[0].Type != types.CommandOptionSubCommandGroup || opts[1].Name != "log" {
+		t.Fatalf("options = %+v", opts)
+	}
+	grant := opts[0].Options[0]
+	if grant.Name != "grant" || len(grant.Options) != 4 {
+		t.Fatalf("subcommand = %+v", grant)
+	}
+	if grant.Options[0].Value != "11" || grant.Options[2].Value != float64(7) || grant.Options[3].Value != true {
+		t.Fatalf("values = %+v", grant.Options)
+	}
+
+	r := decoded.Data.Resolved
+	if r.Users["11"].Username != "grace" || r.Members["11"].Nick != "G" || r.Members["11"].User != nil {
+		t.Fatalf("resolved member = %+v / %+v", r.Users["11"], r.Members["11"])
+	}
+	if r.Roles["12"].Name != "Helper" || r.Channels["13"].ID != "13" {
+		t.Fatalf("resolved = %+v", r)
+	}
+}
+
+func TestModalSubmitReadsWithModalData(t *testing.T) {
+	i := ModalSubmit("report", TextInput("title", "Broken"), SelectInput("severity", "high"))
+	modal := interactions.ModalData(i)
+	if modal.Value("title") != "Broken" || modal.Value("severity") != "high" {
+		t.Fatalf("modal values = %v", modal.Map())
+	}
+}
+
+func TestTargetCommandsResolveTarget(t *testing.T) {
+	i := MessageCommand("quote", types.Message{ID: "6", Content: "hi"})
+	if i.Data.TargetID != "6" || i.Data.Resolved.Messages["6"].Content != "hi" {
+		t.Fatalf("data = %+v", i.Data)
+	}
+	u := UserCommand("inspect", types.User{ID: "5", Username: "ada"}, InGuild("1", "2"))
+	if u.Data.Resolved.Users["5"].Username != "ada" || u.Member == nil {
+		t.Fatalf("user command = %+v", u)
+	}
+}
@@ -0,0 +1,178 @@
+// Package lambda adapts an interactions.Server to AWS Lambda, for bots that
+// answer slash commands from a serverless function.
+//
+// Request and Response mirror the JSON that API Gateway (REST and HTTP APIs,
+// payload formats 1.0 and 2.0) and Lambda function URLs exchange with a
+// function, so the package needs no AWS dependency. Pass the adapter to the
+// AWS runtime as is:
+//
+//	server, _ := interactions.NewServer(publicKey)
+//	server.RegisterCommand("ping", ping)
+//	awslambda.Start(lambda.Handler(server))
+//
+// Signature verification, PING handling, and routing run exactly as they do
+// behind net/http. WithAutoDefer is the exception: its handlers finish after
+// the deferred response is returned, and Lambda freezes the function once
+// the invocation ends, so the edit would never be sent. Handler refuses
+// servers with auto-deferred handlers; return a deferred response and
+// finish with a follow-up invocation or queue instead.
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/interactions"
+)
+
+// Request is an API Gateway or function URL proxy event. Format 1.0 events
+// set HTTPMethod and Path; format 2.0 and function URL events set
+// RequestContext.HTTP and RawPath.
+type Request struct {
+	Version           string              `json:"version,omitempty"`
+	HTTPMethod        string              `json:"httpMethod,omitempty"`
+	Path              string              `json:"path,omitempty"`
+	RawPath           string              `json:"rawPath,omitempty"`
+	RawQueryString    string              `json:"rawQueryString,omitempty"`
+	Headers           map[string]string   `json:"headers,omitempty"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders,omitempty"`
+	RequestContext    RequestContext      `json:"requestContext"`
+	Body              string              `json:"body,omitempty"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded,omitempty"`
+}
+
+// RequestContext holds the parts of the event's request context the adapter
+// reads.
+type RequestContext struct {
+	RequestID string      `json:"requestId,omitempty"`
+	HTTP      HTTPContext `json:"http"`
+}
+
+// HTTPContext describes the HTTP request in format 2.0 events.
+type HTTPContext struct {
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// Response is the proxy response returned to API Gateway or the function URL.
+type Response struct {
+	StatusCode      int               `json:"statusCode"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded,omitempty"`
+}
+
+// ErrAutoDefer is returned for every event when the server has handlers
+// registered with interactions.WithAutoDefer.
+var ErrAutoDefer = errors.New("lambda: WithAutoDefer handlers cannot finish after the invocation returns")
+
+// Handler returns a Lambda handler that verifies and dispatches interaction
+// events with server.
+func Handler(server *interactions.Server) func(context.Context, Request) (Response, error) {
+	return func(ctx context.Context, event Request) (Response, error) {
+		if server.AutoDefers() {
+			return Response{}, ErrAutoDefer
+		}
+		req, err := event.httpRequest(ctx)
+		if err != nil {
+			return Response{}, err
+		}
+		w := newResponseWriter()
+		server.HandleInteraction(w, req)
+		return w.response(), nil
+	}
+}
+
+// httpRequest rebuilds the HTTP request Discord sent. The body must reach
+// signature verification byte for byte, so base64 bodies are decoded and
+// nothing else is touched.
+func (e Request) httpRequest(ctx context.Context) (*http.Request, error) {
+	body := []byte(e.Body)
+	if e.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(e.Body)
+		if err != nil {
+			return nil, fmt.Errorf("lambda: decode body: %w", err)
+		}
+		body = decoded
+	}
+
+	method := e.RequestContext.HTTP.Method
+	if method == "" {
+		method = e.HTTPMethod
+	}
+	path := e.RawPath
+	if path == "" {
+		path = e.Path
+	}
+	if path == "" {
+		path = "/"
+	}
+	if e.RawQueryString != "" {
+		path += "?" + e.RawQueryString
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("lambda: build request: %w", err)
+	}
+	for name, values := range e.MultiValueHeaders {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	for name, v := range e.Headers {
+		if req.Header.Get(name) == "" {
+			// Format 2.0 joins repeated headers with commas.
+			req.Header.Set(name, v)
+		}
+	}
+	return req, nil
+}
+
+// responseWriter collects what the server writes into a Response.
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{header: make(http.Header)}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(p)
+}
+
+func (w *responseWriter) response() Response {
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	headers := make(map[string]string, len(w.header))
+	for name, values := range w.header {
+		headers[name] = strings.Join(values, ",")
+	}
+	// Multipart responses carry file bytes, which must be base64 encoded to
+	// survive the JSON event.
+	if strings.HasPrefix(w.header.Get("Content-Type"), "multipart/") {
+		return Response{StatusCode: status, Headers: headers, Body: base64.StdEncoding.EncodeToString(w.body.Bytes()), IsBase64Encoded: true}
+	}
+	return Response{StatusCode: status, Headers: headers, Body: w.body.String()}
+}
@@ -0,0 +1,171 @@
+package lambda
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/interactions"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func newTestServer(t *testing.T) (*interactions.Server, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	server, err := interactions.NewServer(hex.EncodeToString(pub))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	return server, priv
+}
+
+func sign(priv ed25519.PrivateKey, timestamp string, body []byte) string {
+	return hex.EncodeToString(ed25519.Sign(priv, append([]byte(timestamp), body...)))
+}
+
+func TestHandlerFunctionURLPing(t *testing.T) {
+	server, priv := newTestServer(t)
+	body, _ := json.Marshal(&types.Interaction{Type: types.InteractionTypePing})
+
+	// Function URL events (payload format 2.0) lowercase header names.
+	event := Request{
+		Version: "2.0",
+		RawPath: "/",
+		Headers: map[string]string{
+			"x-signature-ed25519":   sign(priv, "1700000000", body),
+			"x-signature-timestamp": "1700000000",
+			"content-type":          "application/json",
+		},
+		RequestContext: RequestContext{HTTP: HTTPContext{Method: http.MethodPost, Path: "/"}},
+		Body:           string(body),
+	}
+	resp, err := Handler(server)(context.Background(), event)
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Headers["Content-Type"] != "application/json" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	var pong types.InteractionResponse
+	if err := json.Unmarshal([]byte(resp.Body), &pong); err != nil || pong.Type != types.InteractionResponsePong {
+		t.Fatalf("expected PONG, got %q (%v)", resp.Body, err)
+	}
+}
+
+func TestHandlerRESTAPICommandBase64(t *testing.T) {
+	server, priv := newTestServer(t)
+	server.RegisterCommand("hello", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return interactions.NewMessageResponse("world").Build()
+	})
+	body, _ := json.Marshal(&types.Interaction{
+		Type: types.InteractionTypeApplicationCommand,
+		Data: &types.InteractionData{Name: "hello"},
+	})
+
+	event := Request{
+		HTTPMethod: http.MethodPost,
+		Path:       "/prod/interactions",
+		MultiValueHeaders: map[string][]string{
+			"X-Signature-Ed25519":   {sign(priv, "42", body)},
+			"X-Signature-Timestamp": {"42"},
+		},
+		Body:            base64.StdEncoding.EncodeToString(body),
+		IsBase64Encoded: true,
+	}
+	resp, err := Handler(server)(context.Background(), event)
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	var out types.InteractionResponse
+	if err := json.Unmarshal([]byte(resp.Body), &out); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || out.Data == nil || out.Data.Content != "world" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func TestHandlerRejectsBadSignature(t *testing.T) {
+	server, _ := newTestServer(t)
+	_, other, _ := ed25519.GenerateKey(rand.Reader)
+	body := []byte(`{"type":1}`)
+
+	resp, err := Handler(server)(context.Background(), Request{
+		Headers: map[string]string{
+			"x-signature-ed25519":   sign(other, "1", body),
+			"x-signature-timestamp": "1",
+		},
+		RequestContext: RequestContext{HTTP: HTTPContext{Method: http.MethodPost}},
+		Body:           string(body),
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandlerBadBase64(t *testing.T) {
+	server, _ := newTestServer(t)
+	_, err := Handler(server)(context.Background(), Request{
+		HTTPMethod:      http.MethodPost,
+		Body:            "not base64!",
+		IsBase64Encoded: true,
+	})
+	if err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
+
+func TestHandlerBase64EncodesMultipartResponses(t *testing.T) {
+	server, priv := newTestServer(t)
+	server.RegisterCommand("export", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return interactions.NewMessageResponse("csv").AddFile("rows.csv", strings.NewReader("a,b\x00")).Build()
+	})
+	body, _ := json.Marshal(&types.Interaction{
+		Type: types.InteractionTypeApplicationCommand,
+		Data: &types.InteractionData{Name: "export"},
+	})
+
+	resp, err := Handler(server)(context.Background(), Request{
+		Headers: map[string]string{
+			"x-signature-ed25519":   sign(priv, "7", body),
+			"x-signature-timestamp": "7",
+		},
+		RequestContext: RequestContext{HTTP: HTTPContext{Method: http.MethodPost}},
+		Body:           string(body),
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	if !resp.IsBase64Encoded || !strings.HasPrefix(resp.Headers["Content-Type"], "multipart/form-data") {
+		t.Fatalf("expected base64 multipart response, got %+v", resp)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(resp.Body)
+	if err != nil || !strings.Contains(string(decoded), "a,b\x00") {
+		t.Fatalf("unexpected body %q (%v)", decoded, err)
+	}
+}
+
+func TestHandlerRejectsAutoDefer(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.RegisterCommand("slow", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return nil, nil
+	}, interactions.WithAutoDefer(time.Second))
+
+	if _, err := Handler(server)(context.Background(), Request{}); !errors.Is(err, ErrAutoDefer) {
+		t.Fatalf("expected ErrAutoDefer, got %v", err)
+	}
+}
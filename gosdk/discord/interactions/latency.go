@@ -0,0 +1,178 @@
+package interactions
+
+import (
+	"context"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+const (
+	// InteractionDeadline is how long Discord waits for the initial callback.
+	InteractionDeadline = 3 * time.Second
+
+	// MaxAutoDefer is the longest delay WithAutoDefer accepts. It leaves half a
+	// second of InteractionDeadline for the deferred response to reach Discord.
+	MaxAutoDefer = InteractionDeadline - 500*time.Millisecond
+
+	defaultLatencyWarning = 2 * time.Second
+)
+
+// HandlerOption configures behaviour for a single registered handler.
+type HandlerOption func(*handlerConfig)
+
+type handlerConfig struct {
+	autoDefer time.Duration
+	ephemeral bool
+}
+
+// WithAutoDefer answers with a deferred response when the handler has not
+// returned after the given duration. The handler keeps running and its eventual
+// response is delivered by editing the original response through the client
+// configured with WithInteractionClient. Durations above MaxAutoDefer are
+// clamped to it, since a later deferral would miss InteractionDeadline.
+func WithAutoDefer(after time.Duration) HandlerOption {
+	return func(cfg *handlerConfig) {
+		if after > 0 {
+			cfg.autoDefer = min(after, MaxAutoDefer)
+		}
+	}
+}
+
+// WithLatencyWarning sets the handler duration that triggers a slow-handler warning.
+func WithLatencyWarning(threshold time.Duration) ServerOption {
+	return func(s *Server) {
+		if threshold > 0 {
+			s.latencyWarning = threshold
+		}
+	}
+}
+
+// WithInteractionClient provides the REST client used to deliver auto-deferred responses.
+func WithInteractionClient(ic *InteractionClient) ServerOption {
+	return func(s *Server) {
+		if ic != nil {
+			s.interactionClient = ic
+		}
+	}
+}
+
+func (s *Server) wrapHandler(handler Handler, opts []HandlerOption) Handler {
+	var cfg handlerConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.ephemeral {
+		handler = EphemeralByDefault(handler)
+	}
+	if cfg.autoDefer <= 0 {
+		return handler
+	}
+	s.autoDefers = true
+	return s.autoDeferHandler(handler, cfg.autoDefer, cfg.ephemeral)
+}
+
+// AutoDefers reports whether any handler was registered with WithAutoDefer.
+// Those handlers finish in the background after the deferred response is
+// written, so they need a process that keeps running once the request ends.
+func (s *Server) AutoDefers() bool {
+	return s.autoDefers
+}
+
+type handlerResult struct {
+	resp *types.InteractionResponse
+	err  error
+}
+
+func (s *Server) autoDeferHandler(handler Handler, after time.Duration, ephemeral bool) Handler {
+	return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		// The request context ends once the deferred callback is written, so the
+		// handler runs detached from it.
+		handlerCtx := context.WithoutCancel(ctx)
+		done := make(chan handlerResult, 1)
+		go func() {
+			resp, err := handler(handlerCtx, i)
+			done <- handlerResult{resp: resp, err: err}
+		}()
+
+		timer := time.NewTimer(after)
+		defer timer.Stop()
+
+		select {
+		case result := <-done:
+			return result.resp, result.err
+		case <-timer.C:
+			s.logger.Info("auto-deferring interaction", "interaction_id", i.ID, "after", after)
+			go s.completeDeferred(handlerCtx, i, done)
+			resp := deferredResponseFor(i)
+			if ephemeral {
+				markEphemeral(resp)
+			}
+			return resp, nil
+		}
+	}
+}
+
+func (s *Server) completeDeferred(ctx context.Context, i *types.Interaction, done <-chan handlerResult) {
+	result := <-done
+	if result.err != nil {
+		s.logger.Error("deferred interaction handler error", "interaction_id", i.ID, "error", result.err)
+		return
+	}
+	if result.resp == nil || result.resp.Data == nil {
+		return
+	}
+	if s.interactionClient == nil {
+		s.logger.Warn("dropping deferred interaction response: no interaction client configured", "interaction_id", i.ID)
+		return
+	}
+
+	if len(result.resp.Files) > 0 {
+		s.logger.Warn("deferred interaction response files are not uploaded; send them with CreateFollowupMessageWithFiles", "interaction_id", i.ID)
+	}
+	data := result.resp.Data
+	params := &types.MessageEditParams{
+		Content:         data.Content,
+		Embeds:          data.Embeds,
+		Components:      data.Components,
+		AllowedMentions: data.AllowedMentions,
+		Flags:           data.Flags,
+	}
+	if _, err := s.interactionClient.EditOriginalInteractionResponse(ctx, i.ApplicationID, i.Token, params); err != nil {
+		s.logger.Error("failed to deliver deferred interaction response", "interaction_id", i.ID, "error", err)
+	}
+}
+
+func deferredResponseFor(i *types.Interaction) *types.InteractionResponse {
+	if i != nil && i.Type == types.InteractionTypeMessageComponent {
+		return &types.InteractionResponse{Type: types.InteractionResponseDeferredUpdateMessage}
+	}
+	return &types.InteractionResponse{Type: types.InteractionResponseDeferredChannelMessageWithSource}
+}
+
+// observeLatency logs handler duration and warns when it nears the callback deadline.
+func (s *Server) observeLatency(i *types.Interaction, elapsed time.Duration) {
+	name := interactionName(i)
+	if elapsed >= s.latencyWarning {
+		s.logger.Warn("slow interaction handler",
+			"interaction", name,
+			"elapsed", elapsed,
+			"deadline", InteractionDeadline,
+			"remaining", InteractionDeadline-elapsed,
+		)
+		return
+	}
+	s.logger.Debug("interaction handled", "interaction", name, "elapsed", elapsed)
+}
+
+func interactionName(i *types.Interaction) string {
+	if i == nil || i.Data == nil {
+		return ""
+	}
+	if i.Data.Name != "" {
+		return i.Data.Name
+	}
+	return i.Data.CustomID
+}
@@ -0,0 +1,137 @@
+package interactions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestServerAutoDeferSlowHandler(t *testing.T) {
+	edited := make(chan types.MessageEditParams, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/webhooks/app/token/messages/@original" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			return
+		}
+		var payload types.MessageEditParams
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		edited <- payload
+		_ = json.NewEncoder(w).Encode(types.Message{ID: "1", Content: payload.Content})
+	}))
+	defer api.Close()
+
+	ic, err := NewInteractionClient(newInteractionTestClient(t, api.URL))
+	if err != nil {
+		t.Fatalf("NewInteractionClient error: %v", err)
+	}
+
+	server, priv := newTestServer(t)
+	WithInteractionClient(ic)(server)
+
+	release := make(chan struct{})
+	server.RegisterCommand("slow", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		<-release
+		return &types.InteractionResponse{
+			Type: types.InteractionResponseChannelMessageWithSource,
+			Data: &types.InteractionApplicationCommandCallbackData{
+				Content:         "done",
+				Components:      []types.MessageComponent{{Type: types.ComponentTypeActionRow}},
+				AllowedMentions: &types.AllowedMentions{Parse: []types.AllowedMentionType{}},
+				Flags:           1 << 2, // SUPPRESS_EMBEDS
+			},
+		}, nil
+	}, WithAutoDefer(10*time.Millisecond))
+
+	body, _ := json.Marshal(&types.Interaction{
+		ApplicationID: "app",
+		Token:         "token",
+		Type:          types.InteractionTypeApplicationCommand,
+		Data:          &types.InteractionData{Name: "slow"},
+	})
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body))
+
+	var resp types.InteractionResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Type != types.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatalf("expected deferred response, got %d", resp.Type)
+	}
+
+	close(release)
+	select {
+	case payload := <-edited:
+		if payload.Content != "done" {
+			t.Fatalf("expected edited content 'done', got %q", payload.Content)
+		}
+		if len(payload.Components) != 1 || payload.AllowedMentions == nil || payload.Flags != 1<<2 {
+			t.Fatalf("deferred edit dropped response fields: %+v", payload)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("deferred response was not delivered")
+	}
+}
+
+func TestServerAutoDeferFastHandler(t *testing.T) {
+	server, priv := newTestServer(t)
+	server.RegisterComponent("fast", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return &types.InteractionResponse{Type: types.InteractionResponseUpdateMessage}, nil
+	}, WithAutoDefer(time.Second))
+
+	body, _ := json.Marshal(&types.Interaction{
+		Type: types.InteractionTypeMessageComponent,
+		Data: &types.InteractionData{CustomID: "fast"},
+	})
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body))
+
+	var resp types.InteractionResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Type != types.InteractionResponseUpdateMessage {
+		t.Fatalf("expected handler response, got %d", resp.Type)
+	}
+}
+
+func TestDeferredResponseFor(t *testing.T) {
+	component := deferredResponseFor(&types.Interaction{Type: types.InteractionTypeMessageComponent})
+	if component.Type != types.InteractionResponseDeferredUpdateMessage {
+		t.Fatalf("expected deferred update for components, got %d", component.Type)
+	}
+	command := deferredResponseFor(&types.Interaction{Type: types.InteractionTypeApplicationCommand})
+	if command.Type != types.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatalf("expected deferred message for commands, got %d", command.Type)
+	}
+}
+
+func TestWithAutoDeferClampsToDeadline(t *testing.T) {
+	tests := []struct {
+		name  string
+		after time.Duration
+		want  time.Duration
+	}{
+		{"zero disables", 0, 0},
+		{"negative disables", -time.Second, 0},
+		{"below max", time.Second, time.Second},
+		{"at max", MaxAutoDefer, MaxAutoDefer},
+		{"just above max", MaxAutoDefer + time.Millisecond, MaxAutoDefer},
+		{"at deadline", InteractionDeadline, MaxAutoDefer},
+		{"past deadline", 10 * time.Second, MaxAutoDefer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg handlerConfig
+			WithAutoDefer(tt.after)(&cfg)
+			if cfg.autoDefer != tt.want {
+				t.Fatalf("WithAutoDefer(%v) = %v, want %v", tt.after, cfg.autoDefer, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package interactions
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+// DefaultPanicMessage is the ephemeral reply Recover sends when a handler
+// panics.
+const DefaultPanicMessage = "Something went wrong while handling this interaction."
+
+// Built-in middleware for Router.Use. Middleware registered first runs
+// outermost: it sees the interaction before, and the response after, every
+// middleware registered later. A typical chain is
+//
+//	router.Use(interactions.Recover(log, ""))
+//	router.Use(interactions.Logging(log))
+//	router.Use(interactions.Metrics(record))
+//	router.Use(interactions.Timeout(2 * time.Second))
+//
+// Recover goes first so it also catches panics in the middleware after it.
+// Logging and Metrics come before Timeout so that timed-out handlers are
+// logged and measured. Timeout can also wrap a single handler:
+// interactions.Timeout(d)(handler).
+
+type loggerKey struct{}
+
+// LoggerFromContext returns the logger Logging attached to ctx, which carries
+// the interaction's ID, name, guild, and user. Without Logging it returns
+// logger.Default().
+func LoggerFromContext(ctx context.Context) *logger.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*logger.Logger); ok {
+		return l
+	}
+	return logger.Default()
+}
+
+// Recover turns a handler panic into an ephemeral reply with message
+// (DefaultPanicMessage when empty) and logs the panic with its stack.
+// Autocomplete interactions cannot show a message, so they fail with an
+// error instead.
+func Recover(l logger.Interface, message string) Middleware {
+	log := logger.From(l)
+	if log == nil {
+		log = logger.Default()
+	}
+	log = log.WithSubsystem(logger.SubsystemInteractions)
+	if message == "" {
+		message = DefaultPanicMessage
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, i *types.Interaction) (resp *types.InteractionResponse, err error) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				log.Error("interaction handler panicked",
+					"interaction_id", i.ID,
+					"interaction", interactionName(i),
+					"panic", fmt.Sprint(recovered),
+					"stack", string(debug.Stack()),
+				)
+				if i.Type == types.InteractionTypeApplicationCommandAutocomplete {
+					resp, err = nil, fmt.Errorf("interaction %q panicked: %v", interactionName(i), recovered)
+					return
+				}
+				resp, err = panicResponse(message), nil
+			}()
+			return next(ctx, i)
+		}
+	}
+}
+
+func panicResponse(message string) *types.InteractionResponse {
+	return &types.InteractionResponse{
+		Type: types.InteractionResponseChannelMessageWithSource,
+		Data: &types.InteractionApplicationCommandCallbackData{
+			Content: message,
+			Flags:   interactionResponseFlagEphemeral,
+		},
+	}
+}
+
+// Logging logs every handled interaction with its duration and outcome, and
+// attaches a logger carrying the interaction's fields to the handler context
+// (see LoggerFromContext).
+func Logging(l logger.Interface) Middleware {
+	log := logger.From(l)
+	if log == nil {
+		log = logger.Default()
+	}
+	log = log.WithSubsystem(logger.SubsystemInteractions)
+	return func(next Handler) Handler {
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			l := log.With(
+				"interaction_id", i.ID,
+				"interaction", interactionName(i),
+				"type", int(i.Type),
+				"guild_id", i.GuildID,
+				"user_id", interactionUserID(i),
+			)
+			start := time.Now()
+			resp, err := next(context.WithValue(ctx, loggerKey{}, l), i)
+			elapsed := time.Since(start)
+			if err != nil {
+				l.Error("interaction failed", "elapsed", elapsed, "error", err)
+				return resp, err
+			}
+			fields := []interface{}{"elapsed", elapsed}
+			if resp != nil {
+				fields = append(fields, "response_type", int(resp.Type))
+			}
+			l.Info("interaction handled", fields...)
+			return resp, err
+		}
+	}
+}
+
+// HandlerMetric describes one handler invocation, as reported by Metrics.
+type HandlerMetric struct {
+	// Name is the command name or component/modal custom ID.
+	Name    string
+	Type    types.InteractionType
+	Elapsed time.Duration
+	Err     error
+}
+
+// Metrics calls record after every handler invocation, so latency and error
+// counts can be exported to any metrics system. A nil record disables it.
+func Metrics(record func(HandlerMetric)) Middleware {
+	return func(next Handler) Handler {
+		if record == nil {
+			return next
+		}
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			start := time.Now()
+			resp, err := next(ctx, i)
+			record(HandlerMetric{Name: interactionName(i), Type: i.Type, Elapsed: time.Since(start), Err: err})
+			return resp, err
+		}
+	}
+}
+
+// Timeout cancels the handler's context after d and, if the handler has not
+// returned by then, fails the interaction with an error wrapping
+// context.DeadlineExceeded. Handlers should watch ctx; one that ignores it
+// keeps running in the background and its result is dropped. Keep d under
+// InteractionDeadline, or use WithAutoDefer for slow work. A non-positive d
+// disables the timeout.
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		if d <= 0 {
+			return next
+		}
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			done := make(chan timeoutResult, 1)
+			go func() {
+				var result timeoutResult
+				defer func() {
+					// Hand panics back to the calling goroutine so Recover
+					// still sees them.
+					result.panicked = recover()
+					done <- result
+				}()
+				result.resp, result.err = next(ctx, i)
+			}()
+			select {
+			case result := <-done:
+				return result.unwrap()
+			case <-ctx.Done():
+				// A handler that finished just as the deadline passed
+				// still wins.
+				select {
+				case result := <-done:
+					return result.unwrap()
+				default:
+				}
+				return nil, fmt.Errorf("interaction %q did not finish within %s: %w", interactionName(i), d, ctx.Err())
+			}
+		}
+	}
+}
+
+type timeoutResult struct {
+	resp     *types.InteractionResponse
+	err      error
+	panicked any
+}
+
+// unwrap returns the handler's result, re-raising its panic if it had one.
+func (r timeoutResult) unwrap() (*types.InteractionResponse, error) {
+	if r.panicked != nil {
+		panic(r.panicked)
+	}
+	return r.resp, r.err
+}
@@ -0,0 +1,143 @@
+package interactions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+func commandInteraction(name string) *types.Interaction {
+	return &types.Interaction{
+		ID:   "10",
+		Type: types.InteractionTypeApplicationCommand,
+		Data: &types.InteractionData{Name: name},
+		User: &types.User{ID: "42"},
+	}
+}
+
+func TestRecoverReturnsEphemeralMessage(t *testing.T) {
+	var logs bytes.Buffer
+	router := NewRouter()
+	router.Use(Recover(logger.New(logger.InfoLevel, "json", &logs), ""))
+	server, priv := newTestServer(t)
+	WithRouter(router)(server)
+	server.RegisterCommand("boom", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		panic("nil map")
+	})
+
+	body, _ := json.Marshal(commandInteraction("boom"))
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var resp types.InteractionResponse
+	json.Unmarshal(rr.Body.Bytes(), &resp)
+	if resp.Data == nil || resp.Data.Content != DefaultPanicMessage || resp.Data.Flags&interactionResponseFlagEphemeral == 0 {
+		t.Fatalf("unexpected response %+v", resp.Data)
+	}
+	if !strings.Contains(logs.String(), "nil map") || !strings.Contains(logs.String(), "stack") {
+		t.Fatalf("panic not logged: %s", logs.String())
+	}
+}
+
+func TestRecoverAutocompleteReturnsError(t *testing.T) {
+	handler := Recover(logger.New(logger.ErrorLevel, "json", &bytes.Buffer{}), "")(func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		panic("boom")
+	})
+	i := commandInteraction("search")
+	i.Type = types.InteractionTypeApplicationCommandAutocomplete
+	if resp, err := handler(context.Background(), i); err == nil || resp != nil {
+		t.Fatalf("expected error for autocomplete panic, got %+v, %v", resp, err)
+	}
+}
+
+func TestRecoverCatchesPanicInsideTimeout(t *testing.T) {
+	router := NewRouter()
+	router.Use(Recover(logger.New(logger.ErrorLevel, "json", &bytes.Buffer{}), "oops"))
+	router.Use(Timeout(time.Second))
+	router.Command("boom", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		panic("inside timeout")
+	})
+
+	resp, err := router.Resolve(commandInteraction("boom"))(context.Background(), commandInteraction("boom"))
+	if err != nil || resp.Data == nil || resp.Data.Content != "oops" {
+		t.Fatalf("expected recovered response, got %+v, %v", resp, err)
+	}
+}
+
+func TestLoggingEnrichesContext(t *testing.T) {
+	var logs bytes.Buffer
+	handler := Logging(logger.New(logger.InfoLevel, "json", &logs))(func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		LoggerFromContext(ctx).Info("looking up balance")
+		return NewMessageResponse("42 coins").Build()
+	})
+	if _, err := handler(context.Background(), commandInteraction("balance")); err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %q", logs.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"interaction_id":"10"`) || !strings.Contains(line, `"user_id":"42"`) || !strings.Contains(line, `"interaction":"balance"`) {
+			t.Fatalf("log line missing interaction fields: %s", line)
+		}
+	}
+	if !strings.Contains(lines[1], "elapsed") {
+		t.Fatalf("completion line missing elapsed: %s", lines[1])
+	}
+	if LoggerFromContext(context.Background()) == nil {
+		t.Fatalf("expected default logger without Logging")
+	}
+}
+
+func TestMetricsRecordsInvocation(t *testing.T) {
+	handlerErr := errors.New("down")
+	var got []HandlerMetric
+	handler := Metrics(func(m HandlerMetric) { got = append(got, m) })(func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return nil, handlerErr
+	})
+	handler(context.Background(), commandInteraction("status"))
+
+	if len(got) != 1 || got[0].Name != "status" || got[0].Type != types.InteractionTypeApplicationCommand || !errors.Is(got[0].Err, handlerErr) {
+		t.Fatalf("unexpected metrics %+v", got)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	slow := func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return NewMessageResponse("late").Build()
+		}
+	}
+	start := time.Now()
+	_, err := Timeout(20*time.Millisecond)(slow)(context.Background(), commandInteraction("slow"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("timeout did not cut the handler short")
+	}
+
+	fast := func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return NewMessageResponse("ok").Build()
+	}
+	if resp, err := Timeout(time.Second)(fast)(context.Background(), commandInteraction("fast")); err != nil || resp.Data.Content != "ok" {
+		t.Fatalf("fast handler: %+v, %v", resp, err)
+	}
+}
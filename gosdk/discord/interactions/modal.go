@@ -0,0 +1,122 @@
+package interactions
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// ModalSubmission holds the values of a submitted modal, keyed by component
+// custom ID.
+type ModalSubmission struct {
+	// CustomID is the modal's custom ID.
+	CustomID string
+
+	values   map[string][]string
+	resolved *types.ResolvedData
+}
+
+// ModalData collects the values from a modal submit interaction, walking its
+// action rows so handlers can read inputs by custom ID. Text inputs have one
+// value and select menus one per selected option. It returns an empty
+// submission for other interaction types.
+func ModalData(i *types.Interaction) *ModalSubmission {
+	m := &ModalSubmission{values: make(map[string][]string)}
+	if i == nil || i.Data == nil || i.Type != types.InteractionTypeModalSubmit {
+		return m
+	}
+	m.CustomID = i.Data.CustomID
+	m.resolved = i.Data.Resolved
+	m.collect(i.Data.Components)
+	return m
+}
+
+func (m *ModalSubmission) collect(components []types.MessageComponent) {
+	for _, c := range components {
+		switch {
+		case len(c.Components) > 0:
+			m.collect(c.Components)
+		case c.CustomID == "":
+		case c.Type == types.ComponentTypeTextInput:
+			m.values[c.CustomID] = []string{c.Value}
+		default:
+			m.values[c.CustomID] = c.Values
+		}
+	}
+}
+
+// Map returns the submitted values as custom ID to value, joining
+// multi-value selects with commas.
+func (m *ModalSubmission) Map() map[string]string {
+	out := make(map[string]string, len(m.values))
+	for id, values := range m.values {
+		out[id] = strings.Join(values, ",")
+	}
+	return out
+}
+
+// Lookup returns the value of a text input, or the first selected value of
+// a select menu, and whether the modal contained the component.
+func (m *ModalSubmission) Lookup(customID string) (string, bool) {
+	values, ok := m.values[customID]
+	if !ok || len(values) == 0 {
+		return "", ok
+	}
+	return values[0], true
+}
+
+// Value returns the value of a text input, or "" when it is missing or was
+// left empty.
+func (m *ModalSubmission) Value(customID string) string {
+	v, _ := m.Lookup(customID)
+	return v
+}
+
+// Values returns every selected value of a select menu.
+func (m *ModalSubmission) Values(customID string) []string {
+	return m.values[customID]
+}
+
+// Select returns a user, role, mentionable, or channel select's values with
+// the modal's resolved data, so the selected entities can be read with
+// SelectedUsers, SelectedMembers, SelectedRoles, or SelectedChannels.
+func (m *ModalSubmission) Select(customID string) *types.InteractionData {
+	return &types.InteractionData{CustomID: customID, Values: m.values[customID], Resolved: m.resolved}
+}
+
+// Required returns the trimmed value of an input, or a validation error
+// naming the input when it is missing or blank.
+func (m *ModalSubmission) Required(customID string) (string, error) {
+	v := strings.TrimSpace(m.Value(customID))
+	if v == "" {
+		return "", &types.ValidationError{Field: customID, Message: "a value is required"}
+	}
+	return v, nil
+}
+
+// Int parses an input as a base 10 integer.
+func (m *ModalSubmission) Int(customID string) (int64, error) {
+	v, err := m.Required(customID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, &types.ValidationError{Field: customID, Message: "must be a whole number"}
+	}
+	return n, nil
+}
+
+// Float parses an input as a decimal number.
+func (m *ModalSubmission) Float(customID string) (float64, error) {
+	v, err := m.Required(customID)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, &types.ValidationError{Field: customID, Message: "must be a number"}
+	}
+	return f, nil
+}
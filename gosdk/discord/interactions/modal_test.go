@@ -0,0 +1,89 @@
+package interactions
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+const modalSubmitPayload = `{
+	"id": "1",
+	"type": 5,
+	"token": "t",
+	"data": {
+		"custom_id": "feedback",
+		"components": [
+			{"type": 1, "components": [{"type": 4, "custom_id": "title", "value": "  Great bot  "}]},
+			{"type": 1, "components": [{"type": 4, "custom_id": "rating", "value": "9"}]},
+			{"type": 1, "components": [{"type": 4, "custom_id": "comment", "value": ""}]},
+			{"type": 1, "components": [{"type": 3, "custom_id": "areas", "values": ["docs", "speed"]}]}
+		]
+	}
+}`
+
+func TestModalData(t *testing.T) {
+	var i types.Interaction
+	if err := json.Unmarshal([]byte(modalSubmitPayload), &i); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	m := ModalData(&i)
+
+	if m.CustomID != "feedback" || m.Value("title") != "  Great bot  " {
+		t.Fatalf("unexpected submission %+v", m)
+	}
+	if title, err := m.Required("title"); err != nil || title != "Great bot" {
+		t.Fatalf("Required(title) = %q, %v", title, err)
+	}
+	if n, err := m.Int("rating"); err != nil || n != 9 {
+		t.Fatalf("Int(rating) = %d, %v", n, err)
+	}
+	if f, err := m.Float("rating"); err != nil || f != 9 {
+		t.Fatalf("Float(rating) = %v, %v", f, err)
+	}
+	if v, ok := m.Lookup("comment"); !ok || v != "" {
+		t.Fatalf("Lookup(comment) = %q, %v", v, ok)
+	}
+	if _, ok := m.Lookup("missing"); ok {
+		t.Fatalf("expected missing input")
+	}
+	if got := m.Values("areas"); len(got) != 2 || got[1] != "speed" {
+		t.Fatalf("Values(areas) = %v", got)
+	}
+	if got := m.Map(); len(got) != 4 || got["areas"] != "docs,speed" || got["rating"] != "9" {
+		t.Fatalf("Map() = %v", got)
+	}
+
+	var verr *types.ValidationError
+	if _, err := m.Required("comment"); !errors.As(err, &verr) || verr.Field != "comment" {
+		t.Fatalf("expected validation error for blank comment, got %v", err)
+	}
+	if _, err := m.Int("title"); !errors.As(err, &verr) || verr.Field != "title" {
+		t.Fatalf("expected validation error for non-numeric title, got %v", err)
+	}
+}
+
+func TestModalDataOtherInteraction(t *testing.T) {
+	m := ModalData(&types.Interaction{Type: types.InteractionTypeApplicationCommand, Data: &types.InteractionData{Name: "x"}})
+	if m.CustomID != "" || len(m.Map()) != 0 {
+		t.Fatalf("expected empty submission, got %+v", m)
+	}
+	if ModalData(nil).Value("x") != "" {
+		t.Fatalf("expected empty value for nil interaction")
+	}
+}
+
+func TestModalSubmissionSelect(t *testing.T) {
+	var i types.Interaction
+	payload := `{"id":"1","type":5,"token":"t","data":{"custom_id":"setup",
+		"components":[{"type":1,"components":[{"type":6,"custom_id":"roles","values":["7"]}]}],
+		"resolved":{"roles":{"7":{"id":"7","name":"helpers"}}}}}`
+	if err := json.Unmarshal([]byte(payload), &i); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	roles := ModalData(&i).Select("roles").SelectedRoles()
+	if len(roles) != 1 || roles[0].Name != "helpers" {
+		t.Fatalf("SelectedRoles = %+v", roles)
+	}
+}
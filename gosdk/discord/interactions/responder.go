@@ -0,0 +1,138 @@
+package interactions
+
+import (
+	"context"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// Responder binds an InteractionClient to one interaction, so handlers can
+// reply, defer, and send follow-ups without passing the application ID and
+// token around.
+//
+// Handlers served over HTTP answer by returning a response; use Reply and
+// Defer only for interactions received over the gateway, or the interaction
+// will be answered twice. Follow-ups and original-response edits work in
+// both cases.
+type Responder struct {
+	client      *InteractionClient
+	interaction *types.Interaction
+}
+
+type responderKey struct{}
+
+// NewResponder returns a Responder for i.
+func NewResponder(ic *InteractionClient, i *types.Interaction) *Responder {
+	return &Responder{client: ic, interaction: i}
+}
+
+// ResponderFromContext returns the Responder the server attached to a
+// handler's context. Servers configured with WithInteractionClient attach
+// one to every dispatched interaction; otherwise the returned Responder's
+// methods fail with a validation error.
+func ResponderFromContext(ctx context.Context) *Responder {
+	if r, ok := ctx.Value(responderKey{}).(*Responder); ok {
+		return r
+	}
+	return &Responder{}
+}
+
+// ContextWithResponder attaches r to ctx, for gateway dispatchers that want
+// handlers to use ResponderFromContext.
+func ContextWithResponder(ctx context.Context, r *Responder) context.Context {
+	return context.WithValue(ctx, responderKey{}, r)
+}
+
+// Interaction returns the interaction r responds to.
+func (r *Responder) Interaction() *types.Interaction {
+	return r.interaction
+}
+
+// Reply sends the initial response through the callback endpoint.
+func (r *Responder) Reply(ctx context.Context, resp *types.InteractionResponse) error {
+	if err := r.check(); err != nil {
+		return err
+	}
+	return r.client.CreateInteractionResponse(ctx, r.interaction.ID, r.interaction.Token, resp)
+}
+
+// Defer acknowledges the interaction so the response can follow within 15
+// minutes. Components get a deferred message update and everything else a
+// deferred message, ephemeral when requested.
+func (r *Responder) Defer(ctx context.Context, ephemeral bool) error {
+	if err := r.check(); err != nil {
+		return err
+	}
+	resp := deferredResponseFor(r.interaction)
+	if ephemeral {
+		markEphemeral(resp)
+	}
+	return r.Reply(ctx, resp)
+}
+
+// Original returns the original response message.
+func (r *Responder) Original(ctx context.Context) (*types.Message, error) {
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+	return r.client.GetOriginalInteractionResponse(ctx, r.interaction.ApplicationID, r.interaction.Token)
+}
+
+// EditOriginal edits the original response, which is how a deferred
+// response is completed.
+func (r *Responder) EditOriginal(ctx context.Context, params *types.MessageEditParams) (*types.Message, error) {
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+	return r.client.EditOriginalInteractionResponse(ctx, r.interaction.ApplicationID, r.interaction.Token, params)
+}
+
+// DeleteOriginal deletes the original response.
+func (r *Responder) DeleteOriginal(ctx context.Context) error {
+	if err := r.check(); err != nil {
+		return err
+	}
+	return r.client.DeleteOriginalInteractionResponse(ctx, r.interaction.ApplicationID, r.interaction.Token)
+}
+
+// Followup sends a follow-up message.
+func (r *Responder) Followup(ctx context.Context, params *types.MessageCreateParams) (*types.Message, error) {
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+	return r.client.CreateFollowupMessage(ctx, r.interaction.ApplicationID, r.interaction.Token, params)
+}
+
+// FollowupWithFiles sends a follow-up message with file attachments.
+func (r *Responder) FollowupWithFiles(ctx context.Context, params *types.MessageCreateParams, files []types.FileAttachment) (*types.Message, error) {
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+	return r.client.CreateFollowupMessageWithFiles(ctx, r.interaction.ApplicationID, r.interaction.Token, params, files)
+}
+
+// EditFollowup edits a follow-up message.
+func (r *Responder) EditFollowup(ctx context.Context, messageID string, params *types.MessageEditParams) (*types.Message, error) {
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+	return r.client.EditFollowupMessage(ctx, r.interaction.ApplicationID, r.interaction.Token, messageID, params)
+}
+
+// DeleteFollowup deletes a follow-up message.
+func (r *Responder) DeleteFollowup(ctx context.Context, messageID string) error {
+	if err := r.check(); err != nil {
+		return err
+	}
+	return r.client.DeleteFollowupMessage(ctx, r.interaction.ApplicationID, r.interaction.Token, messageID)
+}
+
+func (r *Responder) check() error {
+	if r == nil || r.client == nil {
+		return &types.ValidationError{Field: "responder", Message: "no interaction client configured; use WithInteractionClient"}
+	}
+	if r.interaction == nil {
+		return &types.ValidationError{Field: "interaction", Message: "interaction is required"}
+	}
+	return nil
+}
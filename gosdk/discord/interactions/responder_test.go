@@ -0,0 +1,94 @@
+package interactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestResponder(t *testing.T) {
+	var calls []string
+	var deferred types.InteractionResponse
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.RequestURI())
+		if r.URL.Path == "/interactions/i1/tok/callback" {
+			json.NewDecoder(r.Body).Decode(&deferred)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		io.WriteString(w, `{"id":"m1"}`)
+	}))
+	defer server.Close()
+
+	ic, _ := NewInteractionClient(newInteractionTestClient(t, server.URL))
+	r := NewResponder(ic, &types.Interaction{ID: "i1", ApplicationID: "app", Token: "tok", Type: types.InteractionTypeApplicationCommand})
+	ctx := context.Background()
+
+	if err := r.Defer(ctx, true); err != nil {
+		t.Fatalf("Defer error: %v", err)
+	}
+	if deferred.Type != types.InteractionResponseDeferredChannelMessageWithSource || deferred.Data == nil || deferred.Data.Flags&interactionResponseFlagEphemeral == 0 {
+		t.Fatalf("unexpected deferred response %+v", deferred)
+	}
+	if _, err := r.EditOriginal(ctx, &types.MessageEditParams{Content: "done"}); err != nil {
+		t.Fatalf("EditOriginal error: %v", err)
+	}
+	msg, err := r.Followup(ctx, &types.MessageCreateParams{Content: "more"})
+	if err != nil || msg.ID != "m1" {
+		t.Fatalf("Followup = %+v, %v", msg, err)
+	}
+	if err := r.DeleteFollowup(ctx, "m1"); err != nil {
+		t.Fatalf("DeleteFollowup error: %v", err)
+	}
+	if err := r.DeleteOriginal(ctx); err != nil {
+		t.Fatalf("DeleteOriginal error: %v", err)
+	}
+
+	want := []string{
+		"POST /interactions/i1/tok/callback",
+		"PATCH /webhooks/app/tok/messages/@original",
+		"POST /webhooks/app/tok?wait=true",
+		"DELETE /webhooks/app/tok/messages/m1",
+		"DELETE /webhooks/app/tok/messages/@original",
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v", calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("call %d = %q, want %q", i, calls[i], want[i])
+		}
+	}
+}
+
+func TestResponderFromContext(t *testing.T) {
+	var verr *types.ValidationError
+	if err := ResponderFromContext(context.Background()).DeleteOriginal(context.Background()); !errors.As(err, &verr) {
+		t.Fatalf("expected validation error without a client, got %v", err)
+	}
+
+	server, _ := newTestServer(t)
+	ic, _ := NewInteractionClient(newInteractionTestClient(t, "http://example.com"))
+	WithInteractionClient(ic)(server)
+	var got *Responder
+	server.RegisterCommand("hello", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		got = ResponderFromContext(ctx)
+		return nil, nil
+	})
+	if _, err := server.Dispatch(context.Background(), []byte(`{"id":"9","application_id":"app","token":"tok","type":2,"data":{"name":"hello"}}`)); err != nil {
+		t.Fatalf("Dispatch error: %v", err)
+	}
+	if got == nil || got.Interaction() == nil || got.Interaction().ID != "9" || got.check() != nil {
+		t.Fatalf("expected responder bound to the interaction, got %+v", got)
+	}
+}
@@ -2,6 +2,9 @@ package interactions
 
 import (
 	"fmt"
+	"io"
+	"mime"
+	"path"
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 )
@@ -164,6 +167,28 @@ func (b *ResponseBuilder) SetModalComponents(rows ...types.Component) *ResponseB
 	return b
 }
 
+// AddFile uploads a file with the message response, e.g. a generated chart
+// or CSV. The content type is guessed from the name's extension. Reference
+// it from an embed with "attachment://" + name.
+func (b *ResponseBuilder) AddFile(name string, r io.Reader) *ResponseBuilder {
+	return b.AddFileWithType(name, mime.TypeByExtension(path.Ext(name)), r)
+}
+
+// AddFileWithType is AddFile with an explicit content type.
+func (b *ResponseBuilder) AddFileWithType(name, contentType string, r io.Reader) *ResponseBuilder {
+	if b == nil || b.resp == nil {
+		return b
+	}
+	switch b.resp.Type {
+	case types.InteractionResponseChannelMessageWithSource, types.InteractionResponseUpdateMessage:
+	default:
+		b.err = fmt.Errorf("files can only be attached to message responses")
+		return b
+	}
+	b.resp.Files = append(b.resp.Files, types.FileAttachment{Name: name, ContentType: contentType, Reader: r})
+	return b
+}
+
 // SetEphemeral marks the response as ephemeral.
 func (b *ResponseBuilder) SetEphemeral(ephemeral bool) *ResponseBuilder {
 	if data := b.ensureData(); data != nil {
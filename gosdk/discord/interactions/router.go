@@ -49,6 +49,17 @@ func (r *Router) Command(name string, handler Handler) {
 	r.commands[strings.ToLower(name)] = handler
 }
 
+// CommandCanary registers a command whose invocations are split between a
+// stable and a candidate handler. The returned Canary reports per-handler
+// error rates and can change the rollout percentage while serving.
+func (r *Router) CommandCanary(name string, stable, candidate Handler, opts ...CanaryOption) *Canary {
+	canary := NewCanary(strings.ToLower(name), stable, candidate, opts...)
+	if stable != nil {
+		r.Command(name, canary.Handle)
+	}
+	return canary
+}
+
 // Component registers a handler for an exact component custom ID.
 func (r *Router) Component(customID string, handler Handler) {
 	if r == nil || customID == "" || handler == nil {
@@ -3,13 +3,14 @@ package interactions
 import (
 	"context"
 	"crypto/ed25519"
-	"encoding/hex"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 	"github.com/mtreilly/godiscord/gosdk/logger"
 )
@@ -29,6 +30,14 @@ type Server struct {
 	dryRun    bool
 	router    *Router
 
+	latencyWarning    time.Duration
+	interactionClient *InteractionClient
+	autoDefers        bool
+
+	disallowUnknownFields bool
+	validatePayloads      bool
+	stats                 serverStats
+
 	commandHandlers   map[string]Handler
 	componentHandlers map[string]Handler
 	modalHandlers     map[string]Handler
@@ -38,10 +47,10 @@ type Server struct {
 type ServerOption func(*Server)
 
 // WithLogger overrides the server logger.
-func WithLogger(l *logger.Logger) ServerOption {
+func WithLogger(l logger.Interface) ServerOption {
 	return func(s *Server) {
-		if l != nil {
-			s.logger = l
+		if lg := logger.From(l); lg != nil {
+			s.logger = lg
 		}
 	}
 }
@@ -64,34 +73,34 @@ func WithRouter(r *Router) ServerOption {
 
 // NewServer constructs a new interaction server.
 func NewServer(publicKey string, opts ...ServerOption) (*Server, error) {
-	pubBytes, err := hex.DecodeString(strings.TrimSpace(publicKey))
+	pub, err := ParsePublicKey(publicKey)
 	if err != nil {
-		return nil, fmt.Errorf("invalid public key: %w", err)
-	}
-	if len(pubBytes) != ed25519.PublicKeySize {
-		return nil, fmt.Errorf("invalid public key length: expected %d bytes", ed25519.PublicKeySize)
+		return nil, err
 	}
 
 	s := &Server{
-		publicKey:         ed25519.PublicKey(pubBytes),
+		publicKey:         pub,
 		logger:            logger.Default(),
 		commandHandlers:   make(map[string]Handler),
 		componentHandlers: make(map[string]Handler),
 		modalHandlers:     make(map[string]Handler),
 		router:            NewRouter(),
+		latencyWarning:    defaultLatencyWarning,
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
+	s.logger = s.logger.WithSubsystem(logger.SubsystemInteractions)
 	return s, nil
 }
 
 // RegisterCommand registers a handler for an application command (slash/user/message).
-func (s *Server) RegisterCommand(name string, handler Handler) {
+func (s *Server) RegisterCommand(name string, handler Handler, opts ...HandlerOption) {
 	if name == "" || handler == nil {
 		return
 	}
+	handler = s.wrapHandler(handler, opts)
 	s.commandHandlers[strings.ToLower(name)] = handler
 	if s.router != nil {
 		s.router.Command(name, handler)
@@ -99,10 +108,11 @@ func (s *Server) RegisterCommand(name string, handler Handler) {
 }
 
 // RegisterComponent registers a handler for a component custom ID.
-func (s *Server) RegisterComponent(customID string, handler Handler) {
+func (s *Server) RegisterComponent(customID string, handler Handler, opts ...HandlerOption) {
 	if customID == "" || handler == nil {
 		return
 	}
+	handler = s.wrapHandler(handler, opts)
 	s.componentHandlers[customID] = handler
 	if s.router != nil {
 		s.router.Component(customID, handler)
@@ -110,10 +120,11 @@ func (s *Server) RegisterComponent(customID string, handler Handler) {
 }
 
 // RegisterModal registers a handler for a modal custom ID.
-func (s *Server) RegisterModal(customID string, handler Handler) {
+func (s *Server) RegisterModal(customID string, handler Handler, opts ...HandlerOption) {
 	if customID == "" || handler == nil {
 		return
 	}
+	handler = s.wrapHandler(handler, opts)
 	s.modalHandlers[customID] = handler
 	if s.router != nil {
 		s.router.Modal(customID, handler)
@@ -127,9 +138,11 @@ func (s *Server) HandleInteraction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.stats.requests.Add(1)
+
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		s.logger.Error("failed to read request body", "error", err)
+		s.rejectMalformed("read", err)
 		http.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
@@ -137,31 +150,26 @@ func (s *Server) HandleInteraction(w http.ResponseWriter, r *http.Request) {
 
 	if !s.dryRun {
 		if ok := s.verifyRequest(r, body); !ok {
+			s.stats.invalidSignatures.Add(1)
 			http.Error(w, "invalid request signature", http.StatusUnauthorized)
 			return
 		}
 	}
 
-	var interaction types.Interaction
-	if err := json.Unmarshal(body, &interaction); err != nil {
-		s.logger.Error("failed to decode interaction", "error", err)
-		http.Error(w, "invalid interaction payload", http.StatusBadRequest)
+	resp, err := s.Dispatch(r.Context(), body)
+	var handlerErr *HandlerError
+	switch {
+	case errors.As(err, &handlerErr):
+		s.logger.Error("interaction handler error", "error", handlerErr.Err)
+		http.Error(w, "handler error", http.StatusInternalServerError)
 		return
-	}
-
-	if interaction.Type == types.InteractionTypePing {
-		s.writeJSON(w, http.StatusOK, &types.InteractionResponse{Type: types.InteractionResponsePong})
+	case errors.Is(err, types.ErrMalformedInteraction):
+		http.Error(w, "invalid interaction payload", http.StatusBadRequest)
 		return
-	}
-
-	handler := s.resolveHandler(&interaction)
-	if handler == nil {
+	case errors.Is(err, types.ErrUnhandledInteraction):
 		http.Error(w, "handler not found", http.StatusNotFound)
 		return
-	}
-
-	resp, err := handler(r.Context(), &interaction)
-	if err != nil {
+	case err != nil:
 		s.logger.Error("interaction handler error", "error", err)
 		http.Error(w, "handler error", http.StatusInternalServerError)
 		return
@@ -172,25 +180,13 @@ func (s *Server) HandleInteraction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.writeJSON(w, http.StatusOK, resp); err != nil {
+	if err := s.writeResponse(w, resp); err != nil {
 		s.logger.Error("failed to write interaction response", "error", err)
 	}
 }
 
 func (s *Server) verifyRequest(r *http.Request, body []byte) bool {
-	signatureHex := r.Header.Get(signatureHeader)
-	timestamp := r.Header.Get(timestampHeader)
-	if signatureHex == "" || timestamp == "" {
-		return false
-	}
-
-	signature, err := hex.DecodeString(signatureHex)
-	if err != nil {
-		return false
-	}
-
-	message := append([]byte(timestamp), body...)
-	return ed25519.Verify(s.publicKey, message, signature)
+	return Verify(s.publicKey, r.Header.Get(timestampHeader), r.Header.Get(signatureHeader), body)
 }
 
 func (s *Server) resolveHandler(i *types.Interaction) Handler {
@@ -217,6 +213,23 @@ func (s *Server) resolveHandler(i *types.Interaction) Handler {
 	}
 }
 
+// writeResponse writes resp as JSON, or as multipart/form-data when it
+// carries files.
+func (s *Server) writeResponse(w http.ResponseWriter, resp *types.InteractionResponse) error {
+	if len(resp.Files) == 0 {
+		return s.writeJSON(w, http.StatusOK, resp)
+	}
+	body, contentType, err := client.EncodeMultipart(resp, resp.Files)
+	if err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return err
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(body)
+	return err
+}
+
 func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -0,0 +1,245 @@
+// Package limits collects the Discord API limits enforced by the SDK.
+//
+// Validation across the SDK reads from these constants, so downstream code
+// (form validation, UI counters, chunking) can rely on the same values.
+package limits
+
+// Messages
+const (
+	// MessageContentLength is the maximum message content length in characters.
+	MessageContentLength = 2000
+	// MessageEmbeds is the maximum number of embeds per message.
+	MessageEmbeds = 10
+	// MessageAttachments is the maximum number of attachments per message.
+	MessageAttachments = 10
+	// BulkDeleteMessages is the maximum number of messages per bulk delete.
+	BulkDeleteMessages = 100
+	// MessagesPerPage is the maximum page size for channel message history.
+	MessagesPerPage = 100
+	// ReactionsPerPage is the maximum page size when listing reaction users.
+	ReactionsPerPage = 100
+	// PollVotersPerPage is the maximum page size when listing poll answer voters.
+	PollVotersPerPage = 100
+	// AllowedMentionIDs is the maximum number of user or role IDs in allowed_mentions.
+	AllowedMentionIDs = 100
+	// MessageNonceLength is the maximum length of a message nonce.
+	MessageNonceLength = 25
+)
+
+// Embeds
+const (
+	// EmbedTitleLength is the maximum embed title length in runes.
+	EmbedTitleLength = 256
+	// EmbedDescriptionLength is the maximum embed description length in runes.
+	EmbedDescriptionLength = 4096
+	// EmbedFields is the maximum number of fields per embed.
+	EmbedFields = 25
+	// EmbedFieldNameLength is the maximum embed field name length in runes.
+	EmbedFieldNameLength = 256
+	// EmbedFieldValueLength is the maximum embed field value length in runes.
+	EmbedFieldValueLength = 1024
+	// EmbedTotalLength is the maximum combined text length across all embeds in a message.
+	EmbedTotalLength = 6000
+)
+
+// Files
+const (
+	// FileSize is the default per-file upload limit in bytes.
+	FileSize = 25 * 1024 * 1024
+	// TotalFileSize is the default combined upload limit per message in bytes.
+	TotalFileSize = 25 * 1024 * 1024
+	// Files is the maximum number of files per message.
+	Files = 10
+
+	// FileSizeTier2 is the upload limit for guilds at premium tier 2.
+	FileSizeTier2 = 50 * 1024 * 1024
+	// FileSizeTier3 is the upload limit for guilds at premium tier 3.
+	FileSizeTier3 = 100 * 1024 * 1024
+)
+
+// UploadLimit returns the per-file and per-message upload limit in bytes for a
+// guild premium tier (0-3). Tiers below 2 use the default FileSize.
+func UploadLimit(premiumTier int) int64 {
+	switch {
+	case premiumTier >= 3:
+		return FileSizeTier3
+	case premiumTier == 2:
+		return FileSizeTier2
+	default:
+		return FileSize
+	}
+}
+
+// Components
+const (
+	// ActionRows is the maximum number of action rows per message or modal.
+	ActionRows = 5
+	// ActionRowChildren is the maximum number of components in an action row.
+	ActionRowChildren = 5
+	// CustomIDLength is the maximum component custom_id length.
+	CustomIDLength = 100
+	// ButtonLabelLength is the maximum button label length in runes.
+	ButtonLabelLength = 80
+	// SelectOptions is the maximum number of options in a select menu.
+	SelectOptions = 25
+	// SelectOptionLength is the maximum label, value, or description length of a select option.
+	SelectOptionLength = 100
+	// PlaceholderLength is the maximum select menu placeholder length in runes.
+	PlaceholderLength = 150
+	// TextInputLabelLength is the maximum text input label length in runes.
+	TextInputLabelLength = 45
+	// TextInputPlaceholderLength is the maximum text input placeholder length in runes.
+	TextInputPlaceholderLength = 100
+	// TextInputValueLength is the maximum text input value length.
+	TextInputValueLength = 4000
+	// ModalTitleLength is the maximum modal title length in runes.
+	ModalTitleLength = 45
+)
+
+// Application commands
+const (
+	// CommandNameLength is the maximum command or option name length.
+	CommandNameLength = 32
+	// CommandDescriptionLength is the maximum command or option description length.
+	CommandDescriptionLength = 100
+	// CommandOptions is the maximum number of options per command.
+	CommandOptions = 25
+	// CommandChoices is the maximum number of static choices per option.
+	CommandChoices = 25
+	// AutocompleteChoices is the maximum number of autocomplete choices per response.
+	AutocompleteChoices = 25
+	// ChoiceNameLength is the maximum choice name length in runes.
+	ChoiceNameLength = 100
+	// ChoiceValueLength is the maximum string choice value length in runes.
+	ChoiceValueLength = 100
+)
+
+// Polls
+const (
+	// PollQuestionLength is the maximum poll question length in runes.
+	PollQuestionLength = 300
+	// PollAnswers is the maximum number of answers per poll.
+	PollAnswers = 10
+	// PollAnswerLength is the maximum poll answer length in runes.
+	PollAnswerLength = 55
+	// PollDurationHours is the maximum poll duration in hours (32 days).
+	PollDurationHours = 768
+)
+
+// Guilds, channels, and webhooks
+const (
+	// ChannelNameLength is the maximum channel name length.
+	ChannelNameLength = 100
+	// ThreadNameLength is the maximum thread name length.
+	ThreadNameLength = 100
+	// ThreadAppliedTags is the maximum number of forum tags on a thread.
+	ThreadAppliedTags = 5
+	// ThreadMembersPerPage is the maximum page size when listing thread members.
+	ThreadMembersPerPage = 100
+	// RateLimitPerUser is the maximum slowmode delay in seconds.
+	RateLimitPerUser = 21600
+	// StageTopicLength is the maximum stage instance topic length.
+	StageTopicLength = 120
+	// GuildNameLength is the maximum guild name length.
+	GuildNameLength = 100
+	// MembersPerPage is the maximum page size when listing guild members.
+	MembersPerPage = 1000
+	// WebhookMessagesPerMinute is the per-channel webhook send limit.
+	WebhookMessagesPerMinute = 30
+	// BansPerPage is the maximum page size when listing guild bans.
+	BansPerPage = 1000
+	// BulkBanUsers is the maximum number of users per bulk ban.
+	BulkBanUsers = 200
+	// BanDeleteMessageSeconds is the furthest back a ban can delete messages (7 days).
+	BanDeleteMessageSeconds = 604800
+	// AuditLogReasonLength is the maximum X-Audit-Log-Reason length.
+	AuditLogReasonLength = 512
+	// AuditLogEntriesPerPage is the maximum page size when reading the audit log.
+	AuditLogEntriesPerPage = 100
+	// StickerNameLength is the maximum guild sticker name length.
+	StickerNameLength = 30
+	// StickerDescriptionLength is the maximum guild sticker description length.
+	StickerDescriptionLength = 100
+	// StickerTagsLength is the maximum guild sticker tags length.
+	StickerTagsLength = 200
+	// ScreeningDescriptionLength is the maximum membership screening description length.
+	ScreeningDescriptionLength = 300
+	// ScreeningRules is the maximum number of rules in a screening terms field.
+	ScreeningRules = 16
+	// ScreeningRuleLength is the maximum length of one screening rule.
+	ScreeningRuleLength = 300
+)
+
+// Auto moderation
+const (
+	// AutoModRuleNameLength is the maximum auto moderation rule name length.
+	AutoModRuleNameLength = 100
+	// AutoModKeywords is the maximum number of keywords in a keyword filter.
+	AutoModKeywords = 1000
+	// AutoModKeywordLength is the maximum length of one keyword or allow list entry.
+	AutoModKeywordLength = 60
+	// AutoModRegexPatterns is the maximum number of regex patterns in a rule.
+	AutoModRegexPatterns = 10
+	// AutoModRegexPatternLength is the maximum length of one regex pattern.
+	AutoModRegexPatternLength = 260
+	// AutoModKeywordAllowList is the maximum allow list size for keyword rules.
+	AutoModKeywordAllowList = 100
+	// AutoModPresetAllowList is the maximum allow list size for keyword preset rules.
+	AutoModPresetAllowList = 1000
+	// AutoModMentionLimit is the maximum mention_total_limit.
+	AutoModMentionLimit = 50
+	// AutoModExemptRoles is the maximum number of exempt roles per rule.
+	AutoModExemptRoles = 20
+	// AutoModExemptChannels is the maximum number of exempt channels per rule.
+	AutoModExemptChannels = 50
+	// AutoModTimeoutSeconds is the maximum timeout action duration (4 weeks).
+	AutoModTimeoutSeconds = 2419200
+	// AutoModCustomMessageLength is the maximum block action custom message length.
+	AutoModCustomMessageLength = 150
+)
+
+// Applications
+const (
+	// ApplicationDescriptionLength is the maximum application description length.
+	ApplicationDescriptionLength = 400
+	// ApplicationTags is the maximum number of application tags.
+	ApplicationTags = 5
+	// ApplicationTagLength is the maximum length of one application tag.
+	ApplicationTagLength = 20
+)
+
+// Invites
+const (
+	// InviteMaxAge is the longest invite lifetime in seconds (7 days); 0 never expires.
+	InviteMaxAge = 604800
+	// InviteMaxUses is the highest max_uses value; 0 allows unlimited uses.
+	InviteMaxUses = 100
+)
+
+// Application role connections (linked roles)
+const (
+	// RoleConnectionMetadataRecords is the maximum number of metadata records per application.
+	RoleConnectionMetadataRecords = 5
+	// RoleConnectionMetadataKeyLength is the maximum metadata key length.
+	RoleConnectionMetadataKeyLength = 50
+	// RoleConnectionMetadataNameLength is the maximum metadata name length.
+	RoleConnectionMetadataNameLength = 100
+	// RoleConnectionMetadataDescriptionLength is the maximum metadata description length.
+	RoleConnectionMetadataDescriptionLength = 200
+	// RoleConnectionPlatformNameLength is the maximum platform name length.
+	RoleConnectionPlatformNameLength = 50
+	// RoleConnectionPlatformUsernameLength is the maximum platform username length.
+	RoleConnectionPlatformUsernameLength = 100
+	// RoleConnectionMetadataValueLength is the maximum length of a stringified metadata value.
+	RoleConnectionMetadataValueLength = 100
+)
+
+// Users
+const (
+	// UsernameMinLength is the minimum username length.
+	UsernameMinLength = 2
+	// UsernameMaxLength is the maximum username length.
+	UsernameMaxLength = 32
+	// UserGuildsPerPage is the maximum page size when listing the current user's guilds.
+	UserGuildsPerPage = 200
+)
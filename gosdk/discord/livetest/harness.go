@@ -0,0 +1,303 @@
+// Package livetest runs SDK operations against a real Discord test guild.
+//
+// Live tests are opt-in: they only run when DISCORD_TEST_TOKEN and
+// DISCORD_TEST_GUILD_ID are set, and are otherwise skipped. Every resource the
+// harness creates is named with a run-specific tag and deleted through
+// t.Cleanup, so channels, messages, and commands are removed even when a test
+// fails or panics. Sweep removes leftovers from runs that were killed outright.
+package livetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/utils"
+)
+
+const (
+	// EnvToken holds the bot token used by live tests.
+	EnvToken = "DISCORD_TEST_TOKEN"
+	// EnvGuildID holds the ID of a guild dedicated to testing.
+	EnvGuildID = "DISCORD_TEST_GUILD_ID"
+	// EnvApplicationID holds the bot's application ID; command tests are skipped without it.
+	EnvApplicationID = "DISCORD_TEST_APPLICATION_ID"
+
+	// TagPrefix starts the name of every resource the harness creates.
+	TagPrefix = "gosdk-it-"
+
+	maxCommandNameLength = 32
+)
+
+// Config describes the live test environment.
+type Config struct {
+	Token         string
+	GuildID       string
+	ApplicationID string
+}
+
+// ConfigFromEnv reads the live test configuration. ok is false when the
+// token or guild ID is missing.
+func ConfigFromEnv() (cfg Config, ok bool) {
+	cfg = Config{
+		Token:         strings.TrimSpace(os.Getenv(EnvToken)),
+		GuildID:       strings.TrimSpace(os.Getenv(EnvGuildID)),
+		ApplicationID: strings.TrimSpace(os.Getenv(EnvApplicationID)),
+	}
+	return cfg, cfg.Token != "" && cfg.GuildID != ""
+}
+
+// Option configures a Harness.
+type Option func(*options)
+
+type options struct {
+	config         *Config
+	clientOptions  []client.Option
+	cleanupTimeout time.Duration
+}
+
+// WithConfig uses cfg instead of reading the environment.
+func WithConfig(cfg Config) Option {
+	return func(o *options) {
+		o.config = &cfg
+	}
+}
+
+// WithClientOptions passes extra options to the bot client.
+func WithClientOptions(opts ...client.Option) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, opts...)
+	}
+}
+
+// WithCleanupTimeout bounds each cleanup request (default 15s).
+func WithCleanupTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.cleanupTimeout = d
+		}
+	}
+}
+
+// Harness creates tagged resources in the test guild and removes them when
+// the test ends.
+type Harness struct {
+	Client *client.Client
+	Config Config
+
+	t              testing.TB
+	tag            string
+	cleanupTimeout time.Duration
+
+	mu       sync.Mutex
+	cleanups []cleanup
+}
+
+type cleanup struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// New returns a harness bound to t, skipping the test when no live
+// configuration is available. Cleanups run in reverse creation order.
+func New(t testing.TB, opts ...Option) *Harness {
+	t.Helper()
+
+	o := options{cleanupTimeout: 15 * time.Second}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	cfg, ok := ConfigFromEnv()
+	if o.config != nil {
+		cfg, ok = *o.config, o.config.Token != "" && o.config.GuildID != ""
+	}
+	if !ok {
+		t.Skipf("%s and %s not set; skipping live Discord test", EnvToken, EnvGuildID)
+	}
+
+	c, err := client.New(cfg.Token, o.clientOptions...)
+	if err != nil {
+		t.Fatalf("livetest: create client: %v", err)
+	}
+
+	h := &Harness{
+		Client:         c,
+		Config:         cfg,
+		t:              t,
+		tag:            TagPrefix + strconv.FormatInt(time.Now().UnixNano(), 36),
+		cleanupTimeout: o.cleanupTimeout,
+	}
+	t.Cleanup(h.runCleanups)
+	return h
+}
+
+// Tag identifies resources created by this run.
+func (h *Harness) Tag() string {
+	return h.tag
+}
+
+// Name returns a tagged, lowercase resource name for base.
+func (h *Harness) Name(base string) string {
+	base = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(base), " ", "-"))
+	if base == "" {
+		return h.tag
+	}
+	return h.tag + "-" + base
+}
+
+// Defer registers fn to run when the test ends, after any cleanup
+// registered later. Use it for resources created outside the helpers.
+func (h *Harness) Defer(name string, fn func(ctx context.Context) error) {
+	if fn == nil {
+		return
+	}
+	h.mu.Lock()
+	h.cleanups = append(h.cleanups, cleanup{name: name, fn: fn})
+	h.mu.Unlock()
+}
+
+// RequireApplication skips the test when no application ID is configured.
+func (h *Harness) RequireApplication() {
+	h.t.Helper()
+	if h.Config.ApplicationID == "" {
+		h.t.Skipf("%s not set; skipping application command test", EnvApplicationID)
+	}
+}
+
+// CreateChannel creates a tagged text channel in the test guild.
+func (h *Harness) CreateChannel(ctx context.Context, base string) *types.Channel {
+	h.t.Helper()
+	channel, err := h.Client.Guilds().CreateGuildChannel(ctx, h.Config.GuildID, &types.ChannelCreateParams{
+		Name:  h.Name(base),
+		Type:  types.ChannelTypeGuildText,
+		Topic: "Created by " + h.tag + "; safe to delete",
+	})
+	if err != nil {
+		h.t.Fatalf("livetest: create channel: %v", err)
+	}
+	h.Defer("channel "+channel.ID, func(ctx context.Context) error {
+		return ignoreNotFound(h.Client.Channels().DeleteChannel(ctx, channel.ID))
+	})
+	return channel
+}
+
+// SendMessage posts a tagged message to channelID.
+func (h *Harness) SendMessage(ctx context.Context, channelID, content string) *types.Message {
+	h.t.Helper()
+	msg, err := h.Client.Messages().CreateMessage(ctx, channelID, &types.MessageCreateParams{
+		Content: fmt.Sprintf("[%s] %s", h.tag, content),
+	})
+	if err != nil {
+		h.t.Fatalf("livetest: send message: %v", err)
+	}
+	h.Defer("message "+msg.ID, func(ctx context.Context) error {
+		return ignoreNotFound(h.Client.Messages().DeleteMessage(ctx, channelID, msg.ID))
+	})
+	return msg
+}
+
+// CreateCommand registers a tagged guild command. The command name is derived
+// from cmd.Name and trimmed to Discord's 32 character limit.
+func (h *Harness) CreateCommand(ctx context.Context, cmd *types.ApplicationCommand) *types.ApplicationCommand {
+	h.t.Helper()
+	h.RequireApplication()
+
+	tagged := *cmd
+	tagged.Name = h.Name(cmd.Name)
+	if len(tagged.Name) > maxCommandNameLength {
+		tagged.Name = tagged.Name[:maxCommandNameLength]
+	}
+
+	commands := h.Client.ApplicationCommands(h.Config.ApplicationID)
+	created, err := commands.CreateGuildApplicationCommand(ctx, h.Config.GuildID, &tagged)
+	if err != nil {
+		h.t.Fatalf("livetest: create command: %v", err)
+	}
+	h.Defer("command "+created.ID, func(ctx context.Context) error {
+		return ignoreNotFound(commands.DeleteGuildApplicationCommand(ctx, h.Config.GuildID, created.ID))
+	})
+	return created
+}
+
+// Sweep deletes tagged channels and guild commands older than maxAge, left
+// behind by runs that never reached their cleanup. Resources from concurrent
+// runs younger than maxAge are left alone.
+func (h *Harness) Sweep(ctx context.Context, maxAge time.Duration) error {
+	cutoff := time.Now().Add(-maxAge)
+	stale := func(id, name string) bool {
+		if !strings.HasPrefix(name, TagPrefix) || strings.HasPrefix(name, h.tag) {
+			return false
+		}
+		created, err := utils.SnowflakeToTime(id)
+		return err == nil && created.Before(cutoff)
+	}
+
+	var errs []string
+	channels, err := h.Client.Guilds().GetGuildChannels(ctx, h.Config.GuildID)
+	if err != nil {
+		return fmt.Errorf("livetest: list channels: %w", err)
+	}
+	for _, channel := range channels {
+		if stale(channel.ID, channel.Name) {
+			if err := ignoreNotFound(h.Client.Channels().DeleteChannel(ctx, channel.ID)); err != nil {
+				errs = append(errs, fmt.Sprintf("channel %s: %v", channel.ID, err))
+			}
+		}
+	}
+
+	if h.Config.ApplicationID != "" {
+		commands := h.Client.ApplicationCommands(h.Config.ApplicationID)
+		list, err := commands.GetGuildApplicationCommands(ctx, h.Config.GuildID)
+		if err != nil {
+			return fmt.Errorf("livetest: list commands: %w", err)
+		}
+		for _, cmd := range list {
+			if stale(cmd.ID, cmd.Name) {
+				if err := ignoreNotFound(commands.DeleteGuildApplicationCommand(ctx, h.Config.GuildID, cmd.ID)); err != nil {
+					errs = append(errs, fmt.Sprintf("command %s: %v", cmd.ID, err))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("livetest: sweep failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// runCleanups deletes everything the test created, newest first. A failed
+// cleanup is reported but does not stop the remaining ones.
+func (h *Harness) runCleanups() {
+	h.mu.Lock()
+	cleanups := h.cleanups
+	h.cleanups = nil
+	h.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		ctx, cancel := context.WithTimeout(context.Background(), h.cleanupTimeout)
+		if err := cleanups[i].fn(ctx); err != nil {
+			h.t.Errorf("livetest: cleanup %s: %v", cleanups[i].name, err)
+		}
+		cancel()
+	}
+}
+
+// ignoreNotFound treats already-deleted resources as cleaned up.
+func ignoreNotFound(err error) error {
+	if errors.Is(err, types.ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,153 @@
+package livetest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/utils"
+	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+}
+
+func newFakeDiscord(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) (*httptest.Server, func() []recordedRequest) {
+	var mu sync.Mutex
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, recordedRequest{method: r.Method, path: r.URL.Path})
+		mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+func testOptions(baseURL string, cfg Config) []Option {
+	return []Option{
+		WithConfig(cfg),
+		WithClientOptions(
+			client.WithBaseURL(baseURL),
+			client.WithRateLimiter(ratelimit.NewMemoryTracker()),
+			client.WithMaxRetries(0),
+		),
+	}
+}
+
+func TestNewSkipsWithoutConfig(t *testing.T) {
+	var reached bool
+	t.Run("live", func(t *testing.T) {
+		New(t, WithConfig(Config{Token: "token"}))
+		reached = true
+	})
+	if reached {
+		t.Fatal("expected the live test to be skipped without a guild ID")
+	}
+}
+
+func TestHarnessCleansUpInReverseOrder(t *testing.T) {
+	server, requests := newFakeDiscord(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/guilds/g1/channels":
+			w.Write([]byte(`{"id":"100","name":"x"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/channels/100/messages":
+			w.Write([]byte(`{"id":"200"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/applications/app/guilds/g1/commands":
+			w.Write([]byte(`{"id":"300","name":"x"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/channels/100/messages/200":
+			// Already gone: not found is treated as cleaned up.
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Unknown Message","code":10008}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var tag string
+	passed := t.Run("live", func(t *testing.T) {
+		h := New(t, testOptions(server.URL, Config{Token: "token", GuildID: "g1", ApplicationID: "app"})...)
+		tag = h.Tag()
+		ctx := context.Background()
+
+		channel := h.CreateChannel(ctx, "Smoke Test")
+		if !strings.HasPrefix(h.Name("Smoke Test"), TagPrefix) || h.Name("Smoke Test") != tag+"-smoke-test" {
+			t.Fatalf("unexpected tagged name %q", h.Name("Smoke Test"))
+		}
+		h.SendMessage(ctx, channel.ID, "hello")
+		cmd := &types.ApplicationCommand{Name: "a-rather-long-command-name", Description: "test"}
+		h.CreateCommand(ctx, cmd)
+		if cmd.Name != "a-rather-long-command-name" {
+			t.Fatalf("CreateCommand modified the caller's command: %q", cmd.Name)
+		}
+	})
+	if !passed {
+		t.Fatal("live subtest failed")
+	}
+
+	var deletes []string
+	for _, req := range requests() {
+		if req.method == http.MethodDelete {
+			deletes = append(deletes, req.path)
+		}
+	}
+	want := []string{"/applications/app/guilds/g1/commands/300", "/channels/100/messages/200", "/channels/100"}
+	if strings.Join(deletes, ",") != strings.Join(want, ",") {
+		t.Fatalf("cleanup order = %v, want %v", deletes, want)
+	}
+}
+
+func TestHarnessSweepRemovesStaleResources(t *testing.T) {
+	old := utils.TimeToSnowflake(time.Now().Add(-3 * time.Hour))
+	fresh := utils.TimeToSnowflake(time.Now())
+
+	server, requests := newFakeDiscord(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/guilds/g1/channels":
+			w.Write([]byte(`[
+				{"id":"` + old + `","name":"gosdk-it-abc-old"},
+				{"id":"` + fresh + `","name":"gosdk-it-def-running"},
+				{"id":"` + old + `1","name":"general"}
+			]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/applications/app/guilds/g1/commands":
+			w.Write([]byte(`[{"id":"` + old + `","name":"gosdk-it-abc-cmd"}]`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	h := New(t, testOptions(server.URL, Config{Token: "token", GuildID: "g1", ApplicationID: "app"})...)
+	if err := h.Sweep(context.Background(), time.Hour); err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+
+	var deletes []string
+	for _, req := range requests() {
+		if req.method == http.MethodDelete {
+			deletes = append(deletes, req.path)
+		}
+	}
+	want := []string{"/channels/" + old, "/applications/app/guilds/g1/commands/" + old}
+	if strings.Join(deletes, ",") != strings.Join(want, ",") {
+		t.Fatalf("swept %v, want %v", deletes, want)
+	}
+}
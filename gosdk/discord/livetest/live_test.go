@@ -0,0 +1,73 @@
+//go:build integration
+
+package livetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestLiveSweep(t *testing.T) {
+	h := New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := h.Sweep(ctx, time.Hour); err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+}
+
+func TestLiveChannelMessages(t *testing.T) {
+	h := New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	channel := h.CreateChannel(ctx, "messages")
+	sent := h.SendMessage(ctx, channel.ID, "live message test")
+
+	got, err := h.Client.Messages().GetMessage(ctx, channel.ID, sent.ID)
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if got.Content != sent.Content {
+		t.Fatalf("content mismatch: got %q, want %q", got.Content, sent.Content)
+	}
+
+	edited, err := h.Client.Messages().EditMessage(ctx, channel.ID, sent.ID, &types.MessageEditParams{Content: sent.Content + " (edited)"})
+	if err != nil {
+		t.Fatalf("EditMessage failed: %v", err)
+	}
+	if edited.EditedTimestamp == nil {
+		t.Fatal("expected edited timestamp to be set")
+	}
+
+	if err := h.Client.Messages().CreateReaction(ctx, channel.ID, sent.ID, "✅"); err != nil {
+		t.Fatalf("CreateReaction failed: %v", err)
+	}
+}
+
+func TestLiveGuildCommand(t *testing.T) {
+	h := New(t)
+	h.RequireApplication()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	created := h.CreateCommand(ctx, &types.ApplicationCommand{
+		Name:        "ping",
+		Description: "Live integration test command",
+	})
+
+	commands, err := h.Client.ApplicationCommands(h.Config.ApplicationID).GetGuildApplicationCommands(ctx, h.Config.GuildID)
+	if err != nil {
+		t.Fatalf("GetGuildApplicationCommands failed: %v", err)
+	}
+	for _, cmd := range commands {
+		if cmd.ID == created.ID {
+			return
+		}
+	}
+	t.Fatalf("command %s not listed in guild commands", created.ID)
+}
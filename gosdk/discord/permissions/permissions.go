@@ -1,9 +1,11 @@
 package permissions
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 )
@@ -42,8 +44,8 @@ const (
 	PermissionManageNicknames
 	PermissionManageRoles
 	PermissionManageWebhooks
-	PermissionManageEmojis
-	PermissionUseSlashCommands
+	PermissionManageGuildExpressions
+	PermissionUseApplicationCommands
 	PermissionRequestToSpeak
 	PermissionManageEvents
 	PermissionManageThreads
@@ -53,6 +55,24 @@ const (
 	PermissionSendMessagesInThreads
 	PermissionUseEmbeddedActivities
 	PermissionModerateMembers
+	PermissionViewCreatorMonetizationAnalytics
+	PermissionUseSoundboard
+	PermissionCreateGuildExpressions
+	PermissionCreateEvents
+	PermissionUseExternalSounds
+	PermissionSendVoiceMessages
+	_ // bits 47 and 48 are not documented
+	_
+	PermissionSendPolls
+	PermissionUseExternalApps
+)
+
+// Names Discord used for these permissions before renaming them.
+const (
+	// Deprecated: use PermissionManageGuildExpressions.
+	PermissionManageEmojis = PermissionManageGuildExpressions
+	// Deprecated: use PermissionUseApplicationCommands.
+	PermissionUseSlashCommands = PermissionUseApplicationCommands
 )
 
 var (
@@ -87,8 +107,8 @@ var (
 		PermissionManageNicknames,
 		PermissionManageRoles,
 		PermissionManageWebhooks,
-		PermissionManageEmojis,
-		PermissionUseSlashCommands,
+		PermissionManageGuildExpressions,
+		PermissionUseApplicationCommands,
 		PermissionRequestToSpeak,
 		PermissionManageEvents,
 		PermissionManageThreads,
@@ -98,16 +118,37 @@ var (
 		PermissionSendMessagesInThreads,
 		PermissionUseEmbeddedActivities,
 		PermissionModerateMembers,
+		PermissionViewCreatorMonetizationAnalytics,
+		PermissionUseSoundboard,
+		PermissionCreateGuildExpressions,
+		PermissionCreateEvents,
+		PermissionUseExternalSounds,
+		PermissionSendVoiceMessages,
+		PermissionSendPolls,
+		PermissionUseExternalApps,
 	}
 	permissionNames = map[Permission]string{}
+	// permissionsByKey maps normalised names (lower case, no separators)
+	// to permissions, so ParseNames accepts "SendMessages", "send_messages"
+	// and "SEND_MESSAGES" alike.
+	permissionsByKey = map[string]Permission{
+		"manageemojis":            PermissionManageGuildExpressions,
+		"manageemojisandstickers": PermissionManageGuildExpressions,
+		"useslashcommands":        PermissionUseApplicationCommands,
+	}
 )
 
 func init() {
 	for _, perm := range allPermissions {
 		permissionNames[perm] = perm.name()
+		permissionsByKey[nameKey(perm.name())] = perm
 	}
 }
 
+func nameKey(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", " ", "", "-", "").Replace(name))
+}
+
 func (p Permission) name() string {
 	switch p {
 	case PermissionCreateInstantInvite:
@@ -170,10 +211,10 @@ func (p Permission) name() string {
 		return "ManageRoles"
 	case PermissionManageWebhooks:
 		return "ManageWebhooks"
-	case PermissionManageEmojis:
-		return "ManageEmojis"
-	case PermissionUseSlashCommands:
-		return "UseSlashCommands"
+	case PermissionManageGuildExpressions:
+		return "ManageGuildExpressions"
+	case PermissionUseApplicationCommands:
+		return "UseApplicationCommands"
 	case PermissionRequestToSpeak:
 		return "RequestToSpeak"
 	case PermissionManageEvents:
@@ -192,6 +233,22 @@ func (p Permission) name() string {
 		return "UseEmbeddedActivities"
 	case PermissionModerateMembers:
 		return "ModerateMembers"
+	case PermissionViewCreatorMonetizationAnalytics:
+		return "ViewCreatorMonetizationAnalytics"
+	case PermissionUseSoundboard:
+		return "UseSoundboard"
+	case PermissionCreateGuildExpressions:
+		return "CreateGuildExpressions"
+	case PermissionCreateEvents:
+		return "CreateEvents"
+	case PermissionUseExternalSounds:
+		return "UseExternalSounds"
+	case PermissionSendVoiceMessages:
+		return "SendVoiceMessages"
+	case PermissionSendPolls:
+		return "SendPolls"
+	case PermissionUseExternalApps:
+		return "UseExternalApps"
 	default:
 		return "Unknown"
 	}
@@ -218,6 +275,60 @@ func PermissionFromString(value string) Permission {
 	return Permission(n)
 }
 
+// ParseNames combines named permissions into one mask. Names match the
+// constant suffixes ("SendMessages") or Discord's flag names
+// ("SEND_MESSAGES"), case-insensitively.
+func ParseNames(names []string) (Permission, error) {
+	var mask Permission
+	for _, name := range names {
+		perm, ok := permissionsByKey[nameKey(name)]
+		if !ok {
+			return 0, &types.ValidationError{Field: "permissions", Message: fmt.Sprintf("unknown permission %q", name)}
+		}
+		mask |= perm
+	}
+	return mask, nil
+}
+
+// Names returns the names of the permissions set in p, in bit order.
+func (p Permission) Names() []string {
+	names := []string{}
+	for _, perm := range allPermissions {
+		if p.Has(perm) {
+			names = append(names, permissionNames[perm])
+		}
+	}
+	return names
+}
+
+// MarshalJSON encodes the bitfield as a decimal string, as Discord does.
+func (p Permission) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(p), 10))
+}
+
+// UnmarshalJSON accepts the bitfield as a decimal string or a number.
+func (p *Permission) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("permissions: %s is not a bitfield", data)
+		}
+		*p = Permission(n)
+		return nil
+	}
+	if text == "" {
+		*p = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("permissions: %q is not a bitfield", text)
+	}
+	*p = Permission(n)
+	return nil
+}
+
 // Has reports whether all bits in mask are present.
 func (p Permission) Has(mask Permission) bool {
 	if mask == 0 {
@@ -241,20 +352,16 @@ func (p Permission) String() string {
 	if p == 0 {
 		return "none"
 	}
-	names := []string{}
-	for _, perm := range allPermissions {
-		if p.Has(perm) {
-			names = append(names, permissionNames[perm])
-		}
-	}
-	return fmt.Sprintf("[%s]", strings.Join(names, ", "))
+	return fmt.Sprintf("[%s]", strings.Join(p.Names(), ", "))
 }
 
 // PermissionCalculator evaluates permissions for a member in a channel.
+// With a nil channel it computes the member's guild-wide permissions.
 type PermissionCalculator struct {
 	guild   *types.Guild
 	channel *types.Channel
 	member  *types.Member
+	now     func() time.Time
 }
 
 // NewPermissionCalculator constructs a calculator.
@@ -263,37 +370,36 @@ func NewPermissionCalculator(guild *types.Guild, channel *types.Channel, member
 		guild:   guild,
 		channel: channel,
 		member:  member,
+		now:     time.Now,
 	}
 }
 
-// ComputeBasePermissions calculates the guild-level permissions for the member.
+// At evaluates timeouts as of t instead of the current time.
+func (pc *PermissionCalculator) At(t time.Time) *PermissionCalculator {
+	pc.now = func() time.Time { return t }
+	return pc
+}
+
+// ComputeBasePermissions calculates the guild-level permissions granted by
+// the member's roles. Owners and administrators get every permission.
+// Timeouts are not applied; see ComputeGuild.
 func (pc *PermissionCalculator) ComputeBasePermissions() Permission {
-	if pc.guild == nil || pc.member == nil || pc.member.User == nil {
-		return 0
-	}
-	if pc.guild.OwnerID != "" && pc.member.User.ID == pc.guild.OwnerID {
-		return AllPermissions()
-	}
-	mask := Permission(0)
-	if role := pc.roleByID(pc.guild.ID); role != nil {
-		mask |= PermissionFromString(role.Permissions)
-	}
-	for _, id := range pc.member.Roles {
-		if role := pc.roleByID(id); role != nil {
-			mask |= PermissionFromString(role.Permissions)
-		}
-	}
-	return mask
+	return pc.base().mask
+}
+
+// ComputeGuild returns the member's guild-wide permissions, ignoring the
+// channel, with timeouts applied.
+func (pc *PermissionCalculator) ComputeGuild() Permission {
+	return pc.evaluate(false).mask
 }
 
 // ComputeOverwrites applies channel overwrites to the base permissions.
 func (pc *PermissionCalculator) ComputeOverwrites() Permission {
-	base := pc.ComputeBasePermissions()
-	allow, deny := pc.channelOverwrites()
-	return (base &^ deny) | allow
+	return pc.evaluate(true).mask
 }
 
-// Compute returns the effective permission for the member in the channel.
+// Compute returns the effective permission for the member in the channel,
+// or the guild-wide permissions when the calculator has no channel.
 func (pc *PermissionCalculator) Compute() Permission {
 	return pc.ComputeOverwrites()
 }
@@ -313,46 +419,216 @@ func (pc *PermissionCalculator) CanSendMessages() bool {
 	return pc.Can(PermissionSendMessages)
 }
 
-func (pc *PermissionCalculator) roleByID(id string) *types.Role {
-	if pc.guild == nil {
-		return nil
+// Explain returns one Decision per known permission, in bit order, naming
+// the role, overwrite or rule that settled it.
+func (pc *PermissionCalculator) Explain() []Decision {
+	e := pc.evaluate(true)
+	decisions := make([]Decision, 0, len(allPermissions))
+	for _, perm := range allPermissions {
+		decisions = append(decisions, Decision{
+			Permission: perm,
+			Allowed:    e.mask.Has(perm),
+			Source:     e.sources[perm],
+		})
 	}
-	for i := range pc.guild.Roles {
-		if pc.guild.Roles[i].ID == id {
-			return &pc.guild.Roles[i]
+	return decisions
+}
+
+// SourceKind identifies what settled a permission bit.
+type SourceKind int
+
+const (
+	// SourceNone means no role grants the permission.
+	SourceNone SourceKind = iota
+	SourceOwner
+	SourceAdministrator
+	SourceEveryoneRole
+	SourceRole
+	SourceEveryoneOverwrite
+	SourceRoleOverwrite
+	SourceMemberOverwrite
+	// SourceImplicitDeny means the member cannot view the channel, which
+	// removes every other permission in it.
+	SourceImplicitDeny
+	// SourceTimeout means the member is timed out, which leaves only
+	// ViewChannel and ReadMessageHistory.
+	SourceTimeout
+)
+
+func (k SourceKind) String() string {
+	switch k {
+	case SourceNone:
+		return "no role"
+	case SourceOwner:
+		return "guild owner"
+	case SourceAdministrator:
+		return "administrator"
+	case SourceEveryoneRole:
+		return "@everyone role"
+	case SourceRole:
+		return "role"
+	case SourceEveryoneOverwrite:
+		return "@everyone overwrite"
+	case SourceRoleOverwrite:
+		return "role overwrite"
+	case SourceMemberOverwrite:
+		return "member overwrite"
+	case SourceImplicitDeny:
+		return "missing ViewChannel"
+	case SourceTimeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
+
+// Source is the role, overwrite or rule behind a permission decision. ID is
+// the role or user ID for role and overwrite sources.
+type Source struct {
+	Kind SourceKind
+	ID   string
+}
+
+func (s Source) String() string {
+	if s.ID == "" {
+		return s.Kind.String()
+	}
+	return s.Kind.String() + " " + s.ID
+}
+
+// Decision explains whether one permission is allowed and why.
+type Decision struct {
+	Permission Permission
+	Allowed    bool
+	Source     Source
+}
+
+func (d Decision) String() string {
+	verb := "denied"
+	if d.Allowed {
+		verb = "allowed"
+	}
+	if d.Source.Kind == SourceNone {
+		return fmt.Sprintf("%s %s: %s", permissionNames[d.Permission], verb, d.Source)
+	}
+	return fmt.Sprintf("%s %s by %s", permissionNames[d.Permission], verb, d.Source)
+}
+
+// timeoutAllowed are the permissions a timed-out member keeps.
+const timeoutAllowed = PermissionViewChannel | PermissionReadMessageHistory
+
+type evaluation struct {
+	mask     Permission
+	sources  map[Permission]Source
+	bypassed bool // owner or administrator: overwrites and timeouts do not apply
+}
+
+func (e *evaluation) set(mask Permission, allowed bool, src Source) {
+	if allowed {
+		e.mask |= mask
+	} else {
+		e.mask &^= mask
+	}
+	for _, perm := range allPermissions {
+		if mask&perm != 0 {
+			e.sources[perm] = src
 		}
 	}
-	return nil
 }
 
-func (pc *PermissionCalculator) channelOverwrites() (Permission, Permission) {
-	if pc.channel == nil || pc.member == nil || pc.member.User == nil {
-		return 0, 0
+func (pc *PermissionCalculator) base() *evaluation {
+	e := &evaluation{sources: map[Permission]Source{}}
+	if pc.guild == nil || pc.member == nil || pc.member.User == nil {
+		return e
+	}
+	if pc.guild.OwnerID != "" && pc.member.User.ID == pc.guild.OwnerID {
+		e.set(AllPermissions(), true, Source{Kind: SourceOwner, ID: pc.member.User.ID})
+		e.bypassed = true
+		return e
+	}
+	if role := pc.roleByID(pc.guild.ID); role != nil {
+		e.set(PermissionFromString(role.Permissions), true, Source{Kind: SourceEveryoneRole, ID: role.ID})
+	}
+	for _, id := range pc.member.Roles {
+		if role := pc.roleByID(id); role != nil {
+			e.set(PermissionFromString(role.Permissions)&^e.mask, true, Source{Kind: SourceRole, ID: role.ID})
+		}
+	}
+	if e.mask.Has(PermissionAdministrator) {
+		e.set(AllPermissions(), true, Source{Kind: SourceAdministrator, ID: e.sources[PermissionAdministrator].ID})
+		e.bypassed = true
+	}
+	return e
+}
+
+// evaluate follows Discord's documented order: base permissions, then the
+// @everyone overwrite, the member's role overwrites together, the member
+// overwrite, the implicit deny without ViewChannel, and finally timeouts.
+func (pc *PermissionCalculator) evaluate(withChannel bool) *evaluation {
+	e := pc.base()
+	if e.bypassed || pc.guild == nil || pc.member == nil || pc.member.User == nil {
+		return e
+	}
+	if withChannel && pc.channel != nil {
+		pc.applyOverwrites(e)
+		if !e.mask.Has(PermissionViewChannel) {
+			e.set(e.mask, false, Source{Kind: SourceImplicitDeny})
+		}
 	}
-	var allow, deny Permission
+	if pc.member.TimedOut(pc.now()) {
+		e.set(e.mask&^timeoutAllowed, false, Source{Kind: SourceTimeout, ID: pc.member.User.ID})
+	}
+	return e
+}
+
+func (pc *PermissionCalculator) applyOverwrites(e *evaluation) {
+	var roles, member []types.PermissionOverwrite
 	for _, overwrite := range pc.channel.PermissionOverwrites {
-		permAllow, permDeny := parseOverwrite(overwrite)
 		switch overwrite.Type {
 		case types.PermissionOverwriteRole:
 			if overwrite.ID == pc.guild.ID {
-				allow |= permAllow
-				deny |= permDeny
+				allow, deny := parseOverwrite(overwrite)
+				e.set(deny, false, Source{Kind: SourceEveryoneOverwrite, ID: overwrite.ID})
+				e.set(allow, true, Source{Kind: SourceEveryoneOverwrite, ID: overwrite.ID})
 				continue
 			}
 			for _, roleID := range pc.member.Roles {
 				if roleID == overwrite.ID {
-					allow |= permAllow
-					deny |= permDeny
+					roles = append(roles, overwrite)
 				}
 			}
 		case types.PermissionOverwriteMember:
 			if overwrite.ID == pc.member.User.ID {
-				allow |= permAllow
-				deny |= permDeny
+				member = append(member, overwrite)
 			}
 		}
 	}
-	return allow, deny
+	// Role overwrites combine: any role allow beats any role deny.
+	for _, overwrite := range roles {
+		_, deny := parseOverwrite(overwrite)
+		e.set(deny, false, Source{Kind: SourceRoleOverwrite, ID: overwrite.ID})
+	}
+	for _, overwrite := range roles {
+		allow, _ := parseOverwrite(overwrite)
+		e.set(allow, true, Source{Kind: SourceRoleOverwrite, ID: overwrite.ID})
+	}
+	for _, overwrite := range member {
+		allow, deny := parseOverwrite(overwrite)
+		e.set(deny, false, Source{Kind: SourceMemberOverwrite, ID: overwrite.ID})
+		e.set(allow, true, Source{Kind: SourceMemberOverwrite, ID: overwrite.ID})
+	}
+}
+
+func (pc *PermissionCalculator) roleByID(id string) *types.Role {
+	if pc.guild == nil {
+		return nil
+	}
+	for i := range pc.guild.Roles {
+		if pc.guild.Roles[i].ID == id {
+			return &pc.guild.Roles[i]
+		}
+	}
+	return nil
 }
 
 func parseOverwrite(overwrite types.PermissionOverwrite) (Permission, Permission) {
@@ -1,8 +1,10 @@
 package permissions
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 )
@@ -62,3 +64,170 @@ func TestPermissionCalculatorChannelOverrides(t *testing.T) {
 		t.Fatalf("deny should block manage channels")
 	}
 }
+
+func perm(p Permission) string { return fmt.Sprintf("%d", p) }
+
+func TestPermissionCalculatorAdministratorBypassesOverwrites(t *testing.T) {
+	guild := &types.Guild{ID: "g1", Roles: []types.Role{
+		{ID: "g1", Permissions: perm(PermissionViewChannel)},
+		{ID: "admin", Permissions: perm(PermissionAdministrator)},
+	}}
+	channel := &types.Channel{PermissionOverwrites: []types.PermissionOverwrite{
+		{ID: "g1", Type: types.PermissionOverwriteRole, Deny: perm(PermissionViewChannel | PermissionSendMessages)},
+	}}
+	member := &types.Member{User: &types.User{ID: "u1"}, Roles: []string{"admin"}}
+	calculator := NewPermissionCalculator(guild, channel, member)
+	if calculator.Compute() != AllPermissions() {
+		t.Fatalf("administrator should bypass overwrites, got %s", calculator.Compute())
+	}
+	if d := calculator.Explain()[11]; d.Source.Kind != SourceAdministrator || d.Source.ID != "admin" {
+		t.Fatalf("unexpected decision %v", d)
+	}
+}
+
+func TestPermissionCalculatorOverwriteOrder(t *testing.T) {
+	guild := &types.Guild{ID: "g1", Roles: []types.Role{
+		{ID: "g1", Permissions: perm(PermissionViewChannel | PermissionSendMessages)},
+		{ID: "r1"}, {ID: "r2"},
+	}}
+	channel := &types.Channel{PermissionOverwrites: []types.PermissionOverwrite{
+		{ID: "u1", Type: types.PermissionOverwriteMember, Deny: perm(PermissionAttachFiles)},
+		{ID: "r1", Type: types.PermissionOverwriteRole, Allow: perm(PermissionSendMessages | PermissionAttachFiles)},
+		{ID: "r2", Type: types.PermissionOverwriteRole, Deny: perm(PermissionSendMessages | PermissionEmbedLinks)},
+		{ID: "g1", Type: types.PermissionOverwriteRole, Deny: perm(PermissionSendMessages), Allow: perm(PermissionEmbedLinks)},
+	}}
+	member := &types.Member{User: &types.User{ID: "u1"}, Roles: []string{"r1", "r2"}}
+	calculator := NewPermissionCalculator(guild, channel, member)
+	got := calculator.Compute()
+	if !got.Has(PermissionSendMessages) {
+		t.Fatalf("a role allow should beat a role deny and the @everyone deny: %s", got)
+	}
+	if got.Has(PermissionEmbedLinks) {
+		t.Fatalf("a role deny should beat the @everyone allow: %s", got)
+	}
+	if got.Has(PermissionAttachFiles) {
+		t.Fatalf("the member overwrite should beat role overwrites: %s", got)
+	}
+	for _, d := range calculator.Explain() {
+		switch d.Permission {
+		case PermissionSendMessages:
+			if d.Source != (Source{Kind: SourceRoleOverwrite, ID: "r1"}) {
+				t.Fatalf("SendMessages: %v", d)
+			}
+		case PermissionAttachFiles:
+			if d.String() != "AttachFiles denied by member overwrite u1" {
+				t.Fatalf("AttachFiles: %v", d)
+			}
+		case PermissionBanMembers:
+			if d.Allowed || d.Source.Kind != SourceNone {
+				t.Fatalf("BanMembers: %v", d)
+			}
+		}
+	}
+}
+
+func TestPermissionCalculatorImplicitDeny(t *testing.T) {
+	guild := &types.Guild{ID: "g1", Roles: []types.Role{
+		{ID: "g1", Permissions: perm(PermissionViewChannel | PermissionSendMessages)},
+	}}
+	channel := &types.Channel{PermissionOverwrites: []types.PermissionOverwrite{
+		{ID: "g1", Type: types.PermissionOverwriteRole, Deny: perm(PermissionViewChannel)},
+	}}
+	member := &types.Member{User: &types.User{ID: "u1"}}
+	calculator := NewPermissionCalculator(guild, channel, member)
+	if got := calculator.Compute(); got != 0 {
+		t.Fatalf("expected no permissions without ViewChannel, got %s", got)
+	}
+	for _, d := range calculator.Explain() {
+		if d.Permission == PermissionSendMessages && d.Source.Kind != SourceImplicitDeny {
+			t.Fatalf("SendMessages: %v", d)
+		}
+	}
+	if !calculator.ComputeGuild().Has(PermissionSendMessages) {
+		t.Fatalf("guild-wide permissions should ignore the channel")
+	}
+}
+
+func TestPermissionCalculatorTimeout(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := now.Add(time.Hour)
+	guild := &types.Guild{ID: "g1", OwnerID: "owner", Roles: []types.Role{
+		{ID: "g1", Permissions: perm(PermissionViewChannel | PermissionReadMessageHistory | PermissionSendMessages)},
+	}}
+	member := &types.Member{User: &types.User{ID: "u1"}, CommunicationDisabledUntil: &until}
+
+	calculator := NewPermissionCalculator(guild, nil, member).At(now)
+	if got := calculator.Compute(); got != PermissionViewChannel|PermissionReadMessageHistory {
+		t.Fatalf("timed-out member kept %s", got)
+	}
+	if got := calculator.ComputeBasePermissions(); !got.Has(PermissionSendMessages) {
+		t.Fatalf("base permissions should ignore timeouts")
+	}
+	if !calculator.At(until.Add(time.Second)).CanSendMessages() {
+		t.Fatalf("an expired timeout should not apply")
+	}
+
+	owner := &types.Member{User: &types.User{ID: "owner"}, CommunicationDisabledUntil: &until}
+	if !NewPermissionCalculator(guild, nil, owner).At(now).CanSendMessages() {
+		t.Fatalf("timeouts should not apply to the owner")
+	}
+}
+
+func TestPermissionBitValues(t *testing.T) {
+	tests := map[Permission]uint{
+		PermissionManageGuildExpressions:           30,
+		PermissionUseApplicationCommands:           31,
+		PermissionModerateMembers:                  40,
+		PermissionViewCreatorMonetizationAnalytics: 41,
+		PermissionUseSoundboard:                    42,
+		PermissionCreateGuildExpressions:           43,
+		PermissionCreateEvents:                     44,
+		PermissionUseExternalSounds:                45,
+		PermissionSendVoiceMessages:                46,
+		PermissionSendPolls:                        49,
+		PermissionUseExternalApps:                  50,
+	}
+	for perm, bit := range tests {
+		if perm != 1<<bit {
+			t.Errorf("%s = %d, want 1<<%d", perm, perm, bit)
+		}
+	}
+	if PermissionManageEmojis != PermissionManageGuildExpressions {
+		t.Fatalf("deprecated alias changed")
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	mask, err := ParseNames([]string{"SendMessages", "SEND_POLLS", "manage_emojis_and_stickers"})
+	if err != nil {
+		t.Fatalf("ParseNames() error = %v", err)
+	}
+	if mask != PermissionSendMessages|PermissionSendPolls|PermissionManageGuildExpressions {
+		t.Fatalf("ParseNames() = %s", mask)
+	}
+	if got := mask.Names(); len(got) != 3 || got[0] != "SendMessages" {
+		t.Fatalf("Names() = %v", got)
+	}
+	if _, err := ParseNames([]string{"Fly"}); err == nil {
+		t.Fatalf("expected error for unknown name")
+	}
+}
+
+func TestPermissionJSON(t *testing.T) {
+	raw, err := json.Marshal(struct {
+		Allow Permission `json:"allow"`
+	}{PermissionSendPolls | PermissionViewChannel})
+	if err != nil || string(raw) != `{"allow":"562949953422336"}` {
+		t.Fatalf("Marshal = %s, %v", raw, err)
+	}
+	for _, input := range []string{`"562949953422336"`, `562949953422336`} {
+		var p Permission
+		if err := json.Unmarshal([]byte(input), &p); err != nil || p != PermissionSendPolls|PermissionViewChannel {
+			t.Fatalf("Unmarshal(%s) = %d, %v", input, p, err)
+		}
+	}
+	var p Permission
+	if err := json.Unmarshal([]byte(`"abc"`), &p); err == nil {
+		t.Fatalf("expected error for non-numeric string")
+	}
+}
@@ -0,0 +1,193 @@
+package state
+
+import (
+	"sort"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// Cache is the storage backend behind State. Implementations must be safe for
+// concurrent use. MemoryCache is the default; RedisCache lets several processes
+// or shards share entity state.
+type Cache interface {
+	GetGuild(guildID string) (*types.Guild, bool)
+	SetGuild(guild *types.Guild)
+	RemoveGuild(guildID string)
+
+	GetChannel(channelID string) (*types.Channel, bool)
+	SetChannel(channel *types.Channel)
+	RemoveChannel(channelID string)
+	GuildChannels(guildID string) []*types.Channel
+
+	GetMember(guildID, userID string) (*types.Member, bool)
+	SetMember(guildID string, member *types.Member)
+	RemoveMember(guildID, userID string)
+
+	GetRole(guildID, roleID string) (*types.Role, bool)
+	SetRole(guildID string, role *types.Role)
+	RemoveRole(guildID, roleID string)
+	GuildRoles(guildID string) []*types.Role
+
+	GetVoiceState(guildID, userID string) (*types.VoiceState, bool)
+	SetVoiceState(vs *types.VoiceState)
+	RemoveVoiceState(guildID, userID string)
+	GuildVoiceStates(guildID string) []*types.VoiceState
+
+	// PurgeGuild removes every channel, member, role, and voice state scoped to the guild.
+	PurgeGuild(guildID string)
+
+	Stats() Stats
+}
+
+// Stats summarises every store held by a Cache.
+type Stats struct {
+	Guilds      StoreStats `json:"guilds"`
+	Channels    StoreStats `json:"channels"`
+	Members     StoreStats `json:"members"`
+	Roles       StoreStats `json:"roles"`
+	VoiceStates StoreStats `json:"voice_states"`
+}
+
+type guildScopedKey struct {
+	guildID string
+	id      string
+}
+
+// MemoryCache keeps entities in process with optional TTLs and LRU size limits.
+type MemoryCache struct {
+	guilds   *store[string, *types.Guild]
+	channels *store[string, *types.Channel]
+	members  *store[guildScopedKey, *types.Member]
+	roles    *store[guildScopedKey, *types.Role]
+	voice    *store[guildScopedKey, *types.VoiceState]
+}
+
+// NewMemoryCache builds an in-memory cache using the TTL and size options.
+func NewMemoryCache(opts ...Option) *MemoryCache {
+	cfg := newConfig(opts)
+	memberTTL := cfg.memberTTL
+	if memberTTL == 0 {
+		memberTTL = cfg.ttl
+	}
+
+	return &MemoryCache{
+		guilds:   newStore[string, *types.Guild](cfg.ttl, cfg.maxGuilds),
+		channels: newStore[string, *types.Channel](cfg.ttl, cfg.maxChannels),
+		members:  newStore[guildScopedKey, *types.Member](memberTTL, cfg.maxMembers),
+		roles:    newStore[guildScopedKey, *types.Role](cfg.ttl, cfg.maxRoles),
+		voice:    newStore[guildScopedKey, *types.VoiceState](cfg.ttl, cfg.maxVoice),
+	}
+}
+
+func (c *MemoryCache) GetGuild(guildID string) (*types.Guild, bool) {
+	return c.guilds.get(guildID)
+}
+
+func (c *MemoryCache) SetGuild(guild *types.Guild) {
+	c.guilds.set(guild.ID, guild)
+}
+
+func (c *MemoryCache) RemoveGuild(guildID string) {
+	c.guilds.delete(guildID)
+}
+
+func (c *MemoryCache) GetChannel(channelID string) (*types.Channel, bool) {
+	return c.channels.get(channelID)
+}
+
+func (c *MemoryCache) SetChannel(channel *types.Channel) {
+	c.channels.set(channel.ID, channel)
+}
+
+func (c *MemoryCache) RemoveChannel(channelID string) {
+	c.channels.delete(channelID)
+}
+
+func (c *MemoryCache) GuildChannels(guildID string) []*types.Channel {
+	return c.channels.values(func(_ string, ch *types.Channel) bool {
+		return ch.GuildID == guildID
+	})
+}
+
+func (c *MemoryCache) GetMember(guildID, userID string) (*types.Member, bool) {
+	return c.members.get(guildScopedKey{guildID: guildID, id: userID})
+}
+
+func (c *MemoryCache) SetMember(guildID string, member *types.Member) {
+	c.members.set(guildScopedKey{guildID: guildID, id: member.User.ID}, member)
+}
+
+func (c *MemoryCache) RemoveMember(guildID, userID string) {
+	c.members.delete(guildScopedKey{guildID: guildID, id: userID})
+}
+
+func (c *MemoryCache) GetRole(guildID, roleID string) (*types.Role, bool) {
+	return c.roles.get(guildScopedKey{guildID: guildID, id: roleID})
+}
+
+func (c *MemoryCache) SetRole(guildID string, role *types.Role) {
+	c.roles.set(guildScopedKey{guildID: guildID, id: role.ID}, role)
+}
+
+func (c *MemoryCache) RemoveRole(guildID, roleID string) {
+	c.roles.delete(guildScopedKey{guildID: guildID, id: roleID})
+}
+
+func (c *MemoryCache) GuildRoles(guildID string) []*types.Role {
+	return c.roles.values(func(k guildScopedKey, _ *types.Role) bool {
+		return k.guildID == guildID
+	})
+}
+
+func (c *MemoryCache) GetVoiceState(guildID, userID string) (*types.VoiceState, bool) {
+	return c.voice.get(guildScopedKey{guildID: guildID, id: userID})
+}
+
+func (c *MemoryCache) SetVoiceState(vs *types.VoiceState) {
+	c.voice.set(guildScopedKey{guildID: vs.GuildID, id: vs.UserID}, vs)
+}
+
+func (c *MemoryCache) RemoveVoiceState(guildID, userID string) {
+	c.voice.delete(guildScopedKey{guildID: guildID, id: userID})
+}
+
+func (c *MemoryCache) GuildVoiceStates(guildID string) []*types.VoiceState {
+	return c.voice.values(func(k guildScopedKey, _ *types.VoiceState) bool {
+		return k.guildID == guildID
+	})
+}
+
+func (c *MemoryCache) PurgeGuild(guildID string) {
+	c.channels.deleteFunc(func(_ string, ch *types.Channel) bool { return ch.GuildID == guildID })
+	c.members.deleteFunc(func(k guildScopedKey, _ *types.Member) bool { return k.guildID == guildID })
+	c.roles.deleteFunc(func(k guildScopedKey, _ *types.Role) bool { return k.guildID == guildID })
+	c.voice.deleteFunc(func(k guildScopedKey, _ *types.VoiceState) bool { return k.guildID == guildID })
+}
+
+func (c *MemoryCache) Stats() Stats {
+	return Stats{
+		Guilds:      c.guilds.snapshot(),
+		Channels:    c.channels.snapshot(),
+		Members:     c.members.snapshot(),
+		Roles:       c.roles.snapshot(),
+		VoiceStates: c.voice.snapshot(),
+	}
+}
+
+func sortChannels(channels []*types.Channel) {
+	sort.Slice(channels, func(i, j int) bool {
+		if channels[i].Position != channels[j].Position {
+			return channels[i].Position < channels[j].Position
+		}
+		return channels[i].ID < channels[j].ID
+	})
+}
+
+func sortRoles(roles []*types.Role) {
+	sort.Slice(roles, func(i, j int) bool {
+		if roles[i].Position != roles[j].Position {
+			return roles[i].Position < roles[j].Position
+		}
+		return roles[i].ID < roles[j].ID
+	})
+}
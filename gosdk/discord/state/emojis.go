@@ -0,0 +1,226 @@
+package state
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// EmojiFetcher loads a guild's emojis and stickers on demand.
+// *client.Guilds satisfies this interface.
+type EmojiFetcher interface {
+	ListGuildEmojis(ctx context.Context, guildID string) ([]*types.Emoji, error)
+	ListGuildStickers(ctx context.Context, guildID string) ([]*types.Sticker, error)
+}
+
+type guildEmojis struct {
+	emojis   map[string]types.Emoji
+	stickers map[string]types.Sticker
+}
+
+// EmojiCache maps custom emoji and sticker names to their IDs per guild.
+// It follows GUILD_CREATE, GUILD_EMOJIS_UPDATE, and GUILD_STICKERS_UPDATE when
+// registered on a dispatcher and can refresh a guild on demand via a fetcher.
+type EmojiCache struct {
+	fetcher EmojiFetcher
+
+	mu     sync.RWMutex
+	guilds map[string]*guildEmojis
+}
+
+// NewEmojiCache creates an empty cache. fetcher may be nil when the cache is
+// fed exclusively by gateway events.
+func NewEmojiCache(fetcher EmojiFetcher) *EmojiCache {
+	return &EmojiCache{
+		fetcher: fetcher,
+		guilds:  make(map[string]*guildEmojis),
+	}
+}
+
+// Emoji resolves ":name:" (or "name") to the "name:id" form accepted by
+// reaction endpoints. Strings that are not custom emoji names, such as
+// Unicode emoji, are returned unchanged.
+func (c *EmojiCache) Emoji(guildID, name string) (string, bool) {
+	trimmed, custom := emojiName(name)
+	if !custom {
+		return name, name != ""
+	}
+	emoji, ok := c.Lookup(guildID, trimmed)
+	if !ok {
+		return "", false
+	}
+	return emoji.Reaction(), true
+}
+
+// Lookup returns the cached emoji with the given name (colons optional). The
+// result can be used directly in component Emoji fields or via Mention().
+func (c *EmojiCache) Lookup(guildID, name string) (*types.Emoji, bool) {
+	name, _ = emojiName(name)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	guild, ok := c.guilds[guildID]
+	if !ok {
+		return nil, false
+	}
+	emoji, ok := guild.emojis[name]
+	if !ok {
+		return nil, false
+	}
+	return &emoji, true
+}
+
+// Sticker returns the cached sticker with the given name.
+func (c *EmojiCache) Sticker(guildID, name string) (*types.Sticker, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	guild, ok := c.guilds[guildID]
+	if !ok {
+		return nil, false
+	}
+	sticker, ok := guild.stickers[name]
+	if !ok {
+		return nil, false
+	}
+	return &sticker, true
+}
+
+// Resolve behaves like Emoji but refreshes the guild from the fetcher when
+// the name is not cached yet.
+func (c *EmojiCache) Resolve(ctx context.Context, guildID, name string) (string, error) {
+	if emoji, ok := c.Emoji(guildID, name); ok {
+		return emoji, nil
+	}
+	if c.fetcher != nil {
+		if err := c.Refresh(ctx, guildID); err != nil {
+			return "", err
+		}
+		if emoji, ok := c.Emoji(guildID, name); ok {
+			return emoji, nil
+		}
+	}
+	return "", &types.ValidationError{Field: "emoji", Message: "unknown emoji " + name + " in guild " + guildID}
+}
+
+// Refresh reloads a guild's emojis and stickers from the fetcher.
+func (c *EmojiCache) Refresh(ctx context.Context, guildID string) error {
+	if c.fetcher == nil {
+		return &types.ValidationError{Field: "fetcher", Message: "emoji cache has no fetcher"}
+	}
+	emojis, err := c.fetcher.ListGuildEmojis(ctx, guildID)
+	if err != nil {
+		return err
+	}
+	stickers, err := c.fetcher.ListGuildStickers(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	emojiList := make([]types.Emoji, 0, len(emojis))
+	for _, e := range emojis {
+		if e != nil {
+			emojiList = append(emojiList, *e)
+		}
+	}
+	stickerList := make([]types.Sticker, 0, len(stickers))
+	for _, s := range stickers {
+		if s != nil {
+			stickerList = append(stickerList, *s)
+		}
+	}
+	c.SetEmojis(guildID, emojiList)
+	c.SetStickers(guildID, stickerList)
+	return nil
+}
+
+// SetEmojis replaces the cached emojis for a guild.
+func (c *EmojiCache) SetEmojis(guildID string, emojis []types.Emoji) {
+	byName := make(map[string]types.Emoji, len(emojis))
+	for _, e := range emojis {
+		if e.ID != "" && e.Name != "" {
+			byName[e.Name] = e
+		}
+	}
+
+	c.mu.Lock()
+	c.guild(guildID).emojis = byName
+	c.mu.Unlock()
+}
+
+// SetStickers replaces the cached stickers for a guild.
+func (c *EmojiCache) SetStickers(guildID string, stickers []types.Sticker) {
+	byName := make(map[string]types.Sticker, len(stickers))
+	for _, s := range stickers {
+		if s.ID != "" && s.Name != "" {
+			byName[s.Name] = s
+		}
+	}
+
+	c.mu.Lock()
+	c.guild(guildID).stickers = byName
+	c.mu.Unlock()
+}
+
+// RemoveGuild drops everything cached for a guild.
+func (c *EmojiCache) RemoveGuild(guildID string) {
+	c.mu.Lock()
+	delete(c.guilds, guildID)
+	c.mu.Unlock()
+}
+
+// Register subscribes the cache to the dispatcher so it follows gateway events.
+func (c *EmojiCache) Register(d *gateway.Dispatcher) {
+	if d == nil {
+		return
+	}
+
+	d.On(gateway.EventGuildCreate, func(ctx context.Context, event gateway.Event) error {
+		if evt, ok := event.(*gateway.GuildCreateEvent); ok && evt.Guild != nil {
+			c.SetEmojis(evt.Guild.ID, evt.Guild.Emojis)
+			c.SetStickers(evt.Guild.ID, evt.Guild.Stickers)
+		}
+		return nil
+	})
+	d.On(gateway.EventGuildDelete, func(ctx context.Context, event gateway.Event) error {
+		if evt, ok := event.(*gateway.GuildDeleteEvent); ok {
+			c.RemoveGuild(evt.GuildID)
+		}
+		return nil
+	})
+	d.OnGuildEmojisUpdate(func(ctx context.Context, evt *gateway.GuildEmojisUpdateEvent) error {
+		c.SetEmojis(evt.GuildID, evt.Emojis)
+		return nil
+	})
+	d.OnGuildStickersUpdate(func(ctx context.Context, evt *gateway.GuildStickersUpdateEvent) error {
+		c.SetStickers(evt.GuildID, evt.Stickers)
+		return nil
+	})
+}
+
+// guild returns the entry for guildID, creating it if needed. Callers hold mu.
+func (c *EmojiCache) guild(guildID string) *guildEmojis {
+	g, ok := c.guilds[guildID]
+	if !ok {
+		g = &guildEmojis{}
+		c.guilds[guildID] = g
+	}
+	return g
+}
+
+// emojiName strips surrounding colons and reports whether name looks like a
+// custom emoji name rather than a Unicode emoji.
+func emojiName(name string) (string, bool) {
+	trimmed := strings.Trim(name, ":")
+	if trimmed == "" {
+		return "", false
+	}
+	for _, r := range trimmed {
+		if !(r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return name, false
+		}
+	}
+	return trimmed, true
+}
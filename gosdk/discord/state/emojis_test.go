@@ -0,0 +1,85 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+type fakeEmojiFetcher struct {
+	calls  int
+	emojis []*types.Emoji
+}
+
+func (f *fakeEmojiFetcher) ListGuildEmojis(ctx context.Context, guildID string) ([]*types.Emoji, error) {
+	f.calls++
+	return f.emojis, nil
+}
+
+func (f *fakeEmojiFetcher) ListGuildStickers(ctx context.Context, guildID string) ([]*types.Sticker, error) {
+	return []*types.Sticker{{ID: "s1", Name: "wave"}}, nil
+}
+
+func TestEmojiCacheFollowsGatewayEvents(t *testing.T) {
+	cache := NewEmojiCache(nil)
+	d := gateway.NewDispatcher()
+	cache.Register(d)
+
+	mustDispatch(t, d, &gateway.GuildCreateEvent{Guild: &types.Guild{
+		ID:     "g1",
+		Emojis: []types.Emoji{{ID: "1", Name: "partyparrot", Animated: true}},
+	}})
+	if got, ok := cache.Emoji("g1", ":partyparrot:"); !ok || got != "a:partyparrot:1" {
+		t.Fatalf("expected animated reaction form, got %q (ok=%v)", got, ok)
+	}
+
+	mustDispatch(t, d, &gateway.GuildEmojisUpdateEvent{GuildID: "g1", Emojis: []types.Emoji{{ID: "2", Name: "blob"}}})
+	if _, ok := cache.Emoji("g1", ":partyparrot:"); ok {
+		t.Fatalf("expected removed emoji to be dropped")
+	}
+	emoji, ok := cache.Lookup("g1", "blob")
+	if !ok || emoji.Mention() != "<:blob:2>" {
+		t.Fatalf("expected blob emoji, got %+v", emoji)
+	}
+
+	mustDispatch(t, d, &gateway.GuildStickersUpdateEvent{GuildID: "g1", Stickers: []types.Sticker{{ID: "s1", Name: "hello"}}})
+	if sticker, ok := cache.Sticker("g1", "hello"); !ok || sticker.ID != "s1" {
+		t.Fatalf("expected sticker, got %+v", sticker)
+	}
+
+	mustDispatch(t, d, &gateway.GuildDeleteEvent{GuildID: "g1"})
+	if _, ok := cache.Lookup("g1", "blob"); ok {
+		t.Fatalf("expected guild to be purged")
+	}
+}
+
+func TestEmojiCacheUnicodePassthrough(t *testing.T) {
+	cache := NewEmojiCache(nil)
+	if got, ok := cache.Emoji("g1", "🎉"); !ok || got != "🎉" {
+		t.Fatalf("expected unicode emoji passthrough, got %q", got)
+	}
+}
+
+func TestEmojiCacheResolveRefreshesOnMiss(t *testing.T) {
+	fetcher := &fakeEmojiFetcher{emojis: []*types.Emoji{{ID: "9", Name: "cat"}}}
+	cache := NewEmojiCache(fetcher)
+
+	got, err := cache.Resolve(context.Background(), "g1", ":cat:")
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	if got != "cat:9" {
+		t.Fatalf("expected cat:9, got %q", got)
+	}
+	if _, err := cache.Resolve(context.Background(), "g1", ":cat:"); err != nil || fetcher.calls != 1 {
+		t.Fatalf("expected cached lookup, calls=%d err=%v", fetcher.calls, err)
+	}
+	if _, ok := cache.Sticker("g1", "wave"); !ok {
+		t.Fatalf("expected stickers refreshed alongside emojis")
+	}
+	if _, err := cache.Resolve(context.Background(), "g1", ":missing:"); err == nil {
+		t.Fatalf("expected error for unknown emoji")
+	}
+}
@@ -0,0 +1,113 @@
+package state
+
+import (
+	"context"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+)
+
+// Register subscribes the state to the dispatcher so caches follow gateway events.
+func (s *State) Register(d *gateway.Dispatcher) {
+	if d == nil {
+		return
+	}
+
+	d.On(gateway.EventReady, func(ctx context.Context, event gateway.Event) error {
+		if ready, ok := event.(*gateway.ReadyEvent); ok {
+			s.setUser(ready.User)
+		}
+		return nil
+	})
+	d.On(gateway.EventGuildCreate, func(ctx context.Context, event gateway.Event) error {
+		if evt, ok := event.(*gateway.GuildCreateEvent); ok {
+			s.SetGuild(evt.Guild)
+		}
+		return nil
+	})
+	d.On(gateway.EventGuildUpdate, func(ctx context.Context, event gateway.Event) error {
+		if evt, ok := event.(*gateway.GuildUpdateEvent); ok {
+			s.SetGuild(evt.Guild)
+		}
+		return nil
+	})
+	d.On(gateway.EventGuildDelete, func(ctx context.Context, event gateway.Event) error {
+		if evt, ok := event.(*gateway.GuildDeleteEvent); ok {
+			s.RemoveGuild(evt.GuildID)
+		}
+		return nil
+	})
+
+	d.OnChannelCreate(func(ctx context.Context, evt *gateway.ChannelCreateEvent) error {
+		if evt.Channel != nil {
+			s.SetChannel(evt.GuildID, evt.Channel)
+		}
+		return nil
+	})
+	d.OnChannelUpdate(func(ctx context.Context, evt *gateway.ChannelUpdateEvent) error {
+		if evt.Channel != nil {
+			s.SetChannel(evt.GuildID, evt.Channel)
+		}
+		return nil
+	})
+	d.OnChannelDelete(func(ctx context.Context, evt *gateway.ChannelDeleteEvent) error {
+		if evt.Channel != nil {
+			s.RemoveChannel(evt.ID)
+		}
+		return nil
+	})
+	d.OnThreadCreate(func(ctx context.Context, evt *gateway.ThreadCreateEvent) error {
+		if evt.Channel != nil {
+			s.SetChannel(evt.GuildID, evt.Channel)
+		}
+		return nil
+	})
+	d.OnThreadUpdate(func(ctx context.Context, evt *gateway.ThreadUpdateEvent) error {
+		if evt.Channel != nil {
+			s.SetChannel(evt.GuildID, evt.Channel)
+		}
+		return nil
+	})
+	d.OnThreadDelete(func(ctx context.Context, evt *gateway.ThreadDeleteEvent) error {
+		s.RemoveChannel(evt.ID)
+		return nil
+	})
+	d.OnThreadListSync(func(ctx context.Context, evt *gateway.ThreadListSyncEvent) error {
+		for _, thread := range evt.Threads {
+			s.SetChannel(evt.GuildID, thread)
+		}
+		return nil
+	})
+
+	d.OnGuildMemberAdd(func(ctx context.Context, evt *gateway.GuildMemberAddEvent) error {
+		s.SetMember(evt.GuildID, evt.Member)
+		return nil
+	})
+	d.OnGuildMemberUpdate(func(ctx context.Context, evt *gateway.GuildMemberUpdateEvent) error {
+		s.SetMember(evt.GuildID, evt.Member)
+		return nil
+	})
+	d.OnGuildMemberRemove(func(ctx context.Context, evt *gateway.GuildMemberRemoveEvent) error {
+		if evt.User != nil {
+			s.RemoveMember(evt.GuildID, evt.User.ID)
+		}
+		return nil
+	})
+
+	d.OnGuildRoleCreate(func(ctx context.Context, evt *gateway.GuildRoleCreateEvent) error {
+		s.SetRole(evt.GuildID, evt.Role)
+		return nil
+	})
+	d.OnGuildRoleUpdate(func(ctx context.Context, evt *gateway.GuildRoleUpdateEvent) error {
+		s.SetRole(evt.GuildID, evt.Role)
+		return nil
+	})
+	d.OnGuildRoleDelete(func(ctx context.Context, evt *gateway.GuildRoleDeleteEvent) error {
+		s.RemoveRole(evt.GuildID, evt.RoleID)
+		return nil
+	})
+
+	d.OnVoiceStateUpdate(func(ctx context.Context, evt *gateway.VoiceStateUpdateEvent) error {
+		s.SetVoiceState(evt.VoiceState)
+		return nil
+	})
+}
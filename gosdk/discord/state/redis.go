@@ -0,0 +1,311 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+	"github.com/mtreilly/godiscord/gosdk/redisclient"
+)
+
+// RedisClient is the Redis client RedisCache stores entries through. It is
+// shared with the SDK's other Redis-backed stores, so one adapter serves
+// them all.
+type RedisClient = redisclient.Client
+
+// RedisOption configures a RedisCache.
+type RedisOption func(*RedisCache)
+
+// WithRedisPrefix namespaces every key (default "godiscord:state:").
+func WithRedisPrefix(prefix string) RedisOption {
+	return func(c *RedisCache) {
+		if prefix != "" {
+			c.prefix = prefix
+		}
+	}
+}
+
+// WithRedisTTL expires entries after ttl (0 = no expiry).
+func WithRedisTTL(ttl time.Duration) RedisOption {
+	return func(c *RedisCache) {
+		if ttl >= 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithRedisTimeout bounds each Redis round trip.
+func WithRedisTimeout(timeout time.Duration) RedisOption {
+	return func(c *RedisCache) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// WithRedisLogger overrides the logger used to report Redis failures.
+func WithRedisLogger(l logger.Interface) RedisOption {
+	return func(c *RedisCache) {
+		if lg := logger.From(l); lg != nil {
+			c.logger = lg
+		}
+	}
+}
+
+type redisCounters struct {
+	hits   int64
+	misses int64
+}
+
+func (c *redisCounters) stats() StoreStats {
+	return StoreStats{Hits: atomic.LoadInt64(&c.hits), Misses: atomic.LoadInt64(&c.misses)}
+}
+
+// RedisCache stores entities as JSON in Redis so multiple processes share state.
+// Redis errors are logged and treated as cache misses.
+type RedisCache struct {
+	client  RedisClient
+	prefix  string
+	ttl     time.Duration
+	timeout time.Duration
+	logger  *logger.Logger
+
+	guilds, channels, members, roles, voice redisCounters
+}
+
+// NewRedisCache wraps a Redis client as a state Cache.
+func NewRedisCache(client RedisClient, opts ...RedisOption) (*RedisCache, error) {
+	if client == nil {
+		return nil, &types.ValidationError{Field: "client", Message: "redis client is required"}
+	}
+	c := &RedisCache{
+		client:  client,
+		prefix:  "godiscord:state:",
+		timeout: 2 * time.Second,
+		logger:  logger.Default(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	c.logger = c.logger.WithSubsystem(logger.SubsystemState)
+	return c, nil
+}
+
+func (c *RedisCache) key(parts ...string) string {
+	return c.prefix + strings.Join(parts, ":")
+}
+
+func (c *RedisCache) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.timeout)
+}
+
+func (c *RedisCache) load(key string, out interface{}, counters *redisCounters) bool {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	raw, found, err := c.client.Get(ctx, key)
+	if err != nil {
+		c.logger.Warn("state redis get failed", "key", key, "error", err)
+	}
+	if err != nil || !found {
+		atomic.AddInt64(&counters.misses, 1)
+		return false
+	}
+	if err := json.Unmarshal([]byte(raw), out); err != nil {
+		c.logger.Warn("state redis decode failed", "key", key, "error", err)
+		atomic.AddInt64(&counters.misses, 1)
+		return false
+	}
+	atomic.AddInt64(&counters.hits, 1)
+	return true
+}
+
+func (c *RedisCache) store(key string, value interface{}) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		c.logger.Warn("state redis encode failed", "key", key, "error", err)
+		return
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	if err := c.client.Set(ctx, key, string(raw), c.ttl); err != nil {
+		c.logger.Warn("state redis set failed", "key", key, "error", err)
+	}
+}
+
+func (c *RedisCache) remove(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	if err := c.client.Del(ctx, keys...); err != nil {
+		c.logger.Warn("state redis delete failed", "keys", keys, "error", err)
+	}
+}
+
+func (c *RedisCache) scan(match string) []string {
+	ctx, cancel := c.context()
+	defer cancel()
+	keys, err := c.client.Scan(ctx, match)
+	if err != nil {
+		c.logger.Warn("state redis scan failed", "match", match, "error", err)
+		return nil
+	}
+	return keys
+}
+
+// scanIDs returns the trailing key segment for every key under prefix.
+func (c *RedisCache) scanIDs(prefix string) []string {
+	keys := c.scan(prefix + "*")
+	ids := make([]string, 0, len(keys))
+	for _, k := range keys {
+		ids = append(ids, strings.TrimPrefix(k, prefix))
+	}
+	return ids
+}
+
+func (c *RedisCache) GetGuild(guildID string) (*types.Guild, bool) {
+	var guild types.Guild
+	if !c.load(c.key("guild", guildID), &guild, &c.guilds) {
+		return nil, false
+	}
+	return &guild, true
+}
+
+func (c *RedisCache) SetGuild(guild *types.Guild) {
+	c.store(c.key("guild", guild.ID), guild)
+}
+
+func (c *RedisCache) RemoveGuild(guildID string) {
+	c.remove(c.key("guild", guildID))
+}
+
+func (c *RedisCache) GetChannel(channelID string) (*types.Channel, bool) {
+	var channel types.Channel
+	if !c.load(c.key("channel", channelID), &channel, &c.channels) {
+		return nil, false
+	}
+	return &channel, true
+}
+
+func (c *RedisCache) SetChannel(channel *types.Channel) {
+	c.store(c.key("channel", channel.ID), channel)
+	if channel.GuildID != "" {
+		c.store(c.key("guild-channel", channel.GuildID, channel.ID), channel.ID)
+	}
+}
+
+func (c *RedisCache) RemoveChannel(channelID string) {
+	keys := []string{c.key("channel", channelID)}
+	if channel, ok := c.GetChannel(channelID); ok && channel.GuildID != "" {
+		keys = append(keys, c.key("guild-channel", channel.GuildID, channelID))
+	}
+	c.remove(keys...)
+}
+
+func (c *RedisCache) GuildChannels(guildID string) []*types.Channel {
+	var channels []*types.Channel
+	for _, id := range c.scanIDs(c.key("guild-channel", guildID) + ":") {
+		if channel, ok := c.GetChannel(id); ok {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+func (c *RedisCache) GetMember(guildID, userID string) (*types.Member, bool) {
+	var member types.Member
+	if !c.load(c.key("member", guildID, userID), &member, &c.members) {
+		return nil, false
+	}
+	return &member, true
+}
+
+func (c *RedisCache) SetMember(guildID string, member *types.Member) {
+	c.store(c.key("member", guildID, member.User.ID), member)
+}
+
+func (c *RedisCache) RemoveMember(guildID, userID string) {
+	c.remove(c.key("member", guildID, userID))
+}
+
+func (c *RedisCache) GetRole(guildID, roleID string) (*types.Role, bool) {
+	var role types.Role
+	if !c.load(c.key("role", guildID, roleID), &role, &c.roles) {
+		return nil, false
+	}
+	return &role, true
+}
+
+func (c *RedisCache) SetRole(guildID string, role *types.Role) {
+	c.store(c.key("role", guildID, role.ID), role)
+}
+
+func (c *RedisCache) RemoveRole(guildID, roleID string) {
+	c.remove(c.key("role", guildID, roleID))
+}
+
+func (c *RedisCache) GuildRoles(guildID string) []*types.Role {
+	var roles []*types.Role
+	for _, id := range c.scanIDs(c.key("role", guildID) + ":") {
+		if role, ok := c.GetRole(guildID, id); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func (c *RedisCache) GetVoiceState(guildID, userID string) (*types.VoiceState, bool) {
+	var vs types.VoiceState
+	if !c.load(c.key("voice", guildID, userID), &vs, &c.voice) {
+		return nil, false
+	}
+	return &vs, true
+}
+
+func (c *RedisCache) SetVoiceState(vs *types.VoiceState) {
+	c.store(c.key("voice", vs.GuildID, vs.UserID), vs)
+}
+
+func (c *RedisCache) RemoveVoiceState(guildID, userID string) {
+	c.remove(c.key("voice", guildID, userID))
+}
+
+func (c *RedisCache) GuildVoiceStates(guildID string) []*types.VoiceState {
+	var states []*types.VoiceState
+	for _, id := range c.scanIDs(c.key("voice", guildID) + ":") {
+		if vs, ok := c.GetVoiceState(guildID, id); ok {
+			states = append(states, vs)
+		}
+	}
+	return states
+}
+
+func (c *RedisCache) PurgeGuild(guildID string) {
+	var keys []string
+	for _, id := range c.scanIDs(c.key("guild-channel", guildID) + ":") {
+		keys = append(keys, c.key("channel", id), c.key("guild-channel", guildID, id))
+	}
+	for _, kind := range []string{"member", "role", "voice"} {
+		keys = append(keys, c.scan(c.key(kind, guildID)+":*")...)
+	}
+	c.remove(keys...)
+}
+
+// Stats reports hit/miss counts; sizes and evictions are managed by Redis.
+func (c *RedisCache) Stats() Stats {
+	return Stats{
+		Guilds:      c.guilds.stats(),
+		Channels:    c.channels.stats(),
+		Members:     c.members.stats(),
+		Roles:       c.roles.stats(),
+		VoiceStates: c.voice.stats(),
+	}
+}
@@ -0,0 +1,67 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/redisclient"
+)
+
+func TestRedisCacheSharedBetweenStates(t *testing.T) {
+	backend := redisclient.NewMemory()
+	cacheA, err := NewRedisCache(backend, WithRedisPrefix("bot:"))
+	if err != nil {
+		t.Fatalf("NewRedisCache error: %v", err)
+	}
+	cacheB, _ := NewRedisCache(backend, WithRedisPrefix("bot:"))
+
+	writer := New(WithCache(cacheA))
+	reader := New(WithCache(cacheB))
+
+	writer.SetGuild(&types.Guild{
+		ID:       "g1",
+		Name:     "shared",
+		Channels: []types.Channel{{ID: "c1", Position: 1}, {ID: "c0", Position: 0}},
+		Roles:    []types.Role{{ID: "r1"}},
+		Members:  []types.Member{{User: &types.User{ID: "u1"}, Nick: "nick"}},
+		VoiceStates: []types.VoiceState{
+			{UserID: "u1", ChannelID: "v1"},
+		},
+	})
+
+	if g, ok := reader.Guild("g1"); !ok || g.Name != "shared" {
+		t.Fatalf("expected shared guild, got %+v", g)
+	}
+	if channels := reader.GuildChannels("g1"); len(channels) != 2 || channels[0].ID != "c0" {
+		t.Fatalf("unexpected channels %+v", channels)
+	}
+	if m, ok := reader.Member("g1", "u1"); !ok || m.Nick != "nick" {
+		t.Fatalf("expected shared member")
+	}
+	if len(reader.Roles("g1")) != 1 || len(reader.VoiceStates("g1")) != 1 {
+		t.Fatalf("expected role and voice state to be shared")
+	}
+
+	writer.RemoveChannel("c1")
+	if _, ok := reader.Channel("c1"); ok {
+		t.Fatalf("expected channel removed")
+	}
+
+	writer.RemoveGuild("g1")
+	if _, ok := reader.Guild("g1"); ok {
+		t.Fatalf("expected guild removed")
+	}
+	if keys, _ := backend.Scan(context.Background(), "*"); len(keys) != 0 {
+		t.Fatalf("expected all keys purged, got %v", keys)
+	}
+	if stats := reader.Stats(); stats.Guilds.Hits != 1 || stats.Guilds.Misses != 1 {
+		t.Fatalf("unexpected guild stats %+v", stats.Guilds)
+	}
+}
+
+func TestNewRedisCacheRequiresClient(t *testing.T) {
+	if _, err := NewRedisCache(nil); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
@@ -0,0 +1,294 @@
+// Package state maintains an in-memory view of Discord entities fed by gateway events.
+package state
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// Option configures a State or MemoryCache.
+type Option func(*config)
+
+type config struct {
+	cache       Cache
+	ttl         time.Duration
+	memberTTL   time.Duration
+	maxGuilds   int
+	maxChannels int
+	maxMembers  int
+	maxRoles    int
+	maxVoice    int
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithCache swaps the storage backend. TTL and size options only apply to the
+// default MemoryCache.
+func WithCache(cache Cache) Option {
+	return func(c *config) {
+		if cache != nil {
+			c.cache = cache
+		}
+	}
+}
+
+// WithTTL expires every cached entry after ttl. A ttl <= 0 disables expiration.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.ttl = ttl
+	}
+}
+
+// WithMemberTTL overrides the TTL for members, which churn faster than other entities.
+func WithMemberTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.memberTTL = ttl
+	}
+}
+
+// WithMaxGuilds caps the number of cached guilds (0 = unlimited).
+func WithMaxGuilds(n int) Option {
+	return func(c *config) {
+		if n >= 0 {
+			c.maxGuilds = n
+		}
+	}
+}
+
+// WithMaxChannels caps the number of cached channels and threads (0 = unlimited).
+func WithMaxChannels(n int) Option {
+	return func(c *config) {
+		if n >= 0 {
+			c.maxChannels = n
+		}
+	}
+}
+
+// WithMaxMembers caps the number of cached members across all guilds (0 = unlimited).
+func WithMaxMembers(n int) Option {
+	return func(c *config) {
+		if n >= 0 {
+			c.maxMembers = n
+		}
+	}
+}
+
+// WithMaxRoles caps the number of cached roles across all guilds (0 = unlimited).
+func WithMaxRoles(n int) Option {
+	return func(c *config) {
+		if n >= 0 {
+			c.maxRoles = n
+		}
+	}
+}
+
+// WithMaxVoiceStates caps the number of cached voice states (0 = unlimited).
+func WithMaxVoiceStates(n int) Option {
+	return func(c *config) {
+		if n >= 0 {
+			c.maxVoice = n
+		}
+	}
+}
+
+// State caches guilds, channels, members, roles, and voice states.
+//
+// Guild returns guild metadata only; collections delivered with GUILD_CREATE
+// are split into their own stores and exposed through the dedicated accessors.
+type State struct {
+	cache Cache
+
+	mu   sync.RWMutex
+	user *types.User
+}
+
+// New constructs an empty State backed by a MemoryCache unless WithCache is supplied.
+func New(opts ...Option) *State {
+	cfg := newConfig(opts)
+	cache := cfg.cache
+	if cache == nil {
+		cache = NewMemoryCache(opts...)
+	}
+	return &State{cache: cache}
+}
+
+// Cache exposes the storage backend.
+func (s *State) Cache() Cache {
+	return s.cache
+}
+
+// User returns the current user reported by READY.
+func (s *State) User() (*types.User, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.user, s.user != nil
+}
+
+// Guild returns cached guild metadata.
+func (s *State) Guild(guildID string) (*types.Guild, bool) {
+	return s.cache.GetGuild(guildID)
+}
+
+// Channel returns a cached channel or thread.
+func (s *State) Channel(channelID string) (*types.Channel, bool) {
+	return s.cache.GetChannel(channelID)
+}
+
+// GuildChannels returns cached channels and threads for a guild ordered by position.
+func (s *State) GuildChannels(guildID string) []*types.Channel {
+	channels := s.cache.GuildChannels(guildID)
+	sortChannels(channels)
+	return channels
+}
+
+// Member returns a cached guild member.
+func (s *State) Member(guildID, userID string) (*types.Member, bool) {
+	return s.cache.GetMember(guildID, userID)
+}
+
+// Role returns a cached guild role.
+func (s *State) Role(guildID, roleID string) (*types.Role, bool) {
+	return s.cache.GetRole(guildID, roleID)
+}
+
+// Roles returns cached roles for a guild ordered by position.
+func (s *State) Roles(guildID string) []*types.Role {
+	roles := s.cache.GuildRoles(guildID)
+	sortRoles(roles)
+	return roles
+}
+
+// VoiceState returns a user's cached voice state in a guild.
+func (s *State) VoiceState(guildID, userID string) (*types.VoiceState, bool) {
+	return s.cache.GetVoiceState(guildID, userID)
+}
+
+// VoiceStates returns cached voice states for a guild.
+func (s *State) VoiceStates(guildID string) []*types.VoiceState {
+	return s.cache.GuildVoiceStates(guildID)
+}
+
+// Stats reports per-store cache statistics.
+func (s *State) Stats() Stats {
+	return s.cache.Stats()
+}
+
+// SetGuild stores a guild and any collections it carries.
+func (s *State) SetGuild(guild *types.Guild) {
+	if guild == nil || guild.ID == "" {
+		return
+	}
+
+	for i := range guild.Channels {
+		s.SetChannel(guild.ID, &guild.Channels[i])
+	}
+	for i := range guild.Threads {
+		s.SetChannel(guild.ID, &guild.Threads[i])
+	}
+	if guild.Roles != nil {
+		for _, role := range s.cache.GuildRoles(guild.ID) {
+			s.cache.RemoveRole(guild.ID, role.ID)
+		}
+		for i := range guild.Roles {
+			s.SetRole(guild.ID, &guild.Roles[i])
+		}
+	}
+	for i := range guild.Members {
+		s.SetMember(guild.ID, &guild.Members[i])
+	}
+	for i := range guild.VoiceStates {
+		vs := guild.VoiceStates[i]
+		if vs.GuildID == "" {
+			vs.GuildID = guild.ID
+		}
+		s.SetVoiceState(&vs)
+	}
+
+	meta := *guild
+	meta.Channels = nil
+	meta.Threads = nil
+	meta.Roles = nil
+	meta.Members = nil
+	meta.VoiceStates = nil
+	s.cache.SetGuild(&meta)
+}
+
+// RemoveGuild drops a guild and everything scoped to it.
+func (s *State) RemoveGuild(guildID string) {
+	s.cache.RemoveGuild(guildID)
+	s.cache.PurgeGuild(guildID)
+}
+
+// SetChannel stores a channel, defaulting its guild ID when absent.
+func (s *State) SetChannel(guildID string, channel *types.Channel) {
+	if channel == nil || channel.ID == "" {
+		return
+	}
+	if channel.GuildID == "" {
+		channel.GuildID = guildID
+	}
+	s.cache.SetChannel(channel)
+}
+
+// RemoveChannel drops a channel or thread.
+func (s *State) RemoveChannel(channelID string) {
+	s.cache.RemoveChannel(channelID)
+}
+
+// SetMember stores a guild member.
+func (s *State) SetMember(guildID string, member *types.Member) {
+	if member == nil || member.User == nil || guildID == "" {
+		return
+	}
+	s.cache.SetMember(guildID, member)
+}
+
+// RemoveMember drops a guild member and their voice state.
+func (s *State) RemoveMember(guildID, userID string) {
+	s.cache.RemoveMember(guildID, userID)
+	s.cache.RemoveVoiceState(guildID, userID)
+}
+
+// SetRole stores a guild role.
+func (s *State) SetRole(guildID string, role *types.Role) {
+	if role == nil || role.ID == "" || guildID == "" {
+		return
+	}
+	s.cache.SetRole(guildID, role)
+}
+
+// RemoveRole drops a guild role.
+func (s *State) RemoveRole(guildID, roleID string) {
+	s.cache.RemoveRole(guildID, roleID)
+}
+
+// SetVoiceState stores a voice state; states without a channel are removed.
+func (s *State) SetVoiceState(vs *types.VoiceState) {
+	if vs == nil || vs.GuildID == "" || vs.UserID == "" {
+		return
+	}
+	if vs.ChannelID == "" {
+		s.cache.RemoveVoiceState(vs.GuildID, vs.UserID)
+		return
+	}
+	s.cache.SetVoiceState(vs)
+	if vs.Member != nil {
+		s.SetMember(vs.GuildID, vs.Member)
+	}
+}
+
+func (s *State) setUser(user *types.User) {
+	s.mu.Lock()
+	s.user = user
+	s.mu.Unlock()
+}
@@ -0,0 +1,126 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestStateFollowsGatewayEvents(t *testing.T) {
+	st := New()
+	d := gateway.NewDispatcher()
+	st.Register(d)
+
+	guild := &types.Guild{
+		ID:   "g1",
+		Name: "guild",
+		Channels: []types.Channel{
+			{ID: "c2", Name: "second", Position: 2},
+			{ID: "c1", Name: "first", Position: 1},
+		},
+		Roles:       []types.Role{{ID: "r1", Name: "everyone"}},
+		Members:     []types.Member{{User: &types.User{ID: "u1"}}},
+		VoiceStates: []types.VoiceState{{UserID: "u1", ChannelID: "v1"}},
+	}
+	mustDispatch(t, d, &gateway.GuildCreateEvent{Guild: guild})
+
+	got, ok := st.Guild("g1")
+	if !ok || got.Name != "guild" {
+		t.Fatalf("expected cached guild, got %+v", got)
+	}
+	if got.Channels != nil || got.Members != nil {
+		t.Fatalf("expected guild metadata without collections")
+	}
+	channels := st.GuildChannels("g1")
+	if len(channels) != 2 || channels[0].ID != "c1" || channels[0].GuildID != "g1" {
+		t.Fatalf("unexpected channels %+v", channels)
+	}
+	if _, ok := st.Member("g1", "u1"); !ok {
+		t.Fatalf("expected member from GUILD_CREATE")
+	}
+	if vs, ok := st.VoiceState("g1", "u1"); !ok || vs.ChannelID != "v1" {
+		t.Fatalf("expected voice state from GUILD_CREATE")
+	}
+
+	mustDispatch(t, d, &gateway.GuildRoleCreateEvent{GuildID: "g1", Role: &types.Role{ID: "r2", Position: 1}})
+	if roles := st.Roles("g1"); len(roles) != 2 {
+		t.Fatalf("expected 2 roles, got %d", len(roles))
+	}
+	mustDispatch(t, d, &gateway.GuildRoleDeleteEvent{GuildID: "g1", RoleID: "r2"})
+	if _, ok := st.Role("g1", "r2"); ok {
+		t.Fatalf("expected role removed")
+	}
+
+	mustDispatch(t, d, &gateway.GuildMemberAddEvent{GuildID: "g1", Member: &types.Member{User: &types.User{ID: "u2"}, Nick: "new"}})
+	if m, ok := st.Member("g1", "u2"); !ok || m.Nick != "new" {
+		t.Fatalf("expected member added")
+	}
+	mustDispatch(t, d, &gateway.GuildMemberRemoveEvent{GuildID: "g1", User: &types.User{ID: "u2"}})
+	if _, ok := st.Member("g1", "u2"); ok {
+		t.Fatalf("expected member removed")
+	}
+
+	mustDispatch(t, d, &gateway.VoiceStateUpdateEvent{VoiceState: &types.VoiceState{GuildID: "g1", UserID: "u1"}})
+	if _, ok := st.VoiceState("g1", "u1"); ok {
+		t.Fatalf("expected voice state cleared on disconnect")
+	}
+
+	mustDispatch(t, d, &gateway.ChannelDeleteEvent{Channel: &types.Channel{ID: "c2", GuildID: "g1"}})
+	if _, ok := st.Channel("c2"); ok {
+		t.Fatalf("expected channel removed")
+	}
+
+	mustDispatch(t, d, &gateway.GuildDeleteEvent{GuildID: "g1"})
+	if _, ok := st.Guild("g1"); ok {
+		t.Fatalf("expected guild removed")
+	}
+	if len(st.GuildChannels("g1")) != 0 || len(st.Roles("g1")) != 0 {
+		t.Fatalf("expected guild-scoped entries removed")
+	}
+	if _, ok := st.Member("g1", "u1"); ok {
+		t.Fatalf("expected guild members removed")
+	}
+}
+
+func TestStateReadyUser(t *testing.T) {
+	st := New()
+	d := gateway.NewDispatcher()
+	st.Register(d)
+
+	mustDispatch(t, d, &gateway.ReadyEvent{User: &types.User{ID: "bot"}})
+	if user, ok := st.User(); !ok || user.ID != "bot" {
+		t.Fatalf("expected current user, got %+v", user)
+	}
+}
+
+func TestStateLimitsAndTTL(t *testing.T) {
+	st := New(WithMaxGuilds(1), WithMemberTTL(10*time.Millisecond))
+
+	st.SetGuild(&types.Guild{ID: "g1"})
+	st.SetGuild(&types.Guild{ID: "g2"})
+	if _, ok := st.Guild("g1"); ok {
+		t.Fatalf("expected oldest guild evicted")
+	}
+	if stats := st.Stats(); stats.Guilds.Evictions != 1 || stats.Guilds.Size != 1 {
+		t.Fatalf("unexpected guild stats %+v", stats.Guilds)
+	}
+
+	st.SetMember("g2", &types.Member{User: &types.User{ID: "u1"}})
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := st.Member("g2", "u1"); ok {
+		t.Fatalf("expected member to expire")
+	}
+	if _, ok := st.Guild("g2"); !ok {
+		t.Fatalf("expected guild to outlive member TTL")
+	}
+}
+
+func mustDispatch(t *testing.T, d *gateway.Dispatcher, event gateway.Event) {
+	t.Helper()
+	if err := d.Dispatch(context.Background(), event); err != nil {
+		t.Fatalf("dispatch %s: %v", event.Type(), err)
+	}
+}
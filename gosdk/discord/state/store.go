@@ -0,0 +1,138 @@
+package state
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// StoreStats exposes hit/miss/eviction totals for a single store.
+type StoreStats struct {
+	Size      int   `json:"size"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+}
+
+type storeEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// store is a thread-safe map with optional TTL and LRU size limit.
+type store[K comparable, V any] struct {
+	ttl   time.Duration
+	limit int
+	items map[K]*list.Element
+	order *list.List
+	stats StoreStats
+	mu    sync.Mutex
+}
+
+func newStore[K comparable, V any](ttl time.Duration, limit int) *store[K, V] {
+	return &store[K, V]{
+		ttl:   ttl,
+		limit: limit,
+		items: make(map[K]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (s *store[K, V]) get(key K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero V
+	el, ok := s.items[key]
+	if !ok {
+		s.stats.Misses++
+		return zero, false
+	}
+	ent := el.Value.(*storeEntry[K, V])
+	if s.expired(ent) {
+		s.removeElement(el)
+		s.stats.Misses++
+		return zero, false
+	}
+	s.order.MoveToFront(el)
+	s.stats.Hits++
+	return ent.value, true
+}
+
+func (s *store[K, V]) set(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expires time.Time
+	if s.ttl > 0 {
+		expires = time.Now().Add(s.ttl)
+	}
+
+	if el, ok := s.items[key]; ok {
+		ent := el.Value.(*storeEntry[K, V])
+		ent.value = value
+		ent.expires = expires
+		s.order.MoveToFront(el)
+		return
+	}
+
+	s.items[key] = s.order.PushFront(&storeEntry[K, V]{key: key, value: value, expires: expires})
+	if s.limit > 0 && s.order.Len() > s.limit {
+		s.removeElement(s.order.Back())
+		s.stats.Evictions++
+	}
+}
+
+func (s *store[K, V]) delete(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.items[key]; ok {
+		s.removeElement(el)
+	}
+}
+
+// deleteFunc removes every entry for which fn returns true.
+func (s *store[K, V]) deleteFunc(fn func(K, V) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, el := range s.items {
+		if fn(key, el.Value.(*storeEntry[K, V]).value) {
+			s.removeElement(el)
+		}
+	}
+}
+
+// values returns live entries that match fn.
+func (s *store[K, V]) values(fn func(K, V) bool) []V {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []V
+	for _, el := range s.items {
+		ent := el.Value.(*storeEntry[K, V])
+		if s.expired(ent) {
+			continue
+		}
+		if fn(ent.key, ent.value) {
+			out = append(out, ent.value)
+		}
+	}
+	return out
+}
+
+func (s *store[K, V]) snapshot() StoreStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	stats.Size = len(s.items)
+	return stats
+}
+
+func (s *store[K, V]) expired(ent *storeEntry[K, V]) bool {
+	return !ent.expires.IsZero() && time.Now().After(ent.expires)
+}
+
+func (s *store[K, V]) removeElement(el *list.Element) {
+	ent := s.order.Remove(el).(*storeEntry[K, V])
+	delete(s.items, ent.key)
+}
@@ -0,0 +1,159 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// ApplicationFlags describes an application's capabilities and approvals.
+type ApplicationFlags int
+
+const (
+	ApplicationFlagAutoModerationRuleCreateBadge ApplicationFlags = 1 << 6
+	ApplicationFlagGatewayPresence               ApplicationFlags = 1 << 12
+	ApplicationFlagGatewayPresenceLimited        ApplicationFlags = 1 << 13
+	ApplicationFlagGatewayGuildMembers           ApplicationFlags = 1 << 14
+	ApplicationFlagGatewayGuildMembersLimited    ApplicationFlags = 1 << 15
+	ApplicationFlagVerificationPendingGuildLimit ApplicationFlags = 1 << 16
+	ApplicationFlagEmbedded                      ApplicationFlags = 1 << 17
+	ApplicationFlagGatewayMessageContent         ApplicationFlags = 1 << 18
+	ApplicationFlagGatewayMessageContentLimited  ApplicationFlags = 1 << 19
+	ApplicationFlagApplicationCommandBadge       ApplicationFlags = 1 << 23
+)
+
+// ApplicationFlagsEditable are the flags EditCurrentApplication may change:
+// the "limited" privileged intents unverified apps enable themselves.
+const ApplicationFlagsEditable = ApplicationFlagGatewayPresenceLimited |
+	ApplicationFlagGatewayGuildMembersLimited |
+	ApplicationFlagGatewayMessageContentLimited
+
+// Has reports whether every flag in flag is set.
+func (f ApplicationFlags) Has(flag ApplicationFlags) bool {
+	return f&flag == flag
+}
+
+// Application is a Discord application (the bot's owning app).
+type Application struct {
+	ID                             string           `json:"id"`
+	Name                           string           `json:"name"`
+	Icon                           string           `json:"icon,omitempty"`
+	Description                    string           `json:"description"`
+	RPCOrigins                     []string         `json:"rpc_origins,omitempty"`
+	BotPublic                      bool             `json:"bot_public"`
+	BotRequireCodeGrant            bool             `json:"bot_require_code_grant"`
+	Bot                            *User            `json:"bot,omitempty"`
+	TermsOfServiceURL              string           `json:"terms_of_service_url,omitempty"`
+	PrivacyPolicyURL               string           `json:"privacy_policy_url,omitempty"`
+	Owner                          *User            `json:"owner,omitempty"`
+	VerifyKey                      string           `json:"verify_key"`
+	Team                           *Team            `json:"team,omitempty"`
+	GuildID                        string           `json:"guild_id,omitempty"`
+	CoverImage                     string           `json:"cover_image,omitempty"`
+	Flags                          ApplicationFlags `json:"flags,omitempty"`
+	ApproximateGuildCount          int              `json:"approximate_guild_count,omitempty"`
+	RedirectURIs                   []string         `json:"redirect_uris,omitempty"`
+	InteractionsEndpointURL        string           `json:"interactions_endpoint_url,omitempty"`
+	RoleConnectionsVerificationURL string           `json:"role_connections_verification_url,omitempty"`
+	Tags                           []string         `json:"tags,omitempty"`
+	InstallParams                  *InstallParams   `json:"install_params,omitempty"`
+	CustomInstallURL               string           `json:"custom_install_url,omitempty"`
+}
+
+// InstallParams are the default scopes and permissions for adding the app
+// to a guild.
+type InstallParams struct {
+	Scopes      []string `json:"scopes"`
+	Permissions string   `json:"permissions"`
+}
+
+// Team owns an application on behalf of several developers.
+type Team struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Icon        string       `json:"icon,omitempty"`
+	OwnerUserID string       `json:"owner_user_id"`
+	Members     []TeamMember `json:"members"`
+}
+
+// TeamMembershipState reports whether a team invite was accepted.
+type TeamMembershipState int
+
+const (
+	TeamMembershipInvited  TeamMembershipState = 1
+	TeamMembershipAccepted TeamMembershipState = 2
+)
+
+// Team member roles.
+const (
+	TeamRoleAdmin     = "admin"
+	TeamRoleDeveloper = "developer"
+	TeamRoleReadOnly  = "read_only"
+)
+
+// TeamMember is one developer on a team.
+type TeamMember struct {
+	MembershipState TeamMembershipState `json:"membership_state"`
+	TeamID          string              `json:"team_id"`
+	User            User                `json:"user"`
+	Role            string              `json:"role"`
+}
+
+// ApplicationEditParams updates the current application. Nil fields are
+// left unchanged; set a pointer to "" to clear a URL.
+type ApplicationEditParams struct {
+	Description                    *string           `json:"description,omitempty"`
+	InteractionsEndpointURL        *string           `json:"interactions_endpoint_url,omitempty"`
+	RoleConnectionsVerificationURL *string           `json:"role_connections_verification_url,omitempty"`
+	CustomInstallURL               *string           `json:"custom_install_url,omitempty"`
+	InstallParams                  *InstallParams    `json:"install_params,omitempty"`
+	Flags                          *ApplicationFlags `json:"flags,omitempty"`
+	Icon                           *string           `json:"icon,omitempty"`
+	CoverImage                     *string           `json:"cover_image,omitempty"`
+	Tags                           *[]string         `json:"tags,omitempty"`
+}
+
+// Validate ensures application edits are within Discord's limits.
+func (p *ApplicationEditParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "application edit params required"}
+	}
+	if p.Description != nil && len(*p.Description) > limits.ApplicationDescriptionLength {
+		return &ValidationError{Field: "description", Message: fmt.Sprintf("description cannot exceed %d characters", limits.ApplicationDescriptionLength)}
+	}
+	urls := []struct {
+		field string
+		value *string
+	}{
+		{"interactions_endpoint_url", p.InteractionsEndpointURL},
+		{"role_connections_verification_url", p.RoleConnectionsVerificationURL},
+		{"custom_install_url", p.CustomInstallURL},
+	}
+	for _, u := range urls {
+		if u.value == nil || *u.value == "" {
+			continue
+		}
+		parsed, err := url.Parse(*u.value)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return &ValidationError{Field: u.field, Message: "must be an absolute https URL"}
+		}
+	}
+	if p.Flags != nil && *p.Flags&^ApplicationFlagsEditable != 0 {
+		return &ValidationError{Field: "flags", Message: "only the limited gateway intent flags can be edited"}
+	}
+	if p.InstallParams != nil && len(p.InstallParams.Scopes) == 0 {
+		return &ValidationError{Field: "install_params.scopes", Message: "install params need at least one scope"}
+	}
+	if p.Tags != nil {
+		if len(*p.Tags) > limits.ApplicationTags {
+			return &ValidationError{Field: "tags", Message: fmt.Sprintf("cannot exceed %d tags", limits.ApplicationTags)}
+		}
+		for _, tag := range *p.Tags {
+			if tag == "" || len(tag) > limits.ApplicationTagLength {
+				return &ValidationError{Field: "tags", Message: fmt.Sprintf("tags must be between 1 and %d characters", limits.ApplicationTagLength)}
+			}
+		}
+	}
+	return nil
+}
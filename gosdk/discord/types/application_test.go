@@ -0,0 +1,39 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplicationEditParamsValidate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	flags := func(f ApplicationFlags) *ApplicationFlags { return &f }
+	tags := func(t ...string) *[]string { return &t }
+
+	valid := &ApplicationEditParams{
+		Description:             str("moderation bot"),
+		InteractionsEndpointURL: str("https://bot.example.com/interactions"),
+		Flags:                   flags(ApplicationFlagGatewayMessageContentLimited),
+		Tags:                    tags("moderation", "utility"),
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid params, got %v", err)
+	}
+	if err := (&ApplicationEditParams{InteractionsEndpointURL: str("")}).Validate(); err != nil {
+		t.Fatalf("clearing the endpoint should be allowed: %v", err)
+	}
+
+	cases := map[string]*ApplicationEditParams{
+		"description": {Description: str(strings.Repeat("a", 401))},
+		"http url":    {InteractionsEndpointURL: str("http://bot.example.com")},
+		"flags":       {Flags: flags(ApplicationFlagGatewayPresence)},
+		"tag count":   {Tags: tags("a", "b", "c", "d", "e", "f")},
+		"tag length":  {Tags: tags(strings.Repeat("t", 21))},
+		"scopes":      {InstallParams: &InstallParams{Permissions: "8"}},
+	}
+	for name, params := range cases {
+		if err := params.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileAttachment is a file uploaded with a message or interaction response.
+type FileAttachment struct {
+	// Name is the filename shown in Discord (e.g. "report.txt").
+	Name string
+	// ContentType is the MIME type; empty uses application/octet-stream.
+	ContentType string
+	// Reader provides the file content.
+	Reader io.Reader
+}
+
+// AttachmentURLExpiry returns when a signed Discord CDN attachment URL stops
+// working, read from its hex-encoded "ex" query parameter. ok is false for
+// URLs that don't carry an expiry (unsigned or non-CDN links).
+func AttachmentURLExpiry(rawURL string) (expiry time.Time, ok bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+	ex := u.Query().Get("ex")
+	if ex == "" {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseInt(ex, 16, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// AttachmentURLExpired reports whether rawURL has expired, or will within
+// margin of now. URLs without an expiry never expire.
+func AttachmentURLExpired(rawURL string, now time.Time, margin time.Duration) bool {
+	expiry, ok := AttachmentURLExpiry(rawURL)
+	return ok && !expiry.After(now.Add(margin))
+}
+
+// AttachmentIDFromURL extracts the attachment ID from a CDN or media proxy
+// URL of the form .../attachments/{channel_id}/{attachment_id}/{filename}.
+func AttachmentIDFromURL(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment == "attachments" && i+3 < len(segments) {
+			return segments[i+2], true
+		}
+	}
+	return "", false
+}
+
+// ExpiresAt returns when the attachment's URL expires; see AttachmentURLExpiry.
+func (a *Attachment) ExpiresAt() (time.Time, bool) {
+	return AttachmentURLExpiry(a.URL)
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAttachmentURLExpiry(t *testing.T) {
+	url := "https://cdn.discordapp.com/attachments/1/2/a.png?ex=65f1a2b3&is=65f05133&hm=deadbeef"
+	expiry, ok := AttachmentURLExpiry(url)
+	if !ok || expiry.Unix() != 0x65f1a2b3 {
+		t.Fatalf("AttachmentURLExpiry() = %v, %v", expiry, ok)
+	}
+	a := &Attachment{URL: url}
+	if got, _ := a.ExpiresAt(); !got.Equal(expiry) {
+		t.Fatalf("ExpiresAt() = %v", got)
+	}
+
+	if _, ok := AttachmentURLExpiry("https://example.com/a.png"); ok {
+		t.Fatal("expected no expiry for unsigned URL")
+	}
+	if _, ok := AttachmentURLExpiry("https://cdn.discordapp.com/a.png?ex=zz"); ok {
+		t.Fatal("expected no expiry for malformed ex")
+	}
+
+	if !AttachmentURLExpired(url, expiry, 0) {
+		t.Fatal("URL should be expired at its expiry time")
+	}
+	if AttachmentURLExpired(url, expiry.Add(-time.Hour), time.Minute) {
+		t.Fatal("URL should be valid an hour before expiry")
+	}
+	if !AttachmentURLExpired(url, expiry.Add(-30*time.Second), time.Minute) {
+		t.Fatal("URL within the margin should count as expired")
+	}
+	if AttachmentURLExpired("https://example.com/a.png", time.Now(), 0) {
+		t.Fatal("URLs without expiry never expire")
+	}
+}
+
+func TestAttachmentIDFromURL(t *testing.T) {
+	tests := []struct {
+		url    string
+		want   string
+		wantOK bool
+	}{
+		{"https://cdn.discordapp.com/attachments/1/2/a.png?ex=1", "2", true},
+		{"https://media.discordapp.net/attachments/1/2/a.png", "2", true},
+		{"https://cdn.discordapp.com/avatars/1/abc.png", "", false},
+		{"https://cdn.discordapp.com/attachments/1/2", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := AttachmentIDFromURL(tt.url)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("AttachmentIDFromURL(%q) = %q, %v", tt.url, got, ok)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// AuditLogEvent identifies the action an audit log entry records.
+type AuditLogEvent int
+
+const (
+	AuditLogGuildUpdate                            AuditLogEvent = 1
+	AuditLogChannelCreate                          AuditLogEvent = 10
+	AuditLogChannelUpdate                          AuditLogEvent = 11
+	AuditLogChannelDelete                          AuditLogEvent = 12
+	AuditLogChannelOverwriteCreate                 AuditLogEvent = 13
+	AuditLogChannelOverwriteUpdate                 AuditLogEvent = 14
+	AuditLogChannelOverwriteDelete                 AuditLogEvent = 15
+	AuditLogMemberKick                             AuditLogEvent = 20
+	AuditLogMemberPrune                            AuditLogEvent = 21
+	AuditLogMemberBanAdd                           AuditLogEvent = 22
+	AuditLogMemberBanRemove                        AuditLogEvent = 23
+	AuditLogMemberUpdate                           AuditLogEvent = 24
+	AuditLogMemberRoleUpdate                       AuditLogEvent = 25
+	AuditLogMemberMove                             AuditLogEvent = 26
+	AuditLogMemberDisconnect                       AuditLogEvent = 27
+	AuditLogBotAdd                                 AuditLogEvent = 28
+	AuditLogRoleCreate                             AuditLogEvent = 30
+	AuditLogRoleUpdate                             AuditLogEvent = 31
+	AuditLogRoleDelete                             AuditLogEvent = 32
+	AuditLogInviteCreate                           AuditLogEvent = 40
+	AuditLogInviteUpdate                           AuditLogEvent = 41
+	AuditLogInviteDelete                           AuditLogEvent = 42
+	AuditLogWebhookCreate                          AuditLogEvent = 50
+	AuditLogWebhookUpdate                          AuditLogEvent = 51
+	AuditLogWebhookDelete                          AuditLogEvent = 52
+	AuditLogEmojiCreate                            AuditLogEvent = 60
+	AuditLogEmojiUpdate                            AuditLogEvent = 61
+	AuditLogEmojiDelete                            AuditLogEvent = 62
+	AuditLogMessageDelete                          AuditLogEvent = 72
+	AuditLogMessageBulkDelete                      AuditLogEvent = 73
+	AuditLogMessagePin                             AuditLogEvent = 74
+	AuditLogMessageUnpin                           AuditLogEvent = 75
+	AuditLogIntegrationCreate                      AuditLogEvent = 80
+	AuditLogIntegrationUpdate                      AuditLogEvent = 81
+	AuditLogIntegrationDelete                      AuditLogEvent = 82
+	AuditLogStageInstanceCreate                    AuditLogEvent = 83
+	AuditLogStageInstanceUpdate                    AuditLogEvent = 84
+	AuditLogStageInstanceDelete                    AuditLogEvent = 85
+	AuditLogStickerCreate                          AuditLogEvent = 90
+	AuditLogStickerUpdate                          AuditLogEvent = 91
+	AuditLogStickerDelete                          AuditLogEvent = 92
+	AuditLogGuildScheduledEventCreate              AuditLogEvent = 100
+	AuditLogGuildScheduledEventUpdate              AuditLogEvent = 101
+	AuditLogGuildScheduledEventDelete              AuditLogEvent = 102
+	AuditLogThreadCreate                           AuditLogEvent = 110
+	AuditLogThreadUpdate                           AuditLogEvent = 111
+	AuditLogThreadDelete                           AuditLogEvent = 112
+	AuditLogApplicationCommandPermissionUpdate     AuditLogEvent = 121
+	AuditLogSoundboardSoundCreate                  AuditLogEvent = 130
+	AuditLogSoundboardSoundUpdate                  AuditLogEvent = 131
+	AuditLogSoundboardSoundDelete                  AuditLogEvent = 132
+	AuditLogAutoModerationRuleCreate               AuditLogEvent = 140
+	AuditLogAutoModerationRuleUpdate               AuditLogEvent = 141
+	AuditLogAutoModerationRuleDelete               AuditLogEvent = 142
+	AuditLogAutoModerationBlockMessage             AuditLogEvent = 143
+	AuditLogAutoModerationFlagToChannel            AuditLogEvent = 144
+	AuditLogAutoModerationUserCommunicationDisable AuditLogEvent = 145
+	AuditLogCreatorMonetizationRequestCreated      AuditLogEvent = 150
+	AuditLogCreatorMonetizationTermsAccepted       AuditLogEvent = 151
+	AuditLogOnboardingPromptCreate                 AuditLogEvent = 163
+	AuditLogOnboardingPromptUpdate                 AuditLogEvent = 164
+	AuditLogOnboardingPromptDelete                 AuditLogEvent = 165
+	AuditLogOnboardingCreate                       AuditLogEvent = 166
+	AuditLogOnboardingUpdate                       AuditLogEvent = 167
+	AuditLogHomeSettingsCreate                     AuditLogEvent = 190
+	AuditLogHomeSettingsUpdate                     AuditLogEvent = 191
+)
+
+// AuditLog is a page of a guild's audit log, along with the users, threads,
+// and commands its entries reference.
+type AuditLog struct {
+	Entries             []AuditLogEntry      `json:"audit_log_entries"`
+	Users               []User               `json:"users,omitempty"`
+	Threads             []Channel            `json:"threads,omitempty"`
+	ApplicationCommands []ApplicationCommand `json:"application_commands,omitempty"`
+}
+
+// User returns the referenced user with the given ID, or nil.
+func (l *AuditLog) User(id string) *User {
+	if l == nil {
+		return nil
+	}
+	for i := range l.Users {
+		if l.Users[i].ID == id {
+			return &l.Users[i]
+		}
+	}
+	return nil
+}
+
+// AuditLogEntry records a single administrative action.
+type AuditLogEntry struct {
+	ID         string                `json:"id"`
+	ActionType AuditLogEvent         `json:"action_type"`
+	UserID     string                `json:"user_id,omitempty"`
+	TargetID   string                `json:"target_id,omitempty"`
+	Changes    []AuditLogChange      `json:"changes,omitempty"`
+	Options    *AuditLogEntryOptions `json:"options,omitempty"`
+	Reason     string                `json:"reason,omitempty"`
+}
+
+// AuditLogChange is one field changed by an audit logged action. Values are
+// left raw since their type depends on Key.
+type AuditLogChange struct {
+	Key      string          `json:"key"`
+	NewValue json.RawMessage `json:"new_value,omitempty"`
+	OldValue json.RawMessage `json:"old_value,omitempty"`
+}
+
+// Change returns the change to key, or nil when the entry did not touch it.
+func (e *AuditLogEntry) Change(key string) *AuditLogChange {
+	for i := range e.Changes {
+		if e.Changes[i].Key == key {
+			return &e.Changes[i]
+		}
+	}
+	return nil
+}
+
+// DecodeOld unmarshals the value before the change into v.
+func (c *AuditLogChange) DecodeOld(v any) error {
+	return decodeAuditLogValue(c.OldValue, v)
+}
+
+// DecodeNew unmarshals the value after the change into v.
+func (c *AuditLogChange) DecodeNew(v any) error {
+	return decodeAuditLogValue(c.NewValue, v)
+}
+
+func decodeAuditLogValue(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// AuditLogEntryOptions carries extra context for some action types.
+type AuditLogEntryOptions struct {
+	ApplicationID                 string `json:"application_id,omitempty"`
+	AutoModerationRuleName        string `json:"auto_moderation_rule_name,omitempty"`
+	AutoModerationRuleTriggerType string `json:"auto_moderation_rule_trigger_type,omitempty"`
+	ChannelID                     string `json:"channel_id,omitempty"`
+	Count                         string `json:"count,omitempty"`
+	DeleteMemberDays              string `json:"delete_member_days,omitempty"`
+	ID                            string `json:"id,omitempty"`
+	MembersRemoved                string `json:"members_removed,omitempty"`
+	MessageID                     string `json:"message_id,omitempty"`
+	RoleName                      string `json:"role_name,omitempty"`
+	Type                          string `json:"type,omitempty"`
+	IntegrationType               string `json:"integration_type,omitempty"`
+}
+
+// AuditLogParams filters and pages through the audit log. UserID and
+// ActionType narrow the results to one moderator or one kind of action.
+// Before returns entries older than the given entry ID, newest first; After
+// returns the entries that directly follow it.
+type AuditLogParams struct {
+	UserID     string
+	ActionType AuditLogEvent
+	Before     string
+	After      string
+	Limit      int
+}
+
+// Validate ensures audit log query parameters are within Discord's limits.
+func (p *AuditLogParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Before != "" && p.After != "" {
+		return &ValidationError{Field: "before/after", Message: "use either before or after, not both"}
+	}
+	if p.ActionType < 0 {
+		return &ValidationError{Field: "action_type", Message: "action type must not be negative"}
+	}
+	if p.Limit < 0 || p.Limit > limits.AuditLogEntriesPerPage {
+		return &ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 0 and %d", limits.AuditLogEntriesPerPage)}
+	}
+	return nil
+}
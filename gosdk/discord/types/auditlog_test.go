@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAuditLogHelpers(t *testing.T) {
+	var log AuditLog
+	payload := `{"audit_log_entries":[{"id":"1","action_type":24,"user_id":"7","changes":[{"key":"nick","old_value":"a","new_value":"b"},{"key":"mute","new_value":true}]}],"users":[{"id":"7","username":"mod"}],"threads":[{"id":"9","type":11}]}`
+	if err := json.Unmarshal([]byte(payload), &log); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if user := log.User("7"); user == nil || user.Username != "mod" {
+		t.Fatalf("User(7) = %+v", user)
+	}
+	if log.User("8") != nil || (*AuditLog)(nil).User("7") != nil {
+		t.Fatal("expected nil for unknown users")
+	}
+	if len(log.Threads) != 1 {
+		t.Fatalf("expected threads, got %+v", log.Threads)
+	}
+
+	entry := log.Entries[0]
+	var oldNick, newNick string
+	nick := entry.Change("nick")
+	if nick == nil || nick.DecodeOld(&oldNick) != nil || nick.DecodeNew(&newNick) != nil || oldNick != "a" || newNick != "b" {
+		t.Fatalf("nick change = %q -> %q", oldNick, newNick)
+	}
+	var oldMute, newMute bool
+	mute := entry.Change("mute")
+	if err := mute.DecodeOld(&oldMute); err != nil || oldMute {
+		t.Fatalf("missing old value should leave v alone, got %v (%v)", oldMute, err)
+	}
+	if err := mute.DecodeNew(&newMute); err != nil || !newMute {
+		t.Fatalf("mute new value = %v (%v)", newMute, err)
+	}
+	if entry.Change("deaf") != nil {
+		t.Fatal("expected nil for an untouched key")
+	}
+}
+
+func TestAuditLogParamsValidate(t *testing.T) {
+	valid := []*AuditLogParams{nil, {}, {UserID: "1", ActionType: AuditLogMemberKick, Before: "5", Limit: 100}}
+	for _, p := range valid {
+		if err := p.Validate(); err != nil {
+			t.Fatalf("Validate(%+v) = %v", p, err)
+		}
+	}
+	invalid := []*AuditLogParams{{Limit: 101}, {Before: "5", After: "1"}, {ActionType: -1}}
+	for _, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Fatalf("expected error for %+v", p)
+		}
+	}
+}
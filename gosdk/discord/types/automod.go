@@ -0,0 +1,263 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// AutoModerationEventType is the event that triggers a rule check.
+type AutoModerationEventType int
+
+const (
+	// AutoModerationEventMessageSend checks messages as they are sent or edited.
+	AutoModerationEventMessageSend AutoModerationEventType = 1
+	// AutoModerationEventMemberUpdate checks members as they join or edit their profile.
+	AutoModerationEventMemberUpdate AutoModerationEventType = 2
+)
+
+// AutoModerationTriggerType is the kind of content a rule looks for.
+type AutoModerationTriggerType int
+
+const (
+	AutoModerationTriggerKeyword       AutoModerationTriggerType = 1
+	AutoModerationTriggerSpam          AutoModerationTriggerType = 3
+	AutoModerationTriggerKeywordPreset AutoModerationTriggerType = 4
+	AutoModerationTriggerMentionSpam   AutoModerationTriggerType = 5
+	AutoModerationTriggerMemberProfile AutoModerationTriggerType = 6
+)
+
+// AutoModerationKeywordPreset selects one of Discord's word lists.
+type AutoModerationKeywordPreset int
+
+const (
+	AutoModerationPresetProfanity     AutoModerationKeywordPreset = 1
+	AutoModerationPresetSexualContent AutoModerationKeywordPreset = 2
+	AutoModerationPresetSlurs         AutoModerationKeywordPreset = 3
+)
+
+// AutoModerationActionType is what happens when a rule triggers.
+type AutoModerationActionType int
+
+const (
+	AutoModerationActionBlockMessage           AutoModerationActionType = 1
+	AutoModerationActionSendAlertMessage       AutoModerationActionType = 2
+	AutoModerationActionTimeout                AutoModerationActionType = 3
+	AutoModerationActionBlockMemberInteraction AutoModerationActionType = 4
+)
+
+// AutoModerationRule is a guild's auto moderation rule.
+type AutoModerationRule struct {
+	ID              string                         `json:"id"`
+	GuildID         string                         `json:"guild_id"`
+	Name            string                         `json:"name"`
+	CreatorID       string                         `json:"creator_id"`
+	EventType       AutoModerationEventType        `json:"event_type"`
+	TriggerType     AutoModerationTriggerType      `json:"trigger_type"`
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Actions         []AutoModerationAction         `json:"actions"`
+	Enabled         bool                           `json:"enabled"`
+	ExemptRoles     []string                       `json:"exempt_roles,omitempty"`
+	ExemptChannels  []string                       `json:"exempt_channels,omitempty"`
+}
+
+// AutoModerationTriggerMetadata configures a rule's trigger. Which fields
+// apply depends on the trigger type.
+type AutoModerationTriggerMetadata struct {
+	KeywordFilter                []string                      `json:"keyword_filter,omitempty"`
+	RegexPatterns                []string                      `json:"regex_patterns,omitempty"`
+	Presets                      []AutoModerationKeywordPreset `json:"presets,omitempty"`
+	AllowList                    []string                      `json:"allow_list,omitempty"`
+	MentionTotalLimit            int                           `json:"mention_total_limit,omitempty"`
+	MentionRaidProtectionEnabled bool                          `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+// AutoModerationAction is one action a rule takes.
+type AutoModerationAction struct {
+	Type     AutoModerationActionType      `json:"type"`
+	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
+}
+
+// AutoModerationActionMetadata configures an action. ChannelID is used by
+// alert messages, DurationSeconds by timeouts, and CustomMessage by blocks.
+type AutoModerationActionMetadata struct {
+	ChannelID       string `json:"channel_id,omitempty"`
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+	CustomMessage   string `json:"custom_message,omitempty"`
+}
+
+// AutoModerationRuleCreateParams is the payload for creating a rule.
+type AutoModerationRuleCreateParams struct {
+	Name            string                         `json:"name"`
+	EventType       AutoModerationEventType        `json:"event_type"`
+	TriggerType     AutoModerationTriggerType      `json:"trigger_type"`
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Actions         []AutoModerationAction         `json:"actions"`
+	Enabled         bool                           `json:"enabled,omitempty"`
+	ExemptRoles     []string                       `json:"exempt_roles,omitempty"`
+	ExemptChannels  []string                       `json:"exempt_channels,omitempty"`
+	AuditLogReason  string                         `json:"-"`
+}
+
+// AutoModerationRuleModifyParams is the payload for updating a rule. The
+// trigger type cannot be changed; nil fields are left as they are.
+type AutoModerationRuleModifyParams struct {
+	Name            string                         `json:"name,omitempty"`
+	EventType       AutoModerationEventType        `json:"event_type,omitempty"`
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Actions         []AutoModerationAction         `json:"actions,omitempty"`
+	Enabled         *bool                          `json:"enabled,omitempty"`
+	ExemptRoles     *[]string                      `json:"exempt_roles,omitempty"`
+	ExemptChannels  *[]string                      `json:"exempt_channels,omitempty"`
+	AuditLogReason  string                         `json:"-"`
+}
+
+// Validate ensures a new rule is complete and within Discord's limits.
+func (p *AutoModerationRuleCreateParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "auto moderation rule params required"}
+	}
+	if p.Name == "" || len(p.Name) > limits.AutoModRuleNameLength {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("name must be between 1 and %d characters", limits.AutoModRuleNameLength)}
+	}
+	if p.EventType == 0 {
+		return &ValidationError{Field: "event_type", Message: "event type is required"}
+	}
+	if p.TriggerType == 0 {
+		return &ValidationError{Field: "trigger_type", Message: "trigger type is required"}
+	}
+	if len(p.Actions) == 0 {
+		return &ValidationError{Field: "actions", Message: "at least one action is required"}
+	}
+	if err := p.TriggerMetadata.validate(p.TriggerType); err != nil {
+		return err
+	}
+	return validateAutoModerationRule(p.Actions, p.ExemptRoles, p.ExemptChannels)
+}
+
+// Validate ensures rule updates are within Discord's limits.
+func (p *AutoModerationRuleModifyParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "auto moderation rule params required"}
+	}
+	if len(p.Name) > limits.AutoModRuleNameLength {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("name cannot exceed %d characters", limits.AutoModRuleNameLength)}
+	}
+	if err := p.TriggerMetadata.validate(0); err != nil {
+		return err
+	}
+	var roles, channels []string
+	if p.ExemptRoles != nil {
+		roles = *p.ExemptRoles
+	}
+	if p.ExemptChannels != nil {
+		channels = *p.ExemptChannels
+	}
+	return validateAutoModerationRule(p.Actions, roles, channels)
+}
+
+func validateAutoModerationRule(actions []AutoModerationAction, exemptRoles, exemptChannels []string) error {
+	for i, action := range actions {
+		if err := action.Validate(); err != nil {
+			if ve, ok := err.(*ValidationError); ok {
+				ve.Field = fmt.Sprintf("actions[%d].%s", i, ve.Field)
+			}
+			return err
+		}
+	}
+	if len(exemptRoles) > limits.AutoModExemptRoles {
+		return &ValidationError{Field: "exempt_roles", Message: fmt.Sprintf("cannot exempt more than %d roles", limits.AutoModExemptRoles)}
+	}
+	if len(exemptChannels) > limits.AutoModExemptChannels {
+		return &ValidationError{Field: "exempt_channels", Message: fmt.Sprintf("cannot exempt more than %d channels", limits.AutoModExemptChannels)}
+	}
+	return nil
+}
+
+// validate checks trigger metadata. A zero trigger type skips the checks
+// that depend on it.
+func (m *AutoModerationTriggerMetadata) validate(trigger AutoModerationTriggerType) error {
+	if m == nil {
+		if trigger == AutoModerationTriggerKeywordPreset {
+			return &ValidationError{Field: "trigger_metadata.presets", Message: "keyword preset rules need at least one preset"}
+		}
+		return nil
+	}
+	if len(m.KeywordFilter) > limits.AutoModKeywords {
+		return &ValidationError{Field: "trigger_metadata.keyword_filter", Message: fmt.Sprintf("cannot exceed %d keywords", limits.AutoModKeywords)}
+	}
+	for _, keyword := range m.KeywordFilter {
+		if keyword == "" || len(keyword) > limits.AutoModKeywordLength {
+			return &ValidationError{Field: "trigger_metadata.keyword_filter", Message: fmt.Sprintf("keywords must be between 1 and %d characters", limits.AutoModKeywordLength)}
+		}
+	}
+	if len(m.RegexPatterns) > limits.AutoModRegexPatterns {
+		return &ValidationError{Field: "trigger_metadata.regex_patterns", Message: fmt.Sprintf("cannot exceed %d patterns", limits.AutoModRegexPatterns)}
+	}
+	for _, pattern := range m.RegexPatterns {
+		if pattern == "" || len(pattern) > limits.AutoModRegexPatternLength {
+			return &ValidationError{Field: "trigger_metadata.regex_patterns", Message: fmt.Sprintf("patterns must be between 1 and %d characters", limits.AutoModRegexPatternLength)}
+		}
+	}
+	allowMax := limits.AutoModPresetAllowList
+	if trigger == AutoModerationTriggerKeyword || trigger == AutoModerationTriggerMemberProfile {
+		allowMax = limits.AutoModKeywordAllowList
+	}
+	if len(m.AllowList) > allowMax {
+		return &ValidationError{Field: "trigger_metadata.allow_list", Message: fmt.Sprintf("cannot exceed %d entries", allowMax)}
+	}
+	for _, entry := range m.AllowList {
+		if entry == "" || len(entry) > limits.AutoModKeywordLength {
+			return &ValidationError{Field: "trigger_metadata.allow_list", Message: fmt.Sprintf("entries must be between 1 and %d characters", limits.AutoModKeywordLength)}
+		}
+	}
+	if trigger == AutoModerationTriggerKeywordPreset && len(m.Presets) == 0 {
+		return &ValidationError{Field: "trigger_metadata.presets", Message: "keyword preset rules need at least one preset"}
+	}
+	if m.MentionTotalLimit < 0 || m.MentionTotalLimit > limits.AutoModMentionLimit {
+		return &ValidationError{Field: "trigger_metadata.mention_total_limit", Message: fmt.Sprintf("mention limit must be between 0 and %d", limits.AutoModMentionLimit)}
+	}
+	return nil
+}
+
+// Validate ensures an action carries the metadata its type requires.
+func (a AutoModerationAction) Validate() error {
+	var meta AutoModerationActionMetadata
+	if a.Metadata != nil {
+		meta = *a.Metadata
+	}
+	switch a.Type {
+	case AutoModerationActionBlockMessage:
+		if len(meta.CustomMessage) > limits.AutoModCustomMessageLength {
+			return &ValidationError{Field: "metadata.custom_message", Message: fmt.Sprintf("custom message cannot exceed %d characters", limits.AutoModCustomMessageLength)}
+		}
+	case AutoModerationActionSendAlertMessage:
+		if meta.ChannelID == "" {
+			return &ValidationError{Field: "metadata.channel_id", Message: "alert actions need a channel"}
+		}
+	case AutoModerationActionTimeout:
+		if meta.DurationSeconds <= 0 || meta.DurationSeconds > limits.AutoModTimeoutSeconds {
+			return &ValidationError{Field: "metadata.duration_seconds", Message: fmt.Sprintf("timeout must be between 1 and %d seconds", limits.AutoModTimeoutSeconds)}
+		}
+	case AutoModerationActionBlockMemberInteraction:
+	default:
+		return &ValidationError{Field: "type", Message: fmt.Sprintf("unknown action type %d", a.Type)}
+	}
+	return nil
+}
+
+// AutoModerationActionExecution reports that a rule triggered and an action
+// was taken. It arrives over the gateway with the AutoMod execution intent.
+type AutoModerationActionExecution struct {
+	GuildID              string                    `json:"guild_id"`
+	Action               AutoModerationAction      `json:"action"`
+	RuleID               string                    `json:"rule_id"`
+	RuleTriggerType      AutoModerationTriggerType `json:"rule_trigger_type"`
+	UserID               string                    `json:"user_id"`
+	ChannelID            string                    `json:"channel_id,omitempty"`
+	MessageID            string                    `json:"message_id,omitempty"`
+	AlertSystemMessageID string                    `json:"alert_system_message_id,omitempty"`
+	Content              string                    `json:"content"`
+	MatchedKeyword       string                    `json:"matched_keyword"`
+	MatchedContent       string                    `json:"matched_content"`
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func validAutoModRule() *AutoModerationRuleCreateParams {
+	return &AutoModerationRuleCreateParams{
+		Name:            "no invites",
+		EventType:       AutoModerationEventMessageSend,
+		TriggerType:     AutoModerationTriggerKeyword,
+		TriggerMetadata: &AutoModerationTriggerMetadata{KeywordFilter: []string{"discord.gg/*"}},
+		Actions: []AutoModerationAction{
+			{Type: AutoModerationActionBlockMessage},
+			{Type: AutoModerationActionSendAlertMessage, Metadata: &AutoModerationActionMetadata{ChannelID: "1"}},
+			{Type: AutoModerationActionTimeout, Metadata: &AutoModerationActionMetadata{DurationSeconds: 60}},
+		},
+	}
+}
+
+func TestAutoModerationRuleCreateParamsValidate(t *testing.T) {
+	if err := validAutoModRule().Validate(); err != nil {
+		t.Fatalf("valid rule rejected: %v", err)
+	}
+
+	tests := []struct {
+		field  string
+		mutate func(*AutoModerationRuleCreateParams)
+	}{
+		{"name", func(p *AutoModerationRuleCreateParams) { p.Name = "" }},
+		{"event_type", func(p *AutoModerationRuleCreateParams) { p.EventType = 0 }},
+		{"actions", func(p *AutoModerationRuleCreateParams) { p.Actions = nil }},
+		{"actions[1].metadata.channel_id", func(p *AutoModerationRuleCreateParams) { p.Actions[1].Metadata = nil }},
+		{"actions[2].metadata.duration_seconds", func(p *AutoModerationRuleCreateParams) { p.Actions[2].Metadata.DurationSeconds = 0 }},
+		{"actions[0].type", func(p *AutoModerationRuleCreateParams) { p.Actions[0].Type = 9 }},
+		{"trigger_metadata.keyword_filter", func(p *AutoModerationRuleCreateParams) {
+			p.TriggerMetadata.KeywordFilter = []string{strings.Repeat("a", 61)}
+		}},
+		{"trigger_metadata.allow_list", func(p *AutoModerationRuleCreateParams) {
+			p.TriggerMetadata.AllowList = make([]string, 101)
+		}},
+		{"trigger_metadata.presets", func(p *AutoModerationRuleCreateParams) {
+			p.TriggerType, p.TriggerMetadata = AutoModerationTriggerKeywordPreset, nil
+		}},
+		{"exempt_roles", func(p *AutoModerationRuleCreateParams) { p.ExemptRoles = make([]string, 21) }},
+	}
+	for _, tt := range tests {
+		p := validAutoModRule()
+		tt.mutate(p)
+		err := p.Validate()
+		ve, ok := err.(*ValidationError)
+		if !ok || ve.Field != tt.field {
+			t.Fatalf("expected %s error, got %v", tt.field, err)
+		}
+	}
+}
+
+func TestAutoModerationRuleModifyParamsValidate(t *testing.T) {
+	enabled := false
+	if err := (&AutoModerationRuleModifyParams{Enabled: &enabled}).Validate(); err != nil {
+		t.Fatalf("valid update rejected: %v", err)
+	}
+	channels := make([]string, 51)
+	if err := (&AutoModerationRuleModifyParams{ExemptChannels: &channels}).Validate(); err == nil {
+		t.Fatal("expected error for too many exempt channels")
+	}
+	if err := (*AutoModerationRuleModifyParams)(nil).Validate(); err == nil {
+		t.Fatal("expected error for nil params")
+	}
+}
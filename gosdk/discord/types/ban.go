@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// Ban is a user banned from a guild, with the reason given when banning.
+type Ban struct {
+	Reason string `json:"reason,omitempty"`
+	User   *User  `json:"user"`
+}
+
+// ListBansParams controls pagination when listing guild bans. Bans are
+// ordered by user ID; set Before or After, not both.
+type ListBansParams struct {
+	Limit  int
+	Before string
+	After  string
+}
+
+// Validate ensures the page size is within Discord's limits.
+func (p *ListBansParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Limit < 0 || p.Limit > limits.BansPerPage {
+		return &ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 0 and %d", limits.BansPerPage)}
+	}
+	if p.Before != "" && p.After != "" {
+		return &ValidationError{Field: "before", Message: "before and after cannot be combined"}
+	}
+	return nil
+}
+
+// BulkBanParams bans up to 200 users at once. DeleteMessageSeconds removes
+// each user's messages from that many seconds back, up to 7 days.
+type BulkBanParams struct {
+	UserIDs              []string `json:"user_ids"`
+	DeleteMessageSeconds int      `json:"delete_message_seconds,omitempty"`
+	AuditLogReason       string   `json:"-"`
+}
+
+// Validate ensures the user list and message window are within Discord's limits.
+func (p *BulkBanParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "bulk ban params required"}
+	}
+	if len(p.UserIDs) == 0 || len(p.UserIDs) > limits.BulkBanUsers {
+		return &ValidationError{Field: "user_ids", Message: fmt.Sprintf("between 1 and %d user IDs required", limits.BulkBanUsers)}
+	}
+	for i, id := range p.UserIDs {
+		if id == "" {
+			return &ValidationError{Field: fmt.Sprintf("user_ids[%d]", i), Message: "user ID is required"}
+		}
+	}
+	if p.DeleteMessageSeconds < 0 || p.DeleteMessageSeconds > limits.BanDeleteMessageSeconds {
+		return &ValidationError{Field: "delete_message_seconds", Message: fmt.Sprintf("delete_message_seconds must be between 0 and %d", limits.BanDeleteMessageSeconds)}
+	}
+	return nil
+}
+
+// BulkBanResult reports which users a bulk ban banned. Users that were
+// already banned, or could not be banned, are listed in FailedUsers.
+type BulkBanResult struct {
+	BannedUsers []string `json:"banned_users"`
+	FailedUsers []string `json:"failed_users"`
+}
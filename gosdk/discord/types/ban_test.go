@@ -0,0 +1,32 @@
+package types
+
+import "testing"
+
+func TestBulkBanParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *BulkBanParams
+		valid  bool
+	}{
+		{"nil", nil, false},
+		{"no users", &BulkBanParams{}, false},
+		{"too many users", &BulkBanParams{UserIDs: make([]string, 201)}, false},
+		{"empty ID", &BulkBanParams{UserIDs: []string{"1", ""}}, false},
+		{"window too long", &BulkBanParams{UserIDs: []string{"1"}, DeleteMessageSeconds: 604801}, false},
+		{"valid", &BulkBanParams{UserIDs: []string{"1"}, DeleteMessageSeconds: 604800}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.params.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() error = %v, want valid=%v", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestListBansParamsValidate(t *testing.T) {
+	if err := (*ListBansParams)(nil).Validate(); err != nil {
+		t.Fatalf("nil params should be valid: %v", err)
+	}
+	if err := (&ListBansParams{Limit: 1001}).Validate(); err == nil {
+		t.Fatalf("expected error for limit")
+	}
+}
@@ -1,8 +1,12 @@
 package types
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 )
 
 // ChannelType defines the Discord channel type.
@@ -59,6 +63,26 @@ type ThreadMetadata struct {
 	CreateTimestamp     *time.Time `json:"create_timestamp,omitempty"`
 }
 
+// ThreadMember describes a user's membership in a thread.
+type ThreadMember struct {
+	ID            string    `json:"id,omitempty"`
+	UserID        string    `json:"user_id,omitempty"`
+	JoinTimestamp time.Time `json:"join_timestamp"`
+	Flags         int       `json:"flags"`
+	Member        *Member   `json:"member,omitempty"`
+}
+
+// ThreadList is returned by the active and archived thread listings.
+// Members holds the current user's membership in each listed thread it has
+// joined.
+type ThreadList struct {
+	Threads []Channel      `json:"threads"`
+	Members []ThreadMember `json:"members"`
+	// HasMore reports whether an archived listing has further pages. It is
+	// always false for active threads.
+	HasMore bool `json:"has_more,omitempty"`
+}
+
 // Channel is the primary representation of Discord channel objects.
 type Channel struct {
 	ID                   string                `json:"id"`
@@ -88,6 +112,11 @@ type Channel struct {
 	DefaultReaction      *DefaultReaction      `json:"default_reaction_emoji,omitempty"`
 	DefaultSortOrder     string                `json:"default_sort_order,omitempty"`
 	DefaultForumLayout   string                `json:"default_forum_layout,omitempty"`
+	AppliedTags          []string              `json:"applied_tags,omitempty"`
+
+	// Message is the starter message, set only on threads returned by
+	// StartThreadInForum.
+	Message *Message `json:"message,omitempty"`
 }
 
 // ForumTag represents tags available for forum channels.
@@ -261,11 +290,75 @@ func validateChannelName(name string) error {
 	if name == "" {
 		return &ValidationError{Field: "name", Message: "channel name is required"}
 	}
-	if len(name) > 100 {
-		return &ValidationError{Field: "name", Message: "channel name exceeds 100 characters"}
+	if len(name) > limits.ChannelNameLength {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("channel name exceeds %d characters", limits.ChannelNameLength)}
 	}
 	if !channelNamePattern.MatchString(name) {
 		return &ValidationError{Field: "name", Message: "channel name has invalid characters"}
 	}
 	return nil
 }
+
+// Thread auto-archive durations in minutes.
+const (
+	ThreadArchiveHour  = 60
+	ThreadArchiveDay   = 1440
+	ThreadArchive3Days = 4320
+	ThreadArchiveWeek  = 10080
+)
+
+// ForumThreadParams starts a thread in a forum or media channel.
+type ForumThreadParams struct {
+	Name                string               `json:"name"`
+	AutoArchiveDuration int                  `json:"auto_archive_duration,omitempty"`
+	RateLimitPerUser    int                  `json:"rate_limit_per_user,omitempty"`
+	AppliedTags         []string             `json:"applied_tags,omitempty"`
+	Message             *MessageCreateParams `json:"message"`
+	AuditLogReason      string               `json:"-"`
+}
+
+// Validate ensures forum thread params satisfy Discord requirements.
+func (p *ForumThreadParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "forum thread params required"}
+	}
+	if strings.TrimSpace(p.Name) == "" || len(p.Name) > limits.ThreadNameLength {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("thread name must be between 1 and %d characters", limits.ThreadNameLength)}
+	}
+	if err := validateAutoArchiveDuration(p.AutoArchiveDuration); err != nil {
+		return err
+	}
+	if p.RateLimitPerUser < 0 || p.RateLimitPerUser > limits.RateLimitPerUser {
+		return &ValidationError{Field: "rate_limit_per_user", Message: fmt.Sprintf("rate limit must be between 0 and %d seconds", limits.RateLimitPerUser)}
+	}
+	if err := validateAppliedTags(p.AppliedTags); err != nil {
+		return err
+	}
+	if p.Message == nil || (p.Message.Content == "" && len(p.Message.Embeds) == 0 && len(p.Message.Components) == 0) {
+		return &ValidationError{Field: "message", Message: "forum threads need a starter message with content, embeds, or components"}
+	}
+	if err := p.Message.AllowedMentions.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateAutoArchiveDuration(minutes int) error {
+	switch minutes {
+	case 0, ThreadArchiveHour, ThreadArchiveDay, ThreadArchive3Days, ThreadArchiveWeek:
+		return nil
+	}
+	return &ValidationError{Field: "auto_archive_duration", Message: "auto archive duration must be 60, 1440, 4320, or 10080 minutes"}
+}
+
+func validateAppliedTags(tags []string) error {
+	if len(tags) > limits.ThreadAppliedTags {
+		return &ValidationError{Field: "applied_tags", Message: fmt.Sprintf("maximum %d applied tags allowed", limits.ThreadAppliedTags)}
+	}
+	for i, tag := range tags {
+		if tag == "" {
+			return &ValidationError{Field: fmt.Sprintf("applied_tags[%d]", i), Message: "tag ID is required"}
+		}
+	}
+	return nil
+}
@@ -90,3 +90,25 @@ func TestChannelJSONMarshalling(t *testing.T) {
 		t.Fatalf("expected JSON to contain channel name, got %s", data)
 	}
 }
+
+func TestForumThreadParamsValidate(t *testing.T) {
+	valid := ForumThreadParams{Name: "Bug report", AutoArchiveDuration: ThreadArchiveWeek, Message: &MessageCreateParams{Content: "steps"}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid params, got %v", err)
+	}
+	cases := map[string]func(p *ForumThreadParams){
+		"name":          func(p *ForumThreadParams) { p.Name = " " },
+		"archive":       func(p *ForumThreadParams) { p.AutoArchiveDuration = 30 },
+		"slowmode":      func(p *ForumThreadParams) { p.RateLimitPerUser = 21601 },
+		"tags":          func(p *ForumThreadParams) { p.AppliedTags = []string{"1", "2", "3", "4", "5", "6"} },
+		"message":       func(p *ForumThreadParams) { p.Message = nil },
+		"empty starter": func(p *ForumThreadParams) { p.Message = &MessageCreateParams{} },
+	}
+	for name, mutate := range cases {
+		p := valid
+		mutate(&p)
+		if err := p.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
@@ -5,17 +5,19 @@ import (
 	"net/url"
 	"strings"
 	"unicode/utf8"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 )
 
 const (
-	maxActionRowChildren       = 5
-	maxSelectOptions           = 25
-	maxPlaceholderLength       = 150
-	maxButtonLabelLength       = 80
-	maxTextInputLabelLength    = 45
-	maxTextInputPlaceholderLen = 100
+	maxActionRowChildren       = limits.ActionRowChildren
+	maxSelectOptions           = limits.SelectOptions
+	maxPlaceholderLength       = limits.PlaceholderLength
+	maxButtonLabelLength       = limits.ButtonLabelLength
+	maxTextInputLabelLength    = limits.TextInputLabelLength
+	maxTextInputPlaceholderLen = limits.TextInputPlaceholderLength
 	textInputMinValueMin       = 0
-	textInputMaxValueMax       = 4000
+	textInputMaxValueMax       = limits.TextInputValueLength
 )
 
 // Component describes a typed message component that can be converted into the raw MessageComponent representation.
@@ -251,17 +253,17 @@ func (o SelectOption) Validate() error {
 	if utf8.RuneCountInString(strings.TrimSpace(o.Label)) == 0 {
 		return &ValidationError{Field: "select_option.label", Message: "label is required"}
 	}
-	if utf8.RuneCountInString(o.Label) > 100 {
-		return &ValidationError{Field: "select_option.label", Message: "label must be <= 100 characters"}
+	if utf8.RuneCountInString(o.Label) > limits.SelectOptionLength {
+		return &ValidationError{Field: "select_option.label", Message: fmt.Sprintf("label must be <= %d characters", limits.SelectOptionLength)}
 	}
 	if utf8.RuneCountInString(strings.TrimSpace(o.Value)) == 0 {
 		return &ValidationError{Field: "select_option.value", Message: "value is required"}
 	}
-	if utf8.RuneCountInString(o.Value) > 100 {
-		return &ValidationError{Field: "select_option.value", Message: "value must be <= 100 characters"}
+	if utf8.RuneCountInString(o.Value) > limits.SelectOptionLength {
+		return &ValidationError{Field: "select_option.value", Message: fmt.Sprintf("value must be <= %d characters", limits.SelectOptionLength)}
 	}
-	if utf8.RuneCountInString(o.Description) > 100 {
-		return &ValidationError{Field: "select_option.description", Message: "description must be <= 100 characters"}
+	if utf8.RuneCountInString(o.Description) > limits.SelectOptionLength {
+		return &ValidationError{Field: "select_option.description", Message: fmt.Sprintf("description must be <= %d characters", limits.SelectOptionLength)}
 	}
 	return nil
 }
@@ -0,0 +1,164 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode is the numeric code in a Discord JSON error body. It implements
+// error so a code can be matched with errors.Is:
+//
+//	if errors.Is(err, types.ErrCodeUnknownMessage) { ... }
+type ErrorCode int
+
+// Codes Discord returns in error bodies. See
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#json
+const (
+	ErrCodeUnknownAccount                 ErrorCode = 10001
+	ErrCodeUnknownApplication             ErrorCode = 10002
+	ErrCodeUnknownChannel                 ErrorCode = 10003
+	ErrCodeUnknownGuild                   ErrorCode = 10004
+	ErrCodeUnknownIntegration             ErrorCode = 10005
+	ErrCodeUnknownInvite                  ErrorCode = 10006
+	ErrCodeUnknownMember                  ErrorCode = 10007
+	ErrCodeUnknownMessage                 ErrorCode = 10008
+	ErrCodeUnknownOverwrite               ErrorCode = 10009
+	ErrCodeUnknownRole                    ErrorCode = 10011
+	ErrCodeUnknownToken                   ErrorCode = 10012
+	ErrCodeUnknownUser                    ErrorCode = 10013
+	ErrCodeUnknownEmoji                   ErrorCode = 10014
+	ErrCodeUnknownWebhook                 ErrorCode = 10015
+	ErrCodeUnknownBan                     ErrorCode = 10026
+	ErrCodeUnknownInteraction             ErrorCode = 10062
+	ErrCodeUnknownApplicationCommand      ErrorCode = 10063
+	ErrCodeUnknownScheduledEvent          ErrorCode = 10070
+	ErrCodeBotsCannotUseEndpoint          ErrorCode = 20001
+	ErrCodeChannelWriteRateLimit          ErrorCode = 20028
+	ErrCodeMaxGuilds                      ErrorCode = 30001
+	ErrCodeMaxPins                        ErrorCode = 30003
+	ErrCodeMaxRoles                       ErrorCode = 30005
+	ErrCodeMaxWebhooks                    ErrorCode = 30007
+	ErrCodeMaxReactions                   ErrorCode = 30010
+	ErrCodeMaxChannels                    ErrorCode = 30013
+	ErrCodeUnauthorized                   ErrorCode = 40001
+	ErrCodeRequestTooLarge                ErrorCode = 40005
+	ErrCodeInteractionAlreadyAcknowledged ErrorCode = 40060
+	ErrCodeMissingAccess                  ErrorCode = 50001
+	ErrCodeCannotEditOtherUsersMessage    ErrorCode = 50005
+	ErrCodeEmptyMessage                   ErrorCode = 50006
+	ErrCodeCannotMessageUser              ErrorCode = 50007
+	ErrCodeCannotSendInNonTextChannel     ErrorCode = 50008
+	ErrCodeMissingPermissions             ErrorCode = 50013
+	ErrCodeInvalidChannelType             ErrorCode = 50024
+	ErrCodeInvalidWebhookToken            ErrorCode = 50027
+	ErrCodeMessageTooOldToBulkDelete      ErrorCode = 50034
+	ErrCodeInvalidFormBody                ErrorCode = 50035
+	ErrCodeThreadArchived                 ErrorCode = 50083
+	ErrCodeInvalidJSON                    ErrorCode = 50109
+	ErrCodeReactionBlocked                ErrorCode = 90001
+	ErrCodeReplyWithoutReadMessageHistory ErrorCode = 160002
+)
+
+var errorCodeMessages = map[ErrorCode]string{
+	ErrCodeUnknownAccount:                 "unknown account",
+	ErrCodeUnknownApplication:             "unknown application",
+	ErrCodeUnknownChannel:                 "unknown channel",
+	ErrCodeUnknownGuild:                   "unknown guild",
+	ErrCodeUnknownIntegration:             "unknown integration",
+	ErrCodeUnknownInvite:                  "unknown invite",
+	ErrCodeUnknownMember:                  "unknown member",
+	ErrCodeUnknownMessage:                 "unknown message",
+	ErrCodeUnknownOverwrite:               "unknown permission overwrite",
+	ErrCodeUnknownRole:                    "unknown role",
+	ErrCodeUnknownToken:                   "unknown token",
+	ErrCodeUnknownUser:                    "unknown user",
+	ErrCodeUnknownEmoji:                   "unknown emoji",
+	ErrCodeUnknownWebhook:                 "unknown webhook",
+	ErrCodeUnknownBan:                     "unknown ban",
+	ErrCodeUnknownInteraction:             "unknown interaction",
+	ErrCodeUnknownApplicationCommand:      "unknown application command",
+	ErrCodeUnknownScheduledEvent:          "unknown guild scheduled event",
+	ErrCodeBotsCannotUseEndpoint:          "bots cannot use this endpoint",
+	ErrCodeChannelWriteRateLimit:          "channel write rate limit hit",
+	ErrCodeMaxGuilds:                      "maximum number of guilds reached",
+	ErrCodeMaxPins:                        "maximum number of pins reached",
+	ErrCodeMaxRoles:                       "maximum number of guild roles reached",
+	ErrCodeMaxWebhooks:                    "maximum number of webhooks reached",
+	ErrCodeMaxReactions:                   "maximum number of reactions reached",
+	ErrCodeMaxChannels:                    "maximum number of guild channels reached",
+	ErrCodeUnauthorized:                   "unauthorized",
+	ErrCodeRequestTooLarge:                "request entity too large",
+	ErrCodeInteractionAlreadyAcknowledged: "interaction has already been acknowledged",
+	ErrCodeMissingAccess:                  "missing access",
+	ErrCodeCannotEditOtherUsersMessage:    "cannot edit a message authored by another user",
+	ErrCodeEmptyMessage:                   "cannot send an empty message",
+	ErrCodeCannotMessageUser:              "cannot send messages to this user",
+	ErrCodeCannotSendInNonTextChannel:     "cannot send messages in a non-text channel",
+	ErrCodeMissingPermissions:             "missing permissions",
+	ErrCodeInvalidChannelType:             "cannot execute action on this channel type",
+	ErrCodeInvalidWebhookToken:            "invalid webhook token",
+	ErrCodeMessageTooOldToBulkDelete:      "message too old to bulk delete",
+	ErrCodeInvalidFormBody:                "invalid form body",
+	ErrCodeThreadArchived:                 "thread is archived",
+	ErrCodeInvalidJSON:                    "request body contains invalid JSON",
+	ErrCodeReactionBlocked:                "reaction was blocked",
+	ErrCodeReplyWithoutReadMessageHistory: "cannot reply without permission to read message history",
+}
+
+// String returns a short description of the code.
+func (c ErrorCode) String() string {
+	if msg, ok := errorCodeMessages[c]; ok {
+		return msg
+	}
+	return fmt.Sprintf("error code %d", int(c))
+}
+
+func (c ErrorCode) Error() string {
+	return fmt.Sprintf("Discord error %d: %s", int(c), c.String())
+}
+
+// ErrorCodeOf returns the JSON error code of the APIError in err's chain.
+func ErrorCodeOf(err error) (ErrorCode, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code == 0 {
+		return 0, false
+	}
+	return apiErr.Code, true
+}
+
+// HasErrorCode reports whether err wraps an APIError with any of codes.
+func HasErrorCode(err error, codes ...ErrorCode) bool {
+	code, ok := ErrorCodeOf(err)
+	if !ok {
+		return false
+	}
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUnknownMessage reports whether the message no longer exists.
+func IsUnknownMessage(err error) bool { return HasErrorCode(err, ErrCodeUnknownMessage) }
+
+// IsUnknownChannel reports whether the channel no longer exists.
+func IsUnknownChannel(err error) bool { return HasErrorCode(err, ErrCodeUnknownChannel) }
+
+// IsUnknownMember reports whether the user is not a member of the guild.
+func IsUnknownMember(err error) bool { return HasErrorCode(err, ErrCodeUnknownMember) }
+
+// IsUnknownInteraction reports whether an interaction token expired before
+// it was answered.
+func IsUnknownInteraction(err error) bool { return HasErrorCode(err, ErrCodeUnknownInteraction) }
+
+// IsMissingAccess reports whether the bot cannot see the resource.
+func IsMissingAccess(err error) bool { return HasErrorCode(err, ErrCodeMissingAccess) }
+
+// IsMissingPermissions reports whether the bot lacks a permission for the action.
+func IsMissingPermissions(err error) bool { return HasErrorCode(err, ErrCodeMissingPermissions) }
+
+// IsCannotMessageUser reports whether a DM was refused, usually because the
+// user disabled DMs from server members.
+func IsCannotMessageUser(err error) bool { return HasErrorCode(err, ErrCodeCannotMessageUser) }
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCodeMatching(t *testing.T) {
+	apiErr := &APIError{StatusCode: 404, Message: "Unknown Message", Code: ErrCodeUnknownMessage}
+	err := fmt.Errorf("delete message: %w", fmt.Errorf("attempt 3: %w", apiErr))
+
+	if !errors.Is(err, ErrCodeUnknownMessage) || errors.Is(err, ErrCodeUnknownChannel) {
+		t.Fatalf("errors.Is should match the code through the chain")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("status matching should still work")
+	}
+	var got *APIError
+	if !errors.As(err, &got) || got != apiErr {
+		t.Fatalf("errors.As should find the APIError")
+	}
+	if !IsUnknownMessage(err) || IsMissingPermissions(err) {
+		t.Fatalf("helpers disagree with the code")
+	}
+	if code, ok := ErrorCodeOf(err); !ok || code != 10008 {
+		t.Fatalf("ErrorCodeOf() = %d, %v", code, ok)
+	}
+	if !HasErrorCode(err, ErrCodeUnknownChannel, ErrCodeUnknownMessage) {
+		t.Fatalf("HasErrorCode should match any listed code")
+	}
+}
+
+func TestErrorCodeWithoutCode(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: 502, Message: "bad gateway"})
+	if _, ok := ErrorCodeOf(err); ok {
+		t.Fatalf("an error without a JSON code should report none")
+	}
+	if IsUnknownMessage(errors.New("unknown message")) {
+		t.Fatalf("plain errors should never match")
+	}
+	if ErrCodeMissingPermissions.Error() != "Discord error 50013: missing permissions" {
+		t.Fatalf("Error() = %q", ErrCodeMissingPermissions.Error())
+	}
+	if ErrorCode(99999).String() != "error code 99999" {
+		t.Fatalf("String() = %q", ErrorCode(99999).String())
+	}
+}
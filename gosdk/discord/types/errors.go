@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -38,26 +39,55 @@ var (
 
 	// ErrCircuitBreakerOpen indicates the circuit breaker is open
 	ErrCircuitBreakerOpen = errors.New("circuit breaker is open")
+
+	// ErrCustomIDExpired indicates a component custom_id was encoded with an older schema version
+	ErrCustomIDExpired = errors.New("component custom_id has expired")
+
+	// ErrPromptTimeout indicates nobody answered an interactive prompt in time
+	ErrPromptTimeout = errors.New("prompt timed out waiting for a response")
+
+	// ErrQueueClosed indicates a message was offered to, or abandoned by, a closed queue
+	ErrQueueClosed = errors.New("queue is closed")
+
+	// ErrMalformedInteraction indicates an interaction payload could not be decoded or failed validation
+	ErrMalformedInteraction = errors.New("malformed interaction payload")
+
+	// ErrUnhandledInteraction indicates no handler is registered for an interaction
+	ErrUnhandledInteraction = errors.New("no handler registered for interaction")
+
+	// ErrStopIteration can be returned from a ForEach callback to end iteration early without error
+	ErrStopIteration = errors.New("stop iteration")
 )
 
 // APIError represents a Discord API error response
 type APIError struct {
 	StatusCode int
 	Message    string
-	Code       int
-	Errors     map[string]interface{}
-	RetryAfter int // seconds to wait before retry (for rate limits)
+	Code       ErrorCode
+	Errors     map[string]interface{} // nested field errors; see FieldErrors
+	RetryAfter int                    // seconds to wait before retry (for rate limits)
 }
 
 func (e *APIError) Error() string {
 	if e.RetryAfter > 0 {
 		return fmt.Sprintf("Discord API error %d: %s (retry after %ds)", e.StatusCode, e.Message, e.RetryAfter)
 	}
+	if fields := e.FieldErrors(); len(fields) > 0 {
+		details := make([]string, len(fields))
+		for i, f := range fields {
+			details[i] = f.String()
+		}
+		return fmt.Sprintf("Discord API error %d: %s (%s)", e.StatusCode, e.Message, strings.Join(details, "; "))
+	}
 	return fmt.Sprintf("Discord API error %d: %s", e.StatusCode, e.Message)
 }
 
-// Is implements error matching for common error types
+// Is implements error matching for common error types and for JSON error
+// codes, so errors.Is(err, ErrCodeUnknownMessage) works through wrapping.
 func (e *APIError) Is(target error) bool {
+	if code, ok := target.(ErrorCode); ok {
+		return e.Code != 0 && e.Code == code
+	}
 	switch target {
 	case ErrRateLimited:
 		return e.StatusCode == 429
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldError is one entry from the nested "errors" object Discord returns
+// with ErrCodeInvalidFormBody. Path locates the offending field, such as
+// "embeds[0].description"; it is empty for errors about the whole body.
+type FieldError struct {
+	Path    string
+	Code    string
+	Message string
+}
+
+func (e FieldError) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// FieldErrors flattens Errors into one entry per field error, ordered by
+// path. It returns nil when the response had no field errors.
+func (e *APIError) FieldErrors() []FieldError {
+	if e == nil || len(e.Errors) == 0 {
+		return nil
+	}
+	var out []FieldError
+	flattenFieldErrors("", e.Errors, &out)
+	return out
+}
+
+func flattenFieldErrors(path string, node map[string]interface{}, out *[]FieldError) {
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessFieldKey(keys[i], keys[j]) })
+	for _, key := range keys {
+		value := node[key]
+		if key == "_errors" {
+			list, _ := value.([]interface{})
+			for _, item := range list {
+				entry, _ := item.(map[string]interface{})
+				code, _ := entry["code"].(string)
+				message, _ := entry["message"].(string)
+				*out = append(*out, FieldError{Path: path, Code: code, Message: message})
+			}
+			continue
+		}
+		if child, ok := value.(map[string]interface{}); ok {
+			flattenFieldErrors(joinFieldPath(path, key), child, out)
+		}
+	}
+}
+
+// joinFieldPath renders numeric keys as indexes: embeds, 0, title becomes
+// embeds[0].title.
+func joinFieldPath(path, key string) string {
+	if isIndex(key) {
+		return fmt.Sprintf("%s[%s]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func lessFieldKey(a, b string) bool {
+	if isIndex(a) && isIndex(b) && len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func isIndex(key string) bool {
+	return key != "" && strings.Trim(key, "0123456789") == ""
+}
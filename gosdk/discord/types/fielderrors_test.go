@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorFieldErrors(t *testing.T) {
+	body := `{
+		"embeds": {
+			"10": {"title": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 256 or fewer in length."}]}},
+			"2": {
+				"description": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 4096 or fewer in length."}]},
+				"fields": {"0": {"name": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}}
+			}
+		},
+		"_errors": [{"code": "MESSAGE_BLOCKED", "message": "Message was blocked"}]
+	}`
+	apiErr := &APIError{StatusCode: 400, Message: "Invalid Form Body", Code: ErrCodeInvalidFormBody}
+	if err := json.Unmarshal([]byte(body), &apiErr.Errors); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	got := apiErr.FieldErrors()
+	want := []FieldError{
+		{Path: "", Code: "MESSAGE_BLOCKED", Message: "Message was blocked"},
+		{Path: "embeds[2].description", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 4096 or fewer in length."},
+		{Path: "embeds[2].fields[0].name", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+		{Path: "embeds[10].title", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 256 or fewer in length."},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("FieldErrors() = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FieldErrors()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if msg := apiErr.Error(); !strings.Contains(msg, "embeds[2].description: Must be 4096 or fewer in length.") {
+		t.Fatalf("Error() = %q", msg)
+	}
+}
+
+func TestAPIErrorWithoutFieldErrors(t *testing.T) {
+	apiErr := &APIError{StatusCode: 404, Message: "Unknown Message"}
+	if apiErr.FieldErrors() != nil {
+		t.Fatalf("expected no field errors")
+	}
+	if apiErr.Error() != "Discord API error 404: Unknown Message" {
+		t.Fatalf("Error() = %q", apiErr.Error())
+	}
+}
@@ -1,6 +1,12 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
 
 // Guild represents a Discord guild (server).
 type Guild struct {
@@ -24,12 +30,18 @@ type Guild struct {
 	Roles                       []Role         `json:"roles,omitempty"`
 	Members                     []Member       `json:"members,omitempty"`
 	Channels                    []Channel      `json:"channels,omitempty"`
+	Threads                     []Channel      `json:"threads,omitempty"`
+	VoiceStates                 []VoiceState   `json:"voice_states,omitempty"`
 	Description                 string         `json:"description,omitempty"`
 	Banner                      string         `json:"banner,omitempty"`
 	Features                    []string       `json:"features,omitempty"`
 	ApproximateMemberCount      int            `json:"approximate_member_count,omitempty"`
 	ApproximatePresenceCount    int            `json:"approximate_presence_count,omitempty"`
+	PremiumTier                 int            `json:"premium_tier,omitempty"`
+	PremiumSubscriptionCount    int            `json:"premium_subscription_count,omitempty"`
 	WelcomeScreen               *WelcomeScreen `json:"welcome_screen,omitempty"`
+	Emojis                      []Emoji        `json:"emojis,omitempty"`
+	Stickers                    []Sticker      `json:"stickers,omitempty"`
 }
 
 // GuildModifyParams represents the payload for modifying a guild.
@@ -58,26 +70,51 @@ type Role struct {
 	Hoist       bool   `json:"hoist"`
 	Managed     bool   `json:"managed"`
 	Mentionable bool   `json:"mentionable"`
+	// Icon is the role icon hash; UnicodeEmoji is shown instead when set.
+	Icon         string    `json:"icon,omitempty"`
+	UnicodeEmoji string    `json:"unicode_emoji,omitempty"`
+	Flags        RoleFlags `json:"flags,omitempty"`
+}
+
+// RoleFlags are read-only bits Discord sets on a role.
+type RoleFlags int
+
+const (
+	// RoleFlagInPrompt marks a role that can be picked in an onboarding prompt.
+	RoleFlagInPrompt RoleFlags = 1 << 0
+)
+
+// RolePosition moves one role when reordering the role hierarchy.
+type RolePosition struct {
+	ID       string `json:"id"`
+	Position int    `json:"position"`
 }
 
-// RoleCreateParams represents payload for creating a role.
+// RoleCreateParams represents payload for creating a role. Icon is an image
+// data URI; set Icon or UnicodeEmoji, not both. Role icons need the
+// ROLE_ICONS guild feature. Flags cannot be set; Discord assigns them.
 type RoleCreateParams struct {
 	Name           string `json:"name,omitempty"`
 	Permissions    string `json:"permissions,omitempty"`
 	Color          int    `json:"color,omitempty"`
 	Hoist          bool   `json:"hoist,omitempty"`
+	Icon           string `json:"icon,omitempty"`
+	UnicodeEmoji   string `json:"unicode_emoji,omitempty"`
 	Mentionable    bool   `json:"mentionable,omitempty"`
 	AuditLogReason string `json:"-"`
 }
 
-// RoleModifyParams represents payload for updating a role.
+// RoleModifyParams represents payload for updating a role. Icon and
+// UnicodeEmoji are left unchanged when nil; an empty string clears them.
 type RoleModifyParams struct {
-	Name           string `json:"name,omitempty"`
-	Permissions    string `json:"permissions,omitempty"`
-	Color          int    `json:"color,omitempty"`
-	Hoist          bool   `json:"hoist,omitempty"`
-	Mentionable    bool   `json:"mentionable,omitempty"`
-	AuditLogReason string `json:"-"`
+	Name           string  `json:"name,omitempty"`
+	Permissions    string  `json:"permissions,omitempty"`
+	Color          int     `json:"color,omitempty"`
+	Hoist          bool    `json:"hoist,omitempty"`
+	Icon           *string `json:"icon,omitempty"`
+	UnicodeEmoji   *string `json:"unicode_emoji,omitempty"`
+	Mentionable    bool    `json:"mentionable,omitempty"`
+	AuditLogReason string  `json:"-"`
 }
 
 // Member represents a guild member.
@@ -90,6 +127,13 @@ type Member struct {
 	Deaf         bool       `json:"deaf"`
 	Mute         bool       `json:"mute"`
 	Pending      bool       `json:"pending,omitempty"`
+	// CommunicationDisabledUntil is when the member's timeout ends.
+	CommunicationDisabledUntil *time.Time `json:"communication_disabled_until,omitempty"`
+}
+
+// TimedOut reports whether the member is in a timeout at t.
+func (m *Member) TimedOut(t time.Time) bool {
+	return m != nil && m.CommunicationDisabledUntil != nil && m.CommunicationDisabledUntil.After(t)
 }
 
 // ListMembersParams controls pagination when listing guild members.
@@ -117,9 +161,107 @@ type Emoji struct {
 	ID        string   `json:"id"`
 	Name      string   `json:"name"`
 	Roles     []string `json:"roles,omitempty"`
+	Animated  bool     `json:"animated,omitempty"`
 	Available bool     `json:"available"`
 }
 
+// Reaction returns the "name:id" form used by reaction endpoints. Unicode
+// emoji (no ID) are returned as-is.
+func (e *Emoji) Reaction() string {
+	if e.ID == "" {
+		return e.Name
+	}
+	if e.Animated {
+		return "a:" + e.Name + ":" + e.ID
+	}
+	return e.Name + ":" + e.ID
+}
+
+// Mention returns the "<:name:id>" form used in message content.
+func (e *Emoji) Mention() string {
+	if e.ID == "" {
+		return e.Name
+	}
+	if e.Animated {
+		return "<a:" + e.Name + ":" + e.ID + ">"
+	}
+	return "<:" + e.Name + ":" + e.ID + ">"
+}
+
+// EmojiCreateParams represents payload for creating a guild emoji. Image is
+// an image data URI of at most 256 KiB; Roles limits who may use it.
+type EmojiCreateParams struct {
+	Name           string   `json:"name"`
+	Image          string   `json:"image"`
+	Roles          []string `json:"roles,omitempty"`
+	AuditLogReason string   `json:"-"`
+}
+
+// Validate ensures emoji create params are valid.
+func (p *EmojiCreateParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "emoji create params required"}
+	}
+	if len(p.Name) < 2 || len(p.Name) > 32 {
+		return &ValidationError{Field: "name", Message: "emoji name must be 2-32 characters"}
+	}
+	for _, r := range p.Name {
+		if r != '_' && (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return &ValidationError{Field: "name", Message: "emoji name may only contain letters, digits and underscores"}
+		}
+	}
+	if !strings.HasPrefix(p.Image, "data:image/") {
+		return &ValidationError{Field: "image", Message: "must be an image data URI"}
+	}
+	return nil
+}
+
+// Sticker format types.
+const (
+	StickerFormatPNG    = 1
+	StickerFormatAPNG   = 2
+	StickerFormatLottie = 3
+	StickerFormatGIF    = 4
+)
+
+// Sticker represents a guild or standard sticker.
+type Sticker struct {
+	ID          string `json:"id"`
+	PackID      string `json:"pack_id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Tags        string `json:"tags"`
+	Type        int    `json:"type"`
+	FormatType  int    `json:"format_type"`
+	Available   bool   `json:"available,omitempty"`
+	GuildID     string `json:"guild_id,omitempty"`
+	User        *User  `json:"user,omitempty"`
+}
+
+// StickerPack is a pack of standard stickers.
+type StickerPack struct {
+	ID             string    `json:"id"`
+	Stickers       []Sticker `json:"stickers"`
+	Name           string    `json:"name"`
+	SKUID          string    `json:"sku_id"`
+	CoverStickerID string    `json:"cover_sticker_id,omitempty"`
+	Description    string    `json:"description"`
+	BannerAssetID  string    `json:"banner_asset_id,omitempty"`
+}
+
+// StickerPackList is the response of the list sticker packs endpoint.
+type StickerPackList struct {
+	StickerPacks []StickerPack `json:"sticker_packs"`
+}
+
+// GuildStickerModifyParams represents payload for updating a guild sticker.
+type GuildStickerModifyParams struct {
+	Name           string  `json:"name,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	Tags           string  `json:"tags,omitempty"`
+	AuditLogReason string  `json:"-"`
+}
+
 // WelcomeScreen describes the welcome screen configuration.
 type WelcomeScreen struct {
 	Description     string                 `json:"description,omitempty"`
@@ -166,7 +308,7 @@ func (p *RoleCreateParams) Validate() error {
 	if p.Name == "" {
 		return &ValidationError{Field: "name", Message: "role name is required"}
 	}
-	return nil
+	return validateRoleIcon(&p.Icon, &p.UnicodeEmoji)
 }
 
 // Validate ensures modify params are valid.
@@ -174,6 +316,56 @@ func (p *RoleModifyParams) Validate() error {
 	if p == nil {
 		return &ValidationError{Field: "params", Message: "role modify params required"}
 	}
+	return validateRoleIcon(p.Icon, p.UnicodeEmoji)
+}
+
+func validateRoleIcon(icon, emoji *string) error {
+	if icon != nil && *icon != "" && !strings.HasPrefix(*icon, "data:image/") {
+		return &ValidationError{Field: "icon", Message: "must be an image data URI"}
+	}
+	if icon != nil && *icon != "" && emoji != nil && *emoji != "" {
+		return &ValidationError{Field: "unicode_emoji", Message: "icon and unicode_emoji cannot both be set"}
+	}
+	return nil
+}
+
+// ValidateRolePositions checks a role reorder request: at least one role,
+// no repeated IDs and no negative positions.
+func ValidateRolePositions(positions []RolePosition) error {
+	if len(positions) == 0 {
+		return &ValidationError{Field: "positions", Message: "at least one role position required"}
+	}
+	seen := make(map[string]bool, len(positions))
+	for i, pos := range positions {
+		field := fmt.Sprintf("positions[%d]", i)
+		if pos.ID == "" {
+			return &ValidationError{Field: field + ".id", Message: "role ID is required"}
+		}
+		if seen[pos.ID] {
+			return &ValidationError{Field: field + ".id", Message: "role " + pos.ID + " appears more than once"}
+		}
+		seen[pos.ID] = true
+		if pos.Position < 0 {
+			return &ValidationError{Field: field + ".position", Message: "position cannot be negative"}
+		}
+	}
+	return nil
+}
+
+// Validate ensures sticker updates respect Discord's length limits.
+func (p *GuildStickerModifyParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "sticker modify params required"}
+	}
+	if p.Name != "" && (len(p.Name) < 2 || len(p.Name) > limits.StickerNameLength) {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("name must be between 2 and %d characters", limits.StickerNameLength)}
+	}
+	if p.Description != nil && *p.Description != "" && (len(*p.Description) < 2 || len(*p.Description) > limits.StickerDescriptionLength) {
+		return &ValidationError{Field: "description", Message: fmt.Sprintf("description must be between 2 and %d characters", limits.StickerDescriptionLength)}
+	}
+	if len(p.Tags) > limits.StickerTagsLength {
+		return &ValidationError{Field: "tags", Message: fmt.Sprintf("tags cannot exceed %d characters", limits.StickerTagsLength)}
+	}
 	return nil
 }
 
@@ -182,8 +374,8 @@ func (p *ListMembersParams) Validate() error {
 	if p == nil {
 		return nil
 	}
-	if p.Limit < 0 || p.Limit > 1000 {
-		return &ValidationError{Field: "limit", Message: "limit must be between 0 and 1000"}
+	if p.Limit < 0 || p.Limit > limits.MembersPerPage {
+		return &ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 0 and %d", limits.MembersPerPage)}
 	}
 	return nil
 }
@@ -193,8 +385,8 @@ func (p *GuildModifyParams) Validate() error {
 	if p == nil {
 		return &ValidationError{Field: "params", Message: "guild modify params required"}
 	}
-	if p.Name != "" && len(p.Name) > 100 {
-		return &ValidationError{Field: "name", Message: "guild name exceeds 100 characters"}
+	if p.Name != "" && len(p.Name) > limits.GuildNameLength {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("guild name exceeds %d characters", limits.GuildNameLength)}
 	}
 	return nil
 }
@@ -57,6 +57,56 @@ func TestRoleParamValidation(t *testing.T) {
 	if err := modify.Validate(); err != nil {
 		t.Fatalf("expected modify params valid: %v", err)
 	}
+
+	create = &RoleCreateParams{Name: "Star", Icon: "icon.png"}
+	if err := create.Validate(); err == nil {
+		t.Fatal("expected error for icon that is not a data URI")
+	}
+	create.Icon = "data:image/png;base64,AAAA"
+	create.UnicodeEmoji = "⭐"
+	if err := create.Validate(); err == nil {
+		t.Fatal("expected error for icon and unicode emoji together")
+	}
+	clear := ""
+	emoji := "⭐"
+	modify = &RoleModifyParams{Icon: &clear, UnicodeEmoji: &emoji}
+	if err := modify.Validate(); err != nil {
+		t.Fatalf("expected clearing the icon while setting an emoji to be valid: %v", err)
+	}
+}
+
+func TestRoleJSONIconFields(t *testing.T) {
+	var role Role
+	if err := json.Unmarshal([]byte(`{"id":"1","icon":"abc","unicode_emoji":"⭐","flags":1}`), &role); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if role.Icon != "abc" || role.UnicodeEmoji != "⭐" || role.Flags&RoleFlagInPrompt == 0 {
+		t.Fatalf("unexpected role %+v", role)
+	}
+	clear := ""
+	raw, _ := json.Marshal(RoleModifyParams{Icon: &clear})
+	if string(raw) != `{"icon":""}` {
+		t.Fatalf("modify payload = %s", raw)
+	}
+}
+
+func TestValidateRolePositions(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions []RolePosition
+		valid     bool
+	}{
+		{"empty", nil, false},
+		{"missing ID", []RolePosition{{Position: 1}}, false},
+		{"duplicate", []RolePosition{{ID: "1", Position: 1}, {ID: "1", Position: 2}}, false},
+		{"negative", []RolePosition{{ID: "1", Position: -1}}, false},
+		{"valid", []RolePosition{{ID: "1", Position: 2}, {ID: "2", Position: 1}}, true},
+	}
+	for _, tt := range tests {
+		if err := ValidateRolePositions(tt.positions); (err == nil) != tt.valid {
+			t.Errorf("%s: error = %v, want valid=%v", tt.name, err, tt.valid)
+		}
+	}
 }
 
 func TestListMembersParamsValidate(t *testing.T) {
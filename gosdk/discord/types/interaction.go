@@ -4,19 +4,21 @@ import (
 	"fmt"
 	"strings"
 	"unicode/utf8"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 )
 
 const (
-	maxInteractionResponseContentLength = 2000
-	maxInteractionResponseEmbeds        = 10
-	maxInteractionResponseComponents    = 5
-	maxInteractionComponentsPerRow      = 5
-	maxInteractionResponseAttachments   = 10
-	maxAutocompleteChoices              = 25
+	maxInteractionResponseContentLength = limits.MessageContentLength
+	maxInteractionResponseEmbeds        = limits.MessageEmbeds
+	maxInteractionResponseComponents    = limits.ActionRows
+	maxInteractionComponentsPerRow      = limits.ActionRowChildren
+	maxInteractionResponseAttachments   = limits.MessageAttachments
+	maxAutocompleteChoices              = limits.AutocompleteChoices
 	modalTitleMinRunes                  = 1
-	modalTitleMaxRunes                  = 45
+	modalTitleMaxRunes                  = limits.ModalTitleLength
 	modalCustomIDMinRunes               = 1
-	modalCustomIDMaxRunes               = 100
+	modalCustomIDMaxRunes               = limits.CustomIDLength
 )
 
 // InteractionType defines the type of interaction from Discord.
@@ -58,6 +60,8 @@ type InteractionData struct {
 	ComponentType ComponentType              `json:"component_type,omitempty"`
 	Values        []string                   `json:"values,omitempty"`
 	TargetID      string                     `json:"target_id,omitempty"`
+	// Components holds the submitted action rows of a modal submit.
+	Components []MessageComponent `json:"components,omitempty"`
 }
 
 // ResolvedData contains hydrated entities referenced in commands.
@@ -67,6 +71,8 @@ type ResolvedData struct {
 	Roles    map[string]Role    `json:"roles,omitempty"`
 	Channels map[string]Channel `json:"channels,omitempty"`
 	Messages map[string]Message `json:"messages,omitempty"`
+
+	Attachments map[string]Attachment `json:"attachments,omitempty"`
 }
 
 // ApplicationCommand represents a slash command or user/message command.
@@ -112,6 +118,12 @@ type ApplicationCommandOption struct {
 	MinLength                *int                         `json:"min_length,omitempty"`
 	MaxLength                *int                         `json:"max_length,omitempty"`
 	Autocomplete             bool                         `json:"autocomplete,omitempty"`
+
+	// Value and Focused are set on the options of a received interaction:
+	// the user's input (a snowflake string for users, roles, channels and
+	// attachments) and, for autocomplete, the option being typed.
+	Value   interface{} `json:"value,omitempty"`
+	Focused bool        `json:"focused,omitempty"`
 }
 
 // ApplicationCommandOptionType enumerates option types.
@@ -166,6 +178,19 @@ func (i *Interaction) Validate() error {
 	if i.Token == "" {
 		return &ValidationError{Field: "interaction.token", Message: "interaction token is required"}
 	}
+	switch i.Type {
+	case InteractionTypePing:
+	case InteractionTypeApplicationCommand, InteractionTypeApplicationCommandAutocomplete:
+		if i.Data == nil || i.Data.Name == "" {
+			return &ValidationError{Field: "interaction.data.name", Message: "command name is required"}
+		}
+	case InteractionTypeMessageComponent, InteractionTypeModalSubmit:
+		if i.Data == nil || i.Data.CustomID == "" {
+			return &ValidationError{Field: "interaction.data.custom_id", Message: "custom ID is required"}
+		}
+	default:
+		return &ValidationError{Field: "interaction.type", Message: fmt.Sprintf("unknown interaction type %d", i.Type)}
+	}
 	return nil
 }
 
@@ -174,11 +199,11 @@ func (c *ApplicationCommand) Validate() error {
 	if c == nil {
 		return &ValidationError{Field: "command", Message: "command is required"}
 	}
-	if len(c.Name) < 1 || len(c.Name) > 32 {
-		return &ValidationError{Field: "name", Message: "command name must be 1-32 characters"}
+	if len(c.Name) < 1 || len(c.Name) > limits.CommandNameLength {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("command name must be 1-%d characters", limits.CommandNameLength)}
 	}
-	if len(c.Description) > 100 {
-		return &ValidationError{Field: "description", Message: "description must be <=100 characters"}
+	if len(c.Description) > limits.CommandDescriptionLength {
+		return &ValidationError{Field: "description", Message: fmt.Sprintf("description must be <=%d characters", limits.CommandDescriptionLength)}
 	}
 	for _, opt := range c.Options {
 		if err := opt.Validate(); err != nil {
@@ -190,11 +215,11 @@ func (c *ApplicationCommand) Validate() error {
 
 // Validate ensures option details respect Discord limits.
 func (o *ApplicationCommandOption) Validate() error {
-	if len(o.Name) < 1 || len(o.Name) > 32 {
-		return &ValidationError{Field: "option.name", Message: "option name must be 1-32 characters"}
+	if len(o.Name) < 1 || len(o.Name) > limits.CommandNameLength {
+		return &ValidationError{Field: "option.name", Message: fmt.Sprintf("option name must be 1-%d characters", limits.CommandNameLength)}
 	}
-	if len(o.Description) < 1 || len(o.Description) > 100 {
-		return &ValidationError{Field: "option.description", Message: "description must be 1-100 characters"}
+	if len(o.Description) < 1 || len(o.Description) > limits.CommandDescriptionLength {
+		return &ValidationError{Field: "option.description", Message: fmt.Sprintf("description must be 1-%d characters", limits.CommandDescriptionLength)}
 	}
 	for _, opt := range o.Options {
 		if err := opt.Validate(); err != nil {
@@ -221,6 +246,10 @@ const (
 type InteractionResponse struct {
 	Type InteractionResponseType                    `json:"type"`
 	Data *InteractionApplicationCommandCallbackData `json:"data,omitempty"`
+
+	// Files are uploaded with a message response. They are not part of the
+	// JSON payload; responses carrying files are sent as multipart/form-data.
+	Files []FileAttachment `json:"-"`
 }
 
 // InteractionApplicationCommandCallbackData describes response data.
@@ -256,14 +285,8 @@ type MessageComponent struct {
 	MaxLength    int                `json:"max_length,omitempty"`
 	Required     bool               `json:"required,omitempty"`
 	Value        string             `json:"value,omitempty"`
-}
-
-// AllowedMentions controls mention parsing in responses.
-type AllowedMentions struct {
-	Parse       []string `json:"parse,omitempty"`
-	Roles       []string `json:"roles,omitempty"`
-	Users       []string `json:"users,omitempty"`
-	RepliedUser bool     `json:"replied_user,omitempty"`
+	// Values holds the selected values of a select menu in a modal submit.
+	Values []string `json:"values,omitempty"`
 }
 
 // AutocompleteChoice represents an entry shown during autocomplete interactions.
@@ -292,6 +315,26 @@ func (r *InteractionResponse) Validate() error {
 		}
 	}
 
+	if len(r.Files) > 0 {
+		switch r.Type {
+		case InteractionResponseChannelMessageWithSource, InteractionResponseUpdateMessage:
+		default:
+			return &ValidationError{Field: "response.files", Message: "files can only be sent with message responses"}
+		}
+		existing := 0
+		if r.Data != nil {
+			existing = len(r.Data.Attachments)
+		}
+		if len(r.Files)+existing > maxInteractionResponseAttachments {
+			return &ValidationError{Field: "response.files", Message: fmt.Sprintf("no more than %d attachments are allowed", maxInteractionResponseAttachments)}
+		}
+		for i, f := range r.Files {
+			if f.Name == "" || f.Reader == nil {
+				return &ValidationError{Field: fmt.Sprintf("response.files[%d]", i), Message: "file name and reader are required"}
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -348,11 +391,11 @@ func (d *InteractionApplicationCommandCallbackData) validateAutocompletePayload(
 func (d *InteractionApplicationCommandCallbackData) validateModalPayload() error {
 	customID := strings.TrimSpace(d.CustomID)
 	if l := utf8.RuneCountInString(customID); l < modalCustomIDMinRunes || l > modalCustomIDMaxRunes {
-		return &ValidationError{Field: "response.data.custom_id", Message: "custom_id must be 1-100 characters"}
+		return &ValidationError{Field: "response.data.custom_id", Message: fmt.Sprintf("custom_id must be 1-%d characters", modalCustomIDMaxRunes)}
 	}
 	title := strings.TrimSpace(d.Title)
 	if l := utf8.RuneCountInString(title); l < modalTitleMinRunes || l > modalTitleMaxRunes {
-		return &ValidationError{Field: "response.data.title", Message: "title must be 1-45 characters"}
+		return &ValidationError{Field: "response.data.title", Message: fmt.Sprintf("title must be 1-%d characters", modalTitleMaxRunes)}
 	}
 	if len(d.Choices) > 0 {
 		return &ValidationError{Field: "response.data.choices", Message: "choices are not valid for modal responses"}
@@ -369,8 +412,8 @@ func (d *InteractionApplicationCommandCallbackData) validateModalPayload() error
 // Validate ensures autocomplete choices respect Discord limits.
 func (c AutocompleteChoice) Validate() error {
 	name := strings.TrimSpace(c.Name)
-	if count := utf8.RuneCountInString(name); count < 1 || count > 100 {
-		return &ValidationError{Field: "choice.name", Message: "name must be 1-100 characters"}
+	if count := utf8.RuneCountInString(name); count < 1 || count > limits.ChoiceNameLength {
+		return &ValidationError{Field: "choice.name", Message: fmt.Sprintf("name must be 1-%d characters", limits.ChoiceNameLength)}
 	}
 	if c.Value == nil {
 		return &ValidationError{Field: "choice.value", Message: "value is required"}
@@ -379,8 +422,8 @@ func (c AutocompleteChoice) Validate() error {
 	switch v := c.Value.(type) {
 	case string:
 		val := strings.TrimSpace(v)
-		if count := utf8.RuneCountInString(val); count < 1 || count > 100 {
-			return &ValidationError{Field: "choice.value", Message: "string values must be 1-100 characters"}
+		if count := utf8.RuneCountInString(val); count < 1 || count > limits.ChoiceValueLength {
+			return &ValidationError{Field: "choice.value", Message: fmt.Sprintf("string values must be 1-%d characters", limits.ChoiceValueLength)}
 		}
 	case int, int8, int16, int32, int64,
 		uint, uint8, uint16, uint32, uint64,
@@ -0,0 +1,73 @@
+package types
+
+// Select menus send the chosen IDs in InteractionData.Values and the objects
+// they refer to in InteractionData.Resolved. The accessors below return those
+// objects in the order the user picked them. Values without a resolved entry
+// are skipped, so a mentionable select yields its users from SelectedUsers
+// and its roles from SelectedRoles.
+
+// SelectedUsers returns the users chosen in a user or mentionable select.
+func (d *InteractionData) SelectedUsers() []User {
+	if d == nil || d.Resolved == nil {
+		return nil
+	}
+	var out []User
+	for _, id := range d.Values {
+		if user, ok := d.Resolved.Users[id]; ok {
+			out = append(out, user)
+		}
+	}
+	return out
+}
+
+// SelectedMembers returns the guild members chosen in a user or mentionable
+// select. Resolved members omit their user, so it is filled in from the
+// resolved users.
+func (d *InteractionData) SelectedMembers() []Member {
+	if d == nil || d.Resolved == nil {
+		return nil
+	}
+	var out []Member
+	for _, id := range d.Values {
+		member, ok := d.Resolved.Members[id]
+		if !ok {
+			continue
+		}
+		if member.User == nil {
+			if user, ok := d.Resolved.Users[id]; ok {
+				member.User = &user
+			}
+		}
+		out = append(out, member)
+	}
+	return out
+}
+
+// SelectedRoles returns the roles chosen in a role or mentionable select.
+func (d *InteractionData) SelectedRoles() []Role {
+	if d == nil || d.Resolved == nil {
+		return nil
+	}
+	var out []Role
+	for _, id := range d.Values {
+		if role, ok := d.Resolved.Roles[id]; ok {
+			out = append(out, role)
+		}
+	}
+	return out
+}
+
+// SelectedChannels returns the channels chosen in a channel select. Resolved
+// channels are partial: ID, name, type, permissions, and thread fields.
+func (d *InteractionData) SelectedChannels() []Channel {
+	if d == nil || d.Resolved == nil {
+		return nil
+	}
+	var out []Channel
+	for _, id := range d.Values {
+		if channel, ok := d.Resolved.Channels[id]; ok {
+			out = append(out, channel)
+		}
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInteractionDataSelectedEntities(t *testing.T) {
+	payload := `{
+		"id": "1",
+		"type": 3,
+		"token": "t",
+		"data": {
+			"custom_id": "pick",
+			"component_type": 7,
+			"values": ["30", "10", "20"],
+			"resolved": {
+				"users": {"10": {"id": "10", "username": "ada"}, "30": {"id": "30", "username": "bo"}},
+				"members": {"10": {"nick": "Ada", "roles": [], "joined_at": "2024-01-01T00:00:00Z"}},
+				"roles": {"20": {"id": "20", "name": "mods"}}
+			}
+		}
+	}`
+	var i Interaction
+	if err := json.Unmarshal([]byte(payload), &i); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	users := i.Data.SelectedUsers()
+	if len(users) != 2 || users[0].ID != "30" || users[1].ID != "10" {
+		t.Fatalf("SelectedUsers = %+v", users)
+	}
+	members := i.Data.SelectedMembers()
+	if len(members) != 1 || members[0].Nick != "Ada" || members[0].User == nil || members[0].User.Username != "ada" {
+		t.Fatalf("SelectedMembers = %+v", members)
+	}
+	if roles := i.Data.SelectedRoles(); len(roles) != 1 || roles[0].Name != "mods" {
+		t.Fatalf("SelectedRoles = %+v", roles)
+	}
+	if channels := i.Data.SelectedChannels(); len(channels) != 0 {
+		t.Fatalf("SelectedChannels = %+v", channels)
+	}
+}
+
+func TestInteractionDataSelectedChannelsModalSubmit(t *testing.T) {
+	payload := `{
+		"id": "1",
+		"type": 5,
+		"token": "t",
+		"data": {
+			"custom_id": "setup",
+			"components": [{"type": 1, "components": [{"type": 8, "custom_id": "log_channel", "values": ["40"]}]}],
+			"resolved": {"channels": {"40": {"id": "40", "name": "logs", "type": 0}}}
+		}
+	}`
+	var i Interaction
+	if err := json.Unmarshal([]byte(payload), &i); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	row := i.Data.Components[0].Components[0]
+	if row.CustomID != "log_channel" || len(row.Values) != 1 {
+		t.Fatalf("unexpected submitted component %+v", row)
+	}
+	// Modal submits report values per component; interactions.ModalData
+	// builds this for callers.
+	data := InteractionData{Values: row.Values, Resolved: i.Data.Resolved}
+	if channels := data.SelectedChannels(); len(channels) != 1 || channels[0].Name != "logs" {
+		t.Fatalf("SelectedChannels = %+v", channels)
+	}
+
+	var nilData *InteractionData
+	if nilData.SelectedUsers() != nil {
+		t.Fatalf("expected nil for nil data")
+	}
+}
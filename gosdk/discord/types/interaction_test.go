@@ -6,7 +6,7 @@ import (
 )
 
 func TestInteractionValidate(t *testing.T) {
-	inter := &Interaction{ID: "123", Token: "token"}
+	inter := &Interaction{ID: "123", Token: "token", Type: InteractionTypePing}
 	if err := inter.Validate(); err != nil {
 		t.Fatalf("expected valid interaction: %v", err)
 	}
@@ -15,6 +15,25 @@ func TestInteractionValidate(t *testing.T) {
 	if err := inter.Validate(); err == nil {
 		t.Fatalf("expected error for missing ID")
 	}
+
+	tests := []struct {
+		name  string
+		inter Interaction
+		valid bool
+	}{
+		{"unknown type", Interaction{Type: 9}, false},
+		{"command without data", Interaction{Type: InteractionTypeApplicationCommand}, false},
+		{"command", Interaction{Type: InteractionTypeApplicationCommand, Data: &InteractionData{Name: "ping"}}, true},
+		{"autocomplete without name", Interaction{Type: InteractionTypeApplicationCommandAutocomplete, Data: &InteractionData{}}, false},
+		{"component without custom ID", Interaction{Type: InteractionTypeMessageComponent, Data: &InteractionData{}}, false},
+		{"modal", Interaction{Type: InteractionTypeModalSubmit, Data: &InteractionData{CustomID: "form"}}, true},
+	}
+	for _, tt := range tests {
+		tt.inter.ID, tt.inter.Token = "1", "token"
+		if err := tt.inter.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() error = %v, want valid=%v", tt.name, err, tt.valid)
+		}
+	}
 }
 
 func TestApplicationCommandValidate(t *testing.T) {
@@ -158,3 +177,33 @@ func TestInteractionResponseValidate_Modal(t *testing.T) {
 		t.Fatal("expected error when modal action row has multiple text inputs")
 	}
 }
+
+func TestInteractionResponseValidate_Files(t *testing.T) {
+	file := FileAttachment{Name: "a.txt", Reader: strings.NewReader("a")}
+	resp := &InteractionResponse{
+		Type:  InteractionResponseChannelMessageWithSource,
+		Data:  &InteractionApplicationCommandCallbackData{},
+		Files: []FileAttachment{file},
+	}
+	if err := resp.Validate(); err != nil {
+		t.Fatalf("expected files on a message response to be valid: %v", err)
+	}
+
+	deferred := &InteractionResponse{Type: InteractionResponseDeferredChannelMessageWithSource, Files: []FileAttachment{file}}
+	if err := deferred.Validate(); err == nil {
+		t.Fatalf("expected error for files on a deferred response")
+	}
+
+	resp.Files = make([]FileAttachment, 11)
+	for i := range resp.Files {
+		resp.Files[i] = file
+	}
+	if err := resp.Validate(); err == nil {
+		t.Fatalf("expected error for too many files")
+	}
+
+	resp.Files = []FileAttachment{{Name: "a.txt"}}
+	if err := resp.Validate(); err == nil {
+		t.Fatalf("expected error for a file without a reader")
+	}
+}
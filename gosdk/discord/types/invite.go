@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// InviteType identifies what an invite joins.
+type InviteType int
+
+const (
+	InviteTypeGuild   InviteType = 0
+	InviteTypeGroupDM InviteType = 1
+	InviteTypeFriend  InviteType = 2
+)
+
+// InviteTargetType identifies what a voice channel invite points at.
+type InviteTargetType int
+
+const (
+	InviteTargetStream              InviteTargetType = 1
+	InviteTargetEmbeddedApplication InviteTargetType = 2
+)
+
+// Invite is a code that grants access to a guild or channel. The metadata
+// fields (uses, max age, creation time) are only set on invites returned by
+// the channel and guild listing and creation endpoints.
+type Invite struct {
+	Type                     InviteType       `json:"type"`
+	Code                     string           `json:"code"`
+	Guild                    *Guild           `json:"guild,omitempty"`
+	Channel                  *Channel         `json:"channel,omitempty"`
+	Inviter                  *User            `json:"inviter,omitempty"`
+	TargetType               InviteTargetType `json:"target_type,omitempty"`
+	TargetUser               *User            `json:"target_user,omitempty"`
+	TargetApplication        *Application     `json:"target_application,omitempty"`
+	ApproximatePresenceCount int              `json:"approximate_presence_count,omitempty"`
+	ApproximateMemberCount   int              `json:"approximate_member_count,omitempty"`
+	ExpiresAt                *time.Time       `json:"expires_at,omitempty"`
+
+	Uses      int        `json:"uses,omitempty"`
+	MaxUses   int        `json:"max_uses,omitempty"`
+	MaxAge    int        `json:"max_age,omitempty"`
+	Temporary bool       `json:"temporary,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// URL returns the shareable discord.gg link for the invite.
+func (i *Invite) URL() string {
+	return "https://discord.gg/" + i.Code
+}
+
+// GetInviteParams controls the optional data returned by GET /invites/{code}.
+type GetInviteParams struct {
+	WithCounts            bool
+	WithExpiration        bool
+	GuildScheduledEventID string
+}
+
+// CreateChannelInviteParams configures a new channel invite. MaxAge and
+// MaxUses are pointers so 0 (never expires, unlimited) can be sent
+// explicitly; nil uses Discord's defaults of 24 hours and unlimited.
+type CreateChannelInviteParams struct {
+	MaxAge              *int             `json:"max_age,omitempty"`
+	MaxUses             *int             `json:"max_uses,omitempty"`
+	Temporary           bool             `json:"temporary,omitempty"`
+	Unique              bool             `json:"unique,omitempty"`
+	TargetType          InviteTargetType `json:"target_type,omitempty"`
+	TargetUserID        string           `json:"target_user_id,omitempty"`
+	TargetApplicationID string           `json:"target_application_id,omitempty"`
+	AuditLogReason      string           `json:"-"`
+}
+
+// Validate ensures invite parameters are within Discord's limits.
+func (p *CreateChannelInviteParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAge != nil && (*p.MaxAge < 0 || *p.MaxAge > limits.InviteMaxAge) {
+		return &ValidationError{Field: "max_age", Message: fmt.Sprintf("max_age must be between 0 and %d seconds", limits.InviteMaxAge)}
+	}
+	if p.MaxUses != nil && (*p.MaxUses < 0 || *p.MaxUses > limits.InviteMaxUses) {
+		return &ValidationError{Field: "max_uses", Message: fmt.Sprintf("max_uses must be between 0 and %d", limits.InviteMaxUses)}
+	}
+	switch p.TargetType {
+	case 0:
+		if p.TargetUserID != "" || p.TargetApplicationID != "" {
+			return &ValidationError{Field: "target_type", Message: "target_type is required when a target is set"}
+		}
+	case InviteTargetStream:
+		if p.TargetUserID == "" {
+			return &ValidationError{Field: "target_user_id", Message: "stream invites require target_user_id"}
+		}
+	case InviteTargetEmbeddedApplication:
+		if p.TargetApplicationID == "" {
+			return &ValidationError{Field: "target_application_id", Message: "embedded application invites require target_application_id"}
+		}
+	default:
+		return &ValidationError{Field: "target_type", Message: "unknown invite target type"}
+	}
+	return nil
+}
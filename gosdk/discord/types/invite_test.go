@@ -0,0 +1,33 @@
+package types
+
+import "testing"
+
+func TestCreateChannelInviteParamsValidate(t *testing.T) {
+	n := func(v int) *int { return &v }
+	valid := []*CreateChannelInviteParams{
+		nil,
+		{},
+		{MaxAge: n(0), MaxUses: n(0)},
+		{TargetType: InviteTargetStream, TargetUserID: "1"},
+		{TargetType: InviteTargetEmbeddedApplication, TargetApplicationID: "2"},
+	}
+	for _, p := range valid {
+		if err := p.Validate(); err != nil {
+			t.Fatalf("expected %+v to be valid, got %v", p, err)
+		}
+	}
+
+	invalid := map[string]*CreateChannelInviteParams{
+		"max age":     {MaxAge: n(604801)},
+		"max uses":    {MaxUses: n(101)},
+		"stream":      {TargetType: InviteTargetStream},
+		"application": {TargetType: InviteTargetEmbeddedApplication},
+		"no type":     {TargetUserID: "1"},
+		"unknown":     {TargetType: 9},
+	}
+	for name, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
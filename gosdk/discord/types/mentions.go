@@ -0,0 +1,117 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// AllowedMentionType is a mention category in allowed_mentions.parse.
+type AllowedMentionType string
+
+const (
+	// AllowedMentionUsers pings every user mentioned in the content.
+	AllowedMentionUsers AllowedMentionType = "users"
+	// AllowedMentionRoles pings every role mentioned in the content.
+	AllowedMentionRoles AllowedMentionType = "roles"
+	// AllowedMentionEveryone lets @everyone and @here ping.
+	AllowedMentionEveryone AllowedMentionType = "everyone"
+)
+
+// AllowedMentions controls which mentions in a message notify anyone. A
+// non-nil value with nothing set suppresses every ping.
+type AllowedMentions struct {
+	Parse       []AllowedMentionType `json:"parse,omitempty"`
+	Roles       []string             `json:"roles,omitempty"`
+	Users       []string             `json:"users,omitempty"`
+	RepliedUser bool                 `json:"replied_user,omitempty"`
+}
+
+// NoMentions returns a policy that suppresses every ping, including the reply
+// ping.
+func NoMentions() *AllowedMentions {
+	return &AllowedMentions{}
+}
+
+// Validate checks the policy against Discord's rules: parse values must be
+// known, and a category can't be both parsed and listed explicitly.
+func (a *AllowedMentions) Validate() error {
+	if a == nil {
+		return nil
+	}
+	for _, p := range a.Parse {
+		switch p {
+		case AllowedMentionUsers:
+			if len(a.Users) > 0 {
+				return &ValidationError{Field: "allowed_mentions.users", Message: "cannot list users while parsing all user mentions"}
+			}
+		case AllowedMentionRoles:
+			if len(a.Roles) > 0 {
+				return &ValidationError{Field: "allowed_mentions.roles", Message: "cannot list roles while parsing all role mentions"}
+			}
+		case AllowedMentionEveryone:
+		default:
+			return &ValidationError{Field: "allowed_mentions.parse", Message: fmt.Sprintf("unknown mention type %q", p)}
+		}
+	}
+	if len(a.Users) > limits.AllowedMentionIDs {
+		return &ValidationError{Field: "allowed_mentions.users", Message: fmt.Sprintf("cannot exceed %d users", limits.AllowedMentionIDs)}
+	}
+	if len(a.Roles) > limits.AllowedMentionIDs {
+		return &ValidationError{Field: "allowed_mentions.roles", Message: fmt.Sprintf("cannot exceed %d roles", limits.AllowedMentionIDs)}
+	}
+	return nil
+}
+
+// AllowedMentionsBuilder provides a fluent API for constructing an
+// AllowedMentions policy. It starts from NoMentions, so only what is added
+// pings.
+type AllowedMentionsBuilder struct {
+	mentions AllowedMentions
+}
+
+// NewAllowedMentions returns a builder for a policy that pings nothing until
+// configured.
+func NewAllowedMentions() *AllowedMentionsBuilder {
+	return &AllowedMentionsBuilder{}
+}
+
+// Parse lets every mention of the given categories ping.
+func (b *AllowedMentionsBuilder) Parse(kinds ...AllowedMentionType) *AllowedMentionsBuilder {
+	b.mentions.Parse = append(b.mentions.Parse, kinds...)
+	return b
+}
+
+// Users lets the given users ping.
+func (b *AllowedMentionsBuilder) Users(ids ...string) *AllowedMentionsBuilder {
+	b.mentions.Users = append(b.mentions.Users, ids...)
+	return b
+}
+
+// Roles lets the given roles ping.
+func (b *AllowedMentionsBuilder) Roles(ids ...string) *AllowedMentionsBuilder {
+	b.mentions.Roles = append(b.mentions.Roles, ids...)
+	return b
+}
+
+// RepliedUser controls whether a reply pings the author of the referenced
+// message.
+func (b *AllowedMentionsBuilder) RepliedUser(ping bool) *AllowedMentionsBuilder {
+	b.mentions.RepliedUser = ping
+	return b
+}
+
+// Build returns the configured policy or a validation error.
+func (b *AllowedMentionsBuilder) Build() (*AllowedMentions, error) {
+	mentions := AllowedMentions{
+		Parse:       slices.Clone(b.mentions.Parse),
+		Users:       slices.Clone(b.mentions.Users),
+		Roles:       slices.Clone(b.mentions.Roles),
+		RepliedUser: b.mentions.RepliedUser,
+	}
+	if err := mentions.Validate(); err != nil {
+		return nil, err
+	}
+	return &mentions, nil
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+func TestAllowedMentionsBuilder(t *testing.T) {
+	am, err := NewAllowedMentions().
+		Parse(AllowedMentionEveryone).
+		Users("1", "2").
+		RepliedUser(true).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	data, _ := json.Marshal(am)
+	if got := string(data); got != `{"parse":["everyone"],"users":["1","2"],"replied_user":true}` {
+		t.Fatalf("unexpected JSON %s", got)
+	}
+
+	none, _ := json.Marshal(NoMentions())
+	if string(none) != `{}` {
+		t.Fatalf("NoMentions() = %s, want {}", none)
+	}
+}
+
+func TestAllowedMentionsBuilderCopies(t *testing.T) {
+	b := NewAllowedMentions().Users("1")
+	first, _ := b.Build()
+	b.Users("2")
+	if len(first.Users) != 1 {
+		t.Fatalf("later builder calls changed a built policy: %v", first.Users)
+	}
+}
+
+func TestAllowedMentionsValidate(t *testing.T) {
+	many := make([]string, 101)
+	for i := range many {
+		many[i] = strconv.Itoa(i)
+	}
+	tests := []struct {
+		name    string
+		am      *AllowedMentions
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"empty", NoMentions(), false},
+		{"parse and list users", &AllowedMentions{Parse: []AllowedMentionType{AllowedMentionUsers}, Users: []string{"1"}}, true},
+		{"parse and list roles", &AllowedMentions{Parse: []AllowedMentionType{AllowedMentionRoles}, Roles: []string{"1"}}, true},
+		{"parse users, list roles", &AllowedMentions{Parse: []AllowedMentionType{AllowedMentionUsers}, Roles: []string{"1"}}, false},
+		{"unknown type", &AllowedMentions{Parse: []AllowedMentionType{"channels"}}, true},
+		{"too many users", &AllowedMentions{Users: many}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.am.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewAllowedMentions().Parse(AllowedMentionUsers).Users("1").Build(); err == nil {
+		t.Fatal("Build() should reject conflicting settings")
+	}
+}
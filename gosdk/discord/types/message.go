@@ -15,6 +15,12 @@ type Message struct {
 	Attachments     []Attachment `json:"attachments,omitempty"`
 	Mentions        []User       `json:"mentions,omitempty"`
 	Flags           int          `json:"flags,omitempty"`
+
+	Components []MessageComponent `json:"components,omitempty"`
+	Poll       *Poll              `json:"poll,omitempty"`
+
+	MessageReference *MessageReference `json:"message_reference,omitempty"`
+	MessageSnapshots []MessageSnapshot `json:"message_snapshots,omitempty"`
 }
 
 // User represents a Discord user
@@ -23,6 +29,7 @@ type User struct {
 	Username      string `json:"username"`
 	Discriminator string `json:"discriminator"`
 	Avatar        string `json:"avatar,omitempty"`
+	Banner        string `json:"banner,omitempty"`
 	Bot           bool   `json:"bot,omitempty"`
 }
 
@@ -67,26 +74,47 @@ type EmbedField struct {
 	Inline bool   `json:"inline,omitempty"`
 }
 
-// Attachment represents a message attachment
+// Attachment represents a message attachment. When sent in a request only ID
+// (an existing attachment ID or the index of an uploaded file), Filename, and
+// Description are read, so the remaining fields are omitted when empty.
 type Attachment struct {
-	ID       string `json:"id"`
-	Filename string `json:"filename"`
-	Size     int    `json:"size"`
-	URL      string `json:"url"`
-	ProxyURL string `json:"proxy_url"`
-	Height   int    `json:"height,omitempty"`
-	Width    int    `json:"width,omitempty"`
+	ID          string `json:"id"`
+	Filename    string `json:"filename,omitempty"`
+	Description string `json:"description,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int    `json:"size,omitempty"`
+	URL         string `json:"url,omitempty"`
+	ProxyURL    string `json:"proxy_url,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Width       int    `json:"width,omitempty"`
 }
 
 // MessageCreateParams represents parameters for creating a message
 type MessageCreateParams struct {
-	Content string  `json:"content,omitempty"`
-	Embeds  []Embed `json:"embeds,omitempty"`
-	// Add more fields as needed (components, attachments, etc.)
+	Content         string             `json:"content,omitempty"`
+	Embeds          []Embed            `json:"embeds,omitempty"`
+	Components      []MessageComponent `json:"components,omitempty"`
+	AllowedMentions *AllowedMentions   `json:"allowed_mentions,omitempty"`
+	Poll            *Poll              `json:"poll,omitempty"`
+	// MessageReference makes the message a reply, or with
+	// MessageReferenceForward a forward of another message.
+	MessageReference *MessageReference `json:"message_reference,omitempty"`
+	// RepliedUserPing, when set, overrides AllowedMentions.RepliedUser
+	// after the client's default mention policy has been applied.
+	RepliedUserPing *bool `json:"-"`
+	// Nonce identifies the send; with EnforceNonce, Discord returns the
+	// message already created with the same nonce in the last few minutes
+	// instead of posting another.
+	Nonce        string `json:"nonce,omitempty"`
+	EnforceNonce bool   `json:"enforce_nonce,omitempty"`
+	// Add more fields as needed (attachments, etc.)
 }
 
 // MessageEditParams represents editable message fields.
 type MessageEditParams struct {
-	Content string  `json:"content,omitempty"`
-	Embeds  []Embed `json:"embeds,omitempty"`
+	Content         string             `json:"content,omitempty"`
+	Embeds          []Embed            `json:"embeds,omitempty"`
+	Components      []MessageComponent `json:"components,omitempty"`
+	AllowedMentions *AllowedMentions   `json:"allowed_mentions,omitempty"`
+	Flags           int                `json:"flags,omitempty"`
 }
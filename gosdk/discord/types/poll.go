@@ -0,0 +1,124 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// PollLayoutType selects how a poll is rendered.
+type PollLayoutType int
+
+const (
+	// PollLayoutDefault is the only layout Discord currently supports.
+	PollLayoutDefault PollLayoutType = 1
+)
+
+// PollMedia is the text (and optional emoji) of a poll question or answer.
+type PollMedia struct {
+	Text  string `json:"text,omitempty"`
+	Emoji *Emoji `json:"emoji,omitempty"`
+}
+
+// PollAnswer is a single poll choice. AnswerID is assigned by Discord.
+type PollAnswer struct {
+	AnswerID  int       `json:"answer_id,omitempty"`
+	PollMedia PollMedia `json:"poll_media"`
+}
+
+// Poll describes a message poll. When creating a poll set Duration (hours);
+// Discord reports Expiry on polls it returns.
+type Poll struct {
+	Question         PollMedia      `json:"question"`
+	Answers          []PollAnswer   `json:"answers"`
+	Duration         int            `json:"duration,omitempty"`
+	Expiry           *time.Time     `json:"expiry,omitempty"`
+	AllowMultiselect bool           `json:"allow_multiselect,omitempty"`
+	LayoutType       PollLayoutType `json:"layout_type,omitempty"`
+	// Results is set on polls Discord returns. Counts may lag behind votes
+	// until IsFinalized.
+	Results *PollResults `json:"results,omitempty"`
+}
+
+// PollResults holds the vote counts of a poll.
+type PollResults struct {
+	IsFinalized  bool              `json:"is_finalized"`
+	AnswerCounts []PollAnswerCount `json:"answer_counts"`
+}
+
+// PollAnswerCount is the vote tally for one answer. Answers without votes
+// are left out.
+type PollAnswerCount struct {
+	ID      int  `json:"id"`
+	Count   int  `json:"count"`
+	MeVoted bool `json:"me_voted"`
+}
+
+// Count returns the votes for answerID.
+func (r *PollResults) Count(answerID int) int {
+	if r == nil {
+		return 0
+	}
+	for _, c := range r.AnswerCounts {
+		if c.ID == answerID {
+			return c.Count
+		}
+	}
+	return 0
+}
+
+// Answer returns the answer with answerID, or nil.
+func (p *Poll) Answer(answerID int) *PollAnswer {
+	for i := range p.Answers {
+		if p.Answers[i].AnswerID == answerID {
+			return &p.Answers[i]
+		}
+	}
+	return nil
+}
+
+// NewPoll builds a single-choice poll with text answers lasting duration hours.
+func NewPoll(question string, duration int, answers ...string) *Poll {
+	p := &Poll{Question: PollMedia{Text: question}, Duration: duration}
+	for _, answer := range answers {
+		p.Answers = append(p.Answers, PollAnswer{PollMedia: PollMedia{Text: answer}})
+	}
+	return p
+}
+
+// Validate checks the poll against Discord's creation limits.
+func (p *Poll) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if strings.TrimSpace(p.Question.Text) == "" {
+		return &ValidationError{Field: "poll.question", Message: "question text is required"}
+	}
+	if utf8.RuneCountInString(p.Question.Text) > limits.PollQuestionLength {
+		return &ValidationError{Field: "poll.question", Message: fmt.Sprintf("question exceeds %d characters", limits.PollQuestionLength)}
+	}
+	if len(p.Answers) == 0 {
+		return &ValidationError{Field: "poll.answers", Message: "at least one answer is required"}
+	}
+	if len(p.Answers) > limits.PollAnswers {
+		return &ValidationError{Field: "poll.answers", Message: fmt.Sprintf("maximum %d answers allowed", limits.PollAnswers)}
+	}
+	for i, answer := range p.Answers {
+		if strings.TrimSpace(answer.PollMedia.Text) == "" {
+			return &ValidationError{Field: fmt.Sprintf("poll.answers[%d]", i), Message: "answer text is required"}
+		}
+		if utf8.RuneCountInString(answer.PollMedia.Text) > limits.PollAnswerLength {
+			return &ValidationError{Field: fmt.Sprintf("poll.answers[%d]", i), Message: fmt.Sprintf("answer exceeds %d characters", limits.PollAnswerLength)}
+		}
+	}
+	if p.Duration < 0 || p.Duration > limits.PollDurationHours {
+		return &ValidationError{Field: "poll.duration", Message: fmt.Sprintf("duration cannot exceed %d hours", limits.PollDurationHours)}
+	}
+	if p.LayoutType != 0 && p.LayoutType != PollLayoutDefault {
+		return &ValidationError{Field: "poll.layout_type", Message: "unsupported poll layout"}
+	}
+	return nil
+}
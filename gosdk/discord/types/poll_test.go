@@ -0,0 +1,88 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPollValidate(t *testing.T) {
+	poll := NewPoll("Lunch?", 24, "Pizza", "Tacos")
+	if err := poll.Validate(); err != nil {
+		t.Fatalf("expected valid poll, got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		poll  *Poll
+		field string
+	}{
+		{"missing question", NewPoll(" ", 24, "a"), "poll.question"},
+		{"long question", NewPoll(strings.Repeat("q", 301), 24, "a"), "poll.question"},
+		{"no answers", NewPoll("q", 24), "poll.answers"},
+		{"too many answers", NewPoll("q", 24, "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"), "poll.answers"},
+		{"empty answer", NewPoll("q", 24, "a", ""), "poll.answers[1]"},
+		{"long answer", NewPoll("q", 24, strings.Repeat("a", 56)), "poll.answers[0]"},
+		{"long duration", NewPoll("q", 769, "a"), "poll.duration"},
+		{"bad layout", &Poll{Question: PollMedia{Text: "q"}, Answers: []PollAnswer{{PollMedia: PollMedia{Text: "a"}}}, LayoutType: 9}, "poll.layout_type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var vErr *ValidationError
+			if err := tt.poll.Validate(); !errors.As(err, &vErr) || vErr.Field != tt.field {
+				t.Fatalf("expected %s validation error, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestPollJSON(t *testing.T) {
+	data, err := json.Marshal(NewPoll("Lunch?", 0, "Pizza"))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"question":{"text":"Lunch?"},"answers":[{"poll_media":{"text":"Pizza"}}]}`
+	if string(data) != want {
+		t.Fatalf("unexpected JSON:\n got %s\nwant %s", data, want)
+	}
+}
+
+func TestWebhookMessageComponentsAndPoll(t *testing.T) {
+	link := MessageComponent{Type: ComponentTypeButton, Style: int(ButtonStyleLink), Label: "Docs", URL: "https://example.com"}
+	msg := &WebhookMessage{Components: []MessageComponent{{Type: ComponentTypeActionRow, Components: []MessageComponent{link}}}}
+	if err := msg.Validate(); err != nil {
+		t.Fatalf("expected link buttons to be valid, got %v", err)
+	}
+
+	if err := (&WebhookMessage{Poll: NewPoll("Lunch?", 24, "Pizza")}).Validate(); err != nil {
+		t.Fatalf("expected poll-only message to be valid, got %v", err)
+	}
+
+	custom := MessageComponent{Type: ComponentTypeButton, Style: int(ButtonStylePrimary), Label: "Go", CustomID: "go"}
+	msg.Components[0].Components = []MessageComponent{custom}
+	if err := msg.Validate(); err == nil {
+		t.Fatal("expected interactive button to be rejected")
+	}
+
+	if err := (&WebhookMessage{Poll: NewPoll("", 24, "Pizza")}).Validate(); err == nil {
+		t.Fatal("expected invalid poll to be rejected")
+	}
+}
+
+func TestPollResultsDecode(t *testing.T) {
+	data := `{"question":{"text":"Lunch?"},"answers":[{"answer_id":1,"poll_media":{"text":"Pizza"}},{"answer_id":2,"poll_media":{"text":"Sushi"}}],"results":{"is_finalized":true,"answer_counts":[{"id":2,"count":5,"me_voted":true}]}}`
+	var p Poll
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !p.Results.IsFinalized || p.Results.Count(2) != 5 || p.Results.Count(1) != 0 {
+		t.Fatalf("unexpected results %+v", p.Results)
+	}
+	if a := p.Answer(2); a == nil || a.PollMedia.Text != "Sushi" {
+		t.Fatalf("Answer(2) = %+v", a)
+	}
+	if p.Answer(9) != nil {
+		t.Fatal("expected nil for unknown answer")
+	}
+}
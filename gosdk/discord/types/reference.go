@@ -0,0 +1,119 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// MessageReferenceType selects how a message_reference is interpreted.
+type MessageReferenceType int
+
+const (
+	// MessageReferenceDefault makes the new message a reply to the referenced one.
+	MessageReferenceDefault MessageReferenceType = 0
+	// MessageReferenceForward copies the referenced message into the channel
+	// as a snapshot.
+	MessageReferenceForward MessageReferenceType = 1
+)
+
+// MessageReference points at another message, for replies and forwards.
+type MessageReference struct {
+	Type      MessageReferenceType `json:"type,omitempty"`
+	MessageID string               `json:"message_id,omitempty"`
+	ChannelID string               `json:"channel_id,omitempty"`
+	GuildID   string               `json:"guild_id,omitempty"`
+	// FailIfNotExists makes replies to deleted messages fail instead of
+	// being sent as normal messages. Discord defaults it to true.
+	FailIfNotExists *bool `json:"fail_if_not_exists,omitempty"`
+}
+
+// MessageSnapshot holds the copy of a forwarded message.
+type MessageSnapshot struct {
+	Message *Message `json:"message"`
+}
+
+// Validate checks the reference before it is sent.
+func (r *MessageReference) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.MessageID == "" {
+		return &ValidationError{Field: "message_reference.message_id", Message: "message ID is required"}
+	}
+	switch r.Type {
+	case MessageReferenceDefault:
+	case MessageReferenceForward:
+		if r.ChannelID == "" {
+			return &ValidationError{Field: "message_reference.channel_id", Message: "channel ID is required to forward a message"}
+		}
+	default:
+		return &ValidationError{Field: "message_reference.type", Message: "unknown message reference type"}
+	}
+	return nil
+}
+
+// ReplyTo makes the message a reply to messageID in the same channel.
+func (p *MessageCreateParams) ReplyTo(messageID string) *MessageCreateParams {
+	p.MessageReference = &MessageReference{MessageID: messageID}
+	return p
+}
+
+// FailIfNotExists controls whether a reply to a deleted message fails
+// (the default) or is sent as a normal message. It has no effect without
+// a reference, so call it after ReplyTo.
+func (p *MessageCreateParams) FailIfNotExists(fail bool) *MessageCreateParams {
+	if p.MessageReference != nil {
+		p.MessageReference.FailIfNotExists = &fail
+	}
+	return p
+}
+
+// MentionRepliedUser controls whether a reply pings the author of the
+// referenced message, without otherwise changing the mention policy.
+func (p *MessageCreateParams) MentionRepliedUser(ping bool) *MessageCreateParams {
+	p.RepliedUserPing = &ping
+	return p
+}
+
+// WithRepliedUser returns a copy of the policy with RepliedUser set. A nil
+// policy stands for Discord's default of parsing every mention.
+func (a *AllowedMentions) WithRepliedUser(ping bool) *AllowedMentions {
+	var out AllowedMentions
+	if a == nil {
+		out.Parse = []AllowedMentionType{AllowedMentionUsers, AllowedMentionRoles, AllowedMentionEveryone}
+	} else {
+		out = *a
+	}
+	out.RepliedUser = ping
+	return &out
+}
+
+// Validate checks the create params before they are sent. A forward carries
+// only the reference, so it cannot be combined with content, embeds,
+// components or a poll.
+func (p *MessageCreateParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "message create params required"}
+	}
+	if err := p.AllowedMentions.Validate(); err != nil {
+		return err
+	}
+	if err := p.Poll.Validate(); err != nil {
+		return err
+	}
+	if len(p.Nonce) > limits.MessageNonceLength {
+		return &ValidationError{Field: "nonce", Message: fmt.Sprintf("nonce must be %d or fewer characters", limits.MessageNonceLength)}
+	}
+	if p.EnforceNonce && p.Nonce == "" {
+		return &ValidationError{Field: "enforce_nonce", Message: "enforce_nonce requires a nonce"}
+	}
+	if err := p.MessageReference.Validate(); err != nil {
+		return err
+	}
+	if p.MessageReference != nil && p.MessageReference.Type == MessageReferenceForward &&
+		(p.Content != "" || len(p.Embeds) > 0 || len(p.Components) > 0 || p.Poll != nil) {
+		return &ValidationError{Field: "message_reference", Message: "forwarded messages cannot have content, embeds, components or a poll"}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package types
+
+import "testing"
+
+func TestMessageReferenceValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		ref   *MessageReference
+		valid bool
+	}{
+		{"nil", nil, true},
+		{"reply", &MessageReference{MessageID: "1"}, true},
+		{"reply without ID", &MessageReference{}, false},
+		{"forward", &MessageReference{Type: MessageReferenceForward, MessageID: "1", ChannelID: "2"}, true},
+		{"forward without channel", &MessageReference{Type: MessageReferenceForward, MessageID: "1"}, false},
+		{"unknown type", &MessageReference{Type: 7, MessageID: "1"}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.ref.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() error = %v, want valid=%v", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestMessageCreateParamsValidate(t *testing.T) {
+	var nilParams *MessageCreateParams
+	if err := nilParams.Validate(); err == nil {
+		t.Fatal("expected error for nil params")
+	}
+
+	reply := &MessageCreateParams{Content: "hi", MessageReference: &MessageReference{MessageID: "1"}}
+	if err := reply.Validate(); err != nil {
+		t.Fatalf("expected reply with content to be valid: %v", err)
+	}
+
+	forward := &MessageCreateParams{
+		Embeds:           []Embed{{Title: "x"}},
+		MessageReference: &MessageReference{Type: MessageReferenceForward, MessageID: "1", ChannelID: "2"},
+	}
+	if err := forward.Validate(); err == nil {
+		t.Fatal("expected error for a forward with embeds")
+	}
+}
+
+func TestAllowedMentionsWithRepliedUser(t *testing.T) {
+	var none *AllowedMentions
+	all := none.WithRepliedUser(false)
+	if len(all.Parse) != 3 || all.RepliedUser {
+		t.Fatalf("nil policy should expand to parse-all: %+v", all)
+	}
+	own := &AllowedMentions{Users: []string{"1"}}
+	withPing := own.WithRepliedUser(true)
+	if !withPing.RepliedUser || own.RepliedUser || len(withPing.Users) != 1 {
+		t.Fatalf("WithRepliedUser should copy: own=%+v copy=%+v", own, withPing)
+	}
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// RoleConnectionMetadataType is how Discord compares a user's metadata value
+// with the value a guild's linked role requires.
+type RoleConnectionMetadataType int
+
+const (
+	RoleConnectionIntegerLessThanOrEqual     RoleConnectionMetadataType = 1
+	RoleConnectionIntegerGreaterThanOrEqual  RoleConnectionMetadataType = 2
+	RoleConnectionIntegerEqual               RoleConnectionMetadataType = 3
+	RoleConnectionIntegerNotEqual            RoleConnectionMetadataType = 4
+	RoleConnectionDatetimeLessThanOrEqual    RoleConnectionMetadataType = 5
+	RoleConnectionDatetimeGreaterThanOrEqual RoleConnectionMetadataType = 6
+	RoleConnectionBooleanEqual               RoleConnectionMetadataType = 7
+	RoleConnectionBooleanNotEqual            RoleConnectionMetadataType = 8
+)
+
+// ApplicationRoleConnectionMetadata describes one value an application
+// reports for its users, which guild admins can require for a linked role.
+type ApplicationRoleConnectionMetadata struct {
+	Type                     RoleConnectionMetadataType `json:"type"`
+	Key                      string                     `json:"key"`
+	Name                     string                     `json:"name"`
+	NameLocalizations        map[string]string          `json:"name_localizations,omitempty"`
+	Description              string                     `json:"description"`
+	DescriptionLocalizations map[string]string          `json:"description_localizations,omitempty"`
+}
+
+// Validate ensures the metadata record is within Discord's limits.
+func (m *ApplicationRoleConnectionMetadata) Validate() error {
+	if m.Type < RoleConnectionIntegerLessThanOrEqual || m.Type > RoleConnectionBooleanNotEqual {
+		return &ValidationError{Field: "type", Message: "unknown role connection metadata type"}
+	}
+	if m.Key == "" || len(m.Key) > limits.RoleConnectionMetadataKeyLength {
+		return &ValidationError{Field: "key", Message: fmt.Sprintf("key must be between 1 and %d characters", limits.RoleConnectionMetadataKeyLength)}
+	}
+	for _, r := range m.Key {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+			return &ValidationError{Field: "key", Message: "key may only contain a-z, 0-9 and _"}
+		}
+	}
+	if m.Name == "" || len(m.Name) > limits.RoleConnectionMetadataNameLength {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("name must be between 1 and %d characters", limits.RoleConnectionMetadataNameLength)}
+	}
+	if m.Description == "" || len(m.Description) > limits.RoleConnectionMetadataDescriptionLength {
+		return &ValidationError{Field: "description", Message: fmt.Sprintf("description must be between 1 and %d characters", limits.RoleConnectionMetadataDescriptionLength)}
+	}
+	return nil
+}
+
+// ValidateRoleConnectionMetadata checks a full metadata set before it
+// replaces the application's records.
+func ValidateRoleConnectionMetadata(records []ApplicationRoleConnectionMetadata) error {
+	if len(records) > limits.RoleConnectionMetadataRecords {
+		return &ValidationError{Field: "metadata", Message: fmt.Sprintf("cannot exceed %d metadata records", limits.RoleConnectionMetadataRecords)}
+	}
+	seen := make(map[string]bool, len(records))
+	for i := range records {
+		if err := records[i].Validate(); err != nil {
+			return err
+		}
+		if seen[records[i].Key] {
+			return &ValidationError{Field: "key", Message: fmt.Sprintf("duplicate metadata key %q", records[i].Key)}
+		}
+		seen[records[i].Key] = true
+	}
+	return nil
+}
+
+// ApplicationRoleConnection is a user's connection to an application, with
+// the metadata values Discord checks against linked role requirements.
+// Metadata values are strings: integers as decimal, datetimes as ISO8601 and
+// booleans as "1" or "0". Use the Set helpers to encode them.
+type ApplicationRoleConnection struct {
+	PlatformName     string            `json:"platform_name,omitempty"`
+	PlatformUsername string            `json:"platform_username,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// SetInt stores an integer metadata value.
+func (c *ApplicationRoleConnection) SetInt(key string, v int64) *ApplicationRoleConnection {
+	return c.set(key, strconv.FormatInt(v, 10))
+}
+
+// SetTime stores a datetime metadata value.
+func (c *ApplicationRoleConnection) SetTime(key string, t time.Time) *ApplicationRoleConnection {
+	return c.set(key, t.UTC().Format(time.RFC3339))
+}
+
+// SetBool stores a boolean metadata value.
+func (c *ApplicationRoleConnection) SetBool(key string, v bool) *ApplicationRoleConnection {
+	if v {
+		return c.set(key, "1")
+	}
+	return c.set(key, "0")
+}
+
+func (c *ApplicationRoleConnection) set(key, value string) *ApplicationRoleConnection {
+	if c.Metadata == nil {
+		c.Metadata = make(map[string]string)
+	}
+	c.Metadata[key] = value
+	return c
+}
+
+// Validate ensures the role connection is within Discord's limits.
+func (c *ApplicationRoleConnection) Validate() error {
+	if c == nil {
+		return &ValidationError{Field: "connection", Message: "role connection required"}
+	}
+	if len(c.PlatformName) > limits.RoleConnectionPlatformNameLength {
+		return &ValidationError{Field: "platform_name", Message: fmt.Sprintf("platform name cannot exceed %d characters", limits.RoleConnectionPlatformNameLength)}
+	}
+	if len(c.PlatformUsername) > limits.RoleConnectionPlatformUsernameLength {
+		return &ValidationError{Field: "platform_username", Message: fmt.Sprintf("platform username cannot exceed %d characters", limits.RoleConnectionPlatformUsernameLength)}
+	}
+	for key, value := range c.Metadata {
+		if len(value) > limits.RoleConnectionMetadataValueLength {
+			return &ValidationError{Field: "metadata." + key, Message: fmt.Sprintf("value cannot exceed %d characters", limits.RoleConnectionMetadataValueLength)}
+		}
+	}
+	return nil
+}
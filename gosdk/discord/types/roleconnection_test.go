@@ -0,0 +1,37 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplicationRoleConnectionMetadataValidate(t *testing.T) {
+	valid := ApplicationRoleConnectionMetadata{Type: RoleConnectionBooleanEqual, Key: "is_staff", Name: "Staff", Description: "Member of staff"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid metadata, got %v", err)
+	}
+
+	cases := map[string]func(m *ApplicationRoleConnectionMetadata){
+		"type":        func(m *ApplicationRoleConnectionMetadata) { m.Type = 9 },
+		"key chars":   func(m *ApplicationRoleConnectionMetadata) { m.Key = "Is-Staff" },
+		"key length":  func(m *ApplicationRoleConnectionMetadata) { m.Key = strings.Repeat("k", 51) },
+		"name":        func(m *ApplicationRoleConnectionMetadata) { m.Name = "" },
+		"description": func(m *ApplicationRoleConnectionMetadata) { m.Description = strings.Repeat("d", 201) },
+	}
+	for name, mutate := range cases {
+		m := valid
+		mutate(&m)
+		if err := m.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+
+	if err := ValidateRoleConnectionMetadata(make([]ApplicationRoleConnectionMetadata, 6)); err == nil {
+		t.Fatal("expected error for too many records")
+	}
+
+	conn := &ApplicationRoleConnection{Metadata: map[string]string{"k": strings.Repeat("v", 101)}}
+	if err := conn.Validate(); err == nil {
+		t.Fatal("expected error for long metadata value")
+	}
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// ScreeningFieldTypeTerms is the rules form field members must accept.
+const ScreeningFieldTypeTerms = "TERMS"
+
+// MembershipScreening is a guild's membership screening form. New members
+// stay Pending until they complete it.
+type MembershipScreening struct {
+	// Version is the timestamp of the form's last change.
+	Version     string           `json:"version"`
+	FormFields  []ScreeningField `json:"form_fields"`
+	Description string           `json:"description,omitempty"`
+}
+
+// ScreeningField is one question on the screening form.
+type ScreeningField struct {
+	FieldType string   `json:"field_type"`
+	Label     string   `json:"label"`
+	Values    []string `json:"values,omitempty"`
+	Required  bool     `json:"required"`
+}
+
+// NewTermsField builds a required rules field listing rules.
+func NewTermsField(label string, rules ...string) ScreeningField {
+	return ScreeningField{FieldType: ScreeningFieldTypeTerms, Label: label, Values: rules, Required: true}
+}
+
+// MembershipScreeningModifyParams updates the screening form. Nil fields are
+// left unchanged; setting FormFields replaces every field.
+type MembershipScreeningModifyParams struct {
+	Enabled        *bool
+	FormFields     []ScreeningField
+	Description    *string
+	AuditLogReason string
+}
+
+// MarshalJSON encodes the payload. Discord expects form_fields as a JSON
+// encoded string rather than an array.
+func (p MembershipScreeningModifyParams) MarshalJSON() ([]byte, error) {
+	payload := struct {
+		Enabled     *bool   `json:"enabled,omitempty"`
+		FormFields  string  `json:"form_fields,omitempty"`
+		Description *string `json:"description,omitempty"`
+	}{Enabled: p.Enabled, Description: p.Description}
+	if p.FormFields != nil {
+		fields, err := json.Marshal(p.FormFields)
+		if err != nil {
+			return nil, err
+		}
+		payload.FormFields = string(fields)
+	}
+	return json.Marshal(payload)
+}
+
+// Validate ensures screening updates respect Discord's limits.
+func (p *MembershipScreeningModifyParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "membership screening params required"}
+	}
+	if p.Description != nil && len(*p.Description) > limits.ScreeningDescriptionLength {
+		return &ValidationError{Field: "description", Message: fmt.Sprintf("description cannot exceed %d characters", limits.ScreeningDescriptionLength)}
+	}
+	for i, field := range p.FormFields {
+		name := fmt.Sprintf("form_fields[%d]", i)
+		if field.FieldType != ScreeningFieldTypeTerms {
+			return &ValidationError{Field: name + ".field_type", Message: fmt.Sprintf("unsupported field type %q", field.FieldType)}
+		}
+		if field.Label == "" {
+			return &ValidationError{Field: name + ".label", Message: "label is required"}
+		}
+		if len(field.Values) == 0 || len(field.Values) > limits.ScreeningRules {
+			return &ValidationError{Field: name + ".values", Message: fmt.Sprintf("terms need between 1 and %d rules", limits.ScreeningRules)}
+		}
+		for _, rule := range field.Values {
+			if rule == "" || len(rule) > limits.ScreeningRuleLength {
+				return &ValidationError{Field: name + ".values", Message: fmt.Sprintf("rules must be between 1 and %d characters", limits.ScreeningRuleLength)}
+			}
+		}
+	}
+	return nil
+}
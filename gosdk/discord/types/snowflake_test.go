@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSnowflakeParts(t *testing.T) {
+	// Example from Discord's reference documentation.
+	s, err := ParseSnowflake("175928847299117063")
+	if err != nil {
+		t.Fatalf("ParseSnowflake() error = %v", err)
+	}
+	if got := s.Timestamp().UTC(); !got.Equal(time.Date(2016, 4, 30, 11, 18, 25, 796*int(time.Millisecond), time.UTC)) {
+		t.Fatalf("Timestamp() = %v", got)
+	}
+	if s.WorkerID() != 1 || s.ProcessID() != 0 || s.Increment() != 7 {
+		t.Fatalf("parts = %d/%d/%d", s.WorkerID(), s.ProcessID(), s.Increment())
+	}
+	if s.String() != "175928847299117063" {
+		t.Fatalf("String() = %s", s)
+	}
+	if _, err := ParseSnowflake("abc"); err == nil {
+		t.Fatalf("expected error for non-numeric ID")
+	}
+}
+
+func TestSnowflakeFromTimeAndCompare(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := SnowflakeFromTime(at)
+	if !s.Timestamp().Equal(at) || s.Increment() != 0 {
+		t.Fatalf("SnowflakeFromTime() = %d", s)
+	}
+	if SnowflakeFromTime(time.Unix(0, 0)) != 0 {
+		t.Fatalf("times before the epoch should map to 0")
+	}
+	later := s + 1
+	if !s.Before(later) || !later.After(s) || s.Compare(later) != -1 || later.Compare(s) != 1 || s.Compare(s) != 0 {
+		t.Fatalf("comparison helpers disagree")
+	}
+}
+
+func TestSnowflakeJSON(t *testing.T) {
+	type payload struct {
+		ID     Snowflake `json:"id"`
+		Parent Snowflake `json:"parent_id,omitempty"`
+	}
+	raw, err := json.Marshal(payload{ID: 42})
+	if err != nil || string(raw) != `{"id":"42"}` {
+		t.Fatalf("Marshal = %s, %v", raw, err)
+	}
+	for input, want := range map[string]Snowflake{`{"id":"42"}`: 42, `{"id":42}`: 42, `{"id":null}`: 0, `{"id":""}`: 0} {
+		var p payload
+		if err := json.Unmarshal([]byte(input), &p); err != nil || p.ID != want {
+			t.Fatalf("Unmarshal(%s) = %d, %v", input, p.ID, err)
+		}
+	}
+	var p payload
+	if err := json.Unmarshal([]byte(`{"id":"x"}`), &p); err == nil {
+		t.Fatalf("expected error for invalid ID")
+	}
+}
+
+func TestSnowflakeGenerator(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := NewSnowflakeGenerator(3, 4)
+	first := gen.At(at)
+	if first.WorkerID() != 3 || first.ProcessID() != 4 || !first.Timestamp().Equal(at) {
+		t.Fatalf("first = %d", first)
+	}
+	prev := first
+	for i := 0; i < 5000; i++ {
+		next := gen.At(at.Add(-time.Second))
+		if !next.After(prev) {
+			t.Fatalf("IDs went backwards at %d: %d <= %d", i, next, prev)
+		}
+		prev = next
+	}
+	if !gen.Next().After(prev) {
+		t.Fatalf("Next() should continue increasing")
+	}
+}
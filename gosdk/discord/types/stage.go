@@ -0,0 +1,88 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// StagePrivacyLevel controls who can see a live stage.
+type StagePrivacyLevel int
+
+const (
+	// StagePrivacyPublic is deprecated by Discord and rejected for new stages.
+	StagePrivacyPublic StagePrivacyLevel = 1
+	// StagePrivacyGuildOnly limits the stage to guild members.
+	StagePrivacyGuildOnly StagePrivacyLevel = 2
+)
+
+// StageInstance is a live stage in a stage channel.
+type StageInstance struct {
+	ID                    string            `json:"id"`
+	GuildID               string            `json:"guild_id"`
+	ChannelID             string            `json:"channel_id"`
+	Topic                 string            `json:"topic"`
+	PrivacyLevel          StagePrivacyLevel `json:"privacy_level"`
+	DiscoverableDisabled  bool              `json:"discoverable_disabled,omitempty"`
+	GuildScheduledEventID string            `json:"guild_scheduled_event_id,omitempty"`
+}
+
+// StageInstanceCreateParams starts a stage. The bot must be a stage moderator
+// (MANAGE_CHANNELS, MUTE_MEMBERS, and MOVE_MEMBERS) in the channel.
+type StageInstanceCreateParams struct {
+	ChannelID             string            `json:"channel_id"`
+	Topic                 string            `json:"topic"`
+	PrivacyLevel          StagePrivacyLevel `json:"privacy_level,omitempty"`
+	SendStartNotification bool              `json:"send_start_notification,omitempty"`
+	GuildScheduledEventID string            `json:"guild_scheduled_event_id,omitempty"`
+	AuditLogReason        string            `json:"-"`
+}
+
+// StageInstanceModifyParams updates a live stage.
+type StageInstanceModifyParams struct {
+	Topic          string            `json:"topic,omitempty"`
+	PrivacyLevel   StagePrivacyLevel `json:"privacy_level,omitempty"`
+	AuditLogReason string            `json:"-"`
+}
+
+// Validate ensures a stage can be started with these parameters.
+func (p *StageInstanceCreateParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "stage instance params required"}
+	}
+	if p.ChannelID == "" {
+		return &ValidationError{Field: "channel_id", Message: "channel ID is required"}
+	}
+	if err := validateStageTopic(p.Topic, true); err != nil {
+		return err
+	}
+	return validateStagePrivacy(p.PrivacyLevel)
+}
+
+// Validate ensures stage updates are within Discord's limits.
+func (p *StageInstanceModifyParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "stage instance params required"}
+	}
+	if err := validateStageTopic(p.Topic, false); err != nil {
+		return err
+	}
+	return validateStagePrivacy(p.PrivacyLevel)
+}
+
+func validateStageTopic(topic string, required bool) error {
+	if topic == "" && !required {
+		return nil
+	}
+	if topic == "" || len(topic) > limits.StageTopicLength {
+		return &ValidationError{Field: "topic", Message: fmt.Sprintf("topic must be between 1 and %d characters", limits.StageTopicLength)}
+	}
+	return nil
+}
+
+func validateStagePrivacy(level StagePrivacyLevel) error {
+	if level != 0 && level != StagePrivacyGuildOnly {
+		return &ValidationError{Field: "privacy_level", Message: "only guild-only stages can be created"}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStageInstanceParamsValidate(t *testing.T) {
+	if err := (&StageInstanceCreateParams{ChannelID: "1", Topic: "Town hall", PrivacyLevel: StagePrivacyGuildOnly}).Validate(); err != nil {
+		t.Fatalf("valid params rejected: %v", err)
+	}
+	invalid := []*StageInstanceCreateParams{
+		nil,
+		{Topic: "Town hall"},
+		{ChannelID: "1"},
+		{ChannelID: "1", Topic: strings.Repeat("a", 121)},
+		{ChannelID: "1", Topic: "Town hall", PrivacyLevel: StagePrivacyPublic},
+	}
+	for _, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Fatalf("expected error for %+v", p)
+		}
+	}
+
+	if err := (&StageInstanceModifyParams{}).Validate(); err != nil {
+		t.Fatalf("empty update rejected: %v", err)
+	}
+	if err := (&StageInstanceModifyParams{Topic: strings.Repeat("a", 121)}).Validate(); err == nil {
+		t.Fatal("expected error for long topic")
+	}
+}
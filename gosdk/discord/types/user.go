@@ -0,0 +1,72 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
+// ModifyCurrentUserParams edits the bot's own profile. Avatar and Banner are
+// image data URIs such as "data:image/png;base64,...".
+type ModifyCurrentUserParams struct {
+	Username *string `json:"username,omitempty"`
+	Avatar   *string `json:"avatar,omitempty"`
+	Banner   *string `json:"banner,omitempty"`
+}
+
+// Validate checks the username length and image encodings.
+func (p *ModifyCurrentUserParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "modify current user params required"}
+	}
+	if p.Username != nil {
+		n := utf8.RuneCountInString(*p.Username)
+		if n < limits.UsernameMinLength || n > limits.UsernameMaxLength {
+			return &ValidationError{Field: "username", Message: fmt.Sprintf("username must be %d-%d characters", limits.UsernameMinLength, limits.UsernameMaxLength)}
+		}
+	}
+	for field, value := range map[string]*string{"avatar": p.Avatar, "banner": p.Banner} {
+		if value != nil && *value != "" && !strings.HasPrefix(*value, "data:image/") {
+			return &ValidationError{Field: field, Message: "must be an image data URI"}
+		}
+	}
+	return nil
+}
+
+// CreateDMParams opens a DM channel with a user.
+type CreateDMParams struct {
+	RecipientID string `json:"recipient_id"`
+}
+
+// Validate ensures a recipient is set.
+func (p *CreateDMParams) Validate() error {
+	if p == nil || p.RecipientID == "" {
+		return &ValidationError{Field: "recipient_id", Message: "recipient ID is required"}
+	}
+	return nil
+}
+
+// ListCurrentUserGuildsParams controls pagination when listing the guilds
+// the current user is in. Before and After are guild IDs.
+type ListCurrentUserGuildsParams struct {
+	Before     string
+	After      string
+	Limit      int
+	WithCounts bool
+}
+
+// Validate ensures the limit is in range and only one cursor is set.
+func (p *ListCurrentUserGuildsParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Limit < 0 || p.Limit > limits.UserGuildsPerPage {
+		return &ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 0 and %d", limits.UserGuildsPerPage)}
+	}
+	if p.Before != "" && p.After != "" {
+		return &ValidationError{Field: "before", Message: "cannot use before with after"}
+	}
+	return nil
+}
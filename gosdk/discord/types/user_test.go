@@ -0,0 +1,37 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModifyCurrentUserParamsValidate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name   string
+		params *ModifyCurrentUserParams
+		valid  bool
+	}{
+		{"nil", nil, false},
+		{"username", &ModifyCurrentUserParams{Username: str("relay")}, true},
+		{"short username", &ModifyCurrentUserParams{Username: str("r")}, false},
+		{"long username", &ModifyCurrentUserParams{Username: str(strings.Repeat("r", 33))}, false},
+		{"avatar", &ModifyCurrentUserParams{Avatar: str("data:image/png;base64,AAAA")}, true},
+		{"avatar URL", &ModifyCurrentUserParams{Avatar: str("https://example.com/a.png")}, false},
+		{"banner", &ModifyCurrentUserParams{Banner: str("data:image/gif;base64,AAAA")}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.params.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() error = %v, want valid=%v", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestListCurrentUserGuildsParamsValidate(t *testing.T) {
+	if err := (&ListCurrentUserGuildsParams{Before: "1", After: "2"}).Validate(); err == nil {
+		t.Fatal("expected error for before with after")
+	}
+	if err := (&CreateDMParams{}).Validate(); err == nil {
+		t.Fatal("expected error for missing recipient")
+	}
+}
@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// VoiceState represents a user's voice connection status.
+type VoiceState struct {
+	GuildID                 string     `json:"guild_id,omitempty"`
+	ChannelID               string     `json:"channel_id,omitempty"`
+	UserID                  string     `json:"user_id"`
+	Member                  *Member    `json:"member,omitempty"`
+	SessionID               string     `json:"session_id"`
+	Deaf                    bool       `json:"deaf"`
+	Mute                    bool       `json:"mute"`
+	SelfDeaf                bool       `json:"self_deaf"`
+	SelfMute                bool       `json:"self_mute"`
+	SelfStream              bool       `json:"self_stream,omitempty"`
+	SelfVideo               bool       `json:"self_video"`
+	Suppress                bool       `json:"suppress"`
+	RequestToSpeakTimestamp *time.Time `json:"request_to_speak_timestamp,omitempty"`
+}
@@ -1,15 +1,27 @@
 package types
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+)
+
 // WebhookMessage represents a message to be sent via webhook
 type WebhookMessage struct {
-	Content         string  `json:"content,omitempty"`
-	Username        string  `json:"username,omitempty"`
-	AvatarURL       string  `json:"avatar_url,omitempty"`
-	TTS             bool    `json:"tts,omitempty"`
-	Embeds          []Embed `json:"embeds,omitempty"`
-	AllowedMentions *struct {
-		Parse []string `json:"parse,omitempty"`
-	} `json:"allowed_mentions,omitempty"`
+	Content         string           `json:"content,omitempty"`
+	Username        string           `json:"username,omitempty"`
+	AvatarURL       string           `json:"avatar_url,omitempty"`
+	TTS             bool             `json:"tts,omitempty"`
+	Embeds          []Embed          `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// Components holds action rows of link buttons. Webhooks not owned by an
+	// application may only send non-interactive components.
+	Components []MessageComponent `json:"components,omitempty"`
+
+	// Poll attaches a poll to the message.
+	Poll *Poll `json:"poll,omitempty"`
 
 	// Thread support
 	// ThreadID sends the message to an existing thread (instead of the channel)
@@ -18,35 +30,50 @@ type WebhookMessage struct {
 	// ThreadName creates a new forum thread with this name (forum channels only)
 	// Only works when sending to a forum channel, ignored otherwise
 	ThreadName string `json:"thread_name,omitempty"`
+
+	// AppliedTags are forum tag IDs for the thread created with ThreadName.
+	// Webhooks cannot set a new thread's auto-archive duration or slowmode;
+	// use Channels.StartThreadInForum for those.
+	AppliedTags []string `json:"applied_tags,omitempty"`
 }
 
 // Validate checks if the webhook message is valid
 func (w *WebhookMessage) Validate() error {
-	if w.Content == "" && len(w.Embeds) == 0 {
+	return w.validate(true)
+}
+
+// ValidateWithFiles checks a message sent alongside file uploads, which may
+// carry no content, embeds, components, or poll of its own.
+func (w *WebhookMessage) ValidateWithFiles() error {
+	return w.validate(false)
+}
+
+func (w *WebhookMessage) validate(requireBody bool) error {
+	if requireBody && w.Content == "" && len(w.Embeds) == 0 && len(w.Components) == 0 && w.Poll == nil {
 		return &ValidationError{
 			Field:   "content/embeds",
-			Message: "at least one of content or embeds is required",
+			Message: "at least one of content, embeds, components, or poll is required",
 		}
 	}
 
-	if len(w.Content) > 2000 {
+	if len(w.Content) > limits.MessageContentLength {
 		return &ValidationError{
 			Field:   "content",
-			Message: "content exceeds 2000 characters",
+			Message: fmt.Sprintf("content exceeds %d characters", limits.MessageContentLength),
 		}
 	}
 
-	if len(w.Embeds) > 10 {
+	if len(w.Embeds) > limits.MessageEmbeds {
 		return &ValidationError{
 			Field:   "embeds",
-			Message: "maximum 10 embeds allowed",
+			Message: fmt.Sprintf("maximum %d embeds allowed", limits.MessageEmbeds),
 		}
 	}
 
-	if len(w.ThreadName) > 100 {
+	if len(w.ThreadName) > limits.ThreadNameLength {
 		return &ValidationError{
 			Field:   "thread_name",
-			Message: "thread name exceeds 100 characters",
+			Message: fmt.Sprintf("thread name exceeds %d characters", limits.ThreadNameLength),
 		}
 	}
 
@@ -57,6 +84,16 @@ func (w *WebhookMessage) Validate() error {
 		}
 	}
 
+	if len(w.AppliedTags) > 0 && w.ThreadName == "" {
+		return &ValidationError{
+			Field:   "applied_tags",
+			Message: "applied tags require thread_name",
+		}
+	}
+	if err := validateAppliedTags(w.AppliedTags); err != nil {
+		return err
+	}
+
 	for i, embed := range w.Embeds {
 		if err := validateEmbed(&embed); err != nil {
 			return err
@@ -64,18 +101,49 @@ func (w *WebhookMessage) Validate() error {
 		_ = i // silence unused variable warning for now
 	}
 
+	if err := validateWebhookComponents(w.Components); err != nil {
+		return err
+	}
+
+	if err := w.AllowedMentions.Validate(); err != nil {
+		return err
+	}
+
+	return w.Poll.Validate()
+}
+
+// validateWebhookComponents enforces the action row layout and only allows
+// link buttons, the one component type every webhook may send.
+func validateWebhookComponents(components []MessageComponent) error {
+	if err := validateComponentLayout(components, false, false, "components"); err != nil {
+		return err
+	}
+	for i, row := range components {
+		for j, child := range row.Components {
+			field := fmt.Sprintf("components[%d].components[%d]", i, j)
+			if child.Type != ComponentTypeButton || ButtonStyle(child.Style) != ButtonStyleLink {
+				return &ValidationError{Field: field, Message: "webhook messages only support link buttons"}
+			}
+			if strings.TrimSpace(child.URL) == "" {
+				return &ValidationError{Field: field + ".url", Message: "link buttons require a url"}
+			}
+			if child.CustomID != "" {
+				return &ValidationError{Field: field + ".custom_id", Message: "link buttons cannot have a custom_id"}
+			}
+		}
+	}
 	return nil
 }
 
 func validateEmbed(e *Embed) error {
-	if len(e.Title) > 256 {
-		return &ValidationError{Field: "embed.title", Message: "title exceeds 256 characters"}
+	if len(e.Title) > limits.EmbedTitleLength {
+		return &ValidationError{Field: "embed.title", Message: fmt.Sprintf("title exceeds %d characters", limits.EmbedTitleLength)}
 	}
-	if len(e.Description) > 4096 {
-		return &ValidationError{Field: "embed.description", Message: "description exceeds 4096 characters"}
+	if len(e.Description) > limits.EmbedDescriptionLength {
+		return &ValidationError{Field: "embed.description", Message: fmt.Sprintf("description exceeds %d characters", limits.EmbedDescriptionLength)}
 	}
-	if len(e.Fields) > 25 {
-		return &ValidationError{Field: "embed.fields", Message: "maximum 25 fields allowed"}
+	if len(e.Fields) > limits.EmbedFields {
+		return &ValidationError{Field: "embed.fields", Message: fmt.Sprintf("maximum %d fields allowed", limits.EmbedFields)}
 	}
 	return nil
 }
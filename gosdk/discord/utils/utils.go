@@ -2,12 +2,11 @@ package utils
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
-)
 
-const discordEpoch = 1420070400000
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
 
 // ParseMention extracts the ID from a Discord mention.
 func ParseMention(mention string) (string, bool) {
@@ -71,22 +70,16 @@ func FormatEmoji(name, id string, animated bool) string {
 
 // SnowflakeToTime converts a snowflake string to a time.
 func SnowflakeToTime(id string) (time.Time, error) {
-	val, err := strconv.ParseInt(id, 10, 64)
+	s, err := types.ParseSnowflake(id)
 	if err != nil {
 		return time.Time{}, err
 	}
-	timestamp := (val >> 22) + discordEpoch
-	return time.UnixMilli(timestamp), nil
+	return s.Timestamp(), nil
 }
 
 // TimeToSnowflake converts a time to a snowflake string.
 func TimeToSnowflake(t time.Time) string {
-	ms := t.UnixMilli()
-	if ms < discordEpoch {
-		ms = discordEpoch
-	}
-	val := (ms - discordEpoch) << 22
-	return strconv.FormatInt(val, 10)
+	return types.SnowflakeFromTime(t).String()
 }
 
 // ChunkSlice splits a slice into chunks of the requested size.
@@ -7,21 +7,27 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
 	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+	"github.com/mtreilly/godiscord/gosdk/retry"
+	"github.com/mtreilly/godiscord/gosdk/version"
 )
 
 // MessageEditParams represents parameters for editing a webhook message
 type MessageEditParams struct {
-	Content         *string       `json:"content,omitempty"`
-	Embeds          []types.Embed `json:"embeds,omitempty"`
-	AllowedMentions *struct {
-		Parse []string `json:"parse,omitempty"`
-	} `json:"allowed_mentions,omitempty"`
-	// Note: File attachments cannot be edited, only replaced
+	Content         *string                `json:"content,omitempty"`
+	Embeds          []types.Embed          `json:"embeds,omitempty"`
+	AllowedMentions *types.AllowedMentions `json:"allowed_mentions,omitempty"`
+	// Attachments lists the attachments the message keeps. Existing
+	// attachments are referenced by ID; any not listed are removed, so
+	// point it at an empty slice to remove them all. Leave it nil in Edit
+	// to keep the current attachments unchanged.
+	Attachments *[]types.Attachment `json:"attachments,omitempty"`
 }
 
 // Edit edits a previously sent webhook message
@@ -41,6 +47,15 @@ func (c *Client) Edit(ctx context.Context, messageID string, params *MessageEdit
 		}
 	}
 
+	if err := params.AllowedMentions.Validate(); err != nil {
+		return nil, err
+	}
+	if params.AllowedMentions == nil && c.defaultMentions != nil {
+		withDefault := *params
+		withDefault.AllowedMentions = c.defaultMentions
+		params = &withDefault
+	}
+
 	// Build URL for editing message
 	url := c.buildMessageURL(messageID)
 
@@ -52,6 +67,69 @@ func (c *Client) Edit(ctx context.Context, messageID string, params *MessageEdit
 	return c.doMessageRequest(ctx, "PATCH", url, body)
 }
 
+// EditWithFiles edits a previously sent webhook message and uploads files as
+// new attachments, mirroring SendWithFiles. Discord keeps only the attachments
+// listed in params.Attachments, so list existing ones by ID to keep them;
+// entries for the uploaded files are added automatically. With no
+// params.Attachments the new files replace every existing attachment.
+func (c *Client) EditWithFiles(ctx context.Context, messageID string, params *MessageEditParams, files []FileAttachment) (*types.Message, error) {
+	if messageID == "" {
+		return nil, &types.ValidationError{
+			Field:   "messageID",
+			Message: "message ID is required",
+		}
+	}
+
+	if params == nil {
+		return nil, &types.ValidationError{
+			Field:   "params",
+			Message: "edit parameters are required",
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, &types.ValidationError{
+			Field:   "files",
+			Message: "at least one file is required (use Edit for messages without files)",
+		}
+	}
+
+	if err := params.AllowedMentions.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Reference each upload by its index without modifying the caller's params.
+	payload := *params
+	if payload.AllowedMentions == nil {
+		payload.AllowedMentions = c.defaultMentions
+	}
+	var attachments []types.Attachment
+	if params.Attachments != nil {
+		attachments = append(attachments, *params.Attachments...)
+	}
+	for i, file := range files {
+		attachments = append(attachments, types.Attachment{ID: strconv.Itoa(i), Filename: file.Name})
+	}
+	if len(attachments) > limits.MessageAttachments {
+		return nil, &types.ValidationError{
+			Field:   "attachments",
+			Message: fmt.Sprintf("too many attachments: %d (maximum %d)", len(attachments), limits.MessageAttachments),
+		}
+	}
+	payload.Attachments = &attachments
+
+	body, contentType, err := c.buildMultipart(ctx, &payload, files)
+	if err != nil {
+		return nil, err
+	}
+
+	var edited types.Message
+	if err := c.sendMultipartWithRetry(ctx, "PATCH", body, contentType, c.buildMessageURL(messageID), SendOpts{}, &edited); err != nil {
+		return nil, err
+	}
+	return &edited, nil
+}
+
 // Delete deletes a previously sent webhook message
 func (c *Client) Delete(ctx context.Context, messageID string) error {
 	if messageID == "" {
@@ -64,21 +142,11 @@ func (c *Client) Delete(ctx context.Context, messageID string) error {
 	url := c.buildMessageURL(messageID)
 	route := ratelimit.RouteFromEndpoint("DELETE", url)
 
-	var lastErr error
-	backoff := c.timeout / 30
-
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoff):
-				backoff *= 2
-			}
-		}
+	retrier := retry.New(c.retryPolicy(c.timeout / 30))
 
+	for {
 		// Rate limiting
-		if err := c.waitForRateLimit(ctx, route); err != nil {
+		if err := c.waitForRateLimit(ctx, route, false); err != nil {
 			return err
 		}
 
@@ -87,18 +155,18 @@ func (c *Client) Delete(ctx context.Context, messageID string) error {
 			return fmt.Errorf("failed to create request: %w", err)
 		}
 
-		req.Header.Set("User-Agent", "DiscordWebhook/1.0")
+		req.Header.Set("User-Agent", version.UserAgent())
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			lastErr = &types.NetworkError{Op: "request", Err: err}
+			if err := retrier.Wait(ctx, retry.Attempt{Err: &types.NetworkError{Op: "request", Err: err}}); err != nil {
+				return err
+			}
 			continue
 		}
 
 		// Update rate limiter
-		if c.rateLimiter != nil {
-			c.rateLimiter.Update(route, resp.Header)
-		}
+		c.updateRateLimit(route, resp.Header)
 
 		// Success - 204 No Content
 		if resp.StatusCode == http.StatusNoContent {
@@ -113,35 +181,24 @@ func (c *Client) Delete(ctx context.Context, messageID string) error {
 
 		// Handle rate limiting
 		if resp.StatusCode == 429 {
-			c.logger.Warn("rate limit hit",
+			c.rateLogger.Every("rate limit hit:"+route, rateLimitLogInterval).Warn("rate limit hit",
 				"route", route,
 				"retry_after", apiErr.RetryAfter,
-				"attempt", attempt+1,
+				"attempt", retrier.Attempts()+1,
 				"method", "DELETE",
 			)
 			c.recordStrategyOutcome(route, true)
-
-			if apiErr.RetryAfter > 0 {
-				backoff = time.Duration(apiErr.RetryAfter) * time.Second
-			}
-			lastErr = apiErr
-			continue
+			c.observeRateLimited(route, apiErr, resp.Header)
 		}
 
-		// Don't retry client errors (except rate limits)
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			return apiErr
+		if err := retrier.Wait(ctx, retry.Attempt{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retry.ParseRetryAfter(resp.Header, time.Duration(apiErr.RetryAfter)*time.Second),
+			Err:        apiErr,
+		}); err != nil {
+			return err
 		}
-
-		// Retry server errors
-		lastErr = apiErr
 	}
-
-	if lastErr != nil {
-		return fmt.Errorf("delete request failed after %d attempts: %w", c.maxRetries+1, lastErr)
-	}
-
-	return fmt.Errorf("delete request failed after %d attempts", c.maxRetries+1)
 }
 
 // Get retrieves a previously sent webhook message
@@ -168,22 +225,12 @@ func (c *Client) buildMessageURL(messageID string) string {
 
 // doMessageRequest performs a request that returns a Message
 func (c *Client) doMessageRequest(ctx context.Context, method, url string, body []byte) (*types.Message, error) {
-	var lastErr error
-	backoff := c.timeout / 30
 	route := c.buildRoute(method, url)
+	retrier := retry.New(c.retryPolicy(c.timeout / 30))
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(backoff):
-				backoff *= 2
-			}
-		}
-
+	for {
 		// Rate limiting
-		if err := c.waitForRateLimit(ctx, route); err != nil {
+		if err := c.waitForRateLimit(ctx, route, false); err != nil {
 			return nil, err
 		}
 
@@ -200,18 +247,18 @@ func (c *Client) doMessageRequest(ctx context.Context, method, url string, body
 		if body != nil {
 			req.Header.Set("Content-Type", "application/json")
 		}
-		req.Header.Set("User-Agent", "DiscordWebhook/1.0")
+		req.Header.Set("User-Agent", version.UserAgent())
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			lastErr = &types.NetworkError{Op: "request", Err: err}
+			if err := retrier.Wait(ctx, retry.Attempt{Err: &types.NetworkError{Op: "request", Err: err}}); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
 		// Update rate limiter
-		if c.rateLimiter != nil {
-			c.rateLimiter.Update(route, resp.Header)
-		}
+		c.updateRateLimit(route, resp.Header)
 
 		// Success - parse response
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -234,33 +281,22 @@ func (c *Client) doMessageRequest(ctx context.Context, method, url string, body
 
 		// Handle rate limiting
 		if resp.StatusCode == 429 {
-			c.logger.Warn("rate limit hit",
+			c.rateLogger.Every("rate limit hit:"+route, rateLimitLogInterval).Warn("rate limit hit",
 				"route", route,
 				"retry_after", apiErr.RetryAfter,
-				"attempt", attempt+1,
+				"attempt", retrier.Attempts()+1,
 				"method", method,
 			)
 			c.recordStrategyOutcome(route, true)
-
-			if apiErr.RetryAfter > 0 {
-				backoff = time.Duration(apiErr.RetryAfter) * time.Second
-			}
-			lastErr = apiErr
-			continue
+			c.observeRateLimited(route, apiErr, resp.Header)
 		}
 
-		// Don't retry client errors (except rate limits)
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			return nil, apiErr
+		if err := retrier.Wait(ctx, retry.Attempt{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retry.ParseRetryAfter(resp.Header, time.Duration(apiErr.RetryAfter)*time.Second),
+			Err:        apiErr,
+		}); err != nil {
+			return nil, err
 		}
-
-		// Retry server errors
-		lastErr = apiErr
 	}
-
-	if lastErr != nil {
-		return nil, fmt.Errorf("request failed after %d attempts: %w", c.maxRetries+1, lastErr)
-	}
-
-	return nil, fmt.Errorf("request failed after %d attempts", c.maxRetries+1)
 }
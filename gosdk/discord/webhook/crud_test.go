@@ -3,6 +3,7 @@ package webhook
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -107,7 +108,7 @@ func TestClient_Edit_Validation(t *testing.T) {
 			if tt.wantErr && err != nil {
 				// Check that it's a validation error
 				var valErr *types.ValidationError
-				if !isErrorType(err, &valErr) {
+				if !errors.As(err, &valErr) {
 					t.Errorf("Expected ValidationError, got %T", err)
 				}
 			}
@@ -115,6 +116,88 @@ func TestClient_Edit_Validation(t *testing.T) {
 	}
 }
 
+func TestClient_EditWithFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/messages/123456789" {
+			t.Errorf("Expected PATCH /messages/123456789, got %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			t.Fatalf("Failed to parse multipart form: %v", err)
+		}
+
+		var params MessageEditParams
+		if err := json.Unmarshal([]byte(r.FormValue("payload_json")), &params); err != nil {
+			t.Errorf("Failed to decode payload_json: %v", err)
+		}
+		want := []types.Attachment{{ID: "111"}, {ID: "0", Filename: "chart.png"}}
+		if params.Attachments == nil {
+			t.Fatal("Expected attachments in payload_json")
+		}
+		got := *params.Attachments
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("Expected attachments %+v, got %+v", want, got)
+		}
+		if _, ok := r.MultipartForm.File["file0"]; !ok {
+			t.Error("Expected file0 in multipart form")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"123456789","attachments":[{"id":"111"},{"id":"222","filename":"chart.png"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	content := "refreshed"
+	params := &MessageEditParams{Content: &content, Attachments: &[]types.Attachment{{ID: "111"}}}
+	msg, err := client.EditWithFiles(context.Background(), "123456789", params, []FileAttachment{
+		{Name: "chart.png", ContentType: "image/png", Reader: strings.NewReader("png")},
+	})
+	if err != nil {
+		t.Fatalf("EditWithFiles() error = %v", err)
+	}
+	if len(msg.Attachments) != 2 {
+		t.Fatalf("Expected 2 attachments, got %+v", msg.Attachments)
+	}
+	if len(*params.Attachments) != 1 {
+		t.Errorf("EditWithFiles modified the caller's params: %+v", *params.Attachments)
+	}
+}
+
+func TestClient_EditWithFiles_Validation(t *testing.T) {
+	client, _ := NewClient("http://test.com")
+	ctx := context.Background()
+	file := FileAttachment{Name: "a.txt", Reader: strings.NewReader("a")}
+
+	keep := make([]types.Attachment, 10)
+	tooMany := &MessageEditParams{Attachments: &keep}
+
+	tests := []struct {
+		name      string
+		messageID string
+		params    *MessageEditParams
+		files     []FileAttachment
+	}{
+		{"empty message ID", "", &MessageEditParams{}, []FileAttachment{file}},
+		{"nil params", "123", nil, []FileAttachment{file}},
+		{"no files", "123", &MessageEditParams{}, nil},
+		{"too many attachments", "123", tooMany, []FileAttachment{file}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.EditWithFiles(ctx, tt.messageID, tt.params, tt.files)
+			var valErr *types.ValidationError
+			if !errors.As(err, &valErr) {
+				t.Errorf("Expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
 func TestClient_Delete(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify method
@@ -166,17 +249,16 @@ func TestClient_Delete_NotFound(t *testing.T) {
 		t.Error("Expected error for nonexistent message, got nil")
 	}
 
-	// Check if it's an API error type
-	var dummy *types.APIError
-	if !isErrorType(err, &dummy) {
-		t.Errorf("Expected APIError, got %T: %v", err, err)
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %T: %v", err, err)
 	}
-
-	// Extract API error for status code check
-	apiErr := unwrapToAPIError(err)
-	if apiErr != nil && apiErr.StatusCode != 404 {
+	if apiErr.StatusCode != 404 {
 		t.Errorf("Expected status code 404, got %d", apiErr.StatusCode)
 	}
+	if !types.IsUnknownMessage(err) || !errors.Is(err, types.ErrCodeUnknownMessage) {
+		t.Errorf("Expected unknown message code, got %v", err)
+	}
 }
 
 func TestClient_Get(t *testing.T) {
@@ -240,7 +322,7 @@ func TestClient_Get_Validation(t *testing.T) {
 	}
 
 	var valErr *types.ValidationError
-	if !isErrorType(err, &valErr) {
+	if !errors.As(err, &valErr) {
 		t.Errorf("Expected ValidationError, got %T", err)
 	}
 }
@@ -371,49 +453,13 @@ func stringPtr(s string) *string {
 	return &s
 }
 
-func isErrorType(err error, target interface{}) bool {
-	if err == nil {
-		return false
-	}
-	// Check if error wraps the target type
-	errStr := err.Error()
-	switch target.(type) {
-	case **types.ValidationError:
-		// Check if it's a ValidationError or wraps one
-		_, ok := err.(*types.ValidationError)
-		if !ok {
-			// Check if error message contains validation-related keywords
-			return strings.Contains(errStr, "validation") ||
-				strings.Contains(errStr, "required") ||
-				strings.Contains(errStr, "invalid")
-		}
-		return ok
-	case **types.APIError:
-		// Check if it's an APIError or wraps one
-		_, ok := err.(*types.APIError)
-		if !ok {
-			// Check if error wraps an APIError
-			return strings.Contains(errStr, "Discord API error") ||
-				strings.Contains(errStr, "status code")
-		}
-		return ok
-	default:
-		return false
+func TestMessageEditParams_ClearAttachments(t *testing.T) {
+	keep, _ := json.Marshal(&MessageEditParams{})
+	if strings.Contains(string(keep), "attachments") {
+		t.Errorf("nil Attachments should be omitted, got %s", keep)
 	}
-}
-
-func unwrapToAPIError(err error) *types.APIError {
-	if apiErr, ok := err.(*types.APIError); ok {
-		return apiErr
-	}
-	// Try to extract APIError from wrapped error
-	errStr := err.Error()
-	if strings.Contains(errStr, "Discord API error") {
-		// Extract status code if possible
-		// This is a simplification - in production you'd use errors.As
-		return &types.APIError{
-			StatusCode: 404, // Default for testing
-		}
+	cleared, _ := json.Marshal(&MessageEditParams{Attachments: &[]types.Attachment{}})
+	if !strings.Contains(string(cleared), `"attachments":[]`) {
+		t.Errorf("empty Attachments should clear them, got %s", cleared)
 	}
-	return nil
 }
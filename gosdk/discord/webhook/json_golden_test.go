@@ -24,10 +24,8 @@ func TestWebhookMessageGolden(t *testing.T) {
 				Username:  "GoldenBot",
 				AvatarURL: "https://example.com/avatar.png",
 				TTS:       false,
-				AllowedMentions: &struct {
-					Parse []string `json:"parse,omitempty"`
-				}{
-					Parse: []string{"users"},
+				AllowedMentions: &types.AllowedMentions{
+					Parse: []types.AllowedMentionType{types.AllowedMentionUsers},
 				},
 				ThreadName: "golden-thread",
 				Embeds: []types.Embed{
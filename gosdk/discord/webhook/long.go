@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/format"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// SendLong sends msg even when its content exceeds Discord's limit. Content
+// is split with format.SplitMessage and sent as consecutive messages: the
+// first keeps TTS, the last carries embeds, components, and poll, and every
+// part keeps the username, avatar, thread, and allowed mentions. When msg
+// creates a forum thread, the remaining parts are posted inside it. Content
+// that would need more than opts.MaxMessages parts is uploaded as a file instead.
+//
+// The created messages are returned in order. On failure the messages sent
+// so far are returned with the error.
+func (c *Client) SendLong(ctx context.Context, msg *types.WebhookMessage, opts *format.LongMessageOptions) ([]*types.Message, error) {
+	if msg == nil {
+		return nil, &types.ValidationError{Field: "message", Message: "message is required"}
+	}
+
+	chunks := format.SplitMessage(msg.Content, 0)
+	if len(chunks) <= 1 {
+		created, err := c.SendWait(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Message{created}, nil
+	}
+
+	if name, upload := opts.Upload(len(chunks)); upload {
+		withFile := *msg
+		withFile.Content = ""
+		created, err := c.SendWithFilesWait(ctx, &withFile, []FileAttachment{{
+			Name:        name,
+			ContentType: "text/plain; charset=utf-8",
+			Reader:      strings.NewReader(msg.Content),
+			Size:        int64(len(msg.Content)),
+		}})
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Message{created}, nil
+	}
+
+	sent := make([]*types.Message, 0, len(chunks))
+	for i, chunk := range chunks {
+		part := *msg
+		part.Content = chunk
+		if i > 0 {
+			part.TTS = false
+			if msg.ThreadName != "" {
+				// The first part created a forum thread; continue inside it.
+				part.ThreadName, part.ThreadID = "", sent[0].ChannelID
+			}
+		}
+		if i < len(chunks)-1 {
+			part.Embeds, part.Components, part.Poll = nil, nil, nil
+		}
+		created, err := c.SendWait(ctx, &part)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, created)
+	}
+	return sent, nil
+}
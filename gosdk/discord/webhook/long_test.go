@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/format"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestClient_SendLongSplits(t *testing.T) {
+	var mu sync.Mutex
+	var received []types.WebhookMessage
+	var threadIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg types.WebhookMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		mu.Lock()
+		received = append(received, msg)
+		threadIDs = append(threadIDs, r.URL.Query().Get("thread_id"))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(types.Message{ID: "1", ChannelID: "555"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	content := strings.Repeat("a line of log output\n", 150)
+	sent, err := client.SendLong(context.Background(), &types.WebhookMessage{
+		Content:    content,
+		Username:   "logger",
+		TTS:        true,
+		ThreadName: "build log",
+		Embeds:     []types.Embed{{Title: "summary"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("SendLong() error = %v", err)
+	}
+	if len(sent) != 2 || len(received) != 2 {
+		t.Fatalf("expected 2 messages, sent %d received %d", len(sent), len(received))
+	}
+
+	first, last := received[0], received[1]
+	if !first.TTS || last.TTS {
+		t.Fatal("only the first part should keep TTS")
+	}
+	if len(first.Embeds) != 0 || len(last.Embeds) != 1 {
+		t.Fatal("embeds should only be sent with the last part")
+	}
+	if first.ThreadName != "build log" || last.ThreadName != "" || threadIDs[1] != "555" {
+		t.Fatalf("later parts should post into the created thread, got %q / %q", last.ThreadName, threadIDs[1])
+	}
+	if last.Username != "logger" {
+		t.Fatal("username should be kept on every part")
+	}
+	if got := first.Content + "\n" + last.Content + "\n"; got != content {
+		t.Fatal("parts do not reassemble the original content")
+	}
+}
+
+func TestClient_SendLongUploadsFile(t *testing.T) {
+	var fileName, fileBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatalf("expected multipart request: %v", err)
+		}
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("read part: %v", err)
+			}
+			if part.FileName() != "" {
+				fileName = part.FileName()
+				data, _ := io.ReadAll(part)
+				fileBody = string(data)
+			}
+		}
+		json.NewEncoder(w).Encode(types.Message{ID: "1"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	content := strings.Repeat("x", 5000)
+	sent, err := client.SendLong(context.Background(), &types.WebhookMessage{Content: content}, &format.LongMessageOptions{MaxMessages: 2, FileName: "out.txt"})
+	if err != nil {
+		t.Fatalf("SendLong() error = %v", err)
+	}
+	if len(sent) != 1 {
+		t.Fatalf("expected a single message, got %d", len(sent))
+	}
+	if fileName != "out.txt" || fileBody != content {
+		t.Fatalf("uploaded %q with %d bytes", fileName, len(fileBody))
+	}
+}
@@ -10,20 +10,23 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/retry"
+	"github.com/mtreilly/godiscord/gosdk/version"
 )
 
 const (
-	// MaxFileSize is the maximum size for a single file.
-	// Discord defaults to 25MB for most servers; higher tiers can override via configuration later.
-	MaxFileSize = 25 * 1024 * 1024
+	// MaxFileSize is the default maximum size for a single file.
+	// Boosted guilds allow more; see WithUploadLimit and WithPremiumTierResolver.
+	MaxFileSize = limits.FileSize
 
-	// MaxTotalSize is the maximum total size for all files in a single message.
-	// Kept in sync with MaxFileSize to avoid rejecting valid uploads (server boosts may increase this further).
-	MaxTotalSize = 25 * 1024 * 1024
+	// MaxTotalSize is the default maximum total size for all files in a single message.
+	// Kept in sync with MaxFileSize to avoid rejecting valid uploads.
+	MaxTotalSize = limits.TotalFileSize
 
 	// MaxFiles is the maximum number of files per message
-	MaxFiles = 10
+	MaxFiles = limits.Files
 )
 
 // FileAttachment represents a file to be uploaded via webhook
@@ -42,8 +45,12 @@ type FileAttachment struct {
 	Size int64
 }
 
-// Validate checks if the file attachment is valid
+// Validate checks if the file attachment is valid against the default size limit
 func (f *FileAttachment) Validate() error {
+	return f.validate(MaxFileSize)
+}
+
+func (f *FileAttachment) validate(maxSize int64) error {
 	if f.Name == "" {
 		return &types.ValidationError{
 			Field:   "name",
@@ -58,10 +65,10 @@ func (f *FileAttachment) Validate() error {
 		}
 	}
 
-	if f.Size > MaxFileSize {
+	if f.Size > maxSize {
 		return &types.ValidationError{
 			Field:   "size",
-			Message: fmt.Sprintf("file size %d exceeds maximum %d bytes (25MB)", f.Size, MaxFileSize),
+			Message: fmt.Sprintf("file size %d exceeds maximum %d bytes", f.Size, maxSize),
 		}
 	}
 
@@ -114,51 +121,91 @@ func (f *FileAttachment) resolvedSize() (int64, bool, error) {
 
 // SendWithFiles sends a webhook message with file attachments
 func (c *Client) SendWithFiles(ctx context.Context, msg *types.WebhookMessage, files []FileAttachment) error {
-	if err := msg.Validate(); err != nil {
-		return fmt.Errorf("invalid webhook message: %w", err)
+	return c.SendWithFilesWithOpts(ctx, msg, files, SendOpts{})
+}
+
+// SendWithFilesWithOpts is SendWithFiles with per-call options.
+func (c *Client) SendWithFilesWithOpts(ctx context.Context, msg *types.WebhookMessage, files []FileAttachment, opts SendOpts) error {
+	return c.sendWithFiles(ctx, msg, files, opts, nil)
+}
+
+// SendWithFilesWait sends a webhook message with file attachments and returns
+// the created message.
+func (c *Client) SendWithFilesWait(ctx context.Context, msg *types.WebhookMessage, files []FileAttachment) (*types.Message, error) {
+	return c.SendWithFilesWaitWithOpts(ctx, msg, files, SendOpts{})
+}
+
+// SendWithFilesWaitWithOpts is SendWithFilesWait with per-call options.
+func (c *Client) SendWithFilesWaitWithOpts(ctx context.Context, msg *types.WebhookMessage, files []FileAttachment, opts SendOpts) (*types.Message, error) {
+	var created types.Message
+	if err := c.sendWithFiles(ctx, msg, files, opts, &created); err != nil {
+		return nil, err
 	}
+	return &created, nil
+}
 
+func (c *Client) sendWithFiles(ctx context.Context, msg *types.WebhookMessage, files []FileAttachment, opts SendOpts, out *types.Message) error {
 	if len(files) == 0 {
 		return &types.ValidationError{
 			Field:   "files",
 			Message: "at least one file is required (use Send for messages without files)",
 		}
 	}
+	if err := msg.ValidateWithFiles(); err != nil {
+		return fmt.Errorf("invalid webhook message: %w", err)
+	}
+	if err := opts.validate(msg); err != nil {
+		return err
+	}
+	msg = c.withDefaultMentions(msg)
+
+	body, contentType, err := c.buildMultipart(ctx, msg, files)
+	if err != nil {
+		return err
+	}
 
+	// Send with retry
+	return c.sendMultipartWithRetry(ctx, "POST", body, contentType, c.executeURL(msg, out != nil, opts), opts, out)
+}
+
+// buildMultipart validates files against the upload limit and encodes them
+// with payload as payload_json.
+func (c *Client) buildMultipart(ctx context.Context, payload any, files []FileAttachment) ([]byte, string, error) {
 	if len(files) > MaxFiles {
-		return &types.ValidationError{
+		return nil, "", &types.ValidationError{
 			Field:   "files",
 			Message: fmt.Sprintf("too many files: %d (maximum %d)", len(files), MaxFiles),
 		}
 	}
 
-	// Validate all files and accumulate known sizes
+	// Validate all files against the guild's upload limit and accumulate known sizes
+	uploadLimit := c.UploadLimit(ctx)
 	var totalSize int64
 	for i := range files {
-		if err := (&files[i]).Validate(); err != nil {
-			return fmt.Errorf("file %d validation failed: %w", i, err)
+		if err := (&files[i]).validate(uploadLimit); err != nil {
+			return nil, "", fmt.Errorf("file %d validation failed: %w", i, err)
 		}
 
 		size, known, err := files[i].resolvedSize()
 		if err != nil {
-			return fmt.Errorf("file %d size detection failed: %w", i, err)
+			return nil, "", fmt.Errorf("file %d size detection failed: %w", i, err)
 		}
 
 		if known {
-			if size > MaxFileSize {
-				return &types.ValidationError{
+			if size > uploadLimit {
+				return nil, "", &types.ValidationError{
 					Field:   "files",
-					Message: fmt.Sprintf("file %s exceeds maximum %d bytes", files[i].Name, MaxFileSize),
+					Message: fmt.Sprintf("file %s exceeds maximum %d bytes", files[i].Name, uploadLimit),
 				}
 			}
 			totalSize += size
 		}
 	}
 
-	if MaxTotalSize > 0 && totalSize > MaxTotalSize {
-		return &types.ValidationError{
+	if totalSize > uploadLimit {
+		return nil, "", &types.ValidationError{
 			Field:   "files",
-			Message: fmt.Sprintf("total file size %d exceeds maximum %d bytes", totalSize, MaxTotalSize),
+			Message: fmt.Sprintf("total file size %d exceeds maximum %d bytes", totalSize, uploadLimit),
 		}
 	}
 
@@ -167,32 +214,28 @@ func (c *Client) SendWithFiles(ctx context.Context, msg *types.WebhookMessage, f
 	writer := multipart.NewWriter(body)
 
 	// Add JSON payload
-	if err := c.writeJSONPayload(writer, msg); err != nil {
-		return fmt.Errorf("failed to write JSON payload: %w", err)
+	if err := c.writeJSONPayload(writer, payload); err != nil {
+		return nil, "", fmt.Errorf("failed to write JSON payload: %w", err)
 	}
 
 	// Add files
-	counter := &uploadCounter{limit: MaxTotalSize}
+	counter := &uploadCounter{limit: uploadLimit, fileLimit: uploadLimit}
 	for i, file := range files {
 		if err := c.writeFile(writer, i, file, counter); err != nil {
-			return fmt.Errorf("failed to write file %d: %w", i, err)
+			return nil, "", fmt.Errorf("failed to write file %d: %w", i, err)
 		}
 	}
 
 	// Close multipart writer
 	if err := writer.Close(); err != nil {
-		return fmt.Errorf("failed to close multipart writer: %w", err)
+		return nil, "", fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
-	// Build URL with thread_id query parameter if specified
-	url := c.buildURLWithThreadID(c.webhookURL, msg.ThreadID)
-
-	// Send with retry
-	return c.sendMultipartWithRetry(ctx, body.Bytes(), writer.FormDataContentType(), url)
+	return body.Bytes(), writer.FormDataContentType(), nil
 }
 
-// writeJSONPayload writes the webhook message as JSON to the multipart form
-func (c *Client) writeJSONPayload(writer *multipart.Writer, msg *types.WebhookMessage) error {
+// writeJSONPayload writes the message payload as JSON to the multipart form
+func (c *Client) writeJSONPayload(writer *multipart.Writer, msg any) error {
 	// Create form field for JSON payload
 	part, err := writer.CreateFormField("payload_json")
 	if err != nil {
@@ -234,55 +277,50 @@ func (c *Client) writeFile(writer *multipart.Writer, index int, file FileAttachm
 	}
 
 	// Copy file content to part
-	reader := newCountingReader(file.Reader, file.Name, MaxFileSize, counter)
+	fileLimit := int64(MaxFileSize)
+	if counter != nil && counter.fileLimit > 0 {
+		fileLimit = counter.fileLimit
+	}
+	reader := newCountingReader(file.Reader, file.Name, fileLimit, counter)
 	_, err = io.Copy(part, reader)
 	return err
 }
 
 // sendMultipartWithRetry sends a multipart request with retry logic
-func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, contentType, url string) error {
-	var lastErr error
-	backoff := c.timeout / 30 // Start with ~1 second
-	route := c.buildRoute("POST", url)
-
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoff):
-				backoff *= 2
-			}
-		}
+func (c *Client) sendMultipartWithRetry(ctx context.Context, method string, body []byte, contentType, url string, opts SendOpts, out *types.Message) error {
+	route := c.buildRoute(method, url)
+	retrier := retry.New(c.retryPolicy(c.timeout / 30))
 
+	for {
 		// Rate limiting
-		if err := c.waitForRateLimit(ctx, route); err != nil {
+		if err := c.waitForRateLimit(ctx, route, opts.skipProactive()); err != nil {
 			return err
 		}
 
-		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 		if err != nil {
 			return fmt.Errorf("failed to create request: %w", err)
 		}
 
 		req.Header.Set("Content-Type", contentType)
-		req.Header.Set("User-Agent", "DiscordWebhook/1.0")
+		req.Header.Set("User-Agent", version.UserAgent())
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			lastErr = &types.NetworkError{Op: "request", Err: err}
+			if err := retrier.Wait(ctx, retry.Attempt{Err: &types.NetworkError{Op: "request", Err: err}}); err != nil {
+				return err
+			}
 			continue
 		}
 
 		// Update rate limiter
-		if c.rateLimiter != nil {
-			c.rateLimiter.Update(route, resp.Header)
-		}
+		c.updateRateLimit(route, resp.Header)
 
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			err := decodeMessage(resp, out)
 			resp.Body.Close()
 			c.recordStrategyOutcome(route, false)
-			return nil
+			return err
 		}
 
 		// Handle error response (reuse existing logic)
@@ -291,40 +329,30 @@ func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, conten
 
 		// Handle rate limiting
 		if resp.StatusCode == 429 {
-			c.logger.Warn("rate limit hit",
+			c.rateLogger.Every("rate limit hit:"+route, rateLimitLogInterval).Warn("rate limit hit",
 				"route", route,
 				"retry_after", apiErr.RetryAfter,
-				"attempt", attempt+1,
-				"method", "POST (multipart)",
+				"attempt", retrier.Attempts()+1,
+				"method", method+" (multipart)",
 			)
 			c.recordStrategyOutcome(route, true)
-
-			if apiErr.RetryAfter > 0 {
-				backoff = time.Duration(apiErr.RetryAfter) * time.Second
-			}
-			lastErr = apiErr
-			continue
+			c.observeRateLimited(route, apiErr, resp.Header)
 		}
 
-		// Don't retry client errors (except rate limits)
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			return apiErr
+		if err := retrier.Wait(ctx, retry.Attempt{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retry.ParseRetryAfter(resp.Header, time.Duration(apiErr.RetryAfter)*time.Second),
+			Err:        apiErr,
+		}); err != nil {
+			return err
 		}
-
-		// Retry server errors
-		lastErr = apiErr
 	}
-
-	if lastErr != nil {
-		return fmt.Errorf("multipart request failed after %d attempts: %w", c.maxRetries+1, lastErr)
-	}
-
-	return fmt.Errorf("multipart request failed after %d attempts", c.maxRetries+1)
 }
 
 type uploadCounter struct {
-	limit int64
-	read  int64
+	limit     int64
+	fileLimit int64
+	read      int64
 }
 
 type countingReader struct {
@@ -352,7 +380,7 @@ func (cr *countingReader) Read(p []byte) (int, error) {
 	if cr.perFileLimit > 0 {
 		remaining := cr.perFileLimit - cr.fileRead
 		if remaining <= 0 {
-			return 0, cr.perFileExceededErr()
+			return 0, cr.atLimit(cr.perFileExceededErr())
 		}
 		if int64(len(p)) > remaining {
 			p = p[:remaining]
@@ -362,7 +390,7 @@ func (cr *countingReader) Read(p []byte) (int, error) {
 	if cr.total != nil && cr.total.limit > 0 {
 		remaining := cr.total.limit - cr.total.read
 		if remaining <= 0 {
-			return 0, cr.totalExceededErr()
+			return 0, cr.atLimit(cr.totalExceededErr())
 		}
 		if int64(len(p)) > remaining {
 			p = p[:remaining]
@@ -379,6 +407,24 @@ func (cr *countingReader) Read(p []byte) (int, error) {
 	return n, err
 }
 
+// atLimit probes the underlying reader once a limit is reached so content that
+// ends exactly on the limit reports EOF instead of exceeded.
+func (cr *countingReader) atLimit(exceeded error) error {
+	var probe [1]byte
+	for {
+		n, err := cr.reader.Read(probe[:])
+		if n > 0 {
+			return exceeded
+		}
+		if err == io.EOF {
+			return io.EOF
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (cr *countingReader) perFileExceededErr() error {
 	return &types.ValidationError{
 		Field:   "files",
@@ -147,6 +147,35 @@ func TestClient_SendWithFiles(t *testing.T) {
 	}
 }
 
+func TestClient_SendWithFilesWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.URL.Query().Get("wait") != "true" {
+			t.Errorf("Expected wait=true, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"555","attachments":[{"id":"1","filename":"test.txt"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithUploadLimit(1024))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	files := []FileAttachment{{Name: "test.txt", Reader: strings.NewReader("content")}}
+	created, err := client.SendWithFilesWait(context.Background(), &types.WebhookMessage{Content: "files"}, files)
+	if err != nil {
+		t.Fatalf("SendWithFilesWait() error = %v", err)
+	}
+	if created.ID != "555" || len(created.Attachments) != 1 {
+		t.Fatalf("unexpected message %+v", created)
+	}
+}
+
 func TestClient_SendWithFiles_MultipleFiles(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if err := r.ParseMultipartForm(32 << 20); err != nil {
@@ -1,7 +1,9 @@
 package webhook
 
 import (
+	"bytes"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -117,15 +119,17 @@ func TestWithStrategyName(t *testing.T) {
 }
 
 func TestWithLogger(t *testing.T) {
-	customLogger := logger.New(logger.DebugLevel, "json", nil)
+	var buf bytes.Buffer
+	customLogger := logger.New(logger.DebugLevel, "json", &buf)
 
 	client, err := NewClient("http://example.com", WithLogger(customLogger))
 	if err != nil {
 		t.Fatalf("NewClient() error = %v", err)
 	}
 
-	if client.logger != customLogger {
-		t.Errorf("WithLogger() did not set custom logger")
+	client.logger.Debug("probe")
+	if client.logger.Subsystem() != logger.SubsystemWebhook || !strings.Contains(buf.String(), `"subsystem":"webhook"`) {
+		t.Errorf("WithLogger() did not set custom logger tagged as webhook: %s", buf.String())
 	}
 }
 
@@ -153,7 +157,8 @@ func TestCreateStrategy(t *testing.T) {
 
 func TestMultipleOptions(t *testing.T) {
 	customClient := &http.Client{Timeout: 5 * time.Second}
-	customLogger := logger.New(logger.DebugLevel, "json", nil)
+	var buf bytes.Buffer
+	customLogger := logger.New(logger.DebugLevel, "json", &buf)
 	customLimiter := ratelimit.NewMemoryTracker()
 
 	client, err := NewClient("http://example.com",
@@ -177,7 +182,7 @@ func TestMultipleOptions(t *testing.T) {
 	if client.timeout != 60*time.Second {
 		t.Errorf("timeout = %v, want 60s", client.timeout)
 	}
-	if client.logger != customLogger {
+	if client.logger.Debug("probe"); !strings.Contains(buf.String(), "probe") {
 		t.Errorf("logger not set correctly")
 	}
 	if client.rateLimiter != customLimiter {
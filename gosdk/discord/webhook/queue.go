@@ -0,0 +1,414 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+const (
+	defaultQueueBuffer    = 100
+	defaultQueueLinger    = 250 * time.Millisecond
+	defaultQueueSeparator = "\n"
+)
+
+// QueueOption configures a Queue.
+type QueueOption func(*Queue)
+
+// WithQueueRate caps deliveries at n messages per window (default 30 per
+// minute, Discord's per-channel webhook limit).
+func WithQueueRate(n int, per time.Duration) QueueOption {
+	return func(q *Queue) {
+		if n > 0 && per > 0 {
+			q.rate = n
+			q.per = per
+		}
+	}
+}
+
+// WithQueueLinger sets how long the queue waits after the first pending
+// message so others can be merged into it (default 250ms, 0 sends at once).
+func WithQueueLinger(d time.Duration) QueueOption {
+	return func(q *Queue) {
+		if d >= 0 {
+			q.linger = d
+		}
+	}
+}
+
+// WithQueueBuffer bounds the number of pending messages; Enqueue blocks while
+// the queue is full (default 100).
+func WithQueueBuffer(n int) QueueOption {
+	return func(q *Queue) {
+		if n > 0 {
+			q.buffer = n
+		}
+	}
+}
+
+// WithQueueSeparator sets the text placed between merged messages (default "\n").
+func WithQueueSeparator(sep string) QueueOption {
+	return func(q *Queue) {
+		q.separator = sep
+	}
+}
+
+// Queue accepts webhook messages from many goroutines and delivers them in
+// order on a single goroutine, within the webhook's rate limit. Consecutive
+// text-only messages with the same sender settings are merged into one
+// message while they fit in the content limit, so bursts of short alerts
+// cost one request instead of many.
+type Queue struct {
+	client    *Client
+	rate      int
+	per       time.Duration
+	linger    time.Duration
+	buffer    int
+	separator string
+
+	mu       sync.Mutex
+	pending  []*queuedMessage
+	closed   bool
+	seq      uint64
+	done     uint64
+	flushing int
+	progress chan struct{}
+
+	wake    chan struct{}
+	hurry   chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped chan struct{}
+
+	// sent holds recent delivery times; only the run goroutine touches it.
+	sent []time.Time
+}
+
+type queuedMessage struct {
+	ctx    context.Context
+	msg    types.WebhookMessage
+	onDone func(error)
+	seq    uint64
+}
+
+// NewQueue starts a queue that delivers through c. Call Close to flush and
+// stop it.
+func NewQueue(c *Client, opts ...QueueOption) *Queue {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		client:    c,
+		rate:      limits.WebhookMessagesPerMinute,
+		per:       time.Minute,
+		linger:    defaultQueueLinger,
+		buffer:    defaultQueueBuffer,
+		separator: defaultQueueSeparator,
+		progress:  make(chan struct{}),
+		wake:      make(chan struct{}, 1),
+		hurry:     make(chan struct{}, 1),
+		ctx:       ctx,
+		cancel:    cancel,
+		stopped:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(q)
+		}
+	}
+	go q.run()
+	return q
+}
+
+// Enqueue adds msg to the queue. onDone, if set, is called with the delivery
+// result once msg (or the merged message containing it) has been sent, or
+// with ctx.Err() if ctx ends first. Callbacks run on the queue's goroutine
+// and should not block.
+//
+// Enqueue blocks while the queue is full and returns types.ErrQueueClosed
+// after Close.
+func (q *Queue) Enqueue(ctx context.Context, msg *types.WebhookMessage, onDone func(error)) error {
+	if msg == nil {
+		return &types.ValidationError{Field: "message", Message: "message is required"}
+	}
+	if err := msg.Validate(); err != nil {
+		return fmt.Errorf("invalid webhook message: %w", err)
+	}
+
+	q.mu.Lock()
+	for {
+		if q.closed {
+			q.mu.Unlock()
+			return types.ErrQueueClosed
+		}
+		if len(q.pending) < q.buffer {
+			break
+		}
+		progress := q.progress
+		q.mu.Unlock()
+		select {
+		case <-progress:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		q.mu.Lock()
+	}
+	q.seq++
+	q.pending = append(q.pending, &queuedMessage{ctx: ctx, msg: *msg, onDone: onDone, seq: q.seq})
+	q.mu.Unlock()
+
+	signal(q.wake)
+	return nil
+}
+
+// Flush sends everything enqueued before the call without waiting for the
+// linger window, and returns once it has been delivered.
+func (q *Queue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	target := q.seq
+	q.flushing++
+	q.mu.Unlock()
+	defer func() {
+		q.mu.Lock()
+		q.flushing--
+		q.mu.Unlock()
+	}()
+	signal(q.hurry)
+
+	for {
+		q.mu.Lock()
+		if q.done >= target {
+			q.mu.Unlock()
+			return nil
+		}
+		progress := q.progress
+		q.mu.Unlock()
+
+		select {
+		case <-progress:
+		case <-q.stopped:
+			q.mu.Lock()
+			delivered := q.done >= target
+			q.mu.Unlock()
+			if delivered {
+				return nil
+			}
+			return types.ErrQueueClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Close stops accepting messages and delivers the ones already queued. If
+// ctx ends first, the remaining messages are abandoned with
+// types.ErrQueueClosed and ctx.Err() is returned.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	signal(q.wake)
+	signal(q.hurry)
+
+	select {
+	case <-q.stopped:
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-q.stopped
+		return ctx.Err()
+	}
+}
+
+// Pending reports the number of messages waiting to be sent.
+func (q *Queue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+func (q *Queue) run() {
+	defer close(q.stopped)
+	defer q.cancel()
+
+	for {
+		q.mu.Lock()
+		for len(q.pending) == 0 && !q.closed {
+			q.mu.Unlock()
+			select {
+			case <-q.wake:
+			case <-q.ctx.Done():
+			}
+			q.mu.Lock()
+			if q.ctx.Err() != nil {
+				break
+			}
+		}
+		if len(q.pending) == 0 {
+			q.mu.Unlock()
+			return
+		}
+		hurried := q.closed || q.flushing > 0
+		q.mu.Unlock()
+
+		if !hurried && q.linger > 0 {
+			q.sleep(q.linger, q.hurry)
+		}
+		if err := q.waitForSlot(); err != nil {
+			q.abandon()
+			return
+		}
+
+		batch := q.takeBatch()
+		var err error
+		if msg := q.merge(batch); msg != nil {
+			err = q.client.Send(q.ctx, msg)
+			q.sent = append(q.sent, time.Now())
+		}
+		q.complete(batch, err)
+
+		if q.ctx.Err() != nil {
+			q.abandon()
+			return
+		}
+	}
+}
+
+// sleep waits for d unless interrupted or the queue is cancelled.
+func (q *Queue) sleep(d time.Duration, interrupt <-chan struct{}) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-interrupt:
+	case <-q.ctx.Done():
+	}
+}
+
+// waitForSlot blocks until another message fits in the rate window.
+func (q *Queue) waitForSlot() error {
+	for {
+		now := time.Now()
+		cutoff := now.Add(-q.per)
+		i := 0
+		for i < len(q.sent) && !q.sent[i].After(cutoff) {
+			i++
+		}
+		q.sent = q.sent[i:]
+		if len(q.sent) < q.rate {
+			return nil
+		}
+
+		timer := time.NewTimer(q.sent[0].Add(q.per).Sub(now))
+		select {
+		case <-timer.C:
+		case <-q.ctx.Done():
+			timer.Stop()
+			return q.ctx.Err()
+		}
+	}
+}
+
+// takeBatch pops the head message plus any consecutive messages that can be
+// merged into it. Messages whose context has ended are popped as well and
+// completed with their context error.
+func (q *Queue) takeBatch() []*queuedMessage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var batch []*queuedMessage
+	var head *queuedMessage
+	length := 0
+	for len(q.pending) > 0 {
+		item := q.pending[0]
+		if item.ctx.Err() == nil {
+			if head == nil {
+				head = item
+				length = len(item.msg.Content)
+			} else {
+				next := length + len(q.separator) + len(item.msg.Content)
+				if !mergeable(&head.msg, &item.msg) || next > limits.MessageContentLength {
+					break
+				}
+				length = next
+			}
+		}
+		batch = append(batch, item)
+		q.pending = q.pending[1:]
+	}
+	return batch
+}
+
+// merge builds the message to send for batch, or nil if every message in it
+// has expired.
+func (q *Queue) merge(batch []*queuedMessage) *types.WebhookMessage {
+	var merged *types.WebhookMessage
+	for _, item := range batch {
+		if item.ctx.Err() != nil {
+			continue
+		}
+		if merged == nil {
+			msg := item.msg
+			merged = &msg
+			continue
+		}
+		merged.Content += q.separator + item.msg.Content
+	}
+	return merged
+}
+
+// complete reports the result for batch and records progress.
+func (q *Queue) complete(batch []*queuedMessage, err error) {
+	if len(batch) == 0 {
+		return
+	}
+	for _, item := range batch {
+		result := err
+		if ctxErr := item.ctx.Err(); ctxErr != nil && result == nil {
+			result = ctxErr
+		}
+		if item.onDone != nil {
+			item.onDone(result)
+		}
+	}
+
+	q.mu.Lock()
+	q.done = batch[len(batch)-1].seq
+	close(q.progress)
+	q.progress = make(chan struct{})
+	q.mu.Unlock()
+}
+
+// abandon fails every pending message after the queue was cancelled.
+func (q *Queue) abandon() {
+	q.mu.Lock()
+	batch := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+	q.complete(batch, types.ErrQueueClosed)
+}
+
+// mergeable reports whether b can be appended to a: both must be plain text
+// with the same sender, destination, and mention settings.
+func mergeable(a, b *types.WebhookMessage) bool {
+	textOnly := func(m *types.WebhookMessage) bool {
+		return m.Content != "" && len(m.Embeds) == 0 && len(m.Components) == 0 && m.Poll == nil && m.ThreadName == ""
+	}
+	return textOnly(a) && textOnly(b) &&
+		a.Username == b.Username &&
+		a.AvatarURL == b.AvatarURL &&
+		a.TTS == b.TTS &&
+		a.ThreadID == b.ThreadID &&
+		reflect.DeepEqual(a.AllowedMentions, b.AllowedMentions)
+}
+
+// signal performs a non-blocking send on a wake-up channel.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
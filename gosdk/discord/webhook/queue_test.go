@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// queueServer records the messages a webhook receives.
+type queueServer struct {
+	mu       sync.Mutex
+	messages []types.WebhookMessage
+	times    []time.Time
+	status   int
+}
+
+func newQueueServer(t *testing.T) (*queueServer, *Client) {
+	t.Helper()
+	qs := &queueServer{status: http.StatusNoContent}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg types.WebhookMessage
+		json.NewDecoder(r.Body).Decode(&msg)
+		qs.mu.Lock()
+		qs.messages = append(qs.messages, msg)
+		qs.times = append(qs.times, time.Now())
+		status := qs.status
+		qs.mu.Unlock()
+		w.WriteHeader(status)
+		if status >= 400 {
+			w.Write([]byte(`{"code":50006,"message":"Cannot send an empty message"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return qs, client
+}
+
+func TestQueueMergesTextMessages(t *testing.T) {
+	qs, client := newQueueServer(t)
+	q := NewQueue(client, WithQueueLinger(time.Hour))
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var results []error
+	record := func(err error) {
+		mu.Lock()
+		results = append(results, err)
+		mu.Unlock()
+	}
+	for _, content := range []string{"build 1 ok", "build 2 ok", "build 3 failed"} {
+		if err := q.Enqueue(ctx, &types.WebhookMessage{Content: content}, record); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+	// Different sender settings are never merged.
+	if err := q.Enqueue(ctx, &types.WebhookMessage{Content: "deploy", Username: "deployer"}, record); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	// Flush skips the linger window.
+	if err := q.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	if len(qs.messages) != 2 {
+		t.Fatalf("expected 2 requests, got %d: %+v", len(qs.messages), qs.messages)
+	}
+	if got := qs.messages[0].Content; got != "build 1 ok\nbuild 2 ok\nbuild 3 failed" {
+		t.Fatalf("unexpected merged content %q", got)
+	}
+	if qs.messages[1].Username != "deployer" {
+		t.Fatalf("expected separate message for a different username, got %+v", qs.messages[1])
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(results) != 4 {
+		t.Fatalf("expected 4 delivery callbacks, got %d", len(results))
+	}
+	for _, err := range results {
+		if err != nil {
+			t.Fatalf("unexpected delivery error %v", err)
+		}
+	}
+	if err := q.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestQueueRespectsRate(t *testing.T) {
+	qs, client := newQueueServer(t)
+	q := NewQueue(client, WithQueueRate(2, 100*time.Millisecond), WithQueueLinger(0))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		// Embeds are never merged, so each message is its own request.
+		msg := &types.WebhookMessage{Embeds: []types.Embed{{Title: "alert"}}}
+		if err := q.Enqueue(ctx, msg, nil); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+	if err := q.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	if len(qs.times) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(qs.times))
+	}
+	if gap := qs.times[2].Sub(qs.times[0]); gap < 90*time.Millisecond {
+		t.Fatalf("third message sent %v after the first, expected it to wait for the window", gap)
+	}
+}
+
+func TestQueueDeliveryErrorsAndClose(t *testing.T) {
+	qs, client := newQueueServer(t)
+	qs.status = http.StatusBadRequest
+	q := NewQueue(client, WithQueueLinger(0))
+	ctx := context.Background()
+
+	delivered := make(chan error, 1)
+	if err := q.Enqueue(ctx, &types.WebhookMessage{Content: "hello"}, func(err error) { delivered <- err }); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	if err := q.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var apiErr *types.APIError
+	if err := <-delivered; !errors.As(err, &apiErr) {
+		t.Fatalf("expected API error in callback, got %v", err)
+	}
+	if err := q.Enqueue(ctx, &types.WebhookMessage{Content: "late"}, nil); !errors.Is(err, types.ErrQueueClosed) {
+		t.Fatalf("expected ErrQueueClosed after Close, got %v", err)
+	}
+	if err := q.Enqueue(ctx, &types.WebhookMessage{}, nil); err == nil {
+		t.Fatal("expected validation error for an empty message")
+	}
+}
+
+func TestQueueCloseDeadlineAbandonsPending(t *testing.T) {
+	_, client := newQueueServer(t)
+	q := NewQueue(client, WithQueueRate(1, time.Hour), WithQueueLinger(0))
+	ctx := context.Background()
+
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		msg := &types.WebhookMessage{Embeds: []types.Embed{{Title: "alert"}}}
+		if err := q.Enqueue(ctx, msg, func(err error) { results <- err }); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	closeCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := q.Close(closeCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline from Close, got %v", err)
+	}
+	if err := <-results; err != nil {
+		t.Fatalf("first message should be delivered, got %v", err)
+	}
+	if err := <-results; !errors.Is(err, types.ErrQueueClosed) {
+		t.Fatalf("expected abandoned message to report ErrQueueClosed, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -308,3 +309,73 @@ func TestBuildURLWithThreadID(t *testing.T) {
 		})
 	}
 }
+
+func TestSendOpts_ExecuteQuery(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("wait") == "true" {
+			json.NewEncoder(w).Encode(types.Message{ID: "1"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	opts := SendOpts{
+		ThreadID:       "42",
+		WithComponents: true,
+		Query:          url.Values{"wait": {"false"}, "flag": {"a b"}},
+	}
+	if err := client.SendWithOpts(ctx, &types.WebhookMessage{Content: "hi", ThreadID: "7"}, opts); err != nil {
+		t.Fatalf("SendWithOpts() error = %v", err)
+	}
+	if want := "thread_id=42&with_components=true&flag=a+b"; queries[0] != want {
+		t.Errorf("query = %q, want %q", queries[0], want)
+	}
+
+	files := []FileAttachment{{Name: "a.txt", Reader: strings.NewReader("a")}}
+	if _, err := client.SendWithFilesWaitWithOpts(ctx, &types.WebhookMessage{Content: "file"}, files, SendOpts{ThreadID: "9"}); err != nil {
+		t.Fatalf("SendWithFilesWaitWithOpts() error = %v", err)
+	}
+	if want := "thread_id=9&wait=true"; queries[1] != want {
+		t.Errorf("query = %q, want %q", queries[1], want)
+	}
+
+	err = client.SendWithOpts(ctx, &types.WebhookMessage{Content: "x", ThreadName: "new"}, SendOpts{ThreadID: "1"})
+	if err == nil {
+		t.Fatal("expected error combining ThreadID option with ThreadName")
+	}
+}
+
+func TestClient_CreateThreadWait_AppliedTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		tags, _ := body["applied_tags"].([]any)
+		if body["thread_name"] != "Weekly report" || len(tags) != 1 || r.URL.Query().Get("wait") != "true" {
+			t.Errorf("unexpected request %s %v", r.URL.RawQuery, body)
+		}
+		json.NewEncoder(w).Encode(types.Message{ID: "1", ChannelID: "thread-1"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	msg, err := client.CreateThreadWait(context.Background(), "Weekly report", &types.WebhookMessage{Content: "numbers", AppliedTags: []string{"123"}})
+	if err != nil || msg.ChannelID != "thread-1" {
+		t.Fatalf("CreateThreadWait() = %+v, %v", msg, err)
+	}
+
+	if err := (&types.WebhookMessage{Content: "x", AppliedTags: []string{"123"}}).Validate(); err == nil {
+		t.Fatal("expected applied tags without thread_name to fail validation")
+	}
+}
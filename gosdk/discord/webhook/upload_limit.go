@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/version"
+)
+
+// PremiumTierResolver looks up a guild's boost tier.
+// (*client.Guilds).PremiumTier satisfies this signature and caches results.
+type PremiumTierResolver func(ctx context.Context, guildID string) (int, error)
+
+// WithUploadLimit overrides the per-file and per-message upload limit in bytes.
+// It takes precedence over WithPremiumTier and WithPremiumTierResolver.
+func WithUploadLimit(bytes int64) Option {
+	return func(c *Client) {
+		if bytes > 0 {
+			c.uploadLimit = bytes
+		}
+	}
+}
+
+// WithPremiumTier sets the upload limit from a known guild boost tier (0-3)
+func WithPremiumTier(tier int) Option {
+	return func(c *Client) {
+		if tier >= 0 {
+			c.uploadLimit = limits.UploadLimit(tier)
+		}
+	}
+}
+
+// WithPremiumTierResolver resolves the upload limit from the webhook's guild on
+// the first upload. The webhook is fetched once to learn its guild ID and the
+// resulting limit is cached for the life of the client.
+func WithPremiumTierResolver(resolve PremiumTierResolver) Option {
+	return func(c *Client) {
+		if resolve != nil {
+			c.tierResolver = resolve
+		}
+	}
+}
+
+// UploadLimit returns the upload limit in bytes applied to attachments.
+// Resolver failures are logged and fall back to the default MaxFileSize.
+func (c *Client) UploadLimit(ctx context.Context) int64 {
+	if c.uploadLimit > 0 {
+		return c.uploadLimit
+	}
+	if c.tierResolver == nil {
+		return MaxFileSize
+	}
+
+	c.uploadMu.Lock()
+	defer c.uploadMu.Unlock()
+	if c.resolvedLimit > 0 {
+		return c.resolvedLimit
+	}
+
+	guildID, err := c.fetchGuildID(ctx)
+	if err != nil {
+		c.logger.Warn("failed to resolve webhook guild for upload limit", "error", err)
+		return MaxFileSize
+	}
+	tier, err := c.tierResolver(ctx, guildID)
+	if err != nil {
+		c.logger.Warn("failed to resolve guild premium tier", "guild_id", guildID, "error", err)
+		return MaxFileSize
+	}
+
+	c.resolvedLimit = limits.UploadLimit(tier)
+	c.logger.Debug("resolved upload limit", "guild_id", guildID, "premium_tier", tier, "limit", c.resolvedLimit)
+	return c.resolvedLimit
+}
+
+// fetchGuildID retrieves the webhook object to learn which guild it posts to
+func (c *Client) fetchGuildID(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.webhookURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", &types.NetworkError{Op: "request", Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", c.parseErrorResponse(resp)
+	}
+
+	var webhook struct {
+		GuildID string `json:"guild_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&webhook); err != nil {
+		return "", fmt.Errorf("failed to decode webhook: %w", err)
+	}
+	if webhook.GuildID == "" {
+		return "", fmt.Errorf("webhook has no guild_id")
+	}
+	return webhook.GuildID, nil
+}
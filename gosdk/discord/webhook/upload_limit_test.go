@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+func TestUploadLimitOverride(t *testing.T) {
+	var posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithUploadLimit(16))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	msg := &types.WebhookMessage{Content: "test"}
+	small := []FileAttachment{{Name: "a.txt", Reader: bytes.NewReader(make([]byte, 16))}}
+	if err := client.SendWithFiles(context.Background(), msg, small); err != nil {
+		t.Fatalf("SendWithFiles() error = %v", err)
+	}
+
+	large := []FileAttachment{{Name: "b.txt", Reader: bytes.NewReader(make([]byte, 17))}}
+	err = client.SendWithFiles(context.Background(), msg, large)
+	var vErr *types.ValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if atomic.LoadInt32(&posts) != 1 {
+		t.Fatalf("expected oversized upload to be rejected locally, got %d requests", posts)
+	}
+}
+
+func TestUploadLimitPremiumTier(t *testing.T) {
+	client, _ := NewClient("https://discord.com/api/webhooks/1/token", WithPremiumTier(3))
+	if got := client.UploadLimit(context.Background()); got != limits.FileSizeTier3 {
+		t.Fatalf("UploadLimit() = %d, want %d", got, limits.FileSizeTier3)
+	}
+
+	client, _ = NewClient("https://discord.com/api/webhooks/1/token")
+	if got := client.UploadLimit(context.Background()); got != MaxFileSize {
+		t.Fatalf("UploadLimit() = %d, want default %d", got, MaxFileSize)
+	}
+}
+
+func TestUploadLimitResolver(t *testing.T) {
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		atomic.AddInt32(&gets, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1","guild_id":"42"}`))
+	}))
+	defer server.Close()
+
+	var resolvedGuild string
+	resolver := func(ctx context.Context, guildID string) (int, error) {
+		resolvedGuild = guildID
+		return 2, nil
+	}
+
+	client, _ := NewClient(server.URL, WithPremiumTierResolver(resolver))
+	for i := 0; i < 2; i++ {
+		if got := client.UploadLimit(context.Background()); got != limits.FileSizeTier2 {
+			t.Fatalf("UploadLimit() = %d, want %d", got, limits.FileSizeTier2)
+		}
+	}
+	if resolvedGuild != "42" {
+		t.Fatalf("expected resolver to receive guild 42, got %q", resolvedGuild)
+	}
+	if atomic.LoadInt32(&gets) != 1 {
+		t.Fatalf("expected webhook to be fetched once, got %d", gets)
+	}
+}
+
+func TestUploadLimitResolverFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1","guild_id":"42"}`))
+	}))
+	defer server.Close()
+
+	resolver := func(ctx context.Context, guildID string) (int, error) {
+		return 0, errors.New("boom")
+	}
+	client, _ := NewClient(server.URL, WithPremiumTierResolver(resolver))
+	if got := client.UploadLimit(context.Background()); got != MaxFileSize {
+		t.Fatalf("UploadLimit() = %d, want fallback %d", got, MaxFileSize)
+	}
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// discordHosts are the hosts Discord serves webhook URLs from.
+var discordHosts = map[string]bool{
+	"discord.com":           true,
+	"ptb.discord.com":       true,
+	"canary.discord.com":    true,
+	"discordapp.com":        true,
+	"ptb.discordapp.com":    true,
+	"canary.discordapp.com": true,
+}
+
+// ParseURL extracts the webhook ID and token from a Discord webhook URL of the
+// form https://discord.com/api[/vN]/webhooks/{id}/{token}. The ptb and canary
+// hosts and the legacy discordapp.com domain are accepted.
+func ParseURL(rawURL string) (id, token string, err error) {
+	u, err := parseHTTPURL(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Scheme != "https" || !discordHosts[strings.ToLower(u.Hostname())] {
+		return "", "", &types.ValidationError{
+			Field:   "webhookURL",
+			Message: "webhook URL must be an https://discord.com URL",
+		}
+	}
+
+	id, token, ok := webhookCredentials(u.Path)
+	if !ok {
+		return "", "", &types.ValidationError{
+			Field:   "webhookURL",
+			Message: "webhook URL must look like https://discord.com/api/webhooks/{id}/{token}",
+		}
+	}
+	return id, token, nil
+}
+
+// validateURL rejects URLs the client could never send to. Discord hosts must
+// parse with ParseURL; other hosts (proxies, test servers) only need to be
+// absolute http(s) URLs.
+func validateURL(rawURL string) (id, token string, err error) {
+	u, err := parseHTTPURL(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	if discordHosts[strings.ToLower(u.Hostname())] {
+		return ParseURL(rawURL)
+	}
+	id, token, _ = webhookCredentials(u.Path)
+	return id, token, nil
+}
+
+func parseHTTPURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, &types.ValidationError{
+			Field:   "webhookURL",
+			Message: "webhook URL must be an absolute http(s) URL",
+		}
+	}
+	return u, nil
+}
+
+// webhookCredentials finds .../webhooks/{id}/{token} in path.
+func webhookCredentials(path string) (id, token string, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if segment != "webhooks" || i+2 >= len(segments) {
+			continue
+		}
+		id, token = segments[i+1], segments[i+2]
+		if !isSnowflake(id) || token == "" || i+3 != len(segments) {
+			return "", "", false
+		}
+		return id, token, true
+	}
+	return "", "", false
+}
+
+func isSnowflake(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -7,22 +7,46 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/idempotency"
 	"github.com/mtreilly/godiscord/gosdk/logger"
 	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+	"github.com/mtreilly/godiscord/gosdk/retry"
+	"github.com/mtreilly/godiscord/gosdk/version"
 )
 
+// rateLimitLogInterval bounds "rate limit hit" warnings to one per route per
+// interval; the rest are counted in the next warning.
+const rateLimitLogInterval = 30 * time.Second
+
 // Client represents a Discord webhook client
 type Client struct {
 	webhookURL  string
+	id          string
+	token       string
 	httpClient  *http.Client
 	maxRetries  int
+	retry       retry.Policy
 	timeout     time.Duration
 	rateLimiter ratelimit.Tracker
 	strategy    ratelimit.Strategy
 	logger      *logger.Logger
+	rateLogger  *logger.Logger
+	observer    ratelimit.Observer
+
+	defaultMentions *types.AllowedMentions
+	idempotency     idempotency.Store
+
+	uploadLimit   int64
+	tierResolver  PremiumTierResolver
+	uploadMu      sync.Mutex
+	resolvedLimit int64
 }
 
 // Option is a functional option for configuring the webhook client
@@ -42,6 +66,16 @@ func WithMaxRetries(retries int) Option {
 	}
 }
 
+// WithRetryPolicy replaces the default exponential backoff for failed
+// requests. It takes precedence over WithMaxRetries.
+func WithRetryPolicy(policy retry.Policy) Option {
+	return func(c *Client) {
+		if policy != nil {
+			c.retry = policy
+		}
+	}
+}
+
 // WithTimeout sets the request timeout
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Client) {
@@ -64,17 +98,48 @@ func WithStrategy(strategy ratelimit.Strategy) Option {
 }
 
 // WithStrategyName sets the rate limiting strategy by name
-// Supported: "reactive", "proactive", "adaptive"
+// Supported: "reactive", "proactive", "adaptive", "token_bucket"
 func WithStrategyName(name string) Option {
 	return func(c *Client) {
 		c.strategy = createStrategy(name)
 	}
 }
 
+// WithRateLimitObserver reports rate limit waits, 429s, and bucket updates
+func WithRateLimitObserver(observer ratelimit.Observer) Option {
+	return func(c *Client) {
+		if observer != nil {
+			c.observer = observer
+		}
+	}
+}
+
+// WithDefaultAllowedMentions applies mentions to every message sent or edited
+// through the client that doesn't set its own AllowedMentions, e.g.
+// types.NoMentions() so relayed text can never ping @everyone.
+func WithDefaultAllowedMentions(mentions *types.AllowedMentions) Option {
+	return func(c *Client) {
+		c.defaultMentions = mentions
+	}
+}
+
+// WithIdempotencyStore sets where sends with SendOpts.IdempotencyKey record
+// their keys. The default in-memory store only catches retries made by this
+// process.
+func WithIdempotencyStore(store idempotency.Store) Option {
+	return func(c *Client) {
+		if store != nil {
+			c.idempotency = store
+		}
+	}
+}
+
 // WithLogger sets a custom logger
-func WithLogger(log *logger.Logger) Option {
+func WithLogger(log logger.Interface) Option {
 	return func(c *Client) {
-		c.logger = log
+		if lg := logger.From(log); lg != nil {
+			c.logger = lg
+		}
 	}
 }
 
@@ -87,40 +152,210 @@ func NewClient(webhookURL string, opts ...Option) (*Client, error) {
 		}
 	}
 
+	id, token, err := validateURL(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Client{
 		webhookURL:  webhookURL,
+		id:          id,
+		token:       token,
 		httpClient:  &http.Client{},
 		maxRetries:  3,
 		timeout:     30 * time.Second,
 		rateLimiter: ratelimit.NewMemoryTracker(),
 		strategy:    ratelimit.NewDefaultAdaptiveStrategy(),
 		logger:      logger.Default(),
+		observer:    ratelimit.NopObserver{},
+		idempotency: idempotency.NewMemoryStore(0),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.logger = c.logger.WithSubsystem(logger.SubsystemWebhook)
+	c.rateLogger = c.logger.WithSubsystem(logger.SubsystemRateLimit)
 
 	c.httpClient.Timeout = c.timeout
 
 	return c, nil
 }
 
+// ID returns the webhook ID parsed from the URL, or "" if the URL does not
+// contain one (e.g. a proxy URL).
+func (c *Client) ID() string {
+	return c.id
+}
+
+// Token returns the webhook token parsed from the URL, or "" if the URL does
+// not contain one.
+func (c *Client) Token() string {
+	return c.token
+}
+
+// Priority controls how a single send interacts with rate limit throttling
+type Priority int
+
+const (
+	// PriorityNormal applies the client's configured rate limit strategy
+	PriorityNormal Priority = iota
+	// PriorityHigh bypasses proactive throttling; exhausted buckets are still honored
+	PriorityHigh
+)
+
+// SendOpts overrides rate limit behaviour and execute query parameters for a
+// single send. New execute-time flags are added here rather than as new
+// Send variants.
+type SendOpts struct {
+	Priority          Priority
+	SkipProactiveWait bool
+
+	// ThreadID posts into an existing thread, overriding msg.ThreadID.
+	ThreadID string
+	// WithComponents sets with_components even when msg has no components.
+	// It is set automatically when msg carries components.
+	WithComponents bool
+	// Query holds extra execute query parameters for flags the SDK does not
+	// model yet. wait, thread_id and with_components are managed by the
+	// client and ignored here.
+	Query url.Values
+	// IdempotencyKey makes the send at most once per key: a repeat after
+	// the message was created returns it without posting again. See
+	// SendIdempotent.
+	IdempotencyKey string
+}
+
+// validate rejects option combinations Discord refuses for msg.
+func (o SendOpts) validate(msg *types.WebhookMessage) error {
+	if o.ThreadID != "" && msg.ThreadName != "" {
+		return &types.ValidationError{Field: "thread_id", Message: "cannot send to a thread and create one in the same request"}
+	}
+	return nil
+}
+
+func (o SendOpts) skipProactive() bool {
+	return o.SkipProactiveWait || o.Priority == PriorityHigh
+}
+
 // Send sends a message via the webhook
 func (c *Client) Send(ctx context.Context, msg *types.WebhookMessage) error {
+	return c.SendWithOpts(ctx, msg, SendOpts{})
+}
+
+// SendWithOpts sends a message via the webhook using per-call rate limit overrides.
+// Urgent messages can use PriorityHigh to skip the proactive waits that suit
+// background traffic; Discord's hard limits and 429 responses are still respected.
+func (c *Client) SendWithOpts(ctx context.Context, msg *types.WebhookMessage, opts SendOpts) error {
 	if err := msg.Validate(); err != nil {
 		return fmt.Errorf("invalid webhook message: %w", err)
 	}
+	if err := opts.validate(msg); err != nil {
+		return err
+	}
+	if opts.IdempotencyKey != "" {
+		_, err := c.sendIdempotent(ctx, msg, opts)
+		return err
+	}
+	msg = c.withDefaultMentions(msg)
 
 	body, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal webhook message: %w", err)
 	}
 
-	// Build URL with thread_id query parameter if specified
-	url := c.buildURLWithThreadID(c.webhookURL, msg.ThreadID)
+	return c.sendWithRetryToURL(ctx, body, c.executeURL(msg, false, opts), opts, nil)
+}
+
+// SendWait sends a message with ?wait=true and returns the created message,
+// so it can later be edited or deleted by ID.
+func (c *Client) SendWait(ctx context.Context, msg *types.WebhookMessage) (*types.Message, error) {
+	return c.SendWaitWithOpts(ctx, msg, SendOpts{})
+}
+
+// SendWaitWithOpts is SendWait with per-call rate limit overrides.
+func (c *Client) SendWaitWithOpts(ctx context.Context, msg *types.WebhookMessage, opts SendOpts) (*types.Message, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid webhook message: %w", err)
+	}
+	if err := opts.validate(msg); err != nil {
+		return nil, err
+	}
+	if opts.IdempotencyKey != "" {
+		result, err := c.sendIdempotent(ctx, msg, opts)
+		if err != nil {
+			return nil, err
+		}
+		return result.Message, nil
+	}
+	msg = c.withDefaultMentions(msg)
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook message: %w", err)
+	}
+
+	var created types.Message
+	if err := c.sendWithRetryToURL(ctx, body, c.executeURL(msg, true, opts), opts, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
 
-	return c.sendWithRetryToURL(ctx, body, url)
+// SendIdempotent sends msg under key and reports whether an earlier call
+// with the same key had already created it, in which case nothing is sent.
+//
+// Webhooks have no server-side nonce, so sends made under a key are not
+// retried after network errors or 5xx responses, and a key whose send ended without a
+// response returns idempotency.ErrPending rather than risking a second
+// post. Keys are kept in the client's idempotency store; a key whose send
+// Discord rejected is released and may be reused.
+func (c *Client) SendIdempotent(ctx context.Context, key string, msg *types.WebhookMessage, opts SendOpts) (*idempotency.Result, error) {
+	if key == "" {
+		return nil, &types.ValidationError{Field: "key", Message: "idempotency key is required"}
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid webhook message: %w", err)
+	}
+	if err := opts.validate(msg); err != nil {
+		return nil, err
+	}
+	opts.IdempotencyKey = key
+	return c.sendIdempotent(ctx, msg, opts)
+}
+
+// sendIdempotent sends a validated msg under opts.IdempotencyKey.
+func (c *Client) sendIdempotent(ctx context.Context, msg *types.WebhookMessage, opts SendOpts) (*idempotency.Result, error) {
+	key := opts.IdempotencyKey
+	record, claimed, err := c.idempotency.Claim(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("idempotency claim failed: %w", err)
+	}
+	if !claimed {
+		if record.Pending() {
+			return nil, idempotency.ErrPending
+		}
+		return &idempotency.Result{Message: record.Message, WasDuplicate: true}, nil
+	}
+
+	msg = c.withDefaultMentions(msg)
+	body, err := json.Marshal(msg)
+	if err != nil {
+		_ = c.idempotency.Release(ctx, key)
+		return nil, fmt.Errorf("failed to marshal webhook message: %w", err)
+	}
+
+	var created types.Message
+	if err := c.sendWithRetryToURL(ctx, body, c.executeURL(msg, true, opts), opts, &created); err != nil {
+		if idempotency.Rejected(err) {
+			_ = c.idempotency.Release(ctx, key)
+		}
+		return nil, err
+	}
+	if err := c.idempotency.Complete(ctx, key, &created); err != nil {
+		c.logger.Warn("idempotency record failed", "key", key, "error", err)
+	}
+	return &idempotency.Result{Message: &created}, nil
 }
 
 // SendToThread sends a message to a specific thread
@@ -154,6 +389,22 @@ func (c *Client) CreateThread(ctx context.Context, threadName string, msg *types
 	return c.Send(ctx, msg)
 }
 
+// CreateThreadWait creates a forum thread like CreateThread and returns the
+// starter message; its ChannelID is the new thread's ID. Set
+// msg.AppliedTags to tag the thread.
+func (c *Client) CreateThreadWait(ctx context.Context, threadName string, msg *types.WebhookMessage) (*types.Message, error) {
+	if threadName == "" {
+		return nil, &types.ValidationError{
+			Field:   "threadName",
+			Message: "thread name is required",
+		}
+	}
+
+	msg.ThreadName = threadName
+
+	return c.SendWait(ctx, msg)
+}
+
 // SendSimple sends a simple text message via the webhook
 func (c *Client) SendSimple(ctx context.Context, content string) error {
 	return c.Send(ctx, &types.WebhookMessage{
@@ -161,23 +412,30 @@ func (c *Client) SendSimple(ctx context.Context, content string) error {
 	})
 }
 
-func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string) error {
-	var lastErr error
-	backoff := time.Second
-	route := c.buildRoute("POST", url)
+// withDefaultMentions returns msg, or a copy carrying the client's default
+// allowed mentions when msg sets none.
+func (c *Client) withDefaultMentions(msg *types.WebhookMessage) *types.WebhookMessage {
+	if msg.AllowedMentions != nil || c.defaultMentions == nil {
+		return msg
+	}
+	withDefault := *msg
+	withDefault.AllowedMentions = c.defaultMentions
+	return &withDefault
+}
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoff):
-				backoff *= 2
-			}
-		}
+// sendWithRetryToURL posts body to url, decoding the response into out when
+// it is non-nil (requests sent with ?wait=true).
+func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string, opts SendOpts, out *types.Message) error {
+	route := c.buildRoute("POST", url)
+	policy := c.retryPolicy(time.Second)
+	if opts.IdempotencyKey != "" {
+		policy = noAmbiguousRetry(policy)
+	}
+	retrier := retry.New(policy)
 
+	for {
 		// Rate limiting: centralize proactive + reactive waits
-		if err := c.waitForRateLimit(ctx, route); err != nil {
+		if err := c.waitForRateLimit(ctx, route, opts.skipProactive()); err != nil {
 			return fmt.Errorf("rate limit wait failed: %w", err)
 		}
 
@@ -187,27 +445,28 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 		}
 
 		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "DiscordWebhook/1.0")
+		req.Header.Set("User-Agent", version.UserAgent())
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			lastErr = &types.NetworkError{Op: "request", Err: err}
+			if err := retrier.Wait(ctx, retry.Attempt{Err: &types.NetworkError{Op: "request", Err: err}}); err != nil {
+				return err
+			}
 			continue
 		}
 
 		// Update rate limiter with response headers
-		if c.rateLimiter != nil {
-			c.rateLimiter.Update(route, resp.Header)
-		}
+		c.updateRateLimit(route, resp.Header)
 
 		// Success
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			err := decodeMessage(resp, out)
 			resp.Body.Close()
 
 			// Record successful request for adaptive strategy
 			c.recordStrategyOutcome(route, false)
 
-			return nil
+			return err
 		}
 
 		// Read error response
@@ -222,7 +481,7 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 		// Parse JSON error if available
 		var errData struct {
 			Message    string                 `json:"message"`
-			Code       int                    `json:"code"`
+			Code       types.ErrorCode        `json:"code"`
 			Errors     map[string]interface{} `json:"errors"`
 			RetryAfter float64                `json:"retry_after"`
 		}
@@ -237,36 +496,48 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 
 		// Handle rate limiting (429)
 		if resp.StatusCode == 429 {
-			c.logger.Warn("rate limit hit",
+			c.rateLogger.Every("rate limit hit:"+route, rateLimitLogInterval).Warn("rate limit hit",
 				"route", route,
 				"retry_after", apiErr.RetryAfter,
-				"attempt", attempt+1,
+				"attempt", retrier.Attempts()+1,
 			)
 
 			// Record rate limit hit for adaptive strategy
 			c.recordStrategyOutcome(route, true)
-
-			if apiErr.RetryAfter > 0 {
-				backoff = time.Duration(apiErr.RetryAfter) * time.Second
-			}
-			lastErr = apiErr
-			continue
+			c.observeRateLimited(route, apiErr, resp.Header)
 		}
 
-		// Don't retry client errors (except rate limits)
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			return apiErr
+		if err := retrier.Wait(ctx, retry.Attempt{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retry.ParseRetryAfter(resp.Header, time.Duration(apiErr.RetryAfter)*time.Second),
+			Err:        apiErr,
+		}); err != nil {
+			return err
 		}
-
-		// Retry server errors
-		lastErr = apiErr
 	}
+}
 
-	if lastErr != nil {
-		return fmt.Errorf("webhook request failed after %d attempts: %w", c.maxRetries+1, lastErr)
+// retryPolicy returns the configured policy, or the default backoff
+// starting at base and sized by WithMaxRetries.
+func (c *Client) retryPolicy(base time.Duration) retry.Policy {
+	if c.retry != nil {
+		return c.retry
 	}
+	policy := retry.Default()
+	policy.MaxAttempts = c.maxRetries + 1
+	policy.Base = base
+	return policy
+}
 
-	return fmt.Errorf("webhook request failed after %d attempts", c.maxRetries+1)
+// noAmbiguousRetry wraps policy so requests that got no response or a 5xx
+// are not retried; the failed try may already have created the message.
+func noAmbiguousRetry(policy retry.Policy) retry.Policy {
+	return retry.PolicyFunc(func(a retry.Attempt) (time.Duration, bool) {
+		if a.StatusCode == 0 || a.StatusCode >= 500 {
+			return 0, false
+		}
+		return policy.Next(a)
+	})
 }
 
 // parseErrorResponse parses an HTTP error response into an APIError
@@ -281,7 +552,7 @@ func (c *Client) parseErrorResponse(resp *http.Response) *types.APIError {
 	// Parse JSON error if available
 	var errData struct {
 		Message    string                 `json:"message"`
-		Code       int                    `json:"code"`
+		Code       types.ErrorCode        `json:"code"`
 		Errors     map[string]interface{} `json:"errors"`
 		RetryAfter float64                `json:"retry_after"`
 	}
@@ -306,26 +577,34 @@ func createStrategy(name string) ratelimit.Strategy {
 		return ratelimit.NewDefaultProactiveStrategy()
 	case "adaptive":
 		return ratelimit.NewDefaultAdaptiveStrategy()
+	case "token_bucket":
+		return ratelimit.NewDefaultTokenBucketStrategy()
 	default:
 		// Default to adaptive
 		return ratelimit.NewDefaultAdaptiveStrategy()
 	}
 }
 
-// waitForRateLimit handles rate limiting before making a request
-func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
+// waitForRateLimit handles rate limiting before making a request.
+// When skipProactive is set, only the reactive wait on exhausted buckets applies.
+func (c *Client) waitForRateLimit(ctx context.Context, route string, skipProactive bool) error {
 	if c.rateLimiter == nil {
 		return nil
 	}
 
 	var strategyName string
-	if c.strategy != nil {
+	if skipProactive {
+		strategyName = "skipped"
+	} else if c.strategy != nil {
 		strategyName = c.strategy.Name()
+		if err := c.waitForRouteBudget(ctx, route); err != nil {
+			return err
+		}
 		bucket := c.rateLimiter.GetBucket(route)
 		if bucket != nil && c.strategy.ShouldWait(bucket) {
 			waitDuration := c.strategy.CalculateWait(bucket)
 			if waitDuration > 0 {
-				c.logger.Debug("rate limit: proactive wait",
+				c.rateLogger.Debug("rate limit: proactive wait",
 					"route", route,
 					"wait_duration", waitDuration,
 					"strategy", strategyName,
@@ -337,10 +616,11 @@ func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 				case <-ctx.Done():
 					return ctx.Err()
 				case <-time.After(waitDuration):
-					c.logger.Debug("rate limit: proactive wait complete",
+					c.rateLogger.Debug("rate limit: proactive wait complete",
 						"route", route,
 						"strategy", strategyName,
 					)
+					ratelimit.ObserveWait(ctx, c.observer, route, waitDuration, ratelimit.WaitProactive)
 				}
 			}
 		}
@@ -351,18 +631,22 @@ func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 	reactiveWait := false
 	if bucket := c.rateLimiter.GetBucket(route); bucket != nil && bucket.Remaining <= 0 && time.Now().Before(bucket.Reset) {
 		reactiveWait = true
-		c.logger.Debug("rate limit: reactive wait scheduled",
+		c.rateLogger.Debug("rate limit: reactive wait scheduled",
 			"route", route,
 			"reset_in", time.Until(bucket.Reset),
 		)
 	}
 
+	start := time.Now()
 	if err := c.rateLimiter.Wait(ctx, route); err != nil {
 		return err
 	}
+	if waited := time.Since(start); reactiveWait || waited >= time.Millisecond {
+		ratelimit.ObserveWait(ctx, c.observer, route, waited, ratelimit.WaitReactive)
+	}
 
 	if reactiveWait {
-		c.logger.Debug("rate limit: reactive wait complete",
+		c.rateLogger.Debug("rate limit: reactive wait complete",
 			"route", route,
 			"strategy", strategyName,
 		)
@@ -371,6 +655,32 @@ func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 	return nil
 }
 
+// waitForRouteBudget applies client-side per-route budgets such as TokenBucketStrategy
+func (c *Client) waitForRouteBudget(ctx context.Context, route string) error {
+	wait, err := ratelimit.WaitForRouteBudget(ctx, c.strategy, route, c.observer)
+	if wait > 0 {
+		c.rateLogger.Debug("rate limit: route budget wait",
+			"route", route,
+			"wait_duration", wait,
+		)
+	}
+	return err
+}
+
+// updateRateLimit feeds response headers to the tracker and notifies the observer
+func (c *Client) updateRateLimit(route string, headers http.Header) {
+	if c.rateLimiter == nil {
+		return
+	}
+	c.rateLimiter.Update(route, headers)
+	c.observer.OnBucketUpdate(route, c.rateLimiter.GetBucket(route))
+}
+
+// observeRateLimited reports a 429 response to the observer
+func (c *Client) observeRateLimited(route string, apiErr *types.APIError, headers http.Header) {
+	c.observer.On429(route, time.Duration(apiErr.RetryAfter)*time.Second, ratelimit.IsGlobalRateLimit(headers))
+}
+
 // buildRoute creates a route identifier for rate limiting
 func (c *Client) buildRoute(method, url string) string {
 	return ratelimit.RouteFromEndpoint(method, url)
@@ -391,3 +701,54 @@ func (c *Client) buildURLWithThreadID(baseURL, threadID string) string {
 	}
 	return baseURL + "?thread_id=" + threadID
 }
+
+// executeURL builds the execute URL for msg. Discord drops components from
+// application-less webhooks unless with_components is set, and wait asks it
+// to return the created message.
+func (c *Client) executeURL(msg *types.WebhookMessage, wait bool, opts SendOpts) string {
+	threadID := msg.ThreadID
+	if opts.ThreadID != "" {
+		threadID = opts.ThreadID
+	}
+	endpoint := c.buildURLWithThreadID(c.webhookURL, threadID)
+	if wait {
+		endpoint = appendQuery(endpoint, "wait", "true")
+	}
+	if len(msg.Components) > 0 || opts.WithComponents {
+		endpoint = appendQuery(endpoint, "with_components", "true")
+	}
+	keys := make([]string, 0, len(opts.Query))
+	for key := range opts.Query {
+		switch key {
+		case "wait", "thread_id", "with_components":
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range opts.Query[key] {
+			endpoint = appendQuery(endpoint, url.QueryEscape(key), url.QueryEscape(value))
+		}
+	}
+	return endpoint
+}
+
+// appendQuery adds a single query parameter to url
+func appendQuery(url, key, value string) string {
+	if strings.Contains(url, "?") {
+		return url + "&" + key + "=" + value
+	}
+	return url + "?" + key + "=" + value
+}
+
+// decodeMessage reads the created message from a ?wait=true response
+func decodeMessage(resp *http.Response, out *types.Message) error {
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -1,14 +1,23 @@
 package webhook
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/idempotency"
+	"github.com/mtreilly/godiscord/gosdk/logger"
+	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+	"github.com/mtreilly/godiscord/gosdk/retry"
 )
 
 func TestNewClient(t *testing.T) {
@@ -19,6 +28,10 @@ func TestNewClient(t *testing.T) {
 	}{
 		{"valid URL", "https://discord.com/api/webhooks/123/abc", false},
 		{"empty URL", "", true},
+		{"proxy URL", "http://localhost:8080/hooks/alerts", false},
+		{"not a URL", "discord webhook", true},
+		{"unsupported scheme", "ftp://discord.com/api/webhooks/123/abc", true},
+		{"discord URL without token", "https://discord.com/api/webhooks/123", true},
 	}
 
 	for _, tt := range tests {
@@ -35,6 +48,55 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		wantID    string
+		wantToken string
+		wantErr   bool
+	}{
+		{"discord", "https://discord.com/api/webhooks/123/abc", "123", "abc", false},
+		{"versioned canary", "https://canary.discord.com/api/v10/webhooks/123/abc/", "123", "abc", false},
+		{"legacy domain", "https://discordapp.com/api/webhooks/123/abc", "123", "abc", false},
+		{"other host", "https://example.com/api/webhooks/123/abc", "", "", true},
+		{"http", "http://discord.com/api/webhooks/123/abc", "", "", true},
+		{"non-numeric ID", "https://discord.com/api/webhooks/abc/def", "", "", true},
+		{"extra segments", "https://discord.com/api/webhooks/123/abc/messages/1", "", "", true},
+		{"malformed", "://", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, token, err := ParseURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if id != tt.wantID || token != tt.wantToken {
+				t.Fatalf("ParseURL() = %q, %q, want %q, %q", id, token, tt.wantID, tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestClient_IDAndToken(t *testing.T) {
+	client, err := NewClient("https://ptb.discord.com/api/webhooks/123/abc")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.ID() != "123" || client.Token() != "abc" {
+		t.Fatalf("ID(), Token() = %q, %q", client.ID(), client.Token())
+	}
+
+	proxied, err := NewClient("http://localhost:8080/hooks/alerts")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if proxied.ID() != "" || proxied.Token() != "" {
+		t.Fatalf("expected empty credentials for proxy URL, got %q, %q", proxied.ID(), proxied.Token())
+	}
+}
+
 func TestClient_SendSimple(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -98,6 +160,29 @@ func TestClient_SendWithRetry(t *testing.T) {
 	}
 }
 
+func TestClient_WithRetryPolicy(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithRetryPolicy(&retry.Backoff{MaxAttempts: 2, Base: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	err = client.SendSimple(context.Background(), "test message")
+	var retryErr *retry.Error
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 2 || !errors.Is(err, types.ErrServerError) {
+		t.Fatalf("SendSimple() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
 func TestClient_RateLimit(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -129,3 +214,319 @@ func TestClient_RateLimit(t *testing.T) {
 		t.Errorf("Expected at least 500ms delay for rate limit, got %v", elapsed)
 	}
 }
+
+func TestClient_RateLimitWarningsSampled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(map[string]interface{}{"retry_after": 0, "global": false})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := NewClient(server.URL,
+		WithMaxRetries(3),
+		WithLogger(logger.New(logger.InfoLevel, "json", &buf)),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if err := client.SendSimple(context.Background(), "test message"); err == nil {
+		t.Fatal("expected rate limit error")
+	}
+	if got := strings.Count(buf.String(), "rate limit hit"); got != 1 {
+		t.Fatalf("expected one rate limit warning per interval, got %d:\n%s", got, buf.String())
+	}
+}
+
+// slowStrategy always requests a long proactive wait.
+type slowStrategy struct{}
+
+func (slowStrategy) ShouldWait(*ratelimit.Bucket) bool             { return true }
+func (slowStrategy) CalculateWait(*ratelimit.Bucket) time.Duration { return time.Hour }
+func (slowStrategy) Name() string                                  { return "slow" }
+
+func TestClient_SendWithOptsSkipsProactiveWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.Header().Set("X-RateLimit-Reset-After", "60")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithStrategy(slowStrategy{}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	// First send primes the bucket; the strategy has nothing to act on yet.
+	if err := client.SendSimple(context.Background(), "prime"); err != nil {
+		t.Fatalf("SendSimple() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	msg := &types.WebhookMessage{Content: "urgent"}
+	if err := client.SendWithOpts(ctx, msg, SendOpts{Priority: PriorityHigh}); err != nil {
+		t.Fatalf("SendWithOpts(PriorityHigh) error = %v", err)
+	}
+	if err := client.SendWithOpts(ctx, msg, SendOpts{SkipProactiveWait: true}); err != nil {
+		t.Fatalf("SendWithOpts(SkipProactiveWait) error = %v", err)
+	}
+
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer shortCancel()
+	if err := client.Send(shortCtx, msg); err == nil {
+		t.Fatal("Send() expected proactive wait to exceed the deadline")
+	}
+}
+
+func TestClient_RateLimitObserver(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-RateLimit-Bucket", "abc")
+		w.Header().Set("X-RateLimit-Limit", "5")
+		w.Header().Set("X-RateLimit-Remaining", "3")
+		w.Header().Set("X-RateLimit-Reset-After", "60")
+		if attempts == 1 {
+			w.Header().Set("X-RateLimit-Scope", "global")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(map[string]interface{}{"message": "slow down", "retry_after": 0})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var (
+		mu       sync.Mutex
+		limited  []bool
+		buckets  []*ratelimit.Bucket
+		observer = ratelimit.ObserverFuncs{
+			RateLimited: func(route string, retryAfter time.Duration, global bool) {
+				mu.Lock()
+				limited = append(limited, global)
+				mu.Unlock()
+			},
+			BucketUpdate: func(route string, bucket *ratelimit.Bucket) {
+				mu.Lock()
+				buckets = append(buckets, bucket)
+				mu.Unlock()
+			},
+		}
+	)
+
+	client, err := NewClient(server.URL, WithMaxRetries(1), WithRateLimitObserver(observer))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if err := client.SendSimple(context.Background(), "hi"); err != nil {
+		t.Fatalf("SendSimple() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(limited) != 1 || !limited[0] {
+		t.Fatalf("expected one global 429 observation, got %v", limited)
+	}
+	if len(buckets) != 2 || buckets[1] == nil || buckets[1].Remaining != 3 {
+		t.Fatalf("expected a bucket update per response, got %+v", buckets)
+	}
+}
+
+func TestClient_SendWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("wait"); got != "true" {
+			t.Errorf("Expected wait=true, got %q", got)
+		}
+		if got := r.URL.Query().Get("thread_id"); got != "42" {
+			t.Errorf("Expected thread_id=42, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"987","channel_id":"42","content":"hello"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	msg, err := client.SendWait(context.Background(), &types.WebhookMessage{Content: "hello", ThreadID: "42"})
+	if err != nil {
+		t.Fatalf("SendWait() error = %v", err)
+	}
+	if msg.ID != "987" || msg.ChannelID != "42" || msg.Content != "hello" {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+}
+
+func TestClient_SendWaitDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.SendWait(context.Background(), &types.WebhookMessage{Content: "hello"}); err == nil {
+		t.Fatal("expected an error when Discord returns no message")
+	}
+}
+
+func TestClient_DefaultAllowedMentions(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		bodies = append(bodies, string(payload["allowed_mentions"]))
+		mu.Unlock()
+		if r.Method == http.MethodPatch {
+			json.NewEncoder(w).Encode(types.Message{ID: "1"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithDefaultAllowedMentions(types.NoMentions()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	msg := &types.WebhookMessage{Content: "@everyone"}
+	if err := client.Send(ctx, msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if msg.AllowedMentions != nil {
+		t.Fatal("default should not be written back to the caller's message")
+	}
+	roles, _ := types.NewAllowedMentions().Parse(types.AllowedMentionRoles).Build()
+	if err := client.Send(ctx, &types.WebhookMessage{Content: "<@&2>", AllowedMentions: roles}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	content := "edited"
+	if _, err := client.Edit(ctx, "1", &MessageEditParams{Content: &content}); err != nil {
+		t.Fatalf("Edit() error = %v", err)
+	}
+
+	want := []string{`{}`, `{"parse":["roles"]}`, `{}`}
+	for i := range want {
+		if bodies[i] != want[i] {
+			t.Fatalf("request %d allowed_mentions = %s, want %s", i, bodies[i], want[i])
+		}
+	}
+}
+
+func TestClient_SendWithComponents(t *testing.T) {
+	var got types.WebhookMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("with_components"); q != "true" {
+			t.Errorf("Expected with_components=true, got %q", q)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	msg := &types.WebhookMessage{
+		Content: "Release notes",
+		Components: []types.MessageComponent{{
+			Type: types.ComponentTypeActionRow,
+			Components: []types.MessageComponent{{
+				Type:  types.ComponentTypeButton,
+				Style: int(types.ButtonStyleLink),
+				Label: "Changelog",
+				URL:   "https://example.com/changelog",
+			}},
+		}},
+		Poll: types.NewPoll("Ship it?", 24, "Yes", "No"),
+	}
+	if err := client.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(got.Components) != 1 || got.Poll == nil || len(got.Poll.Answers) != 2 {
+		t.Fatalf("components or poll missing from payload: %+v", got)
+	}
+}
+
+func TestClient_SendIdempotent(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("wait") != "true" {
+			t.Errorf("expected wait=true, got %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(types.Message{ID: "42"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	msg := &types.WebhookMessage{Content: "deploy finished"}
+
+	first, err := client.SendIdempotent(ctx, "deploy-7", msg, SendOpts{})
+	if err != nil || first.WasDuplicate || first.Message.ID != "42" {
+		t.Fatalf("first SendIdempotent() = %+v, %v", first, err)
+	}
+	second, err := client.SendIdempotent(ctx, "deploy-7", msg, SendOpts{})
+	if err != nil || !second.WasDuplicate || second.Message.ID != "42" {
+		t.Fatalf("second SendIdempotent() = %+v, %v", second, err)
+	}
+	if err := client.SendWithOpts(ctx, msg, SendOpts{IdempotencyKey: "deploy-7"}); err != nil {
+		t.Fatalf("SendWithOpts() error = %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestClient_SendIdempotentAmbiguousFailure(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// Drop the connection after the request arrived, as a proxy
+		// timing out would.
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithRetryPolicy(&retry.Backoff{MaxAttempts: 3, Base: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	msg := &types.WebhookMessage{Content: "deploy finished"}
+
+	if _, err := client.SendIdempotent(ctx, "deploy-7", msg, SendOpts{}); !errors.Is(err, types.ErrNetworkError) {
+		t.Fatalf("SendIdempotent() error = %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("expected no retry after a network error, got %d requests", requests.Load())
+	}
+	if _, err := client.SendIdempotent(ctx, "deploy-7", msg, SendOpts{}); !errors.Is(err, idempotency.ErrPending) {
+		t.Fatalf("repeat SendIdempotent() error = %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("expected repeat not to send, got %d requests", requests.Load())
+	}
+}
@@ -0,0 +1,150 @@
+// Package idempotency keeps retried message sends from posting twice. A send
+// made under a key claims it in a Store before the request goes out and
+// records the created message afterwards, so repeating the call with the
+// same key returns the original message instead of sending again.
+//
+// The REST client's CreateMessageIdempotent and the webhook client's
+// SendIdempotent use it; share one Store between processes (see RedisStore)
+// when a retry may run somewhere other than the first attempt.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+)
+
+// DefaultTTL is how long stores remember a key unless configured otherwise.
+const DefaultTTL = 24 * time.Hour
+
+// ErrPending is returned when an earlier send with the same key is still in
+// flight, or ended without a response so it is unknown whether Discord
+// created the message. Check the channel before sending again under a new
+// key.
+var ErrPending = errors.New("idempotency: earlier send with this key has no recorded outcome")
+
+// Record is what a Store holds for a key.
+type Record struct {
+	// Message is the message created under the key, or nil while the send
+	// is pending.
+	Message *types.Message `json:"message,omitempty"`
+}
+
+// Pending reports whether the send under the key has no recorded outcome.
+func (r *Record) Pending() bool {
+	return r != nil && r.Message == nil
+}
+
+// Result is the outcome of an idempotent send.
+type Result struct {
+	// Message is the created message.
+	Message *types.Message
+	// WasDuplicate is set when the message was created by an earlier call
+	// with the same key and this call did not post a new one.
+	WasDuplicate bool
+}
+
+// Store remembers idempotency keys and the messages created under them.
+type Store interface {
+	// Claim reserves key for a new send and returns true. When key is
+	// already claimed it returns the existing record and false.
+	Claim(ctx context.Context, key string) (*Record, bool, error)
+
+	// Complete records the message created under a claimed key.
+	Complete(ctx context.Context, key string, msg *types.Message) error
+
+	// Release drops a claim whose send definitely failed, so the key can
+	// be used again.
+	Release(ctx context.Context, key string) error
+}
+
+// Nonce derives the message nonce Discord deduplicates on from key. Keys of
+// any length map to a nonce within Discord's 25 character limit.
+func Nonce(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])[:limits.MessageNonceLength]
+}
+
+// Rejected reports whether err shows Discord refused a send, so no message
+// was created and its key can be released. Network errors and 5xx
+// responses leave the outcome unknown.
+func Rejected(err error) bool {
+	var apiErr *types.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode < 500
+}
+
+// MemoryStore is an in-process Store. It is the clients' default, which
+// covers retries made by the same process.
+type MemoryStore struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	records map[string]memoryRecord
+}
+
+type memoryRecord struct {
+	record  Record
+	expires time.Time
+}
+
+// NewMemoryStore creates a store that forgets keys ttl after they were
+// claimed or completed. A ttl of 0 means DefaultTTL.
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &MemoryStore{ttl: ttl, records: make(map[string]memoryRecord)}
+}
+
+// Claim implements Store.
+func (s *MemoryStore) Claim(_ context.Context, key string) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.expire(now)
+	if existing, ok := s.records[key]; ok {
+		record := existing.record
+		return &record, false, nil
+	}
+	s.records[key] = memoryRecord{expires: now.Add(s.ttl)}
+	return nil, true, nil
+}
+
+// Complete implements Store.
+func (s *MemoryStore) Complete(_ context.Context, key string, msg *types.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = memoryRecord{record: Record{Message: msg}, expires: time.Now().Add(s.ttl)}
+	return nil
+}
+
+// Release implements Store.
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// Len returns the number of keys the store remembers.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire(time.Now())
+	return len(s.records)
+}
+
+func (s *MemoryStore) expire(now time.Time) {
+	for key, rec := range s.records {
+		if now.After(rec.expires) {
+			delete(s.records, key)
+		}
+	}
+}
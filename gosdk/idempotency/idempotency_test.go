@@ -0,0 +1,108 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/limits"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/redisclient"
+)
+
+func TestMemoryStoreClaimCompleteRelease(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(time.Minute)
+
+	if _, claimed, err := store.Claim(ctx, "k"); err != nil || !claimed {
+		t.Fatalf("first Claim() = %v, %v", claimed, err)
+	}
+	record, claimed, err := store.Claim(ctx, "k")
+	if err != nil || claimed || !record.Pending() {
+		t.Fatalf("second Claim() = %+v, %v, %v", record, claimed, err)
+	}
+
+	if err := store.Complete(ctx, "k", &types.Message{ID: "42"}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	record, claimed, _ = store.Claim(ctx, "k")
+	if claimed || record.Pending() || record.Message.ID != "42" {
+		t.Fatalf("Claim() after Complete = %+v, %v", record, claimed)
+	}
+
+	store.Release(ctx, "k")
+	if _, claimed, _ := store.Claim(ctx, "k"); !claimed {
+		t.Fatal("Claim() after Release should succeed")
+	}
+}
+
+func TestMemoryStoreExpires(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(10 * time.Millisecond)
+	store.Claim(ctx, "k")
+	time.Sleep(20 * time.Millisecond)
+
+	if _, claimed, _ := store.Claim(ctx, "k"); !claimed {
+		t.Fatal("expired key should be claimable")
+	}
+	if store.Len() != 1 {
+		t.Fatalf("Len() = %d", store.Len())
+	}
+}
+
+func TestNonce(t *testing.T) {
+	long := Nonce(string(make([]byte, 500)))
+	if len(long) != limits.MessageNonceLength || len(Nonce("a")) != limits.MessageNonceLength {
+		t.Fatalf("Nonce lengths = %d, %d", len(long), len(Nonce("a")))
+	}
+	if Nonce("a") != Nonce("a") || Nonce("a") == Nonce("b") {
+		t.Fatal("Nonce should be stable and distinct per key")
+	}
+}
+
+func TestRejected(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&types.APIError{StatusCode: 400}, true},
+		{fmt.Errorf("wrapped: %w", &types.APIError{StatusCode: 403}), true},
+		{&types.APIError{StatusCode: 502}, false},
+		{&types.NetworkError{Op: "request", Err: errors.New("reset")}, false},
+		{context.DeadlineExceeded, false},
+	}
+	for _, tt := range tests {
+		if got := Rejected(tt.err); got != tt.want {
+			t.Errorf("Rejected(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	redis := redisclient.NewMemory()
+	store := NewRedisStore(redis, "", 0)
+
+	if _, claimed, err := store.Claim(ctx, "k"); err != nil || !claimed {
+		t.Fatalf("first Claim() = %v, %v", claimed, err)
+	}
+	if _, found, _ := redis.Get(ctx, "godiscord:idempotency:k"); !found {
+		t.Fatal("expected prefixed key")
+	}
+	if record, claimed, _ := store.Claim(ctx, "k"); claimed || !record.Pending() {
+		t.Fatalf("second Claim() = %+v, %v", record, claimed)
+	}
+
+	store.Complete(ctx, "k", &types.Message{ID: "42", ChannelID: "7"})
+	record, claimed, err := store.Claim(ctx, "k")
+	if err != nil || claimed || record.Message.ID != "42" || record.Message.ChannelID != "7" {
+		t.Fatalf("Claim() after Complete = %+v, %v, %v", record, claimed, err)
+	}
+
+	store.Release(ctx, "k")
+	if _, claimed, _ := store.Claim(ctx, "k"); !claimed {
+		t.Fatal("Claim() after Release should succeed")
+	}
+}
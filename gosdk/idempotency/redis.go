@@ -0,0 +1,77 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/redisclient"
+)
+
+// RedisClient is the Redis client RedisStore keeps keys in. It is shared
+// with the SDK's other Redis-backed stores, so one adapter serves them all.
+type RedisClient = redisclient.Client
+
+// RedisStore implements Store on Redis so a retry in another process still
+// sees the first attempt's key.
+type RedisStore struct {
+	client RedisClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisStore creates a Redis-backed store. Keys are namespaced by prefix
+// (default "godiscord:idempotency:") and expire after ttl (0 means
+// DefaultTTL).
+func NewRedisStore(client RedisClient, prefix string, ttl time.Duration) *RedisStore {
+	if prefix == "" {
+		prefix = "godiscord:idempotency:"
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &RedisStore{client: client, prefix: prefix, ttl: ttl}
+}
+
+// pendingValue marks a claimed key without an outcome.
+const pendingValue = "{}"
+
+// Claim implements Store.
+func (s *RedisStore) Claim(ctx context.Context, key string) (*Record, bool, error) {
+	claimed, err := s.client.SetNX(ctx, s.prefix+key, pendingValue, s.ttl)
+	if err != nil || claimed {
+		return nil, claimed, err
+	}
+	raw, found, err := s.client.Get(ctx, s.prefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		// Expired between SETNX and GET; try once more.
+		claimed, err := s.client.SetNX(ctx, s.prefix+key, pendingValue, s.ttl)
+		if err != nil || claimed {
+			return nil, claimed, err
+		}
+		return &Record{}, false, nil
+	}
+	var record Record
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		return nil, false, err
+	}
+	return &record, false, nil
+}
+
+// Complete implements Store.
+func (s *RedisStore) Complete(ctx context.Context, key string, msg *types.Message) error {
+	data, err := json.Marshal(Record{Message: msg})
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.prefix+key, string(data), s.ttl)
+}
+
+// Release implements Store.
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key)
+}
@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Interface is the minimal logger the SDK's clients accept. Fields are
+// alternating key-value pairs. *Logger implements it; Slog, Zap and the
+// zerolog adapter module wrap application loggers so the SDK's entries land
+// in the application's existing logging pipeline.
+type Interface interface {
+	Debug(msg string, fields ...interface{})
+	Info(msg string, fields ...interface{})
+	Warn(msg string, fields ...interface{})
+	Error(msg string, fields ...interface{})
+}
+
+// LevelEnabler is implemented by Interface values that can report whether a
+// level would be written. The SDK checks it to skip building debug entries
+// the backend would discard.
+type LevelEnabler interface {
+	Enabled(level Level) bool
+}
+
+// From returns l as a *Logger. A *Logger is returned unchanged (nil
+// included); any other implementation is wrapped so subsystems, With
+// fields, SetSubsystemLevel and Every keep working and entries are handed
+// to l with those fields prepended. A nil interface returns nil.
+func From(l Interface) *Logger {
+	switch v := l.(type) {
+	case nil:
+		return nil
+	case *Logger:
+		return v
+	default:
+		return &Logger{
+			level:     DebugLevel,
+			backend:   v,
+			overrides: &levelOverrides{},
+			sampler:   newSampler(),
+		}
+	}
+}
+
+// Slog adapts a log/slog logger. A nil l uses slog.Default().
+func Slog(l *slog.Logger) Interface {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogAdapter{l: l}
+}
+
+type slogAdapter struct {
+	l *slog.Logger
+}
+
+func (a slogAdapter) Debug(msg string, fields ...interface{}) {
+	a.l.Log(context.Background(), slog.LevelDebug, msg, fields...)
+}
+
+func (a slogAdapter) Info(msg string, fields ...interface{}) {
+	a.l.Log(context.Background(), slog.LevelInfo, msg, fields...)
+}
+
+func (a slogAdapter) Warn(msg string, fields ...interface{}) {
+	a.l.Log(context.Background(), slog.LevelWarn, msg, fields...)
+}
+
+func (a slogAdapter) Error(msg string, fields ...interface{}) {
+	a.l.Log(context.Background(), slog.LevelError, msg, fields...)
+}
+
+// Enabled implements LevelEnabler.
+func (a slogAdapter) Enabled(level Level) bool {
+	return a.l.Enabled(context.Background(), slogLevel(level))
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case DebugLevel:
+		return slog.LevelDebug
+	case WarnLevel:
+		return slog.LevelWarn
+	case ErrorLevel:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SugaredLogger is the key-value API of zap's *zap.SugaredLogger. It is
+// declared here so the SDK does not depend on zap.
+type SugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// Zap adapts a zap sugared logger; pass zapLogger.Sugar().
+func Zap(s SugaredLogger) Interface {
+	return zapAdapter{s: s}
+}
+
+type zapAdapter struct {
+	s SugaredLogger
+}
+
+func (a zapAdapter) Debug(msg string, fields ...interface{}) { a.s.Debugw(msg, stringKeys(fields)...) }
+func (a zapAdapter) Info(msg string, fields ...interface{})  { a.s.Infow(msg, stringKeys(fields)...) }
+func (a zapAdapter) Warn(msg string, fields ...interface{})  { a.s.Warnw(msg, stringKeys(fields)...) }
+func (a zapAdapter) Error(msg string, fields ...interface{}) { a.s.Errorw(msg, stringKeys(fields)...) }
+
+// stringKeys makes every key a string, since zap treats a non-string key as
+// an error.
+func stringKeys(fields []interface{}) []interface{} {
+	out := make([]interface{}, len(fields))
+	copy(out, fields)
+	for i := 0; i < len(out); i += 2 {
+		if _, ok := out[i].(string); !ok {
+			out[i] = fmt.Sprint(out[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestFromReturnsLoggerUnchanged(t *testing.T) {
+	log := New(InfoLevel, "json", &bytes.Buffer{})
+	if From(log) != log {
+		t.Fatal("From(*Logger) should return it unchanged")
+	}
+	if From(nil) != nil {
+		t.Fatal("From(nil) should return nil")
+	}
+	var typedNil *Logger
+	if From(typedNil) != nil {
+		t.Fatal("From(typed nil) should return nil")
+	}
+}
+
+func TestSlogAdapter(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	log := From(Slog(slog.New(handler))).WithSubsystem(SubsystemGateway).With("shard", 0)
+
+	if log.IsDebug() {
+		t.Fatal("IsDebug() should follow the slog handler level")
+	}
+	log.Debug("dropped")
+	log.Warn("heartbeat missed", "shard", 1, "latency_ms", 250)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a single JSON entry, got %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "heartbeat missed" {
+		t.Fatalf("entry = %v", entry)
+	}
+	if entry["subsystem"] != "gateway" || entry["shard"] != float64(1) || entry["latency_ms"] != float64(250) {
+		t.Fatalf("fields = %v", entry)
+	}
+}
+
+func TestFromKeepsSubsystemLevelsAndSampling(t *testing.T) {
+	var buf bytes.Buffer
+	root := From(Slog(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	root.SetSubsystemLevel(SubsystemRateLimit, WarnLevel)
+
+	rate := root.WithSubsystem(SubsystemRateLimit)
+	rate.Info("quiet")
+	if buf.Len() != 0 {
+		t.Fatalf("subsystem override ignored: %q", buf.String())
+	}
+
+	every := rate.Every("hit", time.Hour)
+	every.Warn("rate limit hit")
+	every.Warn("rate limit hit")
+	if n := bytes.Count(buf.Bytes(), []byte("rate limit hit")); n != 1 {
+		t.Fatalf("expected 1 sampled entry, got %d: %q", n, buf.String())
+	}
+}
+
+type fakeSugared struct {
+	entries []string
+}
+
+func (f *fakeSugared) record(level, msg string, kv []interface{}) {
+	f.entries = append(f.entries, fmt.Sprintf("%s %s %v", level, msg, kv))
+}
+
+func (f *fakeSugared) Debugw(msg string, kv ...interface{}) { f.record("debug", msg, kv) }
+func (f *fakeSugared) Infow(msg string, kv ...interface{})  { f.record("info", msg, kv) }
+func (f *fakeSugared) Warnw(msg string, kv ...interface{})  { f.record("warn", msg, kv) }
+func (f *fakeSugared) Errorw(msg string, kv ...interface{}) { f.record("error", msg, kv) }
+
+func TestZapAdapter(t *testing.T) {
+	sugared := &fakeSugared{}
+	log := From(Zap(sugared)).WithSubsystem(SubsystemClient)
+
+	log.Error("request failed", "status", 500)
+
+	want := "error request failed [subsystem client status 500]"
+	if len(sugared.entries) != 1 || sugared.entries[0] != want {
+		t.Fatalf("entries = %q, want %q", sugared.entries, want)
+	}
+}
+
+func TestBackendCallFieldsOverrideWithFields(t *testing.T) {
+	sugared := &fakeSugared{}
+	From(Zap(sugared)).With("route", "a", "attempt", 1).Info("retry", "attempt", 2)
+
+	want := "info retry [route a attempt 2]"
+	if len(sugared.entries) != 1 || sugared.entries[0] != want {
+		t.Fatalf("entries = %q, want %q", sugared.entries, want)
+	}
+}
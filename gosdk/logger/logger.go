@@ -1,10 +1,13 @@
 package logger
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -54,11 +57,46 @@ func ParseLevel(s string) Level {
 	}
 }
 
+// SubsystemKey is the field that holds a logger's subsystem tag.
+const SubsystemKey = "subsystem"
+
+// Subsystem tags applied by the SDK packages.
+const (
+	SubsystemClient       = "client"
+	SubsystemWebhook      = "webhook"
+	SubsystemGateway      = "gateway"
+	SubsystemInteractions = "interactions"
+	SubsystemRateLimit    = "ratelimit"
+	SubsystemState        = "state"
+	SubsystemAudit        = "audit"
+)
+
 // Logger represents a structured logger
 type Logger struct {
-	level  Level
-	format string // "json" or "text"
-	writer io.Writer
+	level     Level
+	format    string // "json" or "text"
+	writer    io.Writer
+	fields    []interface{}
+	subsystem string
+	overrides *levelOverrides
+	sampler   *sampler
+
+	sampleKey      string
+	sampleInterval time.Duration
+
+	// rate keeps one in every rate.n entries when set (see Sample).
+	rate *rateSampler
+	// discard drops every entry (see Tick).
+	discard bool
+
+	// backend receives entries instead of writer when set (see From).
+	backend Interface
+}
+
+// levelOverrides holds per-subsystem levels shared by a logger and its children.
+type levelOverrides struct {
+	mu     sync.RWMutex
+	levels map[string]Level
 }
 
 // New creates a new logger
@@ -67,10 +105,211 @@ func New(level Level, format string, writer io.Writer) *Logger {
 		writer = os.Stderr
 	}
 	return &Logger{
-		level:  level,
-		format: format,
-		writer: writer,
+		level:     level,
+		format:    format,
+		writer:    writer,
+		overrides: &levelOverrides{},
+		sampler:   newSampler(),
+	}
+}
+
+// With returns a child logger that adds the key-value pairs to every entry.
+// Fields passed to a log call take precedence over these.
+func (l *Logger) With(fields ...interface{}) *Logger {
+	if l == nil {
+		return nil
+	}
+	child := *l
+	child.fields = append(append([]interface{}{}, l.fields...), fields...)
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fmt.Sprint(fields[i]) == SubsystemKey {
+			child.subsystem = fmt.Sprint(fields[i+1])
+		}
+	}
+	return &child
+}
+
+// WithSubsystem returns a child logger tagged with the given subsystem, so
+// its entries can be filtered by component and its level tuned with
+// SetSubsystemLevel.
+func (l *Logger) WithSubsystem(name string) *Logger {
+	if l == nil || l.subsystem == name {
+		return l
+	}
+	return l.With(SubsystemKey, name)
+}
+
+// Subsystem returns the logger's subsystem tag, or "" if it has none.
+func (l *Logger) Subsystem() string {
+	return l.subsystem
+}
+
+// SetSubsystemLevel overrides the level for entries tagged with subsystem.
+// The override is shared with every logger derived from l, including those
+// created earlier.
+func (l *Logger) SetSubsystemLevel(subsystem string, level Level) {
+	if l.overrides == nil {
+		l.overrides = &levelOverrides{}
+	}
+	l.overrides.mu.Lock()
+	defer l.overrides.mu.Unlock()
+	if l.overrides.levels == nil {
+		l.overrides.levels = make(map[string]Level)
+	}
+	l.overrides.levels[subsystem] = level
+}
+
+// Every returns a logger that writes at most one entry per interval for key;
+// entries in between are dropped and counted, and the next entry written
+// carries the count in a "suppressed" field. Use it for warnings that repeat
+// under sustained conditions, such as one "rate limit hit" per route:
+//
+//	log.Every("rate limit hit:"+route, time.Minute).Warn("rate limit hit", "route", route)
+//
+// Sampling state is shared with every logger derived from the same root.
+func (l *Logger) Every(key string, interval time.Duration) *Logger {
+	if l == nil {
+		return nil
+	}
+	child := *l
+	if child.sampler == nil {
+		child.sampler = newSampler()
 	}
+	child.sampleKey = key
+	child.sampleInterval = interval
+	return &child
+}
+
+// Sample returns a logger that keeps one in every n entries, starting with
+// the first, and drops the rest. Use it for high-volume debug lines such as
+// one per REST request; n <= 1 keeps every entry. The count is shared with
+// loggers derived from the result.
+func (l *Logger) Sample(n int) *Logger {
+	if l == nil {
+		return nil
+	}
+	child := *l
+	child.rate = nil
+	if n > 1 {
+		child.rate = &rateSampler{n: uint64(n)}
+	}
+	return &child
+}
+
+// Tick takes one slot from a Sample logger for a group of related entries,
+// such as a request line and its response line. It returns a logger that
+// writes every entry when the slot is kept and none when it is dropped, so
+// the group is logged together or not at all. Loggers without a rate are
+// returned unchanged.
+func (l *Logger) Tick() *Logger {
+	if l == nil || l.rate == nil {
+		return l
+	}
+	child := *l
+	child.rate = nil
+	child.discard = !l.rate.keep()
+	return &child
+}
+
+// rateSampler counts entries for Sample.
+type rateSampler struct {
+	n     uint64
+	count atomic.Uint64
+}
+
+func (r *rateSampler) keep() bool {
+	return (r.count.Add(1)-1)%r.n == 0
+}
+
+type contextFieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx carrying key-value pairs that
+// WithContext adds to log entries, on top of any already in ctx. The SDK's
+// REST client applies them to its per-request entries, so a request ID set
+// by the application shows up on the SDK's lines for that call.
+func ContextWithFields(ctx context.Context, fields ...interface{}) context.Context {
+	existing := FieldsFromContext(ctx)
+	merged := append(append(make([]interface{}, 0, len(existing)+len(fields)), existing...), fields...)
+	return context.WithValue(ctx, contextFieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the fields stored by ContextWithFields.
+func FieldsFromContext(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(contextFieldsKey{}).([]interface{})
+	return fields
+}
+
+// WithContext returns a child logger carrying the fields stored in ctx by
+// ContextWithFields, or l itself when there are none.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	fields := FieldsFromContext(ctx)
+	if l == nil || len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
+
+// sampler tracks when each sampled key last logged.
+type sampler struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	state map[string]*sampleState
+}
+
+type sampleState struct {
+	last       time.Time
+	suppressed int
+}
+
+func newSampler() *sampler {
+	return &sampler{now: time.Now, state: make(map[string]*sampleState)}
+}
+
+// allow reports whether key may log now and how many entries were dropped
+// since it last did.
+func (s *sampler) allow(key string, interval time.Duration) (bool, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	st, ok := s.state[key]
+	if !ok {
+		s.state[key] = &sampleState{last: now}
+		return true, 0
+	}
+	if now.Sub(st.last) < interval {
+		st.suppressed++
+		return false, 0
+	}
+	suppressed := st.suppressed
+	st.last = now
+	st.suppressed = 0
+	return true, suppressed
+}
+
+// enabled reports whether level passes the logger's threshold, honoring any
+// override for its subsystem.
+func (l *Logger) enabled(level Level) bool {
+	if l.discard {
+		return false
+	}
+	threshold := l.level
+	if l.subsystem != "" && l.overrides != nil {
+		l.overrides.mu.RLock()
+		if override, ok := l.overrides.levels[l.subsystem]; ok {
+			threshold = override
+		}
+		l.overrides.mu.RUnlock()
+	}
+	if threshold > level {
+		return false
+	}
+	if enabler, ok := l.backend.(LevelEnabler); ok {
+		return enabler.Enabled(level)
+	}
+	return true
 }
 
 // Default returns a default logger (info level, JSON format, stderr)
@@ -80,57 +319,76 @@ func Default() *Logger {
 
 // IsDebug returns true if debug logging is enabled
 func (l *Logger) IsDebug() bool {
-	return l.level <= DebugLevel
+	return l.enabled(DebugLevel)
 }
 
 // Debug logs a debug message with optional fields
 func (l *Logger) Debug(msg string, fields ...interface{}) {
-	if l.level <= DebugLevel {
+	if l.enabled(DebugLevel) {
 		l.log(DebugLevel, msg, fields...)
 	}
 }
 
 // Info logs an info message with optional fields
 func (l *Logger) Info(msg string, fields ...interface{}) {
-	if l.level <= InfoLevel {
+	if l.enabled(InfoLevel) {
 		l.log(InfoLevel, msg, fields...)
 	}
 }
 
 // Warn logs a warning message with optional fields
 func (l *Logger) Warn(msg string, fields ...interface{}) {
-	if l.level <= WarnLevel {
+	if l.enabled(WarnLevel) {
 		l.log(WarnLevel, msg, fields...)
 	}
 }
 
 // Error logs an error message with optional fields
 func (l *Logger) Error(msg string, fields ...interface{}) {
-	if l.level <= ErrorLevel {
+	if l.enabled(ErrorLevel) {
 		l.log(ErrorLevel, msg, fields...)
 	}
 }
 
 func (l *Logger) log(level Level, msg string, fields ...interface{}) {
+	if l.rate != nil && !l.rate.keep() {
+		return
+	}
+	suppressed := 0
+	if l.sampleKey != "" && l.sampleInterval > 0 {
+		ok, n := l.sampler.allow(l.sampleKey, l.sampleInterval)
+		if !ok {
+			return
+		}
+		suppressed = n
+	}
+
+	if l.backend != nil {
+		l.forward(level, msg, suppressed, fields)
+		return
+	}
+
 	entry := make(map[string]interface{})
 	entry["timestamp"] = time.Now().UTC().Format(time.RFC3339)
 	entry["level"] = level.String()
-	entry["message"] = msg
+	entry["message"] = Redact(msg)
 
-	// Parse fields as key-value pairs
-	for i := 0; i < len(fields); i += 2 {
-		if i+1 < len(fields) {
-			key := fmt.Sprint(fields[i])
-			entry[key] = fields[i+1]
+	// Parse fields as key-value pairs; call-site fields override With fields
+	for _, kv := range [][]interface{}{l.fields, fields} {
+		for i := 0; i+1 < len(kv); i += 2 {
+			entry[fmt.Sprint(kv[i])] = redactValue(kv[i+1])
 		}
 	}
+	if suppressed > 0 {
+		entry["suppressed"] = suppressed
+	}
 
 	if l.format == "json" {
 		data, _ := json.Marshal(entry)
 		fmt.Fprintln(l.writer, string(data))
 	} else {
 		// Simple text format
-		fmt.Fprintf(l.writer, "[%s] %s: %s", entry["timestamp"], level.String(), msg)
+		fmt.Fprintf(l.writer, "[%s] %s: %s", entry["timestamp"], level.String(), entry["message"])
 		for k, v := range entry {
 			if k != "timestamp" && k != "level" && k != "message" {
 				fmt.Fprintf(l.writer, " %s=%v", k, v)
@@ -139,3 +397,37 @@ func (l *Logger) log(level Level, msg string, fields ...interface{}) {
 		fmt.Fprintln(l.writer)
 	}
 }
+
+// forward hands an entry to the backend, with With fields first and
+// call-site fields replacing With fields of the same key. Values are
+// redacted as for the built-in writer.
+func (l *Logger) forward(level Level, msg string, suppressed int, fields []interface{}) {
+	merged := make([]interface{}, 0, len(l.fields)+len(fields)+2)
+	index := make(map[string]int)
+	for _, kv := range [][]interface{}{l.fields, fields} {
+		for i := 0; i+1 < len(kv); i += 2 {
+			key, value := fmt.Sprint(kv[i]), redactValue(kv[i+1])
+			if at, ok := index[key]; ok {
+				merged[at+1] = value
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, key, value)
+		}
+	}
+	if suppressed > 0 {
+		merged = append(merged, "suppressed", suppressed)
+	}
+
+	msg = Redact(msg)
+	switch level {
+	case DebugLevel:
+		l.backend.Debug(msg, merged...)
+	case InfoLevel:
+		l.backend.Info(msg, merged...)
+	case WarnLevel:
+		l.backend.Warn(msg, merged...)
+	default:
+		l.backend.Error(msg, merged...)
+	}
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"os"
 	"strings"
@@ -339,3 +340,165 @@ func TestLevelFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestWithAddsFields(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(DebugLevel, "json", &buf).With("guild_id", "1", "shard", 0)
+	log.Info("scoped", "shard", 2)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if entry["guild_id"] != "1" {
+		t.Errorf("guild_id = %v, want 1", entry["guild_id"])
+	}
+	if entry["shard"] != float64(2) {
+		t.Errorf("shard = %v, want call-site value 2", entry["shard"])
+	}
+}
+
+func TestWithDoesNotAffectParent(t *testing.T) {
+	var buf bytes.Buffer
+	parent := New(DebugLevel, "json", &buf)
+	parent.With("child", true)
+	parent.Info("plain")
+	if strings.Contains(buf.String(), "child") {
+		t.Errorf("parent logged child fields: %s", buf.String())
+	}
+}
+
+func TestSubsystemLevelOverride(t *testing.T) {
+	var buf bytes.Buffer
+	root := New(DebugLevel, "json", &buf)
+	webhook := root.WithSubsystem(SubsystemWebhook)
+	gateway := root.WithSubsystem(SubsystemGateway)
+	if webhook.Subsystem() != SubsystemWebhook {
+		t.Fatalf("Subsystem() = %q", webhook.Subsystem())
+	}
+	if webhook.WithSubsystem(SubsystemWebhook) != webhook {
+		t.Error("re-tagging with the same subsystem should return the logger unchanged")
+	}
+
+	// Overrides set after the child was created still apply.
+	root.SetSubsystemLevel(SubsystemGateway, WarnLevel)
+
+	gateway.Debug("noisy")
+	if buf.Len() != 0 {
+		t.Fatalf("gateway debug should be filtered: %s", buf.String())
+	}
+	if gateway.IsDebug() {
+		t.Error("IsDebug() should honor the subsystem override")
+	}
+	webhook.Debug("kept")
+	if !strings.Contains(buf.String(), `"subsystem":"webhook"`) {
+		t.Fatalf("expected webhook debug entry, got %s", buf.String())
+	}
+}
+
+func TestWithNilLogger(t *testing.T) {
+	var log *Logger
+	if log.With("a", 1) != nil || log.WithSubsystem(SubsystemClient) != nil {
+		t.Error("expected nil child for nil logger")
+	}
+}
+
+func TestEverySuppressesRepeats(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(InfoLevel, "json", &buf)
+	now := time.Unix(0, 0)
+	log.sampler.now = func() time.Time { return now }
+	child := log.WithSubsystem(SubsystemRateLimit)
+
+	warn := func(route string) {
+		child.Every("rate limit hit:"+route, time.Minute).Warn("rate limit hit", "route", route)
+	}
+	warn("a")
+	warn("a")
+	warn("a")
+	warn("b")
+	if got := strings.Count(buf.String(), "rate limit hit"); got != 2 {
+		t.Fatalf("expected one entry per route, got %d:\n%s", got, buf.String())
+	}
+
+	buf.Reset()
+	now = now.Add(time.Minute)
+	warn("a")
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if entry["suppressed"] != float64(2) {
+		t.Fatalf("suppressed = %v, want 2", entry["suppressed"])
+	}
+}
+
+func TestEveryIgnoresFilteredLevels(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(WarnLevel, "json", &buf)
+	log.Every("k", time.Hour).Debug("filtered")
+	log.Every("k", time.Hour).Warn("kept")
+	if !strings.Contains(buf.String(), "kept") {
+		t.Fatalf("filtered entries should not consume the sample: %s", buf.String())
+	}
+}
+
+func TestSampleKeepsOneInN(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(DebugLevel, "json", &buf).Sample(3)
+	child := log.With("route", "a")
+	for i := 0; i < 7; i++ {
+		child.Debug("request", "i", i)
+	}
+	if got := strings.Count(buf.String(), "\n"); got != 3 {
+		t.Fatalf("expected entries 0, 3 and 6, got %d:\n%s", got, buf.String())
+	}
+
+	buf.Reset()
+	log = New(InfoLevel, "json", &buf).Sample(2)
+	log.Debug("filtered")
+	log.Info("kept")
+	if !strings.Contains(buf.String(), "kept") {
+		t.Fatalf("filtered entries should not consume a slot: %s", buf.String())
+	}
+}
+
+func TestTickGroupsEntries(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(DebugLevel, "json", &buf).Sample(2)
+	for i := 0; i < 4; i++ {
+		group := log.Tick().With("request", i)
+		group.Debug("request")
+		group.Debug("response")
+	}
+	if strings.Count(buf.String(), `"request":0`) != 2 || strings.Count(buf.String(), `"request":2`) != 2 {
+		t.Fatalf("expected requests 0 and 2 logged in full:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), `"request":1`) || strings.Contains(buf.String(), `"request":3`) {
+		t.Fatalf("dropped groups were logged:\n%s", buf.String())
+	}
+
+	plain := New(DebugLevel, "json", &buf)
+	if plain.Tick() != plain {
+		t.Fatal("Tick() without Sample should return the logger unchanged")
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(InfoLevel, "json", &buf)
+	ctx := ContextWithFields(context.Background(), "request_id", "r1")
+	ctx = ContextWithFields(ctx, "user", "u1")
+
+	log.WithContext(ctx).Info("handled")
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if entry["request_id"] != "r1" || entry["user"] != "u1" {
+		t.Fatalf("entry = %v", entry)
+	}
+	if log.WithContext(context.Background()) != log {
+		t.Fatal("WithContext without fields should return the logger unchanged")
+	}
+}
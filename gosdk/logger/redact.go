@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secrets removed from log entries.
+const Redacted = "[REDACTED]"
+
+var (
+	// Webhook and interaction URLs carry their token as the path segment
+	// after the ID: /webhooks/{id}/{token}, /interactions/{id}/{token}.
+	tokenPathPattern = regexp.MustCompile(`(/(?:webhooks|interactions)/[^/\s?#"']+/)[^/\s?#"']+`)
+	// Authorization header values.
+	authSchemePattern = regexp.MustCompile(`\b(Bot|Bearer) [A-Za-z0-9._~+/=-]+`)
+	// Bare bot tokens: base64 user ID, timestamp and HMAC joined by dots.
+	botTokenPattern = regexp.MustCompile(`[A-Za-z0-9_-]{23,28}\.[A-Za-z0-9_-]{6,7}\.[A-Za-z0-9_-]{27,}`)
+)
+
+// Redact replaces bot tokens, Authorization values and the token segment of
+// webhook and interaction URLs in s with Redacted. Every entry is passed
+// through it, so URLs and errors can be logged as-is:
+//
+//	Redact("POST /webhooks/123/abc?wait=true") // "POST /webhooks/123/[REDACTED]?wait=true"
+func Redact(s string) string {
+	if strings.Contains(s, "webhooks/") || strings.Contains(s, "interactions/") {
+		s = tokenPathPattern.ReplaceAllString(s, "${1}"+Redacted)
+	}
+	if strings.Contains(s, "Bot ") || strings.Contains(s, "Bearer ") {
+		s = authSchemePattern.ReplaceAllString(s, "$1 "+Redacted)
+	}
+	if strings.Count(s, ".") >= 2 {
+		s = botTokenPattern.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// redactValue redacts strings, URLs and error messages. Errors and URLs
+// are replaced by their redacted text only when it differs, so backends
+// still receive the original values in the common case.
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return Redact(val)
+	case *url.URL:
+		if val == nil {
+			return v
+		}
+		if s := val.String(); Redact(s) != s {
+			return Redact(s)
+		}
+	case error:
+		if s := val.Error(); Redact(s) != s {
+			return Redact(s)
+		}
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const testBotToken = "MTA4OTYxNzY1NjE4NTM1MjQ4Mg.GhJkLm.abcdefghijklmnopqrstuvwxyz0123456789AB"
+
+func TestRedact(t *testing.T) {
+	tests := map[string]string{
+		"https://discord.com/api/webhooks/123/s3cr3t-T0ken": "https://discord.com/api/webhooks/123/[REDACTED]",
+		"POST /webhooks/123/s3cr3t?wait=true":               "POST /webhooks/123/[REDACTED]?wait=true",
+		"/webhooks/1/tok/messages/@original":                "/webhooks/1/[REDACTED]/messages/@original",
+		"/interactions/99/aW50ZXJhY3Rpb24/callback":         "/interactions/99/[REDACTED]/callback",
+		"Authorization: Bot " + testBotToken:                "Authorization: Bot [REDACTED]",
+		"Bearer abc.def":                                    "Bearer [REDACTED]",
+		"token " + testBotToken + " rejected":               "token [REDACTED] rejected",
+		"/channels/123/webhooks":                            "/channels/123/webhooks",
+		"/webhooks/123":                                     "/webhooks/123",
+		"GET /channels/123/messages/456":                    "GET /channels/123/messages/456",
+		"version 1.2.3":                                     "version 1.2.3",
+	}
+	for in, want := range tests {
+		if got := Redact(in); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLogRedactsFields(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(DebugLevel, "json", &buf).With("webhook", "https://discord.com/api/webhooks/1/s3cr3t")
+
+	u, _ := url.Parse("https://discord.com/api/v10/webhooks/2/other?wait=true")
+	urlErr := &url.Error{Op: "Post", URL: "https://discord.com/api/webhooks/1/s3cr3t", Err: errors.New("connection reset")}
+	log.Debug("request to /interactions/5/itok/callback failed", "url", u, "error", urlErr, "status", 0)
+
+	out := buf.String()
+	for _, secret := range []string{"s3cr3t", "other", "itok"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("entry leaks %q: %s", secret, out)
+		}
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if entry["error"] != `Post "https://discord.com/api/webhooks/1/[REDACTED]": connection reset` {
+		t.Fatalf("error = %v", entry["error"])
+	}
+	if entry["status"] != float64(0) {
+		t.Fatalf("non-string fields should be untouched: %v", entry["status"])
+	}
+}
+
+func TestBackendEntriesAreRedacted(t *testing.T) {
+	sugared := &fakeSugared{}
+	plain := errors.New("plain")
+	From(Zap(sugared)).Warn("retrying", "url", "/webhooks/1/s3cr3t", "error", plain)
+
+	want := "warn retrying [url /webhooks/1/[REDACTED] error plain]"
+	if len(sugared.entries) != 1 || sugared.entries[0] != want {
+		t.Fatalf("entries = %q, want %q", sugared.entries, want)
+	}
+}
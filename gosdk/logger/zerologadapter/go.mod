@@ -0,0 +1,16 @@
+module github.com/mtreilly/godiscord/gosdk/logger/zerologadapter
+
+go 1.25.3
+
+require (
+	github.com/mtreilly/godiscord/gosdk v0.0.0
+	github.com/rs/zerolog v1.34.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+replace github.com/mtreilly/godiscord/gosdk => ../../
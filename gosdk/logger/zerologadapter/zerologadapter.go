@@ -0,0 +1,60 @@
+// Package zerologadapter sends the SDK's log entries to a zerolog logger.
+// It is a separate module so the SDK does not depend on zerolog.
+//
+//	rest, _ := client.New(token, client.WithLogger(zerologadapter.New(log.Logger)))
+package zerologadapter
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+// New adapts l to logger.Interface.
+func New(l zerolog.Logger) logger.Interface {
+	return adapter{l: l}
+}
+
+type adapter struct {
+	l zerolog.Logger
+}
+
+func (a adapter) Debug(msg string, fields ...interface{}) { a.write(a.l.Debug(), msg, fields) }
+func (a adapter) Info(msg string, fields ...interface{})  { a.write(a.l.Info(), msg, fields) }
+func (a adapter) Warn(msg string, fields ...interface{})  { a.write(a.l.Warn(), msg, fields) }
+func (a adapter) Error(msg string, fields ...interface{}) { a.write(a.l.Error(), msg, fields) }
+
+// Enabled implements logger.LevelEnabler.
+func (a adapter) Enabled(level logger.Level) bool {
+	return a.l.GetLevel() <= zerologLevel(level) && zerolog.GlobalLevel() <= zerologLevel(level)
+}
+
+func (a adapter) write(event *zerolog.Event, msg string, fields []interface{}) {
+	if event == nil {
+		return
+	}
+	for i := 0; i+1 < len(fields); i += 2 {
+		key := fmt.Sprint(fields[i])
+		if err, ok := fields[i+1].(error); ok {
+			event = event.AnErr(key, err)
+			continue
+		}
+		event = event.Interface(key, fields[i+1])
+	}
+	event.Msg(msg)
+}
+
+func zerologLevel(level logger.Level) zerolog.Level {
+	switch level {
+	case logger.DebugLevel:
+		return zerolog.DebugLevel
+	case logger.WarnLevel:
+		return zerolog.WarnLevel
+	case logger.ErrorLevel:
+		return zerolog.ErrorLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
@@ -0,0 +1,43 @@
+package zerologadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/mtreilly/godiscord/gosdk/logger"
+)
+
+func TestAdapterWritesFields(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.From(New(zerolog.New(&buf))).WithSubsystem(logger.SubsystemWebhook)
+
+	log.Warn("rate limit hit", "route", "POST:/webhooks/1", "error", errors.New("429"))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unmarshal %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "warn" || entry["message"] != "rate limit hit" {
+		t.Fatalf("entry = %v", entry)
+	}
+	if entry["subsystem"] != "webhook" || entry["route"] != "POST:/webhooks/1" || entry["error"] != "429" {
+		t.Fatalf("fields = %v", entry)
+	}
+}
+
+func TestAdapterHonorsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.From(New(zerolog.New(&buf).Level(zerolog.InfoLevel)))
+
+	if log.IsDebug() {
+		t.Fatal("IsDebug() should follow the zerolog level")
+	}
+	log.Debug("dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("debug entry written: %q", buf.String())
+	}
+}
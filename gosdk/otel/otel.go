@@ -0,0 +1,345 @@
+// Package otel instruments the SDK's clients with OpenTelemetry. It is a
+// separate module so applications that don't use OpenTelemetry don't pull
+// it in.
+//
+// New builds an Instrumentation from a tracer and meter provider (the
+// global ones by default). Its option helpers plug it into each client:
+//
+//	inst, err := otel.New(otel.WithTracerProvider(tp), otel.WithMeterProvider(mp))
+//	rest, _ := client.New(token, inst.ClientOptions()...)
+//	hook, _ := webhook.NewClient(url, inst.WebhookOptions()...)
+//	gw, _ := gateway.NewClient(token, intents, gateway.WithDispatcher(
+//		gateway.NewDispatcher(inst.DispatcherOptions()...)))
+//
+// REST and webhook requests get a client span per HTTP attempt, named after
+// the route with IDs and tokens replaced by placeholders. Rate limit waits
+// become spans under the waiting request's context, and gateway events
+// get a span around their handlers. Metrics:
+//
+//	discord.request.duration          histogram, seconds, per route/method/status
+//	discord.ratelimit.hits            counter of 429 responses, per route/global
+//	discord.ratelimit.wait.duration   histogram, seconds, per route/reason
+//	discord.gateway.event.lag         histogram, seconds from receipt to handling
+//	discord.gateway.event.duration    histogram, seconds spent in handlers
+package otel
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/webhook"
+	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+	"github.com/mtreilly/godiscord/gosdk/version"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/mtreilly/godiscord/gosdk/otel"
+
+// Attribute keys recorded on spans and metrics.
+const (
+	RouteKey      = attribute.Key("discord.route")
+	ClientKey     = attribute.Key("discord.client")
+	WaitReasonKey = attribute.Key("discord.ratelimit.reason")
+	GlobalKey     = attribute.Key("discord.ratelimit.global")
+	EventKey      = attribute.Key("discord.gateway.event")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusKey     = attribute.Key("http.response.status_code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures New.
+type Option func(*config)
+
+// WithTracerProvider sets where spans go (default: the global provider).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		if tp != nil {
+			c.tracerProvider = tp
+		}
+	}
+}
+
+// WithMeterProvider sets where metrics go (default: the global provider).
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		if mp != nil {
+			c.meterProvider = mp
+		}
+	}
+}
+
+// Instrumentation holds the tracer and instruments shared by every client
+// it is attached to.
+type Instrumentation struct {
+	tracer trace.Tracer
+
+	requestDuration metric.Float64Histogram
+	rateLimitHits   metric.Int64Counter
+	waitDuration    metric.Float64Histogram
+	eventLag        metric.Float64Histogram
+	eventDuration   metric.Float64Histogram
+}
+
+// New creates the tracer and instruments.
+func New(opts ...Option) (*Instrumentation, error) {
+	cfg := config{
+		tracerProvider: otelapi.GetTracerProvider(),
+		meterProvider:  otelapi.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(version.Version()))
+	inst := &Instrumentation{
+		tracer: cfg.tracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(version.Version())),
+	}
+
+	var err error
+	if inst.requestDuration, err = meter.Float64Histogram("discord.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Discord REST and webhook HTTP requests."),
+	); err != nil {
+		return nil, err
+	}
+	if inst.rateLimitHits, err = meter.Int64Counter("discord.ratelimit.hits",
+		metric.WithDescription("429 Too Many Requests responses from Discord."),
+	); err != nil {
+		return nil, err
+	}
+	if inst.waitDuration, err = meter.Float64Histogram("discord.ratelimit.wait.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time requests spent waiting on rate limits."),
+	); err != nil {
+		return nil, err
+	}
+	if inst.eventLag, err = meter.Float64Histogram("discord.gateway.event.lag",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time from receiving a gateway event to running its handlers."),
+	); err != nil {
+		return nil, err
+	}
+	if inst.eventDuration, err = meter.Float64Histogram("discord.gateway.event.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time spent in a gateway event's handlers."),
+	); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+// ClientOptions instruments a REST client's requests and rate limit waits.
+func (i *Instrumentation) ClientOptions() []client.Option {
+	return []client.Option{
+		client.WithMiddleware(i.ClientMiddleware()),
+		client.WithRateLimitObserver(i.RateLimitObserver()),
+	}
+}
+
+// WebhookOptions instruments a webhook client's requests and rate limit
+// waits. It replaces the client's HTTP client, so combine it with
+// webhook.WithHTTPClient(&http.Client{Transport: inst.Transport(base)})
+// instead when you need a custom transport.
+func (i *Instrumentation) WebhookOptions() []webhook.Option {
+	return []webhook.Option{
+		webhook.WithHTTPClient(&http.Client{Transport: i.Transport(nil)}),
+		webhook.WithRateLimitObserver(i.RateLimitObserver()),
+	}
+}
+
+// DispatcherOptions instruments gateway event handling.
+func (i *Instrumentation) DispatcherOptions() []gateway.DispatcherOption {
+	return []gateway.DispatcherOption{gateway.WithDispatchMiddleware(i.DispatchMiddleware())}
+}
+
+// ClientMiddleware traces and times each REST request attempt.
+func (i *Instrumentation) ClientMiddleware() client.Middleware {
+	return func(next client.RequestHandler) client.RequestHandler {
+		return func(req *client.Request) (*http.Response, error) {
+			ctx, finish := i.startRequest(req.Context(), "rest", req.Request)
+			req.WithContext(ctx)
+			resp, err := next(req)
+			finish(resp, err)
+			return resp, err
+		}
+	}
+}
+
+// Transport traces and times each request made through base, for clients
+// that take an http.Client such as the webhook client. A nil base means
+// http.DefaultTransport.
+func (i *Instrumentation) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		ctx, finish := i.startRequest(req.Context(), "webhook", req)
+		req = req.WithContext(ctx)
+		resp, err := base.RoundTrip(req)
+		finish(resp, err)
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// startRequest opens a client span for req and returns a func that ends it
+// and records the request duration.
+func (i *Instrumentation) startRequest(ctx context.Context, clientName string, req *http.Request) (context.Context, func(*http.Response, error)) {
+	route := RouteTemplate(req.URL.Path)
+	attrs := []attribute.KeyValue{
+		ClientKey.String(clientName),
+		RouteKey.String(route),
+		MethodKey.String(req.Method),
+	}
+	ctx, span := i.tracer.Start(ctx, req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	start := time.Now()
+
+	return ctx, func(resp *http.Response, err error) {
+		if resp != nil {
+			attrs = append(attrs, StatusKey.Int(resp.StatusCode))
+			span.SetAttributes(StatusKey.Int(resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode))
+			}
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		i.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+		span.End()
+	}
+}
+
+// RateLimitObserver records rate limit waits as spans and metrics and
+// counts 429 responses.
+func (i *Instrumentation) RateLimitObserver() ratelimit.Observer {
+	return &observer{inst: i}
+}
+
+type observer struct {
+	ratelimit.NopObserver
+	inst *Instrumentation
+}
+
+// OnWait implements ratelimit.Observer.
+func (o *observer) OnWait(route string, wait time.Duration, reason ratelimit.WaitReason) {
+	o.OnWaitContext(context.Background(), route, wait, reason)
+}
+
+// OnWaitContext implements ratelimit.ContextObserver. The span covers the
+// wait that just ended.
+func (o *observer) OnWaitContext(ctx context.Context, route string, wait time.Duration, reason ratelimit.WaitReason) {
+	attrs := append(routeAttrs(route), WaitReasonKey.String(string(reason)))
+	end := time.Now()
+	_, span := o.inst.tracer.Start(ctx, "discord.ratelimit.wait",
+		trace.WithTimestamp(end.Add(-wait)),
+		trace.WithAttributes(attrs...),
+	)
+	span.End(trace.WithTimestamp(end))
+	o.inst.waitDuration.Record(ctx, wait.Seconds(), metric.WithAttributes(attrs...))
+}
+
+// On429 implements ratelimit.Observer.
+func (o *observer) On429(route string, retryAfter time.Duration, global bool) {
+	attrs := append(routeAttrs(route), GlobalKey.Bool(global))
+	o.inst.rateLimitHits.Add(context.Background(), 1, metric.WithAttributes(attrs...))
+}
+
+// DispatchMiddleware traces and times each gateway event's handlers.
+func (i *Instrumentation) DispatchMiddleware() gateway.DispatchMiddleware {
+	return func(next gateway.EventHandler) gateway.EventHandler {
+		return func(ctx context.Context, event gateway.Event) error {
+			attrs := metric.WithAttributes(EventKey.String(event.Type()))
+			start := time.Now()
+			if received, ok := gateway.EventReceivedAt(ctx); ok {
+				i.eventLag.Record(ctx, start.Sub(received).Seconds(), attrs)
+			}
+
+			ctx, span := i.tracer.Start(ctx, "discord.gateway.event "+event.Type(),
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(EventKey.String(event.Type())),
+			)
+			err := next(ctx, event)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+			i.eventDuration.Record(ctx, time.Since(start).Seconds(), attrs)
+			return err
+		}
+	}
+}
+
+// routeAttrs converts a rate limit route key ("POST:/channels/1/messages")
+// into method and route template attributes.
+func routeAttrs(route string) []attribute.KeyValue {
+	method, path, ok := strings.Cut(route, ":")
+	if !ok {
+		return []attribute.KeyValue{RouteKey.String(RouteTemplate(route))}
+	}
+	return []attribute.KeyValue{MethodKey.String(method), RouteKey.String(RouteTemplate(path))}
+}
+
+// RouteTemplate reduces a request path to a low-cardinality template safe
+// to export: the /api and /api/vN prefixes are dropped, IDs become ":id",
+// emoji ":emoji", and webhook and interaction tokens ":token".
+//
+//	/api/v10/channels/123/messages/456 -> /channels/:id/messages/:id
+//	/webhooks/1/abc/messages/@original -> /webhooks/:id/:token/messages/@original
+func RouteTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && segments[0] == "api" {
+		segments = segments[1:]
+		if len(segments) > 0 && len(segments[0]) > 1 && segments[0][0] == 'v' && isDigits(segments[0][1:]) {
+			segments = segments[1:]
+		}
+	}
+	for idx, seg := range segments {
+		switch {
+		case idx > 0 && segments[idx-1] == "reactions":
+			segments[idx] = ":emoji"
+		case idx > 1 && (segments[idx-2] == "webhooks" || segments[idx-2] == "interactions"):
+			segments[idx] = ":token"
+		case isDigits(seg):
+			segments[idx] = ":id"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
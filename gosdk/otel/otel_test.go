@@ -0,0 +1,159 @@
+package otel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/mtreilly/godiscord/gosdk/discord/client"
+	"github.com/mtreilly/godiscord/gosdk/discord/gateway"
+	"github.com/mtreilly/godiscord/gosdk/discord/types"
+	"github.com/mtreilly/godiscord/gosdk/discord/webhook"
+	"github.com/mtreilly/godiscord/gosdk/ratelimit"
+)
+
+func newTestInstrumentation(t *testing.T) (*Instrumentation, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	inst, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return inst, spans, reader
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	out := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			out[m.Name] = m
+		}
+	}
+	return out
+}
+
+func TestRouteTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/api/v10/channels/123/messages/456":           "/channels/:id/messages/:id",
+		"/api/channels/123/messages/456/reactions/%F0": "/channels/:id/messages/:id/reactions/:emoji",
+		"/api/webhooks/1/s3cr3t":                       "/webhooks/:id/:token",
+		"/webhooks/1/s3cr3t/messages/@original":        "/webhooks/:id/:token/messages/@original",
+		"/interactions/9/tok/callback":                 "/interactions/:id/:token/callback",
+		"/users/@me":                                   "/users/@me",
+	}
+	for in, want := range tests {
+		if got := RouteTemplate(in); got != want {
+			t.Errorf("RouteTemplate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestClientOptionsRecordRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(types.Message{ID: "42"})
+	}))
+	defer server.Close()
+
+	inst, spans, reader := newTestInstrumentation(t)
+	rest, err := client.New("token", append(inst.ClientOptions(), client.WithBaseURL(server.URL))...)
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	if _, err := rest.Messages().GetMessage(context.Background(), "123", "456"); err != nil {
+		t.Fatalf("GetMessage() error = %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 || ended[0].Name() != "GET /channels/:id/messages/:id" {
+		t.Fatalf("spans = %v", ended)
+	}
+	m, ok := collect(t, reader)["discord.request.duration"]
+	if !ok {
+		t.Fatal("missing discord.request.duration")
+	}
+	point := m.Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if status, _ := point.Attributes.Value(StatusKey); status.AsInt64() != 200 {
+		t.Fatalf("status attribute = %v", status)
+	}
+}
+
+func TestWebhookOptionsHideToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	inst, spans, _ := newTestInstrumentation(t)
+	hook, err := webhook.NewClient(server.URL+"/api/webhooks/1/s3cr3t", inst.WebhookOptions()...)
+	if err != nil {
+		t.Fatalf("webhook.NewClient() error = %v", err)
+	}
+	if err := hook.SendSimple(context.Background(), "hi"); err != nil {
+		t.Fatalf("SendSimple() error = %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 || ended[0].Name() != "POST /webhooks/:id/:token" {
+		t.Fatalf("spans = %v", ended)
+	}
+}
+
+func TestRateLimitObserver(t *testing.T) {
+	inst, spans, reader := newTestInstrumentation(t)
+	observer := inst.RateLimitObserver()
+
+	ratelimit.ObserveWait(context.Background(), observer, "POST:/channels/1/messages", 2*time.Second, ratelimit.WaitReactive)
+	observer.On429("POST:/channels/1/messages", time.Second, false)
+
+	ended := spans.Ended()
+	if len(ended) != 1 || ended[0].EndTime().Sub(ended[0].StartTime()) != 2*time.Second {
+		t.Fatalf("wait spans = %v", ended)
+	}
+	metrics := collect(t, reader)
+	hits := metrics["discord.ratelimit.hits"].Data.(metricdata.Sum[int64]).DataPoints
+	if len(hits) != 1 || hits[0].Value != 1 {
+		t.Fatalf("429 count = %+v", hits)
+	}
+	if route, _ := hits[0].Attributes.Value(RouteKey); route.AsString() != "/channels/:id/messages" {
+		t.Fatalf("route attribute = %v", route)
+	}
+	if _, ok := metrics["discord.ratelimit.wait.duration"]; !ok {
+		t.Fatal("missing discord.ratelimit.wait.duration")
+	}
+}
+
+func TestDispatcherOptions(t *testing.T) {
+	inst, spans, reader := newTestInstrumentation(t)
+	dispatcher := gateway.NewDispatcher(inst.DispatcherOptions()...)
+	dispatcher.On(gateway.EventReady, func(ctx context.Context, event gateway.Event) error { return nil })
+
+	if err := dispatcher.Dispatch(context.Background(), &gateway.ReadyEvent{}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 || ended[0].Name() != "discord.gateway.event READY" {
+		t.Fatalf("spans = %v", ended)
+	}
+	lag := collect(t, reader)["discord.gateway.event.lag"].Data.(metricdata.Histogram[float64]).DataPoints
+	if len(lag) != 1 || !lag[0].Attributes.HasValue(EventKey) {
+		t.Fatalf("lag points = %+v", lag)
+	}
+}
@@ -0,0 +1,160 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// WaitReason explains why a request was delayed
+type WaitReason string
+
+const (
+	// WaitProactive is a strategy-driven wait before a bucket is exhausted
+	WaitProactive WaitReason = "proactive"
+	// WaitReactive is a wait on an exhausted bucket or the global limit
+	WaitReactive WaitReason = "reactive"
+	// WaitRouteBudget is a client-side per-route budget wait (RouteStrategy)
+	WaitRouteBudget WaitReason = "route_budget"
+)
+
+// Observer receives rate limit events from clients. Implementations must be
+// safe for concurrent use and should return quickly; they run inline with
+// requests. Use it to export wait times and 429 counts to a metrics system.
+type Observer interface {
+	// OnWait is called after a request was delayed by rate limiting
+	OnWait(route string, wait time.Duration, reason WaitReason)
+
+	// On429 is called when Discord responds with 429 Too Many Requests
+	On429(route string, retryAfter time.Duration, global bool)
+
+	// OnBucketUpdate is called after response headers update a route's bucket.
+	// bucket is nil when the response carried no rate limit headers.
+	OnBucketUpdate(route string, bucket *Bucket)
+}
+
+// ContextObserver is an Observer that also wants the context of the
+// request that waited, for example to record the wait as a span under the
+// caller's trace. Clients call OnWaitContext instead of OnWait when the
+// observer implements it.
+type ContextObserver interface {
+	Observer
+	OnWaitContext(ctx context.Context, route string, wait time.Duration, reason WaitReason)
+}
+
+// ObserveWait reports a wait to o, passing ctx along when o is a
+// ContextObserver.
+func ObserveWait(ctx context.Context, o Observer, route string, wait time.Duration, reason WaitReason) {
+	if co, ok := o.(ContextObserver); ok {
+		co.OnWaitContext(ctx, route, wait, reason)
+		return
+	}
+	o.OnWait(route, wait, reason)
+}
+
+// ObserverFuncs adapts plain functions to the Observer interface; nil fields are skipped
+type ObserverFuncs struct {
+	Wait         func(route string, wait time.Duration, reason WaitReason)
+	RateLimited  func(route string, retryAfter time.Duration, global bool)
+	BucketUpdate func(route string, bucket *Bucket)
+}
+
+// OnWait implements Observer
+func (o ObserverFuncs) OnWait(route string, wait time.Duration, reason WaitReason) {
+	if o.Wait != nil {
+		o.Wait(route, wait, reason)
+	}
+}
+
+// On429 implements Observer
+func (o ObserverFuncs) On429(route string, retryAfter time.Duration, global bool) {
+	if o.RateLimited != nil {
+		o.RateLimited(route, retryAfter, global)
+	}
+}
+
+// OnBucketUpdate implements Observer
+func (o ObserverFuncs) OnBucketUpdate(route string, bucket *Bucket) {
+	if o.BucketUpdate != nil {
+		o.BucketUpdate(route, bucket)
+	}
+}
+
+// NopObserver ignores every event
+type NopObserver struct{}
+
+// OnWait implements Observer
+func (NopObserver) OnWait(string, time.Duration, WaitReason) {}
+
+// On429 implements Observer
+func (NopObserver) On429(string, time.Duration, bool) {}
+
+// OnBucketUpdate implements Observer
+func (NopObserver) OnBucketUpdate(string, *Bucket) {}
+
+// IsGlobalRateLimit reports whether 429 response headers describe the global limit
+func IsGlobalRateLimit(headers http.Header) bool {
+	return headers.Get("X-RateLimit-Global") == "true" || headers.Get("X-RateLimit-Scope") == "global"
+}
+
+// BucketStats is a point-in-time view of a tracked bucket
+type BucketStats struct {
+	Key       string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	Global    bool
+	// Routes lists the routes currently mapped to this bucket
+	Routes []string
+}
+
+// TrackerStats is a snapshot of every live bucket held by a tracker
+type TrackerStats struct {
+	Buckets []BucketStats
+	// Global is set while a global rate limit is in effect
+	Global *BucketStats
+}
+
+// Stats returns a snapshot of every live bucket, sorted by key
+func (t *MemoryTracker) Stats() TrackerStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	now := time.Now()
+	routes := make(map[string][]string, len(t.buckets))
+	for route, key := range t.routeToBucket {
+		routes[key] = append(routes[key], route)
+	}
+
+	var stats TrackerStats
+	for key, bucket := range t.buckets {
+		if now.After(bucket.Reset) {
+			continue
+		}
+		mapped := routes[key]
+		sort.Strings(mapped)
+		stats.Buckets = append(stats.Buckets, BucketStats{
+			Key:       key,
+			Limit:     bucket.Limit,
+			Remaining: bucket.Remaining,
+			Reset:     bucket.Reset,
+			Global:    bucket.Global,
+			Routes:    mapped,
+		})
+	}
+	sort.Slice(stats.Buckets, func(i, j int) bool {
+		return stats.Buckets[i].Key < stats.Buckets[j].Key
+	})
+
+	if t.global != nil && now.Before(t.global.Reset) {
+		stats.Global = &BucketStats{
+			Key:       t.global.Key,
+			Limit:     t.global.Limit,
+			Remaining: t.global.Remaining,
+			Reset:     t.global.Reset,
+			Global:    true,
+		}
+	}
+	return stats
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMemoryTracker_Stats(t *testing.T) {
+	tracker := NewMemoryTracker()
+
+	headers := make(http.Header)
+	headers.Set("X-RateLimit-Bucket", "shared")
+	headers.Set("X-RateLimit-Limit", "5")
+	headers.Set("X-RateLimit-Remaining", "2")
+	headers.Set("X-RateLimit-Reset-After", "30")
+	tracker.Update("POST:/channels/1/messages", headers)
+	tracker.Update("POST:/channels/2/messages", headers)
+
+	global := make(http.Header)
+	global.Set("X-RateLimit-Global", "true")
+	global.Set("X-RateLimit-Reset-After", "1")
+	tracker.Update("GET:/gateway", global)
+
+	stats := tracker.Stats()
+	if len(stats.Buckets) != 1 {
+		t.Fatalf("expected 1 bucket, got %d", len(stats.Buckets))
+	}
+	b := stats.Buckets[0]
+	if b.Key != "shared" || b.Remaining != 2 || b.Limit != 5 {
+		t.Fatalf("unexpected bucket stats %+v", b)
+	}
+	if len(b.Routes) != 2 || b.Routes[0] != "POST:/channels/1/messages" {
+		t.Fatalf("expected both routes mapped, got %v", b.Routes)
+	}
+	if stats.Global == nil || !stats.Global.Reset.After(time.Now()) {
+		t.Fatalf("expected active global limit, got %+v", stats.Global)
+	}
+}
+
+func TestObserverFuncsNilSafe(t *testing.T) {
+	var o Observer = ObserverFuncs{}
+	o.OnWait("r", time.Second, WaitReactive)
+	o.On429("r", time.Second, false)
+	o.OnBucketUpdate("r", nil)
+
+	called := false
+	o = ObserverFuncs{Wait: func(route string, wait time.Duration, reason WaitReason) {
+		called = reason == WaitProactive
+	}}
+	o.OnWait("r", time.Second, WaitProactive)
+	if !called {
+		t.Fatalf("expected Wait callback")
+	}
+}
+
+type contextObserver struct {
+	NopObserver
+	ctx context.Context
+}
+
+func (o *contextObserver) OnWaitContext(ctx context.Context, route string, wait time.Duration, reason WaitReason) {
+	o.ctx = ctx
+}
+
+func TestObserveWait(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "trace")
+
+	co := &contextObserver{}
+	ObserveWait(ctx, co, "GET:/x", time.Second, WaitReactive)
+	if co.ctx == nil || co.ctx.Value(key{}) != "trace" {
+		t.Fatal("ContextObserver should receive the request context")
+	}
+
+	var waited time.Duration
+	ObserveWait(ctx, ObserverFuncs{Wait: func(_ string, d time.Duration, _ WaitReason) { waited = d }}, "GET:/x", time.Second, WaitReactive)
+	if waited != time.Second {
+		t.Fatalf("plain Observer OnWait got %v", waited)
+	}
+}
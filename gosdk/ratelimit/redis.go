@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/redisclient"
+)
+
+// RedisClient is the Redis client RedisStore keeps buckets in. It is shared
+// with the SDK's other Redis-backed stores, so one adapter serves them all.
+type RedisClient = redisclient.Client
+
+// RedisStore implements Store on Redis so several processes share buckets
+type RedisStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisStore creates a Redis-backed store. Keys are namespaced by prefix
+// (default "godiscord:ratelimit:"); use one prefix per bot token.
+func NewRedisStore(client RedisClient, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "godiscord:ratelimit:"
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// NewRedisTracker is shorthand for NewStoreTracker(NewRedisStore(client, prefix), opts...)
+func NewRedisTracker(client RedisClient, prefix string, opts ...StoreTrackerOption) *StoreTracker {
+	return NewStoreTracker(NewRedisStore(client, prefix), opts...)
+}
+
+type redisBucket struct {
+	Key    string `json:"key"`
+	Limit  int    `json:"limit"`
+	Reset  int64  `json:"reset"`
+	Global bool   `json:"global"`
+}
+
+func (s *RedisStore) bucketKey(key string) string    { return s.prefix + "bucket:" + key }
+func (s *RedisStore) remainingKey(key string) string { return s.prefix + "remaining:" + key }
+func (s *RedisStore) aliasKey(route string) string   { return s.prefix + "alias:" + route }
+
+// LoadBucket returns the bucket stored under key, or nil when absent
+func (s *RedisStore) LoadBucket(ctx context.Context, key string) (*Bucket, error) {
+	raw, found, err := s.client.Get(ctx, s.bucketKey(key))
+	if err != nil || !found {
+		return nil, err
+	}
+	var stored redisBucket
+	if err := json.Unmarshal([]byte(raw), &stored); err != nil {
+		return nil, err
+	}
+
+	bucket := &Bucket{
+		Key:    stored.Key,
+		Limit:  stored.Limit,
+		Reset:  time.Unix(0, stored.Reset),
+		Global: stored.Global,
+	}
+	rem, found, err := s.client.Get(ctx, s.remainingKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		bucket.Remaining, _ = strconv.Atoi(rem)
+	}
+	return bucket, nil
+}
+
+// SaveBucket stores a bucket until its reset time
+func (s *RedisStore) SaveBucket(ctx context.Context, key string, bucket *Bucket) error {
+	raw, err := json.Marshal(redisBucket{
+		Key:    bucket.Key,
+		Limit:  bucket.Limit,
+		Reset:  bucket.Reset.UnixNano(),
+		Global: bucket.Global,
+	})
+	if err != nil {
+		return err
+	}
+	ttl := storeTTL(bucket)
+	if err := s.client.Set(ctx, s.bucketKey(key), string(raw), ttl); err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.remainingKey(key), strconv.Itoa(bucket.Remaining), ttl)
+}
+
+// Reserve atomically consumes one request from the bucket
+func (s *RedisStore) Reserve(ctx context.Context, key string) (int, error) {
+	remaining, err := s.client.Decr(ctx, s.remainingKey(key))
+	if err != nil {
+		return 0, err
+	}
+	return int(remaining), nil
+}
+
+// LoadAlias resolves a route to its Discord bucket key
+func (s *RedisStore) LoadAlias(ctx context.Context, route string) (string, error) {
+	key, _, err := s.client.Get(ctx, s.aliasKey(route))
+	return key, err
+}
+
+// SaveAlias maps a route to a Discord bucket key
+func (s *RedisStore) SaveAlias(ctx context.Context, route, key string) error {
+	return s.client.Set(ctx, s.aliasKey(route), key, defaultAliasTTL)
+}
+
+// Clear removes every key under the store prefix
+func (s *RedisStore) Clear(ctx context.Context) error {
+	keys, err := s.client.Scan(ctx, s.prefix+"*")
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+	return s.client.Del(ctx, keys...)
+}
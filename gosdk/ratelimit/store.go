@@ -0,0 +1,201 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	globalBucketKey = "global"
+	defaultAliasTTL = time.Hour
+	defaultStoreTTL = time.Minute
+)
+
+// Store persists bucket state outside the process so several clients sharing
+// one bot token coordinate their limits.
+type Store interface {
+	// LoadBucket returns the bucket stored under key, or nil when absent.
+	LoadBucket(ctx context.Context, key string) (*Bucket, error)
+
+	// SaveBucket stores a bucket until its reset time.
+	SaveBucket(ctx context.Context, key string, bucket *Bucket) error
+
+	// Reserve atomically consumes one request from the bucket and returns
+	// the remaining count after the decrement (negative when exhausted).
+	Reserve(ctx context.Context, key string) (int, error)
+
+	// LoadAlias resolves a route to its Discord bucket key ("" when unknown).
+	LoadAlias(ctx context.Context, route string) (string, error)
+
+	// SaveAlias maps a route to a Discord bucket key.
+	SaveAlias(ctx context.Context, route, key string) error
+
+	// Clear removes all stored rate limit information.
+	Clear(ctx context.Context) error
+}
+
+// StoreTrackerOption configures a StoreTracker
+type StoreTrackerOption func(*StoreTracker)
+
+// WithStoreTimeout bounds each store round trip (default 2s)
+func WithStoreTimeout(timeout time.Duration) StoreTrackerOption {
+	return func(t *StoreTracker) {
+		if timeout > 0 {
+			t.timeout = timeout
+		}
+	}
+}
+
+// WithStoreErrorHandler receives store failures. The tracker fails open so an
+// unavailable store never blocks requests; Discord's 429s remain the backstop.
+func WithStoreErrorHandler(fn func(op string, err error)) StoreTrackerOption {
+	return func(t *StoreTracker) {
+		if fn != nil {
+			t.onError = fn
+		}
+	}
+}
+
+// StoreTracker implements Tracker on top of a shared Store
+type StoreTracker struct {
+	store   Store
+	timeout time.Duration
+	onError func(op string, err error)
+}
+
+// NewStoreTracker creates a tracker backed by the given store
+func NewStoreTracker(store Store, opts ...StoreTrackerOption) *StoreTracker {
+	t := &StoreTracker{
+		store:   store,
+		timeout: 2 * time.Second,
+		onError: func(string, error) {},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Wait blocks until the shared rate limit allows the request. Each call
+// reserves one request from the bucket so concurrent processes do not all
+// spend the same remaining capacity.
+func (t *StoreTracker) Wait(ctx context.Context, route string) error {
+	if global := t.load(ctx, globalBucketKey); global != nil && time.Now().Before(global.Reset) {
+		if err := sleepUntil(ctx, global.Reset); err != nil {
+			return err
+		}
+	}
+
+	key := t.resolve(ctx, route)
+	bucket := t.load(ctx, key)
+	if bucket == nil || !time.Now().Before(bucket.Reset) {
+		return nil
+	}
+
+	storeCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	remaining, err := t.store.Reserve(storeCtx, key)
+	cancel()
+	if err != nil {
+		t.onError("reserve", err)
+		return nil
+	}
+	if remaining >= 0 {
+		return nil
+	}
+	return sleepUntil(ctx, bucket.Reset)
+}
+
+// Update stores the rate limit information from response headers
+func (t *StoreTracker) Update(route string, headers http.Header) {
+	bucket := bucketFromHeaders(headers)
+	if bucket.Reset.IsZero() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+
+	if bucket.Global {
+		if err := t.store.SaveBucket(ctx, globalBucketKey, bucket); err != nil {
+			t.onError("save", err)
+		}
+		return
+	}
+
+	key := bucket.Key
+	if key == "" {
+		key = route
+	} else if err := t.store.SaveAlias(ctx, route, key); err != nil {
+		t.onError("alias", err)
+	}
+	if err := t.store.SaveBucket(ctx, key, bucket); err != nil {
+		t.onError("save", err)
+	}
+}
+
+// GetBucket returns the shared bucket for a route
+func (t *StoreTracker) GetBucket(route string) *Bucket {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+	return t.load(ctx, t.resolve(ctx, route))
+}
+
+// Clear removes all shared rate limit information
+func (t *StoreTracker) Clear() {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+	if err := t.store.Clear(ctx); err != nil {
+		t.onError("clear", err)
+	}
+}
+
+func (t *StoreTracker) resolve(ctx context.Context, route string) string {
+	storeCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	key, err := t.store.LoadAlias(storeCtx, route)
+	if err != nil {
+		t.onError("alias", err)
+	}
+	if key == "" {
+		return route
+	}
+	return key
+}
+
+func (t *StoreTracker) load(ctx context.Context, key string) *Bucket {
+	storeCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	bucket, err := t.store.LoadBucket(storeCtx, key)
+	if err != nil {
+		t.onError("load", err)
+		return nil
+	}
+	return bucket
+}
+
+func sleepUntil(ctx context.Context, deadline time.Time) error {
+	wait := time.Until(deadline)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// storeTTL returns how long a bucket should live in a shared store
+func storeTTL(bucket *Bucket) time.Duration {
+	if bucket == nil || bucket.Reset.IsZero() {
+		return defaultStoreTTL
+	}
+	if ttl := time.Until(bucket.Reset); ttl > 0 {
+		return ttl
+	}
+	return time.Millisecond
+}
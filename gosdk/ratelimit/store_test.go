@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mtreilly/godiscord/gosdk/redisclient"
+)
+
+// failingRedis is an in-memory RedisClient whose reads can be made to fail.
+type failingRedis struct {
+	*redisclient.Memory
+	failGet atomic.Bool
+}
+
+func (f *failingRedis) Get(ctx context.Context, key string) (string, bool, error) {
+	if f.failGet.Load() {
+		return "", false, errors.New("redis down")
+	}
+	return f.Memory.Get(ctx, key)
+}
+
+func rateLimitHeaders(bucket string, limit, remaining int, resetAfter time.Duration) http.Header {
+	headers := make(http.Header)
+	headers.Set("X-RateLimit-Bucket", bucket)
+	headers.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	headers.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	headers.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter.Seconds(), 'f', 3, 64))
+	return headers
+}
+
+func TestStoreTracker_SharedBucket(t *testing.T) {
+	redis := redisclient.NewMemory()
+	a := NewRedisTracker(redis, "test:")
+	b := NewRedisTracker(redis, "test:")
+
+	route := "POST:/channels/1/messages"
+	a.Update(route, rateLimitHeaders("abc", 5, 2, 200*time.Millisecond))
+
+	bucket := b.GetBucket(route)
+	if bucket == nil || bucket.Key != "abc" || bucket.Remaining != 2 {
+		t.Fatalf("expected shared bucket, got %+v", bucket)
+	}
+
+	ctx := context.Background()
+	start := time.Now()
+	if err := a.Wait(ctx, route); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if err := b.Wait(ctx, route); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if time.Since(start) > 50*time.Millisecond {
+		t.Fatalf("expected first two requests to proceed immediately")
+	}
+
+	// Capacity is now exhausted across both trackers.
+	if err := b.Wait(ctx, route); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected third request to wait for reset, waited %v", elapsed)
+	}
+}
+
+func TestStoreTracker_GlobalLimit(t *testing.T) {
+	redis := redisclient.NewMemory()
+	a := NewRedisTracker(redis, "")
+	b := NewRedisTracker(redis, "")
+
+	headers := rateLimitHeaders("", 0, 0, 100*time.Millisecond)
+	headers.Set("X-RateLimit-Global", "true")
+	a.Update("GET:/anything", headers)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx, "GET:/other"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected global wait to exceed deadline, got %v", err)
+	}
+}
+
+func TestStoreTracker_FailsOpenAndClears(t *testing.T) {
+	redis := &failingRedis{Memory: redisclient.NewMemory()}
+	var failures []string
+	tracker := NewRedisTracker(redis, "x:", WithStoreErrorHandler(func(op string, err error) {
+		failures = append(failures, op)
+	}))
+
+	tracker.Update("GET:/r", rateLimitHeaders("k", 1, 0, time.Minute))
+	tracker.Clear()
+	if tracker.GetBucket("GET:/r") != nil {
+		t.Fatalf("expected buckets cleared")
+	}
+
+	tracker.Update("GET:/r", rateLimitHeaders("k", 1, 0, time.Minute))
+	redis.failGet.Store(true)
+	if err := tracker.Wait(context.Background(), "GET:/r"); err != nil {
+		t.Fatalf("expected fail-open, got %v", err)
+	}
+	if len(failures) == 0 {
+		t.Fatalf("expected store errors to be reported")
+	}
+}
@@ -0,0 +1,217 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleSweepInterval is how often Reserve drops buckets that have refilled
+// completely, which behave exactly like routes never seen.
+const idleSweepInterval = time.Minute
+
+// RouteStrategy is implemented by strategies that throttle requests per route
+// before any response headers are known. Clients call Reserve once per request
+// and wait for the returned duration, or call Cancel if they give up first.
+type RouteStrategy interface {
+	// Reserve consumes one request from the route's budget and returns how
+	// long the caller must wait before sending it.
+	Reserve(route string) time.Duration
+	// Cancel returns a request reserved for route that will not be sent.
+	Cancel(route string)
+}
+
+// WaitForRouteBudget reserves one request for route when strategy is a
+// RouteStrategy and waits out the returned delay, reporting it to o. If ctx
+// ends first the reservation is cancelled, so later requests are not held
+// back for it. It returns how long it waited.
+func WaitForRouteBudget(ctx context.Context, strategy Strategy, route string, o Observer) (time.Duration, error) {
+	rs, ok := strategy.(RouteStrategy)
+	if !ok {
+		return 0, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	wait := rs.Reserve(route)
+	if wait <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		rs.Cancel(route)
+		return 0, ctx.Err()
+	case <-timer.C:
+		ObserveWait(ctx, o, route, wait, WaitRouteBudget)
+		return wait, nil
+	}
+}
+
+// TokenBucketOption configures a TokenBucketStrategy
+type TokenBucketOption func(*TokenBucketStrategy)
+
+// WithRouteLimit overrides the rate (requests per second) and burst for a route
+func WithRouteLimit(route string, rate float64, burst int) TokenBucketOption {
+	return func(s *TokenBucketStrategy) {
+		if route != "" && rate > 0 && burst > 0 {
+			s.overrides[route] = tokenBucketLimit{rate: rate, burst: burst}
+		}
+	}
+}
+
+// WithFallbackStrategy applies a header-based strategy on top of the client-side budget
+func WithFallbackStrategy(strategy Strategy) TokenBucketOption {
+	return func(s *TokenBucketStrategy) {
+		s.fallback = strategy
+	}
+}
+
+type tokenBucketLimit struct {
+	rate  float64
+	burst int
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// TokenBucketStrategy enforces a client-side requests-per-second budget per
+// route, independent of Discord's headers. Bursty jobs are smoothed out before
+// they reach the API; Discord's buckets are still honored by the tracker.
+type TokenBucketStrategy struct {
+	mu        sync.Mutex
+	limit     tokenBucketLimit
+	overrides map[string]tokenBucketLimit
+	buckets   map[string]*tokenBucket
+	fallback  Strategy
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewTokenBucketStrategy creates a token bucket strategy
+// rate: sustained requests per second per route
+// burst: number of requests that may be sent back-to-back
+func NewTokenBucketStrategy(rate float64, burst int, opts ...TokenBucketOption) *TokenBucketStrategy {
+	if rate <= 0 {
+		rate = 1
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	s := &TokenBucketStrategy{
+		limit:     tokenBucketLimit{rate: rate, burst: burst},
+		overrides: make(map[string]tokenBucketLimit),
+		buckets:   make(map[string]*tokenBucket),
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
+}
+
+// NewDefaultTokenBucketStrategy creates a token bucket strategy with sensible defaults
+// - 1 request per second sustained per route
+// - Bursts of up to 5 requests (matching Discord's common 5/5s route limit)
+func NewDefaultTokenBucketStrategy() *TokenBucketStrategy {
+	return NewTokenBucketStrategy(1, 5)
+}
+
+// Reserve consumes a token for the route and returns the required delay.
+// Reservations are committed immediately so concurrent callers are spaced out.
+func (s *TokenBucketStrategy) Reserve(route string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limit := s.limit
+	if override, ok := s.overrides[route]; ok {
+		limit = override
+	}
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= idleSweepInterval {
+		s.evictIdle(now)
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[route]
+	if !ok {
+		b = &tokenBucket{tokens: float64(limit.burst), last: now}
+		s.buckets[route] = b
+	}
+
+	// Refill based on elapsed time, capped at burst.
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * limit.rate
+		if b.tokens > float64(limit.burst) {
+			b.tokens = float64(limit.burst)
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / limit.rate * float64(time.Second))
+}
+
+// Cancel gives back a token taken by Reserve, capped at the route's burst.
+func (s *TokenBucketStrategy) Cancel(route string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[route]
+	if !ok {
+		return
+	}
+	limit := s.limit
+	if override, ok := s.overrides[route]; ok {
+		limit = override
+	}
+	b.tokens++
+	if b.tokens > float64(limit.burst) {
+		b.tokens = float64(limit.burst)
+	}
+}
+
+// evictIdle drops buckets that would be full again by now. Callers must hold s.mu.
+func (s *TokenBucketStrategy) evictIdle(now time.Time) {
+	for route, b := range s.buckets {
+		limit := s.limit
+		if override, ok := s.overrides[route]; ok {
+			limit = override
+		}
+		if b.tokens+now.Sub(b.last).Seconds()*limit.rate >= float64(limit.burst) {
+			delete(s.buckets, route)
+		}
+	}
+}
+
+// ShouldWait defers to the fallback strategy, if any
+func (s *TokenBucketStrategy) ShouldWait(bucket *Bucket) bool {
+	if s.fallback == nil {
+		return false
+	}
+	return s.fallback.ShouldWait(bucket)
+}
+
+// CalculateWait defers to the fallback strategy, if any
+func (s *TokenBucketStrategy) CalculateWait(bucket *Bucket) time.Duration {
+	if s.fallback == nil {
+		return 0
+	}
+	return s.fallback.CalculateWait(bucket)
+}
+
+// Name returns the strategy name
+func (s *TokenBucketStrategy) Name() string {
+	return "token_bucket"
+}
@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketStrategy_Burst(t *testing.T) {
+	s := NewTokenBucketStrategy(2, 3)
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if wait := s.Reserve("POST:/channels/1/messages"); wait != 0 {
+			t.Fatalf("request %d: expected no wait within burst, got %v", i, wait)
+		}
+	}
+
+	if wait := s.Reserve("POST:/channels/1/messages"); wait != 500*time.Millisecond {
+		t.Fatalf("expected 500ms wait after burst, got %v", wait)
+	}
+	if wait := s.Reserve("POST:/channels/1/messages"); wait != time.Second {
+		t.Fatalf("expected reservations to queue, got %v", wait)
+	}
+
+	// Other routes have their own budget.
+	if wait := s.Reserve("POST:/channels/2/messages"); wait != 0 {
+		t.Fatalf("expected independent route budget, got %v", wait)
+	}
+}
+
+func TestTokenBucketStrategy_Refill(t *testing.T) {
+	s := NewTokenBucketStrategy(1, 1)
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+
+	s.Reserve("r")
+	if wait := s.Reserve("r"); wait != time.Second {
+		t.Fatalf("expected 1s wait, got %v", wait)
+	}
+
+	now = now.Add(10 * time.Second)
+	if wait := s.Reserve("r"); wait != 0 {
+		t.Fatalf("expected refilled bucket, got %v", wait)
+	}
+	if wait := s.Reserve("r"); wait != time.Second {
+		t.Fatalf("expected refill capped at burst, got %v", wait)
+	}
+}
+
+func TestTokenBucketStrategy_RouteOverrideAndFallback(t *testing.T) {
+	s := NewTokenBucketStrategy(1, 1,
+		WithRouteLimit("bulk", 10, 10),
+		WithFallbackStrategy(NewReactiveStrategy()),
+	)
+	for i := 0; i < 10; i++ {
+		if wait := s.Reserve("bulk"); wait != 0 {
+			t.Fatalf("expected override burst of 10, got wait %v at %d", wait, i)
+		}
+	}
+
+	exhausted := &Bucket{Remaining: 0, Limit: 5, Reset: time.Now().Add(time.Second)}
+	if !s.ShouldWait(exhausted) {
+		t.Fatalf("expected fallback strategy to apply")
+	}
+	if NewTokenBucketStrategy(1, 1).ShouldWait(exhausted) {
+		t.Fatalf("expected no header-based wait without fallback")
+	}
+	if s.Name() != "token_bucket" {
+		t.Fatalf("unexpected name %q", s.Name())
+	}
+}
+
+func TestTokenBucketStrategy_EvictsIdleRoutes(t *testing.T) {
+	s := NewTokenBucketStrategy(1, 2)
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+
+	s.Reserve("idle")
+	now = now.Add(idleSweepInterval)
+	s.Reserve("busy")
+	if _, ok := s.buckets["idle"]; ok {
+		t.Fatal("expected refilled route to be evicted")
+	}
+	if len(s.buckets) != 1 {
+		t.Fatalf("buckets = %d, want 1", len(s.buckets))
+	}
+}
+
+func TestWaitForRouteBudgetCanceled(t *testing.T) {
+	s := NewTokenBucketStrategy(1, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := WaitForRouteBudget(ctx, s, "r", NopObserver{}); err != context.Canceled {
+		t.Fatalf("WaitForRouteBudget() error = %v, want context.Canceled", err)
+	}
+	if wait := s.Reserve("r"); wait != 0 {
+		t.Fatalf("canceled wait consumed a token, next wait %v", wait)
+	}
+}
+
+func TestWaitForRouteBudgetCancelReturnsToken(t *testing.T) {
+	s := NewTokenBucketStrategy(1, 1)
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+
+	if wait := s.Reserve("r"); wait != 0 {
+		t.Fatalf("first reservation wait = %v, want 0", wait)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := WaitForRouteBudget(ctx, s, "r", NopObserver{}); err != context.DeadlineExceeded {
+		t.Fatalf("WaitForRouteBudget() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	if wait := s.Reserve("r"); wait != time.Second {
+		t.Fatalf("next wait = %v, want 1s", wait)
+	}
+}
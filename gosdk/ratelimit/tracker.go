@@ -2,9 +2,10 @@ package ratelimit
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -102,40 +103,15 @@ func (t *MemoryTracker) Wait(ctx context.Context, route string) error {
 
 // Update updates the rate limit information from response headers
 func (t *MemoryTracker) Update(route string, headers http.Header) {
-	// Parse rate limit headers
-	limit := parseIntHeader(headers, "X-RateLimit-Limit")
-	remaining := parseIntHeader(headers, "X-RateLimit-Remaining")
-	resetAfter := parseFloatHeader(headers, "X-RateLimit-Reset-After")
-	bucketKey := headers.Get("X-RateLimit-Bucket")
-	global := headers.Get("X-RateLimit-Global") == "true"
-
-	// Calculate reset time
-	var resetTime time.Time
-	if resetAfter > 0 {
-		resetTime = time.Now().Add(time.Duration(resetAfter * float64(time.Second)))
-	} else {
-		// Fallback to Reset header (Unix timestamp)
-		resetUnix := parseFloatHeader(headers, "X-RateLimit-Reset")
-		if resetUnix > 0 {
-			resetTime = time.Unix(int64(resetUnix), 0)
-		}
-	}
+	bucket := bucketFromHeaders(headers)
 
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	bucket := &Bucket{
-		Key:       bucketKey,
-		Limit:     limit,
-		Remaining: remaining,
-		Reset:     resetTime,
-		Global:    global,
-	}
-
-	if global {
+	if bucket.Global {
 		t.global = bucket
 	} else {
-		key := bucketKey
+		key := bucket.Key
 		if key == "" {
 			key = route
 		}
@@ -218,6 +194,31 @@ func (t *MemoryTracker) getBucketByRouteLocked(route string) (*Bucket, bool) {
 
 // Helper functions
 
+// bucketFromHeaders parses Discord rate limit headers into a bucket
+func bucketFromHeaders(headers http.Header) *Bucket {
+	resetAfter := parseFloatHeader(headers, "X-RateLimit-Reset-After")
+
+	// Calculate reset time
+	var resetTime time.Time
+	if resetAfter > 0 {
+		resetTime = time.Now().Add(time.Duration(resetAfter * float64(time.Second)))
+	} else {
+		// Fallback to Reset header (Unix timestamp)
+		resetUnix := parseFloatHeader(headers, "X-RateLimit-Reset")
+		if resetUnix > 0 {
+			resetTime = time.Unix(int64(resetUnix), 0)
+		}
+	}
+
+	return &Bucket{
+		Key:       headers.Get("X-RateLimit-Bucket"),
+		Limit:     parseIntHeader(headers, "X-RateLimit-Limit"),
+		Remaining: parseIntHeader(headers, "X-RateLimit-Remaining"),
+		Reset:     resetTime,
+		Global:    headers.Get("X-RateLimit-Global") == "true",
+	}
+}
+
 func parseIntHeader(headers http.Header, key string) int {
 	value := headers.Get(key)
 	if value == "" {
@@ -246,11 +247,75 @@ func parseFloatHeader(headers http.Header, key string) float64 {
 	return floatValue
 }
 
-// RouteFromEndpoint extracts a rate limit route identifier from an endpoint
-// Discord uses major parameters (guild_id, channel_id, etc.) for route bucketing
+// RouteFromEndpoint extracts a rate limit route identifier from an endpoint.
+// Discord buckets routes by their major parameters (channel_id, guild_id, and
+// webhook_id with its token), so those are kept while every other ID collapses
+// to a placeholder:
+//
+//	/channels/123/messages/456             -> /channels/123/messages/:id
+//	/channels/123/messages/456/reactions/x -> /channels/123/messages/:id/reactions/:emoji
+//	/webhooks/1/token/messages/2           -> /webhooks/1/token/messages/:id
+//
+// Absolute URLs are reduced to their path, the /api and /api/vN prefixes and
+// the query string are dropped.
 func RouteFromEndpoint(method, endpoint string) string {
-	// This is a simplified implementation
-	// In production, you'd parse the endpoint and replace IDs with placeholders
-	// For example: /channels/123456/messages/789 -> /channels/:id/messages/:id
-	return fmt.Sprintf("%s:%s", method, endpoint)
+	path := endpoint
+	if u, err := url.Parse(endpoint); err == nil {
+		path = u.EscapedPath()
+	}
+	return method + ":" + majorRoute(path)
+}
+
+func majorRoute(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	// Drop the API prefix so routes match regardless of base URL or version.
+	if len(segments) > 0 && segments[0] == "api" {
+		segments = segments[1:]
+		if len(segments) > 0 && isAPIVersion(segments[0]) {
+			segments = segments[1:]
+		}
+	}
+
+	for i := 1; i < len(segments); i++ {
+		seg := segments[i]
+		prev := segments[i-1]
+
+		switch {
+		case i == 1 && (prev == "channels" || prev == "guilds" || prev == "webhooks"):
+			// Major parameter: keep as-is.
+		case i == 2 && segments[0] == "webhooks":
+			// Webhook token is part of the major parameter.
+		case i == 2 && segments[0] == "interactions":
+			segments[i] = ":token"
+		case prev == "reactions":
+			// Reaction routes share a bucket regardless of emoji or user.
+			segments[i] = ":emoji"
+			for j := i + 1; j < len(segments); j++ {
+				if isSnowflake(segments[j]) {
+					segments[j] = ":id"
+				}
+			}
+			return "/" + strings.Join(segments, "/")
+		case isSnowflake(seg):
+			segments[i] = ":id"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func isSnowflake(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAPIVersion(s string) bool {
+	return len(s) > 1 && s[0] == 'v' && isSnowflake(s[1:])
 }
@@ -342,6 +342,66 @@ func TestRouteFromEndpoint(t *testing.T) {
 			endpoint: "/channels/456/messages",
 			want:     "POST:/channels/456/messages",
 		},
+		{
+			name:     "message id collapses",
+			method:   "PATCH",
+			endpoint: "/channels/123/messages/789",
+			want:     "PATCH:/channels/123/messages/:id",
+		},
+		{
+			name:     "absolute url with version and query",
+			method:   "GET",
+			endpoint: "https://discord.com/api/v10/guilds/1/members?limit=1000",
+			want:     "GET:/guilds/1/members",
+		},
+		{
+			name:     "guild member",
+			method:   "PUT",
+			endpoint: "/guilds/1/members/2/roles/3",
+			want:     "PUT:/guilds/1/members/:id/roles/:id",
+		},
+		{
+			name:     "own reaction",
+			method:   "PUT",
+			endpoint: "/channels/123/messages/789/reactions/%F0%9F%8E%89/@me",
+			want:     "PUT:/channels/123/messages/:id/reactions/:emoji/@me",
+		},
+		{
+			name:     "user reaction with custom emoji",
+			method:   "DELETE",
+			endpoint: "/channels/123/messages/789/reactions/party:42/555",
+			want:     "DELETE:/channels/123/messages/:id/reactions/:emoji/:id",
+		},
+		{
+			name:     "thread from message",
+			method:   "POST",
+			endpoint: "/channels/123/messages/789/threads",
+			want:     "POST:/channels/123/messages/:id/threads",
+		},
+		{
+			name:     "thread member",
+			method:   "PUT",
+			endpoint: "/channels/123/thread-members/555",
+			want:     "PUT:/channels/123/thread-members/:id",
+		},
+		{
+			name:     "webhook token kept as major parameter",
+			method:   "POST",
+			endpoint: "https://discord.com/api/webhooks/111/abc-token?wait=true",
+			want:     "POST:/webhooks/111/abc-token",
+		},
+		{
+			name:     "webhook message",
+			method:   "PATCH",
+			endpoint: "https://discord.com/api/webhooks/111/abc-token/messages/222",
+			want:     "PATCH:/webhooks/111/abc-token/messages/:id",
+		},
+		{
+			name:     "interaction callback",
+			method:   "POST",
+			endpoint: "/interactions/999/secret/callback",
+			want:     "POST:/interactions/:id/:token/callback",
+		},
 	}
 
 	for _, tt := range tests {
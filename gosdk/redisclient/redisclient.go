@@ -0,0 +1,133 @@
+// Package redisclient defines the Redis commands the SDK's Redis-backed
+// stores use: ratelimit.RedisStore, state.RedisCache and
+// idempotency.RedisStore all take a Client, so one adapter around go-redis
+// or redigo serves every one of them.
+package redisclient
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Client is the subset of Redis commands the SDK relies on. Adapters are a
+// few lines each. Get must report a missing key with found=false rather
+// than an error, SetNX must be atomic (SET ... NX), and a ttl of 0 means the
+// key does not expire.
+type Client interface {
+	Get(ctx context.Context, key string) (value string, found bool, err error)
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+	Decr(ctx context.Context, key string) (int64, error)
+	Del(ctx context.Context, keys ...string) error
+	// Scan returns every key matching a glob pattern (SCAN ... MATCH).
+	Scan(ctx context.Context, match string) ([]string, error)
+}
+
+// Memory is an in-process Client, for tests and single-process setups that
+// want the Redis-backed stores' behaviour without a server.
+type Memory struct {
+	mu      sync.Mutex
+	data    map[string]string
+	expires map[string]time.Time
+}
+
+// NewMemory creates an empty Memory client.
+func NewMemory() *Memory {
+	return &Memory{data: make(map[string]string), expires: make(map[string]time.Time)}
+}
+
+// live reports whether key exists, dropping it if it expired.
+func (m *Memory) live(key string) bool {
+	if exp, ok := m.expires[key]; ok && time.Now().After(exp) {
+		delete(m.data, key)
+		delete(m.expires, key)
+		return false
+	}
+	_, ok := m.data[key]
+	return ok
+}
+
+func (m *Memory) set(key, value string, ttl time.Duration) {
+	m.data[key] = value
+	if ttl > 0 {
+		m.expires[key] = time.Now().Add(ttl)
+	} else {
+		delete(m.expires, key)
+	}
+}
+
+// Get implements Client.
+func (m *Memory) Get(_ context.Context, key string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.live(key) {
+		return "", false, nil
+	}
+	return m.data[key], true, nil
+}
+
+// Set implements Client.
+func (m *Memory) Set(_ context.Context, key, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(key, value, ttl)
+	return nil
+}
+
+// SetNX implements Client.
+func (m *Memory) SetNX(_ context.Context, key, value string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.live(key) {
+		return false, nil
+	}
+	m.set(key, value, ttl)
+	return true, nil
+}
+
+// Decr implements Client. Like Redis, it keeps the key's expiry and treats
+// a missing key as 0.
+func (m *Memory) Decr(_ context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int64
+	if m.live(key) {
+		var err error
+		if n, err = strconv.ParseInt(m.data[key], 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	n--
+	m.data[key] = strconv.FormatInt(n, 10)
+	return n, nil
+}
+
+// Del implements Client.
+func (m *Memory) Del(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.data, key)
+		delete(m.expires, key)
+	}
+	return nil
+}
+
+// Scan implements Client.
+func (m *Memory) Scan(_ context.Context, match string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for key := range m.data {
+		if !m.live(key) {
+			continue
+		}
+		if ok, _ := path.Match(match, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,35 @@
+package redisclient
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	if _, found, err := m.Get(ctx, "a"); found || err != nil {
+		t.Fatalf("Get(missing) found=%v err=%v", found, err)
+	}
+	if ok, _ := m.SetNX(ctx, "a", "1", 0); !ok {
+		t.Fatal("SetNX on a new key should succeed")
+	}
+	if ok, _ := m.SetNX(ctx, "a", "2", 0); ok {
+		t.Fatal("SetNX on an existing key should fail")
+	}
+	if n, err := m.Decr(ctx, "a"); n != 0 || err != nil {
+		t.Fatalf("Decr() = %d, %v", n, err)
+	}
+	m.Set(ctx, "b:1", "x", time.Millisecond)
+	m.Set(ctx, "b:2", "y", 0)
+	time.Sleep(5 * time.Millisecond)
+	if keys, _ := m.Scan(ctx, "b:*"); len(keys) != 1 || keys[0] != "b:2" {
+		t.Fatalf("Scan() = %v, want only the unexpired key", keys)
+	}
+	m.Del(ctx, "a", "b:2")
+	if keys, _ := m.Scan(ctx, "*"); len(keys) != 0 {
+		t.Fatalf("Scan() after Del = %v", keys)
+	}
+}
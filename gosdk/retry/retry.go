@@ -0,0 +1,239 @@
+// Package retry decides when failed HTTP requests are tried again. The REST
+// and webhook clients share it; pass a custom Policy with their
+// WithRetryPolicy options to change how they back off.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Attempt describes a failed try at a request.
+type Attempt struct {
+	// Number counts tries so far, starting at 1.
+	Number int
+	// Elapsed is the time since the first try started.
+	Elapsed time.Duration
+	// StatusCode is the HTTP status, or 0 when no response arrived.
+	StatusCode int
+	// RetryAfter is how long the server asked the client to wait.
+	RetryAfter time.Duration
+	// Err is the error the try failed with.
+	Err error
+}
+
+// Policy decides whether to try a request again and how long to wait first.
+type Policy interface {
+	Next(a Attempt) (time.Duration, bool)
+}
+
+// PolicyFunc adapts a function to Policy.
+type PolicyFunc func(a Attempt) (time.Duration, bool)
+
+// Next calls f.
+func (f PolicyFunc) Next(a Attempt) (time.Duration, bool) {
+	return f(a)
+}
+
+// Action says how Backoff treats a response status.
+type Action int
+
+const (
+	// ActionStop fails without retrying.
+	ActionStop Action = iota
+	// ActionBackoff retries after the exponential backoff delay.
+	ActionBackoff
+	// ActionRetryAfter retries after the server's Retry-After, falling back
+	// to the backoff delay when it sent none.
+	ActionRetryAfter
+)
+
+// Classify is Backoff's default action for a status: network errors and 5xx
+// responses back off, 429s wait for Retry-After, and everything else stops.
+func Classify(status int) Action {
+	switch {
+	case status == 0, status >= 500:
+		return ActionBackoff
+	case status == http.StatusTooManyRequests:
+		return ActionRetryAfter
+	default:
+		return ActionStop
+	}
+}
+
+// Backoff is exponential backoff with optional jitter, an attempt cap and
+// an overall time budget.
+type Backoff struct {
+	// MaxAttempts caps tries, including the first. Zero means 4.
+	MaxAttempts int
+	// Base is the first delay, doubled for each later one. Zero means 1s.
+	Base time.Duration
+	// Max caps a single backoff delay. Zero means 30s. Retry-After delays
+	// are not capped.
+	Max time.Duration
+	// Jitter removes up to this fraction (0-1) of each backoff delay at
+	// random, so clients that failed together do not retry together.
+	Jitter float64
+	// MaxElapsed gives up once the next try would start this long after
+	// the first. Zero means no limit.
+	MaxElapsed time.Duration
+	// Statuses overrides Classify for particular status codes, for example
+	// {http.StatusBadGateway: ActionStop}.
+	Statuses map[int]Action
+}
+
+// Default returns the policy the clients use unless configured otherwise:
+// four tries, starting at one second and doubling.
+func Default() *Backoff {
+	return &Backoff{MaxAttempts: 4, Base: time.Second, Max: 30 * time.Second}
+}
+
+// Next implements Policy.
+func (b *Backoff) Next(a Attempt) (time.Duration, bool) {
+	action, ok := b.Statuses[a.StatusCode]
+	if !ok {
+		action = Classify(a.StatusCode)
+	}
+	maxAttempts := b.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 4
+	}
+	if action == ActionStop || a.Number >= maxAttempts {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if action == ActionRetryAfter && a.RetryAfter > 0 {
+		delay = a.RetryAfter
+	} else {
+		delay = b.Delay(a.Number)
+	}
+	if b.MaxElapsed > 0 && a.Elapsed+delay > b.MaxElapsed {
+		return 0, false
+	}
+	return delay, true
+}
+
+// Delay returns the backoff delay after the given failed try, counting from
+// 1, ignoring Retry-After and the attempt and time limits.
+func (b *Backoff) Delay(attempt int) time.Duration {
+	base, max := b.Base, b.Max
+	if base <= 0 {
+		base = time.Second
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	if b.Jitter > 0 {
+		jitter := b.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+	return delay
+}
+
+// ParseRetryAfter reads how long a response asks the client to wait, from
+// Retry-After (seconds or an HTTP date), Discord's X-RateLimit-Reset-After,
+// or body, the retry_after field of a 429 response body, whichever is
+// longest. It returns 0 when none is present.
+func ParseRetryAfter(h http.Header, body time.Duration) time.Duration {
+	var wait time.Duration
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.ParseFloat(v, 64); err == nil {
+			wait = time.Duration(secs * float64(time.Second))
+		} else if at, err := http.ParseTime(v); err == nil {
+			wait = time.Until(at)
+		}
+	}
+	if v := h.Get("X-RateLimit-Reset-After"); v != "" {
+		if secs, err := strconv.ParseFloat(v, 64); err == nil {
+			if d := time.Duration(secs * float64(time.Second)); d > wait {
+				wait = d
+			}
+		}
+	}
+	if body > wait {
+		wait = body
+	}
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// Error is returned when a request still fails after more than one try.
+type Error struct {
+	Attempts int
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("request failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns the last attempt's error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Retrier runs one request's attempts against a Policy.
+type Retrier struct {
+	policy   Policy
+	start    time.Time
+	attempts int
+}
+
+// New starts tracking a request. A nil policy means Default().
+func New(policy Policy) *Retrier {
+	if policy == nil {
+		policy = Default()
+	}
+	return &Retrier{policy: policy, start: time.Now()}
+}
+
+// Attempts returns how many tries have failed so far.
+func (r *Retrier) Attempts() int {
+	return r.attempts
+}
+
+// Wait records a failed try and sleeps until the next one. It returns nil
+// when the caller should try again. Otherwise it returns the error to give
+// up with: a.Err itself after a single try, a.Err wrapped in *Error after
+// several, or ctx.Err() if ctx ended while waiting. Number and Elapsed are
+// filled in by Wait.
+func (r *Retrier) Wait(ctx context.Context, a Attempt) error {
+	r.attempts++
+	a.Number = r.attempts
+	a.Elapsed = time.Since(r.start)
+	delay, ok := r.policy.Next(a)
+	if !ok {
+		if r.attempts == 1 {
+			return a.Err
+		}
+		return &Error{Attempts: r.attempts, Err: a.Err}
+	}
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,113 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoffNext(t *testing.T) {
+	b := &Backoff{MaxAttempts: 4, Base: 100 * time.Millisecond, Max: 250 * time.Millisecond}
+	tests := []struct {
+		name  string
+		a     Attempt
+		delay time.Duration
+		ok    bool
+	}{
+		{"network error", Attempt{Number: 1}, 100 * time.Millisecond, true},
+		{"server error doubles", Attempt{Number: 2, StatusCode: 502}, 200 * time.Millisecond, true},
+		{"capped", Attempt{Number: 3, StatusCode: 500}, 250 * time.Millisecond, true},
+		{"attempts exhausted", Attempt{Number: 4, StatusCode: 500}, 0, false},
+		{"client error", Attempt{Number: 1, StatusCode: 400}, 0, false},
+		{"rate limit uses Retry-After", Attempt{Number: 1, StatusCode: 429, RetryAfter: 3 * time.Second}, 3 * time.Second, true},
+		{"rate limit without Retry-After", Attempt{Number: 1, StatusCode: 429}, 100 * time.Millisecond, true},
+	}
+	for _, tt := range tests {
+		delay, ok := b.Next(tt.a)
+		if delay != tt.delay || ok != tt.ok {
+			t.Errorf("%s: Next() = %v, %v; want %v, %v", tt.name, delay, ok, tt.delay, tt.ok)
+		}
+	}
+}
+
+func TestBackoffStatusOverridesAndBudget(t *testing.T) {
+	b := &Backoff{
+		Base:       time.Second,
+		MaxElapsed: 1500 * time.Millisecond,
+		Statuses:   map[int]Action{http.StatusConflict: ActionBackoff, http.StatusBadGateway: ActionStop},
+	}
+	if _, ok := b.Next(Attempt{Number: 1, StatusCode: http.StatusConflict}); !ok {
+		t.Fatalf("409 should be retried when overridden")
+	}
+	if _, ok := b.Next(Attempt{Number: 1, StatusCode: http.StatusBadGateway}); ok {
+		t.Fatalf("502 should stop when overridden")
+	}
+	if _, ok := b.Next(Attempt{Number: 1, Elapsed: time.Second, StatusCode: 500}); ok {
+		t.Fatalf("a retry past MaxElapsed should be refused")
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	b := &Backoff{Base: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		delay, _ := b.Next(Attempt{Number: 1})
+		if delay < 500*time.Millisecond || delay > time.Second {
+			t.Fatalf("jittered delay %v outside [500ms, 1s]", delay)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	h := http.Header{}
+	if ParseRetryAfter(h, 0) != 0 {
+		t.Fatalf("expected 0 without headers")
+	}
+	h.Set("Retry-After", "2")
+	if got := ParseRetryAfter(h, 0); got != 2*time.Second {
+		t.Fatalf("seconds = %v", got)
+	}
+	h.Set("X-RateLimit-Reset-After", "2.5")
+	if got := ParseRetryAfter(h, 0); got != 2500*time.Millisecond {
+		t.Fatalf("reset-after = %v", got)
+	}
+	if got := ParseRetryAfter(h, 4*time.Second); got != 4*time.Second {
+		t.Fatalf("body retry_after = %v", got)
+	}
+	h = http.Header{}
+	h.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if got := ParseRetryAfter(h, 0); got < 59*time.Minute || got > time.Hour {
+		t.Fatalf("date = %v", got)
+	}
+}
+
+func TestRetrierWait(t *testing.T) {
+	fail := errors.New("boom")
+	policy := PolicyFunc(func(a Attempt) (time.Duration, bool) {
+		return time.Millisecond, a.Number < 3
+	})
+
+	r := New(policy)
+	for i := 0; i < 2; i++ {
+		if err := r.Wait(context.Background(), Attempt{Err: fail}); err != nil {
+			t.Fatalf("Wait() #%d = %v", i+1, err)
+		}
+	}
+	err := r.Wait(context.Background(), Attempt{Err: fail})
+	var retryErr *Error
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 || !errors.Is(err, fail) {
+		t.Fatalf("Wait() = %v", err)
+	}
+
+	single := New(&Backoff{})
+	if err := single.Wait(context.Background(), Attempt{StatusCode: 404, Err: fail}); err != fail {
+		t.Fatalf("a single failed try should return its error unwrapped, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := New(nil).Wait(ctx, Attempt{Err: fail}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() on a cancelled context = %v", err)
+	}
+}
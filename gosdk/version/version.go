@@ -0,0 +1,104 @@
+// Package version reports which build of the SDK is running, for
+// User-Agent headers, diagnostics and bug reports.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// ModulePath is the SDK's Go module path.
+const ModulePath = "github.com/mtreilly/godiscord/gosdk"
+
+// repositoryURL is sent in User-Agent headers, as Discord asks bots to.
+const repositoryURL = "https://github.com/mtreilly/godiscord"
+
+// version and commit can be set at link time, taking precedence over build
+// info:
+//
+//	go build -ldflags "-X github.com/mtreilly/godiscord/gosdk/version.version=v1.2.3"
+var (
+	version string
+	commit  string
+)
+
+// Info describes the running SDK build.
+type Info struct {
+	// Version is the module version, "(devel)" for local builds.
+	Version string `json:"version" yaml:"version"`
+	// Commit is the VCS revision when the SDK is the main module.
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	// Modified reports uncommitted changes in a VCS build.
+	Modified  bool   `json:"modified,omitempty" yaml:"modified,omitempty"`
+	GoVersion string `json:"go_version" yaml:"go_version"`
+	Platform  string `json:"platform" yaml:"platform"`
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the build information, read once from the binary.
+func Get() Info {
+	once.Do(func() {
+		info = read(debug.ReadBuildInfo)
+	})
+	return info
+}
+
+// Version returns the SDK module version.
+func Version() string {
+	return Get().Version
+}
+
+// UserAgent returns the User-Agent sent with SDK requests, in the format
+// Discord documents for bots.
+func UserAgent() string {
+	return fmt.Sprintf("DiscordBot (%s, %s)", repositoryURL, Version())
+}
+
+func read(readBuildInfo func() (*debug.BuildInfo, bool)) Info {
+	out := Info{
+		Version:   "(devel)",
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if bi, ok := readBuildInfo(); ok {
+		if bi.Main.Path == ModulePath {
+			if bi.Main.Version != "" {
+				out.Version = bi.Main.Version
+			}
+			for _, s := range bi.Settings {
+				switch s.Key {
+				case "vcs.revision":
+					out.Commit = s.Value
+				case "vcs.modified":
+					out.Modified = s.Value == "true"
+				}
+			}
+		} else {
+			for _, dep := range bi.Deps {
+				if dep.Path != ModulePath {
+					continue
+				}
+				if dep.Replace != nil && dep.Replace.Version != "" {
+					dep = dep.Replace
+				}
+				if dep.Version != "" {
+					out.Version = dep.Version
+				}
+				break
+			}
+		}
+	}
+	if version != "" {
+		out.Version = version
+	}
+	if commit != "" {
+		out.Commit = commit
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package version
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestReadDependency(t *testing.T) {
+	info := read(func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/bot", Version: "v0.0.1"},
+			Deps: []*debug.Module{{Path: ModulePath, Version: "v1.4.0"}},
+		}, true
+	})
+	if info.Version != "v1.4.0" || info.Commit != "" {
+		t.Fatalf("unexpected info %+v", info)
+	}
+}
+
+func TestReadMainModule(t *testing.T) {
+	info := read(func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Path: ModulePath, Version: "(devel)"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "abc123"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	})
+	if info.Version != "(devel)" || info.Commit != "abc123" || !info.Modified {
+		t.Fatalf("unexpected info %+v", info)
+	}
+
+	info = read(func() (*debug.BuildInfo, bool) { return nil, false })
+	if info.Version != "(devel)" || info.GoVersion == "" {
+		t.Fatalf("unexpected fallback %+v", info)
+	}
+}
+
+func TestLinkerOverride(t *testing.T) {
+	version, commit = "v9.9.9", "deadbeef"
+	defer func() { version, commit = "", "" }()
+	info := read(func() (*debug.BuildInfo, bool) { return nil, false })
+	if info.Version != "v9.9.9" || info.Commit != "deadbeef" {
+		t.Fatalf("linker values not applied: %+v", info)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	ua := UserAgent()
+	if !strings.HasPrefix(ua, "DiscordBot (https://github.com/mtreilly/godiscord, ") || !strings.Contains(ua, Version()) {
+		t.Fatalf("unexpected user agent %q", ua)
+	}
+}